@@ -1,16 +1,22 @@
 package config
 
 import (
+	"database/sql"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/joho/godotenv"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
 )
 
 type Config struct {
@@ -19,6 +25,11 @@ type Config struct {
 		Port        string `env:"PORT"    envDefault:"8088"`
 		FrontendURL string `env:"FRONTEND_URL" envDefault:"http://localhost:3000"`
 		UploadDir   string `env:"UPLOAD_DIR"   envDefault:"./public/uploads"`
+		// How long in-flight requests and background workers get to finish on shutdown
+		ShutdownTimeoutSeconds int `env:"SHUTDOWN_TIMEOUT_SECONDS" envDefault:"10"`
+		// Proxies (IPs or CIDRs) whose X-Forwarded-For and X-Real-IP headers are trusted for the
+		// client IP. Empty trusts none, so the client IP is always the connection's address.
+		TrustedProxies []string `env:"TRUSTED_PROXIES"`
 	}
 	DB struct {
 		Host     string `env:"DB_HOST"     envDefault:"localhost"`
@@ -27,6 +38,12 @@ type Config struct {
 		Password string `env:"DB_PASSWORD" envDefault:"password"`
 		Name     string `env:"DB_NAME"     envDefault:"miow_db"`
 		SSLMode  string `env:"DB_SSLMODE"  envDefault:"disable"`
+		// Connection pool; 0 open connections means no limit
+		MaxOpenConns           int `env:"DB_MAX_OPEN_CONNS"            envDefault:"25"`
+		MaxIdleConns           int `env:"DB_MAX_IDLE_CONNS"            envDefault:"10"`
+		ConnMaxLifetimeMinutes int `env:"DB_CONN_MAX_LIFETIME_MINUTES" envDefault:"30"` // 0 keeps connections forever
+		// Optional read replica; when set, the public list endpoints read from it
+		ReadReplicaDSN string `env:"DB_READ_REPLICA_DSN"`
 	}
 	JWT struct {
 		AccessTokenSecret        string `env:"JWT_ACCESS_TOKEN_SECRET"  envDefault:"supersecret"`
@@ -34,11 +51,100 @@ type Config struct {
 		RefreshTokenSecret       string `env:"JWT_REFRESH_TOKEN_SECRET" envDefault:"supersecretrefresh"`
 		RefreshTokenExpiryDays   int    `env:"JWT_REFRESH_TOKEN_EXPIRY_DAYS"   envDefault:"7"`
 	}
+	Auth struct {
+		RegistrationOpen           bool `env:"REGISTRATION_OPEN"        envDefault:"true"`
+		InviteOnly                 bool `env:"REGISTRATION_INVITE_ONLY" envDefault:"false"`
+		InviteCodeExpiryHours      int  `env:"INVITE_CODE_EXPIRY_HOURS" envDefault:"168"`
+		ImpersonationExpiryMinutes int  `env:"IMPERSONATION_TOKEN_EXPIRY_MINUTES" envDefault:"10"` // 1 to MaxImpersonationExpiryMinutes
+		// bcrypt cost for new password hashes; older, cheaper hashes are upgraded on login
+		PasswordHashCost int `env:"PASSWORD_HASH_COST" envDefault:"14"`
+		// Email verification links redirect the browser instead of returning JSON when enabled
+		// (overridable per request with ?redirect=true|false)
+		EmailVerifyRedirect   bool   `env:"EMAIL_VERIFY_REDIRECT"    envDefault:"false"`
+		EmailVerifySuccessURL string `env:"EMAIL_VERIFY_SUCCESS_URL"` // Defaults to FRONTEND_URL + "/email-verified"
+		EmailVerifyFailureURL string `env:"EMAIL_VERIFY_FAILURE_URL"` // Defaults to FRONTEND_URL + "/email-verification-failed"
+	}
+	Teams struct {
+		InvitationExpiryHours  int `env:"TEAM_INVITATION_EXPIRY_HOURS"   envDefault:"168"`
+		JoinRequestExpiryHours int `env:"TEAM_JOIN_REQUEST_EXPIRY_HOURS" envDefault:"168"`
+	}
+	Tournaments struct {
+		// Check capacity against the registrations actually stored (counted under a row lock)
+		// instead of the cached current_teams counter
+		CountLiveRegistrations bool `env:"TOURNAMENT_COUNT_LIVE_REGISTRATIONS" envDefault:"true"`
+	}
+	Challenges struct {
+		// Challenges cannot be accepted once the proposed start is closer than this; 0 disables the check
+		MinAcceptLeadMinutes int `env:"CHALLENGE_MIN_ACCEPT_LEAD_MINUTES" envDefault:"60"`
+	}
+	Matches struct {
+		// Lets a player of one of the teams be assigned as a match official (referee or scorer)
+		AllowPlayerOfficials bool `env:"MATCH_ALLOW_PLAYER_OFFICIALS" envDefault:"false"`
+	}
+	// Match comment moderation. Comments containing a blocked word are flagged (hidden from
+	// everyone but the author and moderators) or rejected, depending on FilterAction.
+	Comments struct {
+		PerUserPerMinute int    `env:"COMMENT_RATE_LIMIT_PER_MINUTE" envDefault:"5"`   // 0 disables the limit
+		MaxPerMatch      int    `env:"COMMENT_MAX_PER_MATCH"         envDefault:"500"` // 0 disables the limit
+		BlockedWords     string `env:"COMMENT_BLOCKED_WORDS"`                          // Comma separated, matched as whole words
+		FilterAction     string `env:"COMMENT_FILTER_ACTION"         envDefault:"flag"`
+	}
+	// Token bucket rate limits: each client may burst up to *Burst requests, refilled at
+	// *RequestsPerMinute. Auth limits apply to login, OTP requests, registration and password
+	// reset requests; lookup limits to account availability checks and user searches, which
+	// could otherwise be used to enumerate users.
+	RateLimit struct {
+		Enabled                 bool `env:"RATE_LIMIT_ENABLED"                    envDefault:"true"`
+		RequestsPerMinute       int  `env:"RATE_LIMIT_REQUESTS_PER_MINUTE"        envDefault:"300"`
+		Burst                   int  `env:"RATE_LIMIT_BURST"                      envDefault:"60"`
+		AuthRequestsPerMinute   int  `env:"RATE_LIMIT_AUTH_REQUESTS_PER_MINUTE"   envDefault:"10"`
+		AuthBurst               int  `env:"RATE_LIMIT_AUTH_BURST"                 envDefault:"5"`
+		LookupRequestsPerMinute int  `env:"RATE_LIMIT_LOOKUP_REQUESTS_PER_MINUTE" envDefault:"20"`
+		LookupBurst             int  `env:"RATE_LIMIT_LOOKUP_BURST"               envDefault:"10"`
+	}
+	// Caching of rarely changing records (venues, teams, tournaments); disabled without REDIS_ADDR
+	Cache struct {
+		RedisAddr     string `env:"REDIS_ADDR"`
+		RedisPassword string `env:"REDIS_PASSWORD"`
+		RedisDB       int    `env:"REDIS_DB"          envDefault:"0"`
+		TTLSeconds    int    `env:"CACHE_TTL_SECONDS" envDefault:"300"`
+	}
+	// In-app notifications are always stored; these enable the extra delivery channels
+	Notifications struct {
+		EmailEnabled bool `env:"NOTIFICATIONS_EMAIL_ENABLED" envDefault:"false"`
+		PushEnabled  bool `env:"NOTIFICATIONS_PUSH_ENABLED"  envDefault:"false"`
+	}
+	// Where uploaded images (venue photos, team logos) are stored: "local" saves them under
+	// UPLOAD_DIR, "s3" puts them in an S3 compatible bucket
+	Storage struct {
+		Backend           string `env:"STORAGE_BACKEND"          envDefault:"local"`
+		LocalBaseURL      string `env:"STORAGE_LOCAL_BASE_URL"   envDefault:"/public/uploads"` // URL UPLOAD_DIR is served at
+		S3Endpoint        string `env:"S3_ENDPOINT"`                                           // Defaults to AWS S3 in S3_REGION
+		S3Region          string `env:"S3_REGION"                envDefault:"us-east-1"`
+		S3Bucket          string `env:"S3_BUCKET"`
+		S3AccessKeyID     string `env:"S3_ACCESS_KEY_ID"`
+		S3SecretAccessKey string `env:"S3_SECRET_ACCESS_KEY"`
+		S3PublicURL       string `env:"S3_PUBLIC_URL"` // Base URL objects are read from; defaults to the endpoint and bucket
+		MaxImageSizeMB    int    `env:"MAX_IMAGE_SIZE_MB"        envDefault:"5"`
+	}
+	Workers struct {
+		ChallengeExpiryIntervalMinutes int `env:"CHALLENGE_EXPIRY_INTERVAL_MINUTES" envDefault:"5"` // 0 disables the worker
+		MatchReminderIntervalMinutes   int `env:"MATCH_REMINDER_INTERVAL_MINUTES"   envDefault:"5"` // 0 disables the worker
+		MatchReminderLeadMinutes       int `env:"MATCH_REMINDER_LEAD_MINUTES"       envDefault:"60"`
+		MatchAutoStartIntervalMinutes  int `env:"MATCH_AUTO_START_INTERVAL_MINUTES" envDefault:"1"` // 0 disables the worker
+	}
 	// Add other configurations like Email, SMS services if needed
 	// Email struct { ... }
 	// SMS struct { ... }
 }
 
+// minTeamExpiryHours is the shortest time a team invitation or join request may stay open
+const minTeamExpiryHours = 1
+
+// MaxImpersonationExpiryMinutes is the longest an admin impersonation token may be valid for.
+// The tokens cannot be refreshed, so there is no reason for them to live long.
+const MaxImpersonationExpiryMinutes = 60
+
 // Global DB instance, accessible after ConnectDB() is called via Initialize.
 var DB *gorm.DB
 
@@ -56,12 +162,26 @@ func LoadConfig() (*Config, error) {
 	}
 
 	cfg := &Config{}
+	var err error
 
 	// --- App Configuration ---
 	cfg.App.Env = getEnv("APP_ENV", "development")
 	cfg.App.Port = getEnv("PORT", "8088")
 	cfg.App.FrontendURL = getEnv("FRONTEND_URL", "http://localhost:3000")
 	cfg.App.UploadDir = getEnv("UPLOAD_DIR", "./public/uploads") // Ensure this path is writable
+	cfg.App.ShutdownTimeoutSeconds, err = getEnvAsInt("SHUTDOWN_TIMEOUT_SECONDS", 10)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SHUTDOWN_TIMEOUT_SECONDS: %w", err)
+	}
+	if cfg.App.ShutdownTimeoutSeconds < 1 {
+		return nil, fmt.Errorf("invalid SHUTDOWN_TIMEOUT_SECONDS: must be at least 1")
+	}
+	cfg.App.TrustedProxies = getEnvAsList("TRUSTED_PROXIES")
+	for _, proxy := range cfg.App.TrustedProxies {
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			return nil, fmt.Errorf("invalid TRUSTED_PROXIES: %q is not an IP address or CIDR", proxy)
+		}
+	}
 
 	// --- Database Configuration ---
 	cfg.DB.Host = getEnv("DB_HOST", "localhost")
@@ -70,12 +190,30 @@ func LoadConfig() (*Config, error) {
 	cfg.DB.Password = getEnv("DB_PASSWORD", "password")
 	cfg.DB.Name = getEnv("DB_NAME", "miow_db")
 	cfg.DB.SSLMode = getEnv("DB_SSLMODE", "disable")
+	cfg.DB.MaxOpenConns, err = getEnvAsInt("DB_MAX_OPEN_CONNS", 25)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DB_MAX_OPEN_CONNS: %w", err)
+	}
+	cfg.DB.MaxIdleConns, err = getEnvAsInt("DB_MAX_IDLE_CONNS", 10)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DB_MAX_IDLE_CONNS: %w", err)
+	}
+	cfg.DB.ConnMaxLifetimeMinutes, err = getEnvAsInt("DB_CONN_MAX_LIFETIME_MINUTES", 30)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DB_CONN_MAX_LIFETIME_MINUTES: %w", err)
+	}
+	if cfg.DB.MaxOpenConns < 0 || cfg.DB.MaxIdleConns < 0 || cfg.DB.ConnMaxLifetimeMinutes < 0 {
+		return nil, fmt.Errorf("invalid database pool settings: DB_MAX_OPEN_CONNS, DB_MAX_IDLE_CONNS and DB_CONN_MAX_LIFETIME_MINUTES must not be negative")
+	}
+	if cfg.DB.MaxOpenConns > 0 && cfg.DB.MaxIdleConns > cfg.DB.MaxOpenConns {
+		return nil, fmt.Errorf("invalid DB_MAX_IDLE_CONNS: must not exceed DB_MAX_OPEN_CONNS")
+	}
+	cfg.DB.ReadReplicaDSN = getEnv("DB_READ_REPLICA_DSN", "")
 
 	// --- JWT Configuration ---
 	cfg.JWT.AccessTokenSecret = getEnv("JWT_ACCESS_TOKEN_SECRET", "your-very-strong-access-secret")
 	cfg.JWT.RefreshTokenSecret = getEnv("JWT_REFRESH_TOKEN_SECRET", "your-very-strong-refresh-secret")
 
-	var err error
 	cfg.JWT.AccessTokenExpiryMinutes, err = getEnvAsInt("JWT_ACCESS_TOKEN_EXPIRY_MINUTES", 15)
 	if err != nil {
 		return nil, fmt.Errorf("invalid JWT_ACCESS_TOKEN_EXPIRY_MINUTES: %w", err)
@@ -85,6 +223,177 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("invalid JWT_REFRESH_TOKEN_EXPIRY_DAYS: %w", err)
 	}
 
+	// --- Registration Configuration ---
+	cfg.Auth.RegistrationOpen, err = getEnvAsBool("REGISTRATION_OPEN", true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REGISTRATION_OPEN: %w", err)
+	}
+	cfg.Auth.InviteOnly, err = getEnvAsBool("REGISTRATION_INVITE_ONLY", false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REGISTRATION_INVITE_ONLY: %w", err)
+	}
+	cfg.Auth.InviteCodeExpiryHours, err = getEnvAsInt("INVITE_CODE_EXPIRY_HOURS", 168)
+	if err != nil {
+		return nil, fmt.Errorf("invalid INVITE_CODE_EXPIRY_HOURS: %w", err)
+	}
+	cfg.Auth.ImpersonationExpiryMinutes, err = getEnvAsInt("IMPERSONATION_TOKEN_EXPIRY_MINUTES", 10)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IMPERSONATION_TOKEN_EXPIRY_MINUTES: %w", err)
+	}
+	if cfg.Auth.ImpersonationExpiryMinutes < 1 || cfg.Auth.ImpersonationExpiryMinutes > MaxImpersonationExpiryMinutes {
+		return nil, fmt.Errorf("invalid IMPERSONATION_TOKEN_EXPIRY_MINUTES: must be between 1 and %d", MaxImpersonationExpiryMinutes)
+	}
+	cfg.Auth.PasswordHashCost, err = getEnvAsInt("PASSWORD_HASH_COST", 14)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PASSWORD_HASH_COST: %w", err)
+	}
+	if cfg.Auth.PasswordHashCost < bcrypt.MinCost || cfg.Auth.PasswordHashCost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("invalid PASSWORD_HASH_COST: must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+	}
+	cfg.Auth.EmailVerifyRedirect, err = getEnvAsBool("EMAIL_VERIFY_REDIRECT", false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EMAIL_VERIFY_REDIRECT: %w", err)
+	}
+	cfg.Auth.EmailVerifySuccessURL = getEnv("EMAIL_VERIFY_SUCCESS_URL", cfg.App.FrontendURL+"/email-verified")
+	cfg.Auth.EmailVerifyFailureURL = getEnv("EMAIL_VERIFY_FAILURE_URL", cfg.App.FrontendURL+"/email-verification-failed")
+	cfg.Teams.InvitationExpiryHours, err = getEnvAsInt("TEAM_INVITATION_EXPIRY_HOURS", 168)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TEAM_INVITATION_EXPIRY_HOURS: %w", err)
+	}
+	if cfg.Teams.InvitationExpiryHours < minTeamExpiryHours {
+		return nil, fmt.Errorf("invalid TEAM_INVITATION_EXPIRY_HOURS: must be at least %d", minTeamExpiryHours)
+	}
+	cfg.Teams.JoinRequestExpiryHours, err = getEnvAsInt("TEAM_JOIN_REQUEST_EXPIRY_HOURS", 168)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TEAM_JOIN_REQUEST_EXPIRY_HOURS: %w", err)
+	}
+	if cfg.Teams.JoinRequestExpiryHours < minTeamExpiryHours {
+		return nil, fmt.Errorf("invalid TEAM_JOIN_REQUEST_EXPIRY_HOURS: must be at least %d", minTeamExpiryHours)
+	}
+	cfg.Tournaments.CountLiveRegistrations, err = getEnvAsBool("TOURNAMENT_COUNT_LIVE_REGISTRATIONS", true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TOURNAMENT_COUNT_LIVE_REGISTRATIONS: %w", err)
+	}
+	cfg.Challenges.MinAcceptLeadMinutes, err = getEnvAsInt("CHALLENGE_MIN_ACCEPT_LEAD_MINUTES", 60)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CHALLENGE_MIN_ACCEPT_LEAD_MINUTES: %w", err)
+	}
+	if cfg.Challenges.MinAcceptLeadMinutes < 0 {
+		return nil, fmt.Errorf("invalid CHALLENGE_MIN_ACCEPT_LEAD_MINUTES: must not be negative")
+	}
+	cfg.Matches.AllowPlayerOfficials, err = getEnvAsBool("MATCH_ALLOW_PLAYER_OFFICIALS", false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MATCH_ALLOW_PLAYER_OFFICIALS: %w", err)
+	}
+	cfg.Comments.PerUserPerMinute, err = getEnvAsInt("COMMENT_RATE_LIMIT_PER_MINUTE", 5)
+	if err != nil {
+		return nil, fmt.Errorf("invalid COMMENT_RATE_LIMIT_PER_MINUTE: %w", err)
+	}
+	if cfg.Comments.PerUserPerMinute < 0 {
+		return nil, fmt.Errorf("invalid COMMENT_RATE_LIMIT_PER_MINUTE: must not be negative")
+	}
+	cfg.Comments.MaxPerMatch, err = getEnvAsInt("COMMENT_MAX_PER_MATCH", 500)
+	if err != nil {
+		return nil, fmt.Errorf("invalid COMMENT_MAX_PER_MATCH: %w", err)
+	}
+	if cfg.Comments.MaxPerMatch < 0 {
+		return nil, fmt.Errorf("invalid COMMENT_MAX_PER_MATCH: must not be negative")
+	}
+	cfg.Comments.BlockedWords = getEnv("COMMENT_BLOCKED_WORDS", "")
+	cfg.Comments.FilterAction = getEnv("COMMENT_FILTER_ACTION", "flag")
+	if cfg.Comments.FilterAction != "flag" && cfg.Comments.FilterAction != "reject" {
+		return nil, fmt.Errorf("invalid COMMENT_FILTER_ACTION: must be flag or reject")
+	}
+	cfg.RateLimit.Enabled, err = getEnvAsBool("RATE_LIMIT_ENABLED", true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_ENABLED: %w", err)
+	}
+	cfg.RateLimit.RequestsPerMinute, err = getEnvAsInt("RATE_LIMIT_REQUESTS_PER_MINUTE", 300)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_REQUESTS_PER_MINUTE: %w", err)
+	}
+	cfg.RateLimit.Burst, err = getEnvAsInt("RATE_LIMIT_BURST", 60)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_BURST: %w", err)
+	}
+	cfg.RateLimit.AuthRequestsPerMinute, err = getEnvAsInt("RATE_LIMIT_AUTH_REQUESTS_PER_MINUTE", 10)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_AUTH_REQUESTS_PER_MINUTE: %w", err)
+	}
+	cfg.RateLimit.AuthBurst, err = getEnvAsInt("RATE_LIMIT_AUTH_BURST", 5)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_AUTH_BURST: %w", err)
+	}
+	cfg.RateLimit.LookupRequestsPerMinute, err = getEnvAsInt("RATE_LIMIT_LOOKUP_REQUESTS_PER_MINUTE", 20)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_LOOKUP_REQUESTS_PER_MINUTE: %w", err)
+	}
+	cfg.RateLimit.LookupBurst, err = getEnvAsInt("RATE_LIMIT_LOOKUP_BURST", 10)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_LOOKUP_BURST: %w", err)
+	}
+	cfg.Cache.RedisAddr = getEnv("REDIS_ADDR", "")
+	cfg.Cache.RedisPassword = getEnv("REDIS_PASSWORD", "")
+	cfg.Cache.RedisDB, err = getEnvAsInt("REDIS_DB", 0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid REDIS_DB: %w", err)
+	}
+	cfg.Cache.TTLSeconds, err = getEnvAsInt("CACHE_TTL_SECONDS", 300)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CACHE_TTL_SECONDS: %w", err)
+	}
+	if cfg.Cache.TTLSeconds < 1 {
+		return nil, fmt.Errorf("invalid CACHE_TTL_SECONDS: must be at least 1")
+	}
+	cfg.Notifications.EmailEnabled, err = getEnvAsBool("NOTIFICATIONS_EMAIL_ENABLED", false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NOTIFICATIONS_EMAIL_ENABLED: %w", err)
+	}
+	cfg.Notifications.PushEnabled, err = getEnvAsBool("NOTIFICATIONS_PUSH_ENABLED", false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NOTIFICATIONS_PUSH_ENABLED: %w", err)
+	}
+	cfg.Storage.Backend = getEnv("STORAGE_BACKEND", "local")
+	if cfg.Storage.Backend != "local" && cfg.Storage.Backend != "s3" {
+		return nil, fmt.Errorf("invalid STORAGE_BACKEND: must be local or s3")
+	}
+	cfg.Storage.LocalBaseURL = getEnv("STORAGE_LOCAL_BASE_URL", "/public/uploads")
+	cfg.Storage.S3Endpoint = getEnv("S3_ENDPOINT", "")
+	cfg.Storage.S3Region = getEnv("S3_REGION", "us-east-1")
+	cfg.Storage.S3Bucket = getEnv("S3_BUCKET", "")
+	cfg.Storage.S3AccessKeyID = getEnv("S3_ACCESS_KEY_ID", "")
+	cfg.Storage.S3SecretAccessKey = getEnv("S3_SECRET_ACCESS_KEY", "")
+	cfg.Storage.S3PublicURL = getEnv("S3_PUBLIC_URL", "")
+	if cfg.Storage.Backend == "s3" && cfg.Storage.S3Bucket == "" {
+		return nil, fmt.Errorf("invalid S3_BUCKET: required when STORAGE_BACKEND is s3")
+	}
+	cfg.Storage.MaxImageSizeMB, err = getEnvAsInt("MAX_IMAGE_SIZE_MB", 5)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAX_IMAGE_SIZE_MB: %w", err)
+	}
+	if cfg.Storage.MaxImageSizeMB < 1 {
+		return nil, fmt.Errorf("invalid MAX_IMAGE_SIZE_MB: must be at least 1")
+	}
+	cfg.Workers.ChallengeExpiryIntervalMinutes, err = getEnvAsInt("CHALLENGE_EXPIRY_INTERVAL_MINUTES", 5)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CHALLENGE_EXPIRY_INTERVAL_MINUTES: %w", err)
+	}
+	cfg.Workers.MatchReminderIntervalMinutes, err = getEnvAsInt("MATCH_REMINDER_INTERVAL_MINUTES", 5)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MATCH_REMINDER_INTERVAL_MINUTES: %w", err)
+	}
+	cfg.Workers.MatchReminderLeadMinutes, err = getEnvAsInt("MATCH_REMINDER_LEAD_MINUTES", 60)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MATCH_REMINDER_LEAD_MINUTES: %w", err)
+	}
+	if cfg.Workers.MatchReminderLeadMinutes < 1 {
+		return nil, fmt.Errorf("invalid MATCH_REMINDER_LEAD_MINUTES: must be at least 1")
+	}
+	cfg.Workers.MatchAutoStartIntervalMinutes, err = getEnvAsInt("MATCH_AUTO_START_INTERVAL_MINUTES", 1)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MATCH_AUTO_START_INTERVAL_MINUTES: %w", err)
+	}
+
 	// Basic validation for critical secrets
 	if cfg.JWT.AccessTokenSecret == "your-very-strong-access-secret" || cfg.JWT.RefreshTokenSecret == "your-very-strong-refresh-secret" {
 		log.Println("WARNING: Using default JWT secrets. Please set JWT_ACCESS_TOKEN_SECRET and JWT_REFRESH_TOKEN_SECRET environment variables for production.")
@@ -101,7 +410,7 @@ func LoadConfig() (*Config, error) {
 // It sets the global DB variable.
 func ConnectDB(dbCfg Config) (*gorm.DB, error) {
 	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=Asia/Shanghai", // Example TimeZone
+		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=UTC", // Timestamps are stored and returned in UTC
 		dbCfg.DB.Host,
 		dbCfg.DB.User,
 		dbCfg.DB.Password,
@@ -110,7 +419,9 @@ func ConnectDB(dbCfg Config) (*gorm.DB, error) {
 		dbCfg.DB.SSLMode,
 	)
 
-	gormConfig := &gorm.Config{}
+	gormConfig := &gorm.Config{
+		NowFunc: func() time.Time { return time.Now().UTC() },
+	}
 	if dbCfg.App.Env == "development" {
 		gormConfig.Logger = logger.Default.LogMode(logger.Info) // Log SQL queries in development
 	} else {
@@ -122,12 +433,55 @@ func ConnectDB(dbCfg Config) (*gorm.DB, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
+	if err := registerUTCTimestamps(gormDB); err != nil {
+		return nil, fmt.Errorf("failed to register timestamp callbacks: %w", err)
+	}
+	sqlDB, err := gormDB.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to access database pool: %w", err)
+	}
+	applyPoolSettings(sqlDB, dbCfg)
+	log.Printf("Database pool: max open %d, max idle %d, max lifetime %s",
+		dbCfg.DB.MaxOpenConns, dbCfg.DB.MaxIdleConns, time.Duration(dbCfg.DB.ConnMaxLifetimeMinutes)*time.Minute)
+
+	if dbCfg.DB.ReadReplicaDSN != "" {
+		gormDB, err = UseReadReplica(gormDB, postgres.Open(dbCfg.DB.ReadReplicaDSN), dbCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to read replica: %w", err)
+		}
+		log.Println("Read replica enabled")
+	}
 
 	DB = gormDB // Set the global DB instance
 	log.Println("Successfully connected to database!")
 	return gormDB, nil
 }
 
+// UseReadReplica registers replica as db's read replica and returns a handle that keeps every
+// query on the primary unless it asks for the replica with Clauses(dbresolver.Read), as the
+// list endpoints do. Reads that must see the latest writes, such as the load before an update
+// or the auth check of a user who just signed up, therefore never lag. Transactions and locking
+// reads always stay on the primary. The replica pool is sized like the primary's.
+func UseReadReplica(db *gorm.DB, replica gorm.Dialector, dbCfg Config) (*gorm.DB, error) {
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: []gorm.Dialector{replica},
+	}).
+		SetMaxOpenConns(dbCfg.DB.MaxOpenConns).
+		SetMaxIdleConns(dbCfg.DB.MaxIdleConns).
+		SetConnMaxLifetime(time.Duration(dbCfg.DB.ConnMaxLifetimeMinutes) * time.Minute)
+	if err := db.Use(resolver); err != nil {
+		return nil, err
+	}
+	return db.Clauses(dbresolver.Write).Session(&gorm.Session{}), nil
+}
+
+// applyPoolSettings sizes the connection pool from the DB_MAX_* settings
+func applyPoolSettings(sqlDB *sql.DB, dbCfg Config) {
+	sqlDB.SetMaxOpenConns(dbCfg.DB.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(dbCfg.DB.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(dbCfg.DB.ConnMaxLifetimeMinutes) * time.Minute)
+}
+
 // Initialize loads all configurations and connects to the database.
 // This should be called once at the start of your application (e.g., in main.go).
 func Initialize() error {
@@ -181,3 +535,27 @@ func getEnvAsInt(key string, fallback int) (int, error) {
 	}
 	return value, nil
 }
+
+// Helper function to get a comma separated environment variable as a list, skipping empty items.
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// Helper function to get an environment variable as a boolean or return a default value.
+func getEnvAsBool(key string, fallback bool) (bool, error) {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return fallback, nil
+	}
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		return fallback, fmt.Errorf("env var %s: expected boolean, got '%s'", key, valueStr)
+	}
+	return value, nil
+}
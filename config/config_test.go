@@ -0,0 +1,293 @@
+package config
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
+)
+
+func TestLoadConfigTrustedProxies(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "unset trusts none", value: "", want: nil},
+		{name: "addresses and ranges", value: "10.0.0.0/8, 192.168.1.10,,", want: []string{"10.0.0.0/8", "192.168.1.10"}},
+		{name: "ipv6 range", value: "fd00::/8", want: []string{"fd00::/8"}},
+		{name: "hostname rejected", value: "proxy.internal", wantErr: true},
+		{name: "bad range rejected", value: "10.0.0.0/33", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TRUSTED_PROXIES", tt.value)
+			cfg, err := LoadConfig()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("LoadConfig() accepted TRUSTED_PROXIES=%q", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if !reflect.DeepEqual(cfg.App.TrustedProxies, tt.want) {
+				t.Errorf("TrustedProxies = %q, want %q", cfg.App.TrustedProxies, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigImpersonationExpiry(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{value: "", want: 10},
+		{value: "1", want: 1},
+		{value: "60", want: MaxImpersonationExpiryMinutes},
+		{value: "0", wantErr: true},
+		{value: "-5", wantErr: true},
+		{value: "61", wantErr: true},
+		{value: "ten", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("IMPERSONATION_TOKEN_EXPIRY_MINUTES", tt.value)
+			cfg, err := LoadConfig()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("LoadConfig() accepted IMPERSONATION_TOKEN_EXPIRY_MINUTES=%q", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if cfg.Auth.ImpersonationExpiryMinutes != tt.want {
+				t.Errorf("ImpersonationExpiryMinutes = %d, want %d", cfg.Auth.ImpersonationExpiryMinutes, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigPoolSettings(t *testing.T) {
+	tests := []struct {
+		name                 string
+		open, idle, lifetime string
+		want                 [3]int
+		wantErr              bool
+	}{
+		{name: "defaults", want: [3]int{25, 10, 30}},
+		{name: "custom", open: "50", idle: "20", lifetime: "5", want: [3]int{50, 20, 5}},
+		{name: "unlimited open connections", open: "0", idle: "40", lifetime: "0", want: [3]int{0, 40, 0}},
+		{name: "negative open connections", open: "-1", wantErr: true},
+		{name: "negative lifetime", lifetime: "-1", wantErr: true},
+		{name: "more idle than open", open: "5", idle: "6", wantErr: true},
+		{name: "not a number", idle: "ten", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DB_MAX_OPEN_CONNS", tt.open)
+			t.Setenv("DB_MAX_IDLE_CONNS", tt.idle)
+			t.Setenv("DB_CONN_MAX_LIFETIME_MINUTES", tt.lifetime)
+			cfg, err := LoadConfig()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("LoadConfig() accepted pool settings %q/%q/%q", tt.open, tt.idle, tt.lifetime)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			got := [3]int{cfg.DB.MaxOpenConns, cfg.DB.MaxIdleConns, cfg.DB.ConnMaxLifetimeMinutes}
+			if got != tt.want {
+				t.Errorf("pool settings = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeDriver opens connections that answer every query with no rows, recording which
+// connection (named by its DSN) ran each statement
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) { return fakeConn{name: name}, nil }
+
+type fakeConn struct{ name string }
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return fakeTx{}, nil }
+
+func (c fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	served.record(c.name, query)
+	return fakeRows{}, nil
+}
+
+func (c fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	served.record(c.name, query)
+	return driver.RowsAffected(0), nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct{}
+
+func (fakeRows) Columns() []string         { return nil }
+func (fakeRows) Close() error              { return nil }
+func (fakeRows) Next([]driver.Value) error { return io.EOF }
+
+// served records the statements each fake connection ran
+var served = &servedQueries{byConn: map[string][]string{}}
+
+type servedQueries struct {
+	mu     sync.Mutex
+	byConn map[string][]string
+}
+
+func (s *servedQueries) record(conn, query string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.byConn[conn] = append(s.byConn[conn], query)
+}
+
+// take returns and forgets the statements the connection ran
+func (s *servedQueries) take(conn string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	queries := s.byConn[conn]
+	delete(s.byConn, conn)
+	return queries
+}
+
+func init() {
+	sql.Register("config-fake", fakeDriver{})
+}
+
+// openFake opens a fake connection pool named dsn
+func openFake(t *testing.T, dsn string) *sql.DB {
+	t.Helper()
+	sqlDB, err := sql.Open("config-fake", dsn)
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+	return sqlDB
+}
+
+type routedModel struct {
+	ID   uint
+	Name string
+}
+
+func TestUseReadReplica(t *testing.T) {
+	primaryName, replicaName := t.Name()+"/primary", t.Name()+"/replica"
+	primary, err := gorm.Open(postgres.New(postgres.Config{Conn: openFake(t, primaryName)}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open primary: %v", err)
+	}
+	var cfg Config
+	cfg.DB.MaxOpenConns = 2
+	db, err := UseReadReplica(primary, postgres.New(postgres.Config{Conn: openFake(t, replicaName)}), cfg)
+	if err != nil {
+		t.Fatalf("UseReadReplica() error = %v", err)
+	}
+
+	var rows []routedModel
+	var count int64
+	tests := []struct {
+		name  string
+		run   func() error
+		reads string
+	}{
+		{"plain read", func() error { return db.Find(&rows).Error }, primaryName},
+		{"read asking for the replica", func() error { return db.Clauses(dbresolver.Read).Find(&rows).Error }, replicaName},
+		{"count asking for the replica", func() error {
+			return db.Clauses(dbresolver.Read).Model(&routedModel{}).Where("name = ?", "a").Count(&count).Error
+		}, replicaName},
+		{"plain read after a replica read", func() error { return db.Where("name = ?", "b").Find(&rows).Error }, primaryName},
+		{"locking read", func() error {
+			return db.Clauses(dbresolver.Read, clause.Locking{Strength: "UPDATE"}).Find(&rows).Error
+		}, primaryName},
+		{"read in a transaction", func() error {
+			return db.Transaction(func(tx *gorm.DB) error { return tx.Clauses(dbresolver.Read).Find(&rows).Error })
+		}, primaryName},
+		{"raw select", func() error { return db.Raw("SELECT name FROM routed_models").Scan(&rows).Error }, primaryName},
+		{"write", func() error { return db.Exec("UPDATE routed_models SET name = ?", "c").Error }, primaryName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); err != nil {
+				t.Fatalf("query failed: %v", err)
+			}
+			got := map[string][]string{primaryName: served.take(primaryName), replicaName: served.take(replicaName)}
+			if len(got[tt.reads]) == 0 {
+				t.Errorf("served by %v, want %s", got, tt.reads)
+			}
+			for conn, queries := range got {
+				if conn != tt.reads && len(queries) > 0 {
+					t.Errorf("%s also ran %q", conn, queries)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyPoolSettings(t *testing.T) {
+	sqlDB := openFake(t, t.Name())
+
+	var cfg Config
+	cfg.DB.MaxOpenConns = 3
+	cfg.DB.MaxIdleConns = 2
+	cfg.DB.ConnMaxLifetimeMinutes = 30
+	applyPoolSettings(sqlDB, cfg)
+
+	if got := sqlDB.Stats().MaxOpenConnections; got != 3 {
+		t.Errorf("MaxOpenConnections = %d, want 3", got)
+	}
+
+	ctx := context.Background()
+	var conns []*sql.Conn
+	for i := 0; i < 3; i++ {
+		conn, err := sqlDB.Conn(ctx)
+		if err != nil {
+			t.Fatalf("Conn() error = %v", err)
+		}
+		conns = append(conns, conn)
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if conn, err := sqlDB.Conn(waitCtx); err == nil {
+		conn.Close()
+		t.Error("pool opened a connection past its limit")
+	}
+
+	for _, conn := range conns {
+		conn.Close()
+	}
+	if stats := sqlDB.Stats(); stats.Idle != 2 || stats.MaxIdleClosed != 1 {
+		t.Errorf("idle = %d with %d closed, want 2 kept idle and 1 closed", stats.Idle, stats.MaxIdleClosed)
+	}
+}
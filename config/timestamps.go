@@ -0,0 +1,59 @@
+package config
+
+import (
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// registerUTCTimestamps normalizes every time.Time field of a model to UTC before it is
+// written. Handlers often echo the saved model back to the client, so timestamps parsed
+// from requests with an offset (e.g. "+05:30") are serialized as RFC3339 with a "Z"
+// suffix like everything else.
+func registerUTCTimestamps(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:create").Register("app:utc_timestamps", normalizeTimestamps); err != nil {
+		return err
+	}
+	return db.Callback().Update().Before("gorm:update").Register("app:utc_timestamps", normalizeTimestamps)
+}
+
+func normalizeTimestamps(db *gorm.DB) {
+	if db.Statement.Schema == nil || !db.Statement.ReflectValue.IsValid() {
+		return
+	}
+
+	rv := reflect.Indirect(db.Statement.ReflectValue)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			normalizeModelTimestamps(db, reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		normalizeModelTimestamps(db, rv)
+	}
+}
+
+func normalizeModelTimestamps(db *gorm.DB, rv reflect.Value) {
+	if rv.Kind() != reflect.Struct {
+		return
+	}
+	ctx := db.Statement.Context
+	for _, field := range db.Statement.Schema.Fields {
+		value, isZero := field.ValueOf(ctx, rv)
+		if isZero {
+			continue
+		}
+		switch t := value.(type) {
+		case time.Time:
+			if t.Location() != time.UTC {
+				_ = field.Set(ctx, rv, t.UTC())
+			}
+		case *time.Time:
+			if t != nil && t.Location() != time.UTC {
+				utc := t.UTC()
+				_ = field.Set(ctx, rv, &utc)
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type timestampModel struct {
+	gorm.Model
+	StartsAt   time.Time
+	FinishedAt *time.Time
+}
+
+// newDryRunDB returns a connection that builds statements without running them, so the
+// timestamp callbacks can be tested without a database
+func newDryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("failed to open dry-run connection: %v", err)
+	}
+	if err := registerUTCTimestamps(db); err != nil {
+		t.Fatalf("registerUTCTimestamps() error = %v", err)
+	}
+	return db
+}
+
+func TestUTCTimestampsSerializeWithZSuffix(t *testing.T) {
+	db := newDryRunDB(t)
+	india := time.FixedZone("IST", 5*60*60+30*60)
+	finished := time.Date(2026, 3, 1, 20, 0, 0, 0, india)
+	m := &timestampModel{StartsAt: time.Date(2026, 3, 1, 18, 30, 0, 0, india), FinishedAt: &finished}
+
+	if err := db.Create(m).Error; err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if m.StartsAt.Location() != time.UTC || m.FinishedAt.Location() != time.UTC {
+		t.Fatalf("timestamps not normalized to UTC: %v, %v", m.StartsAt, m.FinishedAt)
+	}
+	body, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(body, &out); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got, want := out["StartsAt"], "2026-03-01T13:00:00Z"; got != want {
+		t.Errorf("StartsAt = %v, want %v", got, want)
+	}
+	if got, want := out["FinishedAt"], "2026-03-01T14:30:00Z"; got != want {
+		t.Errorf("FinishedAt = %v, want %v", got, want)
+	}
+}
+
+func TestUTCTimestampsOnUpdate(t *testing.T) {
+	db := newDryRunDB(t)
+	m := &timestampModel{StartsAt: time.Date(2026, 3, 1, 9, 0, 0, 0, time.FixedZone("EST", -5*60*60))}
+	m.ID = 1
+
+	if err := db.Save(m).Error; err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	if got, want := m.StartsAt.Format(time.RFC3339), "2026-03-01T14:00:00Z"; got != want {
+		t.Errorf("StartsAt = %s, want %s", got, want)
+	}
+	if m.FinishedAt != nil {
+		t.Errorf("unset FinishedAt became %v", m.FinishedAt)
+	}
+}
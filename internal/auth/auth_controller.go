@@ -4,15 +4,20 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"math"
 	"net/http"
+	"net/url"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
-	"github.com/DhavalSuthar-24/miow/config"              // For DB and other app config
-	"github.com/DhavalSuthar-24/miow/internal/middleware" // Assuming your middleware is here for GetUserIDFromContext
+	"github.com/DhavalSuthar-24/miow/config" // For DB and other app config
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/internal/models"
 	"github.com/DhavalSuthar-24/miow/internal/user"
+	"github.com/DhavalSuthar-24/miow/pkg/i18n"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
 	"github.com/DhavalSuthar-24/miow/pkg/token" // Assuming token utilities are here
 	"github.com/DhavalSuthar-24/miow/pkg/utils" // General utilities like hashing, OTP
 	"github.com/gin-gonic/gin"
@@ -20,23 +25,32 @@ import (
 )
 
 const (
-	maxOTPSendAttempts = 5 // Max attempts for sending OTP before cooldown
-	otpCooldownMinutes = 1 // Cooldown period in minutes
-	otpExpiryMinutes   = 5 // OTP expiry time
+	maxOTPSendAttempts = 5                // Max attempts for sending OTP before cooldown
+	otpCooldownMinutes = 1                // Cooldown period in minutes
+	otpResendInterval  = 60 * time.Second // Minimum gap between two OTPs to the same phone
+	otpExpiryMinutes   = 5                // OTP expiry time
 	DefaultUserRole    = "player"
+	DefaultSportLevel  = "beginner" // Level given to preferred sports picked at registration
+
+	resetTokenTTL            = 1 * time.Hour    // Password reset link validity
+	forgotPasswordCooldown   = 5 * time.Minute  // Minimum gap before a new reset token is issued
+	forgotPasswordEmailLimit = 3                // Reset requests allowed per email per window
+	forgotPasswordWindow     = 15 * time.Minute // Window for the per-email limit
 )
 
 type AuthController struct {
-	repo   AuthRepository
-	config *config.Config // If you have a general config struct
+	repo                  AuthRepository
+	config                *config.Config // If you have a general config struct
+	forgotPasswordLimiter *middleware.RateLimiter
 	// mailer MailerService // Interface for sending emails
 	// sms    SMSService    // Interface for sending SMS
 }
 
 func NewAuthController(repo AuthRepository, cfg *config.Config /* mailer MailerService, sms SMSService*/) *AuthController {
 	return &AuthController{
-		repo:   repo,
-		config: cfg,
+		repo:                  repo,
+		config:                cfg,
+		forgotPasswordLimiter: middleware.NewRateLimiter(forgotPasswordEmailLimit, forgotPasswordWindow),
 		// mailer: mailer,
 		// sms:    sms,
 	}
@@ -66,8 +80,9 @@ func (ac *AuthController) generateAndSaveTokens(c *gin.Context, userID uint) (st
 }
 
 // sendOTPToPhone simulates sending OTP. Replace with actual SMS service.
-func (ac *AuthController) sendOTPToPhone(phone, otpCode string) error {
-	fmt.Printf("SIMULATING: Sending OTP %s to %s\n", otpCode, phone)
+// The code itself is never logged.
+func (ac *AuthController) sendOTPToPhone(c *gin.Context, phone, otpCode string) error {
+	middleware.Logger(c).Debug("SMS delivery not configured, OTP not sent", "phone", phone)
 	// Example: return ac.sms.Send(phone, fmt.Sprintf("Your OTP code is: %s", otpCode))
 
 	// Integrate with your SMS provider here
@@ -75,42 +90,55 @@ func (ac *AuthController) sendOTPToPhone(phone, otpCode string) error {
 }
 
 // sendEmail simulates sending an email. Replace with actual email service.
-func (ac *AuthController) sendEmail(to, subject, body string) error {
-	fmt.Printf("SIMULATING: Sending Email\nTo: %s\nSubject: %s\nBody: %s\n", to, subject, body)
+// The body carries verification and reset links, so only the envelope is logged.
+func (ac *AuthController) sendEmail(c *gin.Context, to, subject, body string) error {
+	middleware.Logger(c).Debug("email delivery not configured, email not sent", "to", to, "subject", subject)
 
 	// Integrate with your Email provider here
 	return nil
 }
 
 // @Summary      Register a new user
-// @Description  Create a new user with username, email, phone and password.
+// @Description  Create a new user with username, email, phone and password. Each preferred sport must name a known sport and is added to the user's sports at beginner level.
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
 // @Param        user  body  RegisterRequest  true  "User registration details"
-// @Success      201   {object} AuthResponse "User registered successfully, returns tokens and user info"
-// @Failure      400   {object} map[string]string "Validation error or invalid input"
-// @Failure      409   {object} map[string]string "User with this email or phone or username already exists"
-// @Failure      500   {object} map[string]string "Internal server error"
+// @Success      201 {object} response.SuccessResponse{data=AuthResponse} "User registered successfully, returns tokens and user info"
+// @Failure      400 {object} response.ErrorResponse "Validation error, invalid input or unknown preferred sport"
+// @Failure      403 {object} response.ErrorResponse "Registration closed or invalid invite code"
+// @Failure      409 {object} response.ErrorResponse "User with this email or phone or username already exists"
+// @Failure      500 {object} response.ErrorResponse "Internal server error"
 // @Router       /auth/register [post]
 func (ac *AuthController) Register(c *gin.Context) {
 	var req RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+		response.ValidationError(c, err)
+		return
+	}
+
+	// Registration gating: invite-only mode requires a code, closed mode rejects everyone
+	if ac.config.Auth.InviteOnly {
+		if strings.TrimSpace(req.InviteCode) == "" {
+			response.Error(c, http.StatusForbidden, i18n.T(c, i18n.AuthInviteRequired))
+			return
+		}
+	} else if !ac.config.Auth.RegistrationOpen {
+		response.Error(c, http.StatusForbidden, i18n.T(c, i18n.AuthRegistrationClosed))
 		return
 	}
 
 	// Check for existing users
 	if _, err := ac.repo.GetUserByEmail(req.Email); !errors.Is(err, gorm.ErrRecordNotFound) {
-		c.JSON(http.StatusConflict, gin.H{"error": "User with this email already exists"})
+		response.Error(c, http.StatusConflict, i18n.T(c, i18n.AuthEmailExists))
 		return
 	}
 	if _, err := ac.repo.GetUserByPhone(req.Phone); !errors.Is(err, gorm.ErrRecordNotFound) {
-		c.JSON(http.StatusConflict, gin.H{"error": "User with this phone number already exists"})
+		response.Error(c, http.StatusConflict, i18n.T(c, i18n.AuthPhoneExists))
 		return
 	}
 	if _, err := ac.repo.GetUserByUsername(req.Username); !errors.Is(err, gorm.ErrRecordNotFound) {
-		c.JSON(http.StatusConflict, gin.H{"error": "User with this username already exists"})
+		response.Error(c, http.StatusConflict, i18n.T(c, i18n.AuthUsernameExists))
 		return
 	}
 
@@ -118,18 +146,18 @@ func (ac *AuthController) Register(c *gin.Context) {
 		_, err := ac.repo.GetRoleByName(rn)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("role %q does not exist", rn)})
+				response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.AuthRoleNotFound, rn))
 				return
 			}
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "role lookup failed"})
+			response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthRoleLookupFailed))
 			return
 		}
 
 	}
 
-	hashedPassword, err := utils.HashPassword(req.Password)
+	hashedPassword, err := utils.HashPasswordWithCost(req.Password, ac.config.Auth.PasswordHashCost)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Error hashing password"})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthHashFailed))
 		return
 	}
 
@@ -172,70 +200,431 @@ func (ac *AuthController) Register(c *gin.Context) {
 	if req.Bio != "" {
 		newUser.Bio = req.Bio
 	}
+	var preferredSportIDs []uint
 	if len(req.PreferredSports) > 0 {
-		newUser.PreferredSports = req.PreferredSports
+		sportsByName, err := ac.repo.GetSportsByNames(req.PreferredSports)
+		if err != nil {
+			middleware.Logger(c).Error("preferred sport lookup failed", "error", err)
+			response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthSportLookupFailed))
+			return
+		}
+		var unknown, names []string
+		seen := make(map[uint]bool)
+		for _, name := range req.PreferredSports {
+			s, ok := sportsByName[strings.ToLower(strings.TrimSpace(name))]
+			if !ok {
+				unknown = append(unknown, name)
+				continue
+			}
+			if seen[s.ID] {
+				continue
+			}
+			seen[s.ID] = true
+			names = append(names, s.Name)
+			preferredSportIDs = append(preferredSportIDs, s.ID)
+		}
+		if len(unknown) > 0 {
+			response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.AuthUnknownSports, strings.Join(unknown, ", ")))
+			return
+		}
+		newUser.PreferredSports = names
 	}
 	if req.SocialMedia != nil {
 		newUser.SocialMedia = *req.SocialMedia
 	}
 
-	// Create user
-	if err := ac.repo.CreateUser(newUser); err != nil {
-		// Print the real error
-		log.Printf("❌ CreateUser failed: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "User creation failed: " + err.Error()})
+	// Create user (consuming the invite code when registration is invite-only)
+	if ac.config.Auth.InviteOnly {
+		err = ac.repo.CreateUserWithInviteCode(newUser, strings.TrimSpace(req.InviteCode))
+	} else {
+		err = ac.repo.CreateUser(newUser)
+	}
+	if err != nil {
+		if errors.Is(err, ErrInvalidInviteCode) {
+			response.Error(c, http.StatusForbidden, i18n.T(c, i18n.AuthInviteInvalid))
+			return
+		}
+		middleware.Logger(c).Error("create user failed", "error", err)
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthUserCreationFailed, err.Error()))
 		return
 	}
-	DefaultUserRoleID := 1
-
 	if len(req.Roles) == 0 {
-
-		if err := ac.repo.AssignRoleToUser(newUser.ID, "player"); err != nil {
-			log.Printf("Assign role %d failed: %v", DefaultUserRoleID, err)
+		if err := ac.repo.AssignRoleToUser(newUser.ID, DefaultUserRole); err != nil {
+			middleware.Logger(c).Error("assign role failed", "user_id", newUser.ID, "role", DefaultUserRole, "error", err)
 		}
 	}
 	for _, role := range req.Roles {
 		if err := ac.repo.AssignRoleToUser(newUser.ID, role); err != nil {
-			log.Printf("Assign role %d failed: %v", DefaultUserRoleID, err)
+			middleware.Logger(c).Error("assign role failed", "user_id", newUser.ID, "role", role, "error", err)
+		}
+	}
+
+	if len(preferredSportIDs) > 0 {
+		if err := ac.repo.AddUserSports(newUser.ID, preferredSportIDs, DefaultSportLevel); err != nil {
+			middleware.Logger(c).Error("add preferred sports failed", "user_id", newUser.ID, "error", err)
 		}
 	}
 
 	// Send verification email
 	verificationLink := fmt.Sprintf("%s/api/auth/verify-email?token=%s", ac.config.App.FrontendURL, emailVerifyToken)
 	emailBody := fmt.Sprintf("Hello %s, please verify your email by clicking on this link: %s", newUser.Name, verificationLink)
-	if err := ac.sendEmail(newUser.Email, "Verify Your Email Address", emailBody); err != nil {
-		fmt.Printf("Failed to send verification email to %s: %v\n", newUser.Email, err)
+	if err := ac.sendEmail(c, newUser.Email, "Verify Your Email Address", emailBody); err != nil {
+		middleware.Logger(c).Error("send verification email failed", "user_id", newUser.ID, "error", err)
 	}
 
 	accessToken, refreshToken, err := ac.generateAndSaveTokens(c, newUser.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusCreated, AuthResponse{
+	response.Success(c, http.StatusCreated, "", AuthResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		User:         FilterUserRecord(newUser),
 	})
 }
 
+// @Summary      Check identifier availability
+// @Description  Reports whether a username, email and/or phone number is free to register. Only booleans are returned.
+// @Tags         Auth
+// @Produce      json
+// @Param        username  query  string  false  "Username to check"
+// @Param        email     query  string  false  "Email to check"
+// @Param        phone     query  string  false  "Phone number to check"
+// @Success      200 {object} response.SuccessResponse{data=AvailabilityResponse} "Availability of each requested identifier"
+// @Failure      400 {object} response.ErrorResponse "No identifier provided"
+// @Failure      429 {object} response.ErrorResponse "Too many requests"
+// @Failure      500 {object} response.ErrorResponse "Internal server error"
+// @Router       /auth/available [get]
+func (ac *AuthController) CheckAvailability(c *gin.Context) {
+	username := strings.TrimSpace(c.Query("username"))
+	email := strings.ToLower(strings.TrimSpace(c.Query("email")))
+	phone := strings.TrimSpace(c.Query("phone"))
+
+	if username == "" && email == "" && phone == "" {
+		response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.AuthIdentifierRequired))
+		return
+	}
+
+	isFree := func(lookup func(string) (*user.User, error), value string) (*bool, error) {
+		if value == "" {
+			return nil, nil
+		}
+		_, err := lookup(value)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		available := errors.Is(err, gorm.ErrRecordNotFound)
+		return &available, nil
+	}
+
+	var resp AvailabilityResponse
+	var err error
+	if resp.Username, err = isFree(ac.repo.GetUserByUsername, username); err != nil {
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthAvailabilityFailed))
+		return
+	}
+	if resp.Email, err = isFree(ac.repo.GetUserByEmail, email); err != nil {
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthAvailabilityFailed))
+		return
+	}
+	if resp.Phone, err = isFree(ac.repo.GetUserByPhone, phone); err != nil {
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthAvailabilityFailed))
+		return
+	}
+
+	response.Success(c, http.StatusOK, "", resp)
+}
+
+// @Summary      Role cache statistics
+// @Description  Admin only. Returns the in-memory role cache size and hit/miss counters, for debugging.
+// @Tags         Auth
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Success      200 {object} response.SuccessResponse{data=middleware.RoleCacheStats} "Role cache statistics"
+// @Failure      401 {object} response.ErrorResponse "Unauthorized"
+// @Failure      403 {object} response.ErrorResponse "Forbidden"
+// @Router       /auth/admin/role-cache [get]
+func (ac *AuthController) GetRoleCacheStats(c *gin.Context) {
+	response.Success(c, http.StatusOK, "", middleware.GetRoleCacheStats())
+}
+
+// @Summary      Generate invite codes
+// @Description  Admin only. Generates single-use, expiring invite codes for invite-only registration.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        request  body  CreateInviteCodesRequest  false  "Number of codes and expiry"
+// @Success      201 {object} response.SuccessResponse{data=[]InviteCode} "Generated invite codes"
+// @Failure      400 {object} response.ErrorResponse "Invalid input"
+// @Failure      401 {object} response.ErrorResponse "Unauthorized"
+// @Failure      403 {object} response.ErrorResponse "Forbidden"
+// @Failure      500 {object} response.ErrorResponse "Internal server error"
+// @Router       /auth/admin/invite-codes [post]
+func (ac *AuthController) CreateInviteCodes(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, i18n.T(c, i18n.CommonUnauthorized))
+		return
+	}
+
+	var req CreateInviteCodesRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.ValidationError(c, err)
+			return
+		}
+	}
+	if req.Count == 0 {
+		req.Count = 1
+	}
+	if req.ExpiryHours == 0 {
+		req.ExpiryHours = ac.config.Auth.InviteCodeExpiryHours
+	}
+
+	expiresAt := time.Now().Add(time.Duration(req.ExpiryHours) * time.Hour)
+	codes := make([]InviteCode, 0, req.Count)
+	for i := 0; i < req.Count; i++ {
+		codes = append(codes, InviteCode{
+			Code:        strings.ToUpper(utils.GenerateRandomToken(6)),
+			CreatedByID: userID,
+			ExpiresAt:   expiresAt,
+		})
+	}
+
+	if err := ac.repo.CreateInviteCodes(codes); err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "", codes)
+}
+
+// @Summary      Admin audit log
+// @Description  Admin only. Lists audited admin actions, newest first. Impersonation is currently the only audited action.
+// @Tags         Auth
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        actor_id query int false "Only actions performed by this admin"
+// @Param        target_user_id query int false "Only actions affecting this user"
+// @Param        action query string false "Only this action" Enums(impersonate)
+// @Param        from query string false "Only actions at or after this time (RFC 3339 or YYYY-MM-DD)"
+// @Param        to query string false "Only actions before this time (RFC 3339 or YYYY-MM-DD)"
+// @Param        page query int false "Page number" default(1)
+// @Param        limit query int false "Items per page" default(20)
+// @Success      200 {object} response.PaginatedResponse{data=response.Page{items=[]AuditLogEntry}} "Audit log entries"
+// @Failure      400 {object} response.ErrorResponse "Invalid filter"
+// @Failure      401 {object} response.ErrorResponse "Unauthorized"
+// @Failure      403 {object} response.ErrorResponse "Forbidden"
+// @Failure      500 {object} response.ErrorResponse "Internal server error"
+// @Router       /admin/audit-log [get]
+func (ac *AuthController) GetAuditLog(c *gin.Context) {
+	var filter AuditLogFilter
+	var err error
+	if filter.ActorID, err = utils.ParseOptionalID(c.Query("actor_id")); err != nil {
+		response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.CommonInvalidInput, "actor_id"))
+		return
+	}
+	if filter.TargetUserID, err = utils.ParseOptionalID(c.Query("target_user_id")); err != nil {
+		response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.CommonInvalidInput, "target_user_id"))
+		return
+	}
+	if filter.From, err = utils.ParseOptionalTime(c.Query("from")); err != nil {
+		response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.CommonInvalidInput, "from"))
+		return
+	}
+	if filter.To, err = utils.ParseOptionalTime(c.Query("to")); err != nil {
+		response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.CommonInvalidInput, "to"))
+		return
+	}
+	if action := c.Query("action"); action != "" && action != AuditActionImpersonate {
+		response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.CommonInvalidInput, "action"))
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	logs, total, err := ac.repo.GetImpersonationLogs(filter, page, limit)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.CommonDatabaseError, err.Error()))
+		return
+	}
+
+	entries := make([]AuditLogEntry, 0, len(logs))
+	for i := range logs {
+		entries = append(entries, newImpersonationAuditEntry(&logs[i]))
+	}
+	response.Paginated(c, http.StatusOK, "", entries, total, page, limit)
+}
+
+// @Summary      Admin dashboard metrics
+// @Description  Admin only. Platform-wide counts of users, teams, matches, tournaments and bookings. Users, teams and tournaments are counted by creation time, matches by scheduled time and bookings by start time. Revenue is not reported because the platform does not take payments.
+// @Tags         Auth
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        from query string false "Start of the range, inclusive (RFC 3339 or YYYY-MM-DD)"
+// @Param        to query string false "End of the range, exclusive (RFC 3339 or YYYY-MM-DD)"
+// @Success      200 {object} response.SuccessResponse{data=PlatformMetrics}
+// @Failure      400 {object} response.ErrorResponse "Invalid range"
+// @Failure      401 {object} response.ErrorResponse "Unauthorized"
+// @Failure      403 {object} response.ErrorResponse "Forbidden"
+// @Failure      500 {object} response.ErrorResponse "Internal server error"
+// @Router       /admin/metrics [get]
+func (ac *AuthController) GetPlatformMetrics(c *gin.Context) {
+	from, err := utils.ParseOptionalTime(c.Query("from"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.CommonInvalidInput, "from"))
+		return
+	}
+	to, err := utils.ParseOptionalTime(c.Query("to"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.CommonInvalidInput, "to"))
+		return
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.CommonInvalidInput, "to"))
+		return
+	}
+
+	metrics, err := ac.repo.GetPlatformMetrics(from, to)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.CommonDatabaseError, err.Error()))
+		return
+	}
+	if !from.IsZero() {
+		metrics.From = &from
+	}
+	if !to.IsZero() {
+		metrics.To = &to
+	}
+	response.Success(c, http.StatusOK, "", metrics)
+}
+
+// @Summary      Impersonate a user
+// @Description  Admin only. Issues a short-lived, non-refreshable access token for the user with an "impersonated_by" claim. Every issuance is audited; administrators cannot be impersonated.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id       path  int                 true   "User ID"
+// @Param        request  body  ImpersonateRequest  false  "Reason for impersonation"
+// @Success      200 {object} response.SuccessResponse{data=ImpersonationResponse}
+// @Failure      400 {object} response.ErrorResponse "Invalid input"
+// @Failure      401 {object} response.ErrorResponse "Unauthorized"
+// @Failure      403 {object} response.ErrorResponse "Forbidden"
+// @Failure      404 {object} response.ErrorResponse "User not found"
+// @Failure      500 {object} response.ErrorResponse "Internal server error"
+// @Router       /admin/users/{id}/impersonate [post]
+func (ac *AuthController) ImpersonateUser(c *gin.Context) {
+	adminID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, i18n.T(c, i18n.CommonUnauthorized))
+		return
+	}
+	// An impersonation token must never be used to start another impersonation
+	if _, impersonating := middleware.GetImpersonatorIDFromContext(c); impersonating {
+		response.Error(c, http.StatusForbidden, "Forbidden")
+		return
+	}
+
+	targetID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || targetID == 0 {
+		response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.AuthInvalidUserID))
+		return
+	}
+	if uint(targetID) == adminID {
+		response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.AuthImpersonateSelf))
+		return
+	}
+
+	var req ImpersonateRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.ValidationError(c, err)
+			return
+		}
+	}
+
+	target, err := ac.repo.GetUserByID(uint(targetID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, http.StatusNotFound, i18n.T(c, i18n.AuthUserNotFound))
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthRetrieveUserFailed, err.Error()))
+		return
+	}
+
+	roles, err := ac.repo.GetUserRoles(target.ID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthRoleLookupFailed))
+		return
+	}
+	for _, r := range roles {
+		if strings.EqualFold(r, "admin") {
+			response.Error(c, http.StatusForbidden, i18n.T(c, i18n.AuthImpersonateAdmin))
+			return
+		}
+	}
+
+	expiryMinutes := ac.config.Auth.ImpersonationExpiryMinutes
+	expiresAt := time.Now().Add(time.Duration(expiryMinutes) * time.Minute)
+	accessToken, err := token.GenerateImpersonationJWT(target.ID, adminID, ac.config.JWT.AccessTokenSecret, expiryMinutes)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthImpersonationFailed))
+		return
+	}
+
+	// The token is only handed out once the audit record is safely stored
+	entry := &ImpersonationLog{
+		AdminID:   adminID,
+		UserID:    target.ID,
+		Reason:    req.Reason,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		ExpiresAt: expiresAt,
+	}
+	if err := ac.repo.CreateImpersonationLog(entry); err != nil {
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthImpersonationFailed))
+		return
+	}
+	middleware.Logger(c).Info("impersonation token issued",
+		"admin_id", adminID, "target_user_id", target.ID, "audit_log_id", entry.ID, "expires_at", expiresAt.Format(time.RFC3339))
+
+	response.Success(c, http.StatusOK, "", ImpersonationResponse{
+		AccessToken:    accessToken,
+		TokenType:      "Bearer",
+		ExpiresAt:      expiresAt,
+		UserID:         target.ID,
+		ImpersonatedBy: adminID,
+	})
+}
+
 // @Summary      Login user
 // @Description  Authenticate user with email/username and password.
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
 // @Param        credentials  body  LoginRequest  true  "Login credentials"
-// @Success      200   {object} AuthResponse "Login successful, returns tokens and user info"
-// @Failure      400   {object} map[string]string "Invalid input"
-// @Failure      401   {object} map[string]string "Invalid credentials or user not verified"
-// @Failure      404   {object} map[string]string "User not found"
-// @Failure      500   {object} map[string]string "Internal server error"
+// @Success      200 {object} response.SuccessResponse{data=AuthResponse} "Login successful, returns tokens and user info"
+// @Failure      400 {object} response.ErrorResponse "Invalid input"
+// @Failure      401 {object} response.ErrorResponse "Invalid credentials or user not verified"
+// @Failure      404 {object} response.ErrorResponse "User not found"
+// @Failure      500 {object} response.ErrorResponse "Internal server error"
 // @Router       /auth/login [post]
 func (ac *AuthController) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+		response.ValidationError(c, err)
 		return
 	}
 
@@ -247,17 +636,17 @@ func (ac *AuthController) Login(c *gin.Context) {
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		// foundUser, err = ac.repo.GetUserByUsername(req.LoginIdentifier) // Uncomment if username login is supported
 		// if errors.Is(err, gorm.ErrRecordNotFound) {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		response.Error(c, http.StatusNotFound, i18n.T(c, i18n.AuthUserNotFound))
 		return
 		// }
 	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error: " + err.Error()})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.CommonDatabaseError, err.Error()))
 		return
 	}
 
 	if !utils.CheckPassword(foundUser.Password, req.Password) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		response.Error(c, http.StatusUnauthorized, i18n.T(c, i18n.AuthInvalidCredentials))
 		return
 	}
 
@@ -269,16 +658,25 @@ func (ac *AuthController) Login(c *gin.Context) {
 
 	accessToken, refreshToken, err := ac.generateAndSaveTokens(c, foundUser.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	// Transparently upgrade hashes stored with a lower cost than currently configured
+	if utils.PasswordNeedsRehash(foundUser.Password, ac.config.Auth.PasswordHashCost) {
+		if rehashed, err := utils.HashPasswordWithCost(req.Password, ac.config.Auth.PasswordHashCost); err != nil {
+			middleware.Logger(c).Warn("password rehash failed", "user_id", foundUser.ID, "error", err)
+		} else {
+			foundUser.Password = rehashed
+		}
+	}
+
 	foundUser.LastActive = time.Now()
 	if err := ac.repo.UpdateUser(foundUser); err != nil {
-		fmt.Printf("Error updating last active for user %d: %v\n", foundUser.ID, err)
+		middleware.Logger(c).Warn("update last active failed", "user_id", foundUser.ID, "error", err)
 	}
 
-	c.JSON(http.StatusOK, AuthResponse{
+	response.Success(c, http.StatusOK, "", AuthResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		User:         FilterUserRecord(foundUser),
@@ -291,31 +689,31 @@ func (ac *AuthController) Login(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        request body RefreshTokenRequest true "Refresh Token Request"
-// @Success      200 {object} map[string]string "Returns a new access token"
-// @Failure      400 {object} map[string]string "Invalid input"
-// @Failure      401 {object} map[string]string "Invalid or expired refresh token"
-// @Failure      500 {object} map[string]string "Token generation failed"
+// @Success      200 {object} response.SuccessResponse "Returns a new access token"
+// @Failure      400 {object} response.ErrorResponse "Invalid input"
+// @Failure      401 {object} response.ErrorResponse "Invalid or expired refresh token"
+// @Failure      500 {object} response.ErrorResponse "Token generation failed"
 // @Router       /auth/refresh-token [post]
 func (ac *AuthController) RefreshToken(c *gin.Context) {
 	var req RefreshTokenRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+		response.ValidationError(c, err)
 		return
 	}
 
 	rt, err := ac.repo.GetRefreshToken(req.RefreshToken)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired refresh token"})
+		response.Error(c, http.StatusUnauthorized, i18n.T(c, i18n.AuthInvalidRefreshToken))
 		return
 	}
 
 	newAccessToken, err := token.GenerateJWT(rt.UserID, ac.config.JWT.AccessTokenSecret, ac.config.JWT.AccessTokenExpiryMinutes)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "New access token generation failed"})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthAccessTokenFailed))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"access_token": newAccessToken})
+	response.Success(c, http.StatusOK, "", gin.H{"access_token": newAccessToken})
 }
 
 // @Summary      Get User Profile
@@ -323,28 +721,186 @@ func (ac *AuthController) RefreshToken(c *gin.Context) {
 // @Tags         Profile
 // @Security     BearerAuth
 // @Produce      json
-// @Success      200 {object} UserResponse "User profile data"
-// @Failure      401 {object} map[string]string "Unauthorized"
-// @Failure      404 {object} map[string]string "User not found"
-// @Failure      500 {object} map[string]string "Internal server error"
+// @Success      200 {object} response.SuccessResponse{data=UserResponse} "User profile data"
+// @Failure      401 {object} response.ErrorResponse "Unauthorized"
+// @Failure      404 {object} response.ErrorResponse "User not found"
+// @Failure      500 {object} response.ErrorResponse "Internal server error"
 // @Router       /auth/me [get]
 func (ac *AuthController) GetProfile(c *gin.Context) {
-	userID, err := middleware.GetUserIDFromContext(c) // Assumes your middleware sets this
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, i18n.T(c, i18n.CommonUnauthorized))
 		return
 	}
 
 	currentUser, err := ac.repo.GetUserByID(userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "User not found."})
+			response.Error(c, http.StatusNotFound, i18n.T(c, i18n.AuthUserNotFound))
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve profile: " + err.Error()})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthRetrieveProfileFailed, err.Error()))
+		return
+	}
+	response.Success(c, http.StatusOK, "", FilterUserRecord(currentUser))
+}
+
+// @Summary      Get public profiles of several users
+// @Description  Returns the public profiles of up to 50 users in one call, e.g. to render a team roster or match lineup. Unknown IDs are skipped.
+// @Tags         Profile
+// @Security     BearerAuth
+// @Produce      json
+// @Param        ids query string true "Comma-separated user IDs" example(1,2,3)
+// @Success      200 {object} response.SuccessResponse{data=[]PublicUserResponse} "Public user profiles"
+// @Failure      400 {object} response.ErrorResponse "Missing, invalid or too many IDs"
+// @Failure      401 {object} response.ErrorResponse "Unauthorized"
+// @Failure      500 {object} response.ErrorResponse "Internal server error"
+// @Router       /users [get]
+func (ac *AuthController) GetUsersByIDs(c *gin.Context) {
+	rawIDs := strings.TrimSpace(c.Query("ids"))
+	if rawIDs == "" {
+		response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.AuthUserIDsRequired))
+		return
+	}
+
+	seen := make(map[uint]bool)
+	ids := make([]uint, 0)
+	for _, raw := range strings.Split(rawIDs, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(raw), 10, 32)
+		if err != nil || id == 0 {
+			response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.AuthInvalidUserID))
+			return
+		}
+		if !seen[uint(id)] {
+			seen[uint(id)] = true
+			ids = append(ids, uint(id))
+		}
+	}
+	if len(ids) > maxBatchUserIDs {
+		response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.AuthTooManyUserIDs, maxBatchUserIDs))
+		return
+	}
+
+	users, err := ac.repo.GetUsersByIDs(ids)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthRetrieveUsersFailed, err.Error()))
+		return
+	}
+
+	profiles := make([]PublicUserResponse, 0, len(users))
+	for i := range users {
+		profiles = append(profiles, FilterPublicUserRecord(&users[i]))
+	}
+	response.Success(c, http.StatusOK, "", profiles)
+}
+
+// @Summary      Search users
+// @Description  Finds users by username or name, e.g. to invite a player to a team. Optionally filters by a sport the user plays and their level in it. The caller and private profiles are never returned.
+// @Tags         Profile
+// @Security     BearerAuth
+// @Produce      json
+// @Param        q query string true "Username or name to search for (at least 2 characters)"
+// @Param        sport query string false "Sport name the user plays" example(Cricket)
+// @Param        skill query string false "Skill level in that sport" example(intermediate)
+// @Param        page query int false "Page number" default(1)
+// @Param        limit query int false "Items per page" default(10)
+// @Success      200 {object} response.PaginatedResponse{data=response.Page{items=[]PublicUserResponse}} "Matching users"
+// @Failure      400 {object} response.ErrorResponse "Search term too short"
+// @Failure      401 {object} response.ErrorResponse "Unauthorized"
+// @Failure      429 {object} response.ErrorResponse "Too many requests"
+// @Failure      500 {object} response.ErrorResponse "Internal server error"
+// @Router       /users/search [get]
+func (ac *AuthController) SearchUsers(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, i18n.T(c, i18n.CommonUnauthorized))
+		return
+	}
+
+	q := strings.TrimSpace(c.Query("q"))
+	if len([]rune(q)) < minUserSearchQueryLength {
+		response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.AuthSearchQueryTooShort, minUserSearchQueryLength))
 		return
 	}
-	c.JSON(http.StatusOK, FilterUserRecord(currentUser))
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > maxBatchUserIDs {
+		limit = 10
+	}
+
+	filter := UserSearchFilter{
+		Query:         q,
+		Sport:         strings.TrimSpace(c.Query("sport")),
+		Skill:         strings.TrimSpace(c.Query("skill")),
+		ExcludeUserID: userID,
+	}
+	users, total, err := ac.repo.SearchUsers(filter, page, limit)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthRetrieveUsersFailed, err.Error()))
+		return
+	}
+
+	profiles := make([]PublicUserResponse, 0, len(users))
+	for i := range users {
+		profiles = append(profiles, FilterPublicUserRecord(&users[i]))
+	}
+	response.Paginated(c, http.StatusOK, "", profiles, total, page, limit)
+}
+
+// @Summary      Get a user's public profile
+// @Description  Returns another user's public profile with the sports they play and their level, e.g. before inviting or challenging them. Private profiles only show the user's name, username and image, except to the user themselves and admins.
+// @Tags         Profile
+// @Security     BearerAuth
+// @Produce      json
+// @Param        id path int true "User ID"
+// @Success      200 {object} response.SuccessResponse{data=PublicUserResponse} "Public user profile"
+// @Failure      400 {object} response.ErrorResponse "Invalid user ID"
+// @Failure      401 {object} response.ErrorResponse "Unauthorized"
+// @Failure      404 {object} response.ErrorResponse "User not found"
+// @Failure      500 {object} response.ErrorResponse "Internal server error"
+// @Router       /users/{id} [get]
+func (ac *AuthController) GetPublicProfile(c *gin.Context) {
+	requesterID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, i18n.T(c, i18n.CommonUnauthorized))
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil || id == 0 {
+		response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.AuthInvalidUserID))
+		return
+	}
+
+	u, err := ac.repo.GetUserByID(uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			response.Error(c, http.StatusNotFound, i18n.T(c, i18n.AuthUserNotFound))
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthRetrieveUserFailed, err.Error()))
+		return
+	}
+
+	// Users always see their own full public profile, and admins see past the privacy flag
+	if u.ProfilePrivate && (u.ID == requesterID || middleware.HasRole(c, "admin")) {
+		u.ProfilePrivate = false
+	}
+
+	profile := FilterPublicUserRecord(u)
+	if !profile.Private {
+		sports, err := ac.repo.GetUserSports(u.ID)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthRetrieveUserFailed, err.Error()))
+			return
+		}
+		profile.Sports = sports
+	}
+	response.Success(c, http.StatusOK, "", profile)
 }
 
 // @Summary      Update User Profile
@@ -354,33 +910,33 @@ func (ac *AuthController) GetProfile(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        profileData body UpdateProfileRequest true "Profile data to update"
-// @Success      200 {object} UserResponse "Updated user profile data"
-// @Failure      400 {object} map[string]string "Invalid input"
-// @Failure      401 {object} map[string]string "Unauthorized"
-// @Failure      404 {object} map[string]string "User not found"
-// @Failure      409 {object} map[string]string "Username already taken"
-// @Failure      500 {object} map[string]string "Internal server error"
+// @Success      200 {object} response.SuccessResponse{data=UserResponse} "Updated user profile data"
+// @Failure      400 {object} response.ErrorResponse "Invalid input"
+// @Failure      401 {object} response.ErrorResponse "Unauthorized"
+// @Failure      404 {object} response.ErrorResponse "User not found"
+// @Failure      409 {object} response.ErrorResponse "Username already taken"
+// @Failure      500 {object} response.ErrorResponse "Internal server error"
 // @Router       /auth/me [put]
 func (ac *AuthController) UpdateProfile(c *gin.Context) {
-	userID, err := middleware.GetUserIDFromContext(c)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, i18n.T(c, i18n.CommonUnauthorized))
 		return
 	}
 
 	var req UpdateProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+		response.ValidationError(c, err)
 		return
 	}
 
 	u, err := ac.repo.GetUserByID(userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "User not found."})
+			response.Error(c, http.StatusNotFound, i18n.T(c, i18n.AuthUserNotFound))
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve user: " + err.Error()})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthRetrieveUserFailed, err.Error()))
 		return
 	}
 
@@ -390,7 +946,7 @@ func (ac *AuthController) UpdateProfile(c *gin.Context) {
 	if req.Username != nil {
 		existingUser, findErr := ac.repo.GetUserByUsername(*req.Username)
 		if findErr == nil && existingUser.ID != u.ID {
-			c.JSON(http.StatusConflict, gin.H{"error": "Username already taken."})
+			response.Error(c, http.StatusConflict, i18n.T(c, i18n.AuthUsernameTaken))
 			return
 		}
 		u.Username = *req.Username
@@ -425,14 +981,17 @@ func (ac *AuthController) UpdateProfile(c *gin.Context) {
 	if req.Coordinates != nil {
 		u.Coordinates = *req.Coordinates
 	}
+	if req.ProfilePrivate != nil {
+		u.ProfilePrivate = *req.ProfilePrivate
+	}
 
 	u.LastActive = time.Now()
 
 	if err := ac.repo.UpdateUser(u); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not update profile: " + err.Error()})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthUpdateProfileFailed, err.Error()))
 		return
 	}
-	c.JSON(http.StatusOK, FilterUserRecord(u))
+	response.Success(c, http.StatusOK, "", FilterUserRecord(u))
 }
 
 // @Summary      Update Profile Image
@@ -442,21 +1001,21 @@ func (ac *AuthController) UpdateProfile(c *gin.Context) {
 // @Accept       multipart/form-data
 // @Produce      json
 // @Param        image formData file true "Profile image file"
-// @Success      200 {object} map[string]string "Profile image updated successfully"
-// @Failure      400 {object} map[string]string "Invalid file or input"
-// @Failure      401 {object} map[string]string "Unauthorized"
-// @Failure      500 {object} map[string]string "Failed to upload or save image path"
+// @Success      200 {object} response.SuccessResponse "Profile image updated successfully"
+// @Failure      400 {object} response.ErrorResponse "Invalid file or input"
+// @Failure      401 {object} response.ErrorResponse "Unauthorized"
+// @Failure      500 {object} response.ErrorResponse "Failed to upload or save image path"
 // @Router       /auth/me/profile-image [put]
 func (ac *AuthController) UpdateProfileImage(c *gin.Context) {
-	userID, err := middleware.GetUserIDFromContext(c)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, i18n.T(c, i18n.CommonUnauthorized))
 		return
 	}
 
 	file, err := c.FormFile("image")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Image file is required: " + err.Error()})
+		response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.AuthImageRequired, err.Error()))
 		return
 	}
 
@@ -465,7 +1024,7 @@ func (ac *AuthController) UpdateProfileImage(c *gin.Context) {
 
 	u, err := ac.repo.GetUserByID(userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve user: " + err.Error()})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthRetrieveUserFailed, err.Error()))
 		return
 	}
 
@@ -476,12 +1035,12 @@ func (ac *AuthController) UpdateProfileImage(c *gin.Context) {
 
 	// Ensure directory exists
 	if err := utils.EnsureDir(filepath.Dir(uploadPath)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Could not create upload directory: " + err.Error()})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthUploadDirFailed, err.Error()))
 		return
 	}
 
 	if err := c.SaveUploadedFile(file, uploadPath); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save uploaded image: " + err.Error()})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthSaveImageFailed, err.Error()))
 		return
 	}
 
@@ -490,11 +1049,11 @@ func (ac *AuthController) UpdateProfileImage(c *gin.Context) {
 	u.LastActive = time.Now()
 
 	if err := ac.repo.UpdateUser(u); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save profile image path to database: " + err.Error()})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthSaveImagePathFailed, err.Error()))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Profile image updated successfully", "profile_image_url": u.ProfileImage})
+	response.Success(c, http.StatusOK, i18n.T(c, i18n.AuthProfileImageUpdated), gin.H{"profile_image_url": u.ProfileImage})
 }
 
 // @Summary      Change Password
@@ -504,43 +1063,43 @@ func (ac *AuthController) UpdateProfileImage(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        passwords body ChangePasswordRequest true "Old and new password details"
-// @Success      200 {object} map[string]string "Password changed successfully"
-// @Failure      400 {object} map[string]string "Invalid input or password mismatch"
-// @Failure      401 {object} map[string]string "Unauthorized or incorrect old password"
-// @Failure      500 {object} map[string]string "Failed to change password"
+// @Success      200 {object} response.SuccessResponse "Password changed successfully"
+// @Failure      400 {object} response.ErrorResponse "Invalid input or password mismatch"
+// @Failure      401 {object} response.ErrorResponse "Unauthorized or incorrect old password"
+// @Failure      500 {object} response.ErrorResponse "Failed to change password"
 // @Router       /auth/change-password [post]
 func (ac *AuthController) ChangePassword(c *gin.Context) {
-	userID, err := middleware.GetUserIDFromContext(c)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, i18n.T(c, i18n.CommonUnauthorized))
 		return
 	}
 
 	var req ChangePasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+		response.ValidationError(c, err)
 		return
 	}
 
 	u, err := ac.repo.GetUserByID(userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve user: " + err.Error()})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthRetrieveUserFailed, err.Error()))
 		return
 	}
 
 	if !utils.CheckPassword(u.Password, req.OldPassword) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Incorrect old password."})
+		response.Error(c, http.StatusUnauthorized, i18n.T(c, i18n.AuthIncorrectOldPassword))
 		return
 	}
 
 	if req.OldPassword == req.NewPassword {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "New password cannot be the same as the old password."})
+		response.Error(c, http.StatusBadRequest, i18n.T(c, i18n.AuthSamePassword))
 		return
 	}
 
-	newHashedPassword, err := utils.HashPassword(req.NewPassword)
+	newHashedPassword, err := utils.HashPasswordWithCost(req.NewPassword, ac.config.Auth.PasswordHashCost)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash new password."})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthHashNewFailed))
 		return
 	}
 
@@ -550,11 +1109,11 @@ func (ac *AuthController) ChangePassword(c *gin.Context) {
 	// if err := ac.repo.InvalidateAllRefreshTokensForUser(u.ID); err != nil { ... }
 
 	if err := ac.repo.UpdateUser(u); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change password: " + err.Error()})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthChangePasswordFailed, err.Error()))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Password changed successfully."})
+	response.Success(c, http.StatusOK, i18n.T(c, i18n.AuthPasswordChanged), nil)
 }
 
 // @Summary      Logout User
@@ -564,23 +1123,22 @@ func (ac *AuthController) ChangePassword(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        request body LogoutRequest false "Logout options"
-// @Success      200 {object} map[string]string "Logged out successfully"
-// @Failure      400 {object} map[string]string "Invalid input"
-// @Failure      401 {object} map[string]string "Unauthorized"
-// @Failure      500 {object} map[string]string "Failed to logout"
+// @Success      200 {object} response.SuccessResponse "Logged out successfully"
+// @Failure      400 {object} response.ErrorResponse "Invalid input"
+// @Failure      401 {object} response.ErrorResponse "Unauthorized"
+// @Failure      500 {object} response.ErrorResponse "Failed to logout"
 // @Router       /auth/logout [post]
 func (ac *AuthController) Logout(c *gin.Context) {
 	// Get user ID from context (set by your auth middleware)
-	userID, err := middleware.GetUserIDFromContext(c)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized: " + err.Error()})
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, i18n.T(c, i18n.CommonUnauthorized))
 		return
 	}
 
 	var req LogoutRequest
 	if err := c.ShouldBindJSON(&req); err != nil && err != io.EOF {
-
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+		response.ValidationError(c, err)
 		return
 	}
 
@@ -600,7 +1158,7 @@ func (ac *AuthController) Logout(c *gin.Context) {
 	if refreshToken != "" {
 		if err := ac.repo.InvalidateRefreshToken(refreshToken); err != nil {
 			if !errors.Is(err, gorm.ErrRecordNotFound) {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to invalidate refresh token: " + err.Error()})
+				response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthInvalidateTokenFailed, err.Error()))
 				return
 			}
 			// Token not found is acceptable (maybe already expired/revoked)
@@ -610,7 +1168,7 @@ func (ac *AuthController) Logout(c *gin.Context) {
 	// If requested, invalidate ALL user's refresh tokens
 	if req.InvalidateAllSessions {
 		if err := ac.repo.InvalidateAllRefreshTokensForUser(userID); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to invalidate all sessions: " + err.Error()})
+			response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthInvalidateSessionsFailed, err.Error()))
 			return
 		}
 	}
@@ -618,8 +1176,7 @@ func (ac *AuthController) Logout(c *gin.Context) {
 	c.SetCookie("refresh_token", "", -1, "/", "", false, true) // secure flag true in production
 	c.SetCookie("access_token", "", -1, "/", "", false, true)  // if you use access token cookies
 
-	c.JSON(http.StatusOK, gin.H{
-		"message":                  "Logged out successfully",
+	response.Success(c, http.StatusOK, i18n.T(c, i18n.AuthLoggedOut), gin.H{
 		"all_sessions_invalidated": req.InvalidateAllSessions,
 	})
 }
@@ -630,15 +1187,15 @@ func (ac *AuthController) Logout(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        request  body  OTPRequest  true  "Phone Number Request"
-// @Success      200  {object}  map[string]string  "OTP sent successfully"
-// @Failure      400  {object}  map[string]string  "Invalid phone number format"
-// @Failure      429  {object}  map[string]string  "Too many OTP requests. Please try again later."
-// @Failure      500  {object}  map[string]string  "Failed to generate or send OTP"
+// @Success      200 {object} response.SuccessResponse  "OTP sent successfully"
+// @Failure      400 {object} response.ErrorResponse  "Invalid phone number format"
+// @Failure      429 {object} response.ErrorResponse{error=response.ErrorDetail{details=OTPThrottleDetails}}  "OTP recently sent (otp_throttled_short) or too many requests (otp_cooldown); see Retry-After"
+// @Failure      500 {object} response.ErrorResponse  "Failed to generate or send OTP"
 // @Router       /auth/request-otp [post]
 func (ac *AuthController) RequestOTP(c *gin.Context) {
 	var req OTPRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+		response.ValidationError(c, err)
 		return
 	}
 
@@ -655,13 +1212,15 @@ func (ac *AuthController) RequestOTP(c *gin.Context) {
 
 	latestOTP, err := ac.repo.GetLatestOTP(req.Phone)
 	if err == nil && latestOTP != nil {
-		if latestOTP.Attempt >= maxOTPSendAttempts && time.Since(latestOTP.CreatedAt) < otpCooldownMinutes*time.Minute {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": fmt.Sprintf("Too many OTP requests. Please try again in %.0f minute(s).", otpCooldownMinutes-time.Since(latestOTP.CreatedAt).Minutes())})
+		sinceLast := time.Since(latestOTP.CreatedAt)
+		if latestOTP.Attempt >= maxOTPSendAttempts && sinceLast < otpCooldownMinutes*time.Minute {
+			wait := otpCooldownMinutes*time.Minute - sinceLast
+			otpThrottled(c, OTPErrorCooldown, wait, i18n.T(c, i18n.AuthOTPCooldown, math.Ceil(wait.Minutes())))
 			return
 		}
-		// If an OTP was sent recently (e.g., within the last 60 seconds), resend it or ask user to wait
-		if time.Since(latestOTP.CreatedAt) < 60*time.Second {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": "An OTP was recently sent. Please wait a moment before requesting a new one."})
+		// If an OTP was sent recently, ask the user to wait before requesting another
+		if sinceLast < otpResendInterval {
+			otpThrottled(c, OTPErrorThrottledShort, otpResendInterval-sinceLast, i18n.T(c, i18n.AuthOTPRecentlySent))
 			return
 		}
 	}
@@ -681,96 +1240,100 @@ func (ac *AuthController) RequestOTP(c *gin.Context) {
 	}
 
 	if err := ac.repo.SaveOTP(otp); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save OTP: " + err.Error()})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthSaveOTPFailed, err.Error()))
 		return
 	}
 
-	if err := ac.sendOTPToPhone(req.Phone, otpCode); err != nil {
+	if err := ac.sendOTPToPhone(c, req.Phone, otpCode); err != nil {
 		// Log error, but don't necessarily expose detailed failure to client for security
-		fmt.Printf("Failed to send OTP to %s: %v\n", req.Phone, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send OTP. Please try again."})
+		middleware.Logger(c).Error("send OTP failed", "error", err)
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthOTPSendFailed))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "OTP sent successfully."})
+	response.Success(c, http.StatusOK, i18n.T(c, i18n.AuthOTPSent), nil)
+}
+
+// otpThrottled refuses an OTP request with the reason and wait time in the error details and
+// the Retry-After header
+func otpThrottled(c *gin.Context, code string, wait time.Duration, message string) {
+	retryAfter := int(math.Ceil(wait.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	response.ErrorWithDetails(c, http.StatusTooManyRequests, message, OTPThrottleDetails{
+		Code:              code,
+		RetryAfterSeconds: retryAfter,
+	})
 }
 
 // @Summary      Verify OTP
-// @Description  Verify the OTP. If user with phone doesn't exist, create one. Then log in user.
+// @Description  Verify the OTP. If user with phone doesn't exist, create one, subject to the same registration gating as /auth/register. Then log in user.
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
 // @Param        request  body  VerifyOTPRequest  true  "OTP Verification Request"
-// @Success      200  {object}  AuthResponse "OTP verified, tokens and user info returned"
-// @Failure      400  {object}  map[string]string  "Invalid input or OTP format"
-// @Failure      401  {object}  map[string]string  "Invalid, expired, or already used OTP"
-// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Success      200 {object} response.SuccessResponse{data=AuthResponse} "OTP verified, tokens and user info returned"
+// @Failure      400 {object} response.ErrorResponse  "Invalid input or OTP format"
+// @Failure      401 {object} response.ErrorResponse  "Invalid, expired, or already used OTP"
+// @Failure      403 {object} response.ErrorResponse  "Phone not registered while registration is closed, or invalid invite code"
+// @Failure      500 {object} response.ErrorResponse  "Internal server error"
 // @Router       /auth/verify-otp [post]
 func (ac *AuthController) VerifyOTP(c *gin.Context) {
 	var req VerifyOTPRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+		response.ValidationError(c, err)
 		return
 	}
 
-	otp, err := ac.repo.GetOTP(req.Phone, req.Code)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid, expired, or already used OTP."})
-		return
+	// Registered only if no user has the phone number yet
+	newUser := &user.User{
+		Name:            "User_" + strings.ReplaceAll(req.Phone, "+", ""),
+		Username:        "user_" + strings.ReplaceAll(req.Phone, "+", ""),
+		Phone:           req.Phone,
+		PhoneVerified:   true,
+		Verified:        true,
+		LastActive:      time.Now(),
+		PreferredSports: models.StringSlice{},
+	}
+
+	// Unknown phones register under the same gating as Register; existing users always sign in
+	inviteCode := strings.TrimSpace(req.InviteCode)
+	refusal := ""
+	if ac.config.Auth.InviteOnly {
+		if inviteCode == "" {
+			newUser, refusal = nil, i18n.AuthInviteRequired
+		}
+	} else {
+		inviteCode = ""
+		if !ac.config.Auth.RegistrationOpen {
+			newUser, refusal = nil, i18n.AuthRegistrationClosed
+		}
 	}
 
-	otp.Verified = true
-	if err := ac.repo.UpdateOTP(otp); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update OTP status: " + err.Error()})
+	u, _, err := ac.repo.VerifyOTP(req.Phone, req.Code, newUser, DefaultUserRole, inviteCode)
+	if errors.Is(err, ErrInvalidOTP) {
+		response.Error(c, http.StatusUnauthorized, i18n.T(c, i18n.AuthInvalidOTP))
 		return
 	}
-
-	var u *user.User
-	u, err = ac.repo.GetUserByPhone(req.Phone)
-
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		// Auto-register user with minimal information
-		newUser := &user.User{
-			Name:          "User_" + strings.ReplaceAll(req.Phone, "+", ""),
-			Username:      "user_" + strings.ReplaceAll(req.Phone, "+", ""),
-			Phone:         req.Phone,
-			PhoneVerified: true,
-			Verified:      true,
-			LastActive:    time.Now(),
-		}
-
-		if errCreate := ac.repo.CreateUser(newUser); errCreate != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user: " + errCreate.Error()})
-			return
-		}
-
-		// Assign default role
-		if errRole := ac.repo.AssignRoleToUser(newUser.ID, DefaultUserRole); errRole != nil {
-			fmt.Printf("Failed to assign default role to user: %v\n", errRole)
-		}
-
-		u = newUser
-	} else if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error: " + err.Error()})
+	if errors.Is(err, ErrOTPRegistrationRefused) {
+		response.Error(c, http.StatusForbidden, i18n.T(c, refusal))
+		return
+	}
+	if errors.Is(err, ErrInvalidInviteCode) {
+		response.Error(c, http.StatusForbidden, i18n.T(c, i18n.AuthInviteInvalid))
+		return
+	}
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.CommonDatabaseError, err.Error()))
 		return
-	} else {
-		// User exists, update verification status
-		u.PhoneVerified = true
-		u.Verified = u.EmailVerified // Verified becomes true if email was already verified
-		u.LastActive = time.Now()
-		if errUpdate := ac.repo.UpdateUser(u); errUpdate != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update user: " + errUpdate.Error()})
-			return
-		}
 	}
 
 	accessToken, refreshToken, err := ac.generateAndSaveTokens(c, u.ID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	c.JSON(http.StatusOK, AuthResponse{
+	response.Success(c, http.StatusOK, "", AuthResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		User:         FilterUserRecord(u),
@@ -778,53 +1341,68 @@ func (ac *AuthController) VerifyOTP(c *gin.Context) {
 }
 
 // @Summary      Forgot Password
-// @Description  Sends a password reset link/code to the user's email.
+// @Description  Sends a password reset link to the user's email. Always responds with the same generic message so it cannot be used to discover registered emails.
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
 // @Param        request body ForgotPasswordRequest true "Email for password reset"
-// @Success      200 {object} map[string]string "Password reset instructions sent"
-// @Failure      400 {object} map[string]string "Invalid email format"
-// @Failure      404 {object} map[string]string "User with this email not found"
-// @Failure      500 {object} map[string]string "Failed to process request"
+// @Success      200 {object} response.SuccessResponse "If the account exists, instructions were sent"
+// @Failure      400 {object} response.ErrorResponse "Invalid email format"
+// @Failure      429 {object} response.ErrorResponse "Too many requests"
+// @Failure      500 {object} response.ErrorResponse "Failed to process request"
 // @Router       /auth/forgot-password [post]
 func (ac *AuthController) ForgotPassword(c *gin.Context) {
 	var req ForgotPasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+		response.ValidationError(c, err)
 		return
 	}
 
-	u, err := ac.repo.GetUserByEmail(strings.ToLower(req.Email))
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	genericResponse := gin.H{"message": i18n.T(c, i18n.AuthResetInstructionsSent)}
+
+	// Requests over the per-email limit get the same answer as everything else,
+	// so the limit itself does not reveal whether the account exists
+	if !ac.forgotPasswordLimiter.Allow(email) {
+		response.Success(c, http.StatusOK, "", genericResponse)
+		return
+	}
+
+	u, err := ac.repo.GetUserByEmail(email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "User with this email not found."})
+			response.Success(c, http.StatusOK, "", genericResponse)
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error: " + err.Error()})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.CommonDatabaseError, err.Error()))
 		return
 	}
 
-	resetToken := utils.GenerateRandomToken(32)   // Ensure this token is cryptographically secure
-	resetExpires := time.Now().Add(1 * time.Hour) // Token valid for 1 hour
+	// A token issued within the cooldown is still in the user's inbox; don't mint and mail another
+	if u.ResetExpires != nil && time.Until(*u.ResetExpires) > resetTokenTTL-forgotPasswordCooldown {
+		response.Success(c, http.StatusOK, "", genericResponse)
+		return
+	}
+
+	resetToken := utils.GenerateRandomToken(32) // Ensure this token is cryptographically secure
+	resetExpires := time.Now().Add(resetTokenTTL)
 
 	u.ResetToken = resetToken
 	u.ResetExpires = &resetExpires
 	if err := ac.repo.UpdateUser(u); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save reset token: " + err.Error()})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthSaveResetTokenFailed, err.Error()))
 		return
 	}
 
 	resetLink := fmt.Sprintf("%s/reset-password?token=%s", ac.config.App.FrontendURL, resetToken)
 	emailBody := fmt.Sprintf("Hello %s,\n\nYou requested a password reset. Click the link below to reset your password:\n%s\n\nIf you didn't request this, please ignore this email.\nThis link is valid for 1 hour.", u.Username, resetLink)
 
-	if err := ac.sendEmail(u.Email, "Password Reset Request", emailBody); err != nil {
-		fmt.Printf("Failed to send password reset email to %s: %v\n", u.Email, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send password reset email. Please try again later."})
-		return
+	if err := ac.sendEmail(c, u.Email, "Password Reset Request", emailBody); err != nil {
+		// Not surfaced to the client: a failure here would only happen for existing accounts
+		middleware.Logger(c).Error("send password reset email failed", "user_id", u.ID, "error", err)
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Password reset instructions sent to your email."})
+	response.Success(c, http.StatusOK, "", genericResponse)
 }
 
 // @Summary      Reset Password
@@ -833,64 +1411,71 @@ func (ac *AuthController) ForgotPassword(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        request body ResetPasswordRequest true "Password reset token and new password"
-// @Success      200 {object} map[string]string "Password reset successfully"
-// @Failure      400 {object} map[string]string "Invalid input or password mismatch"
-// @Failure      401 {object} map[string]string "Invalid or expired reset token"
-// @Failure      500 {object} map[string]string "Failed to update password"
+// @Success      200 {object} response.SuccessResponse "Password reset successfully"
+// @Failure      400 {object} response.ErrorResponse "Invalid input or password mismatch"
+// @Failure      401 {object} response.ErrorResponse "Invalid or expired reset token"
+// @Failure      500 {object} response.ErrorResponse "Failed to update password"
 // @Router       /auth/reset-password [post]
 func (ac *AuthController) ResetPassword(c *gin.Context) {
 	var req ResetPasswordRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+		response.ValidationError(c, err)
 		return
 	}
 
-	u, err := ac.repo.GetUserByResetToken(req.Token)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired password reset token."})
+	// Cheap early rejection of unknown or expired tokens before hashing the password
+	if _, err := ac.repo.GetUserByResetToken(req.Token); err != nil {
+		response.Error(c, http.StatusUnauthorized, i18n.T(c, i18n.AuthInvalidResetToken))
 		return
 	}
 
-	hashedPassword, err := utils.HashPassword(req.Password)
+	hashedPassword, err := utils.HashPasswordWithCost(req.Password, ac.config.Auth.PasswordHashCost)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash new password"})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthHashNewFailed))
 		return
 	}
 
-	u.Password = hashedPassword
-	u.ResetToken = ""    // Clear the token
-	u.ResetExpires = nil // Clear expiry
-	u.LastActive = time.Now()
-
-	if err := ac.repo.UpdateUser(u); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update password: " + err.Error()})
+	// The token is consumed atomically with the password change; a concurrent
+	// request using the same token loses here even if it passed the check above
+	if err := ac.repo.ResetPasswordWithToken(req.Token, hashedPassword); err != nil {
+		if errors.Is(err, ErrInvalidResetToken) {
+			response.Error(c, http.StatusUnauthorized, i18n.T(c, i18n.AuthInvalidResetToken))
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthUpdatePasswordFailed, err.Error()))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Password has been reset successfully."})
+	response.Success(c, http.StatusOK, i18n.T(c, i18n.AuthPasswordReset), nil)
 }
 
 // @Summary      Verify Email
-// @Description  Verifies a user's email address using a token.
+// @Description  Verifies a user's email address using a token. With redirects enabled (EMAIL_VERIFY_REDIRECT or ?redirect=true) the browser is sent to the frontend success or failure page instead of receiving JSON.
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
 // @Param        token query string true "Email verification token"
-// @Success      200 {object} map[string]string "Email verified successfully"
-// @Failure      400 {object} map[string]string "Invalid or missing token"
-// @Failure      401 {object} map[string]string "Invalid or expired token"
-// @Failure      500 {object} map[string]string "Failed to verify email"
+// @Param        redirect query bool false "Redirect to the frontend instead of returning JSON"
+// @Success      200 {object} response.SuccessResponse "Email verified successfully"
+// @Success      302 "Redirect to the frontend success or failure page"
+// @Failure      400 {object} response.ErrorResponse "Invalid or missing token"
+// @Failure      401 {object} response.ErrorResponse "Invalid or expired token"
+// @Failure      500 {object} response.ErrorResponse "Failed to verify email"
 // @Router       /auth/verify-email [get]
 func (ac *AuthController) VerifyEmail(c *gin.Context) {
 	token := c.Query("token")
 	if token == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Verification token is required."})
+		ac.verifyEmailFailure(c, http.StatusBadRequest, "missing_token", i18n.T(c, i18n.AuthVerifyTokenRequired), nil)
 		return
 	}
 
 	u, err := ac.repo.GetUserByVerifyToken(token)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired email verification token."})
+		if errors.Is(err, ErrVerifyTokenExpired) {
+			ac.verifyEmailFailure(c, http.StatusUnauthorized, "expired", i18n.T(c, i18n.AuthVerifyTokenExpired), gin.H{"code": "verification_token_expired"})
+			return
+		}
+		ac.verifyEmailFailure(c, http.StatusUnauthorized, "invalid", i18n.T(c, i18n.AuthInvalidVerifyToken), nil)
 		return
 	}
 
@@ -903,12 +1488,42 @@ func (ac *AuthController) VerifyEmail(c *gin.Context) {
 	u.LastActive = time.Now()
 
 	if err := ac.repo.UpdateUser(u); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update email verification status: " + err.Error()})
+		ac.verifyEmailFailure(c, http.StatusInternalServerError, "server_error", i18n.T(c, i18n.AuthUpdateEmailStatusFailed, err.Error()), nil)
+		return
+	}
+
+	if ac.verifyEmailShouldRedirect(c) {
+		c.Redirect(http.StatusFound, ac.config.Auth.EmailVerifySuccessURL)
+		return
+	}
+	response.Success(c, http.StatusOK, i18n.T(c, i18n.AuthEmailVerified), nil)
+}
+
+// verifyEmailShouldRedirect reports whether the verification result should be a browser redirect
+// rather than JSON. The ?redirect query param overrides the EMAIL_VERIFY_REDIRECT setting.
+func (ac *AuthController) verifyEmailShouldRedirect(c *gin.Context) bool {
+	if v, err := strconv.ParseBool(c.Query("redirect")); err == nil {
+		return v
+	}
+	return ac.config.Auth.EmailVerifyRedirect
+}
+
+// verifyEmailFailure responds with JSON, or redirects to the configured failure page
+// with the failure reason in the query string.
+func (ac *AuthController) verifyEmailFailure(c *gin.Context, status int, reason, message string, details interface{}) {
+	if !ac.verifyEmailShouldRedirect(c) {
+		response.ErrorWithDetails(c, status, message, details)
+		return
+	}
+	target, err := url.Parse(ac.config.Auth.EmailVerifyFailureURL)
+	if err != nil {
+		response.ErrorWithDetails(c, status, message, details)
 		return
 	}
-	// Instead of No Content, maybe redirect to a success page or return a success message
-	// c.Redirect(http.StatusFound, ac.config.App.FrontendURL+"/email-verified")
-	c.JSON(http.StatusOK, gin.H{"message": "Email verified successfully."})
+	q := target.Query()
+	q.Set("reason", reason)
+	target.RawQuery = q.Encode()
+	c.Redirect(http.StatusFound, target.String())
 }
 
 // @Summary      Resend Verification Email
@@ -917,31 +1532,31 @@ func (ac *AuthController) VerifyEmail(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Param        request body ResendVerificationRequest true "Email to resend verification for"
-// @Success      200 {object} map[string]string "Verification email resent"
-// @Failure      400 {object} map[string]string "Invalid email format"
-// @Failure      404 {object} map[string]string "User not found"
-// @Failure      409 {object} map[string]string "Email already verified"
-// @Failure      500 {object} map[string]string "Failed to resend verification"
+// @Success      200 {object} response.SuccessResponse "Verification email resent"
+// @Failure      400 {object} response.ErrorResponse "Invalid email format"
+// @Failure      404 {object} response.ErrorResponse "User not found"
+// @Failure      409 {object} response.ErrorResponse "Email already verified"
+// @Failure      500 {object} response.ErrorResponse "Failed to resend verification"
 // @Router       /auth/resend-verification [post]
 func (ac *AuthController) ResendVerificationEmail(c *gin.Context) {
 	var req ResendVerificationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+		response.ValidationError(c, err)
 		return
 	}
 
 	u, err := ac.repo.GetUserByEmail(strings.ToLower(req.Email))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": "User with this email not found."})
+			response.Error(c, http.StatusNotFound, i18n.T(c, i18n.AuthEmailNotFound))
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error: " + err.Error()})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.CommonDatabaseError, err.Error()))
 		return
 	}
 
 	if u.EmailVerified {
-		c.JSON(http.StatusConflict, gin.H{"error": "Email is already verified."})
+		response.Error(c, http.StatusConflict, i18n.T(c, i18n.AuthEmailAlreadyVerified))
 		return
 	}
 
@@ -951,18 +1566,18 @@ func (ac *AuthController) ResendVerificationEmail(c *gin.Context) {
 	u.VerifyExpires = &newVerifyExpires
 
 	if err := ac.repo.UpdateUser(u); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update verification token: " + err.Error()})
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthUpdateVerifyTokenFailed, err.Error()))
 		return
 	}
 
 	verificationLink := fmt.Sprintf("%s/auth/verify-email?token=%s", ac.config.App.FrontendURL, newVerifyToken)
 	emailBody := fmt.Sprintf("Hello %s, please verify your email address by clicking on this link: %s", u.Username, verificationLink)
 
-	if err := ac.sendEmail(u.Email, "Resend: Verify Your Email Address", emailBody); err != nil {
-		fmt.Printf("Failed to resend verification email to %s: %v\n", u.Email, err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send verification email. Please try again later."})
+	if err := ac.sendEmail(c, u.Email, "Resend: Verify Your Email Address", emailBody); err != nil {
+		middleware.Logger(c).Error("resend verification email failed", "user_id", u.ID, "error", err)
+		response.Error(c, http.StatusInternalServerError, i18n.T(c, i18n.AuthVerificationEmailFailed))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Verification email has been resent."})
+	response.Success(c, http.StatusOK, i18n.T(c, i18n.AuthVerificationResent), nil)
 }
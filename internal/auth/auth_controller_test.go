@@ -0,0 +1,334 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/internal/user"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/DhavalSuthar-24/miow/pkg/validator"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// setResetToken gives the user a password reset token expiring at expires
+func setResetToken(t *testing.T, db *gorm.DB, u *user.User, token string, expires time.Time) {
+	t.Helper()
+	if err := db.Model(u).Updates(map[string]interface{}{"reset_token": token, "reset_expires": expires}).Error; err != nil {
+		t.Fatalf("failed to set reset token: %v", err)
+	}
+}
+
+// passwordMatches reports whether the user's stored password hash is for password
+func passwordMatches(t *testing.T, db *gorm.DB, userID uint, password string) bool {
+	t.Helper()
+	var u user.User
+	if err := db.Select("password").First(&u, userID).Error; err != nil {
+		t.Fatalf("failed to reload user: %v", err)
+	}
+	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password)) == nil
+}
+
+func resetPasswordRouter(ac *AuthController) *gin.Engine {
+	r := gin.New()
+	r.POST("/auth/reset-password", ac.ResetPassword)
+	return r
+}
+
+func TestResetPasswordRejectsExpiredToken(t *testing.T) {
+	db := newTestDB(t)
+	r := resetPasswordRouter(newTestController(t, db))
+	u := testutil.CreateUser(t, db, "Expired")
+	setResetToken(t, db, u, "expired-token", time.Now().Add(-time.Minute))
+
+	w := testutil.Request(t, r, http.MethodPost, "/auth/reset-password", ResetPasswordRequest{
+		Token: "expired-token", Password: "new-password-1", PasswordConfirm: "new-password-1",
+	})
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusUnauthorized, w.Body)
+	}
+	if passwordMatches(t, db, u.ID, "new-password-1") {
+		t.Error("password was changed with an expired token")
+	}
+}
+
+func TestResetPasswordTokenIsSingleUse(t *testing.T) {
+	db := newTestDB(t)
+	r := resetPasswordRouter(newTestController(t, db))
+	u := testutil.CreateUser(t, db, "Reuse")
+	setResetToken(t, db, u, "single-use-token", time.Now().Add(time.Hour))
+
+	w := testutil.Request(t, r, http.MethodPost, "/auth/reset-password", ResetPasswordRequest{
+		Token: "single-use-token", Password: "first-password", PasswordConfirm: "first-password",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("first reset status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	w = testutil.Request(t, r, http.MethodPost, "/auth/reset-password", ResetPasswordRequest{
+		Token: "single-use-token", Password: "second-password", PasswordConfirm: "second-password",
+	})
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("second reset status = %d, want %d: %s", w.Code, http.StatusUnauthorized, w.Body)
+	}
+	if !passwordMatches(t, db, u.ID, "first-password") {
+		t.Error("reused token changed the password again")
+	}
+}
+
+// setVerifyToken gives the user an email verification token expiring at expires
+func setVerifyToken(t *testing.T, db *gorm.DB, u *user.User, token string, expires time.Time) {
+	t.Helper()
+	if err := db.Model(u).Updates(map[string]interface{}{"verify_token": token, "verify_expires": expires}).Error; err != nil {
+		t.Fatalf("failed to set verify token: %v", err)
+	}
+}
+
+func TestVerifyEmailRejectsExpiredToken(t *testing.T) {
+	db := newTestDB(t)
+	ac := newTestController(t, db)
+	ac.config.Auth.EmailVerifyFailureURL = "http://frontend.test/email-verification-failed"
+	r := gin.New()
+	r.GET("/auth/verify-email", ac.VerifyEmail)
+	u := testutil.CreateUser(t, db, "Unverified")
+	setVerifyToken(t, db, u, "stale-token", time.Now().Add(-time.Minute))
+
+	w := testutil.Request(t, r, http.MethodGet, "/auth/verify-email?token=stale-token", nil)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusUnauthorized, w.Body)
+	}
+	var details struct {
+		Code string `json:"code"`
+	}
+	if env := testutil.DecodeEnvelope(t, w); json.Unmarshal(env.Error.Details, &details) != nil || details.Code != "verification_token_expired" {
+		t.Errorf("response = %s, want error code verification_token_expired", w.Body)
+	}
+
+	w = testutil.Request(t, r, http.MethodGet, "/auth/verify-email?token=stale-token&redirect=true", nil)
+	if w.Code != http.StatusFound || w.Header().Get("Location") != "http://frontend.test/email-verification-failed?reason=expired" {
+		t.Errorf("redirect = %d to %q, want 302 to the failure page with reason=expired", w.Code, w.Header().Get("Location"))
+	}
+
+	var stored user.User
+	if err := db.First(&stored, u.ID).Error; err != nil {
+		t.Fatalf("failed to reload user: %v", err)
+	}
+	if stored.EmailVerified {
+		t.Error("expired token verified the email")
+	}
+}
+
+func TestVerifyEmailAcceptsValidTokenOnce(t *testing.T) {
+	db := newTestDB(t)
+	r := gin.New()
+	r.GET("/auth/verify-email", newTestController(t, db).VerifyEmail)
+	u := testutil.CreateUser(t, db, "Verifying")
+	setVerifyToken(t, db, u, "fresh-token", time.Now().Add(time.Hour))
+
+	if w := testutil.Request(t, r, http.MethodGet, "/auth/verify-email?token=fresh-token", nil); w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	var stored user.User
+	if err := db.First(&stored, u.ID).Error; err != nil {
+		t.Fatalf("failed to reload user: %v", err)
+	}
+	if !stored.EmailVerified || stored.VerifyToken != "" {
+		t.Errorf("email_verified = %v, verify_token = %q; want verified with the token cleared", stored.EmailVerified, stored.VerifyToken)
+	}
+
+	if w := testutil.Request(t, r, http.MethodGet, "/auth/verify-email?token=fresh-token", nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("reused token status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestRegisterReportsFieldErrors(t *testing.T) {
+	validator.RegisterJSONFieldNames()
+	r := gin.New()
+	r.POST("/auth/register", newTestController(t, nil).Register)
+
+	tests := []struct {
+		name string
+		body string
+		want []response.FieldError // only Field, Tag and Param are compared
+	}{
+		{
+			name: "missing fields",
+			body: `{"name":"Sam","email":"sam@example.com","password":"longenough"}`,
+			want: []response.FieldError{{Field: "username", Tag: "required"}, {Field: "phone", Tag: "required"}},
+		},
+		{
+			name: "invalid values",
+			body: `{"name":"Sam","username":"sam","email":"not-an-email","password":"short","phone":"+15550000000"}`,
+			want: []response.FieldError{{Field: "email", Tag: "email"}, {Field: "password", Tag: "min", Param: "8"}},
+		},
+		{
+			name: "wrong type",
+			body: `{"name":42}`,
+			want: []response.FieldError{{Field: "name", Tag: "type", Param: "string"}},
+		},
+		{
+			name: "malformed JSON",
+			body: `{"name":`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/auth/register", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			env := testutil.DecodeEnvelope(t, w)
+			if w.Code != http.StatusBadRequest || env.Success || env.Error.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, body = %s; want a 400 error envelope", w.Code, w.Body.String())
+			}
+			if tt.want == nil {
+				if len(env.Error.Details) != 0 {
+					t.Errorf("details = %s, want none for a payload that does not parse", env.Error.Details)
+				}
+				return
+			}
+			var got []response.FieldError
+			if err := json.Unmarshal(env.Error.Details, &got); err != nil {
+				t.Fatalf("details %s are not field errors: %v", env.Error.Details, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("details = %+v, want %+v", got, tt.want)
+			}
+			for i, fe := range got {
+				if fe.Field != tt.want[i].Field || fe.Tag != tt.want[i].Tag || fe.Param != tt.want[i].Param {
+					t.Errorf("details[%d] = %+v, want %+v", i, fe, tt.want[i])
+				}
+				if fe.Message == "" {
+					t.Errorf("details[%d] has no message", i)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyOTPRegistrationGating(t *testing.T) {
+	tests := []struct {
+		name       string
+		inviteOnly bool
+		open       bool
+		inviteCode string
+		wantCode   int
+	}{
+		{"open registration", false, true, "", http.StatusOK},
+		{"closed registration", false, false, "", http.StatusForbidden},
+		{"invite only without a code", true, false, "", http.StatusForbidden},
+		{"invite only with a valid code", true, false, "WELCOME1", http.StatusOK},
+		{"invite only with an unknown code", true, false, "NOPE", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			if err := db.Create(&user.Role{Name: DefaultUserRole}).Error; err != nil {
+				t.Fatalf("failed to create role: %v", err)
+			}
+			inviter := testutil.CreateUser(t, db, "Inviter")
+			invite := &InviteCode{Code: "WELCOME1", CreatedByID: inviter.ID, ExpiresAt: time.Now().Add(time.Hour)}
+			if err := db.Create(invite).Error; err != nil {
+				t.Fatalf("failed to create invite code: %v", err)
+			}
+			otp := createOTP(t, db, "+15550009999", "123456")
+
+			cfg := newTestConfig()
+			cfg.Auth.InviteOnly = tt.inviteOnly
+			cfg.Auth.RegistrationOpen = tt.open
+			r := gin.New()
+			r.POST("/auth/verify-otp", NewAuthController(NewAuthRepository(db), cfg).VerifyOTP)
+
+			w := testutil.Request(t, r, http.MethodPost, "/auth/verify-otp", VerifyOTPRequest{
+				Phone: otp.Phone, Code: otp.Code, InviteCode: tt.inviteCode,
+			})
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+
+			var users int64
+			if err := db.Model(&user.User{}).Where("phone = ?", otp.Phone).Count(&users).Error; err != nil {
+				t.Fatalf("failed to count users: %v", err)
+			}
+			var stored OTP
+			if err := db.First(&stored, otp.ID).Error; err != nil {
+				t.Fatalf("failed to reload OTP: %v", err)
+			}
+			if err := db.First(invite, invite.ID).Error; err != nil {
+				t.Fatalf("failed to reload invite code: %v", err)
+			}
+			if tt.wantCode != http.StatusOK {
+				if users != 0 {
+					t.Errorf("refused sign-in created %d users", users)
+				}
+				if stored.Verified {
+					t.Error("refused sign-in consumed the OTP")
+				}
+				if invite.UsedByID != nil {
+					t.Error("refused sign-in consumed the invite code")
+				}
+				return
+			}
+			if users != 1 {
+				t.Errorf("got %d users for the phone, want 1", users)
+			}
+			if redeemed := invite.UsedByID != nil; redeemed != (tt.inviteCode != "") {
+				t.Errorf("invite code redeemed = %v, want %v", redeemed, tt.inviteCode != "")
+			}
+		})
+	}
+}
+
+func TestVerifyOTPSignsInExistingUserWhileRegistrationIsClosed(t *testing.T) {
+	db := newTestDB(t)
+	u := testutil.CreateUser(t, db, "Existing")
+	otp := createOTP(t, db, u.Phone, "123456")
+
+	cfg := newTestConfig()
+	cfg.Auth.RegistrationOpen = false
+	r := gin.New()
+	r.POST("/auth/verify-otp", NewAuthController(NewAuthRepository(db), cfg).VerifyOTP)
+
+	w := testutil.Request(t, r, http.MethodPost, "/auth/verify-otp", VerifyOTPRequest{Phone: otp.Phone, Code: otp.Code})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+}
+
+func TestDeliveryStubsDoNotLogSecrets(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	ac := newTestController(t, nil)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	if err := ac.sendOTPToPhone(c, "+15550001111", "482913"); err != nil {
+		t.Fatalf("sendOTPToPhone() error = %v", err)
+	}
+	if err := ac.sendEmail(c, "sam@example.com", "Password Reset Request", "Reset: http://frontend.test/reset?token=secret-token"); err != nil {
+		t.Fatalf("sendEmail() error = %v", err)
+	}
+
+	out := logs.String()
+	for _, secret := range []string{"482913", "secret-token"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("logs contain %q:\n%s", secret, out)
+		}
+	}
+	for _, want := range []string{"+15550001111", "sam@example.com"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("logs do not mention the recipient %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/match"
+	"github.com/DhavalSuthar-24/miow/internal/sport"
+	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/internal/venue"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// metricsSeed inserts platform rows with the timestamp each metric is ranged on
+type metricsSeed struct {
+	t       *testing.T
+	db      *gorm.DB
+	ownerID uint
+	sportID uint
+	court   *venue.Ground
+}
+
+func newMetricsSeed(t *testing.T) *metricsSeed {
+	t.Helper()
+	models := append(testutil.UserModels,
+		&sport.Sport{}, &team.Team{}, &venue.Venue{}, &venue.Ground{}, &venue.Booking{},
+		&match.Challenge{}, &match.Match{}, &match.Tournament{})
+	db := testutil.DB(t, models...)
+
+	s := &metricsSeed{t: t, db: db, ownerID: testutil.CreateUser(t, db, "Owner").ID}
+	sp := &sport.Sport{Name: fmt.Sprintf("Sport %d", testutil.Seq()), IsActive: true}
+	if err := db.Create(sp).Error; err != nil {
+		t.Fatalf("failed to create sport: %v", err)
+	}
+	s.sportID = sp.ID
+	v := &venue.Venue{Name: "Venue", Location: "Test Street", Coordinates: "{}", Facilities: "[]", Images: "[]",
+		SocialHours: "{}", Available: true, Timezone: "UTC", ManagerID: s.ownerID}
+	if err := db.Omit("Manager").Create(v).Error; err != nil {
+		t.Fatalf("failed to create venue: %v", err)
+	}
+	s.court = &venue.Ground{VenueID: v.ID, Name: "Court", Type: "court"}
+	if err := db.Omit("Venue").Create(s.court).Error; err != nil {
+		t.Fatalf("failed to create court: %v", err)
+	}
+	return s
+}
+
+// set overwrites columns of a seeded row, bypassing hooks and automatic timestamps
+func (s *metricsSeed) set(model interface{}, column string, value interface{}) {
+	s.t.Helper()
+	if err := s.db.Model(model).UpdateColumn(column, value).Error; err != nil {
+		s.t.Fatalf("failed to set %s: %v", column, err)
+	}
+}
+
+func (s *metricsSeed) user(createdAt time.Time, deleted bool) {
+	s.t.Helper()
+	u := testutil.CreateUser(s.t, s.db, "User")
+	s.set(u, "created_at", createdAt)
+	if deleted {
+		s.set(u, "deleted_at", createdAt)
+	}
+}
+
+func (s *metricsSeed) team(createdAt time.Time, deleted bool) {
+	s.t.Helper()
+	tm := &team.Team{Name: fmt.Sprintf("Team %d", testutil.Seq()), CreatedByID: s.ownerID, SportID: s.sportID,
+		Requirements: "{}", Achievements: "[]", SocialLinks: "{}", MatchHistory: "[]"}
+	if err := s.db.Omit("Sport").Create(tm).Error; err != nil {
+		s.t.Fatalf("failed to create team: %v", err)
+	}
+	s.set(tm, "created_at", createdAt)
+	if deleted {
+		s.set(tm, "deleted_at", createdAt)
+	}
+}
+
+func (s *metricsSeed) match(scheduledAt time.Time, status match.MatchStatus, deleted bool) {
+	s.t.Helper()
+	m := &match.Match{CreatedByUserID: s.ownerID, SportID: s.sportID, ScheduledAt: scheduledAt, CustomRules: "{}", Status: status}
+	if err := s.db.Omit("CreatedByUser", "Sport", "Venue", "Challenge", "TossWinnerTeam", "WinningTeam", "ManOfTheMatch", "MatchTeams").
+		Create(m).Error; err != nil {
+		s.t.Fatalf("failed to create match: %v", err)
+	}
+	if deleted {
+		s.set(m, "deleted_at", scheduledAt)
+	}
+}
+
+func (s *metricsSeed) tournament(createdAt time.Time, status string) {
+	s.t.Helper()
+	tr := &match.Tournament{Name: fmt.Sprintf("Tournament %d", testutil.Seq()), SportID: s.sportID, CreatedByUserID: s.ownerID,
+		StartDate: createdAt.Add(7 * 24 * time.Hour), Status: status, FormatDetails: "{}", Bracket: "{}"}
+	if err := s.db.Omit("CreatedByUser", "Sport", "Teams", "Matches").Create(tr).Error; err != nil {
+		s.t.Fatalf("failed to create tournament: %v", err)
+	}
+	s.set(tr, "created_at", createdAt)
+}
+
+func (s *metricsSeed) booking(start time.Time, status string) {
+	s.t.Helper()
+	b := &venue.Booking{GroundID: s.court.ID, UserID: s.ownerID, StartTime: start, EndTime: start.Add(time.Hour), Status: status}
+	if err := s.db.Omit("Ground").Create(b).Error; err != nil {
+		s.t.Fatalf("failed to create booking: %v", err)
+	}
+}
+
+// getMetrics requests the dashboard metrics with the query string
+func getMetrics(t *testing.T, db *gorm.DB, query string) (int, PlatformMetrics) {
+	t.Helper()
+	r := gin.New()
+	r.GET("/admin/metrics", newTestController(t, db).GetPlatformMetrics)
+	w := testutil.Request(t, r, http.MethodGet, "/admin/metrics"+query, nil)
+	var metrics PlatformMetrics
+	if w.Code == http.StatusOK {
+		testutil.DecodeData(t, w, &metrics)
+	}
+	return w.Code, metrics
+}
+
+// assertCounts compares status counts, treating a missing status as zero
+func assertCounts(t *testing.T, name string, got, want map[string]int64) {
+	t.Helper()
+	for status, count := range want {
+		if got[status] != count {
+			t.Errorf("%s[%s] = %d, want %d (all: %v)", name, status, got[status], count, got)
+		}
+	}
+	for status, count := range got {
+		if _, ok := want[status]; !ok && count != 0 {
+			t.Errorf("%s[%s] = %d, want none", name, status, count)
+		}
+	}
+}
+
+func TestGetPlatformMetrics(t *testing.T) {
+	s := newMetricsSeed(t)
+
+	// Everything in January 2020 is inside the range; the owner and other rows are not
+	in := time.Date(2020, 1, 15, 10, 0, 0, 0, time.UTC)
+	before := time.Date(2019, 12, 31, 23, 0, 0, 0, time.UTC)
+	after := time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC) // The end of the range is exclusive
+
+	s.user(in, false)
+	s.user(in, false)
+	s.user(in, true)
+	s.user(before, false)
+
+	s.team(in, false)
+	s.team(in, true)
+	s.team(after, false)
+
+	s.match(in, match.StatusMatchUpcoming, false)
+	s.match(in, match.StatusMatchUpcoming, false)
+	s.match(in, match.StatusMatchLive, false)
+	s.match(in, match.StatusMatchPreToss, false)
+	s.match(in, match.StatusMatchCompleted, false)
+	s.match(in, match.StatusMatchCancelled, false)
+	s.match(in, match.StatusMatchUpcoming, true)
+	s.match(after, match.StatusMatchUpcoming, false)
+
+	s.tournament(in, match.TournamentStatusRegistrationOpen)
+	s.tournament(in, match.TournamentStatusOngoing)
+	s.tournament(before, match.TournamentStatusOngoing)
+
+	s.booking(in, "confirmed")
+	s.booking(in, "confirmed")
+	s.booking(in, "pending")
+	s.booking(after, "confirmed")
+
+	status, metrics := getMetrics(t, s.db, "?from=2020-01-01&to=2020-02-01")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want %d", status, http.StatusOK)
+	}
+	if metrics.From == nil || metrics.To == nil {
+		t.Errorf("range = %v to %v, want both ends echoed", metrics.From, metrics.To)
+	}
+	if metrics.Users != 2 || metrics.Teams != 1 {
+		t.Errorf("users = %d, teams = %d; want 2 and 1", metrics.Users, metrics.Teams)
+	}
+	if metrics.Matches.Active != 4 || metrics.Matches.Upcoming != 2 || metrics.Matches.Live != 1 {
+		t.Errorf("matches = %+v, want 4 active, 2 upcoming and 1 live", metrics.Matches)
+	}
+	assertCounts(t, "matches", metrics.Matches.ByStatus, map[string]int64{
+		"upcoming": 2, "live": 1, "pre_toss": 1, "completed": 1, "cancelled": 1,
+	})
+	assertCounts(t, "tournaments", metrics.TournamentsByStatus, map[string]int64{
+		match.TournamentStatusRegistrationOpen: 1, match.TournamentStatusOngoing: 1,
+	})
+	assertCounts(t, "bookings", metrics.BookingsByStatus, map[string]int64{"confirmed": 2, "pending": 1})
+
+	// Without a range every row that is not deleted counts, including the owner
+	status, metrics = getMetrics(t, s.db, "")
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, want %d", status, http.StatusOK)
+	}
+	if metrics.From != nil || metrics.To != nil {
+		t.Errorf("range = %v to %v, want it open", metrics.From, metrics.To)
+	}
+	if metrics.Users != 4 || metrics.Teams != 2 || metrics.Matches.Upcoming != 3 {
+		t.Errorf("metrics = %+v, want 4 users, 2 teams and 3 upcoming matches", metrics)
+	}
+	assertCounts(t, "bookings", metrics.BookingsByStatus, map[string]int64{"confirmed": 3, "pending": 1})
+}
+
+func TestGetPlatformMetricsRejectsInvalidRange(t *testing.T) {
+	db := newMetricsSeed(t).db
+	for _, query := range []string{"?from=yesterday", "?to=2020-13-01", "?from=2020-02-01&to=2020-01-01", "?from=2020-01-01&to=2020-01-01"} {
+		if status, _ := getMetrics(t, db, query); status != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want %d", query, status, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// EnsureAuditLogIndexes adds the indexes the admin audit log filters on that cannot be
+// declared on the embedded gorm.Model fields. It is safe to run on every startup.
+func EnsureAuditLogIndexes(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&ImpersonationLog{}) {
+		return nil
+	}
+
+	if err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_impersonation_logs_created_at
+		ON impersonation_logs (created_at DESC)`).Error; err != nil {
+		return fmt.Errorf("failed to create impersonation log date index: %w", err)
+	}
+	return nil
+}
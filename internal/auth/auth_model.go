@@ -18,6 +18,98 @@ type OTP struct {
 	Attempt   int       `gorm:"default:0"`
 }
 
+// InviteCode is a single-use code that allows registration while the
+// platform is in invite-only mode.
+type InviteCode struct {
+	gorm.Model
+	Code        string     `json:"code" gorm:"uniqueIndex;not null"`
+	CreatedByID uint       `json:"created_by_id" gorm:"index"`
+	ExpiresAt   time.Time  `json:"expires_at" gorm:"not null"`
+	UsedByID    *uint      `json:"used_by_id,omitempty" gorm:"index"`
+	UsedAt      *time.Time `json:"used_at,omitempty"`
+}
+
+// ImpersonationLog is the audit record written whenever an admin issues an
+// impersonation token for another user.
+type ImpersonationLog struct {
+	gorm.Model
+	AdminID   uint      `json:"admin_id" gorm:"index;not null"`
+	UserID    uint      `json:"user_id" gorm:"index;not null"`
+	Reason    string    `json:"reason" gorm:"type:text"`
+	IPAddress string    `json:"ip_address" gorm:"size:45"`
+	UserAgent string    `json:"user_agent" gorm:"size:512"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
+}
+
+// AuditActionImpersonate is the audit log action recorded for each ImpersonationLog
+const AuditActionImpersonate = "impersonate"
+
+// AuditLogEntry is one record of the admin audit log
+type AuditLogEntry struct {
+	ID           uint      `json:"id"`
+	Action       string    `json:"action" example:"impersonate"`
+	ActorID      uint      `json:"actor_id"`
+	TargetUserID uint      `json:"target_user_id"`
+	Reason       string    `json:"reason,omitempty"`
+	IPAddress    string    `json:"ip_address,omitempty"`
+	UserAgent    string    `json:"user_agent,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// AuditLogFilter narrows an audit log listing; zero values are ignored
+type AuditLogFilter struct {
+	ActorID      uint
+	TargetUserID uint
+	From         time.Time
+	To           time.Time
+}
+
+// PlatformMetrics is the admin dashboard summary. Users, teams and tournaments are counted by
+// creation time, matches by scheduled time and bookings by start time; without a range the
+// counts cover all time.
+type PlatformMetrics struct {
+	From                *time.Time       `json:"from,omitempty"`
+	To                  *time.Time       `json:"to,omitempty"`
+	Users               int64            `json:"users"`
+	Teams               int64            `json:"teams"`
+	Matches             MatchMetrics     `json:"matches"`
+	TournamentsByStatus map[string]int64 `json:"tournaments_by_status"`
+	BookingsByStatus    map[string]int64 `json:"bookings_by_status"`
+}
+
+// MatchMetrics summarises matches by lifecycle stage. Active counts every match that has not
+// reached a final status, which includes upcoming and live ones.
+type MatchMetrics struct {
+	Active   int64            `json:"active"`
+	Upcoming int64            `json:"upcoming"`
+	Live     int64            `json:"live"`
+	ByStatus map[string]int64 `json:"by_status"`
+}
+
+// finalMatchStatuses are the match statuses that are not counted as active. The match
+// package owns these values but cannot be imported from here.
+var finalMatchStatuses = map[string]bool{
+	"completed": true,
+	"cancelled": true,
+	"forfeited": true,
+	"abandoned": true,
+}
+
+func newImpersonationAuditEntry(entry *ImpersonationLog) AuditLogEntry {
+	return AuditLogEntry{
+		ID:           entry.ID,
+		Action:       AuditActionImpersonate,
+		ActorID:      entry.AdminID,
+		TargetUserID: entry.UserID,
+		Reason:       entry.Reason,
+		IPAddress:    entry.IPAddress,
+		UserAgent:    entry.UserAgent,
+		ExpiresAt:    entry.ExpiresAt,
+		CreatedAt:    entry.CreatedAt,
+	}
+}
+
 type LoginRequest struct {
 	LoginIdentifier string `json:"login_identifier" binding:"required" example:"john@example.com"` // Can be email or username
 	Password        string `json:"password" binding:"required" example:"password123"`
@@ -30,6 +122,8 @@ type OTPRequest struct {
 type VerifyOTPRequest struct {
 	Phone string `json:"phone" binding:"required,e164" example:"+919876543210"`
 	Code  string `json:"code" binding:"required,len=6" example:"123456"` // Assuming 6 digit OTP
+	// InviteCode lets a new phone number register while registration is invite-only
+	InviteCode string `json:"invite_code,omitempty"`
 }
 
 type RefreshTokenRequest struct {
@@ -67,6 +161,7 @@ type UpdateProfileRequest struct {
 	PreferredSports []string            `json:"preferred_sports,omitempty"`
 	Coordinates     *models.Coordinates `json:"coordinates,omitempty"`
 	SocialMedia     *models.SocialMedia `json:"social_media,omitempty"`
+	ProfilePrivate  *bool               `json:"profile_private,omitempty" example:"false"`
 }
 
 type UpdateProfileImageRequest struct {
@@ -102,6 +197,12 @@ type RegisterRequest struct {
 	PreferredSports []string            `json:"preferred_sports,omitempty"`
 	SocialMedia     *models.SocialMedia `json:"social_media,omitempty"`
 	Coordinates     *models.Coordinates `json:"coordinates,omitempty"`
+	InviteCode      string              `json:"invite_code,omitempty"`
+}
+
+type CreateInviteCodesRequest struct {
+	Count       int `json:"count" binding:"omitempty,min=1,max=100" example:"5"`
+	ExpiryHours int `json:"expiry_hours" binding:"omitempty,min=1" example:"168"`
 }
 
 type UserResponse struct {
@@ -125,11 +226,63 @@ type UserResponse struct {
 	Coordinates     models.Coordinates `json:"coordinates"`
 	PreferredSports []string           `json:"preferred_sports"`
 	SocialMedia     models.SocialMedia `json:"social_media"`
+	ProfilePrivate  bool               `json:"profile_private"`
 	Roles           []string           `json:"roles"`
 	CreatedAt       time.Time          `json:"created_at"`
 	UpdatedAt       time.Time          `json:"updated_at"`
 }
 
+// PublicUserResponse is the part of a user's profile visible to other users. Contact
+// details, address and location are never included. For private profiles only the
+// identity fields are filled in and Private is set.
+type PublicUserResponse struct {
+	ID              uint               `json:"id"`
+	Name            string             `json:"name"`
+	Username        string             `json:"username"`
+	ProfileImage    string             `json:"profile_image"`
+	Private         bool               `json:"private"`
+	Verified        bool               `json:"verified"`
+	City            string             `json:"city"`
+	State           string             `json:"state"`
+	Country         string             `json:"country"`
+	Bio             string             `json:"bio"`
+	PreferredSports []string           `json:"preferred_sports"`
+	SocialMedia     models.SocialMedia `json:"social_media"`
+	Sports          []PublicUserSport  `json:"sports,omitempty"`
+	CreatedAt       time.Time          `json:"created_at"`
+}
+
+// PublicUserSport is a sport on a user's public profile with their self-declared level
+type PublicUserSport struct {
+	SportID  uint   `json:"sport_id"`
+	Name     string `json:"name"`
+	Position string `json:"position,omitempty"`
+	Level    string `json:"level,omitempty"`
+	// Endorsements counts skill endorsements from users who shared a completed match with this user
+	Endorsements int64 `json:"endorsements"`
+}
+
+// Reasons an OTP request is refused
+const (
+	OTPErrorThrottledShort = "otp_throttled_short" // An OTP was sent moments ago
+	OTPErrorCooldown       = "otp_cooldown"        // Too many OTPs were requested; wait for the cooldown
+)
+
+// OTPThrottleDetails tells the client why an OTP request was refused and how long to wait
+// before retrying, so it can show the right message and countdown
+type OTPThrottleDetails struct {
+	Code              string `json:"code" example:"otp_throttled_short"`
+	RetryAfterSeconds int    `json:"retry_after_seconds" example:"42"`
+}
+
+// AvailabilityResponse reports whether each requested identifier is free to register.
+// Only the identifiers present in the query are included.
+type AvailabilityResponse struct {
+	Username *bool `json:"username,omitempty"`
+	Email    *bool `json:"email,omitempty"`
+	Phone    *bool `json:"phone,omitempty"`
+}
+
 type LogoutRequest struct {
 	RefreshToken          string `json:"refresh_token"`           // Optional: specific token to invalidate
 	InvalidateAllSessions bool   `json:"invalidate_all_sessions"` // If true, invalidate all user's sessions
@@ -162,8 +315,52 @@ func FilterUserRecord(user *user.User) UserResponse {
 		Coordinates:     user.Coordinates,
 		PreferredSports: user.PreferredSports,
 		SocialMedia:     user.SocialMedia,
+		ProfilePrivate:  user.ProfilePrivate,
 		Roles:           roles,
 		CreatedAt:       user.CreatedAt,
 		UpdatedAt:       user.UpdatedAt,
 	}
 }
+
+// FilterPublicUserRecord strips a user down to their public profile. A private profile keeps
+// only the fields needed to recognise the user.
+func FilterPublicUserRecord(user *user.User) PublicUserResponse {
+	if user.ProfilePrivate {
+		return PublicUserResponse{
+			ID:           user.ID,
+			Name:         user.Name,
+			Username:     user.Username,
+			ProfileImage: user.ProfileImage,
+			Private:      true,
+		}
+	}
+	return PublicUserResponse{
+		ID:              user.ID,
+		Name:            user.Name,
+		Username:        user.Username,
+		ProfileImage:    user.ProfileImage,
+		Verified:        user.Verified,
+		City:            user.City,
+		State:           user.State,
+		Country:         user.Country,
+		Bio:             user.Bio,
+		PreferredSports: user.PreferredSports,
+		SocialMedia:     user.SocialMedia,
+		CreatedAt:       user.CreatedAt,
+	}
+}
+
+// ImpersonateRequest optionally records why support is impersonating the user.
+type ImpersonateRequest struct {
+	Reason string `json:"reason,omitempty" binding:"omitempty,max=500"`
+}
+
+// ImpersonationResponse is returned when an impersonation token is issued.
+// There is deliberately no refresh token.
+type ImpersonationResponse struct {
+	AccessToken    string    `json:"access_token"`
+	TokenType      string    `json:"token_type"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	UserID         uint      `json:"user_id"`
+	ImpersonatedBy uint      `json:"impersonated_by"`
+}
@@ -0,0 +1,230 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/internal/sport"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/internal/user"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// addUserSport records that the user plays a new sport at the level
+func addUserSport(t *testing.T, db *gorm.DB, userID uint, level string) *sport.Sport {
+	t.Helper()
+	s := &sport.Sport{Name: fmt.Sprintf("Sport %d", testutil.Seq()), IsActive: true}
+	if err := db.Omit("Rules", "Positions", "Equipment").Create(s).Error; err != nil {
+		t.Fatalf("failed to create sport: %v", err)
+	}
+	if err := db.Omit("Sport").Create(&sport.UserSport{UserID: userID, SportID: s.ID, Level: level}).Error; err != nil {
+		t.Fatalf("failed to add user sport: %v", err)
+	}
+	return s
+}
+
+// makePrivate hides the user's public profile
+func makePrivate(t *testing.T, db *gorm.DB, u *user.User) {
+	t.Helper()
+	if err := db.Model(u).Update("profile_private", true).Error; err != nil {
+		t.Fatalf("failed to make profile private: %v", err)
+	}
+}
+
+// getPublicProfile requests a profile as the requester, who holds roles
+func getPublicProfile(ac *AuthController, requesterID uint, roles []string, path string) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.GET("/users/:id", asUser(requesterID), func(c *gin.Context) {
+		c.Set(middleware.UserRolesKey, roles)
+	}, ac.GetPublicProfile)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+func TestGetPublicProfile(t *testing.T) {
+	db := newTestDB(t)
+	ac := newTestController(t, db)
+	viewer := testutil.CreateUser(t, db, "Viewer")
+	player := testutil.CreateUser(t, db, "Player")
+	if err := db.Model(player).Updates(map[string]interface{}{"bio": "Left-arm spinner", "city": "Pune"}).Error; err != nil {
+		t.Fatalf("failed to update player: %v", err)
+	}
+	played := addUserSport(t, db, player.ID, "Advanced")
+
+	w := getPublicProfile(ac, viewer.ID, nil, "/users/"+fmt.Sprint(player.ID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	if body := w.Body.String(); strings.Contains(body, player.Email) || strings.Contains(body, player.Phone) {
+		t.Errorf("public profile exposes contact details: %s", body)
+	}
+	var profile PublicUserResponse
+	testutil.DecodeData(t, w, &profile)
+	if profile.Private || profile.Username != player.Username || profile.Bio != "Left-arm spinner" || profile.City != "Pune" {
+		t.Errorf("profile = %+v, want the player's public fields", profile)
+	}
+	if len(profile.Sports) != 1 || profile.Sports[0].SportID != played.ID || profile.Sports[0].Level != "Advanced" {
+		t.Errorf("sports = %+v, want %s at Advanced", profile.Sports, played.Name)
+	}
+}
+
+func TestGetPublicProfileHidden(t *testing.T) {
+	db := newTestDB(t)
+	ac := newTestController(t, db)
+	viewer := testutil.CreateUser(t, db, "Viewer")
+	admin := testutil.CreateUser(t, db, "Admin")
+	player := testutil.CreateUser(t, db, "Player")
+	if err := db.Model(player).Update("bio", "Left-arm spinner").Error; err != nil {
+		t.Fatalf("failed to update player: %v", err)
+	}
+	addUserSport(t, db, player.ID, "Advanced")
+	makePrivate(t, db, player)
+	path := "/users/" + fmt.Sprint(player.ID)
+
+	var hidden PublicUserResponse
+	testutil.DecodeData(t, getPublicProfile(ac, viewer.ID, nil, path), &hidden)
+	if !hidden.Private || hidden.Username != player.Username || hidden.Bio != "" || len(hidden.Sports) != 0 {
+		t.Errorf("private profile shown to another user = %+v, want only the name, username and image", hidden)
+	}
+
+	for name, requester := range map[string]struct {
+		id    uint
+		roles []string
+	}{
+		"owner": {player.ID, nil},
+		"admin": {admin.ID, []string{"Admin"}},
+	} {
+		var full PublicUserResponse
+		testutil.DecodeData(t, getPublicProfile(ac, requester.id, requester.roles, path), &full)
+		if full.Private || full.Bio != "Left-arm spinner" || len(full.Sports) != 1 {
+			t.Errorf("private profile shown to the %s = %+v, want the full public profile", name, full)
+		}
+	}
+}
+
+func TestGetPublicProfileErrors(t *testing.T) {
+	db := newTestDB(t)
+	ac := newTestController(t, db)
+	viewer := testutil.CreateUser(t, db, "Viewer")
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/users/" + fmt.Sprint(viewer.ID+1000), http.StatusNotFound},
+		{"/users/0", http.StatusBadRequest},
+		{"/users/abc", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		if w := getPublicProfile(ac, viewer.ID, nil, tt.path); w.Code != tt.status {
+			t.Errorf("GET %s status = %d, want %d", tt.path, w.Code, tt.status)
+		}
+	}
+}
+
+// searchUsers runs a user search as the requester
+func searchUsers(t *testing.T, ac *AuthController, requesterID uint, query string) *httptest.ResponseRecorder {
+	t.Helper()
+	r := gin.New()
+	r.GET("/users/search", asUser(requesterID), ac.SearchUsers)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/search?"+query, nil))
+	return w
+}
+
+// searchUsernames decodes a successful search into the usernames found and the total count
+func searchUsernames(t *testing.T, w *httptest.ResponseRecorder) ([]string, int64) {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	var page struct {
+		Items      []PublicUserResponse `json:"items"`
+		Pagination struct {
+			TotalItems int64 `json:"total_items"`
+		} `json:"pagination"`
+	}
+	testutil.DecodeData(t, w, &page)
+	names := make([]string, 0, len(page.Items))
+	for _, p := range page.Items {
+		names = append(names, p.Name)
+	}
+	return names, page.Pagination.TotalItems
+}
+
+func TestSearchUsers(t *testing.T) {
+	db := newTestDB(t)
+	ac := newTestController(t, db)
+
+	caller := testutil.CreateUser(t, db, "Striker Self")
+	advanced := testutil.CreateUser(t, db, "Striker Advanced")
+	beginner := testutil.CreateUser(t, db, "Striker Beginner")
+	hidden := testutil.CreateUser(t, db, "Striker Hidden")
+	testutil.CreateUser(t, db, "Goalkeeper")
+	makePrivate(t, db, hidden)
+
+	football := addUserSport(t, db, advanced.ID, "Advanced")
+	if err := db.Create(&sport.UserSport{UserID: beginner.ID, SportID: football.ID, Level: "Beginner"}).Error; err != nil {
+		t.Fatalf("failed to add user sport: %v", err)
+	}
+	if err := db.Create(&sport.UserSport{UserID: caller.ID, SportID: football.ID, Level: "Advanced"}).Error; err != nil {
+		t.Fatalf("failed to add user sport: %v", err)
+	}
+	addUserSport(t, db, hidden.ID, "Advanced")
+	sportName := url.QueryEscape(strings.ToUpper(football.Name))
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"by name", "q=striker", []string{"Striker Advanced", "Striker Beginner"}},
+		{"by username", "q=" + advanced.Username, []string{"Striker Advanced"}},
+		{"by sport", "q=striker&sport=" + sportName, []string{"Striker Advanced", "Striker Beginner"}},
+		{"by sport and skill", "q=striker&sport=" + sportName + "&skill=advanced", []string{"Striker Advanced"}},
+		{"by skill", "q=striker&skill=BEGINNER", []string{"Striker Beginner"}},
+		{"wildcards are literal", "q=%25%25", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, total := searchUsernames(t, searchUsers(t, ac, caller.ID, tt.query))
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") || total != int64(len(tt.want)) {
+				t.Errorf("search %q = %v (total %d), want %v", tt.query, got, total, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchUsersPaginates(t *testing.T) {
+	db := newTestDB(t)
+	ac := newTestController(t, db)
+	caller := testutil.CreateUser(t, db, "Caller")
+	for i := 0; i < 3; i++ {
+		testutil.CreateUser(t, db, "Keeper")
+	}
+
+	first, total := searchUsernames(t, searchUsers(t, ac, caller.ID, "q=keeper&limit=2"))
+	second, _ := searchUsernames(t, searchUsers(t, ac, caller.ID, "q=keeper&limit=2&page=2"))
+	if len(first) != 2 || len(second) != 1 || total != 3 {
+		t.Errorf("pages hold %d and %d users of %d, want 2 and 1 of 3", len(first), len(second), total)
+	}
+}
+
+func TestSearchUsersRejectsShortQueries(t *testing.T) {
+	db := newTestDB(t)
+	ac := newTestController(t, db)
+	caller := testutil.CreateUser(t, db, "Caller")
+
+	for _, query := range []string{"", "q=a", "q=%20%20a%20"} {
+		if w := searchUsers(t, ac, caller.ID, query); w.Code != http.StatusBadRequest {
+			t.Errorf("search %q status = %d, want %d", query, w.Code, http.StatusBadRequest)
+		}
+	}
+}
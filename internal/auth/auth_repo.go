@@ -1,12 +1,17 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
 	"github.com/DhavalSuthar-24/miow/internal/user"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
 )
 
 type AuthRepository interface {
@@ -16,13 +21,20 @@ type AuthRepository interface {
 	GetUserByID(id uint) (*user.User, error)
 	UpdateUser(u *user.User) error
 	GetUserByResetToken(token string) (*user.User, error)
+	ResetPasswordWithToken(token, hashedPassword string) error
 	GetUserByVerifyToken(token string) (*user.User, error)
 	GetUserByUsername(username string) (*user.User, error)
+	GetUsersByIDs(ids []uint) ([]user.User, error)
+	GetSportsByNames(names []string) (map[string]SportRef, error)
+	AddUserSports(userID uint, sportIDs []uint, level string) error
+	GetUserSports(userID uint) ([]PublicUserSport, error)
+	SearchUsers(filter UserSearchFilter, page, limit int) ([]user.User, int64, error)
 
 	SaveOTP(otp *OTP) error
 	GetOTP(phone, code string) (*OTP, error)
 	UpdateOTP(otp *OTP) error
 	GetLatestOTP(phone string) (*OTP, error)
+	VerifyOTP(phone, code string, newUser *user.User, role, inviteCode string) (*user.User, bool, error)
 
 	SaveRefreshToken(token *user.RefreshToken) error
 	GetRefreshToken(tokenString string) (*user.RefreshToken, error)
@@ -34,8 +46,29 @@ type AuthRepository interface {
 	AssignRoleToUser(userID uint, role string) error
 	GetUserRoles(userID uint) ([]string, error)
 	RemoveRoleFromUser(userID uint, role string) error
+
+	CreateInviteCodes(codes []InviteCode) error
+	CreateImpersonationLog(entry *ImpersonationLog) error
+	GetImpersonationLogs(filter AuditLogFilter, page, limit int) ([]ImpersonationLog, int64, error)
+	CreateUserWithInviteCode(u *user.User, code string) error
+	GetPlatformMetrics(from, to time.Time) (*PlatformMetrics, error)
+	Ping(ctx context.Context) error
 }
 
+// ErrInvalidInviteCode is returned when an invite code does not exist, has expired or was already used.
+var ErrInvalidInviteCode = errors.New("invalid, expired or already used invite code")
+
+var ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+
+var ErrVerifyTokenExpired = errors.New("email verification token has expired")
+
+// ErrInvalidOTP is returned when an OTP does not exist, has expired or was already used.
+var ErrInvalidOTP = errors.New("invalid, expired or already used OTP")
+
+// ErrOTPRegistrationRefused is returned when an OTP is verified for an unregistered phone
+// number but no new user may be created for it.
+var ErrOTPRegistrationRefused = errors.New("phone number is not registered")
+
 type authRepository struct {
 	db *gorm.DB
 }
@@ -72,6 +105,78 @@ func (r *authRepository) GetUserByID(id uint) (*user.User, error) {
 	return &u, nil
 }
 
+// GetUsersByIDs loads the users with the given IDs in one query. Unknown IDs are skipped.
+func (r *authRepository) GetUsersByIDs(ids []uint) ([]user.User, error) {
+	var users []user.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	if err := r.db.Where("id IN ?", ids).Order("id asc").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// SportRef is the slice of a sport row registration needs
+type SportRef struct {
+	ID   uint
+	Name string
+}
+
+// userSportRow mirrors the columns of sport.UserSport that registration fills in.
+type userSportRow struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt time.Time
+	UserID    uint
+	SportID   uint
+	Level     string
+}
+
+func (userSportRow) TableName() string { return "user_sports" }
+
+// GetSportsByNames resolves active sports by name, case-insensitively. The result is keyed
+// by the lowercased name; names that match no sport are absent.
+func (r *authRepository) GetSportsByNames(names []string) (map[string]SportRef, error) {
+	result := make(map[string]SportRef)
+	lowered := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			lowered = append(lowered, name)
+		}
+	}
+	if len(lowered) == 0 {
+		return result, nil
+	}
+	var sports []SportRef
+	if err := r.db.Table("sports").Select("id, name").
+		Where("LOWER(name) IN ? AND is_active = ?", lowered, true).
+		Scan(&sports).Error; err != nil {
+		return nil, err
+	}
+	for _, s := range sports {
+		result[strings.ToLower(s.Name)] = s
+	}
+	return result, nil
+}
+
+// AddUserSports links the user to each sport at the given level. Sports the user is already
+// linked to are left untouched.
+func (r *authRepository) AddUserSports(userID uint, sportIDs []uint, level string) error {
+	if len(sportIDs) == 0 {
+		return nil
+	}
+	rows := make([]userSportRow, 0, len(sportIDs))
+	for _, id := range sportIDs {
+		rows = append(rows, userSportRow{UserID: userID, SportID: id, Level: level})
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "sport_id"}},
+		DoNothing: true,
+	}).Create(&rows).Error
+}
+
 func (r *authRepository) UpdateUser(u *user.User) error {
 	return r.db.Save(u).Error
 }
@@ -92,11 +197,44 @@ func (r *authRepository) GetUserByResetToken(token string) (*user.User, error) {
 	return &u, nil
 }
 
+// ResetPasswordWithToken sets the new password and clears the reset token in a single
+// conditional update, so an expired token is rejected and a token can only be redeemed
+// once even when two requests race with it.
+func (r *authRepository) ResetPasswordWithToken(token, hashedPassword string) error {
+	if token == "" {
+		return ErrInvalidResetToken
+	}
+	now := time.Now()
+	result := r.db.Model(&user.User{}).
+		Where("reset_token = ? AND reset_expires > ?", token, now).
+		Updates(map[string]interface{}{
+			"password":      hashedPassword,
+			"reset_token":   "",
+			"reset_expires": nil,
+			"last_active":   now,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to reset password: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvalidResetToken
+	}
+	return nil
+}
+
+// GetUserByVerifyToken returns gorm.ErrRecordNotFound for unknown tokens and
+// ErrVerifyTokenExpired for tokens past their verify_expires deadline.
 func (r *authRepository) GetUserByVerifyToken(token string) (*user.User, error) {
 	var u user.User
-	if err := r.db.Where("verify_token = ? AND verify_expires > ?", token, time.Now()).First(&u).Error; err != nil {
+	if token == "" {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if err := r.db.Where("verify_token = ?", token).First(&u).Error; err != nil {
 		return nil, err
 	}
+	if u.VerifyExpires == nil || !u.VerifyExpires.After(time.Now()) {
+		return nil, ErrVerifyTokenExpired
+	}
 	return &u, nil
 }
 
@@ -124,6 +262,76 @@ func (r *authRepository) GetLatestOTP(phone string) (*OTP, error) {
 	return &otp, nil
 }
 
+// VerifyOTP consumes the OTP and resolves the user with the phone number in a single
+// transaction. The OTP row is locked, so of two concurrent verifications of the same code only
+// one succeeds and the other gets ErrInvalidOTP. When no user has the phone number, newUser is
+// inserted with the role; the insert does nothing if a user with the phone appeared meanwhile,
+// so a phone never registers twice. If the role cannot be assigned nothing is committed, and
+// the OTP can be tried again. The returned bool reports whether newUser was created.
+//
+// A nil newUser only signs in existing users; an unknown phone gets ErrOTPRegistrationRefused.
+// With an inviteCode, newUser is only created if the code can be redeemed, in the same
+// transaction; otherwise ErrInvalidInviteCode is returned. In both cases the OTP stays unused.
+func (r *authRepository) VerifyOTP(phone, code string, newUser *user.User, role, inviteCode string) (*user.User, bool, error) {
+	var u user.User
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var otp OTP
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("phone = ? AND code = ? AND expires_at > ? AND verified = ?", phone, code, time.Now(), false).
+			First(&otp).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvalidOTP
+			}
+			return err
+		}
+		if err := tx.Model(&otp).Update("verified", true).Error; err != nil {
+			return fmt.Errorf("failed to update OTP: %w", err)
+		}
+
+		if newUser == nil {
+			if err := tx.Where("phone = ?", phone).First(&u).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return ErrOTPRegistrationRefused
+				}
+				return err
+			}
+		} else {
+			result := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "phone"}},
+				DoNothing: true,
+			}).Create(newUser)
+			if result.Error != nil {
+				return fmt.Errorf("failed to create user: %w", result.Error)
+			}
+			if result.RowsAffected == 1 {
+				created = true
+				u = *newUser
+				if inviteCode != "" {
+					if err := redeemInviteCode(tx, inviteCode, u.ID); err != nil {
+						return err
+					}
+				}
+				return assignRole(tx, u.ID, role)
+			}
+
+			if err := tx.Where("phone = ?", phone).First(&u).Error; err != nil {
+				return err
+			}
+		}
+		// Verified becomes true only if the email was already verified
+		return tx.Model(&u).Updates(map[string]interface{}{
+			"phone_verified": true,
+			"verified":       u.EmailVerified,
+			"last_active":    time.Now(),
+		}).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return &u, created, nil
+}
+
 func (r *authRepository) SaveRefreshToken(token *user.RefreshToken) error {
 	return r.db.Create(token).Error
 }
@@ -165,57 +373,47 @@ func (r *authRepository) GetRoleByName(roleName string) (*user.Role, error) {
 }
 
 func (r *authRepository) AssignRoleToUser(userID uint, roleName string) error {
-	var role user.Role
-	var existingUserRole user.UserRole
-	userRole := user.UserRole{
-		UserID: userID,
-		RoleID: role.ID,
-	}
-	tx := r.db.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var u user.User
+		if err := tx.First(&u, userID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("user not found")
+			}
+			return fmt.Errorf("failed to find user: %w", err)
 		}
-	}()
-
-	var user user.User
-	if err := tx.First(&user, userID).Error; err != nil {
-		tx.Rollback()
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return fmt.Errorf("user not found")
-		}
-		return fmt.Errorf("failed to find user: %w", err)
+		return assignRole(tx, userID, roleName)
+	})
+	if err != nil {
+		return err
 	}
+	middleware.InvalidateUserRoles(userID)
+
+	return nil
+}
 
+// assignRole gives the user the named role within tx, unless they already have it
+func assignRole(tx *gorm.DB, userID uint, roleName string) error {
+	var role user.Role
 	if err := tx.Where("name = ?", roleName).First(&role).Error; err != nil {
-		tx.Rollback()
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("role '%s' not found", roleName)
 		}
 		return fmt.Errorf("failed to find role: %w", err)
 	}
-	fmt.Print(role.ID)
 
+	var existingUserRole user.UserRole
 	if err := tx.Where("user_id = ? AND role_id = ?", userID, role.ID).First(&existingUserRole).Error; err == nil {
-		tx.Rollback()
 		return nil // User already has this role, no error but no action needed
 	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
-		tx.Rollback()
 		return fmt.Errorf("failed to check existing user role: %w", err)
 	}
 
-	if err := tx.Create(&userRole).Error; err != nil {
-		tx.Rollback()
+	if err := tx.Create(&user.UserRole{UserID: userID, RoleID: role.ID}).Error; err != nil {
 		return fmt.Errorf("failed to assign role to user: %w", err)
 	}
-
-	// Commit the transaction
-	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
 	return nil
 }
+
 func (r *authRepository) GetUserRoles(userID uint) ([]string, error) {
 	var roles []string
 	err := r.db.Model(&user.UserRole{}).
@@ -258,6 +456,217 @@ func (r *authRepository) RemoveRoleFromUser(userID uint, roleName string) error
 	if err := tx.Commit().Error; err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
+	middleware.InvalidateUserRoles(userID)
+
+	return nil
+}
+
+func (r *authRepository) CreateInviteCodes(codes []InviteCode) error {
+	if err := r.db.Create(&codes).Error; err != nil {
+		return fmt.Errorf("failed to create invite codes: %w", err)
+	}
+	return nil
+}
+
+func (r *authRepository) CreateImpersonationLog(entry *ImpersonationLog) error {
+	if err := r.db.Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to create impersonation log: %w", err)
+	}
+	return nil
+}
+
+// GetImpersonationLogs lists impersonation records, newest first. The actor, target and
+// date filters are each served by an index (see EnsureAuditLogIndexes).
+func (r *authRepository) GetImpersonationLogs(filter AuditLogFilter, page, limit int) ([]ImpersonationLog, int64, error) {
+	query := r.db.Model(&ImpersonationLog{})
+	if filter.ActorID != 0 {
+		query = query.Where("admin_id = ?", filter.ActorID)
+	}
+	if filter.TargetUserID != 0 {
+		query = query.Where("user_id = ?", filter.TargetUserID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count impersonation logs: %w", err)
+	}
+
+	var entries []ImpersonationLog
+	if err := query.Order("created_at DESC, id DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&entries).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list impersonation logs: %w", err)
+	}
+	return entries, total, nil
+}
+
+// CreateUserWithInviteCode creates the user and consumes the invite code in a single
+// transaction, so a code can never be redeemed twice and is not burned if user creation fails.
+func (r *authRepository) CreateUserWithInviteCode(u *user.User, code string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(u).Error; err != nil {
+			return err
+		}
+		return redeemInviteCode(tx, code, u.ID)
+	})
+}
 
+// redeemInviteCode marks the invite code as used by the user, or returns ErrInvalidInviteCode
+// if it is unknown, expired or already used. It must run inside a transaction.
+func redeemInviteCode(tx *gorm.DB, code string, userID uint) error {
+	now := time.Now()
+	result := tx.Model(&InviteCode{}).
+		Where("code = ? AND used_at IS NULL AND expires_at > ?", code, now).
+		Updates(map[string]interface{}{
+			"used_by_id": userID,
+			"used_at":    now,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to redeem invite code: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvalidInviteCode
+	}
 	return nil
 }
+
+// GetUserSports lists the sports a user plays with their position, level and endorsement count, by sport name
+func (r *authRepository) GetUserSports(userID uint) ([]PublicUserSport, error) {
+	var sports []PublicUserSport
+	if err := r.db.Table("user_sports").
+		Select("user_sports.sport_id, sports.name, user_sports.position, user_sports.level, "+
+			"(SELECT COUNT(*) FROM skill_endorsements se WHERE se.user_id = user_sports.user_id AND se.sport_id = user_sports.sport_id) AS endorsements").
+		Joins("JOIN sports ON sports.id = user_sports.sport_id").
+		Where("user_sports.user_id = ?", userID).
+		Order("sports.name ASC").
+		Scan(&sports).Error; err != nil {
+		return nil, err
+	}
+	return sports, nil
+}
+
+// UserSearchFilter narrows a user search. Sport and Skill match the sports a user plays
+// (user_sports), by sport name and level, ignoring case.
+type UserSearchFilter struct {
+	Query         string
+	Sport         string
+	Skill         string
+	ExcludeUserID uint
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchUsers finds users whose username or name contains the query. Private profiles are
+// never returned. The search may read from the replica.
+func (r *authRepository) SearchUsers(filter UserSearchFilter, page, limit int) ([]user.User, int64, error) {
+	pattern := "%" + likeEscaper.Replace(filter.Query) + "%"
+	query := r.db.Clauses(dbresolver.Read).Model(&user.User{}).
+		Where("(username ILIKE ? OR name ILIKE ?)", pattern, pattern).
+		Where("profile_private = ?", false)
+	if filter.ExcludeUserID != 0 {
+		query = query.Where("id <> ?", filter.ExcludeUserID)
+	}
+	if filter.Sport != "" || filter.Skill != "" {
+		played := r.db.Table("user_sports").Select("1").
+			Joins("JOIN sports ON sports.id = user_sports.sport_id").
+			Where("user_sports.user_id = users.id")
+		if filter.Sport != "" {
+			played = played.Where("LOWER(sports.name) = LOWER(?)", filter.Sport)
+		}
+		if filter.Skill != "" {
+			played = played.Where("LOWER(user_sports.level) = LOWER(?)", filter.Skill)
+		}
+		query = query.Where("EXISTS (?)", played)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []user.User
+	if err := query.Order("username ASC").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// statusCount is one row of a GROUP BY status aggregate
+type statusCount struct {
+	Status string
+	Count  int64
+}
+
+// GetPlatformMetrics aggregates platform-wide counts in the database; no rows are loaded.
+// Zero from/to leave that end of the range open.
+func (r *authRepository) GetPlatformMetrics(from, to time.Time) (*PlatformMetrics, error) {
+	inRange := func(query *gorm.DB, column string) *gorm.DB {
+		if !from.IsZero() {
+			query = query.Where(column+" >= ?", from)
+		}
+		if !to.IsZero() {
+			query = query.Where(column+" < ?", to)
+		}
+		return query
+	}
+	byStatus := func(table, column string, softDelete bool) (map[string]int64, error) {
+		query := inRange(r.db.Table(table), column)
+		if softDelete {
+			query = query.Where("deleted_at IS NULL")
+		}
+		var rows []statusCount
+		if err := query.Select("status, COUNT(*) AS count").Group("status").Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		counts := make(map[string]int64, len(rows))
+		for _, row := range rows {
+			counts[row.Status] = row.Count
+		}
+		return counts, nil
+	}
+
+	metrics := &PlatformMetrics{}
+	if err := inRange(r.db.Table("users"), "created_at").
+		Where("deleted_at IS NULL").Count(&metrics.Users).Error; err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+	if err := inRange(r.db.Table("teams"), "created_at").
+		Where("deleted_at IS NULL").Count(&metrics.Teams).Error; err != nil {
+		return nil, fmt.Errorf("failed to count teams: %w", err)
+	}
+
+	matches, err := byStatus("matches", "scheduled_at", true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count matches: %w", err)
+	}
+	metrics.Matches.ByStatus = matches
+	for status, count := range matches {
+		if !finalMatchStatuses[status] {
+			metrics.Matches.Active += count
+		}
+	}
+	metrics.Matches.Upcoming = matches["upcoming"]
+	metrics.Matches.Live = matches["live"]
+
+	if metrics.TournamentsByStatus, err = byStatus("tournaments", "created_at", true); err != nil {
+		return nil, fmt.Errorf("failed to count tournaments: %w", err)
+	}
+	// Venue models keep their own deleted_at that is never set, so bookings are not filtered on it
+	if metrics.BookingsByStatus, err = byStatus("bookings", "start_time", false); err != nil {
+		return nil, fmt.Errorf("failed to count bookings: %w", err)
+	}
+	return metrics, nil
+}
+
+// Ping checks that the users table answers a trivial query, for the readiness probe
+func (r *authRepository) Ping(ctx context.Context) error {
+	return r.db.WithContext(ctx).Exec("SELECT 1 FROM users LIMIT 1").Error
+}
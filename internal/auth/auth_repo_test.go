@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/models"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/internal/user"
+	"gorm.io/gorm"
+)
+
+func TestGetUserByResetTokenRejectsExpiredToken(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewAuthRepository(db)
+	u := testutil.CreateUser(t, db, "Reset")
+	setResetToken(t, db, u, "old-token", time.Now().Add(-time.Second))
+
+	if _, err := repo.GetUserByResetToken("old-token"); err == nil {
+		t.Fatal("GetUserByResetToken() accepted an expired token")
+	}
+	if err := repo.ResetPasswordWithToken("old-token", "hash"); !errors.Is(err, ErrInvalidResetToken) {
+		t.Fatalf("ResetPasswordWithToken() error = %v, want ErrInvalidResetToken", err)
+	}
+}
+
+func TestResetPasswordWithTokenConcurrentlyRedeemsOnce(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewAuthRepository(db)
+	u := testutil.CreateUser(t, db, "Race")
+	setResetToken(t, db, u, "race-token", time.Now().Add(time.Hour))
+
+	const attempts = 8
+	errs := make([]error, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = repo.ResetPasswordWithToken("race-token", "hash")
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, ErrInvalidResetToken):
+			t.Errorf("ResetPasswordWithToken() error = %v, want ErrInvalidResetToken", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("token redeemed %d times, want once", succeeded)
+	}
+}
+
+// createOTP stores an unused code for the phone that expires in an hour
+func createOTP(t *testing.T, db *gorm.DB, phone, code string) *OTP {
+	t.Helper()
+	otp := &OTP{Phone: phone, Code: code, ExpiresAt: time.Now().Add(time.Hour)}
+	if err := db.Create(otp).Error; err != nil {
+		t.Fatalf("failed to create OTP: %v", err)
+	}
+	return otp
+}
+
+// otpUser is the user VerifyOTP registers for the phone
+func otpUser(phone string) *user.User {
+	return &user.User{
+		Name:            "User " + phone,
+		Username:        "user_" + phone,
+		Phone:           phone,
+		PhoneVerified:   true,
+		Verified:        true,
+		PreferredSports: models.StringSlice{},
+	}
+}
+
+func TestVerifyOTPConcurrentlyRegistersOnce(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewAuthRepository(db)
+	if err := db.Create(&user.Role{Name: DefaultUserRole}).Error; err != nil {
+		t.Fatalf("failed to create role: %v", err)
+	}
+	createOTP(t, db, "15550001", "123456")
+
+	const attempts = 8
+	errs := make([]error, attempts)
+	created := make([]bool, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, created[i], errs[i] = repo.VerifyOTP("15550001", "123456", otpUser("15550001"), DefaultUserRole, "")
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+			if !created[i] {
+				t.Error("the verification that succeeded did not register the user")
+			}
+		case !errors.Is(err, ErrInvalidOTP):
+			t.Errorf("VerifyOTP() error = %v, want ErrInvalidOTP", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("OTP verified %d times, want once", succeeded)
+	}
+
+	var users []user.User
+	if err := db.Where("phone = ?", "15550001").Find(&users).Error; err != nil {
+		t.Fatalf("failed to load users: %v", err)
+	}
+	if len(users) != 1 {
+		t.Fatalf("registered %d users for the phone, want 1", len(users))
+	}
+	roles, err := repo.GetUserRoles(users[0].ID)
+	if err != nil {
+		t.Fatalf("GetUserRoles: %v", err)
+	}
+	if len(roles) != 1 || roles[0] != DefaultUserRole {
+		t.Errorf("roles = %v, want [%s]", roles, DefaultUserRole)
+	}
+}
+
+func TestVerifyOTPSignsInExistingUser(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewAuthRepository(db)
+	existing := testutil.CreateUser(t, db, "Existing")
+	if err := db.Model(existing).Update("phone", "15550002").Error; err != nil {
+		t.Fatalf("failed to set phone: %v", err)
+	}
+	createOTP(t, db, "15550002", "654321")
+
+	// No roles exist, so this would fail if the existing user were registered again
+	u, created, err := repo.VerifyOTP("15550002", "654321", otpUser("15550002"), DefaultUserRole, "")
+	if err != nil {
+		t.Fatalf("VerifyOTP: %v", err)
+	}
+	if created || u.ID != existing.ID || !u.PhoneVerified {
+		t.Errorf("VerifyOTP() = user %d (phone verified %v), created %v; want the existing user %d, phone verified",
+			u.ID, u.PhoneVerified, created, existing.ID)
+	}
+}
+
+func TestVerifyOTPRollsBackWithoutDefaultRole(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewAuthRepository(db)
+	otp := createOTP(t, db, "15550003", "111111")
+
+	if _, _, err := repo.VerifyOTP("15550003", "111111", otpUser("15550003"), DefaultUserRole, ""); err == nil {
+		t.Fatal("VerifyOTP() registered a user without the default role")
+	}
+
+	var users int64
+	if err := db.Model(&user.User{}).Where("phone = ?", "15550003").Count(&users).Error; err != nil {
+		t.Fatalf("failed to count users: %v", err)
+	}
+	if users != 0 {
+		t.Errorf("%d users left behind without a role", users)
+	}
+	var stored OTP
+	if err := db.First(&stored, otp.ID).Error; err != nil {
+		t.Fatalf("failed to reload OTP: %v", err)
+	}
+	if stored.Verified {
+		t.Error("OTP consumed although the registration was rolled back")
+	}
+}
@@ -7,6 +7,12 @@ import (
 	"gorm.io/gorm"
 )
 
+// maxBatchUserIDs caps how many profiles GET /users returns in one call
+const maxBatchUserIDs = 50
+
+// minUserSearchQueryLength is the shortest search term accepted by GET /users/search
+const minUserSearchQueryLength = 2
+
 func RegisterAuthRoutes(router *gin.RouterGroup, db *gorm.DB, appConfig *config.Config) {
 	// Initialize repository and controller
 	// mailerService := services.NewSESMailer(appConfig) // Example
@@ -15,19 +21,33 @@ func RegisterAuthRoutes(router *gin.RouterGroup, db *gorm.DB, appConfig *config.
 	authRepo := NewAuthRepository(db)
 	authController := NewAuthController(authRepo, appConfig /* mailerService, smsService */)
 
+	// Stricter limits for endpoints attractive to credential stuffing and SMS/email abuse
+	limiter := middleware.NewTokenBucketLimiter(appConfig)
+	authLimit := middleware.RateLimit{
+		RequestsPerMinute: appConfig.RateLimit.AuthRequestsPerMinute,
+		Burst:             appConfig.RateLimit.AuthBurst,
+	}
+	// Availability checks and user searches are cheap to send and could otherwise be used to
+	// enumerate registered accounts
+	lookupLimit := middleware.RateLimit{
+		RequestsPerMinute: appConfig.RateLimit.LookupRequestsPerMinute,
+		Burst:             appConfig.RateLimit.LookupBurst,
+	}
+
 	// Public routes
 	authPublic := router.Group("/auth")
 	{
-		authPublic.POST("/register", authController.Register)
-		authPublic.POST("/login", authController.Login)
+		authPublic.POST("/register", limiter.Limit("auth_register", authLimit), authController.Register)
+		authPublic.GET("/available", limiter.Limit("auth_available", lookupLimit), authController.CheckAvailability)
+		authPublic.POST("/login", limiter.Limit("auth_login", authLimit), authController.Login)
 		authPublic.POST("/refresh-token", authController.RefreshToken)
 
-		authPublic.POST("/request-otp", authController.RequestOTP)
+		authPublic.POST("/request-otp", limiter.Limit("auth_request_otp", authLimit), authController.RequestOTP)
 		authPublic.POST("/verify-otp", authController.VerifyOTP)
 		// Resend OTP might be similar to request-otp, or have its own logic
 		// authPublic.POST("/resend-otp", authController.ResendOTP) // Assuming ResendOTP exists
 
-		authPublic.POST("/forgot-password", authController.ForgotPassword)
+		authPublic.POST("/forgot-password", limiter.Limit("auth_forgot_password", authLimit), authController.ForgotPassword)
 		authPublic.POST("/reset-password", authController.ResetPassword)
 
 		authPublic.GET("/verify-email", authController.VerifyEmail) // Changed to GET as it's usually a link
@@ -44,4 +64,38 @@ func RegisterAuthRoutes(router *gin.RouterGroup, db *gorm.DB, appConfig *config.
 		authProtected.POST("/change-password", authController.ChangePassword)
 		authProtected.POST("/logout", authController.Logout) // Changed to POST
 	}
+
+	users := router.Group("/users")
+	users.Use(middleware.AuthMiddleware(appConfig.JWT.AccessTokenSecret, config.DB))
+	{
+		users.GET("", authController.GetUsersByIDs)
+		users.GET("/search", limiter.Limit("user_search", lookupLimit), authController.SearchUsers)
+		users.GET("/:id", authController.GetPublicProfile)
+	}
+
+	// Admin-only auth management routes
+	authAdmin := router.Group("/auth/admin")
+	authAdmin.Use(middleware.AuthMiddleware(appConfig.JWT.AccessTokenSecret, config.DB), middleware.RequireRole("admin"))
+	{
+		authAdmin.POST("/invite-codes", authController.CreateInviteCodes)
+		authAdmin.GET("/role-cache", authController.GetRoleCacheStats)
+	}
+
+	adminUsers := router.Group("/admin/users")
+	adminUsers.Use(middleware.AuthMiddleware(appConfig.JWT.AccessTokenSecret, config.DB), middleware.RequireRole("admin"))
+	{
+		adminUsers.POST("/:id/impersonate", authController.ImpersonateUser)
+	}
+
+	adminAudit := router.Group("/admin/audit-log")
+	adminAudit.Use(middleware.AuthMiddleware(appConfig.JWT.AccessTokenSecret, config.DB), middleware.RequireRole("admin"))
+	{
+		adminAudit.GET("", authController.GetAuditLog)
+	}
+
+	adminMetrics := router.Group("/admin/metrics")
+	adminMetrics.Use(middleware.AuthMiddleware(appConfig.JWT.AccessTokenSecret, config.DB), middleware.RequireRole("admin"))
+	{
+		adminMetrics.GET("", authController.GetPlatformMetrics)
+	}
 }
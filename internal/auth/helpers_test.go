@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/DhavalSuthar-24/miow/config"
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/internal/sport"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/internal/user"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTestDB opens a test database with the auth tables
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	models := append(testutil.UserModels,
+		&OTP{}, &InviteCode{}, &ImpersonationLog{}, &user.RefreshToken{},
+		&sport.Sport{}, &sport.UserSport{}, &sport.SkillEndorsement{})
+	return testutil.DB(t, models...)
+}
+
+// newTestConfig returns a configuration with cheap password hashing and test JWT secrets
+func newTestConfig() *config.Config {
+	cfg := &config.Config{}
+	cfg.JWT.AccessTokenSecret = "test-access-secret"
+	cfg.JWT.RefreshTokenSecret = "test-refresh-secret"
+	cfg.JWT.AccessTokenExpiryMinutes = 15
+	cfg.JWT.RefreshTokenExpiryDays = 7
+	cfg.Auth.PasswordHashCost = bcrypt.MinCost
+	cfg.Auth.ImpersonationExpiryMinutes = 10
+	cfg.Auth.RegistrationOpen = true
+	cfg.App.FrontendURL = "http://frontend.test"
+	return cfg
+}
+
+// newTestController creates a controller over db with the test configuration
+func newTestController(t *testing.T, db *gorm.DB) *AuthController {
+	t.Helper()
+	return NewAuthController(NewAuthRepository(db), newTestConfig())
+}
+
+// asUser authenticates every request as the user, as AuthMiddleware would
+func asUser(userID uint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(middleware.AuthUserIDKey, userID)
+		c.Next()
+	}
+}
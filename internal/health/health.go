@@ -0,0 +1,117 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// defaultCheckTimeout bounds each readiness check
+const defaultCheckTimeout = 2 * time.Second
+
+// Pinger is implemented by the module repositories; Ping runs a trivial query against the
+// tables the module depends on
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Check is a named readiness check, such as a module repository
+type Check struct {
+	Name   string
+	Pinger Pinger
+}
+
+// CheckResult is the outcome of one readiness check
+type CheckResult struct {
+	Status    string `json:"status"` // "ok" or "error"
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Report is the readiness payload: the overall status and each check's result by name
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Handler serves the liveness and readiness probes
+type Handler struct {
+	db      *gorm.DB
+	checks  []Check
+	timeout time.Duration
+}
+
+// NewHandler creates the probe handler. Readiness pings the database connection, then runs
+// every check.
+func NewHandler(db *gorm.DB, checks ...Check) *Handler {
+	return &Handler{db: db, checks: checks, timeout: defaultCheckTimeout}
+}
+
+// Live reports that the process is up; it does not touch the database
+func (h *Handler) Live(c *gin.Context) {
+	response.Success(c, http.StatusOK, "", gin.H{"status": "ok"})
+}
+
+// Ready runs the database ping and the repository checks concurrently and responds 200 when
+// all of them pass, or 503 with the failing checks
+func (h *Handler) Ready(c *gin.Context) {
+	checks := append([]Check{{Name: "database", Pinger: dbPinger{h.db}}}, h.checks...)
+
+	report := Report{Status: "ok", Checks: make(map[string]CheckResult, len(checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+			result := run(c.Request.Context(), check.Pinger, h.timeout)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name] = result
+			if result.Status != "ok" {
+				report.Status = "error"
+			}
+		}(check)
+	}
+	wg.Wait()
+
+	if report.Status != "ok" {
+		response.ErrorWithDetails(c, http.StatusServiceUnavailable, "Service not ready", report)
+		return
+	}
+	response.Success(c, http.StatusOK, "", report)
+}
+
+// run executes one check within the timeout
+func run(ctx context.Context, p Pinger, timeout time.Duration) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := p.Ping(ctx)
+	result := CheckResult{Status: "ok", LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = "error"
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// dbPinger checks the connection itself, without touching any table
+type dbPinger struct {
+	db *gorm.DB
+}
+
+func (p dbPinger) Ping(ctx context.Context) error {
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
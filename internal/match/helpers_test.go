@@ -0,0 +1,307 @@
+package match
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/config"
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/internal/notification"
+	"github.com/DhavalSuthar-24/miow/internal/sport"
+	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/internal/user"
+	"github.com/DhavalSuthar-24/miow/internal/venue"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTestDB opens a test database with the tables behind challenges, matches and tournaments
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	models := append(testutil.UserModels,
+		&sport.Sport{}, &sport.UserSport{},
+		&team.Team{}, &team.TeamMember{}, &team.TeamBlock{},
+		&venue.Venue{}, &venue.Ground{}, &venue.Booking{},
+		&Challenge{}, &ChallengeCounterOffer{}, &Match{}, &MatchTeam{}, &MatchPeriodScore{},
+		&MatchOfficial{}, &MatchLineup{}, &MatchComment{}, &Tournament{}, &TournamentTeam{}, &AdminAuditLog{})
+	return testutil.DB(t, models...)
+}
+
+// recordingNotifier keeps the messages sent to it
+type recordingNotifier struct {
+	mu       sync.Mutex
+	messages []notification.Message
+}
+
+func (n *recordingNotifier) Notify(msg notification.Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.messages = append(n.messages, msg)
+	return nil
+}
+
+// newTestController creates a controller over db with the default configuration
+func newTestController(t *testing.T, db *gorm.DB) *MatchController {
+	t.Helper()
+	return NewMatchController(NewGormMatchRepository(db), team.NewTeamRepository(db), &config.Config{}, &recordingNotifier{})
+}
+
+// asUser authenticates every request as the user, as AuthMiddleware would
+func asUser(userID uint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(middleware.AuthUserIDKey, userID)
+		c.Next()
+	}
+}
+
+// createSport inserts a sport with a unique name
+func createSport(t *testing.T, db *gorm.DB) *sport.Sport {
+	t.Helper()
+	s := &sport.Sport{Name: fmt.Sprintf("Sport %d", testutil.Seq()), IsActive: true}
+	if err := db.Omit("Rules", "Positions", "Equipment").Create(s).Error; err != nil {
+		t.Fatalf("failed to create sport: %v", err)
+	}
+	return s
+}
+
+// createTeam inserts a team for the sport with creatorID as its active captain
+func createTeam(t *testing.T, db *gorm.DB, sportID, creatorID uint) *team.Team {
+	t.Helper()
+	tm := &team.Team{
+		Name:         fmt.Sprintf("Team %d", testutil.Seq()),
+		CreatedByID:  creatorID,
+		SportID:      sportID,
+		Requirements: "{}",
+		Achievements: "[]",
+		SocialLinks:  "{}",
+		MatchHistory: "[]",
+	}
+	if err := db.Omit("Sport").Create(tm).Error; err != nil {
+		t.Fatalf("failed to create team: %v", err)
+	}
+	addTeamMember(t, db, tm.ID, creatorID, "captain")
+	return tm
+}
+
+// addTeamMember adds the user to the team as an active member with the role
+func addTeamMember(t *testing.T, db *gorm.DB, teamID, userID uint, role string) {
+	t.Helper()
+	member := &team.TeamMember{
+		TeamID:    teamID,
+		UserID:    userID,
+		Role:      role,
+		JoinedAt:  time.Now(),
+		IsActive:  true,
+		IsCaptain: role == "captain",
+		Stats:     "{}",
+	}
+	if err := db.Omit("Team").Create(member).Error; err != nil {
+		t.Fatalf("failed to add team member: %v", err)
+	}
+}
+
+// createMatch inserts a match of the sport between the teams, after applying opts
+func createMatch(t *testing.T, db *gorm.DB, sportID, creatorID uint, teams []*team.Team, opts ...func(*Match)) *Match {
+	t.Helper()
+	m := &Match{
+		CreatedByUserID: creatorID,
+		SportID:         sportID,
+		ScheduledAt:     time.Now().Add(48 * time.Hour).Truncate(time.Second),
+		Duration:        90,
+		CustomRules:     "{}",
+		Status:          StatusMatchUpcoming,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if err := db.Omit("CreatedByUser", "Sport", "Venue", "Challenge", "TossWinnerTeam", "WinningTeam", "ManOfTheMatch", "MatchTeams").
+		Create(m).Error; err != nil {
+		t.Fatalf("failed to create match: %v", err)
+	}
+	for i, tm := range teams {
+		mt := MatchTeam{MatchID: m.ID, TeamID: tm.ID, IsHomeTeam: i == 0, TeamDetails: "{}"}
+		if err := db.Omit("Match", "Team").Create(&mt).Error; err != nil {
+			t.Fatalf("failed to add team to match: %v", err)
+		}
+		m.MatchTeams = append(m.MatchTeams, mt)
+	}
+	return m
+}
+
+// inStatus sets the match status, for createMatch and withMatch
+func inStatus(status MatchStatus) func(*Match) {
+	return func(m *Match) { m.Status = status }
+}
+
+// twoTeamMatch is a controller over a fresh test database with a sport, a home and an away
+// team of it and an upcoming match between them. By default the match creator captains the
+// home team and a rival the away team; twoTeamMatchOptions change that.
+type twoTeamMatch struct {
+	db          *gorm.DB
+	mc          *MatchController
+	sport       *sport.Sport
+	creator     *user.User
+	homeCaptain *user.User
+	awayCaptain *user.User
+	home, away  *team.Team
+	match       *Match
+}
+
+type twoTeamMatchConfig struct {
+	homeCaptain string // Name of a new user captaining the home team; empty for the creator
+	creatorAway bool
+	noMatch     bool
+	matchOpts   []func(*Match)
+}
+
+// twoTeamMatchOption changes how newTwoTeamMatch sets up the teams or the match
+type twoTeamMatchOption func(*twoTeamMatchConfig)
+
+// withHomeCaptain has a new user of that name captain the home team instead of the creator
+func withHomeCaptain(name string) twoTeamMatchOption {
+	return func(c *twoTeamMatchConfig) { c.homeCaptain = name }
+}
+
+// withCreatorAway has the creator captain the away team instead of a rival
+func withCreatorAway() twoTeamMatchOption {
+	return func(c *twoTeamMatchConfig) { c.creatorAway = true }
+}
+
+// withMatch applies opts to the match before it is created
+func withMatch(opts ...func(*Match)) twoTeamMatchOption {
+	return func(c *twoTeamMatchConfig) { c.matchOpts = append(c.matchOpts, opts...) }
+}
+
+// withoutMatch leaves the match to the caller, e.g. when it needs records that refer to the teams
+func withoutMatch() twoTeamMatchOption {
+	return func(c *twoTeamMatchConfig) { c.noMatch = true }
+}
+
+func newTwoTeamMatch(t *testing.T, opts ...twoTeamMatchOption) *twoTeamMatch {
+	t.Helper()
+	var cfg twoTeamMatchConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	db := newTestDB(t)
+	f := &twoTeamMatch{db: db, mc: newTestController(t, db), sport: createSport(t, db)}
+	f.creator = testutil.CreateUser(t, db, "Creator")
+	f.homeCaptain, f.awayCaptain = f.creator, f.creator
+	if cfg.homeCaptain != "" {
+		f.homeCaptain = testutil.CreateUser(t, db, cfg.homeCaptain)
+	}
+	if !cfg.creatorAway {
+		f.awayCaptain = testutil.CreateUser(t, db, "Rival")
+	}
+	f.home = createTeam(t, db, f.sport.ID, f.homeCaptain.ID)
+	f.away = createTeam(t, db, f.sport.ID, f.awayCaptain.ID)
+	if !cfg.noMatch {
+		f.match = f.newMatch(t, cfg.matchOpts...)
+	}
+	return f
+}
+
+// newMatch creates another match by the creator between the two teams, after applying opts
+func (f *twoTeamMatch) newMatch(t *testing.T, opts ...func(*Match)) *Match {
+	t.Helper()
+	return createMatch(t, f.db, f.sport.ID, f.creator.ID, []*team.Team{f.home, f.away}, opts...)
+}
+
+// createVenue inserts an available venue at the coordinates
+func createVenue(t *testing.T, db *gorm.DB, managerID uint, lat, lng float64) *venue.Venue {
+	t.Helper()
+	v := &venue.Venue{
+		Name:        fmt.Sprintf("Venue %d", testutil.Seq()),
+		Location:    "Test Street",
+		Coordinates: fmt.Sprintf(`{"latitude":%g,"longitude":%g}`, lat, lng),
+		Facilities:  "[]",
+		Images:      "[]",
+		SocialHours: "{}",
+		Available:   true,
+		Timezone:    "UTC",
+		ManagerID:   managerID,
+	}
+	if err := db.Omit("Manager").Create(v).Error; err != nil {
+		t.Fatalf("failed to create venue: %v", err)
+	}
+	return v
+}
+
+// createChallenge inserts an open team challenge sent by senderTeam, after applying opts
+func createChallenge(t *testing.T, db *gorm.DB, sportID, creatorID uint, senderTeam *team.Team, opts ...func(*Challenge)) *Challenge {
+	t.Helper()
+	ch := &Challenge{
+		Title:            fmt.Sprintf("Challenge %d", testutil.Seq()),
+		SportID:          sportID,
+		CreatedByUserID:  creatorID,
+		ChallengeType:    OpenChallengeTeam,
+		Status:           StatusOpen,
+		ProposedDateTime: time.Now().Add(48 * time.Hour).Truncate(time.Second),
+		AdditionalRules:  "{}",
+	}
+	if senderTeam != nil {
+		ch.SenderTeamID = &senderTeam.ID
+	}
+	for _, opt := range opts {
+		opt(ch)
+	}
+	if err := db.Omit("Sport", "CreatedByUser", "SenderTeam", "ReceiverTeam", "SenderUser", "ReceiverUser", "Venue").
+		Create(ch).Error; err != nil {
+		t.Fatalf("failed to create challenge: %v", err)
+	}
+	return ch
+}
+
+// createTournament inserts a tournament of the sport open for registration, after applying opts
+func createTournament(t *testing.T, db *gorm.DB, sportID, creatorID uint, opts ...func(*Tournament)) *Tournament {
+	t.Helper()
+	tr := &Tournament{
+		Name:            fmt.Sprintf("Tournament %d", testutil.Seq()),
+		SportID:         sportID,
+		CreatedByUserID: creatorID,
+		StartDate:       time.Now().Add(7 * 24 * time.Hour).Truncate(time.Second),
+		Status:          TournamentStatusRegistrationOpen,
+		FormatDetails:   "{}",
+		Bracket:         "{}",
+	}
+	for _, opt := range opts {
+		opt(tr)
+	}
+	if err := db.Omit("CreatedByUser", "Sport", "Teams", "Matches").Create(tr).Error; err != nil {
+		t.Fatalf("failed to create tournament: %v", err)
+	}
+	return tr
+}
+
+// registerTeam stores an approved registration without touching the tournament's counter
+func registerTeam(t *testing.T, db *gorm.DB, tournamentID, teamID uint) *TournamentTeam {
+	t.Helper()
+	reg := &TournamentTeam{TournamentID: tournamentID, TeamID: teamID, RegisteredAt: time.Now(), Status: TournamentTeamApproved}
+	if err := db.Omit("Tournament", "Team").Create(reg).Error; err != nil {
+		t.Fatalf("failed to register team: %v", err)
+	}
+	return reg
+}
+
+// uintPtr returns a pointer to v
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+// intPtr returns a pointer to v
+func intPtr(v int) *int {
+	return &v
+}
+
+// itoa formats an ID for a URL path
+func itoa(id uint) string {
+	return fmt.Sprint(id)
+}
@@ -0,0 +1,389 @@
+package match
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/DhavalSuthar-24/miow/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Admin audit log actions
+const (
+	AuditActionOverrideMatchStatus = "match.override_status"
+	AuditActionOverrideMatchScore  = "match.override_score"
+)
+
+// AuditEntityMatch is the entity type recorded for match overrides
+const AuditEntityMatch = "match"
+
+var (
+	// errOverrideMatchNotFound ends an override whose match does not exist
+	errOverrideMatchNotFound = errors.New("match not found")
+	// errScoresNotOverridable ends a score override of a match that is neither live nor completed
+	errScoresNotOverridable = errors.New("scores can only be overridden for live or completed matches")
+)
+
+// AdminAuditLog records an admin override: who made it, why, and the affected values before
+// and after the change. It is written in the same transaction as the override.
+type AdminAuditLog struct {
+	gorm.Model
+	AdminID    uint            `json:"admin_id" gorm:"index;not null"`
+	Action     string          `json:"action" gorm:"size:64;index;not null" example:"match.override_status"`
+	EntityType string          `json:"entity_type" gorm:"size:32;not null;index:idx_admin_audit_entity" example:"match"`
+	EntityID   uint            `json:"entity_id" gorm:"not null;index:idx_admin_audit_entity"`
+	Before     json.RawMessage `json:"before" gorm:"type:jsonb" swaggertype:"object"`
+	After      json.RawMessage `json:"after" gorm:"type:jsonb" swaggertype:"object"`
+	Reason     string          `json:"reason" gorm:"type:text;not null"`
+}
+
+// AdminAuditFilter narrows an admin audit log listing; zero values are ignored
+type AdminAuditFilter struct {
+	AdminID    uint
+	Action     string
+	EntityType string
+	EntityID   uint
+	From       time.Time
+	To         time.Time
+}
+
+// AdminOverrideStatusRequest defines the payload for an admin match status override
+type AdminOverrideStatusRequest struct {
+	Status MatchStatus `json:"status" binding:"required,oneof=pending upcoming pre_toss toss_done live completed cancelled postponed forfeited abandoned"`
+	Reason string      `json:"reason" binding:"required,max=500"`
+}
+
+// AdminOverrideScoreRequest defines the payload for an admin match score override
+type AdminOverrideScoreRequest struct {
+	Reason string                    `json:"reason" binding:"required,max=500"`
+	Scores []UpdateMatchScoreRequest `json:"scores" binding:"required,min=1,dive"`
+}
+
+// auditStatus is the audited state of a status override
+type auditStatus struct {
+	Status MatchStatus `json:"status"`
+}
+
+// auditScore is the audited state of one team's score in a score override
+type auditScore struct {
+	TeamID       uint   `json:"team_id"`
+	Score        int    `json:"score"`
+	ResultStatus string `json:"result_status,omitempty"`
+}
+
+// newAdminAuditLog builds an audit record for a match override, encoding before and after as JSON
+func newAdminAuditLog(adminID uint, action string, matchID uint, before, after interface{}, reason string) (*AdminAuditLog, error) {
+	beforeJSON, err := json.Marshal(before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit state: %w", err)
+	}
+	afterJSON, err := json.Marshal(after)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit state: %w", err)
+	}
+	return &AdminAuditLog{
+		AdminID:    adminID,
+		Action:     action,
+		EntityType: AuditEntityMatch,
+		EntityID:   matchID,
+		Before:     beforeJSON,
+		After:      afterJSON,
+		Reason:     reason,
+	}, nil
+}
+
+// CreateAdminAuditLog records an admin override. Call it through WithTransaction so the record
+// is only kept when the override itself commits.
+func (r *GormMatchRepository) CreateAdminAuditLog(entry *AdminAuditLog) error {
+	return r.db.Create(entry).Error
+}
+
+// GetAdminAuditLogs lists admin audit records, newest first
+func (r *GormMatchRepository) GetAdminAuditLogs(filter AdminAuditFilter, page, limit int) ([]AdminAuditLog, int64, error) {
+	query := r.db.Model(&AdminAuditLog{})
+	if filter.AdminID != 0 {
+		query = query.Where("admin_id = ?", filter.AdminID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != 0 {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count admin audit logs: %w", err)
+	}
+
+	var entries []AdminAuditLog
+	if err := query.Order("created_at DESC, id DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&entries).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list admin audit logs: %w", err)
+	}
+	return entries, total, nil
+}
+
+// @Summary      Admin override audit log
+// @Description  Admin only. Lists admin overrides with the values before and after each change, newest first.
+// @Tags         Matches
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        admin_id query int false "Only overrides made by this admin"
+// @Param        action query string false "Only this action" Enums(match.override_status, match.override_score)
+// @Param        entity_type query string false "Only overrides of this entity type" Enums(match)
+// @Param        entity_id query int false "Only overrides of this entity"
+// @Param        from query string false "Only overrides at or after this time (RFC 3339 or YYYY-MM-DD)"
+// @Param        to query string false "Only overrides before this time (RFC 3339 or YYYY-MM-DD)"
+// @Param        page query int false "Page number" default(1)
+// @Param        limit query int false "Items per page" default(20)
+// @Success      200 {object} response.PaginatedResponse{data=response.Page{items=[]AdminAuditLog}} "Audit log entries"
+// @Failure      400 {object} response.ErrorResponse "Invalid filter"
+// @Failure      401 {object} response.ErrorResponse "Unauthorized"
+// @Failure      403 {object} response.ErrorResponse "Forbidden"
+// @Failure      500 {object} response.ErrorResponse "Internal server error"
+// @Router       /admin/audit [get]
+func (mc *MatchController) GetAdminAuditLog(c *gin.Context) {
+	filter := AdminAuditFilter{
+		Action:     c.Query("action"),
+		EntityType: c.Query("entity_type"),
+	}
+	var err error
+	if filter.AdminID, err = utils.ParseOptionalID(c.Query("admin_id")); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid admin_id")
+		return
+	}
+	if filter.EntityID, err = utils.ParseOptionalID(c.Query("entity_id")); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid entity_id")
+		return
+	}
+	if filter.From, err = utils.ParseOptionalTime(c.Query("from")); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid from date")
+		return
+	}
+	if filter.To, err = utils.ParseOptionalTime(c.Query("to")); err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid to date")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	entries, total, err := mc.repo.GetAdminAuditLogs(filter, page, limit)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch audit log: "+err.Error())
+		return
+	}
+	response.Paginated(c, http.StatusOK, "", entries, total, page, limit)
+}
+
+// @Summary      Override match status
+// @Description  Admin only. Sets a match's status directly. The change and its reason are recorded in the admin audit log.
+// @Tags         Matches
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id path int true "Match ID"
+// @Param        request body AdminOverrideStatusRequest true "New status and reason"
+// @Success      200 {object} response.SuccessResponse{data=AdminAuditLog}
+// @Failure      400 {object} response.ErrorResponse "Invalid input"
+// @Failure      401 {object} response.ErrorResponse "Unauthorized"
+// @Failure      403 {object} response.ErrorResponse "Forbidden"
+// @Failure      404 {object} response.ErrorResponse "Match not found"
+// @Failure      500 {object} response.ErrorResponse "Internal server error"
+// @Router       /admin/matches/{id}/override-status [post]
+func (mc *MatchController) AdminOverrideMatchStatus(c *gin.Context) {
+	adminID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	matchID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid match ID")
+		return
+	}
+
+	var req AdminOverrideStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		response.Error(c, http.StatusBadRequest, "Reason is required")
+		return
+	}
+
+	// The previous status is read under the match's row lock so the audit record matches what
+	// the override replaced
+	var entry *AdminAuditLog
+	err = mc.repo.WithTransaction(func(txRepo MatchRepository) error {
+		match, err := txRepo.LockMatch(uint(matchID))
+		if err != nil {
+			return err
+		}
+		if match == nil {
+			return errOverrideMatchNotFound
+		}
+		entry, err = newAdminAuditLog(adminID, AuditActionOverrideMatchStatus, match.ID,
+			auditStatus{Status: match.Status}, auditStatus{Status: req.Status}, reason)
+		if err != nil {
+			return err
+		}
+		if err := txRepo.UpdateMatchStatus(match.ID, req.Status); err != nil {
+			return err
+		}
+		return txRepo.CreateAdminAuditLog(entry)
+	})
+	if err != nil {
+		if errors.Is(err, errOverrideMatchNotFound) {
+			response.Error(c, http.StatusNotFound, "Match not found")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to override match status: "+err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Match status overridden successfully", entry)
+}
+
+// @Summary      Override match scores
+// @Description  Admin only. Sets or adjusts team scores of a live or completed match. The previous and new scores and the reason are recorded in the admin audit log.
+// @Tags         Matches
+// @Accept       json
+// @Produce      json
+// @Security     ApiKeyAuth
+// @Param        id path int true "Match ID"
+// @Param        request body AdminOverrideScoreRequest true "Score updates and reason"
+// @Success      200 {object} response.SuccessResponse
+// @Failure      400 {object} response.ErrorResponse "Invalid input"
+// @Failure      401 {object} response.ErrorResponse "Unauthorized"
+// @Failure      403 {object} response.ErrorResponse "Forbidden"
+// @Failure      404 {object} response.ErrorResponse "Match not found"
+// @Failure      409 {object} response.ErrorResponse "Score is derived from period scores"
+// @Failure      500 {object} response.ErrorResponse "Internal server error"
+// @Router       /admin/matches/{id}/override-score [post]
+func (mc *MatchController) AdminOverrideMatchScore(c *gin.Context) {
+	adminID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	matchID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid match ID")
+		return
+	}
+
+	var req AdminOverrideScoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		response.Error(c, http.StatusBadRequest, "Reason is required")
+		return
+	}
+
+	for _, scoreUpdate := range req.Scores {
+		if !scoreUpdate.Increment && *scoreUpdate.Score < 0 {
+			response.Error(c, http.StatusBadRequest, "Score cannot be negative for team "+strconv.Itoa(int(scoreUpdate.TeamID)))
+			return
+		}
+	}
+
+	matchTeams := make([]*MatchTeam, 0, len(req.Scores))
+	err = mc.repo.WithTransaction(func(txRepo MatchRepository) error {
+		// The previous scores are read under row locks so a concurrent score update cannot
+		// slip in between them and the override
+		match, err := txRepo.LockMatch(uint(matchID))
+		if err != nil {
+			return err
+		}
+		if match == nil {
+			return errOverrideMatchNotFound
+		}
+		if match.Status != StatusMatchCompleted && match.Status != StatusMatchLive {
+			return errScoresNotOverridable
+		}
+
+		before := make([]auditScore, 0, len(req.Scores))
+		for _, scoreUpdate := range req.Scores {
+			for _, mt := range match.MatchTeams {
+				if mt.TeamID == scoreUpdate.TeamID {
+					before = append(before, auditScore{TeamID: mt.TeamID, Score: mt.Score, ResultStatus: mt.ResultStatus})
+					break
+				}
+			}
+		}
+
+		after := make([]auditScore, 0, len(req.Scores))
+		for _, scoreUpdate := range req.Scores {
+			matchTeam, err := txRepo.UpdateMatchScore(match.ID, scoreUpdate.TeamID, *scoreUpdate.Score, scoreUpdate.Increment, scoreUpdate.ResultStatus)
+			if err != nil {
+				return fmt.Errorf("failed to update score for team %d: %w", scoreUpdate.TeamID, err)
+			}
+			matchTeams = append(matchTeams, matchTeam)
+			after = append(after, auditScore{TeamID: matchTeam.TeamID, Score: matchTeam.Score, ResultStatus: matchTeam.ResultStatus})
+		}
+
+		entry, err := newAdminAuditLog(adminID, AuditActionOverrideMatchScore, match.ID, before, after, reason)
+		if err != nil {
+			return err
+		}
+		return txRepo.CreateAdminAuditLog(entry)
+	})
+
+	if err != nil {
+		if errors.Is(err, errOverrideMatchNotFound) {
+			response.Error(c, http.StatusNotFound, "Match not found")
+			return
+		}
+		if errors.Is(err, errScoresNotOverridable) {
+			response.Error(c, http.StatusBadRequest, "Scores can only be overridden for live or completed matches.")
+			return
+		}
+		if errors.Is(err, ErrMatchTeamNotFound) {
+			response.Error(c, http.StatusBadRequest, "Failed to override match scores: "+err.Error())
+			return
+		}
+		if errors.Is(err, ErrScoreDerivedFromPeriods) {
+			response.Error(c, http.StatusConflict, "Failed to override match scores: "+err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to override match scores: "+err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Match scores overridden successfully", gin.H{
+		"match_teams": matchTeams,
+	})
+}
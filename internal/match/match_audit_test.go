@@ -0,0 +1,162 @@
+package match
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/gin-gonic/gin"
+)
+
+func (f *scoreFixture) adminRouter() *gin.Engine {
+	r := gin.New()
+	r.Use(asUser(f.creator.ID))
+	r.GET("/admin/audit", f.mc.GetAdminAuditLog)
+	r.POST("/admin/matches/:id/override-status", f.mc.AdminOverrideMatchStatus)
+	r.POST("/admin/matches/:id/override-score", f.mc.AdminOverrideMatchScore)
+	return r
+}
+
+// auditEntries lists the audit log entries recorded for the fixture's match
+func (f *scoreFixture) auditEntries(t *testing.T) []AdminAuditLog {
+	t.Helper()
+	var page struct {
+		Items []AdminAuditLog `json:"items"`
+	}
+	w := testutil.Request(t, f.adminRouter(), http.MethodGet, "/admin/audit?entity_id="+itoa(f.match.ID)+"&from=2000-01-01", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("audit log status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	testutil.DecodeData(t, w, &page)
+	return page.Items
+}
+
+// auditScores decodes an audited score state into each team's score
+func auditScores(t *testing.T, raw json.RawMessage) map[uint]int {
+	t.Helper()
+	var scores []auditScore
+	if err := json.Unmarshal(raw, &scores); err != nil {
+		t.Fatalf("failed to decode audited scores %s: %v", raw, err)
+	}
+	byTeam := make(map[uint]int)
+	for _, s := range scores {
+		byTeam[s.TeamID] = s.Score
+	}
+	return byTeam
+}
+
+func TestAdminOverrideMatchScoreRecordsAudit(t *testing.T) {
+	f := newScoreFixture(t)
+	f.score(t, UpdateMatchScoreRequest{TeamID: f.home.ID, Score: intPtr(2)})
+
+	w := testutil.Request(t, f.adminRouter(), http.MethodPost, "/admin/matches/"+itoa(f.match.ID)+"/override-score", AdminOverrideScoreRequest{
+		Reason: "  Scorer entered the wrong totals  ",
+		Scores: []UpdateMatchScoreRequest{
+			{TeamID: f.home.ID, Score: intPtr(4)},
+			{TeamID: f.away.ID, Score: intPtr(1), Increment: true},
+		},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	entries := f.auditEntries(t)
+	if len(entries) != 1 {
+		t.Fatalf("got %d audit entries, want 1", len(entries))
+	}
+	entry := entries[0]
+	if entry.Action != AuditActionOverrideMatchScore || entry.EntityType != AuditEntityMatch || entry.AdminID != f.creator.ID {
+		t.Errorf("entry = %s %s by %d, want %s %s by %d",
+			entry.Action, entry.EntityType, entry.AdminID, AuditActionOverrideMatchScore, AuditEntityMatch, f.creator.ID)
+	}
+	if entry.Reason != "Scorer entered the wrong totals" {
+		t.Errorf("reason = %q, want it trimmed", entry.Reason)
+	}
+	before, after := auditScores(t, entry.Before), auditScores(t, entry.After)
+	if before[f.home.ID] != 2 || before[f.away.ID] != 0 || len(before) != 2 {
+		t.Errorf("before = %v, want home 2 and away 0", before)
+	}
+	if after[f.home.ID] != 4 || after[f.away.ID] != 1 || len(after) != 2 {
+		t.Errorf("after = %v, want home 4 and away 1", after)
+	}
+}
+
+func TestAdminOverrideMatchStatusRecordsAudit(t *testing.T) {
+	f := newScoreFixture(t)
+
+	w := testutil.Request(t, f.adminRouter(), http.MethodPost, "/admin/matches/"+itoa(f.match.ID)+"/override-status", AdminOverrideStatusRequest{
+		Status: StatusMatchAbandoned,
+		Reason: "Rain stopped play",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	var stored Match
+	if err := f.db.First(&stored, f.match.ID).Error; err != nil {
+		t.Fatalf("failed to reload match: %v", err)
+	}
+	if stored.Status != StatusMatchAbandoned {
+		t.Errorf("match status = %q, want %q", stored.Status, StatusMatchAbandoned)
+	}
+
+	entries := f.auditEntries(t)
+	if len(entries) != 1 {
+		t.Fatalf("got %d audit entries, want 1", len(entries))
+	}
+	var before, after auditStatus
+	if err := json.Unmarshal(entries[0].Before, &before); err != nil {
+		t.Fatalf("failed to decode before: %v", err)
+	}
+	if err := json.Unmarshal(entries[0].After, &after); err != nil {
+		t.Fatalf("failed to decode after: %v", err)
+	}
+	if before.Status != StatusMatchLive || after.Status != StatusMatchAbandoned {
+		t.Errorf("audited %q -> %q, want %q -> %q", before.Status, after.Status, StatusMatchLive, StatusMatchAbandoned)
+	}
+}
+
+func TestAdminOverrideRejectsInvalidRequests(t *testing.T) {
+	f := newScoreFixture(t)
+	upcoming := createMatch(t, f.db, f.match.SportID, f.creator.ID, nil)
+	score := []UpdateMatchScoreRequest{{TeamID: f.home.ID, Score: intPtr(1)}}
+
+	tests := []struct {
+		name     string
+		path     string
+		body     interface{}
+		wantCode int
+	}{
+		{"unknown status", "/admin/matches/" + itoa(f.match.ID) + "/override-status",
+			gin.H{"status": "finished", "reason": "Typo"}, http.StatusBadRequest},
+		{"blank status reason", "/admin/matches/" + itoa(f.match.ID) + "/override-status",
+			AdminOverrideStatusRequest{Status: StatusMatchCompleted, Reason: "   "}, http.StatusBadRequest},
+		{"status of unknown match", "/admin/matches/999999/override-status",
+			AdminOverrideStatusRequest{Status: StatusMatchCompleted, Reason: "Cleanup"}, http.StatusNotFound},
+		{"blank score reason", "/admin/matches/" + itoa(f.match.ID) + "/override-score",
+			AdminOverrideScoreRequest{Reason: "\t\n", Scores: score}, http.StatusBadRequest},
+		{"score of unknown match", "/admin/matches/999999/override-score",
+			AdminOverrideScoreRequest{Reason: "Cleanup", Scores: score}, http.StatusNotFound},
+		{"score of upcoming match", "/admin/matches/" + itoa(upcoming.ID) + "/override-score",
+			AdminOverrideScoreRequest{Reason: "Cleanup", Scores: score}, http.StatusBadRequest},
+		{"unknown result status", "/admin/matches/" + itoa(f.match.ID) + "/override-score",
+			AdminOverrideScoreRequest{Reason: "Cleanup", Scores: []UpdateMatchScoreRequest{
+				{TeamID: f.home.ID, Score: intPtr(1), ResultStatus: "victory"},
+			}}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := testutil.Request(t, f.adminRouter(), http.MethodPost, tt.path, tt.body); w.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+		})
+	}
+
+	if entries := f.auditEntries(t); len(entries) != 0 {
+		t.Errorf("rejected overrides left %d audit entries", len(entries))
+	}
+	if w := testutil.Request(t, f.adminRouter(), http.MethodGet, "/admin/audit?entity_id=abc", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("audit log with a bad entity_id status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,80 @@
+package match
+
+import "time"
+
+// TournamentBracket is the tree of a tournament's matches grouped by round, for drawing a bracket
+type TournamentBracket struct {
+	TournamentID uint           `json:"tournament_id"`
+	Format       string         `json:"format"`
+	Rounds       []BracketRound `json:"rounds"`
+	Unplaced     []BracketMatch `json:"unplaced,omitempty"` // Tournament matches without a round
+}
+
+// BracketRound holds the matches of one round, top to bottom
+type BracketRound struct {
+	Round   int            `json:"round"`
+	Matches []BracketMatch `json:"matches"`
+}
+
+// BracketMatch is one match in the bracket with a slot per team
+type BracketMatch struct {
+	MatchID       uint          `json:"match_id"`
+	Position      *int          `json:"position,omitempty"`
+	Status        MatchStatus   `json:"status"`
+	ScheduledAt   time.Time     `json:"scheduled_at"`
+	WinningTeamID *uint         `json:"winning_team_id,omitempty"`
+	ThirdPlace    bool          `json:"third_place,omitempty"`
+	Slots         []BracketSlot `json:"slots"`
+}
+
+// BracketSlot is a team's place in a bracket match
+type BracketSlot struct {
+	TeamID       uint   `json:"team_id"`
+	TeamName     string `json:"team_name"`
+	TeamLogo     string `json:"team_logo,omitempty"`
+	Score        int    `json:"score"`
+	ResultStatus string `json:"result_status,omitempty"`
+	IsWinner     bool   `json:"is_winner"`
+}
+
+// buildBracket groups matches, already sorted by round and position, into rounds
+func buildBracket(tournament *Tournament, matches []Match) TournamentBracket {
+	bracket := TournamentBracket{
+		TournamentID: tournament.ID,
+		Format:       tournament.Format,
+		Rounds:       []BracketRound{},
+	}
+
+	for _, m := range matches {
+		bm := BracketMatch{
+			MatchID:       m.ID,
+			Position:      m.BracketPosition,
+			Status:        m.Status,
+			ScheduledAt:   m.ScheduledAt,
+			WinningTeamID: m.WinningTeamID,
+			ThirdPlace:    m.ThirdPlace,
+			Slots:         make([]BracketSlot, 0, len(m.MatchTeams)),
+		}
+		for _, mt := range m.MatchTeams {
+			bm.Slots = append(bm.Slots, BracketSlot{
+				TeamID:       mt.TeamID,
+				TeamName:     mt.Team.Name,
+				TeamLogo:     mt.Team.Logo,
+				Score:        mt.Score,
+				ResultStatus: mt.ResultStatus,
+				IsWinner:     m.WinningTeamID != nil && *m.WinningTeamID == mt.TeamID,
+			})
+		}
+
+		if m.Round == nil {
+			bracket.Unplaced = append(bracket.Unplaced, bm)
+			continue
+		}
+		if n := len(bracket.Rounds); n == 0 || bracket.Rounds[n-1].Round != *m.Round {
+			bracket.Rounds = append(bracket.Rounds, BracketRound{Round: *m.Round})
+		}
+		last := &bracket.Rounds[len(bracket.Rounds)-1]
+		last.Matches = append(last.Matches, bm)
+	}
+	return bracket
+}
@@ -0,0 +1,127 @@
+package match
+
+import (
+	"context"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/pkg/cache"
+)
+
+// cachedMatchRepository serves GetTournamentByID from the cache. The entry is dropped when the
+// tournament, its registered teams or its matches change through the repository; status
+// updates of individual tournament matches may show up only after the TTL.
+type cachedMatchRepository struct {
+	MatchRepository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachedMatchRepository wraps a MatchRepository with cache-aside reads of tournament details
+func NewCachedMatchRepository(repo MatchRepository, c cache.Cache, ttl time.Duration) MatchRepository {
+	return &cachedMatchRepository{MatchRepository: repo, cache: c, ttl: ttl}
+}
+
+func tournamentCacheKey(id uint) string {
+	return cache.Key("tournament", id)
+}
+
+func (r *cachedMatchRepository) invalidateTournament(id uint) {
+	cache.Invalidate(context.Background(), r.cache, tournamentCacheKey(id))
+}
+
+func (r *cachedMatchRepository) GetTournamentByID(id uint) (*Tournament, error) {
+	ctx := context.Background()
+	var tournament Tournament
+	if cache.GetJSON(ctx, r.cache, tournamentCacheKey(id), &tournament) {
+		return &tournament, nil
+	}
+
+	found, err := r.MatchRepository.GetTournamentByID(id)
+	if err != nil || found == nil {
+		return found, err
+	}
+	cache.SetJSON(ctx, r.cache, tournamentCacheKey(id), found, r.ttl)
+	return found, nil
+}
+
+func (r *cachedMatchRepository) UpdateTournament(tournament *Tournament) error {
+	err := r.MatchRepository.UpdateTournament(tournament)
+	r.invalidateTournament(tournament.ID)
+	return err
+}
+
+func (r *cachedMatchRepository) DeleteTournament(id uint) error {
+	err := r.MatchRepository.DeleteTournament(id)
+	r.invalidateTournament(id)
+	return err
+}
+
+func (r *cachedMatchRepository) RegisterTeamInTournament(tournamentID uint, teamID uint, userID uint) error {
+	err := r.MatchRepository.RegisterTeamInTournament(tournamentID, teamID, userID)
+	r.invalidateTournament(tournamentID)
+	return err
+}
+
+func (r *cachedMatchRepository) UnregisterTeamFromTournament(tournamentID uint, teamID uint) error {
+	err := r.MatchRepository.UnregisterTeamFromTournament(tournamentID, teamID)
+	r.invalidateTournament(tournamentID)
+	return err
+}
+
+func (r *cachedMatchRepository) RegenerateTournamentFixtures(tournamentID uint) (*FixtureRegeneration, error) {
+	result, err := r.MatchRepository.RegenerateTournamentFixtures(tournamentID)
+	r.invalidateTournament(tournamentID)
+	return result, err
+}
+
+func (r *cachedMatchRepository) CreateMatch(match *Match) error {
+	err := r.MatchRepository.CreateMatch(match)
+	if match.TournamentID != nil {
+		r.invalidateTournament(*match.TournamentID)
+	}
+	return err
+}
+
+func (r *cachedMatchRepository) UpdateMatch(match *Match) error {
+	err := r.MatchRepository.UpdateMatch(match)
+	if match.TournamentID != nil {
+		r.invalidateTournament(*match.TournamentID)
+	}
+	return err
+}
+
+func (r *cachedMatchRepository) EndMatch(matchID uint, result string, winningTeamID *uint, ranks map[uint]int) error {
+	err := r.MatchRepository.EndMatch(matchID, result, winningTeamID, ranks)
+	if match, getErr := r.MatchRepository.GetMatchByID(matchID); getErr == nil && match != nil && match.TournamentID != nil {
+		r.invalidateTournament(*match.TournamentID)
+	}
+	return err
+}
+
+func (r *cachedMatchRepository) ForfeitMatch(matchID, forfeitingTeamID uint, summary string) error {
+	err := r.MatchRepository.ForfeitMatch(matchID, forfeitingTeamID, summary)
+	if match, getErr := r.MatchRepository.GetMatchByID(matchID); getErr == nil && match != nil && match.TournamentID != nil {
+		r.invalidateTournament(*match.TournamentID)
+	}
+	return err
+}
+
+func (r *cachedMatchRepository) RecomputeTournamentTeamCount(tournamentID uint) (int, int, error) {
+	previous, current, err := r.MatchRepository.RecomputeTournamentTeamCount(tournamentID)
+	r.invalidateTournament(tournamentID)
+	return previous, current, err
+}
+
+func (r *cachedMatchRepository) RepairTournamentTeamCounts() ([]uint, error) {
+	ids, err := r.MatchRepository.RepairTournamentTeamCounts()
+	for _, id := range ids {
+		r.invalidateTournament(id)
+	}
+	return ids, err
+}
+
+func (r *cachedMatchRepository) WithdrawTeamFromTournament(tournamentID, teamID uint) ([]uint, error) {
+	forfeited, err := r.MatchRepository.WithdrawTeamFromTournament(tournamentID, teamID)
+	r.invalidateTournament(tournamentID)
+	return forfeited, err
+}
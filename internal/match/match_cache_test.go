@@ -0,0 +1,93 @@
+package match
+
+import (
+	"testing"
+
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+)
+
+// stubTournamentRepository serves tournaments and matches from memory and counts the
+// tournament reads that reach it
+type stubTournamentRepository struct {
+	MatchRepository
+	tournaments map[uint]*Tournament
+	matches     map[uint]*Match
+	reads       int
+}
+
+func (r *stubTournamentRepository) GetTournamentByID(id uint) (*Tournament, error) {
+	r.reads++
+	if tournament, ok := r.tournaments[id]; ok {
+		copied := *tournament
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *stubTournamentRepository) RegisterTeamInTournament(tournamentID, teamID, userID uint) error {
+	r.tournaments[tournamentID].CurrentTeams++
+	return nil
+}
+
+func (r *stubTournamentRepository) GetMatchByID(id uint) (*Match, error) {
+	return r.matches[id], nil
+}
+
+func (r *stubTournamentRepository) EndMatch(matchID uint, result string, winningTeamID *uint, ranks map[uint]int) error {
+	r.matches[matchID].Status = StatusMatchCompleted
+	return nil
+}
+
+func TestCachedMatchRepositoryTournaments(t *testing.T) {
+	tournamentID := uint(2)
+	stub := &stubTournamentRepository{
+		tournaments: map[uint]*Tournament{tournamentID: {Name: "Cup"}},
+		matches:     map[uint]*Match{8: {TournamentID: &tournamentID}, 9: {}},
+	}
+	c := testutil.NewMemoryCache()
+	repo := NewCachedMatchRepository(stub, c, 0)
+
+	get := func() *Tournament {
+		t.Helper()
+		tournament, err := repo.GetTournamentByID(tournamentID)
+		if err != nil || tournament == nil {
+			t.Fatalf("GetTournamentByID() = %v, %v", tournament, err)
+		}
+		return tournament
+	}
+
+	get()
+	get()
+	if stub.reads != 1 || c.Hits != 1 || c.Misses != 1 {
+		t.Fatalf("%d database reads, %d hits, %d misses; want 1, 1, 1", stub.reads, c.Hits, c.Misses)
+	}
+
+	// Registering a team changes the tournament, so the next read goes to the database
+	if err := repo.RegisterTeamInTournament(tournamentID, 5, 1); err != nil {
+		t.Fatalf("RegisterTeamInTournament() error = %v", err)
+	}
+	if tournament := get(); tournament.CurrentTeams != 1 || stub.reads != 2 {
+		t.Errorf("after registration %d teams with %d reads, want 1 with 2", tournament.CurrentTeams, stub.reads)
+	}
+
+	// Ending a match outside the tournament keeps the entry; ending one of its matches drops it
+	if err := repo.EndMatch(9, "", nil, nil); err != nil {
+		t.Fatalf("EndMatch() error = %v", err)
+	}
+	if !c.Has(tournamentCacheKey(tournamentID)) {
+		t.Error("ending an unrelated match invalidated the tournament")
+	}
+	if err := repo.EndMatch(8, "", nil, nil); err != nil {
+		t.Fatalf("EndMatch() error = %v", err)
+	}
+	if c.Has(tournamentCacheKey(tournamentID)) {
+		t.Error("ending a tournament match left the tournament cached")
+	}
+
+	if tournament, err := repo.GetTournamentByID(99); err != nil || tournament != nil {
+		t.Errorf("GetTournamentByID() of a missing tournament = %v, %v; want nil, nil", tournament, err)
+	}
+	if c.Has(tournamentCacheKey(99)) {
+		t.Error("a missing tournament was cached")
+	}
+}
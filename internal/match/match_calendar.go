@@ -0,0 +1,237 @@
+package match
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/internal/models"
+	"github.com/DhavalSuthar-24/miow/internal/venue"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/DhavalSuthar-24/miow/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	calendarTokenBytes = 32
+	calendarMaxEvents  = 200 // Per kind: upcoming matches and confirmed bookings
+	calendarProductID  = "-//Miow//Schedule//EN"
+	icsTimeFormat      = "20060102T150405Z"
+	icsMaxLineOctets   = 75
+)
+
+// calendarEvent is one VEVENT in a user's calendar feed
+type calendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Geo         *models.Coordinates
+	Start       time.Time
+	End         time.Time
+}
+
+// hashCalendarToken returns the hex SHA-256 of a feed token; only the hash is stored
+func hashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// RotateCalendarToken issues a new calendar feed token for the current user. Calendar apps
+// cannot send a JWT, so the feed is authorised by this token instead; issuing a new one
+// revokes the previous token.
+func (mc *MatchController) RotateCalendarToken(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	token := utils.GenerateRandomToken(calendarTokenBytes)
+	if err := mc.repo.SetCalendarTokenHash(userID, hashCalendarToken(token)); err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to create calendar token: "+err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Calendar token created. Subscribe to calendar.ics?token=<token>; it is not shown again.",
+		gin.H{"token": token})
+}
+
+// GetCalendarFeed serves the iCalendar feed of the token owner's upcoming matches and
+// confirmed bookings
+func (mc *MatchController) GetCalendarFeed(c *gin.Context) {
+	token := c.Query("token")
+	if token == "" {
+		response.Error(c, http.StatusUnauthorized, "Calendar token required")
+		return
+	}
+
+	userID, err := mc.repo.GetUserIDByCalendarTokenHash(hashCalendarToken(token))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check calendar token: "+err.Error())
+		return
+	}
+	if userID == 0 {
+		response.Error(c, http.StatusUnauthorized, "Invalid calendar token")
+		return
+	}
+
+	matches, _, err := mc.repo.GetUserMatches(userID, string(StatusMatchUpcoming), 1, calendarMaxEvents)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch matches: "+err.Error())
+		return
+	}
+	bookings, err := mc.repo.GetUserConfirmedBookings(userID, time.Now(), calendarMaxEvents)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch bookings: "+err.Error())
+		return
+	}
+
+	events := make([]calendarEvent, 0, len(matches)+len(bookings))
+	for _, m := range matches {
+		events = append(events, matchCalendarEvent(m))
+	}
+	for _, b := range bookings {
+		events = append(events, bookingCalendarEvent(b))
+	}
+
+	c.Header("Content-Disposition", `inline; filename="calendar.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(renderCalendar(events, time.Now())))
+}
+
+// matchCalendarEvent describes a match as "Team A vs Team B (Sport)" at its venue
+func matchCalendarEvent(m Match) calendarEvent {
+	names := make([]string, 0, len(m.MatchTeams))
+	for _, mt := range m.MatchTeams {
+		if mt.Team.Name != "" {
+			names = append(names, mt.Team.Name)
+		}
+	}
+	summary := "Match"
+	if len(names) > 0 {
+		summary = strings.Join(names, " vs ")
+	}
+	if m.Sport.Name != "" {
+		summary += " (" + m.Sport.Name + ")"
+	}
+
+	event := calendarEvent{
+		UID:         fmt.Sprintf("match-%d@miow", m.ID),
+		Summary:     summary,
+		Description: m.Description,
+		Location:    m.LocationText,
+		Start:       m.ScheduledAt,
+		End:         matchEnd(m.ScheduledAt, m.Duration),
+	}
+	if m.Venue != nil {
+		event.Location, event.Geo = venueCalendarLocation(m.Venue)
+	}
+	return event
+}
+
+// bookingCalendarEvent describes a court booking
+func bookingCalendarEvent(b venue.Booking) calendarEvent {
+	location, geo := venueCalendarLocation(&b.Ground.Venue)
+	summary := "Booking: " + b.Ground.Name
+	if b.Ground.Venue.Name != "" {
+		summary += " at " + b.Ground.Venue.Name
+	}
+	return calendarEvent{
+		UID:         fmt.Sprintf("booking-%d@miow", b.ID),
+		Summary:     summary,
+		Description: b.Purpose,
+		Location:    location,
+		Geo:         geo,
+		Start:       b.StartTime,
+		End:         b.EndTime,
+	}
+}
+
+// venueCalendarLocation returns the venue's name and address, and its coordinates when known
+func venueCalendarLocation(v *venue.Venue) (string, *models.Coordinates) {
+	parts := make([]string, 0, 2)
+	for _, p := range []string{v.Name, v.Location} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	if coords, ok := models.ParseCoordinates(v.Coordinates); ok {
+		return strings.Join(parts, ", "), &coords
+	}
+	return strings.Join(parts, ", "), nil
+}
+
+// renderCalendar writes events as an RFC 5545 iCalendar document with CRLF line endings
+func renderCalendar(events []calendarEvent, now time.Time) string {
+	var b strings.Builder
+	line := func(s string) {
+		b.WriteString(foldICSLine(s))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:" + calendarProductID)
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:Miow")
+	for _, e := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + e.UID)
+		line("DTSTAMP:" + now.UTC().Format(icsTimeFormat))
+		line("DTSTART:" + e.Start.UTC().Format(icsTimeFormat))
+		line("DTEND:" + e.End.UTC().Format(icsTimeFormat))
+		line("SUMMARY:" + escapeICSText(e.Summary))
+		if e.Location != "" {
+			line("LOCATION:" + escapeICSText(e.Location))
+		}
+		if e.Geo != nil {
+			line(fmt.Sprintf("GEO:%f;%f", e.Geo.Latitude, e.Geo.Longitude))
+		}
+		if e.Description != "" {
+			line("DESCRIPTION:" + escapeICSText(e.Description))
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return b.String()
+}
+
+// escapeICSText escapes a TEXT property value
+func escapeICSText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(s)
+}
+
+// foldICSLine splits a content line longer than 75 octets into continuation lines that begin
+// with a space, without breaking UTF-8 sequences
+func foldICSLine(s string) string {
+	if len(s) <= icsMaxLineOctets {
+		return s
+	}
+	var b strings.Builder
+	limit := icsMaxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		limit = icsMaxLineOctets - 1 // The leading space counts towards the next line
+	}
+	b.WriteString(s)
+	return b.String()
+}
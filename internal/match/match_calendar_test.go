@@ -0,0 +1,271 @@
+package match
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/internal/venue"
+	"github.com/gin-gonic/gin"
+)
+
+// icsComponent is a parsed iCalendar component with its properties and nested components
+type icsComponent struct {
+	name       string
+	props      map[string][]string
+	components []*icsComponent
+}
+
+// parseICS checks the framing of an iCalendar document and returns its top-level component.
+// Every line must end in CRLF and be at most 75 octets; folded lines are unfolded first.
+func parseICS(t *testing.T, doc string) *icsComponent {
+	t.Helper()
+	if !strings.HasSuffix(doc, "\r\n") {
+		t.Fatalf("document does not end with CRLF")
+	}
+	raw := strings.Split(strings.TrimSuffix(doc, "\r\n"), "\r\n")
+	var lines []string
+	for i, l := range raw {
+		if len(l) > icsMaxLineOctets {
+			t.Errorf("line %d is %d octets, want at most %d: %q", i+1, len(l), icsMaxLineOctets, l)
+		}
+		if strings.ContainsAny(l, "\r\n") {
+			t.Errorf("line %d has a bare CR or LF: %q", i+1, l)
+		}
+		if strings.HasPrefix(l, " ") && len(lines) > 0 {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		lines = append(lines, l)
+	}
+
+	var stack []*icsComponent
+	var root *icsComponent
+	for _, l := range lines {
+		name, value, ok := strings.Cut(l, ":")
+		if !ok {
+			t.Fatalf("content line without a value: %q", l)
+		}
+		switch name {
+		case "BEGIN":
+			c := &icsComponent{name: value, props: make(map[string][]string)}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.components = append(parent.components, c)
+			} else if root != nil {
+				t.Fatalf("second top-level component %s", value)
+			} else {
+				root = c
+			}
+			stack = append(stack, c)
+		case "END":
+			if len(stack) == 0 || stack[len(stack)-1].name != value {
+				t.Fatalf("END:%s does not close the open component", value)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				t.Fatalf("property %s outside a component", name)
+			}
+			c := stack[len(stack)-1]
+			c.props[name] = append(c.props[name], value)
+		}
+	}
+	if len(stack) != 0 || root == nil || root.name != "VCALENDAR" {
+		t.Fatalf("document is not a single closed VCALENDAR")
+	}
+	return root
+}
+
+// prop returns the single value of a property, failing if it is missing or repeated
+func (c *icsComponent) prop(t *testing.T, name string) string {
+	t.Helper()
+	if len(c.props[name]) != 1 {
+		t.Fatalf("%s has %d %s properties, want 1", c.name, len(c.props[name]), name)
+	}
+	return c.props[name][0]
+}
+
+func TestRenderCalendar(t *testing.T) {
+	now := time.Date(2026, 4, 1, 8, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 4, 2, 18, 30, 0, 0, time.FixedZone("IST", 5*3600+1800))
+	events := []calendarEvent{
+		{
+			UID:         "match-1@miow",
+			Summary:     "Lions vs Tigers (Cricket)",
+			Description: "Bring whites; toss at 6pm\nParking at gate 2",
+			Location:    "Oval, Main Road",
+			Start:       start,
+			End:         start.Add(2 * time.Hour),
+		},
+		{
+			UID:     "booking-2@miow",
+			Summary: "Booking: " + strings.Repeat("Court ñ ", 20),
+			Start:   start,
+			End:     start.Add(time.Hour),
+		},
+	}
+
+	cal := parseICS(t, renderCalendar(events, now))
+	if cal.prop(t, "VERSION") != "2.0" || cal.prop(t, "PRODID") != calendarProductID {
+		t.Errorf("calendar properties = %v", cal.props)
+	}
+	if len(cal.components) != 2 {
+		t.Fatalf("calendar has %d components, want 2 events", len(cal.components))
+	}
+
+	match := cal.components[0]
+	if match.name != "VEVENT" {
+		t.Fatalf("component = %s, want VEVENT", match.name)
+	}
+	want := map[string]string{
+		"UID":         "match-1@miow",
+		"DTSTAMP":     "20260401T080000Z",
+		"DTSTART":     "20260402T130000Z",
+		"DTEND":       "20260402T150000Z",
+		"SUMMARY":     "Lions vs Tigers (Cricket)",
+		"LOCATION":    `Oval\, Main Road`,
+		"DESCRIPTION": `Bring whites\; toss at 6pm\nParking at gate 2`,
+	}
+	for name, value := range want {
+		if got := match.prop(t, name); got != value {
+			t.Errorf("%s = %q, want %q", name, got, value)
+		}
+	}
+
+	// The long summary is folded and unfolds back to the original text
+	booking := cal.components[1]
+	if got := booking.prop(t, "SUMMARY"); got != events[1].Summary {
+		t.Errorf("unfolded SUMMARY = %q, want %q", got, events[1].Summary)
+	}
+	if _, ok := booking.props["LOCATION"]; ok {
+		t.Errorf("event without a location has LOCATION %v", booking.props["LOCATION"])
+	}
+}
+
+func TestFoldICSLineKeepsUTF8(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("é", 100)
+	for _, part := range strings.Split(foldICSLine(line), "\r\n ") {
+		if len(part) > icsMaxLineOctets {
+			t.Errorf("folded part is %d octets: %q", len(part), part)
+		}
+		if !utf8.ValidString(part) {
+			t.Errorf("folded part splits a character: %q", part)
+		}
+	}
+	if got := strings.ReplaceAll(foldICSLine(line), "\r\n ", ""); got != line {
+		t.Errorf("unfolded line = %q, want %q", got, line)
+	}
+}
+
+func TestGetCalendarFeed(t *testing.T) {
+	db := newTestDB(t)
+	player := testutil.CreateUser(t, db, "Player")
+	rival := testutil.CreateUser(t, db, "Rival")
+	s := createSport(t, db)
+	home := createTeam(t, db, s.ID, player.ID)
+	away := createTeam(t, db, s.ID, rival.ID)
+	pitch := createVenue(t, db, rival.ID, 12.5, 77.25)
+
+	upcoming := createMatch(t, db, s.ID, rival.ID, []*team.Team{home, away}, func(m *Match) {
+		m.VenueID = &pitch.ID
+	})
+	createMatch(t, db, s.ID, rival.ID, []*team.Team{home, away}, func(m *Match) {
+		m.Status = StatusMatchCompleted
+	})
+
+	court := &venue.Ground{VenueID: pitch.ID, Name: "Court 1", Type: "court"}
+	if err := db.Omit("Venue").Create(court).Error; err != nil {
+		t.Fatalf("failed to create court: %v", err)
+	}
+	bookingStart := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	var confirmed venue.Booking
+	for _, status := range []string{"confirmed", "pending"} {
+		b := venue.Booking{GroundID: court.ID, UserID: player.ID, StartTime: bookingStart, EndTime: bookingStart.Add(time.Hour), Status: status}
+		if err := db.Omit("Ground").Create(&b).Error; err != nil {
+			t.Fatalf("failed to create booking: %v", err)
+		}
+		if status == "confirmed" {
+			confirmed = b
+		}
+	}
+
+	mc := newTestController(t, db)
+	r := gin.New()
+	r.GET("/users/me/calendar.ics", mc.GetCalendarFeed)
+	r.POST("/users/me/calendar/token", asUser(player.ID), mc.RotateCalendarToken)
+	rotate := func() string {
+		var data struct {
+			Token string `json:"token"`
+		}
+		testutil.DecodeData(t, testutil.Request(t, r, http.MethodPost, "/users/me/calendar/token", nil), &data)
+		if data.Token == "" {
+			t.Fatal("no calendar token issued")
+		}
+		return data.Token
+	}
+	feed := func(token string) *httptest.ResponseRecorder {
+		return testutil.Request(t, r, http.MethodGet, "/users/me/calendar.ics?token="+token, nil)
+	}
+
+	token := rotate()
+	w := feed(token)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/calendar") {
+		t.Errorf("Content-Type = %q, want text/calendar", ct)
+	}
+
+	cal := parseICS(t, w.Body.String())
+	events := make(map[string]*icsComponent)
+	for _, c := range cal.components {
+		events[c.prop(t, "UID")] = c
+	}
+	if len(events) != 2 {
+		t.Fatalf("feed has events %v, want the upcoming match and the confirmed booking", events)
+	}
+	match, ok := events["match-"+itoa(upcoming.ID)+"@miow"]
+	if !ok {
+		t.Fatalf("feed has no event for match %d", upcoming.ID)
+	}
+	if got, want := match.prop(t, "DTSTART"), upcoming.ScheduledAt.UTC().Format(icsTimeFormat); got != want {
+		t.Errorf("match DTSTART = %s, want %s", got, want)
+	}
+	if got, want := match.prop(t, "DTEND"), upcoming.ScheduledAt.Add(90*time.Minute).UTC().Format(icsTimeFormat); got != want {
+		t.Errorf("match DTEND = %s, want %s", got, want)
+	}
+	if got := match.prop(t, "SUMMARY"); got != home.Name+" vs "+away.Name+" ("+s.Name+")" {
+		t.Errorf("match SUMMARY = %q", got)
+	}
+	if got := match.prop(t, "LOCATION"); got != pitch.Name+`\, Test Street` {
+		t.Errorf("match LOCATION = %q", got)
+	}
+	if got := match.prop(t, "GEO"); got != "12.500000;77.250000" {
+		t.Errorf("match GEO = %q", got)
+	}
+	booking, ok := events["booking-"+itoa(confirmed.ID)+"@miow"]
+	if !ok {
+		t.Fatalf("feed has no event for booking %d", confirmed.ID)
+	}
+	if got := booking.prop(t, "SUMMARY"); got != "Booking: Court 1 at "+pitch.Name {
+		t.Errorf("booking SUMMARY = %q", got)
+	}
+
+	// Rotating the token revokes the old one
+	newToken := rotate()
+	if w := feed(token); w.Code != http.StatusUnauthorized {
+		t.Errorf("old token status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+	if w := feed(newToken); w.Code != http.StatusOK {
+		t.Errorf("new token status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if w := feed(""); w.Code != http.StatusUnauthorized {
+		t.Errorf("status without a token = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
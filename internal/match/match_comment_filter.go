@@ -0,0 +1,66 @@
+package match
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/DhavalSuthar-24/miow/config"
+)
+
+// CommentVerdict is a comment filter's decision about a comment body
+type CommentVerdict int
+
+const (
+	CommentAllow  CommentVerdict = iota
+	CommentFlag                  // Stored, but hidden from everyone except the author and moderators
+	CommentReject                // Refused
+)
+
+// CommentFilter screens match comments before they are stored. Implementations must be safe
+// for concurrent use.
+type CommentFilter interface {
+	Check(body string) CommentVerdict
+}
+
+// WordListFilter returns its verdict for comments containing any of its words, compared case
+// insensitively as whole words, and allows the rest
+type WordListFilter struct {
+	words   map[string]struct{}
+	verdict CommentVerdict
+}
+
+// NewWordListFilter creates a filter for the given words; blank entries are ignored
+func NewWordListFilter(words []string, verdict CommentVerdict) *WordListFilter {
+	f := &WordListFilter{words: make(map[string]struct{}, len(words)), verdict: verdict}
+	for _, w := range words {
+		if w = strings.ToLower(strings.TrimSpace(w)); w != "" {
+			f.words[w] = struct{}{}
+		}
+	}
+	return f
+}
+
+// Check implements CommentFilter
+func (f *WordListFilter) Check(body string) CommentVerdict {
+	if len(f.words) == 0 {
+		return CommentAllow
+	}
+	tokens := strings.FieldsFunc(strings.ToLower(body), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, t := range tokens {
+		if _, blocked := f.words[t]; blocked {
+			return f.verdict
+		}
+	}
+	return CommentAllow
+}
+
+// commentFilterFromConfig builds the default word list filter from COMMENT_BLOCKED_WORDS
+func commentFilterFromConfig(appConfig *config.Config) CommentFilter {
+	verdict := CommentFlag
+	if appConfig.Comments.FilterAction == "reject" {
+		verdict = CommentReject
+	}
+	return NewWordListFilter(strings.Split(appConfig.Comments.BlockedWords, ","), verdict)
+}
@@ -2,43 +2,67 @@ package match
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/DhavalSuthar-24/miow/config"
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/internal/notification"
 	"github.com/DhavalSuthar-24/miow/internal/team"
-	responses "github.com/DhavalSuthar-24/miow/pkg/matchresponse"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/DhavalSuthar-24/miow/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	challengeShortNotice = 2 * time.Hour // Challenges proposed closer than this get a warning
+	challengeClashWindow = 3 * time.Hour // Existing team matches within this window get a warning
+
+	staleLiveScoreAfter      = 30 * time.Minute // Live matches without a score change for this long need an update
+	maxActionRequiredMatches = 100
+)
+
 // MatchController handles match-related HTTP requests
 type MatchController struct {
-	repo      MatchRepository
-	teamRepo  team.TeamRepository
-	appConfig *config.Config
+	repo           MatchRepository
+	teamRepo       team.TeamRepository
+	appConfig      *config.Config
+	notifier       notification.Notifier
+	commentFilter  CommentFilter
+	commentLimiter *middleware.RateLimiter // Per user; nil when comment rate limiting is disabled
+	// Tournament report download formats, by ?format= value
+	reportRenderers map[string]TournamentReportRenderer
 }
 
 // NewMatchController creates a new match controller
-func NewMatchController(repo MatchRepository, teamRepo team.TeamRepository, appConfig *config.Config) *MatchController {
-	return &MatchController{
-		repo:      repo,
-		teamRepo:  teamRepo,
-		appConfig: appConfig,
-	}
+func NewMatchController(repo MatchRepository, teamRepo team.TeamRepository, appConfig *config.Config, notifier notification.Notifier) *MatchController {
+	mc := &MatchController{
+		repo:          repo,
+		teamRepo:      teamRepo,
+		appConfig:     appConfig,
+		notifier:      notifier,
+		commentFilter: commentFilterFromConfig(appConfig),
+		reportRenderers: map[string]TournamentReportRenderer{
+			"csv": CSVReportRenderer{},
+		},
+	}
+	if appConfig.Comments.PerUserPerMinute > 0 {
+		mc.commentLimiter = middleware.NewRateLimiter(appConfig.Comments.PerUserPerMinute, time.Minute)
+	}
+	return mc
 }
 
-// --- Helper Functions for Auth ---
-
-func getCurrentUserID(c *gin.Context) (uint, bool) {
-	userIDVal, exists := c.Get("currentUserID")
-	if !exists {
-		return 0, false
-	}
-	userID, ok := userIDVal.(uint)
-	return userID, ok
+// SetCommentFilter replaces the filter that screens match comments, which by default uses
+// COMMENT_BLOCKED_WORDS
+func (mc *MatchController) SetCommentFilter(filter CommentFilter) {
+	mc.commentFilter = filter
 }
 
+// --- Helper Functions for Auth ---
+
 // isTeamMember checks if the user is a member of the team
 func (mc *MatchController) isTeamMember(teamID, userID uint) (bool, error) {
 	member, err := mc.teamRepo.GetTeamMember(teamID, userID)
@@ -108,6 +132,7 @@ type UpdateChallengeRequest struct {
 	TeamSize         *int       `json:"team_size,omitempty"`
 	AdditionalRules  *string    `json:"additional_rules,omitempty"`
 	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	Version          *int       `json:"version,omitempty"` // Version last read; a changed challenge is not overwritten
 }
 
 // CreateDirectMatchRequest defines the request payload for creating a match directly
@@ -126,6 +151,17 @@ type CreateDirectMatchRequest struct {
 	SkillLevel   string    `json:"skill_level,omitempty"`
 	CustomRules  string    `json:"custom_rules,omitempty"`
 	Visibility   string    `json:"visibility" binding:"omitempty,oneof=public private unlisted"`
+	AutoStart    bool      `json:"auto_start"`    // Go live automatically at scheduled_at
+	AutoComplete bool      `json:"auto_complete"` // Complete automatically after duration, leaving the result pending
+}
+
+// EndMatchRequest defines the payload for ending a match. Result defaults to "win", which
+// requires winning_team_id, or for matches of more than two teams, rankings of every team;
+// "draw" and "no_result" must not name a winner.
+type EndMatchRequest struct {
+	Result        string        `json:"result" binding:"omitempty,oneof=win draw no_result"`
+	WinningTeamID uint          `json:"winning_team_id"`
+	Rankings      []TeamRanking `json:"rankings,omitempty" binding:"omitempty,dive"`
 }
 
 // UpdateMatchRequest defines the request payload for updating a match
@@ -140,13 +176,24 @@ type UpdateMatchRequest struct {
 	Visibility   *string    `json:"visibility,omitempty" binding:"omitempty,oneof=public private unlisted"`
 	StreamURL    *string    `json:"stream_url,omitempty"`
 	VodURL       *string    `json:"vod_url,omitempty"`
+	AutoStart    *bool      `json:"auto_start,omitempty"`
+	AutoComplete *bool      `json:"auto_complete,omitempty"`
+	Version      *int       `json:"version,omitempty"` // Version last read; a changed match is not overwritten
 }
 
 // UpdateMatchScoreRequest defines the request payload for updating match scores
 type UpdateMatchScoreRequest struct {
 	TeamID       uint   `json:"team_id" binding:"required"`
-	Score        int    `json:"score" binding:"required"`
-	ResultStatus string `json:"result_status,omitempty"`
+	Score        *int   `json:"score" binding:"required"`
+	Increment    bool   `json:"increment"` // Add Score to the current score instead of replacing it
+	ResultStatus string `json:"result_status,omitempty" binding:"omitempty,oneof=win loss draw tie no_result forfeit"`
+}
+
+// SetPeriodScoreRequest defines the request payload for recording a team's score in one period
+type SetPeriodScoreRequest struct {
+	TeamID uint `json:"team_id" binding:"required"`
+	Period int  `json:"period" binding:"required,min=1"`
+	Value  *int `json:"value" binding:"required,min=0"`
 }
 
 // CreateTournamentRequest defines the request payload for creating a tournament
@@ -163,6 +210,7 @@ type CreateTournamentRequest struct {
 	PrizePool            float64   `json:"prize_pool,omitempty"`
 	EntryFee             float64   `json:"entry_fee,omitempty"`
 	MaxTeams             int       `json:"max_teams" binding:"required,min=2"`
+	ThirdPlaceMatch      bool      `json:"third_place_match,omitempty"` // Knockouts only
 }
 
 // UpdateTournamentRequest defines the request payload for updating a tournament
@@ -179,27 +227,40 @@ type UpdateTournamentRequest struct {
 	EntryFee             *float64   `json:"entry_fee,omitempty"`
 	MaxTeams             *int       `json:"max_teams,omitempty" binding:"omitempty,min=2"`
 	Status               *string    `json:"status,omitempty" binding:"omitempty,oneof=registration_open upcoming ongoing completed cancelled"`
+	ThirdPlaceMatch      *bool      `json:"third_place_match,omitempty"` // Knockouts only
+	Version              *int       `json:"version,omitempty"`           // Version last read; a changed tournament is not overwritten
 }
 
 // --- Challenge Controller Methods ---
 
 // CreateChallenge handles the creation of a new challenge
 func (mc *MatchController) CreateChallenge(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req CreateChallengeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.ValidationErrorResponse(c, err)
+		response.ValidationError(c, err)
 		return
 	}
 
 	// Validate challenge type and required fields
 	if err := mc.validateChallengeRequest(req, userID); err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, err.Error())
+		response.Error(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Soft checks: warn by default, reject when ?strict=true
+	warnings, err := mc.challengeWarnings(req)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to validate challenge: "+err.Error())
+		return
+	}
+	if len(warnings) > 0 && utils.StrictMode(c) {
+		response.ErrorWithDetails(c, http.StatusUnprocessableEntity, "Challenge rejected in strict mode", gin.H{"warnings": warnings})
 		return
 	}
 
@@ -236,14 +297,63 @@ func (mc *MatchController) CreateChallenge(c *gin.Context) {
 
 	// Save challenge
 	if err := mc.repo.CreateChallenge(&challenge); err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to create challenge: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to create challenge: "+err.Error())
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusCreated, gin.H{
+	resp := gin.H{
 		"message":   "Challenge created successfully",
 		"challenge": challenge,
-	})
+	}
+	if len(warnings) > 0 {
+		resp["warnings"] = warnings
+	}
+	response.Success(c, http.StatusCreated, "", resp)
+}
+
+// minAcceptLeadTime is how long before the proposed start a challenge must be accepted
+func (mc *MatchController) minAcceptLeadTime() time.Duration {
+	return time.Duration(mc.appConfig.Challenges.MinAcceptLeadMinutes) * time.Minute
+}
+
+// checkAcceptLead verifies that a match starting at start can still be agreed to under
+// CHALLENGE_MIN_ACCEPT_LEAD_MINUTES. It writes the error response and returns false when it cannot.
+func (mc *MatchController) checkAcceptLead(c *gin.Context, start time.Time) bool {
+	lead := mc.minAcceptLeadTime()
+	if lead <= 0 || time.Until(start) >= lead {
+		return true
+	}
+	response.ErrorWithDetails(c, http.StatusUnprocessableEntity,
+		fmt.Sprintf("Challenges must be accepted at least %d minutes before the proposed start time", int(lead.Minutes())),
+		gin.H{"accept_by": start.Add(-lead)})
+	return false
+}
+
+// challengeWarnings returns non-blocking issues with a challenge request, such as
+// short notice or a team that already has a match around the proposed time.
+func (mc *MatchController) challengeWarnings(req CreateChallengeRequest) (utils.Warnings, error) {
+	var warnings utils.Warnings
+
+	if until := time.Until(req.ProposedDateTime); until < challengeShortNotice {
+		warnings.Add("proposed time is less than %d hours away", int(challengeShortNotice.Hours()))
+	}
+	if req.ExpiresAt != nil && req.ExpiresAt.After(req.ProposedDateTime) {
+		warnings.Add("challenge expires after the proposed match time")
+	}
+
+	for _, teamID := range []*uint{req.SenderTeamID, req.ReceiverTeamID} {
+		if teamID == nil {
+			continue
+		}
+		count, err := mc.repo.CountTeamMatchesAround(*teamID, req.ProposedDateTime, challengeClashWindow)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			warnings.Add("team %d already has a match within %d hours of the proposed time", *teamID, int(challengeClashWindow.Hours()))
+		}
+	}
+	return warnings, nil
 }
 
 // validateChallengeRequest validates the challenge request based on challenge type
@@ -279,6 +389,13 @@ func (mc *MatchController) validateChallengeRequest(req CreateChallengeRequest,
 		if !isManager {
 			return errors.New("you must be a team manager to create team challenges")
 		}
+		blocked, err := mc.teamRepo.IsTeamBlocked(*req.ReceiverTeamID, *req.SenderTeamID)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return errors.New("the receiving team is not accepting challenges from your team")
+		}
 	case OpenChallengeIndividual:
 		// For open individual challenges, sender user must be the current user
 		if req.SenderUserID == nil {
@@ -332,14 +449,26 @@ func (mc *MatchController) GetChallenges(c *gin.Context) {
 		filters["challenge_type"] = challengeType
 	}
 
+	// Hide open challenges from teams that blocked one of the user's teams
+	if userID, ok := middleware.CurrentUserID(c); ok {
+		blockingTeamIDs, err := mc.teamRepo.GetTeamsBlockingUser(userID)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, "Failed to check team blocks: "+err.Error())
+			return
+		}
+		if len(blockingTeamIDs) > 0 {
+			filters["NOT (challenge_type = '"+string(OpenChallengeTeam)+"' AND sender_team_id IN ?)"] = blockingTeamIDs
+		}
+	}
+
 	// Get challenges
 	challenges, total, err := mc.repo.GetChallenges(filters, page, pageSize)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch challenges: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch challenges: "+err.Error())
 		return
 	}
 
-	responses.PaginatedResponse(c, http.StatusOK, challenges, page, pageSize, total)
+	response.Paginated(c, http.StatusOK, "", challenges, total, page, pageSize)
 }
 
 // GetChallengeByID retrieves a specific challenge by ID
@@ -347,48 +476,137 @@ func (mc *MatchController) GetChallengeByID(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid challenge ID")
+		response.Error(c, http.StatusBadRequest, "Invalid challenge ID")
 		return
 	}
 
 	challenge, err := mc.repo.GetChallengeByID(uint(id))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch challenge: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch challenge: "+err.Error())
 		return
 	}
 
 	if challenge == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Challenge not found")
+		response.Error(c, http.StatusNotFound, "Challenge not found")
+		return
+	}
+
+	response.Success(c, http.StatusOK, "", challenge)
+}
+
+// GetEligibleTeams lists the requester's teams that can accept a team challenge: teams they
+// manage that pass teamCanAccept. Direct challenges only list the invited team.
+func (mc *MatchController) GetEligibleTeams(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid challenge ID")
+		return
+	}
+
+	challenge, err := mc.repo.GetChallengeByID(uint(id))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch challenge: "+err.Error())
+		return
+	}
+	if challenge == nil {
+		response.Error(c, http.StatusNotFound, "Challenge not found")
+		return
+	}
+	if challenge.ChallengeType != OpenChallengeTeam && challenge.ChallengeType != DirectChallengeTeam {
+		response.Error(c, http.StatusBadRequest, "Only team challenges can be accepted by a team")
+		return
+	}
+	if (challenge.Status != StatusOpen && challenge.Status != StatusPending) || challenge.ScheduledMatchID != nil {
+		response.Error(c, http.StatusConflict, "Challenge has already been accepted or is no longer open")
+		return
+	}
+
+	managedTeams, err := mc.teamRepo.GetTeamsManagedByUserID(userID, challenge.SportID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch your teams: "+err.Error())
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, challenge)
+	eligible := make([]team.Team, 0, len(managedTeams))
+	for i := range managedTeams {
+		canAccept, err := mc.teamCanAccept(challenge, &managedTeams[i])
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, "Failed to check team eligibility: "+err.Error())
+			return
+		}
+		if canAccept {
+			eligible = append(eligible, managedTeams[i])
+		}
+	}
+
+	response.Success(c, http.StatusOK, "", eligible)
+}
+
+// teamCanAccept reports whether the team may accept the team challenge: it is not the sending
+// team, is the invited team of a direct challenge, plays the challenge's sport at a fitting
+// skill level, has enough active players for the requested team size and is not blocked by
+// the sending team.
+func (mc *MatchController) teamCanAccept(challenge *Challenge, t *team.Team) (bool, error) {
+	if challenge.SenderTeamID != nil && *challenge.SenderTeamID == t.ID {
+		return false, nil
+	}
+	if challenge.ChallengeType == DirectChallengeTeam && (challenge.ReceiverTeamID == nil || *challenge.ReceiverTeamID != t.ID) {
+		return false, nil
+	}
+	if t.SportID != challenge.SportID || !skillInRange(t.Level, challenge.MinSkillLevel, challenge.MaxSkillLevel) {
+		return false, nil
+	}
+	if challenge.TeamSize != nil {
+		players, err := mc.teamRepo.CountActiveTeamMembers(t.ID)
+		if err != nil {
+			return false, err
+		}
+		if players < int64(*challenge.TeamSize) {
+			return false, nil
+		}
+	}
+	if challenge.SenderTeamID != nil {
+		blocked, err := mc.teamRepo.IsTeamBlocked(*challenge.SenderTeamID, t.ID)
+		if err != nil {
+			return false, err
+		}
+		if blocked {
+			return false, nil
+		}
+	}
+	return true, nil
 }
 
 // UpdateChallenge updates an existing challenge
 func (mc *MatchController) UpdateChallenge(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid challenge ID")
+		response.Error(c, http.StatusBadRequest, "Invalid challenge ID")
 		return
 	}
 
 	// Get existing challenge
 	challenge, err := mc.repo.GetChallengeByID(uint(id))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch challenge: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch challenge: "+err.Error())
 		return
 	}
 
 	if challenge == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Challenge not found")
+		response.Error(c, http.StatusNotFound, "Challenge not found")
 		return
 	}
 
@@ -405,20 +623,20 @@ func (mc *MatchController) UpdateChallenge(c *gin.Context) {
 		}
 
 		if !isAuthorized {
-			responses.ErrorResponse(c, http.StatusForbidden, "You are not authorized to update this challenge")
+			response.Error(c, http.StatusForbidden, "You are not authorized to update this challenge")
 			return
 		}
 	}
 
 	// Check if challenge is in a valid state to be updated
 	if challenge.Status != StatusOpen && challenge.Status != StatusPending {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Cannot update challenge in its current state")
+		response.Error(c, http.StatusBadRequest, "Cannot update challenge in its current state")
 		return
 	}
 
 	var req UpdateChallengeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.ValidationErrorResponse(c, err)
+		response.ValidationError(c, err)
 		return
 	}
 
@@ -462,42 +680,48 @@ func (mc *MatchController) UpdateChallenge(c *gin.Context) {
 	if req.ExpiresAt != nil {
 		challenge.ExpiresAt = req.ExpiresAt
 	}
+	if req.Version != nil {
+		challenge.Version = *req.Version
+	}
 
 	if err := mc.repo.UpdateChallenge(challenge); err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to update challenge: "+err.Error())
+		if errors.Is(err, ErrVersionConflict) {
+			response.Error(c, http.StatusConflict, "Challenge was changed by someone else; fetch it again and retry")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to update challenge: "+err.Error())
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{
-		"message":   "Challenge updated successfully",
+	response.Success(c, http.StatusOK, "Challenge updated successfully", gin.H{
 		"challenge": challenge,
 	})
 }
 
 // DeleteChallenge handles deleting a challenge
 func (mc *MatchController) DeleteChallenge(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid challenge ID")
+		response.Error(c, http.StatusBadRequest, "Invalid challenge ID")
 		return
 	}
 
 	// Get existing challenge
 	challenge, err := mc.repo.GetChallengeByID(uint(id))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch challenge: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch challenge: "+err.Error())
 		return
 	}
 
 	if challenge == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Challenge not found")
+		response.Error(c, http.StatusNotFound, "Challenge not found")
 		return
 	}
 
@@ -514,32 +738,30 @@ func (mc *MatchController) DeleteChallenge(c *gin.Context) {
 		}
 
 		if !isAuthorized {
-			responses.ErrorResponse(c, http.StatusForbidden, "You are not authorized to delete this challenge")
+			response.Error(c, http.StatusForbidden, "You are not authorized to delete this challenge")
 			return
 		}
 	}
 
 	// Check if challenge is in a valid state to be deleted
 	if challenge.Status == StatusAccepted && challenge.ScheduledMatchID != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Cannot delete a challenge that has been accepted and has a scheduled match")
+		response.Error(c, http.StatusBadRequest, "Cannot delete a challenge that has been accepted and has a scheduled match")
 		return
 	}
 
 	if err := mc.repo.DeleteChallenge(uint(id)); err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete challenge: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to delete challenge: "+err.Error())
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{
-		"message": "Challenge deleted successfully",
-	})
+	response.Success(c, http.StatusOK, "Challenge deleted successfully", nil)
 }
 
 // GetUserChallenges retrieves all challenges related to the current user
 func (mc *MatchController) GetUserChallenges(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
@@ -555,36 +777,36 @@ func (mc *MatchController) GetUserChallenges(c *gin.Context) {
 
 	challenges, total, err := mc.repo.GetUserChallenges(userID, status, page, pageSize)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch challenges: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch challenges: "+err.Error())
 		return
 	}
 
-	responses.PaginatedResponse(c, http.StatusOK, challenges, page, pageSize, total)
+	response.Paginated(c, http.StatusOK, "", challenges, total, page, pageSize)
 }
 
 // GetTeamChallenges retrieves all challenges related to a specific team
 func (mc *MatchController) GetTeamChallenges(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	teamIDStr := c.Param("teamId")
 	teamID, err := strconv.Atoi(teamIDStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid team ID")
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
 	// Check if user is a member of the team
 	isMember, err := mc.isTeamMember(uint(teamID), userID)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to check team membership: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to check team membership: "+err.Error())
 		return
 	}
 	if !isMember {
-		responses.ErrorResponse(c, http.StatusForbidden, "You must be a member of the team to view its challenges")
+		response.Error(c, http.StatusForbidden, "You must be a member of the team to view its challenges")
 		return
 	}
 
@@ -600,37 +822,154 @@ func (mc *MatchController) GetTeamChallenges(c *gin.Context) {
 
 	challenges, total, err := mc.repo.GetTeamChallenges(uint(teamID), status, page, pageSize)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch challenges: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch challenges: "+err.Error())
+		return
+	}
+
+	response.Paginated(c, http.StatusOK, "", challenges, total, page, pageSize)
+}
+
+// GetMatchmakingChallenges suggests open challenges for the current user's sports and skill level,
+// nearest venues and soonest matches first. sport_id and skill_level override the user's profile,
+// latitude/longitude override their stored location. Only the soonest matchmakingCandidateLimit
+// open challenges are ranked.
+func (mc *MatchController) GetMatchmakingChallenges(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	profile, err := mc.repo.GetMatchmakingProfile(userID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to load matchmaking profile: "+err.Error())
+		return
+	}
+	if profile == nil {
+		response.Error(c, http.StatusNotFound, "User not found")
+		return
+	}
+
+	levels := profile.SportLevels
+	if sportIDStr := c.Query("sport_id"); sportIDStr != "" {
+		sportID, err := strconv.Atoi(sportIDStr)
+		if err != nil || sportID < 1 {
+			response.Error(c, http.StatusBadRequest, "Invalid sport ID")
+			return
+		}
+		levels = map[uint]string{uint(sportID): profile.SportLevels[uint(sportID)]}
+	}
+	if skillLevel := c.Query("skill_level"); skillLevel != "" {
+		if skillRank(skillLevel) == 0 {
+			response.Error(c, http.StatusBadRequest, "Invalid skill level")
+			return
+		}
+		for id := range levels {
+			levels[id] = skillLevel
+		}
+	}
+
+	origin := profile.Coordinates
+	if latStr, lngStr := c.Query("latitude"), c.Query("longitude"); latStr != "" || lngStr != "" {
+		lat, latErr := strconv.ParseFloat(latStr, 64)
+		lng, lngErr := strconv.ParseFloat(lngStr, 64)
+		if latErr != nil || lngErr != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid latitude or longitude")
+			return
+		}
+		origin.Latitude, origin.Longitude = lat, lng
+	}
+
+	sportIDs := make([]uint, 0, len(levels))
+	for id := range levels {
+		sportIDs = append(sportIDs, id)
+	}
+	blockingTeamIDs, err := mc.teamRepo.GetTeamsBlockingUser(userID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check team blocks: "+err.Error())
+		return
+	}
+	challenges, err := mc.repo.GetMatchmakingCandidates(userID, sportIDs, blockingTeamIDs, matchmakingCandidateLimit)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch challenges: "+err.Error())
+		return
+	}
+
+	ranked := rankMatchmakingChallenges(challenges, levels, origin)
+	total := len(ranked)
+	start := (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end := start + pageSize
+	if end > total {
+		end = total
+	}
+
+	response.Paginated(c, http.StatusOK, "", ranked[start:end], int64(total), page, pageSize)
+}
+
+// respondScheduleConflict reports a schedule clash with the conflicting matches as details
+func respondScheduleConflict(c *gin.Context, err error) {
+	var conflictErr *ScheduleConflictError
+	if !errors.As(err, &conflictErr) {
+		response.Error(c, http.StatusConflict, "Team already has a match scheduled at that time")
 		return
 	}
+	response.ErrorWithDetails(c, http.StatusConflict, "Team already has a match scheduled at that time", conflictErr.Conflicts)
+}
 
-	responses.PaginatedResponse(c, http.StatusOK, challenges, page, pageSize, total)
+// AcceptChallengeRequest names the team accepting a team challenge. It is required for open
+// team challenges, which are not sent to a particular team; see GetEligibleTeams.
+type AcceptChallengeRequest struct {
+	TeamID uint `json:"team_id,omitempty"`
 }
 
 // AcceptChallenge handles accepting a challenge
 func (mc *MatchController) AcceptChallenge(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid challenge ID")
+		response.Error(c, http.StatusBadRequest, "Invalid challenge ID")
 		return
 	}
 
+	var req AcceptChallengeRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.ValidationError(c, err)
+			return
+		}
+	}
+
 	// Get challenge
 	challenge, err := mc.repo.GetChallengeByID(uint(id))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch challenge: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch challenge: "+err.Error())
 		return
 	}
 
 	if challenge == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Challenge not found")
+		response.Error(c, http.StatusNotFound, "Challenge not found")
+		return
+	}
+
+	if !mc.checkAcceptLead(c, challenge.ProposedDateTime) {
 		return
 	}
 
@@ -639,71 +978,131 @@ func (mc *MatchController) AcceptChallenge(c *gin.Context) {
 	if challenge.ChallengeType == OpenChallengeTeam || challenge.ChallengeType == DirectChallengeTeam {
 		acceptorType = "team"
 
-		// Check if user is a team manager for the receiving team
+		// The receiving team is the invited one, or for an open challenge the one the user picked
+		teamID := req.TeamID
 		if challenge.ReceiverTeamID != nil {
-			isManager, err := mc.isTeamManager(*challenge.ReceiverTeamID, userID)
-			if err != nil {
-				responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
+			if teamID != 0 && teamID != *challenge.ReceiverTeamID {
+				response.Error(c, http.StatusForbidden, "This challenge was sent to another team")
 				return
 			}
-			if !isManager {
-				responses.ErrorResponse(c, http.StatusForbidden, "You must be a team manager to accept challenges")
-				return
-			}
-		} else {
-			responses.ErrorResponse(c, http.StatusBadRequest, "Invalid challenge: no receiver team specified")
+			teamID = *challenge.ReceiverTeamID
+		} else if teamID == 0 {
+			response.Error(c, http.StatusBadRequest, "team_id is required to accept an open team challenge")
+			return
+		}
+		if challenge.SenderTeamID != nil && *challenge.SenderTeamID == teamID {
+			response.Error(c, http.StatusBadRequest, "A team cannot accept its own challenge")
+			return
+		}
+		req.TeamID = teamID
+
+		// Check if user is a team manager for the receiving team
+		isManager, err := mc.isTeamManager(teamID, userID)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
+			return
+		}
+		if !isManager {
+			response.Error(c, http.StatusForbidden, "You must be a team manager to accept challenges")
+			return
+		}
+
+		// Hold the accepting team to the same rules as the GetEligibleTeams listing
+		accepting, err := mc.teamRepo.GetTeamByID(teamID)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, "Failed to fetch team: "+err.Error())
+			return
+		}
+		if accepting == nil {
+			response.Error(c, http.StatusNotFound, "Team not found")
+			return
+		}
+		canAccept, err := mc.teamCanAccept(challenge, accepting)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, "Failed to check team eligibility: "+err.Error())
+			return
+		}
+		if !canAccept {
+			response.Error(c, http.StatusForbidden, "This challenge is not available to your team")
 			return
 		}
 	} else if challenge.ChallengeType == OpenChallengeIndividual || challenge.ChallengeType == DirectChallengeIndividual {
 		acceptorType = "individual"
 
-		// Check if user is the receiver
-		if challenge.ReceiverUserID == nil || *challenge.ReceiverUserID != userID {
-			responses.ErrorResponse(c, http.StatusForbidden, "You are not authorized to accept this challenge")
+		// Check if user is the receiver; anyone but the sender may take an open challenge
+		if challenge.ReceiverUserID == nil && challenge.ChallengeType == OpenChallengeIndividual {
+			if challenge.CreatedByUserID == userID || (challenge.SenderUserID != nil && *challenge.SenderUserID == userID) {
+				response.Error(c, http.StatusBadRequest, "You cannot accept your own challenge")
+				return
+			}
+		} else if challenge.ReceiverUserID == nil || *challenge.ReceiverUserID != userID {
+			response.Error(c, http.StatusForbidden, "You are not authorized to accept this challenge")
 			return
 		}
 	} else {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid challenge type")
+		response.Error(c, http.StatusBadRequest, "Invalid challenge type")
 		return
 	}
 
 	// Accept challenge
-	if err := mc.repo.AcceptChallenge(uint(id), userID, acceptorType); err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to accept challenge: "+err.Error())
+	if err := mc.repo.AcceptChallenge(uint(id), userID, acceptorType, req.TeamID); err != nil {
+		switch {
+		case errors.Is(err, ErrChallengeNotFound):
+			response.Error(c, http.StatusNotFound, "Challenge not found")
+		case errors.Is(err, ErrChallengeNotAcceptable):
+			response.Error(c, http.StatusConflict, "Challenge has already been accepted or is no longer open")
+		case errors.Is(err, ErrRegisteringTeamNotFound):
+			response.Error(c, http.StatusNotFound, "Team not found")
+		case errors.Is(err, ErrNotTeamManager), errors.Is(err, ErrNotChallengeReceiver):
+			response.Error(c, http.StatusForbidden, "You are not authorized to accept this challenge")
+		case errors.Is(err, ErrAcceptingTeamRequired):
+			response.Error(c, http.StatusBadRequest, "team_id is required to accept an open team challenge")
+		case errors.Is(err, ErrScheduleConflict):
+			respondScheduleConflict(c, err)
+		default:
+			response.Error(c, http.StatusInternalServerError, "Failed to accept challenge: "+err.Error())
+		}
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{
-		"message": "Challenge accepted successfully",
+	mc.notifier.Notify(notification.Message{
+		UserID:       challenge.CreatedByUserID,
+		Type:         notification.TypeChallengeAccepted,
+		Title:        "Challenge accepted",
+		Body:         fmt.Sprintf("Your challenge %q has been accepted.", challenge.Title),
+		ResourceType: "challenge",
+		ResourceID:   challenge.ID,
 	})
+
+	response.Success(c, http.StatusOK, "Challenge accepted successfully", nil)
 }
 
 // --- Missing Controller Methods ---
 
 // RejectChallenge handles rejecting a challenge
 func (mc *MatchController) RejectChallenge(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid challenge ID")
+		response.Error(c, http.StatusBadRequest, "Invalid challenge ID")
 		return
 	}
 
 	// Get challenge
 	challenge, err := mc.repo.GetChallengeByID(uint(id))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch challenge: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch challenge: "+err.Error())
 		return
 	}
 
 	if challenge == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Challenge not found")
+		response.Error(c, http.StatusNotFound, "Challenge not found")
 		return
 	}
 
@@ -716,15 +1115,15 @@ func (mc *MatchController) RejectChallenge(c *gin.Context) {
 		if challenge.ReceiverTeamID != nil {
 			isManager, err := mc.isTeamManager(*challenge.ReceiverTeamID, userID)
 			if err != nil {
-				responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
+				response.Error(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
 				return
 			}
 			if !isManager {
-				responses.ErrorResponse(c, http.StatusForbidden, "You must be a team manager to reject challenges")
+				response.Error(c, http.StatusForbidden, "You must be a team manager to reject challenges")
 				return
 			}
 		} else {
-			responses.ErrorResponse(c, http.StatusBadRequest, "Invalid challenge: no receiver team specified")
+			response.Error(c, http.StatusBadRequest, "Invalid challenge: no receiver team specified")
 			return
 		}
 	} else if challenge.ChallengeType == OpenChallengeIndividual || challenge.ChallengeType == DirectChallengeIndividual {
@@ -732,97 +1131,305 @@ func (mc *MatchController) RejectChallenge(c *gin.Context) {
 
 		// Check if user is the receiver
 		if challenge.ReceiverUserID == nil || *challenge.ReceiverUserID != userID {
-			responses.ErrorResponse(c, http.StatusForbidden, "You are not authorized to reject this challenge")
+			response.Error(c, http.StatusForbidden, "You are not authorized to reject this challenge")
 			return
 		}
 	} else {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid challenge type")
+		response.Error(c, http.StatusBadRequest, "Invalid challenge type")
 		return
 	}
 
 	// Reject challenge
 	if err := mc.repo.RejectChallenge(uint(id), userID, rejectorType); err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to reject challenge: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to reject challenge: "+err.Error())
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{
-		"message": "Challenge rejected successfully",
-	})
+	response.Success(c, http.StatusOK, "Challenge rejected successfully", nil)
 }
 
-// CancelChallenge handles canceling a challenge
-func (mc *MatchController) CancelChallenge(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+// CounterChallengeRequest defines the payload for proposing different terms for a challenge
+type CounterChallengeRequest struct {
+	ProposedDateTime *time.Time `json:"proposed_date_time,omitempty"`
+	VenueID          *uint      `json:"venue_id,omitempty"`
+	Message          string     `json:"message,omitempty" binding:"max=1000"`
+}
+
+// isChallengeReceiver checks if the user can respond to the challenge on behalf of its receiver.
+// It is always false for a challenge without a receiver (see hasReceiver).
+func (mc *MatchController) isChallengeReceiver(challenge *Challenge, userID uint) (bool, error) {
+	switch challenge.ChallengeType {
+	case OpenChallengeTeam, DirectChallengeTeam:
+		if challenge.ReceiverTeamID == nil {
+			return false, nil
+		}
+		return mc.isTeamManager(*challenge.ReceiverTeamID, userID)
+	case OpenChallengeIndividual, DirectChallengeIndividual:
+		return challenge.ReceiverUserID != nil && *challenge.ReceiverUserID == userID, nil
+	}
+	return false, nil
+}
+
+// isChallengeCreator checks if the user created the challenge or manages the sending team
+func (mc *MatchController) isChallengeCreator(challenge *Challenge, userID uint) (bool, error) {
+	if challenge.CreatedByUserID == userID {
+		return true, nil
+	}
+	if challenge.SenderTeamID != nil {
+		return mc.isTeamManager(*challenge.SenderTeamID, userID)
+	}
+	return false, nil
+}
+
+// CounterChallenge lets the receiver propose a different date and/or venue instead of accepting
+func (mc *MatchController) CounterChallenge(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
-	idStr := c.Param("id")
-	id, err := strconv.Atoi(idStr)
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid challenge ID")
+		response.Error(c, http.StatusBadRequest, "Invalid challenge ID")
+		return
+	}
+
+	var req CounterChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+	if req.ProposedDateTime == nil && req.VenueID == nil {
+		response.Error(c, http.StatusBadRequest, "A counter offer must propose a new date/time or venue")
+		return
+	}
+	if req.ProposedDateTime != nil && !req.ProposedDateTime.After(time.Now()) {
+		response.Error(c, http.StatusBadRequest, "Proposed date/time must be in the future")
 		return
 	}
 
-	// Get challenge
 	challenge, err := mc.repo.GetChallengeByID(uint(id))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch challenge: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch challenge: "+err.Error())
 		return
 	}
-
 	if challenge == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Challenge not found")
+		response.Error(c, http.StatusNotFound, "Challenge not found")
 		return
 	}
 
-	// Check authorization - only creator or team manager can cancel
-	if challenge.CreatedByUserID != userID {
-		isAuthorized := false
-
-		// Check if user is a team manager for the sender team
-		if challenge.SenderTeamID != nil {
-			isManager, err := mc.isTeamManager(*challenge.SenderTeamID, userID)
-			if err != nil {
-				responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
-				return
-			}
-			if isManager {
-				isAuthorized = true
-			}
+	// Counter offers are negotiated with a named receiver; anyone else should accept the
+	// challenge as it stands
+	if !challenge.hasReceiver() {
+		response.Error(c, http.StatusConflict, "Challenges without a receiver cannot be countered")
+		return
+	}
+	isReceiver, err := mc.isChallengeReceiver(challenge, userID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
+		return
+	}
+	if !isReceiver {
+		response.Error(c, http.StatusForbidden, "Only the challenged party can counter this challenge")
+		return
+	}
+	if req.ProposedDateTime != nil && !mc.checkAcceptLead(c, *req.ProposedDateTime) {
+		return
+	}
+	if req.VenueID != nil {
+		exists, err := mc.teamRepo.VenueExists(*req.VenueID)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, "Failed to check venue: "+err.Error())
+			return
 		}
-
-		if !isAuthorized {
-			responses.ErrorResponse(c, http.StatusForbidden, "You are not authorized to cancel this challenge")
+		if !exists {
+			response.Error(c, http.StatusBadRequest, "Venue not found")
 			return
 		}
 	}
 
-	// Update challenge status
-	challenge.Status = StatusCancelled
-	if err := mc.repo.UpdateChallenge(challenge); err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to cancel challenge: "+err.Error())
+	offer := ChallengeCounterOffer{
+		ChallengeID:      challenge.ID,
+		ProposedByUserID: userID,
+		ProposedDateTime: req.ProposedDateTime,
+		VenueID:          req.VenueID,
+		Message:          req.Message,
+	}
+	if err := mc.repo.CounterChallenge(&offer); err != nil {
+		switch {
+		case errors.Is(err, ErrChallengeNotFound):
+			response.Error(c, http.StatusNotFound, "Challenge not found")
+		case errors.Is(err, ErrChallengeNotCounterable):
+			response.Error(c, http.StatusConflict, "Challenge is no longer open to counter offers")
+		default:
+			response.Error(c, http.StatusInternalServerError, "Failed to counter challenge: "+err.Error())
+		}
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{
-		"message": "Challenge cancelled successfully",
+	response.Success(c, http.StatusCreated, "Counter offer sent successfully", gin.H{
+		"counter_offer": offer,
 	})
 }
 
-// CreateDirectMatch handles creating a match directly without a challenge
-func (mc *MatchController) CreateDirectMatch(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
-	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
-		return
+// AcceptCounterOffer lets the challenge creator accept the pending counter offer, scheduling the match on its terms
+func (mc *MatchController) AcceptCounterOffer(c *gin.Context) {
+	mc.respondToCounterOffer(c, true)
+}
+
+// RejectCounterOffer lets the challenge creator decline the pending counter offer
+func (mc *MatchController) RejectCounterOffer(c *gin.Context) {
+	mc.respondToCounterOffer(c, false)
+}
+
+func (mc *MatchController) respondToCounterOffer(c *gin.Context, accept bool) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid challenge ID")
+		return
+	}
+
+	challenge, err := mc.repo.GetChallengeByID(uint(id))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch challenge: "+err.Error())
+		return
+	}
+	if challenge == nil {
+		response.Error(c, http.StatusNotFound, "Challenge not found")
+		return
+	}
+
+	isCreator, err := mc.isChallengeCreator(challenge, userID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
+		return
+	}
+	if !isCreator {
+		response.Error(c, http.StatusForbidden, "Only the challenge creator can respond to counter offers")
+		return
+	}
+
+	// The counter offer may move the match closer; it must still leave the accept lead time
+	if accept {
+		start := challenge.ProposedDateTime
+		if offer := challenge.pendingCounterOffer(); offer != nil && offer.ProposedDateTime != nil {
+			start = *offer.ProposedDateTime
+		}
+		if !mc.checkAcceptLead(c, start) {
+			return
+		}
+	}
+
+	message := "Counter offer rejected successfully"
+	if accept {
+		err = mc.repo.AcceptCounterOffer(challenge.ID)
+		message = "Counter offer accepted successfully"
+	} else {
+		err = mc.repo.RejectCounterOffer(challenge.ID)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrChallengeNotFound):
+			response.Error(c, http.StatusNotFound, "Challenge not found")
+		case errors.Is(err, ErrNoPendingCounterOffer):
+			response.Error(c, http.StatusConflict, "Challenge has no pending counter offer")
+		case errors.Is(err, ErrScheduleConflict):
+			respondScheduleConflict(c, err)
+		default:
+			response.Error(c, http.StatusInternalServerError, "Failed to respond to counter offer: "+err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, message, nil)
+}
+
+// CancelChallenge handles canceling a challenge
+func (mc *MatchController) CancelChallenge(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid challenge ID")
+		return
+	}
+
+	// Get challenge
+	challenge, err := mc.repo.GetChallengeByID(uint(id))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch challenge: "+err.Error())
+		return
+	}
+
+	if challenge == nil {
+		response.Error(c, http.StatusNotFound, "Challenge not found")
+		return
+	}
+
+	// Check authorization - only creator or team manager can cancel
+	if challenge.CreatedByUserID != userID {
+		isAuthorized := false
+
+		// Check if user is a team manager for the sender team
+		if challenge.SenderTeamID != nil {
+			isManager, err := mc.isTeamManager(*challenge.SenderTeamID, userID)
+			if err != nil {
+				response.Error(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
+				return
+			}
+			if isManager {
+				isAuthorized = true
+			}
+		}
+
+		if !isAuthorized {
+			response.Error(c, http.StatusForbidden, "You are not authorized to cancel this challenge")
+			return
+		}
+	}
+
+	// Update challenge status
+	challenge.Status = StatusCancelled
+	if err := mc.repo.UpdateChallenge(challenge); err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			response.Error(c, http.StatusConflict, "Challenge was changed by someone else; fetch it again and retry")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to cancel challenge: "+err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Challenge cancelled successfully", nil)
+}
+
+// CreateDirectMatch handles creating a match directly without a challenge
+func (mc *MatchController) CreateDirectMatch(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
 	}
 
 	var req CreateDirectMatchRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.ValidationErrorResponse(c, err)
+		response.ValidationError(c, err)
+		return
+	}
+
+	if req.AutoComplete && req.Duration <= 0 {
+		response.Error(c, http.StatusBadRequest, "auto_complete requires a duration")
 		return
 	}
 
@@ -830,21 +1437,25 @@ func (mc *MatchController) CreateDirectMatch(c *gin.Context) {
 	// Check if user is a manager for both teams
 	isTeam1Manager, err := mc.isTeamManager(req.Team1ID, userID)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to validate team 1: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to validate team 1: "+err.Error())
 		return
 	}
 	if !isTeam1Manager {
-		responses.ErrorResponse(c, http.StatusForbidden, "You must be a manager of team 1 to create a match")
+		response.Error(c, http.StatusForbidden, "You must be a manager of team 1 to create a match")
 		return
 	}
 
 	isTeam2Manager, err := mc.isTeamManager(req.Team2ID, userID)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to validate team 2: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to validate team 2: "+err.Error())
 		return
 	}
 	if !isTeam2Manager {
-		responses.ErrorResponse(c, http.StatusForbidden, "You must be a manager of team 2 to create a match")
+		response.Error(c, http.StatusForbidden, "You must be a manager of team 2 to create a match")
+		return
+	}
+
+	if !mc.checkTeamsPerMatch(c, req.SportID, 2) {
 		return
 	}
 
@@ -863,45 +1474,60 @@ func (mc *MatchController) CreateDirectMatch(c *gin.Context) {
 		SkillLevel:      req.SkillLevel,
 		Status:          StatusMatchUpcoming,
 		Visibility:      req.Visibility,
+		AutoStart:       req.AutoStart,
+		AutoComplete:    req.AutoComplete,
 	}
 
-	// Begin transaction to create match and add teams
-	err = mc.repo.WithTransaction(func(txRepo MatchRepository) error {
-		// Create match
-		if err := txRepo.CreateMatch(&match); err != nil {
+	if !mc.createMatchWithTeams(c, &match, []uint{req.Team1ID, req.Team2ID}) {
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Match created successfully", gin.H{
+		"match": match,
+	})
+}
+
+// createMatchWithTeams creates the match and adds the teams in one transaction, refusing teams
+// that already have a match at the same time. It writes the error response and returns false
+// on failure.
+func (mc *MatchController) createMatchWithTeams(c *gin.Context, match *Match, teamIDs []uint) bool {
+	err := mc.repo.WithTransaction(func(txRepo MatchRepository) error {
+		conflicts, err := txRepo.FindScheduleConflicts(teamIDs,
+			match.ScheduledAt, matchEnd(match.ScheduledAt, match.Duration), 0)
+		if err != nil {
 			return err
 		}
-
-		// Add team 1
-		team1 := MatchTeam{
-			MatchID: match.ID,
-			TeamID:  req.Team1ID,
+		if len(conflicts) > 0 {
+			return &ScheduleConflictError{Conflicts: conflicts}
 		}
-		if err := txRepo.AddTeamToMatch(&team1); err != nil {
+
+		// Create match
+		if err := txRepo.CreateMatch(match); err != nil {
 			return err
 		}
 
-		// Add team 2
-		team2 := MatchTeam{
-			MatchID: match.ID,
-			TeamID:  req.Team2ID,
-		}
-		if err := txRepo.AddTeamToMatch(&team2); err != nil {
-			return err
+		for _, teamID := range teamIDs {
+			matchTeam := MatchTeam{
+				MatchID: match.ID,
+				TeamID:  teamID,
+			}
+			if err := txRepo.AddTeamToMatch(&matchTeam); err != nil {
+				return err
+			}
 		}
 
 		return nil
 	})
 
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to create match: "+err.Error())
-		return
+		if errors.Is(err, ErrScheduleConflict) {
+			respondScheduleConflict(c, err)
+			return false
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to create match: "+err.Error())
+		return false
 	}
-
-	responses.SuccessResponse(c, http.StatusCreated, gin.H{
-		"message": "Match created successfully",
-		"match":   match,
-	})
+	return true
 }
 
 // GetMatchByID retrieves a specific match by ID
@@ -909,77 +1535,65 @@ func (mc *MatchController) GetMatchByID(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid match ID")
+		response.Error(c, http.StatusBadRequest, "Invalid match ID")
 		return
 	}
 
 	match, err := mc.repo.GetMatchByID(uint(id))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
 		return
 	}
 
 	if match == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Match not found")
+		response.Error(c, http.StatusNotFound, "Match not found")
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, match)
+	response.Success(c, http.StatusOK, "", match)
 }
 
 // UpdateMatch updates an existing match
 func (mc *MatchController) UpdateMatch(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid match ID")
+		response.Error(c, http.StatusBadRequest, "Invalid match ID")
 		return
 	}
 
 	// Get existing match
 	match, err := mc.repo.GetMatchByID(uint(id))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
 		return
 	}
 
 	if match == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Match not found")
+		response.Error(c, http.StatusNotFound, "Match not found")
 		return
 	}
 
 	// Check authorization - only creator or team manager can update
-	if match.CreatedByUserID != userID {
-		isAuthorized := false
-
-		// Check if user is a manager for any of the participating teams
-		for _, matchTeam := range match.MatchTeams {
-			isManager, err := mc.isTeamManager(matchTeam.TeamID, userID)
-			if err != nil {
-				responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
-				return
-			}
-			if isManager {
-				isAuthorized = true
-				break
-			}
-		}
-
-		if !isAuthorized {
-			responses.ErrorResponse(c, http.StatusForbidden, "You are not authorized to update this match")
-			return
-		}
+	isAuthorized, err := mc.canManageMatch(match, userID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
+		return
+	}
+	if !isAuthorized {
+		response.Error(c, http.StatusForbidden, "You are not authorized to update this match")
+		return
 	}
 
 	var req UpdateMatchRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.ValidationErrorResponse(c, err)
+		response.ValidationError(c, err)
 		return
 	}
 
@@ -1014,73 +1628,115 @@ func (mc *MatchController) UpdateMatch(c *gin.Context) {
 	if req.VodURL != nil {
 		match.VodURL = *req.VodURL
 	}
+	if req.AutoStart != nil {
+		match.AutoStart = *req.AutoStart
+	}
+	if req.AutoComplete != nil {
+		match.AutoComplete = *req.AutoComplete
+	}
+	if match.AutoComplete && match.Duration <= 0 {
+		response.Error(c, http.StatusBadRequest, "auto_complete requires a duration")
+		return
+	}
+	if req.Version != nil {
+		match.Version = *req.Version
+	}
 
 	if err := mc.repo.UpdateMatch(match); err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to update match: "+err.Error())
+		if errors.Is(err, ErrVersionConflict) {
+			response.Error(c, http.StatusConflict, "Match was changed by someone else; fetch it again and retry")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to update match: "+err.Error())
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{
-		"message": "Match updated successfully",
-		"match":   match,
+	response.Success(c, http.StatusOK, "Match updated successfully", gin.H{
+		"match": match,
 	})
 }
 
 // DeleteMatch handles deleting a match
 func (mc *MatchController) DeleteMatch(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid match ID")
+		response.Error(c, http.StatusBadRequest, "Invalid match ID")
 		return
 	}
 
 	// Get existing match
 	match, err := mc.repo.GetMatchByID(uint(id))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
 		return
 	}
 
 	if match == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Match not found")
+		response.Error(c, http.StatusNotFound, "Match not found")
 		return
 	}
 
 	// Check authorization - only creator or admin can delete
 	if match.CreatedByUserID != userID {
-		responses.ErrorResponse(c, http.StatusForbidden, "You are not authorized to delete this match")
+		response.Error(c, http.StatusForbidden, "You are not authorized to delete this match")
 		return
 	}
 
 	// Check if match can be deleted (not started or completed)
 	if match.Status == StatusMatchLive || match.Status == StatusMatchCompleted {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Cannot delete a match that is live or completed")
+		response.Error(c, http.StatusBadRequest, "Cannot delete a match that is live or completed")
 		return
 	}
 
 	if err := mc.repo.DeleteMatch(uint(id)); err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete match: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to delete match: "+err.Error())
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{
-		"message": "Match deleted successfully",
-	})
+	response.Success(c, http.StatusOK, "Match deleted successfully", nil)
+}
+
+// MatchExpand selects the related resources loaded with a match list. Nothing extra is loaded
+// unless asked for.
+type MatchExpand struct {
+	WinningTeam bool // The winning team's id, name and logo
+}
+
+// parseMatchExpand reads the comma-separated ?expand= of a match list. It writes the error
+// response and returns false for an unknown resource.
+func parseMatchExpand(c *gin.Context) (MatchExpand, bool) {
+	var expand MatchExpand
+	for _, field := range strings.Split(c.Query("expand"), ",") {
+		switch strings.TrimSpace(strings.ToLower(field)) {
+		case "winning_team":
+			expand.WinningTeam = true
+		case "":
+		default:
+			response.Error(c, http.StatusBadRequest, "Invalid expand value: "+strings.TrimSpace(field))
+			return expand, false
+		}
+	}
+	return expand, true
 }
 
-// GetMatches retrieves matches based on filters
+// GetMatches retrieves matches based on filters. With expand=winning_team the winning team of
+// each finished match is included.
 func (mc *MatchController) GetMatches(c *gin.Context) {
 	// Parse query parameters for filters
 	sportID := c.Query("sport_id")
 	status := c.Query("status")
 	visibility := c.Query("visibility")
+	expand, ok := parseMatchExpand(c)
+	if !ok {
+		return
+	}
 
 	// Parse pagination parameters
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -1108,20 +1764,47 @@ func (mc *MatchController) GetMatches(c *gin.Context) {
 	}
 
 	// Get matches
-	matches, total, err := mc.repo.GetMatches(filters, page, pageSize)
+	matches, total, err := mc.repo.GetMatches(filters, expand, page, pageSize)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch matches: "+err.Error())
+		return
+	}
+
+	response.Paginated(c, http.StatusOK, "", matches, total, page, pageSize)
+}
+
+// GetVenueResults lists completed public matches played at a venue with scores and winners
+func (mc *MatchController) GetVenueResults(c *gin.Context) {
+	venueID, err := strconv.Atoi(c.Param("venue_id"))
+	if err != nil || venueID < 1 {
+		response.Error(c, http.StatusBadRequest, "Invalid venue ID")
+		return
+	}
+
+	// Parse pagination parameters
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	matches, total, err := mc.repo.GetVenueResults(uint(venueID), page, pageSize)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch matches: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch venue results: "+err.Error())
 		return
 	}
 
-	responses.PaginatedResponse(c, http.StatusOK, matches, page, pageSize, total)
+	response.Paginated(c, http.StatusOK, "", matches, total, page, pageSize)
 }
 
 // GetUserMatches retrieves all matches related to the current user
 func (mc *MatchController) GetUserMatches(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
@@ -1137,36 +1820,79 @@ func (mc *MatchController) GetUserMatches(c *gin.Context) {
 
 	matches, total, err := mc.repo.GetUserMatches(userID, status, page, pageSize)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch matches: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch matches: "+err.Error())
 		return
 	}
 
-	responses.PaginatedResponse(c, http.StatusOK, matches, page, pageSize, total)
+	response.Paginated(c, http.StatusOK, "", matches, total, page, pageSize)
+}
+
+// Actions reported by GetActionRequiredMatches
+const (
+	ActionUpdateScore  = "update_score"
+	ActionRecordResult = "record_result"
+	ActionStartMatch   = "start_match"
+)
+
+// ActionRequiredMatch is a match waiting on its organizer, with the action they need to take
+type ActionRequiredMatch struct {
+	Action string `json:"action"`
+	Match  Match  `json:"match"`
+}
+
+// GetActionRequiredMatches lists matches the current user created or manages a team in that
+// need their attention: stale live scores, missing results and overdue starts
+func (mc *MatchController) GetActionRequiredMatches(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	matches, err := mc.repo.GetActionRequiredMatches(userID, time.Now(), staleLiveScoreAfter, maxActionRequiredMatches)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch matches: "+err.Error())
+		return
+	}
+
+	items := make([]ActionRequiredMatch, 0, len(matches))
+	for _, match := range matches {
+		action := ActionStartMatch
+		switch match.Status {
+		case StatusMatchLive:
+			action = ActionUpdateScore
+		case StatusMatchCompleted:
+			action = ActionRecordResult
+		}
+		items = append(items, ActionRequiredMatch{Action: action, Match: match})
+	}
+
+	response.Success(c, http.StatusOK, "", items)
 }
 
 // GetTeamMatches retrieves all matches related to a specific team
 func (mc *MatchController) GetTeamMatches(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	teamIDStr := c.Param("teamId")
 	teamID, err := strconv.Atoi(teamIDStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid team ID")
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
 	// Check if user is a member of the team
 	isMember, err := mc.isTeamMember(uint(teamID), userID)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to check team membership: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to check team membership: "+err.Error())
 		return
 	}
 	if !isMember {
-		responses.ErrorResponse(c, http.StatusForbidden, "You must be a member of the team to view its matches")
+		response.Error(c, http.StatusForbidden, "You must be a member of the team to view its matches")
 		return
 	}
 
@@ -1182,289 +1908,716 @@ func (mc *MatchController) GetTeamMatches(c *gin.Context) {
 
 	matches, total, err := mc.repo.GetTeamMatches(uint(teamID), status, page, pageSize)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch matches: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch matches: "+err.Error())
 		return
 	}
 
-	responses.PaginatedResponse(c, http.StatusOK, matches, page, pageSize, total)
+	response.Paginated(c, http.StatusOK, "", matches, total, page, pageSize)
 }
 
-// StartMatch handles starting a match
-func (mc *MatchController) StartMatch(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+// GetTeamRecord returns a team's win/loss record in completed and forfeited matches, optionally
+// limited to one tournament (tournament_id) or to tournament matches in general (tournament_only)
+func (mc *MatchController) GetTeamRecord(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("teamId"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+
+	var tournamentID *uint
+	if idStr := c.Query("tournament_id"); idStr != "" {
+		id, err := strconv.ParseUint(idStr, 10, 32)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid tournament ID")
+			return
+		}
+		tid := uint(id)
+		tournamentID = &tid
+	}
+	tournamentOnly, _ := strconv.ParseBool(c.DefaultQuery("tournament_only", "false"))
+
+	record, err := mc.repo.GetTeamRecord(uint(teamID), tournamentID, tournamentOnly)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch team record: "+err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "", record)
+}
+
+// LineupPlayerRequest is a single player selection in a lineup
+type LineupPlayerRequest struct {
+	UserID    uint   `json:"user_id" binding:"required"`
+	Position  string `json:"position,omitempty" binding:"max=100"`
+	IsStarter *bool  `json:"is_starter,omitempty"` // Defaults to true
+}
+
+// SetLineupRequest defines the payload for setting a team's lineup
+type SetLineupRequest struct {
+	Players []LineupPlayerRequest `json:"players" binding:"required,dive"`
+}
+
+// isLineupLocked reports whether the match has progressed past the point where lineups may change
+func isLineupLocked(status MatchStatus) bool {
+	switch status {
+	case StatusMatchLive, StatusMatchCompleted, StatusMatchCancelled, StatusMatchForfeited, StatusMatchAbandoned:
+		return true
+	}
+	return false
+}
+
+// lineupSizeLimit returns the maximum number of starters per team: the team size agreed in the
+// originating challenge, otherwise the sport's max players. Zero means no limit.
+func lineupSizeLimit(match *Match) int {
+	if match.Challenge != nil && match.Challenge.TeamSize != nil && *match.Challenge.TeamSize > 0 {
+		return *match.Challenge.TeamSize
+	}
+	return match.Sport.Rules.MaxPlayers
+}
+
+// SetMatchLineup replaces a team's lineup for a match. Only the team's managers can set it,
+// every player must be an active member of the team, and it is locked once the match goes live.
+func (mc *MatchController) SetMatchLineup(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
-	idStr := c.Param("id")
-	id, err := strconv.Atoi(idStr)
+	matchID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid match ID")
+		return
+	}
+	teamID, err := strconv.Atoi(c.Param("team_id"))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid match ID")
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
-	// Get match
-	match, err := mc.repo.GetMatchByID(uint(id))
+	var req SetLineupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	match, err := mc.repo.GetMatchByID(uint(matchID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
+		return
+	}
+	if match == nil {
+		response.Error(c, http.StatusNotFound, "Match not found")
+		return
+	}
+	if isLineupLocked(match.Status) {
+		response.Error(c, http.StatusConflict, "Lineups are locked once the match has started")
+		return
+	}
+
+	matchTeam, err := mc.repo.GetMatchTeam(match.ID, uint(teamID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch match team: "+err.Error())
+		return
+	}
+	if matchTeam == nil {
+		response.Error(c, http.StatusNotFound, "Team is not part of this match")
+		return
+	}
+
+	isManager, err := mc.isTeamManager(uint(teamID), userID)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
+		return
+	}
+	if !isManager {
+		response.Error(c, http.StatusForbidden, "You must be a team manager to set the lineup")
 		return
 	}
 
+	playerIDs := make([]uint, 0, len(req.Players))
+	seen := make(map[uint]bool, len(req.Players))
+	for _, p := range req.Players {
+		if seen[p.UserID] {
+			response.Error(c, http.StatusBadRequest, "Each player can only appear once in the lineup")
+			return
+		}
+		seen[p.UserID] = true
+		playerIDs = append(playerIDs, p.UserID)
+	}
+	members, err := mc.teamRepo.GetActiveTeamMemberIDs(uint(teamID), playerIDs)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check team membership: "+err.Error())
+		return
+	}
+
+	lineup := make([]MatchLineup, 0, len(req.Players))
+	starters := 0
+	for _, p := range req.Players {
+		if !members[p.UserID] {
+			response.Error(c, http.StatusBadRequest, "User "+strconv.Itoa(int(p.UserID))+" is not an active member of this team")
+			return
+		}
+
+		isStarter := p.IsStarter == nil || *p.IsStarter
+		if isStarter {
+			starters++
+		}
+		lineup = append(lineup, MatchLineup{
+			MatchID:   match.ID,
+			TeamID:    uint(teamID),
+			UserID:    p.UserID,
+			Position:  p.Position,
+			IsStarter: isStarter,
+		})
+	}
+
+	if limit := lineupSizeLimit(match); limit > 0 && starters > limit {
+		response.Error(c, http.StatusBadRequest, "Lineup exceeds the team size of "+strconv.Itoa(limit)+" starters")
+		return
+	}
+
+	if err := mc.repo.ReplaceMatchLineup(match.ID, uint(teamID), lineup); err != nil {
+		if errors.Is(err, ErrLineupLocked) {
+			response.Error(c, http.StatusConflict, "Lineups are locked once the match has started")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to save lineup: "+err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Lineup saved successfully", gin.H{
+		"lineup": lineup,
+	})
+}
+
+// GetMatchLineup retrieves the lineups of every team in a match
+func (mc *MatchController) GetMatchLineup(c *gin.Context) {
+	matchID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid match ID")
+		return
+	}
+
+	match, err := mc.repo.GetMatchByID(uint(matchID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
+		return
+	}
 	if match == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Match not found")
+		response.Error(c, http.StatusNotFound, "Match not found")
 		return
 	}
 
-	// Check authorization - only creator or team manager can start match
-	if match.CreatedByUserID != userID {
-		isAuthorized := false
+	lineup, err := mc.repo.GetMatchLineup(match.ID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch lineup: "+err.Error())
+		return
+	}
 
-		// Check if user is a manager for any of the participating teams
-		for _, matchTeam := range match.MatchTeams {
-			isManager, err := mc.isTeamManager(matchTeam.TeamID, userID)
+	response.Success(c, http.StatusOK, "", gin.H{
+		"match_id": match.ID,
+		"locked":   isLineupLocked(match.Status),
+		"lineup":   lineup,
+	})
+}
+
+// CreateMatchCommentRequest defines the payload for posting a match comment
+type CreateMatchCommentRequest struct {
+	Body string `json:"body" binding:"required,min=1,max=2000"`
+}
+
+// matchThreadAccess reports whether the user can take part in the match's comment thread
+// (the match creator or an active member of a participating team) and whether they can
+// moderate it (the match creator or a manager of a participating team).
+func (mc *MatchController) matchThreadAccess(match *Match, userID uint) (canParticipate, canModerate bool, err error) {
+	if match.CreatedByUserID == userID {
+		return true, true, nil
+	}
+
+	teamIDs, err := mc.repo.GetMatchTeamIDs(match.ID)
+	if err != nil {
+		return false, false, err
+	}
+	for _, teamID := range teamIDs {
+		isManager, err := mc.isTeamManager(teamID, userID)
+		if err != nil {
+			return false, false, err
+		}
+		if isManager {
+			return true, true, nil
+		}
+		if !canParticipate {
+			isMember, err := mc.isTeamMember(teamID, userID)
 			if err != nil {
-				responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
-				return
-			}
-			if isManager {
-				isAuthorized = true
-				break
+				return false, false, err
 			}
+			canParticipate = isMember
 		}
+	}
+	return canParticipate, false, nil
+}
 
-		if !isAuthorized {
-			responses.ErrorResponse(c, http.StatusForbidden, "You are not authorized to start this match")
+// loadMatchForThread resolves the match from the URL and checks the user may access its thread.
+// It writes the error response and returns a nil match when the request should stop; the
+// bool reports whether the user can moderate the thread.
+func (mc *MatchController) loadMatchForThread(c *gin.Context, userID uint) (*Match, bool) {
+	matchID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid match ID")
+		return nil, false
+	}
+
+	match, err := mc.repo.GetMatchByID(uint(matchID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
+		return nil, false
+	}
+	if match == nil {
+		response.Error(c, http.StatusNotFound, "Match not found")
+		return nil, false
+	}
+
+	canParticipate, canModerate, err := mc.matchThreadAccess(match, userID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check match participation: "+err.Error())
+		return nil, false
+	}
+	if !canParticipate {
+		response.Error(c, http.StatusForbidden, "Only match participants can access the comments")
+		return nil, false
+	}
+	return match, canModerate
+}
+
+// CreateMatchComment posts a comment to the match thread. Posting is rate limited per user,
+// threads are capped at COMMENT_MAX_PER_MATCH comments, and the comment filter may flag or
+// reject the comment.
+func (mc *MatchController) CreateMatchComment(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req CreateMatchCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	match, _ := mc.loadMatchForThread(c, userID)
+	if match == nil {
+		return
+	}
+
+	if mc.commentLimiter != nil && !mc.commentLimiter.Allow(strconv.FormatUint(uint64(userID), 10)) {
+		response.Error(c, http.StatusTooManyRequests, "You are commenting too quickly, please try again later")
+		return
+	}
+
+	if limit := mc.appConfig.Comments.MaxPerMatch; limit > 0 {
+		count, err := mc.repo.CountMatchComments(match.ID)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, "Failed to count comments: "+err.Error())
+			return
+		}
+		if count >= int64(limit) {
+			response.Error(c, http.StatusConflict, fmt.Sprintf("This match has reached its limit of %d comments", limit))
 			return
 		}
 	}
 
+	verdict := mc.commentFilter.Check(req.Body)
+	if verdict == CommentReject {
+		response.Error(c, http.StatusUnprocessableEntity, "Comment contains language that is not allowed")
+		return
+	}
+
+	comment := MatchComment{
+		MatchID: match.ID,
+		UserID:  userID,
+		Body:    req.Body,
+		Flagged: verdict == CommentFlag,
+	}
+	if err := mc.repo.CreateMatchComment(&comment); err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to create comment: "+err.Error())
+		return
+	}
+
+	message := "Comment posted successfully"
+	if comment.Flagged {
+		message = "Comment posted but flagged for review; other players will not see it"
+	}
+	response.Success(c, http.StatusCreated, message, gin.H{
+		"comment": comment,
+	})
+}
+
+// GetMatchComments lists the match thread, newest first. Flagged comments are only listed for
+// moderators and their authors.
+func (mc *MatchController) GetMatchComments(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	match, canModerate := mc.loadMatchForThread(c, userID)
+	if match == nil {
+		return
+	}
+
+	comments, total, err := mc.repo.GetMatchComments(match.ID, userID, canModerate, page, pageSize)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch comments: "+err.Error())
+		return
+	}
+
+	response.Paginated(c, http.StatusOK, "", comments, total, page, pageSize)
+}
+
+// DeleteMatchComment removes a comment; authors can delete their own and moderators any in the thread
+func (mc *MatchController) DeleteMatchComment(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	commentID, err := strconv.Atoi(c.Param("comment_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid comment ID")
+		return
+	}
+
+	match, canModerate := mc.loadMatchForThread(c, userID)
+	if match == nil {
+		return
+	}
+
+	comment, err := mc.repo.GetMatchCommentByID(uint(commentID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch comment: "+err.Error())
+		return
+	}
+	if comment == nil || comment.MatchID != match.ID {
+		response.Error(c, http.StatusNotFound, "Comment not found")
+		return
+	}
+	if comment.UserID != userID && !canModerate {
+		response.Error(c, http.StatusForbidden, "You can only delete your own comments")
+		return
+	}
+
+	if err := mc.repo.DeleteMatchComment(comment.ID); err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to delete comment: "+err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Comment deleted successfully", nil)
+}
+
+// StartMatch handles starting a match
+func (mc *MatchController) StartMatch(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid match ID")
+		return
+	}
+
+	// Get match
+	match, err := mc.repo.GetMatchByID(uint(id))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
+		return
+	}
+
+	if match == nil {
+		response.Error(c, http.StatusNotFound, "Match not found")
+		return
+	}
+
+	// Check authorization - only creator or team manager can start match
+	isAuthorized, err := mc.canManageMatch(match, userID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
+		return
+	}
+	if !isAuthorized {
+		response.Error(c, http.StatusForbidden, "You are not authorized to start this match")
+		return
+	}
+
 	// Check if match can be started
 	if match.Status != StatusMatchUpcoming {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Match cannot be started in its current state")
+		response.Error(c, http.StatusBadRequest, "Match cannot be started in its current state")
+		return
+	}
+	if !match.HasParticipants() {
+		response.Error(c, http.StatusConflict, "Match is awaiting participants and cannot be started")
 		return
 	}
 
 	// Update match status
 	if err := mc.repo.UpdateMatchStatus(match.ID, StatusMatchLive); err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to start match: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to start match: "+err.Error())
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{
-		"message": "Match started successfully",
-	})
+	response.Success(c, http.StatusOK, "Match started successfully", nil)
 }
 
 // EndMatch handles ending a match and setting the winner
 func (mc *MatchController) EndMatch(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid match ID")
+		response.Error(c, http.StatusBadRequest, "Invalid match ID")
 		return
 	}
 
 	// Get match
 	match, err := mc.repo.GetMatchByID(uint(id))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
 		return
 	}
 
 	if match == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Match not found")
+		response.Error(c, http.StatusNotFound, "Match not found")
 		return
 	}
 
-	// Check authorization - only creator or team manager can end match
-	if match.CreatedByUserID != userID {
-		isAuthorized := false
-
-		// Check if user is a manager for any of the participating teams
-		for _, matchTeam := range match.MatchTeams {
-			isManager, err := mc.isTeamManager(matchTeam.TeamID, userID)
-			if err != nil {
-				responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
-				return
-			}
-			if isManager {
-				isAuthorized = true
-				break
-			}
-		}
-
-		if !isAuthorized {
-			responses.ErrorResponse(c, http.StatusForbidden, "You are not authorized to end this match")
-			return
-		}
+	// Check authorization - only creator, team manager or match official can end match
+	isAuthorized, err := mc.canScoreMatch(match, userID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
+		return
 	}
-
-	// Check if match can be ended
-	if match.Status != StatusMatchLive {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Match cannot be ended in its current state")
+	if !isAuthorized {
+		response.Error(c, http.StatusForbidden, "You are not authorized to end this match")
 		return
 	}
 
-	// Parse winning team ID from request
-	var req struct {
-		WinningTeamID uint `json:"winning_team_id"`
+	// Check if match can be ended. Auto-completed matches still need their result recorded.
+	if match.Status != StatusMatchLive && !match.AwaitingResult() {
+		response.Error(c, http.StatusBadRequest, "Match cannot be ended in its current state")
+		return
 	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.ValidationErrorResponse(c, err)
+	if !match.HasParticipants() {
+		response.Error(c, http.StatusConflict, "Match is awaiting participants and cannot be ended")
 		return
 	}
 
-	// Validate winning team is part of the match
-	isValidTeam := false
-	for _, matchTeam := range match.MatchTeams {
-		if matchTeam.TeamID == req.WinningTeamID {
-			isValidTeam = true
-			break
-		}
+	var req EndMatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
 	}
-	if !isValidTeam {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid winning team - team must be part of the match")
+	if req.Result == "" {
+		req.Result = ResultWin
+	}
+
+	multiTeam := len(match.MatchTeams) > 2
+	if len(req.Rankings) > 0 && (!multiTeam || req.Result != ResultWin) {
+		response.Error(c, http.StatusBadRequest, "Rankings are only recorded for won matches of more than two teams")
 		return
 	}
 
+	var winningTeamID *uint
+	var ranks map[uint]int
+	switch {
+	case req.Result == ResultWin && multiTeam:
+		ranked, winner, reason := rankMatchTeams(match, req.Rankings)
+		if reason != "" {
+			response.Error(c, http.StatusBadRequest, reason)
+			return
+		}
+		if req.WinningTeamID != 0 && req.WinningTeamID != winner {
+			response.Error(c, http.StatusBadRequest, "The winning team must be the team ranked first")
+			return
+		}
+		ranks = ranked
+		winningTeamID = &winner
+	case req.Result == ResultWin:
+		// Validate winning team is part of the match
+		isValidTeam := false
+		for _, matchTeam := range match.MatchTeams {
+			if matchTeam.TeamID == req.WinningTeamID {
+				isValidTeam = true
+				break
+			}
+		}
+		if !isValidTeam {
+			response.Error(c, http.StatusBadRequest, "Invalid winning team - team must be part of the match")
+			return
+		}
+		winningTeamID = &req.WinningTeamID
+	case req.Result == ResultDraw:
+		if req.WinningTeamID != 0 {
+			response.Error(c, http.StatusBadRequest, "A drawn match cannot have a winning team")
+			return
+		}
+		allowed, reason, err := mc.drawAllowed(match)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, "Failed to check draw rules: "+err.Error())
+			return
+		}
+		if !allowed {
+			response.Error(c, http.StatusBadRequest, reason)
+			return
+		}
+	case req.Result == ResultNoResult:
+		if req.WinningTeamID != 0 {
+			response.Error(c, http.StatusBadRequest, "A match without a result cannot have a winning team")
+			return
+		}
+	}
+
 	// End match
-	if err := mc.repo.EndMatch(match.ID, req.WinningTeamID); err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to end match: "+err.Error())
+	if err := mc.repo.EndMatch(match.ID, req.Result, winningTeamID, ranks); err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to end match: "+err.Error())
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{
-		"message": "Match ended successfully",
-	})
+	response.Success(c, http.StatusOK, "Match ended successfully", nil)
+}
+
+// drawAllowed reports whether the match may end in a draw: the sport must permit draws and
+// knockout tournament matches always need a winner
+func (mc *MatchController) drawAllowed(match *Match) (bool, string, error) {
+	if !match.Sport.Rules.DrawsAllowed() {
+		return false, "Matches of this sport cannot end in a draw", nil
+	}
+	if match.TournamentID != nil {
+		tournament, err := mc.repo.GetTournamentByID(*match.TournamentID)
+		if err != nil {
+			return false, "", err
+		}
+		if tournament != nil && tournament.Format == "knockout" {
+			return false, "Knockout tournament matches cannot end in a draw", nil
+		}
+	}
+	return true, "", nil
 }
 
 // CancelMatch handles canceling a match
 func (mc *MatchController) CancelMatch(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid match ID")
+		response.Error(c, http.StatusBadRequest, "Invalid match ID")
 		return
 	}
 
 	// Get match
 	match, err := mc.repo.GetMatchByID(uint(id))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
 		return
 	}
 
 	if match == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Match not found")
+		response.Error(c, http.StatusNotFound, "Match not found")
 		return
 	}
 
 	// Check authorization - only creator or team manager can cancel match
-	if match.CreatedByUserID != userID {
-		isAuthorized := false
-
-		// Check if user is a manager for any of the participating teams
-		for _, matchTeam := range match.MatchTeams {
-			isManager, err := mc.isTeamManager(matchTeam.TeamID, userID)
-			if err != nil {
-				responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
-				return
-			}
-			if isManager {
-				isAuthorized = true
-				break
-			}
-		}
-
-		if !isAuthorized {
-			responses.ErrorResponse(c, http.StatusForbidden, "You are not authorized to cancel this match")
-			return
-		}
+	isAuthorized, err := mc.canManageMatch(match, userID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
+		return
+	}
+	if !isAuthorized {
+		response.Error(c, http.StatusForbidden, "You are not authorized to cancel this match")
+		return
 	}
 
 	// Check if match can be canceled
 	if match.Status == StatusMatchCompleted || match.Status == StatusMatchCancelled {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Match cannot be canceled in its current state")
+		response.Error(c, http.StatusBadRequest, "Match cannot be canceled in its current state")
 		return
 	}
 
 	// Update match status
 	if err := mc.repo.UpdateMatchStatus(match.ID, StatusMatchCancelled); err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to cancel match: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to cancel match: "+err.Error())
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{
-		"message": "Match cancelled successfully",
-	})
+	response.Success(c, http.StatusOK, "Match cancelled successfully", nil)
 }
 
 // PostponeMatch handles postponing a match
 func (mc *MatchController) PostponeMatch(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid match ID")
+		response.Error(c, http.StatusBadRequest, "Invalid match ID")
 		return
 	}
 
 	// Get match
 	match, err := mc.repo.GetMatchByID(uint(id))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
 		return
 	}
 
 	if match == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Match not found")
+		response.Error(c, http.StatusNotFound, "Match not found")
 		return
 	}
 
 	// Check authorization - only creator or team manager can postpone match
-	if match.CreatedByUserID != userID {
-		isAuthorized := false
-
-		// Check if user is a manager for any of the participating teams
-		for _, matchTeam := range match.MatchTeams {
-			isManager, err := mc.isTeamManager(matchTeam.TeamID, userID)
-			if err != nil {
-				responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
-				return
-			}
-			if isManager {
-				isAuthorized = true
-				break
-			}
-		}
-
-		if !isAuthorized {
-			responses.ErrorResponse(c, http.StatusForbidden, "You are not authorized to postpone this match")
-			return
-		}
+	isAuthorized, err := mc.canManageMatch(match, userID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
+		return
+	}
+	if !isAuthorized {
+		response.Error(c, http.StatusForbidden, "You are not authorized to postpone this match")
+		return
 	}
 
 	// Check if match can be postponed
 	if match.Status != StatusMatchUpcoming {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Match cannot be postponed in its current state")
+		response.Error(c, http.StatusBadRequest, "Match cannot be postponed in its current state")
 		return
 	}
 
@@ -1473,7 +2626,7 @@ func (mc *MatchController) PostponeMatch(c *gin.Context) {
 		NewScheduledAt time.Time `json:"new_scheduled_at" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.ValidationErrorResponse(c, err)
+		response.ValidationError(c, err)
 		return
 	}
 
@@ -1482,75 +2635,70 @@ func (mc *MatchController) PostponeMatch(c *gin.Context) {
 	match.Status = StatusMatchPostponed
 
 	if err := mc.repo.UpdateMatch(match); err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to postpone match: "+err.Error())
+		if errors.Is(err, ErrVersionConflict) {
+			response.Error(c, http.StatusConflict, "Match was changed by someone else; fetch it again and retry")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to postpone match: "+err.Error())
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{
-		"message": "Match postponed successfully",
-		"match":   match,
+	response.Success(c, http.StatusOK, "Match postponed successfully", gin.H{
+		"match": match,
 	})
 }
 
 // UpdateMatchScore updates the score for a team in a match
 func (mc *MatchController) UpdateMatchScore(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	idStr := c.Param("id")
 	matchID, err := strconv.Atoi(idStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid match ID")
+		response.Error(c, http.StatusBadRequest, "Invalid match ID")
 		return
 	}
 
 	// Get match
 	match, err := mc.repo.GetMatchByID(uint(matchID))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
 		return
 	}
 
 	if match == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Match not found")
+		response.Error(c, http.StatusNotFound, "Match not found")
 		return
 	}
 
-	// Check authorization - only creator or team manager can update score
-	if match.CreatedByUserID != userID {
-		isAuthorized := false
-
-		// Check if user is a manager for any of the participating teams
-		for _, matchTeam := range match.MatchTeams {
-			isManager, err := mc.isTeamManager(matchTeam.TeamID, userID)
-			if err != nil {
-				responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
-				return
-			}
-			if isManager {
-				isAuthorized = true
-				break
-			}
-		}
-
-		if !isAuthorized {
-			responses.ErrorResponse(c, http.StatusForbidden, "You are not authorized to update scores for this match")
-			return
-		}
+	// Check authorization - only creator, team manager or match official can update score
+	isAuthorized, err := mc.canScoreMatch(match, userID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
+		return
+	}
+	if !isAuthorized {
+		response.Error(c, http.StatusForbidden, "You are not authorized to update scores for this match")
+		return
 	}
 
 	// Check if match is in progress
 	if match.Status != StatusMatchLive {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Scores can only be updated for live matches")
+		response.Error(c, http.StatusBadRequest, "Scores can only be updated for live matches")
+		return
+	}
+	if !match.HasParticipants() {
+		response.Error(c, http.StatusConflict, "Match is awaiting participants")
 		return
 	}
 
 	var req UpdateMatchScoreRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.ValidationErrorResponse(c, err)
+		response.ValidationError(c, err)
 		return
 	}
 
@@ -1563,25 +2711,113 @@ func (mc *MatchController) UpdateMatchScore(c *gin.Context) {
 		}
 	}
 	if !isValidTeam {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid team - team must be part of the match")
+		response.Error(c, http.StatusBadRequest, "Invalid team - team must be part of the match")
+		return
+	}
+
+	if !req.Increment && *req.Score < 0 {
+		response.Error(c, http.StatusBadRequest, "Score cannot be negative")
 		return
 	}
 
 	// Update match team score
-	matchTeam := MatchTeam{
-		MatchID: uint(matchID),
-		TeamID:  req.TeamID,
+	matchTeam, err := mc.repo.UpdateMatchScore(uint(matchID), req.TeamID, *req.Score, req.Increment, req.ResultStatus)
+	if err != nil {
+		if errors.Is(err, ErrScoreDerivedFromPeriods) {
+			response.Error(c, http.StatusConflict, err.Error())
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to update match score: "+err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Match score updated successfully", gin.H{
+		"match_team": matchTeam,
+	})
+}
+
+// canManageMatch reports whether the user may manage the match, for instance to start it, end
+// it or update its scores: its creator or a manager of one of the participating teams. Only
+// the creator manages a match that has no teams yet.
+func (mc *MatchController) canManageMatch(match *Match, userID uint) (bool, error) {
+	if match.CreatedByUserID == userID {
+		return true, nil
+	}
+	if len(match.MatchTeams) == 0 {
+		return false, nil
+	}
+	for _, matchTeam := range match.MatchTeams {
+		isManager, err := mc.isTeamManager(matchTeam.TeamID, userID)
+		if err != nil {
+			return false, err
+		}
+		if isManager {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// SetMatchPeriodScore records a team's score for one period (set, quarter, half) of a live match
+func (mc *MatchController) SetMatchPeriodScore(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	matchID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid match ID")
+		return
+	}
+
+	var req SetPeriodScoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	match, err := mc.repo.GetMatchByID(uint(matchID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
+		return
+	}
+	if match == nil {
+		response.Error(c, http.StatusNotFound, "Match not found")
+		return
+	}
+
+	isAuthorized, err := mc.canScoreMatch(match, userID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
+		return
+	}
+	if !isAuthorized {
+		response.Error(c, http.StatusForbidden, "You are not authorized to update scores for this match")
+		return
+	}
 
-		ResultStatus: req.ResultStatus,
+	if match.Status != StatusMatchLive {
+		response.Error(c, http.StatusBadRequest, "Period scores can only be updated for live matches")
+		return
 	}
 
-	if err := mc.repo.UpdateMatchScore(&matchTeam); err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to update match score: "+err.Error())
+	matchTeam, err := mc.repo.SetPeriodScore(uint(matchID), req.TeamID, req.Period, *req.Value)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrMatchTeamNotFound):
+			response.Error(c, http.StatusBadRequest, "Invalid team - team must be part of the match")
+		case errors.Is(err, ErrPeriodOutOfSequence):
+			response.Error(c, http.StatusBadRequest, err.Error())
+		default:
+			response.Error(c, http.StatusInternalServerError, "Failed to update period score: "+err.Error())
+		}
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{
-		"message": "Match score updated successfully",
+	response.Success(c, http.StatusOK, "Period score updated successfully", gin.H{
+		"match_team": matchTeam,
 	})
 }
 
@@ -1589,29 +2825,29 @@ func (mc *MatchController) UpdateMatchScore(c *gin.Context) {
 
 // CreateTournament handles creating a new tournament
 func (mc *MatchController) CreateTournament(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	var req CreateTournamentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.ValidationErrorResponse(c, err)
+		response.ValidationError(c, err)
 		return
 	}
 
 	// Validate dates
 	if req.StartDate.Before(time.Now()) {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Start date must be in the future")
+		response.Error(c, http.StatusBadRequest, "Start date must be in the future")
 		return
 	}
 	if req.EndDate.Before(req.StartDate) {
-		responses.ErrorResponse(c, http.StatusBadRequest, "End date must be after start date")
+		response.Error(c, http.StatusBadRequest, "End date must be after start date")
 		return
 	}
 	if req.RegistrationDeadline.After(req.StartDate) {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Registration deadline must be before start date")
+		response.Error(c, http.StatusBadRequest, "Registration deadline must be before start date")
 		return
 	}
 
@@ -1630,21 +2866,24 @@ func (mc *MatchController) CreateTournament(c *gin.Context) {
 		PrizePool:            req.PrizePool,
 		EntryFee:             req.EntryFee,
 		MaxTeams:             req.MaxTeams,
+		ThirdPlaceMatch:      req.ThirdPlaceMatch,
 		Status:               "registration_open",
 	}
 
 	if err := mc.repo.CreateTournament(&tournament); err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to create tournament: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to create tournament: "+err.Error())
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusCreated, gin.H{
-		"message":    "Tournament created successfully",
+	response.Success(c, http.StatusCreated, "Tournament created successfully", gin.H{
 		"tournament": tournament,
 	})
 }
 
-// GetTournaments retrieves tournaments based on filters
+// GetTournaments retrieves tournaments based on filters, soonest start date first. q searches
+// the name and description; starting_after and starting_before take a date (YYYY-MM-DD) or an
+// RFC 3339 time; accepting_registrations keeps tournaments whose registration is open and
+// whose deadline has not passed (or, when false, the others).
 func (mc *MatchController) GetTournaments(c *gin.Context) {
 	// Parse query parameters for filters
 	sportID := c.Query("sport_id")
@@ -1675,15 +2914,41 @@ func (mc *MatchController) GetTournaments(c *gin.Context) {
 	if format != "" {
 		filters["format"] = format
 	}
+	if q := strings.TrimSpace(c.Query("q")); q != "" {
+		filters[tournamentFilterSearch] = q
+	}
+	for param, key := range map[string]string{
+		"starting_after":  tournamentFilterStartingAfter,
+		"starting_before": tournamentFilterStartingBefore,
+	} {
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		t, err := utils.ParseOptionalTime(raw)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid "+param+": use YYYY-MM-DD or an RFC 3339 time")
+			return
+		}
+		filters[key] = t
+	}
+	if raw := c.Query("accepting_registrations"); raw != "" {
+		accepting, err := strconv.ParseBool(raw)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid accepting_registrations parameter")
+			return
+		}
+		filters[tournamentFilterAccepting] = accepting
+	}
 
 	// Get tournaments
 	tournaments, total, err := mc.repo.GetTournaments(filters, page, pageSize)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch tournaments: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch tournaments: "+err.Error())
 		return
 	}
 
-	responses.PaginatedResponse(c, http.StatusOK, tournaments, page, pageSize, total)
+	response.Paginated(c, http.StatusOK, "", tournaments, total, page, pageSize)
 }
 
 // GetTournamentByID retrieves a specific tournament by ID
@@ -1691,56 +2956,56 @@ func (mc *MatchController) GetTournamentByID(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid tournament ID")
+		response.Error(c, http.StatusBadRequest, "Invalid tournament ID")
 		return
 	}
 
 	tournament, err := mc.repo.GetTournamentByID(uint(id))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch tournament: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch tournament: "+err.Error())
 		return
 	}
 
 	if tournament == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Tournament not found")
+		response.Error(c, http.StatusNotFound, "Tournament not found")
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, tournament)
+	response.Success(c, http.StatusOK, "", tournament)
 }
 func (mc *MatchController) UpdateTournament(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid tournament ID")
+		response.Error(c, http.StatusBadRequest, "Invalid tournament ID")
 		return
 	}
 
 	tournament, err := mc.repo.GetTournamentByID(uint(id))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch tournament: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch tournament: "+err.Error())
 		return
 	}
 
 	if tournament == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Tournament not found")
+		response.Error(c, http.StatusNotFound, "Tournament not found")
 		return
 	}
 
 	if tournament.CreatedByUserID != userID {
-		responses.ErrorResponse(c, http.StatusForbidden, "You are not authorized to update this tournament")
+		response.Error(c, http.StatusForbidden, "You are not authorized to update this tournament")
 		return
 	}
 
 	var req UpdateTournamentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.ValidationErrorResponse(c, err)
+		response.ValidationError(c, err)
 		return
 	}
 
@@ -1777,261 +3042,328 @@ func (mc *MatchController) UpdateTournament(c *gin.Context) {
 	if req.MaxTeams != nil {
 		tournament.MaxTeams = *req.MaxTeams
 	}
+	if req.ThirdPlaceMatch != nil {
+		tournament.ThirdPlaceMatch = *req.ThirdPlaceMatch
+	}
 	if req.Status != nil {
 		tournament.Status = *req.Status
 	}
+	if req.Version != nil {
+		tournament.Version = *req.Version
+	}
 
 	if err := mc.repo.UpdateTournament(tournament); err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to update tournament: "+err.Error())
+		if errors.Is(err, ErrVersionConflict) {
+			response.Error(c, http.StatusConflict, "Tournament was changed by someone else; fetch it again and retry")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to update tournament: "+err.Error())
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{
-		"message":    "Tournament updated successfully",
+	response.Success(c, http.StatusOK, "Tournament updated successfully", gin.H{
 		"tournament": tournament,
 	})
 }
 
-func (mc *MatchController) AdminOverrideMatchStatus(c *gin.Context) {
-	idStr := c.Param("id")
-	matchID, err := strconv.Atoi(idStr)
+func (mc *MatchController) ExpireChallenges(c *gin.Context) {
+	expired, err := mc.repo.ExpireChallenges()
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid match ID")
+		response.Error(c, http.StatusInternalServerError, "Failed to expire challenges: "+err.Error())
 		return
 	}
+	response.Success(c, http.StatusOK, "Challenges expired successfully", gin.H{"expired": expired})
+}
 
-	var req struct {
-		Status MatchStatus `json:"status" binding:"required"`
-	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.ValidationErrorResponse(c, err)
+func (mc *MatchController) DeleteTournament(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
-	match, err := mc.repo.GetMatchByID(uint(matchID))
+	tournamentIDStr := c.Param("id")
+	tournamentID, err := strconv.Atoi(tournamentIDStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
-		return
-	}
-	if match == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Match not found")
-		return
-	}
-
-	if err := mc.repo.UpdateMatchStatus(uint(matchID), req.Status); err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to override match status: "+err.Error())
+		response.Error(c, http.StatusBadRequest, "Invalid tournament ID")
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{"message": "Match status overridden successfully"})
-}
-
-func (mc *MatchController) AdminOverrideMatchScore(c *gin.Context) {
-	matchIDStr := c.Param("id")
-	matchID, err := strconv.Atoi(matchIDStr)
+	tournament, err := mc.repo.GetTournamentByID(uint(tournamentID))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid match ID")
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch tournament: "+err.Error())
 		return
 	}
 
-	var req []UpdateMatchScoreRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.ValidationErrorResponse(c, err)
+	if tournament == nil {
+		response.Error(c, http.StatusNotFound, "Tournament not found")
 		return
 	}
 
-	match, err := mc.repo.GetMatchByID(uint(matchID))
-	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
-		return
-	}
-	if match == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Match not found")
+	if tournament.CreatedByUserID != userID {
+		response.Error(c, http.StatusForbidden, "You are not authorized to delete this tournament")
 		return
 	}
 
-	if match.Status != StatusMatchCompleted && match.Status != StatusMatchLive {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Scores can only be overridden for live or completed matches.")
+	if tournament.Status == "ongoing" || tournament.Status == "completed" {
+		response.Error(c, http.StatusBadRequest, "Cannot delete an ongoing or completed tournament")
 		return
 	}
 
-	err = mc.repo.WithTransaction(func(txRepo MatchRepository) error {
-		for _, scoreUpdate := range req {
-			matchTeam := MatchTeam{
-				MatchID: uint(matchID),
-				TeamID:  scoreUpdate.TeamID,
-
-				ResultStatus: scoreUpdate.ResultStatus,
-			}
-			if err := txRepo.UpdateMatchScore(&matchTeam); err != nil {
-				return errors.New("failed to update score for team " + strconv.Itoa(int(scoreUpdate.TeamID)) + ": " + err.Error())
-			}
-		}
-		return nil
-	})
-
-	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to override match scores: "+err.Error())
+	if err := mc.repo.DeleteTournament(uint(tournamentID)); err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to delete tournament: "+err.Error())
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{"message": "Match scores overridden successfully"})
+	response.Success(c, http.StatusOK, "Tournament deleted successfully", nil)
 }
-func (mc *MatchController) ExpireChallenges(c *gin.Context) {
-	err := mc.repo.ExpireChallenges()
-	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to expire challenges: "+err.Error())
-		return
-	}
-	responses.SuccessResponse(c, http.StatusOK, gin.H{"message": "Challenges expired successfully"})
+
+type TournamentTeamRegistrationRequest struct {
+	TeamID uint `json:"team_id" binding:"required"`
 }
 
-func (mc *MatchController) DeleteTournament(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+func (mc *MatchController) RegisterTeamForTournament(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	tournamentIDStr := c.Param("id")
 	tournamentID, err := strconv.Atoi(tournamentIDStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid tournament ID")
+		response.Error(c, http.StatusBadRequest, "Invalid tournament ID")
+		return
+	}
+
+	var req TournamentTeamRegistrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
 		return
 	}
 
 	tournament, err := mc.repo.GetTournamentByID(uint(tournamentID))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch tournament: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch tournament: "+err.Error())
 		return
 	}
-
 	if tournament == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Tournament not found")
+		response.Error(c, http.StatusNotFound, "Tournament not found")
 		return
 	}
 
-	if tournament.CreatedByUserID != userID {
-		responses.ErrorResponse(c, http.StatusForbidden, "You are not authorized to delete this tournament")
+	if tournament.Status != "registration_open" {
+		response.Error(c, http.StatusBadRequest, "Tournament registration is not open")
 		return
 	}
 
-	if tournament.Status == "ongoing" || tournament.Status == "completed" {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Cannot delete an ongoing or completed tournament")
+	if time.Now().After(tournament.RegistrationDeadline) {
+		response.Error(c, http.StatusBadRequest, "Registration deadline has passed")
 		return
 	}
 
-	if err := mc.repo.DeleteTournament(uint(tournamentID)); err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete tournament: "+err.Error())
+	// With live counting the repository checks capacity against the stored registrations
+	if !mc.appConfig.Tournaments.CountLiveRegistrations && tournament.MaxTeams > 0 && tournament.CurrentTeams >= tournament.MaxTeams {
+		response.Error(c, http.StatusBadRequest, "Tournament is full")
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{"message": "Tournament deleted successfully"})
-}
+	isManager, err := mc.isTeamManager(req.TeamID, userID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to verify team manager status: "+err.Error())
+		return
+	}
+	if !isManager {
+		response.Error(c, http.StatusForbidden, "You must be a manager of the team to register it")
+		return
+	}
 
-type TournamentTeamRegistrationRequest struct {
-	TeamID uint `json:"team_id" binding:"required"`
-}
+	if tournament.EndDate.After(tournament.StartDate) {
+		conflicts, err := mc.repo.FindScheduleConflicts([]uint{req.TeamID}, tournament.StartDate, tournament.EndDate, tournament.ID)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, "Failed to check team schedule: "+err.Error())
+			return
+		}
+		if len(conflicts) > 0 {
+			respondScheduleConflict(c, &ScheduleConflictError{Conflicts: conflicts})
+			return
+		}
+	}
 
-func (mc *MatchController) RegisterTeamForTournament(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
-	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+	if err := mc.repo.RegisterTeamInTournament(uint(tournamentID), req.TeamID, userID); err != nil {
+		switch {
+		case errors.Is(err, ErrTeamSportMismatch):
+			response.Error(c, http.StatusBadRequest, "Team plays a different sport from this tournament")
+		case errors.Is(err, ErrRegisteringTeamNotFound):
+			response.Error(c, http.StatusNotFound, "Team not found")
+		case errors.Is(err, ErrNotTeamManager):
+			response.Error(c, http.StatusForbidden, "You must be a manager of the team to register it")
+		case errors.Is(err, ErrTeamAlreadyRegistered):
+			response.Error(c, http.StatusConflict, "Team is already registered for this tournament")
+		case errors.Is(err, ErrTournamentFull):
+			response.Error(c, http.StatusBadRequest, "Tournament is full")
+		default:
+			response.Error(c, http.StatusInternalServerError, "Failed to register team: "+err.Error())
+		}
 		return
 	}
 
-	tournamentIDStr := c.Param("id")
-	tournamentID, err := strconv.Atoi(tournamentIDStr)
+	response.Success(c, http.StatusOK, "Team registered successfully for the tournament", nil)
+}
+
+// TournamentTeamCountRepair reports a tournament's team counter before and after a recount
+type TournamentTeamCountRepair struct {
+	TournamentID uint `json:"tournament_id"`
+	Previous     int  `json:"previous"`
+	Current      int  `json:"current"`
+}
+
+// AdminRecomputeTournamentTeamCount resets a tournament's current_teams to its stored registrations
+func (mc *MatchController) AdminRecomputeTournamentTeamCount(c *gin.Context) {
+	tournamentID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid tournament ID")
+		response.Error(c, http.StatusBadRequest, "Invalid tournament ID")
 		return
 	}
 
-	var req TournamentTeamRegistrationRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.ValidationErrorResponse(c, err)
+	previous, current, err := mc.repo.RecomputeTournamentTeamCount(uint(tournamentID))
+	if err != nil {
+		if errors.Is(err, ErrTournamentNotFound) {
+			response.Error(c, http.StatusNotFound, "Tournament not found")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to recount tournament teams: "+err.Error())
 		return
 	}
 
-	tournament, err := mc.repo.GetTournamentByID(uint(tournamentID))
+	response.Success(c, http.StatusOK, "Tournament team count recomputed", TournamentTeamCountRepair{
+		TournamentID: uint(tournamentID),
+		Previous:     previous,
+		Current:      current,
+	})
+}
+
+// AdminRepairTournamentTeamCounts recounts every tournament whose counter has drifted
+func (mc *MatchController) AdminRepairTournamentTeamCounts(c *gin.Context) {
+	ids, err := mc.repo.RepairTournamentTeamCounts()
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch tournament: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to repair tournament team counts: "+err.Error())
 		return
 	}
-	if tournament == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Tournament not found")
-		return
+	if ids == nil {
+		ids = []uint{}
 	}
+	response.Success(c, http.StatusOK, "Tournament team counts repaired", gin.H{"repaired_tournament_ids": ids})
+}
 
-	if tournament.Status != "registration_open" {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Tournament registration is not open")
+// TournamentWithdrawal reports a team's withdrawal and the matches it forfeited
+type TournamentWithdrawal struct {
+	TournamentID      uint   `json:"tournament_id"`
+	TeamID            uint   `json:"team_id"`
+	ForfeitedMatchIDs []uint `json:"forfeited_match_ids"`
+}
+
+// WithdrawTeamFromTournament withdraws a team from an ongoing tournament. Its unfinished
+// tournament matches are forfeited to the opponents; completed matches are untouched.
+func (mc *MatchController) WithdrawTeamFromTournament(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
-	if time.Now().After(tournament.RegistrationDeadline) {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Registration deadline has passed")
+	tournamentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid tournament ID")
 		return
 	}
-
-	if tournament.MaxTeams > 0 && tournament.CurrentTeams >= tournament.MaxTeams {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Tournament is full")
+	teamID, err := strconv.Atoi(c.Param("team_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
-	isManager, err := mc.isTeamManager(req.TeamID, userID)
+	tournament, err := mc.repo.GetTournamentByID(uint(tournamentID))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to verify team manager status: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch tournament: "+err.Error())
 		return
 	}
-	if !isManager {
-		responses.ErrorResponse(c, http.StatusForbidden, "You must be a manager of the team to register it")
+	if tournament == nil {
+		response.Error(c, http.StatusNotFound, "Tournament not found")
 		return
 	}
 
-	if err := mc.repo.RegisterTeamInTournament(uint(tournamentID), req.TeamID); err != nil {
-		if err.Error() == "team already registered" { // Example specific error check
-			responses.ErrorResponse(c, http.StatusConflict, "Team is already registered for this tournament")
+	if tournament.CreatedByUserID != userID {
+		isManager, err := mc.isTeamManager(uint(teamID), userID)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, "Failed to verify team manager status: "+err.Error())
+			return
+		}
+		if !isManager {
+			response.Error(c, http.StatusForbidden, "Only a team manager or the tournament creator can withdraw the team")
 			return
 		}
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to register team: "+err.Error())
+	}
+
+	forfeited, err := mc.repo.WithdrawTeamFromTournament(uint(tournamentID), uint(teamID))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrTournamentNotFound):
+			response.Error(c, http.StatusNotFound, "Tournament not found")
+		case errors.Is(err, ErrTeamNotRegistered):
+			response.Error(c, http.StatusNotFound, "Team is not registered for this tournament")
+		case errors.Is(err, ErrTournamentNotOngoing):
+			response.Error(c, http.StatusBadRequest, "Teams can only withdraw while the tournament is ongoing; unregister during registration instead")
+		case errors.Is(err, ErrTeamAlreadyWithdrawn):
+			response.Error(c, http.StatusConflict, "Team has already withdrawn from this tournament")
+		default:
+			response.Error(c, http.StatusInternalServerError, "Failed to withdraw team: "+err.Error())
+		}
 		return
 	}
+	if forfeited == nil {
+		forfeited = []uint{}
+	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{"message": "Team registered successfully for the tournament"})
+	response.Success(c, http.StatusOK, "Team withdrawn from the tournament", TournamentWithdrawal{
+		TournamentID:      uint(tournamentID),
+		TeamID:            uint(teamID),
+		ForfeitedMatchIDs: forfeited,
+	})
 }
 
 func (mc *MatchController) UnregisterTeamFromTournament(c *gin.Context) {
-	userID, ok := getCurrentUserID(c)
+	userID, ok := middleware.CurrentUserID(c)
 	if !ok {
-		responses.ErrorResponse(c, http.StatusUnauthorized, "Unauthorized")
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
 	tournamentIDStr := c.Param("id")
 	tournamentID, err := strconv.Atoi(tournamentIDStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid tournament ID")
+		response.Error(c, http.StatusBadRequest, "Invalid tournament ID")
 		return
 	}
 
 	var req TournamentTeamRegistrationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.ValidationErrorResponse(c, err)
+		response.ValidationError(c, err)
 		return
 	}
 
 	tournament, err := mc.repo.GetTournamentByID(uint(tournamentID))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch tournament: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch tournament: "+err.Error())
 		return
 	}
 	if tournament == nil {
-		responses.ErrorResponse(c, http.StatusNotFound, "Tournament not found")
+		response.Error(c, http.StatusNotFound, "Tournament not found")
 		return
 	}
 
 	if tournament.Status != "registration_open" {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Cannot unregister team if registration is not open")
+		response.Error(c, http.StatusBadRequest, "Cannot unregister team if registration is not open")
 		return
 	}
 	if time.Now().After(tournament.RegistrationDeadline) && tournament.Status == "registration_open" {
@@ -2039,43 +3371,49 @@ func (mc *MatchController) UnregisterTeamFromTournament(c *gin.Context) {
 		// but typically this might be disallowed or have penalties.
 		// For now, let's allow it if status is still registration_open.
 	} else if tournament.Status != "registration_open" { // Stricter check for other statuses
-		responses.ErrorResponse(c, http.StatusBadRequest, "Cannot unregister team from a tournament that is not in registration phase.")
+		response.Error(c, http.StatusBadRequest, "Cannot unregister team from a tournament that is not in registration phase.")
 		return
 	}
 
 	isManager, err := mc.isTeamManager(req.TeamID, userID)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to verify team manager status: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to verify team manager status: "+err.Error())
 		return
 	}
 	if !isManager {
-		responses.ErrorResponse(c, http.StatusForbidden, "You must be a manager of the team to unregister it")
+		response.Error(c, http.StatusForbidden, "You must be a manager of the team to unregister it")
 		return
 	}
 
 	if err := mc.repo.UnregisterTeamFromTournament(uint(tournamentID), req.TeamID); err != nil {
 		if err.Error() == "team not registered" { // Example specific error check
-			responses.ErrorResponse(c, http.StatusNotFound, "Team is not registered for this tournament")
+			response.Error(c, http.StatusNotFound, "Team is not registered for this tournament")
 			return
 		}
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to unregister team: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to unregister team: "+err.Error())
 		return
 	}
 
-	responses.SuccessResponse(c, http.StatusOK, gin.H{"message": "Team unregistered successfully from the tournament"})
+	response.Success(c, http.StatusOK, "Team unregistered successfully from the tournament", nil)
 }
 
+// GetTournamentMatches lists a tournament's matches, optionally with their winning teams
+// (expand=winning_team)
 func (mc *MatchController) GetTournamentMatches(c *gin.Context) {
 	tournamentIDStr := c.Param("id")
 	tournamentID, err := strconv.Atoi(tournamentIDStr)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusBadRequest, "Invalid tournament ID")
+		response.Error(c, http.StatusBadRequest, "Invalid tournament ID")
+		return
+	}
+	expand, ok := parseMatchExpand(c)
+	if !ok {
 		return
 	}
 
 	_, err = mc.repo.GetTournamentByID(uint(tournamentID))
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch tournament details: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch tournament details: "+err.Error())
 		return
 	}
 
@@ -2096,11 +3434,38 @@ func (mc *MatchController) GetTournamentMatches(c *gin.Context) {
 		filters["status"] = status
 	}
 
-	matches, total, err := mc.repo.GetMatches(filters, page, pageSize)
+	matches, total, err := mc.repo.GetMatches(filters, expand, page, pageSize)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch tournament matches: "+err.Error())
+		return
+	}
+
+	response.Paginated(c, http.StatusOK, "", matches, total, page, pageSize)
+}
+
+// GetTournamentBracket returns the tournament's matches grouped into rounds for drawing its bracket
+func (mc *MatchController) GetTournamentBracket(c *gin.Context) {
+	tournamentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid tournament ID")
+		return
+	}
+
+	tournament, err := mc.repo.GetTournamentByID(uint(tournamentID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch tournament: "+err.Error())
+		return
+	}
+	if tournament == nil {
+		response.Error(c, http.StatusNotFound, "Tournament not found")
+		return
+	}
+
+	matches, err := mc.repo.GetTournamentBracketMatches(tournament.ID)
 	if err != nil {
-		responses.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch tournament matches: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch tournament matches: "+err.Error())
 		return
 	}
 
-	responses.PaginatedResponse(c, http.StatusOK, matches, page, pageSize, total)
+	response.Success(c, http.StatusOK, "", buildBracket(tournament, matches))
 }
@@ -0,0 +1,621 @@
+package match
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/internal/user"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// counterFixture is a direct individual challenge from creator to receiver
+type counterFixture struct {
+	db        *gorm.DB
+	mc        *MatchController
+	creator   *user.User
+	receiver  *user.User
+	challenge *Challenge
+}
+
+func newCounterFixture(t *testing.T) *counterFixture {
+	t.Helper()
+	db := newTestDB(t)
+	creator := testutil.CreateUser(t, db, "Creator")
+	receiver := testutil.CreateUser(t, db, "Receiver")
+	s := createSport(t, db)
+	challenge := createChallenge(t, db, s.ID, creator.ID, nil, func(ch *Challenge) {
+		ch.ChallengeType = DirectChallengeIndividual
+		ch.Status = StatusPending
+		ch.SenderUserID = &creator.ID
+		ch.ReceiverUserID = &receiver.ID
+	})
+	return &counterFixture{db: db, mc: newTestController(t, db), creator: creator, receiver: receiver, challenge: challenge}
+}
+
+// router serves the counter offer endpoints as the user
+func (f *counterFixture) router(userID uint) *gin.Engine {
+	r := gin.New()
+	r.Use(asUser(userID))
+	r.POST("/matches/challenges/:id/counter", f.mc.CounterChallenge)
+	r.POST("/matches/challenges/:id/counter/accept", f.mc.AcceptCounterOffer)
+	r.POST("/matches/challenges/:id/counter/reject", f.mc.RejectCounterOffer)
+	return r
+}
+
+func (f *counterFixture) reload(t *testing.T) (*Challenge, []ChallengeCounterOffer) {
+	t.Helper()
+	var challenge Challenge
+	if err := f.db.First(&challenge, f.challenge.ID).Error; err != nil {
+		t.Fatalf("failed to reload challenge: %v", err)
+	}
+	var offers []ChallengeCounterOffer
+	if err := f.db.Where("challenge_id = ?", f.challenge.ID).Order("id").Find(&offers).Error; err != nil {
+		t.Fatalf("failed to load counter offers: %v", err)
+	}
+	return &challenge, offers
+}
+
+func TestCounterChallengeThenAccept(t *testing.T) {
+	f := newCounterFixture(t)
+	v := createVenue(t, f.db, f.creator.ID, 0, 0)
+	proposed := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+	path := "/matches/challenges/" + itoa(f.challenge.ID) + "/counter"
+
+	w := testutil.Request(t, f.router(f.receiver.ID), http.MethodPost, path, CounterChallengeRequest{
+		ProposedDateTime: &proposed,
+		VenueID:          &v.ID,
+		Message:          "Can we play later?",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("counter status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+	}
+	if challenge, _ := f.reload(t); challenge.Status != StatusCountered {
+		t.Fatalf("challenge status after counter = %q, want %q", challenge.Status, StatusCountered)
+	}
+
+	w = testutil.Request(t, f.router(f.creator.ID), http.MethodPost, path+"/accept", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("accept status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	challenge, offers := f.reload(t)
+	if challenge.Status != StatusAccepted || challenge.ScheduledMatchID == nil {
+		t.Fatalf("challenge status = %q, scheduled match = %v; want accepted with a match", challenge.Status, challenge.ScheduledMatchID)
+	}
+	if len(offers) != 1 || offers[0].Status != CounterOfferAccepted || offers[0].RespondedAt == nil {
+		t.Errorf("counter offers = %+v, want one accepted offer", offers)
+	}
+
+	var match Match
+	if err := f.db.First(&match, *challenge.ScheduledMatchID).Error; err != nil {
+		t.Fatalf("failed to load match: %v", err)
+	}
+	if !match.ScheduledAt.Equal(proposed) {
+		t.Errorf("match scheduled at %v, want the countered time %v", match.ScheduledAt, proposed)
+	}
+	if match.VenueID == nil || *match.VenueID != v.ID {
+		t.Errorf("match venue = %v, want the countered venue %d", match.VenueID, v.ID)
+	}
+}
+
+func TestCounterChallengeThenReject(t *testing.T) {
+	f := newCounterFixture(t)
+	proposed := time.Now().Add(72 * time.Hour)
+	path := "/matches/challenges/" + itoa(f.challenge.ID) + "/counter"
+
+	w := testutil.Request(t, f.router(f.receiver.ID), http.MethodPost, path, CounterChallengeRequest{ProposedDateTime: &proposed})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("counter status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+	}
+
+	// Only the creator may respond
+	w = testutil.Request(t, f.router(f.receiver.ID), http.MethodPost, path+"/reject", nil)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("reject by receiver status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+
+	w = testutil.Request(t, f.router(f.creator.ID), http.MethodPost, path+"/reject", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("reject status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	challenge, offers := f.reload(t)
+	if challenge.Status != StatusPending {
+		t.Errorf("challenge status = %q, want it restored to %q", challenge.Status, StatusPending)
+	}
+	if !challenge.ProposedDateTime.Equal(f.challenge.ProposedDateTime) {
+		t.Errorf("challenge time changed to %v, want the original %v", challenge.ProposedDateTime, f.challenge.ProposedDateTime)
+	}
+	if challenge.ScheduledMatchID != nil {
+		t.Errorf("rejecting a counter offer scheduled match %d", *challenge.ScheduledMatchID)
+	}
+	if len(offers) != 1 || offers[0].Status != CounterOfferRejected {
+		t.Errorf("counter offers = %+v, want one rejected offer", offers)
+	}
+
+	// Nothing is left to respond to
+	w = testutil.Request(t, f.router(f.creator.ID), http.MethodPost, path+"/accept", nil)
+	if w.Code != http.StatusConflict {
+		t.Errorf("accept after reject status = %d, want %d", w.Code, http.StatusConflict)
+	}
+}
+
+func TestCounterChallengeValidation(t *testing.T) {
+	f := newCounterFixture(t)
+	stranger := testutil.CreateUser(t, f.db, "Stranger")
+	openChallenge := createChallenge(t, f.db, f.challenge.SportID, f.creator.ID, nil)
+	later := time.Now().Add(72 * time.Hour)
+	soon := time.Now().Add(30 * time.Minute)
+	missingVenue := uint(999999)
+
+	tests := []struct {
+		name        string
+		userID      uint
+		challengeID uint
+		req         CounterChallengeRequest
+		want        int
+	}{
+		{"no changes", f.receiver.ID, f.challenge.ID, CounterChallengeRequest{Message: "hi"}, http.StatusBadRequest},
+		{"unknown venue", f.receiver.ID, f.challenge.ID, CounterChallengeRequest{VenueID: &missingVenue}, http.StatusBadRequest},
+		{"not the receiver", stranger.ID, f.challenge.ID, CounterChallengeRequest{ProposedDateTime: &later}, http.StatusForbidden},
+		{"no receiver", stranger.ID, openChallenge.ID, CounterChallengeRequest{ProposedDateTime: &later}, http.StatusConflict},
+		{"inside accept lead time", f.receiver.ID, f.challenge.ID, CounterChallengeRequest{ProposedDateTime: &soon}, http.StatusUnprocessableEntity},
+	}
+	f.mc.appConfig.Challenges.MinAcceptLeadMinutes = 60
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := testutil.Request(t, f.router(tt.userID), http.MethodPost,
+				"/matches/challenges/"+itoa(tt.challengeID)+"/counter", tt.req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+
+	if challenge, offers := f.reload(t); challenge.Status != StatusPending || len(offers) != 0 {
+		t.Errorf("rejected counters changed the challenge: status %q, %d offers", challenge.Status, len(offers))
+	}
+}
+
+func TestAcceptCounterOfferEnforcesLeadTime(t *testing.T) {
+	f := newCounterFixture(t)
+	proposed := time.Now().Add(2 * time.Hour)
+	path := "/matches/challenges/" + itoa(f.challenge.ID) + "/counter"
+
+	w := testutil.Request(t, f.router(f.receiver.ID), http.MethodPost, path, CounterChallengeRequest{ProposedDateTime: &proposed})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("counter status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+	}
+
+	// The challenge's own time is two days away, but the countered one is not
+	f.mc.appConfig.Challenges.MinAcceptLeadMinutes = 180
+	w = testutil.Request(t, f.router(f.creator.ID), http.MethodPost, path+"/accept", nil)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("accept status = %d, want %d: %s", w.Code, http.StatusUnprocessableEntity, w.Body)
+	}
+	if challenge, _ := f.reload(t); challenge.Status != StatusCountered {
+		t.Errorf("challenge status = %q, want it still %q", challenge.Status, StatusCountered)
+	}
+}
+
+// lineupFixture is an upcoming match between a managed team and an opponent, limited to two
+// starters per team by its challenge
+type lineupFixture struct {
+	*twoTeamMatch
+	players  []*user.User
+	outsider *user.User
+}
+
+func newLineupFixture(t *testing.T) *lineupFixture {
+	t.Helper()
+	f := &lineupFixture{twoTeamMatch: newTwoTeamMatch(t, withoutMatch())}
+	for i := 0; i < 3; i++ {
+		p := testutil.CreateUser(t, f.db, "Player")
+		addTeamMember(t, f.db, f.home.ID, p.ID, "player")
+		f.players = append(f.players, p)
+	}
+	f.outsider = testutil.CreateUser(t, f.db, "Outsider")
+
+	teamSize := 2
+	challenge := createChallenge(t, f.db, f.sport.ID, f.creator.ID, f.home, func(ch *Challenge) {
+		ch.Status = StatusAccepted
+		ch.ReceiverTeamID = &f.away.ID
+		ch.TeamSize = &teamSize
+	})
+	f.match = f.newMatch(t, func(m *Match) { m.ChallengeID = &challenge.ID })
+	return f
+}
+
+func (f *lineupFixture) setLineup(t *testing.T, userID uint, players ...LineupPlayerRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	r := gin.New()
+	r.POST("/matches/:id/teams/:team_id/lineup", asUser(userID), f.mc.SetMatchLineup)
+	return testutil.Request(t, r, http.MethodPost,
+		"/matches/"+itoa(f.match.ID)+"/teams/"+itoa(f.home.ID)+"/lineup", SetLineupRequest{Players: players})
+}
+
+func TestSetMatchLineupValidation(t *testing.T) {
+	f := newLineupFixture(t)
+	bench := false
+	player := func(u *user.User) LineupPlayerRequest { return LineupPlayerRequest{UserID: u.ID} }
+
+	tests := []struct {
+		name    string
+		userID  uint
+		players []LineupPlayerRequest
+		want    int
+	}{
+		{"not a manager", f.players[0].ID, []LineupPlayerRequest{player(f.players[0])}, http.StatusForbidden},
+		{"not a member", f.homeCaptain.ID, []LineupPlayerRequest{player(f.players[0]), player(f.outsider)}, http.StatusBadRequest},
+		{"duplicate player", f.homeCaptain.ID, []LineupPlayerRequest{player(f.players[0]), player(f.players[0])}, http.StatusBadRequest},
+		{"too many starters", f.homeCaptain.ID, []LineupPlayerRequest{player(f.players[0]), player(f.players[1]), player(f.players[2])}, http.StatusBadRequest},
+		{"substitutes do not count", f.homeCaptain.ID, []LineupPlayerRequest{
+			player(f.players[0]), player(f.players[1]), {UserID: f.players[2].ID, IsStarter: &bench},
+		}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := f.setLineup(t, tt.userID, tt.players...)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+
+	var lineup []MatchLineup
+	if err := f.db.Where("match_id = ?", f.match.ID).Find(&lineup).Error; err != nil {
+		t.Fatalf("failed to load lineup: %v", err)
+	}
+	if len(lineup) != 3 {
+		t.Errorf("stored %d lineup entries, want only the valid lineup of 3", len(lineup))
+	}
+}
+
+func TestSetMatchLineupLockedOnceLive(t *testing.T) {
+	f := newLineupFixture(t)
+	if w := f.setLineup(t, f.homeCaptain.ID, LineupPlayerRequest{UserID: f.players[0].ID}); w.Code != http.StatusOK {
+		t.Fatalf("status before kick-off = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	if err := f.db.Model(&Match{}).Where("id = ?", f.match.ID).Update("status", StatusMatchLive).Error; err != nil {
+		t.Fatalf("failed to start match: %v", err)
+	}
+
+	w := f.setLineup(t, f.homeCaptain.ID, LineupPlayerRequest{UserID: f.players[1].ID})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status once live = %d, want %d: %s", w.Code, http.StatusConflict, w.Body)
+	}
+	// A request that passed the controller's check before kick-off is still refused by the repo
+	lineup := []MatchLineup{{MatchID: f.match.ID, TeamID: f.home.ID, UserID: f.players[1].ID, IsStarter: true}}
+	if err := NewGormMatchRepository(f.db).ReplaceMatchLineup(f.match.ID, f.home.ID, lineup); !errors.Is(err, ErrLineupLocked) {
+		t.Fatalf("ReplaceMatchLineup once live = %v, want %v", err, ErrLineupLocked)
+	}
+
+	r := gin.New()
+	r.GET("/matches/:id/lineup", f.mc.GetMatchLineup)
+	w = testutil.Request(t, r, http.MethodGet, "/matches/"+itoa(f.match.ID)+"/lineup", nil)
+	var got struct {
+		Locked bool          `json:"locked"`
+		Lineup []MatchLineup `json:"lineup"`
+	}
+	testutil.DecodeData(t, w, &got)
+	if !got.Locked {
+		t.Error("lineup of a live match not reported as locked")
+	}
+	if len(got.Lineup) != 1 || got.Lineup[0].UserID != f.players[0].ID {
+		t.Errorf("lineup = %+v, want the one saved before kick-off", got.Lineup)
+	}
+}
+
+// commentFixture is a match between two teams with a member and a captain on the home side
+type commentFixture struct {
+	*twoTeamMatch
+	member   *user.User
+	outsider *user.User
+}
+
+func newCommentFixture(t *testing.T) *commentFixture {
+	t.Helper()
+	f := &commentFixture{twoTeamMatch: newTwoTeamMatch(t, withHomeCaptain("Captain"), withCreatorAway())}
+	f.member = testutil.CreateUser(t, f.db, "Member")
+	f.outsider = testutil.CreateUser(t, f.db, "Outsider")
+	addTeamMember(t, f.db, f.home.ID, f.member.ID, "player")
+	return f
+}
+
+func (f *commentFixture) router(userID uint) *gin.Engine {
+	r := gin.New()
+	r.Use(asUser(userID))
+	r.POST("/matches/:id/comments", f.mc.CreateMatchComment)
+	r.GET("/matches/:id/comments", f.mc.GetMatchComments)
+	r.DELETE("/matches/:id/comments/:comment_id", f.mc.DeleteMatchComment)
+	return r
+}
+
+func (f *commentFixture) path() string {
+	return "/matches/" + itoa(f.match.ID) + "/comments"
+}
+
+func TestMatchCommentsAccessControl(t *testing.T) {
+	f := newCommentFixture(t)
+	body := CreateMatchCommentRequest{Body: "See you there"}
+
+	for _, u := range []*user.User{f.creator, f.homeCaptain, f.member} {
+		if w := testutil.Request(t, f.router(u.ID), http.MethodPost, f.path(), body); w.Code != http.StatusCreated {
+			t.Errorf("%s posting status = %d, want %d: %s", u.Name, w.Code, http.StatusCreated, w.Body)
+		}
+	}
+
+	if w := testutil.Request(t, f.router(f.outsider.ID), http.MethodPost, f.path(), body); w.Code != http.StatusForbidden {
+		t.Errorf("non-participant posting status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if w := testutil.Request(t, f.router(f.outsider.ID), http.MethodGet, f.path(), nil); w.Code != http.StatusForbidden {
+		t.Errorf("non-participant reading status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
+
+func TestDeleteMatchCommentPermissions(t *testing.T) {
+	f := newCommentFixture(t)
+	post := func(u *user.User) uint {
+		w := testutil.Request(t, f.router(u.ID), http.MethodPost, f.path(), CreateMatchCommentRequest{Body: "Hello"})
+		var got struct {
+			Comment MatchComment `json:"comment"`
+		}
+		testutil.DecodeData(t, w, &got)
+		return got.Comment.ID
+	}
+	creatorComment := post(f.creator)
+	memberComment := post(f.member)
+	otherMemberComment := post(f.member)
+
+	if w := testutil.Request(t, f.router(f.member.ID), http.MethodDelete, f.path()+"/"+itoa(creatorComment), nil); w.Code != http.StatusForbidden {
+		t.Errorf("member deleting another's comment status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if w := testutil.Request(t, f.router(f.member.ID), http.MethodDelete, f.path()+"/"+itoa(memberComment), nil); w.Code != http.StatusOK {
+		t.Errorf("member deleting own comment status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	if w := testutil.Request(t, f.router(f.homeCaptain.ID), http.MethodDelete, f.path()+"/"+itoa(otherMemberComment), nil); w.Code != http.StatusOK {
+		t.Errorf("captain moderating a comment status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	var remaining int64
+	if err := f.db.Model(&MatchComment{}).Where("match_id = ?", f.match.ID).Count(&remaining).Error; err != nil {
+		t.Fatalf("failed to count comments: %v", err)
+	}
+	if remaining != 1 {
+		t.Errorf("%d comments left, want 1", remaining)
+	}
+}
+
+func TestGetMatchCommentsPaginatesNewestFirst(t *testing.T) {
+	f := newCommentFixture(t)
+	base := time.Now().Add(-time.Hour)
+	var ids []uint
+	for i := 0; i < 5; i++ {
+		comment := MatchComment{MatchID: f.match.ID, UserID: f.member.ID, Body: "Comment " + itoa(uint(i))}
+		comment.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		if err := f.db.Omit("User").Create(&comment).Error; err != nil {
+			t.Fatalf("failed to create comment: %v", err)
+		}
+		ids = append(ids, comment.ID)
+	}
+
+	var page struct {
+		Items      []MatchComment `json:"items"`
+		Pagination struct {
+			TotalItems int64 `json:"total_items"`
+			TotalPages int   `json:"total_pages"`
+		} `json:"pagination"`
+	}
+	w := testutil.Request(t, f.router(f.homeCaptain.ID), http.MethodGet, f.path()+"?page=1&page_size=2", nil)
+	testutil.DecodeData(t, w, &page)
+	if page.Pagination.TotalItems != 5 || page.Pagination.TotalPages != 3 {
+		t.Errorf("pagination = %+v, want 5 items over 3 pages", page.Pagination)
+	}
+	if len(page.Items) != 2 || page.Items[0].ID != ids[4] || page.Items[1].ID != ids[3] {
+		t.Fatalf("first page = %+v, want comments %d and %d", page.Items, ids[4], ids[3])
+	}
+	if page.Items[0].User.Username != f.member.Username {
+		t.Errorf("author username = %q, want %q", page.Items[0].User.Username, f.member.Username)
+	}
+
+	w = testutil.Request(t, f.router(f.homeCaptain.ID), http.MethodGet, f.path()+"?page=3&page_size=2", nil)
+	testutil.DecodeData(t, w, &page)
+	if len(page.Items) != 1 || page.Items[0].ID != ids[0] {
+		t.Errorf("last page = %+v, want the oldest comment %d", page.Items, ids[0])
+	}
+}
+
+// awaitingFixture is a match with no teams yet, as a bracket slot awaiting the previous round,
+// next to one that has both of its teams
+type awaitingFixture struct {
+	*twoTeamMatch
+	outsider *user.User
+	empty    *Match
+}
+
+func newAwaitingFixture(t *testing.T) *awaitingFixture {
+	t.Helper()
+	f := &awaitingFixture{twoTeamMatch: newTwoTeamMatch(t, withHomeCaptain("Manager"))}
+	f.outsider = testutil.CreateUser(t, f.db, "Outsider")
+	f.empty = createMatch(t, f.db, f.sport.ID, f.creator.ID, nil)
+	return f
+}
+
+// router serves the match lifecycle endpoints as the user
+func (f *awaitingFixture) router(userID uint) *gin.Engine {
+	r := gin.New()
+	r.Use(asUser(userID))
+	r.GET("/matches/:id", f.mc.GetMatchByID)
+	r.POST("/matches/:id/start", f.mc.StartMatch)
+	r.POST("/matches/:id/end", f.mc.EndMatch)
+	r.POST("/matches/:id/score", f.mc.UpdateMatchScore)
+	return r
+}
+
+func TestMatchAwaitingParticipantsIsFlagged(t *testing.T) {
+	f := newAwaitingFixture(t)
+	repo := NewGormMatchRepository(f.db)
+
+	for _, tt := range []struct {
+		match *Match
+		want  bool
+	}{{f.empty, true}, {f.match, false}} {
+		m, err := repo.GetMatchByID(tt.match.ID)
+		if err != nil {
+			t.Fatalf("GetMatchByID: %v", err)
+		}
+		if m.AwaitingParticipants != tt.want {
+			t.Errorf("match %d AwaitingParticipants = %v, want %v", tt.match.ID, m.AwaitingParticipants, tt.want)
+		}
+	}
+
+	matches, _, err := repo.GetMatches(map[string]interface{}{}, MatchExpand{}, 1, 10)
+	if err != nil {
+		t.Fatalf("GetMatches: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("listed %d matches, want 2", len(matches))
+	}
+	for _, m := range matches {
+		if want := m.ID == f.empty.ID; m.AwaitingParticipants != want {
+			t.Errorf("listed match %d AwaitingParticipants = %v, want %v", m.ID, m.AwaitingParticipants, want)
+		}
+	}
+
+	var got struct {
+		AwaitingParticipants bool `json:"awaiting_participants"`
+	}
+	testutil.DecodeData(t, testutil.Request(t, f.router(f.outsider.ID), http.MethodGet, "/matches/"+itoa(f.empty.ID), nil), &got)
+	if !got.AwaitingParticipants {
+		t.Error("match without teams not reported as awaiting participants")
+	}
+}
+
+func TestMatchAwaitingParticipantsHandlers(t *testing.T) {
+	t.Run("only the creator manages it", func(t *testing.T) {
+		f := newAwaitingFixture(t)
+		for _, u := range []*user.User{f.homeCaptain, f.outsider} {
+			ok, err := f.mc.canManageMatch(f.empty, u.ID)
+			if err != nil {
+				t.Fatalf("canManageMatch: %v", err)
+			}
+			if ok {
+				t.Errorf("user %d may manage a match with no teams", u.ID)
+			}
+			if w := testutil.Request(t, f.router(u.ID), http.MethodPost, "/matches/"+itoa(f.empty.ID)+"/start", nil); w.Code != http.StatusForbidden {
+				t.Errorf("start as user %d: status = %d, want %d", u.ID, w.Code, http.StatusForbidden)
+			}
+		}
+		if ok, err := f.mc.canManageMatch(f.empty, f.creator.ID); err != nil || !ok {
+			t.Errorf("canManageMatch(creator) = %v, %v, want true", ok, err)
+		}
+		if ok, err := f.mc.canManageMatch(f.match, f.homeCaptain.ID); err != nil || !ok {
+			t.Errorf("canManageMatch(team manager) = %v, %v, want true", ok, err)
+		}
+	})
+
+	t.Run("cannot be started", func(t *testing.T) {
+		f := newAwaitingFixture(t)
+		w := testutil.Request(t, f.router(f.creator.ID), http.MethodPost, "/matches/"+itoa(f.empty.ID)+"/start", nil)
+		if w.Code != http.StatusConflict {
+			t.Errorf("status = %d, want %d: %s", w.Code, http.StatusConflict, w.Body)
+		}
+		var m Match
+		if err := f.db.First(&m, f.empty.ID).Error; err != nil {
+			t.Fatalf("failed to reload match: %v", err)
+		}
+		if m.Status != StatusMatchUpcoming {
+			t.Errorf("status = %q, want it left %q", m.Status, StatusMatchUpcoming)
+		}
+	})
+
+	t.Run("cannot be scored or ended", func(t *testing.T) {
+		f := newAwaitingFixture(t)
+		if err := f.db.Model(f.empty).Update("status", StatusMatchLive).Error; err != nil {
+			t.Fatalf("failed to start match: %v", err)
+		}
+		for _, action := range []string{"score", "end"} {
+			w := testutil.Request(t, f.router(f.creator.ID), http.MethodPost, "/matches/"+itoa(f.empty.ID)+"/"+action, gin.H{})
+			if w.Code != http.StatusConflict {
+				t.Errorf("%s: status = %d, want %d: %s", action, w.Code, http.StatusConflict, w.Body)
+			}
+		}
+	})
+
+	t.Run("is not auto-started", func(t *testing.T) {
+		f := newAwaitingFixture(t)
+		due := map[string]interface{}{"auto_start": true, "scheduled_at": time.Now().Add(-time.Minute)}
+		if err := f.db.Model(&Match{}).Where("id IN ?", []uint{f.empty.ID, f.match.ID}).Updates(due).Error; err != nil {
+			t.Fatalf("failed to schedule matches: %v", err)
+		}
+		started, err := NewGormMatchRepository(f.db).AutoStartDueMatches(time.Now())
+		if err != nil {
+			t.Fatalf("AutoStartDueMatches: %v", err)
+		}
+		if started != 1 {
+			t.Errorf("started %d matches, want only the one with teams", started)
+		}
+		var m Match
+		if err := f.db.First(&m, f.empty.ID).Error; err != nil {
+			t.Fatalf("failed to reload match: %v", err)
+		}
+		if m.Status != StatusMatchUpcoming {
+			t.Errorf("match without teams auto-started to %q", m.Status)
+		}
+	})
+}
+
+func TestOpenTeamChallengeSkipsTeamsBlockedBySender(t *testing.T) {
+	db := newTestDB(t)
+	mc := newTestController(t, db)
+	sender := testutil.CreateUser(t, db, "Sender")
+	manager := testutil.CreateUser(t, db, "Manager")
+	s := createSport(t, db)
+	senderTeam := createTeam(t, db, s.ID, sender.ID)
+	blocked := createTeam(t, db, s.ID, manager.ID)
+	allowed := createTeam(t, db, s.ID, manager.ID)
+	if err := db.Omit("BlockedTeam").Create(&team.TeamBlock{BlockerTeamID: senderTeam.ID, BlockedTeamID: blocked.ID, CreatedByID: sender.ID}).Error; err != nil {
+		t.Fatalf("failed to block team: %v", err)
+	}
+	challenge := createChallenge(t, db, s.ID, sender.ID, senderTeam)
+	path := "/matches/challenges/" + itoa(challenge.ID)
+
+	r := gin.New()
+	r.Use(asUser(manager.ID))
+	r.GET("/matches/challenges/:id/eligible-teams", mc.GetEligibleTeams)
+	r.POST("/matches/challenges/:id/accept", mc.AcceptChallenge)
+
+	w := testutil.Request(t, r, http.MethodGet, path+"/eligible-teams", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("eligible teams status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	var eligible []team.Team
+	testutil.DecodeData(t, w, &eligible)
+	if len(eligible) != 1 || eligible[0].ID != allowed.ID {
+		t.Fatalf("eligible teams = %+v, want only team %d", eligible, allowed.ID)
+	}
+
+	if w := testutil.Request(t, r, http.MethodPost, path+"/accept", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("accept without a team status = %d, want %d: %s", w.Code, http.StatusBadRequest, w.Body)
+	}
+	if w := testutil.Request(t, r, http.MethodPost, path+"/accept", AcceptChallengeRequest{TeamID: blocked.ID}); w.Code != http.StatusForbidden {
+		t.Errorf("accept as the blocked team status = %d, want %d: %s", w.Code, http.StatusForbidden, w.Body)
+	}
+	if w := testutil.Request(t, r, http.MethodPost, path+"/accept", AcceptChallengeRequest{TeamID: allowed.ID}); w.Code != http.StatusOK {
+		t.Fatalf("accept as an eligible team status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	var stored Challenge
+	if err := db.First(&stored, challenge.ID).Error; err != nil {
+		t.Fatalf("failed to reload challenge: %v", err)
+	}
+	if stored.ReceiverTeamID == nil || *stored.ReceiverTeamID != allowed.ID {
+		t.Errorf("receiver team = %v, want %d", stored.ReceiverTeamID, allowed.ID)
+	}
+}
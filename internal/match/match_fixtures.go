@@ -0,0 +1,367 @@
+package match
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// fixtureRoundInterval separates the rounds of generated fixtures, starting at the tournament's start date
+const fixtureRoundInterval = 24 * time.Hour
+
+// unplayedFixtureStatuses are the statuses of tournament matches that have not been played
+// and can be replaced when fixtures are regenerated. Cancelled matches are kept as they are.
+var unplayedFixtureStatuses = []MatchStatus{StatusMatchPending, StatusMatchUpcoming, StatusMatchPostponed}
+
+// FixtureRegeneration reports the fixtures removed and created for a tournament
+type FixtureRegeneration struct {
+	TournamentID    uint    `json:"tournament_id"`
+	DeletedMatchIDs []uint  `json:"deleted_match_ids"`
+	Matches         []Match `json:"matches"`
+}
+
+// fixturePairing is one generated match between two teams
+type fixturePairing struct {
+	Round    int
+	Position int
+	HomeID   uint
+	AwayID   uint
+}
+
+// roundRobinPairings schedules every team against every other once using the circle method.
+// With an odd number of teams one team sits out each round.
+func roundRobinPairings(teamIDs []uint) []fixturePairing {
+	ids := append([]uint(nil), teamIDs...)
+	if len(ids)%2 == 1 {
+		ids = append(ids, 0) // Bye
+	}
+	n := len(ids)
+
+	var pairings []fixturePairing
+	for round := 1; round < n; round++ {
+		position := 1
+		for i := 0; i < n/2; i++ {
+			home, away := ids[i], ids[n-1-i]
+			if home == 0 || away == 0 {
+				continue
+			}
+			// Alternate the fixed team between home and away so it does not always host
+			if i == 0 && round%2 == 0 {
+				home, away = away, home
+			}
+			pairings = append(pairings, fixturePairing{Round: round, Position: position, HomeID: home, AwayID: away})
+			position++
+		}
+		// Keep the first team in place and rotate the others one step
+		last := ids[n-1]
+		copy(ids[2:], ids[1:n-1])
+		ids[1] = last
+	}
+	return pairings
+}
+
+// fixtureBye is a team that goes straight to round two of a knockout
+type fixtureBye struct {
+	Position int // Round two match it joins
+	TeamID   uint
+	Home     bool
+}
+
+// knockoutRounds returns how many rounds a knockout bracket for n teams has
+func knockoutRounds(n int) int {
+	rounds := 0
+	for size := 1; size < n; size *= 2 {
+		rounds++
+	}
+	return rounds
+}
+
+// knockoutPairings draws the first round of a knockout bracket, top seed against bottom seed.
+// When the teams do not fill a power of two bracket, the top seeds get byes into round two.
+// The winner of round one match p meets the winner of its neighbour in round two match (p+1)/2.
+func knockoutPairings(teamIDs []uint) ([]fixturePairing, []fixtureBye) {
+	size := 1 << knockoutRounds(len(teamIDs))
+
+	var pairings []fixturePairing
+	var byes []fixtureBye
+	for i := 1; i <= size/2; i++ {
+		opponent := size + 1 - i
+		if opponent > len(teamIDs) {
+			byes = append(byes, fixtureBye{Position: (i + 1) / 2, TeamID: teamIDs[i-1], Home: i%2 == 1})
+			continue
+		}
+		pairings = append(pairings, fixturePairing{Round: 1, Position: i, HomeID: teamIDs[i-1], AwayID: teamIDs[opponent-1]})
+	}
+	return pairings, byes
+}
+
+// knockoutTeamCount is the size of a knockout tournament's draw: its approved teams and those
+// that withdrew, whose places stay in the bracket
+func knockoutTeamCount(tx *gorm.DB, tournamentID uint) (int, error) {
+	var count int64
+	err := tx.Model(&TournamentTeam{}).
+		Where("tournament_id = ? AND status IN ?", tournamentID, []string{TournamentTeamApproved, TournamentTeamWithdrawn}).
+		Count(&count).Error
+	return int(count), err
+}
+
+// placeInKnockoutMatch adds a team to a later knockout match, creating the match the first
+// time one of its teams is known. Once both teams are in, the match is upcoming.
+func placeInKnockoutMatch(tx *gorm.DB, tournament *Tournament, round, position int, thirdPlace bool, teamID uint, home bool, scheduledAt time.Time) error {
+	var match Match
+	err := tx.Preload("MatchTeams").
+		Where("tournament_id = ? AND round = ? AND bracket_position = ? AND third_place = ? AND status <> ?",
+			tournament.ID, round, position, thirdPlace, StatusMatchCancelled).
+		First(&match).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		match = Match{
+			CreatedByUserID: tournament.CreatedByUserID,
+			SportID:         tournament.SportID,
+			ScheduledAt:     scheduledAt,
+			Status:          StatusMatchPending,
+			Visibility:      "public",
+			TournamentID:    &tournament.ID,
+			Round:           &round,
+			BracketPosition: &position,
+			ThirdPlace:      thirdPlace,
+		}
+		if err := tx.Omit("MatchTeams").Create(&match).Error; err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	for _, mt := range match.MatchTeams {
+		if mt.TeamID == teamID {
+			return nil // Already placed, e.g. when a result is recorded again
+		}
+	}
+	if len(match.MatchTeams) >= 2 {
+		return fmt.Errorf("knockout match %d already has both teams", match.ID)
+	}
+	if err := tx.Create(&MatchTeam{MatchID: match.ID, TeamID: teamID, IsHomeTeam: home}).Error; err != nil {
+		return err
+	}
+	if len(match.MatchTeams) == 1 && match.Status == StatusMatchPending {
+		return tx.Model(&Match{}).Where("id = ?", match.ID).
+			Updates(map[string]interface{}{"status": StatusMatchUpcoming, "version": bumpVersion}).Error
+	}
+	return nil
+}
+
+// advanceKnockoutWinner moves the winner of a knockout tournament match into the next round.
+// With a third place match, the losers of the semifinals are placed in it too. Matches outside
+// knockout tournaments, or without a bracket place, are left alone.
+func advanceKnockoutWinner(tx *gorm.DB, matchID, winnerID uint) error {
+	var match Match
+	if err := tx.Preload("MatchTeams").First(&match, matchID).Error; err != nil {
+		return err
+	}
+	if match.TournamentID == nil || match.Round == nil || match.BracketPosition == nil || match.ThirdPlace {
+		return nil
+	}
+	var tournament Tournament
+	if err := tx.First(&tournament, *match.TournamentID).Error; err != nil {
+		return err
+	}
+	if tournament.Format != "knockout" {
+		return nil
+	}
+
+	teams, err := knockoutTeamCount(tx, tournament.ID)
+	if err != nil {
+		return err
+	}
+	rounds := knockoutRounds(teams)
+	round, position := *match.Round, *match.BracketPosition
+	if round >= rounds {
+		return nil // The final
+	}
+
+	home := position%2 == 1
+	nextAt := match.ScheduledAt.Add(fixtureRoundInterval)
+	if err := placeInKnockoutMatch(tx, &tournament, round+1, (position+1)/2, false, winnerID, home, nextAt); err != nil {
+		return err
+	}
+
+	if tournament.ThirdPlaceMatch && round == rounds-1 && teams >= 4 {
+		for _, mt := range match.MatchTeams {
+			if mt.TeamID != winnerID {
+				// Placed beside the final so the bracket shows it in the last round
+				return placeInKnockoutMatch(tx, &tournament, rounds, 2, true, mt.TeamID, home, nextAt)
+			}
+		}
+	}
+	return nil
+}
+
+// RegenerateTournamentFixtures replaces the unplayed matches of a tournament that has not
+// started with fresh fixtures for its approved teams, in registration order. Leagues get a full
+// round robin; knockouts get their first round, with teams on a bye placed in round two. It is
+// refused once any match has been played.
+func (r *GormMatchRepository) RegenerateTournamentFixtures(tournamentID uint) (*FixtureRegeneration, error) {
+	result := &FixtureRegeneration{TournamentID: tournamentID, DeletedMatchIDs: []uint{}, Matches: []Match{}}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var tournament Tournament
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&tournament, tournamentID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTournamentNotFound
+			}
+			return err
+		}
+		if (tournament.Status != TournamentStatusRegistrationOpen && tournament.Status != TournamentStatusUpcoming) ||
+			(!tournament.StartDate.IsZero() && !tournament.StartDate.After(time.Now())) {
+			return ErrTournamentStarted
+		}
+
+		var played int64
+		if err := tx.Model(&Match{}).
+			Where("tournament_id = ? AND status NOT IN ? AND status <> ?", tournamentID, unplayedFixtureStatuses, StatusMatchCancelled).
+			Count(&played).Error; err != nil {
+			return err
+		}
+		if played > 0 {
+			return ErrTournamentMatchesPlayed
+		}
+
+		var teamIDs []uint
+		if err := tx.Model(&TournamentTeam{}).
+			Where("tournament_id = ? AND status = ?", tournamentID, TournamentTeamApproved).
+			Order("registered_at ASC, id ASC").
+			Pluck("team_id", &teamIDs).Error; err != nil {
+			return err
+		}
+		if len(teamIDs) < 2 {
+			return ErrNotEnoughTournamentTeams
+		}
+
+		if err := tx.Model(&Match{}).
+			Where("tournament_id = ? AND status IN ?", tournamentID, unplayedFixtureStatuses).
+			Pluck("id", &result.DeletedMatchIDs).Error; err != nil {
+			return err
+		}
+		if len(result.DeletedMatchIDs) > 0 {
+			if err := tx.Where("match_team_id IN (?)",
+				tx.Model(&MatchTeam{}).Select("id").Where("match_id IN ?", result.DeletedMatchIDs)).
+				Delete(&MatchPlayer{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("match_id IN ?", result.DeletedMatchIDs).Delete(&MatchTeam{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Delete(&Match{}, result.DeletedMatchIDs).Error; err != nil {
+				return err
+			}
+		}
+
+		var pairings []fixturePairing
+		var byes []fixtureBye
+		if tournament.Format == "knockout" {
+			pairings, byes = knockoutPairings(teamIDs)
+		} else {
+			pairings = roundRobinPairings(teamIDs)
+		}
+
+		start := tournament.StartDate
+		if start.IsZero() {
+			start = time.Now()
+		}
+		for _, p := range pairings {
+			round, position := p.Round, p.Position
+			match := Match{
+				CreatedByUserID: tournament.CreatedByUserID,
+				SportID:         tournament.SportID,
+				ScheduledAt:     start.Add(time.Duration(p.Round-1) * fixtureRoundInterval),
+				Status:          StatusMatchUpcoming,
+				Visibility:      "public",
+				TournamentID:    &tournament.ID,
+				Round:           &round,
+				BracketPosition: &position,
+				MatchTeams: []MatchTeam{
+					{TeamID: p.HomeID, IsHomeTeam: true},
+					{TeamID: p.AwayID},
+				},
+			}
+			if err := tx.Create(&match).Error; err != nil {
+				return err
+			}
+		}
+
+		// Teams with a bye wait in their round two match for the winner of round one
+		for _, bye := range byes {
+			if err := placeInKnockoutMatch(tx, &tournament, 2, bye.Position, false, bye.TeamID, bye.Home, start.Add(fixtureRoundInterval)); err != nil {
+				return err
+			}
+		}
+
+		return tx.Preload("MatchTeams", func(db *gorm.DB) *gorm.DB {
+			return db.Order("is_home_team DESC, id ASC")
+		}).
+			Where("tournament_id = ? AND status IN ?", tournamentID, unplayedFixtureStatuses).
+			Order("round ASC, bracket_position ASC").
+			Find(&result.Matches).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RegenerateTournamentFixtures rebuilds a tournament's fixtures for its current teams, e.g.
+// after a team left during registration. Only the tournament creator can do this, before the
+// tournament starts and while no match has been played.
+func (mc *MatchController) RegenerateTournamentFixtures(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	tournamentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid tournament ID")
+		return
+	}
+
+	tournament, err := mc.repo.GetTournamentByID(uint(tournamentID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch tournament: "+err.Error())
+		return
+	}
+	if tournament == nil {
+		response.Error(c, http.StatusNotFound, "Tournament not found")
+		return
+	}
+	if tournament.CreatedByUserID != userID {
+		response.Error(c, http.StatusForbidden, "Only the tournament creator can regenerate fixtures")
+		return
+	}
+
+	result, err := mc.repo.RegenerateTournamentFixtures(uint(tournamentID))
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrTournamentNotFound):
+			response.Error(c, http.StatusNotFound, "Tournament not found")
+		case errors.Is(err, ErrTournamentStarted):
+			response.Error(c, http.StatusBadRequest, "Fixtures can only be regenerated before the tournament starts")
+		case errors.Is(err, ErrTournamentMatchesPlayed):
+			response.Error(c, http.StatusConflict, "Fixtures cannot be regenerated once a tournament match has been played")
+		case errors.Is(err, ErrNotEnoughTournamentTeams):
+			response.Error(c, http.StatusBadRequest, "At least two approved teams are needed to generate fixtures")
+		default:
+			response.Error(c, http.StatusInternalServerError, "Failed to regenerate fixtures: "+err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Tournament fixtures regenerated", result)
+}
@@ -0,0 +1,169 @@
+package match
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ForfeitMatchRequest defines the payload for recording a team's forfeit
+type ForfeitMatchRequest struct {
+	TeamID uint   `json:"team_id" binding:"required"` // The team that forfeits
+	Reason string `json:"reason,omitempty" binding:"max=500"`
+}
+
+// forfeitableStatuses are the states a match can be forfeited from. Forfeits before the match
+// goes live are walkovers.
+var forfeitableStatuses = []MatchStatus{StatusMatchUpcoming, StatusMatchPreToss, StatusMatchTossDone, StatusMatchLive}
+
+// ForfeitMatch ends a two-team match as forfeited by forfeitingTeamID and awards the win to the
+// opponent, which moves on in a knockout tournament. A match forfeited before it went live is
+// recorded as a walkover. ErrMatchNotForfeitable is returned when the match has meanwhile
+// left the upcoming and live states.
+func (r *GormMatchRepository) ForfeitMatch(matchID, forfeitingTeamID uint, summary string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var opponent MatchTeam
+		if err := tx.Where("match_id = ? AND team_id <> ?", matchID, forfeitingTeamID).First(&opponent).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&Match{}).
+			Where("id = ? AND status IN ?", matchID, forfeitableStatuses).
+			Updates(map[string]interface{}{
+				"walkover":        gorm.Expr("status <> ?", StatusMatchLive),
+				"status":          StatusMatchForfeited,
+				"winning_team_id": opponent.TeamID,
+				"completed_at":    time.Now(),
+				"result_summary":  summary,
+				"version":         bumpVersion,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrMatchNotForfeitable
+		}
+
+		if err := tx.Model(&MatchTeam{}).Where("match_id = ? AND team_id = ?", matchID, forfeitingTeamID).
+			Update("result_status", ResultForfeit).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&MatchTeam{}).Where("match_id = ? AND team_id = ?", matchID, opponent.TeamID).
+			Update("result_status", ResultWin).Error; err != nil {
+			return err
+		}
+		return advanceKnockoutWinner(tx, matchID, opponent.TeamID)
+	})
+}
+
+// forfeitSummary describes the forfeit for the match's result summary
+func forfeitSummary(match *Match, forfeiting, opponent *MatchTeam, reason string) string {
+	var summary string
+	if match.Status == StatusMatchLive {
+		summary = opponent.Team.Name + " won: " + forfeiting.Team.Name + " forfeited the match"
+	} else {
+		summary = opponent.Team.Name + " won by walkover: " + forfeiting.Team.Name + " forfeited before the start"
+	}
+	if reason != "" {
+		summary += " (" + reason + ")"
+	}
+	return summary
+}
+
+// ForfeitMatch records that a team forfeits an upcoming or live two-team match, such as when it
+// does not show up. The opponent is awarded the win; a forfeit before the match went live is a
+// walkover. Only the match creator, team managers and match officials can record a forfeit.
+func (mc *MatchController) ForfeitMatch(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid match ID")
+		return
+	}
+
+	var req ForfeitMatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	match, err := mc.repo.GetMatchByID(uint(id))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
+		return
+	}
+	if match == nil {
+		response.Error(c, http.StatusNotFound, "Match not found")
+		return
+	}
+
+	isAuthorized, err := mc.canScoreMatch(match, userID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
+		return
+	}
+	if !isAuthorized {
+		response.Error(c, http.StatusForbidden, "You are not authorized to record a forfeit for this match")
+		return
+	}
+
+	forfeitable := false
+	for _, status := range forfeitableStatuses {
+		if match.Status == status {
+			forfeitable = true
+			break
+		}
+	}
+	if !forfeitable {
+		response.Error(c, http.StatusBadRequest, "Only upcoming or live matches can be forfeited")
+		return
+	}
+	if !match.HasParticipants() {
+		response.Error(c, http.StatusConflict, "Match is awaiting participants and cannot be forfeited")
+		return
+	}
+	if len(match.MatchTeams) > 2 {
+		response.Error(c, http.StatusBadRequest, "Forfeits can only be recorded for matches between two teams")
+		return
+	}
+
+	var forfeiting, opponent *MatchTeam
+	for i := range match.MatchTeams {
+		if match.MatchTeams[i].TeamID == req.TeamID {
+			forfeiting = &match.MatchTeams[i]
+		} else {
+			opponent = &match.MatchTeams[i]
+		}
+	}
+	if forfeiting == nil {
+		response.Error(c, http.StatusBadRequest, "Invalid team - team must be part of the match")
+		return
+	}
+
+	summary := forfeitSummary(match, forfeiting, opponent, req.Reason)
+	if err := mc.repo.ForfeitMatch(match.ID, req.TeamID, summary); err != nil {
+		if errors.Is(err, ErrMatchNotForfeitable) {
+			response.Error(c, http.StatusConflict, "Match is no longer upcoming or live")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to forfeit match: "+err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Match forfeited successfully", gin.H{
+		"winning_team_id": opponent.TeamID,
+		"walkover":        match.Status != StatusMatchLive,
+		"result_summary":  summary,
+	})
+}
@@ -0,0 +1,169 @@
+package match
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/internal/user"
+	"github.com/gin-gonic/gin"
+)
+
+// forfeitFixture is two teams, the home one managed by its captain, with no match yet
+type forfeitFixture struct {
+	*twoTeamMatch
+	outsider *user.User
+}
+
+func newForfeitFixture(t *testing.T) *forfeitFixture {
+	t.Helper()
+	f := &forfeitFixture{twoTeamMatch: newTwoTeamMatch(t, withHomeCaptain("Captain"), withoutMatch())}
+	f.outsider = testutil.CreateUser(t, f.db, "Outsider")
+	return f
+}
+
+func (f *forfeitFixture) forfeit(t *testing.T, userID, matchID uint, req interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	r := gin.New()
+	r.Use(asUser(userID))
+	r.POST("/matches/:id/forfeit", f.mc.ForfeitMatch)
+	return testutil.Request(t, r, http.MethodPost, "/matches/"+itoa(matchID)+"/forfeit", req)
+}
+
+// reload reloads the match and each team's result status
+func (f *forfeitFixture) reload(t *testing.T, matchID uint) (Match, map[uint]string) {
+	t.Helper()
+	var m Match
+	if err := f.db.Preload("MatchTeams").First(&m, matchID).Error; err != nil {
+		t.Fatalf("failed to reload match: %v", err)
+	}
+	results := map[uint]string{}
+	for _, mt := range m.MatchTeams {
+		results[mt.TeamID] = mt.ResultStatus
+	}
+	return m, results
+}
+
+func TestForfeitMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   MatchStatus
+		walkover bool
+	}{
+		{"upcoming match is a walkover", StatusMatchUpcoming, true},
+		{"live match", StatusMatchLive, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newForfeitFixture(t)
+			match := f.newMatch(t, inStatus(tt.status))
+
+			w := f.forfeit(t, f.homeCaptain.ID, match.ID, ForfeitMatchRequest{TeamID: f.home.ID, Reason: "No show"})
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+			}
+			var got struct {
+				WinningTeamID uint   `json:"winning_team_id"`
+				Walkover      bool   `json:"walkover"`
+				ResultSummary string `json:"result_summary"`
+			}
+			testutil.DecodeData(t, w, &got)
+			if got.WinningTeamID != f.away.ID || got.Walkover != tt.walkover {
+				t.Errorf("response = %+v, want team %d to win with walkover %v", got, f.away.ID, tt.walkover)
+			}
+
+			m, results := f.reload(t, match.ID)
+			if m.Status != StatusMatchForfeited || m.Walkover != tt.walkover || m.CompletedAt == nil {
+				t.Errorf("match = status %q, walkover %v, completed %v; want forfeited, walkover %v, completed",
+					m.Status, m.Walkover, m.CompletedAt, tt.walkover)
+			}
+			if m.WinningTeamID == nil || *m.WinningTeamID != f.away.ID {
+				t.Errorf("winning team = %v, want %d", m.WinningTeamID, f.away.ID)
+			}
+			if m.ResultSummary != got.ResultSummary || m.ResultSummary == "" {
+				t.Errorf("stored summary = %q, want %q", m.ResultSummary, got.ResultSummary)
+			}
+			if results[f.home.ID] != ResultForfeit || results[f.away.ID] != ResultWin {
+				t.Errorf("result statuses = %v, want %s for the home team and %s for the away team", results, ResultForfeit, ResultWin)
+			}
+
+			if w := f.forfeit(t, f.homeCaptain.ID, match.ID, ForfeitMatchRequest{TeamID: f.away.ID}); w.Code != http.StatusBadRequest {
+				t.Errorf("second forfeit: status = %d, want %d", w.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestForfeitMatchRules(t *testing.T) {
+	f := newForfeitFixture(t)
+	upcoming := f.newMatch(t, inStatus(StatusMatchUpcoming))
+	completed := f.newMatch(t, inStatus(StatusMatchCompleted))
+	other := createTeam(t, f.db, f.sport.ID, f.outsider.ID)
+
+	tests := []struct {
+		name    string
+		userID  uint
+		matchID uint
+		req     interface{}
+		status  int
+	}{
+		{"outsider", f.outsider.ID, upcoming.ID, ForfeitMatchRequest{TeamID: f.home.ID}, http.StatusForbidden},
+		{"team not in the match", f.homeCaptain.ID, upcoming.ID, ForfeitMatchRequest{TeamID: other.ID}, http.StatusBadRequest},
+		{"no team", f.homeCaptain.ID, upcoming.ID, gin.H{}, http.StatusBadRequest},
+		{"completed match", f.creator.ID, completed.ID, ForfeitMatchRequest{TeamID: f.home.ID}, http.StatusBadRequest},
+		{"unknown match", f.creator.ID, 999999, ForfeitMatchRequest{TeamID: f.home.ID}, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := f.forfeit(t, tt.userID, tt.matchID, tt.req); w.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+		})
+	}
+	if m, _ := f.reload(t, upcoming.ID); m.Status != StatusMatchUpcoming {
+		t.Errorf("rejected forfeits changed the match to %q", m.Status)
+	}
+
+	t.Run("official", func(t *testing.T) {
+		referee := testutil.CreateUser(t, f.db, "Referee")
+		official := MatchOfficial{MatchID: upcoming.ID, UserID: referee.ID, Role: OfficialRoleReferee, AssignedByUserID: f.creator.ID}
+		if err := f.db.Omit("User").Create(&official).Error; err != nil {
+			t.Fatalf("failed to assign official: %v", err)
+		}
+		if w := f.forfeit(t, referee.ID, upcoming.ID, ForfeitMatchRequest{TeamID: f.away.ID}); w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+		}
+	})
+}
+
+func TestForfeitMatchAdvancesKnockoutWinner(t *testing.T) {
+	f := newForfeitFixture(t)
+	tournament := createTournament(t, f.db, f.sport.ID, f.creator.ID, func(tr *Tournament) {
+		tr.Format = "knockout"
+		tr.Status = TournamentStatusOngoing
+	})
+	for _, tm := range []*team.Team{f.home, f.away, createTeam(t, f.db, f.sport.ID, f.creator.ID), createTeam(t, f.db, f.sport.ID, f.creator.ID)} {
+		registerTeam(t, f.db, tournament.ID, tm.ID)
+	}
+	semi := f.newMatch(t, inStatus(StatusMatchUpcoming), func(m *Match) {
+		m.TournamentID = &tournament.ID
+		m.Round = intPtr(1)
+		m.BracketPosition = intPtr(1)
+	})
+
+	if w := f.forfeit(t, f.homeCaptain.ID, semi.ID, ForfeitMatchRequest{TeamID: f.home.ID}); w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	var final Match
+	if err := f.db.Preload("MatchTeams").
+		Where("tournament_id = ? AND round = ? AND bracket_position = ?", tournament.ID, 2, 1).
+		First(&final).Error; err != nil {
+		t.Fatalf("final not created: %v", err)
+	}
+	if len(final.MatchTeams) != 1 || final.MatchTeams[0].TeamID != f.away.ID || !final.MatchTeams[0].IsHomeTeam {
+		t.Errorf("final teams = %+v, want the away team placed as home", final.MatchTeams)
+	}
+}
@@ -30,6 +30,16 @@ const (
 	StatusExpired   ChallengeStatus = "expired"
 	StatusCancelled ChallengeStatus = "cancelled"
 	StatusCompleted ChallengeStatus = "completed"
+	StatusCountered ChallengeStatus = "countered"
+)
+
+// CounterOfferStatus tracks the creator's response to a counter offer
+type CounterOfferStatus string
+
+const (
+	CounterOfferPending  CounterOfferStatus = "pending"
+	CounterOfferAccepted CounterOfferStatus = "accepted"
+	CounterOfferRejected CounterOfferStatus = "rejected"
 )
 
 type MatchStatus string
@@ -79,6 +89,9 @@ const (
 // --- Existing Challenge model (seems okay for setting up matches) ---
 type Challenge struct {
 	gorm.Model
+	// Version is bumped by every write to the row so a stale full update can be detected
+	Version int `json:"version" gorm:"not null;default:1"`
+
 	Title           string      `json:"title" gorm:"not null"`
 	Description     string      `json:"description" gorm:"type:text"`
 	SportID         uint        `json:"sport_id" gorm:"index;not null"`
@@ -115,11 +128,53 @@ type Challenge struct {
 	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
 	AcceptedAt       *time.Time `json:"accepted_at,omitempty"`
 	ScheduledMatchID *uint      `json:"scheduled_match_id,omitempty" gorm:"index;unique"`
+
+	CounterOffers []ChallengeCounterOffer `json:"counter_offers,omitempty" gorm:"foreignKey:ChallengeID"`
+}
+
+// ChallengeCounterOffer is a receiver's proposal of different terms for a challenge.
+// Unset fields keep the challenge's current terms.
+type ChallengeCounterOffer struct {
+	gorm.Model
+	ChallengeID      uint               `json:"challenge_id" gorm:"index;not null"`
+	ProposedByUserID uint               `json:"proposed_by_user_id" gorm:"index;not null"`
+	ProposedDateTime *time.Time         `json:"proposed_date_time,omitempty"`
+	VenueID          *uint              `json:"venue_id,omitempty"`
+	Message          string             `json:"message,omitempty" gorm:"type:text"`
+	Status           CounterOfferStatus `json:"status" gorm:"index;not null;default:'pending'"`
+	PreviousStatus   ChallengeStatus    `json:"-"` // Challenge status to restore if the offer is rejected
+	RespondedAt      *time.Time         `json:"responded_at,omitempty"`
+}
+
+// hasReceiver reports whether the challenge names the team or user it was sent to. Open
+// challenges may be sent to no one in particular until they are accepted.
+func (ch *Challenge) hasReceiver() bool {
+	switch ch.ChallengeType {
+	case OpenChallengeTeam, DirectChallengeTeam:
+		return ch.ReceiverTeamID != nil
+	case OpenChallengeIndividual, DirectChallengeIndividual:
+		return ch.ReceiverUserID != nil
+	}
+	return false
+}
+
+// pendingCounterOffer returns the latest counter offer awaiting the creator's response, or nil.
+// CounterOffers must be loaded.
+func (ch *Challenge) pendingCounterOffer() *ChallengeCounterOffer {
+	for i := len(ch.CounterOffers) - 1; i >= 0; i-- {
+		if ch.CounterOffers[i].Status == CounterOfferPending {
+			return &ch.CounterOffers[i]
+		}
+	}
+	return nil
 }
 
 // Match represents a sports game. Enhanced for pre-toss and live scoring.
 type Match struct {
 	gorm.Model
+	// Version is bumped by every write to the row so a stale full update can be detected
+	Version int `json:"version" gorm:"not null;default:1"`
+
 	CreatedByUserID uint         `json:"created_by_user_id" gorm:"index"`
 	CreatedByUser   user.User    `gorm:"foreignKey:CreatedByUserID"`
 	SportID         uint         `json:"sport_id" gorm:"index;not null"`
@@ -147,6 +202,18 @@ type Match struct {
 	StreamURL     string      `json:"stream_url,omitempty"`
 	VodURL        string      `json:"vod_url,omitempty"`
 	TournamentID  *uint       `json:"tournament_id,omitempty" gorm:"index"`
+	// Set once the upcoming-match reminder has gone out, so it is only sent once
+	ReminderSentAt *time.Time `json:"-" gorm:"index"`
+	// AutoStart makes the match go live at ScheduledAt; AutoComplete ends it Duration minutes
+	// after it starts, leaving the result for a manager to record
+	AutoStart    bool `json:"auto_start" gorm:"default:false"`
+	AutoComplete bool `json:"auto_complete" gorm:"default:false"`
+	// Place in a tournament bracket: Round counts from 1 (first round), BracketPosition orders
+	// the matches of a round from top to bottom
+	Round           *int `json:"round,omitempty"`
+	BracketPosition *int `json:"bracket_position,omitempty"`
+	// ThirdPlace marks the knockout match between the semifinal losers
+	ThirdPlace bool `json:"third_place,omitempty" gorm:"default:false"`
 	// Tournament      *Tournament  `gorm:"foreignKey:TournamentID"`
 
 	// Toss Information
@@ -160,16 +227,53 @@ type Match struct {
 	ResultSummary   string     `json:"result_summary,omitempty" gorm:"type:text"` // e.g., "Team A won by 5 wickets"
 	ManOfTheMatchID *uint      `json:"man_of_the_match_id,omitempty" gorm:"index"`
 	ManOfTheMatch   *user.User `gorm:"foreignKey:ManOfTheMatchID"`
+	// Walkover marks a forfeited match that was never played: the forfeit came before it went live
+	Walkover bool `json:"walkover,omitempty" gorm:"default:false"`
 
 	// Scorecard and Live Data
-	MatchTeams       []MatchTeam `json:"match_teams,omitempty" gorm:"foreignKey:MatchID"`
-	Innings          []Inning    `json:"innings_data,omitempty" gorm:"foreignKey:MatchID"` // Detailed innings data
-	CurrentInningsID *uint       `json:"current_innings_id,omitempty"`                     // To quickly identify the active innings
+	MatchTeams       []MatchTeam        `json:"match_teams,omitempty" gorm:"foreignKey:MatchID"`
+	Innings          []Inning           `json:"innings_data,omitempty" gorm:"foreignKey:MatchID"`  // Detailed innings data
+	PeriodScores     []MatchPeriodScore `json:"period_scores,omitempty" gorm:"foreignKey:MatchID"` // Per set/period breakdown
+	CurrentInningsID *uint              `json:"current_innings_id,omitempty"`                      // To quickly identify the active innings
 	// Scoreboard field (JSON) can be kept for a quick summary or derived from Innings.
 	// For live updates, Innings and BallDelivery are the source of truth.
 	// Scoreboard    string      `json:"scoreboard,omitempty" gorm:"type:json"`
+
+	// Set on loaded matches with fewer than two teams, such as a bracket slot awaiting the
+	// winners of the previous round
+	AwaitingParticipants bool `json:"awaiting_participants,omitempty" gorm:"-"`
 }
 
+// HasParticipants reports whether the match has the two or more teams it needs to be played.
+// MatchTeams must be loaded.
+func (m *Match) HasParticipants() bool {
+	return len(m.MatchTeams) >= 2
+}
+
+// AwaitingResult reports whether the match has finished without a result being recorded,
+// as happens when it is completed automatically
+func (m *Match) AwaitingResult() bool {
+	if m.Status != StatusMatchCompleted || m.WinningTeamID != nil {
+		return false
+	}
+	for _, mt := range m.MatchTeams {
+		if mt.ResultStatus != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Result statuses recorded on MatchTeam.ResultStatus
+const (
+	ResultWin      = "win"
+	ResultLoss     = "loss"
+	ResultDraw     = "draw"
+	ResultTie      = "tie"
+	ResultNoResult = "no_result"
+	ResultForfeit  = "forfeit" // The team forfeited the match; counted as a loss
+)
+
 // MatchTeam represents a team participating in a match.
 // Lineup now references MatchPlayer for structured player info.
 type MatchTeam struct {
@@ -184,14 +288,64 @@ type MatchTeam struct {
 	Players []MatchPlayer `json:"players,omitempty" gorm:"foreignKey:MatchTeamID"`
 
 	// Summary scores can be here, but detailed scores are in Innings
-	// Score        int       `json:"score" gorm:"default:0"` // This might be total score if innings not used, otherwise derive
+	Score int `json:"score" gorm:"not null;default:0"` // Total points/runs/goals for sports that do not use innings
 	// Wickets      int       `json:"wickets" gorm:"default:0"` // This might be total wickets if innings not used, otherwise derive
 	// OversPlayed  float32   `json:"overs_played" gorm:"default:0.0"` // This might be total overs if innings not used
 
 	ResultStatus string `json:"result_status,omitempty"`                 // "win", "loss", "draw", "tie", "no_result"
+	Rank         *int   `json:"rank,omitempty"`                          // Finishing position in a multi-team match, 1 for the winner
 	TeamDetails  string `json:"team_details,omitempty" gorm:"type:json"` // e.g., captain for the match if different
 }
 
+// MatchPeriodScore is a team's score in one period of a match: a set in tennis or volleyball,
+// a quarter or half elsewhere. Periods are numbered from 1. When a team has period scores its
+// MatchTeam.Score is their sum.
+type MatchPeriodScore struct {
+	gorm.Model
+	MatchID uint `json:"match_id" gorm:"not null;uniqueIndex:idx_match_period_team"`
+	TeamID  uint `json:"team_id" gorm:"not null;uniqueIndex:idx_match_period_team"`
+	Period  int  `json:"period" gorm:"not null;uniqueIndex:idx_match_period_team"`
+	Value   int  `json:"value" gorm:"not null;default:0"`
+}
+
+// MatchComment is a message in a match's discussion thread
+type MatchComment struct {
+	gorm.Model
+	MatchID uint      `json:"match_id" gorm:"index;not null"`
+	UserID  uint      `json:"user_id" gorm:"index;not null"`
+	User    user.User `json:"user" gorm:"foreignKey:UserID"`
+	Body    string    `json:"body" gorm:"type:text;not null"`
+	Flagged bool      `json:"flagged" gorm:"default:false"` // Caught by the comment filter; shown only to the author and moderators
+}
+
+// Match official roles
+const (
+	OfficialRoleReferee = "referee"
+	OfficialRoleScorer  = "scorer"
+)
+
+// MatchOfficial is a user assigned to officiate a match. Officials may update its scores and
+// record its result alongside the creator and team managers.
+type MatchOfficial struct {
+	gorm.Model
+	MatchID          uint      `json:"match_id" gorm:"not null;uniqueIndex:idx_match_official_user"`
+	UserID           uint      `json:"user_id" gorm:"index;not null;uniqueIndex:idx_match_official_user"`
+	User             user.User `json:"user" gorm:"foreignKey:UserID"`
+	Role             string    `json:"role" gorm:"not null;default:'referee'"`
+	AssignedByUserID uint      `json:"assigned_by_user_id"`
+}
+
+// MatchLineup records a player a team fields for a match
+type MatchLineup struct {
+	gorm.Model
+	MatchID   uint      `json:"match_id" gorm:"index;not null;uniqueIndex:idx_match_lineup_player"`
+	TeamID    uint      `json:"team_id" gorm:"index;not null"`
+	UserID    uint      `json:"user_id" gorm:"index;not null;uniqueIndex:idx_match_lineup_player"`
+	User      user.User `json:"user" gorm:"foreignKey:UserID"`
+	Position  string    `json:"position,omitempty"`
+	IsStarter bool      `json:"is_starter" gorm:"default:true"`
+}
+
 // MatchPlayer defines a player's role and participation in a specific match for a team.
 type MatchPlayer struct {
 	gorm.Model
@@ -340,6 +494,9 @@ type PlayerMatchStat struct {
 // --- Existing Tournament & TournamentTeam models (seem okay) ---
 type Tournament struct {
 	gorm.Model
+	// Version is bumped by every write to the row so a stale full update can be detected
+	Version int `json:"version" gorm:"not null;default:1"`
+
 	Name                 string      `json:"name" gorm:"not null"`
 	Description          string      `json:"description" gorm:"type:text"`
 	CreatedByUserID      uint        `json:"created_by_user_id" gorm:"index"`
@@ -358,8 +515,28 @@ type Tournament struct {
 	CurrentTeams         int         `json:"current_teams" gorm:"default:0"`
 	Status               string      `json:"status" gorm:"default:'registration_open'"`
 	Bracket              string      `json:"bracket,omitempty" gorm:"type:json"`
+	// Knockouts only: the semifinal losers play off for third place
+	ThirdPlaceMatch bool `json:"third_place_match" gorm:"default:false"`
+
+	Teams   []TournamentTeam `json:"teams,omitempty" gorm:"foreignKey:TournamentID"`
+	Matches []Match          `json:"matches,omitempty" gorm:"foreignKey:TournamentID"`
 }
 
+// Tournament lifecycle statuses
+const (
+	TournamentStatusRegistrationOpen = "registration_open"
+	TournamentStatusUpcoming         = "upcoming"
+	TournamentStatusOngoing          = "ongoing"
+	TournamentStatusCompleted        = "completed"
+	TournamentStatusCancelled        = "cancelled"
+)
+
+// Tournament registration statuses
+const (
+	TournamentTeamApproved  = "approved"
+	TournamentTeamWithdrawn = "withdrawn"
+)
+
 type TournamentTeam struct {
 	gorm.Model
 	TournamentID uint       `json:"tournament_id" gorm:"index;not null;uniqueIndex:idx_tournament_team_unique"`
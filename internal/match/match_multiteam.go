@@ -0,0 +1,171 @@
+package match
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateMultiTeamMatchRequest defines the payload for creating a match between several teams,
+// such as a relay or a free-for-all
+type CreateMultiTeamMatchRequest struct {
+	Title        string    `json:"title" binding:"required,min=3,max=200"`
+	Description  string    `json:"description" binding:"max=2000"`
+	SportID      uint      `json:"sport_id" binding:"required"`
+	TeamIDs      []uint    `json:"team_ids" binding:"required,min=2,max=32,dive,required"`
+	ScheduledAt  time.Time `json:"scheduled_at" binding:"required"`
+	Duration     int       `json:"duration,omitempty"`
+	VenueID      *uint     `json:"venue_id,omitempty"`
+	LocationText string    `json:"location_text,omitempty"`
+	EntryFee     float64   `json:"entry_fee,omitempty"`
+	WinningPrize string    `json:"winning_prize,omitempty"`
+	SkillLevel   string    `json:"skill_level,omitempty"`
+	CustomRules  string    `json:"custom_rules,omitempty"`
+	Visibility   string    `json:"visibility" binding:"omitempty,oneof=public private unlisted"`
+	AutoStart    bool      `json:"auto_start"`
+	AutoComplete bool      `json:"auto_complete"`
+}
+
+// TeamRanking is a team's finishing position when ending a multi-team match
+type TeamRanking struct {
+	TeamID uint `json:"team_id" binding:"required"`
+	Rank   int  `json:"rank" binding:"required,min=1"`
+}
+
+// checkTeamsPerMatch verifies that the sport exists and allows a match of `teams` teams. It
+// writes the error response and returns false when it does not.
+func (mc *MatchController) checkTeamsPerMatch(c *gin.Context, sportID uint, teams int) bool {
+	rules, err := mc.repo.GetSportRules(sportID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch sport: "+err.Error())
+		return false
+	}
+	if rules == nil {
+		response.Error(c, http.StatusBadRequest, "Sport not found")
+		return false
+	}
+
+	min, max := rules.TeamsPerMatch()
+	if teams < min || teams > max {
+		if min == max {
+			response.Error(c, http.StatusBadRequest, fmt.Sprintf("Matches of this sport are played by %d teams", min))
+		} else {
+			response.Error(c, http.StatusBadRequest, fmt.Sprintf("Matches of this sport are played by %d to %d teams", min, max))
+		}
+		return false
+	}
+	return true
+}
+
+// CreateMultiTeamMatch creates a match between any number of teams, within the sport's
+// min_teams and max_teams rules. The creator must manage every team.
+func (mc *MatchController) CreateMultiTeamMatch(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req CreateMultiTeamMatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	if req.AutoComplete && req.Duration <= 0 {
+		response.Error(c, http.StatusBadRequest, "auto_complete requires a duration")
+		return
+	}
+
+	seen := make(map[uint]bool, len(req.TeamIDs))
+	for _, teamID := range req.TeamIDs {
+		if seen[teamID] {
+			response.Error(c, http.StatusBadRequest, fmt.Sprintf("Team %d is listed more than once", teamID))
+			return
+		}
+		seen[teamID] = true
+
+		isManager, err := mc.isTeamManager(teamID, userID)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, fmt.Sprintf("Failed to validate team %d: %s", teamID, err.Error()))
+			return
+		}
+		if !isManager {
+			response.Error(c, http.StatusForbidden, fmt.Sprintf("You must be a manager of team %d to create a match", teamID))
+			return
+		}
+	}
+
+	if !mc.checkTeamsPerMatch(c, req.SportID, len(req.TeamIDs)) {
+		return
+	}
+
+	match := Match{
+		CreatedByUserID: userID,
+		SportID:         req.SportID,
+		VenueID:         req.VenueID,
+		LocationText:    req.LocationText,
+		ScheduledAt:     req.ScheduledAt,
+		Duration:        req.Duration,
+		Description:     req.Description,
+		CustomRules:     req.CustomRules,
+		EntryFee:        req.EntryFee,
+		WinningPrize:    req.WinningPrize,
+		SkillLevel:      req.SkillLevel,
+		Status:          StatusMatchUpcoming,
+		Visibility:      req.Visibility,
+		AutoStart:       req.AutoStart,
+		AutoComplete:    req.AutoComplete,
+	}
+
+	if !mc.createMatchWithTeams(c, &match, req.TeamIDs) {
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Match created successfully", gin.H{
+		"match": match,
+	})
+}
+
+// rankMatchTeams checks that rankings place every team of the match exactly once, with ranks
+// between 1 and the number of teams and a single team first. Teams may share lower ranks. It
+// returns the rank of each team and the winner, or the reason the rankings are invalid.
+func rankMatchTeams(match *Match, rankings []TeamRanking) (map[uint]int, uint, string) {
+	if len(rankings) != len(match.MatchTeams) {
+		return nil, 0, fmt.Sprintf("Rankings must list all %d teams in the match", len(match.MatchTeams))
+	}
+
+	inMatch := make(map[uint]bool, len(match.MatchTeams))
+	for _, mt := range match.MatchTeams {
+		inMatch[mt.TeamID] = true
+	}
+
+	ranks := make(map[uint]int, len(rankings))
+	var winner uint
+	for _, r := range rankings {
+		if !inMatch[r.TeamID] {
+			return nil, 0, fmt.Sprintf("Team %d is not part of the match", r.TeamID)
+		}
+		if _, dup := ranks[r.TeamID]; dup {
+			return nil, 0, fmt.Sprintf("Team %d is ranked more than once", r.TeamID)
+		}
+		if r.Rank > len(rankings) {
+			return nil, 0, fmt.Sprintf("Rank %d is beyond the number of teams", r.Rank)
+		}
+		if r.Rank == 1 {
+			if winner != 0 {
+				return nil, 0, "Only one team can be ranked first"
+			}
+			winner = r.TeamID
+		}
+		ranks[r.TeamID] = r.Rank
+	}
+	if winner == 0 {
+		return nil, 0, "One team must be ranked first"
+	}
+	return ranks, winner, ""
+}
@@ -0,0 +1,241 @@
+package match
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/internal/user"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// AssignMatchOfficialRequest defines the payload for assigning an official to a match
+type AssignMatchOfficialRequest struct {
+	UserID uint   `json:"user_id" binding:"required"`
+	Role   string `json:"role" binding:"omitempty,oneof=referee scorer"` // Defaults to referee
+}
+
+// AddMatchOfficial assigns a user as an official of a match and loads their summary
+func (r *GormMatchRepository) AddMatchOfficial(official *MatchOfficial) error {
+	var users int64
+	if err := r.db.Model(&user.User{}).Where("id = ?", official.UserID).Count(&users).Error; err != nil {
+		return err
+	}
+	if users == 0 {
+		return ErrOfficialUserNotFound
+	}
+
+	var existing int64
+	if err := r.db.Model(&MatchOfficial{}).
+		Where("match_id = ? AND user_id = ?", official.MatchID, official.UserID).
+		Count(&existing).Error; err != nil {
+		return err
+	}
+	if existing > 0 {
+		return ErrMatchOfficialExists
+	}
+
+	if err := r.db.Create(official).Error; err != nil {
+		return err
+	}
+	return r.db.Scopes(selectUserSummary).First(&official.User, official.UserID).Error
+}
+
+// RemoveMatchOfficial unassigns an official. The row is deleted outright so the user can be
+// assigned again later.
+func (r *GormMatchRepository) RemoveMatchOfficial(matchID, userID uint) error {
+	result := r.db.Unscoped().Where("match_id = ? AND user_id = ?", matchID, userID).Delete(&MatchOfficial{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrMatchOfficialNotFound
+	}
+	return nil
+}
+
+// GetMatchOfficials retrieves the officials of a match in the order they were assigned
+func (r *GormMatchRepository) GetMatchOfficials(matchID uint) ([]MatchOfficial, error) {
+	var officials []MatchOfficial
+	err := r.db.Preload("User", selectUserSummary).
+		Where("match_id = ?", matchID).
+		Order("id asc").
+		Find(&officials).Error
+	return officials, err
+}
+
+// IsMatchOfficial reports whether the user officiates the match
+func (r *GormMatchRepository) IsMatchOfficial(matchID, userID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&MatchOfficial{}).Where("match_id = ? AND user_id = ?", matchID, userID).Count(&count).Error
+	return count > 0, err
+}
+
+// canScoreMatch reports whether the user may update the match's scores and record its result:
+// anyone who can manage the match, or one of its assigned officials
+func (mc *MatchController) canScoreMatch(match *Match, userID uint) (bool, error) {
+	canManage, err := mc.canManageMatch(match, userID)
+	if err != nil || canManage {
+		return canManage, err
+	}
+	return mc.repo.IsMatchOfficial(match.ID, userID)
+}
+
+// loadManagedMatch resolves the match from the URL and checks the user can manage it. It writes
+// the error response and returns nil when the request should stop.
+func (mc *MatchController) loadManagedMatch(c *gin.Context, userID uint) *Match {
+	matchID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid match ID")
+		return nil
+	}
+
+	match, err := mc.repo.GetMatchByID(uint(matchID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
+		return nil
+	}
+	if match == nil {
+		response.Error(c, http.StatusNotFound, "Match not found")
+		return nil
+	}
+
+	isAuthorized, err := mc.canManageMatch(match, userID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check team management: "+err.Error())
+		return nil
+	}
+	if !isAuthorized {
+		response.Error(c, http.StatusForbidden, "You are not authorized to manage officials for this match")
+		return nil
+	}
+	return match
+}
+
+// GetMatchOfficials lists the officials assigned to a match
+func (mc *MatchController) GetMatchOfficials(c *gin.Context) {
+	matchID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid match ID")
+		return
+	}
+
+	match, err := mc.repo.GetMatchByID(uint(matchID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch match: "+err.Error())
+		return
+	}
+	if match == nil {
+		response.Error(c, http.StatusNotFound, "Match not found")
+		return
+	}
+
+	officials, err := mc.repo.GetMatchOfficials(match.ID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch match officials: "+err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "", officials)
+}
+
+// AssignMatchOfficial assigns a referee or scorer to a match. Only the match creator and team
+// managers can assign officials, and unless MATCH_ALLOW_PLAYER_OFFICIALS is set the official
+// must not be a member of either team.
+func (mc *MatchController) AssignMatchOfficial(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req AssignMatchOfficialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+	if req.Role == "" {
+		req.Role = OfficialRoleReferee
+	}
+
+	match := mc.loadManagedMatch(c, userID)
+	if match == nil {
+		return
+	}
+
+	switch match.Status {
+	case StatusMatchCompleted, StatusMatchCancelled, StatusMatchAbandoned, StatusMatchForfeited:
+		response.Error(c, http.StatusBadRequest, "Officials cannot be assigned to a match that has finished")
+		return
+	}
+
+	if !mc.appConfig.Matches.AllowPlayerOfficials {
+		for _, matchTeam := range match.MatchTeams {
+			isMember, err := mc.isTeamMember(matchTeam.TeamID, req.UserID)
+			if err != nil {
+				response.Error(c, http.StatusInternalServerError, "Failed to check team membership: "+err.Error())
+				return
+			}
+			if isMember {
+				response.Error(c, http.StatusBadRequest, "A player of one of the teams cannot officiate the match")
+				return
+			}
+		}
+	}
+
+	official := MatchOfficial{
+		MatchID:          match.ID,
+		UserID:           req.UserID,
+		Role:             req.Role,
+		AssignedByUserID: userID,
+	}
+	if err := mc.repo.AddMatchOfficial(&official); err != nil {
+		switch {
+		case errors.Is(err, ErrOfficialUserNotFound):
+			response.Error(c, http.StatusNotFound, "User not found")
+		case errors.Is(err, ErrMatchOfficialExists):
+			response.Error(c, http.StatusConflict, "User is already an official of this match")
+		default:
+			response.Error(c, http.StatusInternalServerError, "Failed to assign official: "+err.Error())
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Official assigned successfully", gin.H{
+		"official": official,
+	})
+}
+
+// RemoveMatchOfficial unassigns an official from a match. Only the match creator and team
+// managers can remove officials.
+func (mc *MatchController) RemoveMatchOfficial(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.Error(c, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	officialID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	match := mc.loadManagedMatch(c, userID)
+	if match == nil {
+		return
+	}
+
+	if err := mc.repo.RemoveMatchOfficial(match.ID, uint(officialID)); err != nil {
+		if errors.Is(err, ErrMatchOfficialNotFound) {
+			response.Error(c, http.StatusNotFound, "User is not an official of this match")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to remove official: "+err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Official removed successfully", nil)
+}
@@ -0,0 +1,178 @@
+package match
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/internal/user"
+	"github.com/gin-gonic/gin"
+)
+
+// officialFixture is a live match between two teams, with a referee who plays for neither
+type officialFixture struct {
+	*twoTeamMatch
+	referee  *user.User
+	player   *user.User
+	outsider *user.User
+}
+
+func newOfficialFixture(t *testing.T) *officialFixture {
+	t.Helper()
+	f := &officialFixture{twoTeamMatch: newTwoTeamMatch(t, withMatch(inStatus(StatusMatchLive)))}
+	f.referee = testutil.CreateUser(t, f.db, "Referee")
+	f.player = testutil.CreateUser(t, f.db, "Player")
+	f.outsider = testutil.CreateUser(t, f.db, "Outsider")
+	addTeamMember(t, f.db, f.home.ID, f.player.ID, "player")
+	return f
+}
+
+// router serves the official and scoring endpoints as the user
+func (f *officialFixture) router(userID uint) *gin.Engine {
+	r := gin.New()
+	r.Use(asUser(userID))
+	r.GET("/matches/:id/officials", f.mc.GetMatchOfficials)
+	r.POST("/matches/:id/officials", f.mc.AssignMatchOfficial)
+	r.DELETE("/matches/:id/officials/:user_id", f.mc.RemoveMatchOfficial)
+	r.POST("/matches/:id/score", f.mc.UpdateMatchScore)
+	r.POST("/matches/:id/periods", f.mc.SetMatchPeriodScore)
+	r.POST("/matches/:id/end", f.mc.EndMatch)
+	return r
+}
+
+func (f *officialFixture) path() string {
+	return "/matches/" + itoa(f.match.ID)
+}
+
+func (f *officialFixture) assign(t *testing.T, asUserID uint, req interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	return testutil.Request(t, f.router(asUserID), http.MethodPost, f.path()+"/officials", req)
+}
+
+func (f *officialFixture) score(t *testing.T, asUserID uint) *httptest.ResponseRecorder {
+	t.Helper()
+	score := 3
+	return testutil.Request(t, f.router(asUserID), http.MethodPost, f.path()+"/score",
+		UpdateMatchScoreRequest{TeamID: f.home.ID, Score: &score})
+}
+
+func TestAssignMatchOfficial(t *testing.T) {
+	f := newOfficialFixture(t)
+
+	w := f.assign(t, f.creator.ID, AssignMatchOfficialRequest{UserID: f.referee.ID})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+	}
+	var officials []MatchOfficial
+	testutil.DecodeData(t, testutil.Request(t, f.router(f.outsider.ID), http.MethodGet, f.path()+"/officials", nil), &officials)
+	if len(officials) != 1 || officials[0].UserID != f.referee.ID || officials[0].Role != OfficialRoleReferee {
+		t.Fatalf("officials = %+v, want the referee with the default role", officials)
+	}
+	if officials[0].User.ID != f.referee.ID {
+		t.Errorf("official's user = %+v, want the referee's summary", officials[0].User)
+	}
+
+	tests := []struct {
+		name   string
+		as     uint
+		req    interface{}
+		status int
+	}{
+		{"already assigned", f.creator.ID, AssignMatchOfficialRequest{UserID: f.referee.ID}, http.StatusConflict},
+		{"by an outsider", f.outsider.ID, AssignMatchOfficialRequest{UserID: f.outsider.ID}, http.StatusForbidden},
+		{"by an official", f.referee.ID, AssignMatchOfficialRequest{UserID: f.outsider.ID}, http.StatusForbidden},
+		{"unknown user", f.creator.ID, AssignMatchOfficialRequest{UserID: 999999}, http.StatusNotFound},
+		{"player of a team", f.creator.ID, AssignMatchOfficialRequest{UserID: f.player.ID}, http.StatusBadRequest},
+		{"unknown role", f.creator.ID, gin.H{"user_id": f.outsider.ID, "role": "coach"}, http.StatusBadRequest},
+		{"no user", f.creator.ID, gin.H{"role": OfficialRoleScorer}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := f.assign(t, tt.as, tt.req); w.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+		})
+	}
+
+	t.Run("player when allowed", func(t *testing.T) {
+		f := newOfficialFixture(t)
+		f.mc.appConfig.Matches.AllowPlayerOfficials = true
+		w := f.assign(t, f.creator.ID, AssignMatchOfficialRequest{UserID: f.player.ID, Role: OfficialRoleScorer})
+		if w.Code != http.StatusCreated {
+			t.Errorf("status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+		}
+	})
+
+	t.Run("finished match", func(t *testing.T) {
+		f := newOfficialFixture(t)
+		if err := f.db.Model(f.match).Update("status", StatusMatchCompleted).Error; err != nil {
+			t.Fatalf("failed to complete match: %v", err)
+		}
+		if w := f.assign(t, f.creator.ID, AssignMatchOfficialRequest{UserID: f.referee.ID}); w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+}
+
+func TestMatchOfficialScoringPermissions(t *testing.T) {
+	f := newOfficialFixture(t)
+
+	if w := f.score(t, f.referee.ID); w.Code != http.StatusForbidden {
+		t.Fatalf("score before assignment: status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if w := f.assign(t, f.creator.ID, AssignMatchOfficialRequest{UserID: f.referee.ID, Role: OfficialRoleScorer}); w.Code != http.StatusCreated {
+		t.Fatalf("assign: status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+	}
+
+	if w := f.score(t, f.referee.ID); w.Code != http.StatusOK {
+		t.Errorf("score as official: status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	value := 2
+	w := testutil.Request(t, f.router(f.referee.ID), http.MethodPost, f.path()+"/periods",
+		SetPeriodScoreRequest{TeamID: f.away.ID, Period: 1, Value: &value})
+	if w.Code != http.StatusOK {
+		t.Errorf("period score as official: status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	if w := f.score(t, f.outsider.ID); w.Code != http.StatusForbidden {
+		t.Errorf("score as outsider: status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if w := testutil.Request(t, f.router(f.referee.ID), http.MethodDelete, f.path()+"/officials/"+itoa(f.referee.ID), nil); w.Code != http.StatusForbidden {
+		t.Errorf("official removing themselves: status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+
+	t.Run("ends the match", func(t *testing.T) {
+		f := newOfficialFixture(t)
+		if w := f.assign(t, f.creator.ID, AssignMatchOfficialRequest{UserID: f.referee.ID}); w.Code != http.StatusCreated {
+			t.Fatalf("assign: status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+		}
+		w := testutil.Request(t, f.router(f.referee.ID), http.MethodPost, f.path()+"/end",
+			EndMatchRequest{Result: "win", WinningTeamID: f.home.ID})
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+		}
+		var m Match
+		if err := f.db.First(&m, f.match.ID).Error; err != nil {
+			t.Fatalf("failed to reload match: %v", err)
+		}
+		if m.Status != StatusMatchCompleted {
+			t.Errorf("status = %q, want %q", m.Status, StatusMatchCompleted)
+		}
+	})
+
+	t.Run("loses access once removed", func(t *testing.T) {
+		path := f.path() + "/officials/" + itoa(f.referee.ID)
+		if w := testutil.Request(t, f.router(f.creator.ID), http.MethodDelete, path, nil); w.Code != http.StatusOK {
+			t.Fatalf("remove: status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+		}
+		if w := f.score(t, f.referee.ID); w.Code != http.StatusForbidden {
+			t.Errorf("score after removal: status = %d, want %d", w.Code, http.StatusForbidden)
+		}
+		if w := testutil.Request(t, f.router(f.creator.ID), http.MethodDelete, path, nil); w.Code != http.StatusNotFound {
+			t.Errorf("second removal: status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+		if w := f.assign(t, f.creator.ID, AssignMatchOfficialRequest{UserID: f.referee.ID}); w.Code != http.StatusCreated {
+			t.Errorf("reassign: status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+		}
+	})
+}
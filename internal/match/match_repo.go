@@ -1,11 +1,18 @@
 package match
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"time"
 
+	"github.com/DhavalSuthar-24/miow/internal/sport"
 	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/user"
+	"github.com/DhavalSuthar-24/miow/internal/venue"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
 )
 
 // MatchRepository defines methods to interact with match-related data
@@ -18,39 +25,172 @@ type MatchRepository interface {
 	GetChallenges(filters map[string]interface{}, page, pageSize int) ([]Challenge, int64, error)
 	GetUserChallenges(userID uint, status string, page, pageSize int) ([]Challenge, int64, error)
 	GetTeamChallenges(teamID uint, status string, page, pageSize int) ([]Challenge, int64, error)
-	AcceptChallenge(challengeID, userID uint, acceptorType string) error
+	AcceptChallenge(challengeID, userID uint, acceptorType string, teamID uint) error
 	RejectChallenge(challengeID, userID uint, rejectorType string) error
-	ExpireChallenges() error
+	CounterChallenge(offer *ChallengeCounterOffer) error
+	AcceptCounterOffer(challengeID uint) error
+	RejectCounterOffer(challengeID uint) error
+	ExpireChallenges() (int64, error)
+	GetMatchmakingProfile(userID uint) (*MatchmakingProfile, error)
+	GetMatchmakingCandidates(userID uint, sportIDs, blockingTeamIDs []uint, limit int) ([]Challenge, error)
 
 	// Match methods
 	CreateMatch(match *Match) error
 	GetMatchByID(id uint) (*Match, error)
+	LockMatch(id uint) (*Match, error)
 	UpdateMatch(match *Match) error
 	DeleteMatch(id uint) error
-	GetMatches(filters map[string]interface{}, page, pageSize int) ([]Match, int64, error)
+	GetMatches(filters map[string]interface{}, expand MatchExpand, page, pageSize int) ([]Match, int64, error)
 	GetUserMatches(userID uint, status string, page, pageSize int) ([]Match, int64, error)
+	GetActionRequiredMatches(userID uint, now time.Time, staleScoreAfter time.Duration, limit int) ([]Match, error)
+	SetCalendarTokenHash(userID uint, tokenHash string) error
+	GetUserIDByCalendarTokenHash(tokenHash string) (uint, error)
+	GetUserConfirmedBookings(userID uint, from time.Time, limit int) ([]venue.Booking, error)
 	GetTeamMatches(teamID uint, status string, page, pageSize int) ([]Match, int64, error)
 	AddTeamToMatch(matchTeam *MatchTeam) error
 	UpdateMatchStatus(matchID uint, status MatchStatus) error
-	UpdateMatchScore(matchTeam *MatchTeam) error
-	EndMatch(matchID uint, winningTeamID uint) error
+	UpdateMatchScore(matchID, teamID uint, score int, increment bool, resultStatus string) (*MatchTeam, error)
+	SetPeriodScore(matchID, teamID uint, period, value int) (*MatchTeam, error)
+	EndMatch(matchID uint, result string, winningTeamID *uint, ranks map[uint]int) error
+	ForfeitMatch(matchID, forfeitingTeamID uint, summary string) error
+	GetSportRules(sportID uint) (*sport.Rules, error)
+	GetTeamRecord(teamID uint, tournamentID *uint, tournamentOnly bool) (*TeamRecord, error)
+	CountTeamMatchesAround(teamID uint, at time.Time, window time.Duration) (int64, error)
+	FindScheduleConflicts(teamIDs []uint, start, end time.Time, excludeTournamentID uint) ([]ScheduleConflict, error)
+	GetVenueResults(venueID uint, page, pageSize int) ([]Match, int64, error)
+	GetMatchTeam(matchID, teamID uint) (*MatchTeam, error)
+	ReplaceMatchLineup(matchID, teamID uint, lineup []MatchLineup) error
+	GetMatchLineup(matchID uint) ([]MatchLineup, error)
+	GetMatchTeamIDs(matchID uint) ([]uint, error)
+	ClaimMatchesForReminder(leadTime time.Duration) ([]Match, error)
+	AutoStartDueMatches(now time.Time) (int64, error)
+	AutoCompleteDueMatches(now time.Time) (int64, error)
+	GetMatchReminderRecipients(matchID uint) ([]uint, error)
+	CreateMatchComment(comment *MatchComment) error
+	GetMatchCommentByID(id uint) (*MatchComment, error)
+	GetMatchComments(matchID, viewerID uint, includeFlagged bool, page, pageSize int) ([]MatchComment, int64, error)
+	CountMatchComments(matchID uint) (int64, error)
+	DeleteMatchComment(id uint) error
+	AddMatchOfficial(official *MatchOfficial) error
+	RemoveMatchOfficial(matchID, userID uint) error
+	GetMatchOfficials(matchID uint) ([]MatchOfficial, error)
+	IsMatchOfficial(matchID, userID uint) (bool, error)
 
 	// Tournment methods
 	CreateTournament(tournament *Tournament) error
 	GetTournamentByID(id uint) (*Tournament, error)
+	GetTournamentBracketMatches(tournamentID uint) ([]Match, error)
+	GetTournamentTopPerformers(tournamentID uint, limit int) ([]TopPerformer, error)
 	GetTournaments(filters map[string]interface{}, page, pageSize int) ([]Tournament, int64, error)
 	UpdateTournament(tournament *Tournament) error
 	DeleteTournament(id uint) error
-	RegisterTeamInTournament(tournamentID uint, teamID uint) error
+	RegisterTeamInTournament(tournamentID uint, teamID uint, userID uint) error
 	UnregisterTeamFromTournament(tournamentID uint, teamID uint) error
+	RecomputeTournamentTeamCount(tournamentID uint) (previous, current int, err error)
+	WithdrawTeamFromTournament(tournamentID, teamID uint) ([]uint, error)
+	RepairTournamentTeamCounts() ([]uint, error)
+	RegenerateTournamentFixtures(tournamentID uint) (*FixtureRegeneration, error)
+
+	// Admin audit log
+	CreateAdminAuditLog(entry *AdminAuditLog) error
+	GetAdminAuditLogs(filter AdminAuditFilter, page, limit int) ([]AdminAuditLog, int64, error)
 
 	// Transaction support
 	WithTransaction(txFunc func(MatchRepository) error) error
+
+	// Health check
+	Ping(ctx context.Context) error
 }
 
+var (
+	// ErrChallengeNotFound is returned when the challenge does not exist
+	ErrChallengeNotFound = errors.New("challenge not found")
+	// ErrChallengeNotAcceptable is returned when a challenge is no longer open or pending,
+	// e.g. because a concurrent request already accepted it
+	ErrChallengeNotAcceptable = errors.New("challenge cannot be accepted in its current state")
+	// ErrNotChallengeReceiver is returned when the user or team may not accept the challenge
+	ErrNotChallengeReceiver = errors.New("not authorized to accept this challenge")
+	// ErrAcceptingTeamRequired is returned when an open team challenge is accepted without naming a team
+	ErrAcceptingTeamRequired = errors.New("a team is required to accept an open team challenge")
+	// ErrChallengeNotCounterable is returned when a counter offer is made on a challenge
+	// that is no longer open or pending
+	ErrChallengeNotCounterable = errors.New("challenge cannot be countered in its current state")
+	// ErrNoPendingCounterOffer is returned when responding to a challenge without an open counter offer
+	ErrNoPendingCounterOffer = errors.New("challenge has no pending counter offer")
+	// ErrTournamentNotFound is returned when the tournament does not exist
+	ErrTournamentNotFound = errors.New("tournament not found")
+	// ErrTournamentFull is returned when a tournament has no free team slots
+	ErrTournamentFull = errors.New("tournament has reached its maximum number of teams")
+	// ErrTeamSportMismatch is returned when a team registers for a tournament of another sport
+	ErrTeamSportMismatch = errors.New("team does not play the tournament's sport")
+	// ErrRegisteringTeamNotFound is returned when registering, or accepting a challenge with,
+	// a team that does not exist
+	ErrRegisteringTeamNotFound = errors.New("team not found")
+	// ErrNotTeamManager is returned when the user registering a team, or accepting a challenge
+	// for it, does not manage it
+	ErrNotTeamManager = errors.New("user is not a manager of the team")
+	// ErrTeamAlreadyRegistered is returned when a team registers twice for the same tournament
+	ErrTeamAlreadyRegistered = errors.New("team is already registered in this tournament")
+	// ErrTeamNotRegistered is returned when the team has no registration in the tournament
+	ErrTeamNotRegistered = errors.New("team is not registered in this tournament")
+	// ErrTournamentNotOngoing is returned when withdrawing from a tournament that is not running
+	ErrTournamentNotOngoing = errors.New("tournament is not ongoing")
+	// ErrTeamAlreadyWithdrawn is returned when the team has already withdrawn from the tournament
+	ErrTeamAlreadyWithdrawn = errors.New("team has already withdrawn from this tournament")
+	// ErrTournamentStarted is returned when changing fixtures of a tournament that has started
+	ErrTournamentStarted = errors.New("tournament has already started")
+	// ErrTournamentMatchesPlayed is returned when regenerating fixtures after a match has been played
+	ErrTournamentMatchesPlayed = errors.New("tournament has matches that have already been played")
+	// ErrNotEnoughTournamentTeams is returned when generating fixtures for fewer than two teams
+	ErrNotEnoughTournamentTeams = errors.New("at least two approved teams are needed for fixtures")
+	// ErrMatchTeamNotFound is returned when the team is not taking part in the match
+	ErrMatchTeamNotFound = errors.New("team is not part of this match")
+	// ErrPeriodOutOfSequence is returned when a period score skips ahead of the match's last period
+	ErrPeriodOutOfSequence = errors.New("period must be an existing period or the next one in sequence")
+	// ErrScoreDerivedFromPeriods is returned when setting a team's score directly while it is
+	// computed from period scores
+	ErrScoreDerivedFromPeriods = errors.New("score is derived from period scores; update the periods instead")
+	// ErrMatchOfficialExists is returned when assigning a user who already officiates the match
+	ErrMatchOfficialExists = errors.New("user is already an official of this match")
+	// ErrMatchOfficialNotFound is returned when removing a user who does not officiate the match
+	ErrMatchOfficialNotFound = errors.New("user is not an official of this match")
+	// ErrOfficialUserNotFound is returned when assigning an official who does not exist
+	ErrOfficialUserNotFound = errors.New("user not found")
+	// ErrVersionConflict is returned when a challenge, match or tournament was changed by
+	// another request since it was loaded
+	ErrVersionConflict = errors.New("record was modified by another request")
+	// ErrMatchNotForfeitable is returned when the match is no longer upcoming or live
+	ErrMatchNotForfeitable = errors.New("match cannot be forfeited in its current state")
+	// ErrLineupLocked is returned when replacing a lineup after the match has started
+	ErrLineupLocked = errors.New("lineups are locked once the match has started")
+)
+
 // GormMatchRepository implements MatchRepository using GORM
 type GormMatchRepository struct {
 	db *gorm.DB
+	// countLiveRegistrations makes tournament capacity checks count the stored registrations
+	// under a row lock instead of trusting current_teams
+	countLiveRegistrations bool
+}
+
+// bumpVersion goes into every partial update of a challenge, match or tournament, so a full
+// update of a copy loaded before it fails saveVersioned's version check
+var bumpVersion = gorm.Expr("version + 1")
+
+// saveVersioned saves every field of a record like Save, but only if the row still has the
+// version the record was loaded with, and bumps the version. ErrVersionConflict is returned
+// when another update got there first, or the row is gone.
+func saveVersioned(db *gorm.DB, record interface{}, version *int) error {
+	loaded := *version
+	*version = loaded + 1
+	result := db.Model(record).Where("version = ?", loaded).Select("*").Updates(record)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version = loaded
+	}
+	return result.Error
 }
 
 // NewGormMatchRepository creates a new GormMatchRepository
@@ -58,6 +198,17 @@ func NewGormMatchRepository(db *gorm.DB) *GormMatchRepository {
 	return &GormMatchRepository{db: db}
 }
 
+// WithLiveRegistrationCount toggles counting stored registrations for tournament capacity checks
+func (r *GormMatchRepository) WithLiveRegistrationCount(enabled bool) *GormMatchRepository {
+	r.countLiveRegistrations = enabled
+	return r
+}
+
+// withDB returns a copy of the repository that runs on db, e.g. inside a transaction
+func (r *GormMatchRepository) withDB(db *gorm.DB) *GormMatchRepository {
+	return &GormMatchRepository{db: db, countLiveRegistrations: r.countLiveRegistrations}
+}
+
 // WithTransaction implements transaction support
 func (r *GormMatchRepository) WithTransaction(txFunc func(MatchRepository) error) error {
 	tx := r.db.Begin()
@@ -65,7 +216,7 @@ func (r *GormMatchRepository) WithTransaction(txFunc func(MatchRepository) error
 		return tx.Error
 	}
 
-	txRepo := &GormMatchRepository{db: tx}
+	txRepo := r.withDB(tx)
 	err := txFunc(txRepo)
 	if err != nil {
 		tx.Rollback()
@@ -86,18 +237,15 @@ func (r *GormMatchRepository) CreateChallenge(challenge *Challenge) error {
 func (r *GormMatchRepository) GetChallengeByID(id uint) (*Challenge, error) {
 	var challenge Challenge
 	result := r.db.Preload("Sport").
-		Preload("CreatedByUser", func(db *gorm.DB) *gorm.DB {
-			return db.Select("ID, Username, FirstName, LastName, Avatar") // Only load essential user fields
-		}).
+		Preload("CreatedByUser", selectUserSummary).
 		Preload("SenderTeam").
 		Preload("ReceiverTeam").
-		Preload("SenderUser", func(db *gorm.DB) *gorm.DB {
-			return db.Select("ID, Username, FirstName, LastName, Avatar")
-		}).
-		Preload("ReceiverUser", func(db *gorm.DB) *gorm.DB {
-			return db.Select("ID, Username, FirstName, LastName, Avatar")
-		}).
+		Preload("SenderUser", selectUserSummary).
+		Preload("ReceiverUser", selectUserSummary).
 		Preload("Venue").
+		Preload("CounterOffers", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at asc")
+		}).
 		First(&challenge, id)
 
 	if result.Error != nil {
@@ -111,7 +259,7 @@ func (r *GormMatchRepository) GetChallengeByID(id uint) (*Challenge, error) {
 
 // UpdateChallenge updates an existing challenge
 func (r *GormMatchRepository) UpdateChallenge(challenge *Challenge) error {
-	return r.db.Save(challenge).Error
+	return saveVersioned(r.db, challenge, &challenge.Version)
 }
 
 // DeleteChallenge soft-deletes a challenge
@@ -119,12 +267,13 @@ func (r *GormMatchRepository) DeleteChallenge(id uint) error {
 	return r.db.Delete(&Challenge{}, id).Error
 }
 
-// GetChallenges retrieves challenges based on filters with pagination
+// GetChallenges retrieves challenges based on filters with pagination. Like the other public
+// lists it reads from the replica when one is configured.
 func (r *GormMatchRepository) GetChallenges(filters map[string]interface{}, page, pageSize int) ([]Challenge, int64, error) {
 	var challenges []Challenge
 	var total int64
 
-	query := r.db.Model(&Challenge{})
+	query := r.db.Clauses(dbresolver.Read).Model(&Challenge{})
 
 	// Apply filters
 	for key, value := range filters {
@@ -140,17 +289,11 @@ func (r *GormMatchRepository) GetChallenges(filters map[string]interface{}, page
 	// Apply pagination
 	offset := (page - 1) * pageSize
 	result := query.Preload("Sport").
-		Preload("CreatedByUser", func(db *gorm.DB) *gorm.DB {
-			return db.Select("ID, Username, FirstName, LastName, Avatar")
-		}).
+		Preload("CreatedByUser", selectUserSummary).
 		Preload("SenderTeam").
 		Preload("ReceiverTeam").
-		Preload("SenderUser", func(db *gorm.DB) *gorm.DB {
-			return db.Select("ID, Username, FirstName, LastName, Avatar")
-		}).
-		Preload("ReceiverUser", func(db *gorm.DB) *gorm.DB {
-			return db.Select("ID, Username, FirstName, LastName, Avatar")
-		}).
+		Preload("SenderUser", selectUserSummary).
+		Preload("ReceiverUser", selectUserSummary).
 		Preload("Venue").
 		Offset(offset).Limit(pageSize).
 		Find(&challenges)
@@ -185,17 +328,11 @@ func (r *GormMatchRepository) GetUserChallenges(userID uint, status string, page
 	// Apply pagination
 	offset := (page - 1) * pageSize
 	result := query.Preload("Sport").
-		Preload("CreatedByUser", func(db *gorm.DB) *gorm.DB {
-			return db.Select("ID, Username, FirstName, LastName, Avatar")
-		}).
+		Preload("CreatedByUser", selectUserSummary).
 		Preload("SenderTeam").
 		Preload("ReceiverTeam").
-		Preload("SenderUser", func(db *gorm.DB) *gorm.DB {
-			return db.Select("ID, Username, FirstName, LastName, Avatar")
-		}).
-		Preload("ReceiverUser", func(db *gorm.DB) *gorm.DB {
-			return db.Select("ID, Username, FirstName, LastName, Avatar")
-		}).
+		Preload("SenderUser", selectUserSummary).
+		Preload("ReceiverUser", selectUserSummary).
 		Preload("Venue").
 		Offset(offset).Limit(pageSize).
 		Find(&challenges)
@@ -229,17 +366,11 @@ func (r *GormMatchRepository) GetTeamChallenges(teamID uint, status string, page
 	// Apply pagination
 	offset := (page - 1) * pageSize
 	result := query.Preload("Sport").
-		Preload("CreatedByUser", func(db *gorm.DB) *gorm.DB {
-			return db.Select("ID, Username, FirstName, LastName, Avatar")
-		}).
+		Preload("CreatedByUser", selectUserSummary).
 		Preload("SenderTeam").
 		Preload("ReceiverTeam").
-		Preload("SenderUser", func(db *gorm.DB) *gorm.DB {
-			return db.Select("ID, Username, FirstName, LastName, Avatar")
-		}).
-		Preload("ReceiverUser", func(db *gorm.DB) *gorm.DB {
-			return db.Select("ID, Username, FirstName, LastName, Avatar")
-		}).
+		Preload("SenderUser", selectUserSummary).
+		Preload("ReceiverUser", selectUserSummary).
 		Preload("Venue").
 		Offset(offset).Limit(pageSize).
 		Find(&challenges)
@@ -252,52 +383,115 @@ func (r *GormMatchRepository) GetTeamChallenges(teamID uint, status string, page
 }
 
 // AcceptChallenge accepts a challenge and creates a match
-func (r *GormMatchRepository) AcceptChallenge(challengeID, userID uint, acceptorType string) error {
-	challenge, err := r.GetChallengeByID(challengeID)
+// The challenge row is locked for the duration of the transaction and its state re-checked,
+// so concurrent acceptances of the same challenge produce a single match; the losers get
+// ErrChallengeNotAcceptable. teamID is the team accepting a team challenge, which userID must
+// manage; it may be 0 when the challenge already names its receiver team. An open challenge
+// takes the accepting team or user as its receiver.
+func (r *GormMatchRepository) AcceptChallenge(challengeID, userID uint, acceptorType string, teamID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := r.withDB(tx)
+		return txRepo.acceptLockedChallenge(challengeID, userID, acceptorType, teamID)
+	})
+}
+
+// acceptLockedChallenge performs the acceptance; it must run inside a transaction
+func (r *GormMatchRepository) acceptLockedChallenge(challengeID, userID uint, acceptorType string, teamID uint) error {
+	challenge, err := r.lockChallenge(challengeID)
 	if err != nil {
 		return err
 	}
 
-	if challenge == nil {
-		return errors.New("challenge not found")
-	}
-
-	// Check if challenge is in a valid state to be accepted
-	if challenge.Status != StatusPending && challenge.Status != StatusOpen {
-		return errors.New("challenge cannot be accepted in its current state")
+	// Re-check the state now that the row is locked: another request may have won the race
+	if (challenge.Status != StatusPending && challenge.Status != StatusOpen) || challenge.ScheduledMatchID != nil {
+		return ErrChallengeNotAcceptable
 	}
 
 	// Validate acceptor
 	if acceptorType == "team" {
 		// For team challenges
-		if challenge.ChallengeType == OpenChallengeTeam || challenge.ChallengeType == DirectChallengeTeam {
-			// Check if acceptor team ID matches receiver team ID
-			team := r.db.Model(&team.Team{}).Where("id = ?", challenge.ReceiverTeamID).First(&team.Team{})
-			if team.Error != nil {
-				return errors.New("team not authorized to accept this challenge")
-			}
-		} else {
+		if challenge.ChallengeType != OpenChallengeTeam && challenge.ChallengeType != DirectChallengeTeam {
 			return errors.New("this is not a team challenge")
 		}
+		if challenge.ReceiverTeamID != nil {
+			if teamID != 0 && teamID != *challenge.ReceiverTeamID {
+				return ErrNotChallengeReceiver
+			}
+			teamID = *challenge.ReceiverTeamID
+		} else if teamID == 0 {
+			return ErrAcceptingTeamRequired
+		}
+		if challenge.SenderTeamID != nil && *challenge.SenderTeamID == teamID {
+			return ErrNotChallengeReceiver
+		}
+
+		var accepting team.Team
+		if err := r.db.Select("id", "created_by_id").First(&accepting, teamID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRegisteringTeamNotFound
+			}
+			return err
+		}
+		manages, err := r.managesTeam(&accepting, userID)
+		if err != nil {
+			return err
+		}
+		if !manages {
+			return ErrNotTeamManager
+		}
+		challenge.ReceiverTeamID = &teamID
 	} else if acceptorType == "individual" {
 		// For individual challenges
-		if challenge.ChallengeType == OpenChallengeIndividual || challenge.ChallengeType == DirectChallengeIndividual {
-			// Check if acceptor user ID matches receiver user ID
-			if challenge.ReceiverUserID == nil || *challenge.ReceiverUserID != userID {
-				return errors.New("user not authorized to accept this challenge")
-			}
-		} else {
+		if challenge.ChallengeType != OpenChallengeIndividual && challenge.ChallengeType != DirectChallengeIndividual {
 			return errors.New("this is not an individual challenge")
 		}
+		if challenge.ReceiverUserID == nil && challenge.ChallengeType == OpenChallengeIndividual {
+			if challenge.CreatedByUserID == userID || (challenge.SenderUserID != nil && *challenge.SenderUserID == userID) {
+				return ErrNotChallengeReceiver
+			}
+			challenge.ReceiverUserID = &userID
+		} else if challenge.ReceiverUserID == nil || *challenge.ReceiverUserID != userID {
+			return ErrNotChallengeReceiver
+		}
 	} else {
 		return errors.New("invalid acceptor type")
 	}
 
+	return r.createMatchFromChallenge(challenge)
+}
+
+// managesTeam reports whether the user created the team or is one of its active captains,
+// vice captains or moderators. t needs only its ID and CreatedByID.
+func (r *GormMatchRepository) managesTeam(t *team.Team, userID uint) (bool, error) {
+	if t.CreatedByID == userID {
+		return true, nil
+	}
+	var managers int64
+	if err := r.db.Model(&team.TeamMember{}).
+		Where("team_id = ? AND user_id = ? AND is_active = ?", t.ID, userID, true).
+		Where("role IN ? OR is_captain = ?", []string{"captain", "vice_captain", "moderator"}, true).
+		Count(&managers).Error; err != nil {
+		return false, err
+	}
+	return managers > 0, nil
+}
+
+// createMatchFromChallenge marks the challenge accepted and schedules its match on the
+// challenge's current terms. It must run inside a transaction holding the challenge row lock.
+func (r *GormMatchRepository) createMatchFromChallenge(challenge *Challenge) error {
 	// Update challenge status
 	now := time.Now()
 	challenge.Status = StatusAccepted
 	challenge.AcceptedAt = &now
 
+	if challenge.SenderTeamID != nil && challenge.ReceiverTeamID != nil {
+		teamIDs := []uint{*challenge.SenderTeamID, *challenge.ReceiverTeamID}
+		start := challenge.ProposedDateTime
+		if err := r.checkScheduleConflicts(teamIDs, start, matchEnd(start, 0), 0); err != nil {
+			return err
+		}
+	}
+
 	// Create match from challenge
 	match := Match{
 		CreatedByUserID: challenge.CreatedByUserID,
@@ -314,42 +508,39 @@ func (r *GormMatchRepository) AcceptChallenge(challengeID, userID uint, acceptor
 		Status:          StatusMatchUpcoming,
 	}
 
-	// Begin transaction
-	return r.WithTransaction(func(txRepo MatchRepository) error {
-		// Create match
-		if err := txRepo.CreateMatch(&match); err != nil {
-			return err
-		}
+	// Create match
+	if err := r.CreateMatch(&match); err != nil {
+		return err
+	}
 
-		// Link match back to challenge
-		challenge.ScheduledMatchID = &match.ID
-		if err := txRepo.UpdateChallenge(challenge); err != nil {
+	// Link match back to challenge
+	challenge.ScheduledMatchID = &match.ID
+	if err := r.UpdateChallenge(challenge); err != nil {
+		return err
+	}
+
+	// Add teams to match
+	if challenge.ChallengeType == OpenChallengeTeam || challenge.ChallengeType == DirectChallengeTeam {
+		// Add challenger team
+		senderTeam := MatchTeam{
+			MatchID: match.ID,
+			TeamID:  *challenge.SenderTeamID,
+		}
+		if err := r.AddTeamToMatch(&senderTeam); err != nil {
 			return err
 		}
 
-		// Add teams to match
-		if challenge.ChallengeType == OpenChallengeTeam || challenge.ChallengeType == DirectChallengeTeam {
-			// Add challenger team
-			senderTeam := MatchTeam{
-				MatchID: match.ID,
-				TeamID:  *challenge.SenderTeamID,
-			}
-			if err := txRepo.AddTeamToMatch(&senderTeam); err != nil {
-				return err
-			}
-
-			// Add receiver team
-			receiverTeam := MatchTeam{
-				MatchID: match.ID,
-				TeamID:  *challenge.ReceiverTeamID,
-			}
-			if err := txRepo.AddTeamToMatch(&receiverTeam); err != nil {
-				return err
-			}
+		// Add receiver team
+		receiverTeam := MatchTeam{
+			MatchID: match.ID,
+			TeamID:  *challenge.ReceiverTeamID,
 		}
+		if err := r.AddTeamToMatch(&receiverTeam); err != nil {
+			return err
+		}
+	}
 
-		return nil
-	})
+	return nil
 }
 
 // RejectChallenge rejects a challenge
@@ -398,12 +589,203 @@ func (r *GormMatchRepository) RejectChallenge(challengeID, userID uint, rejector
 	return r.UpdateChallenge(challenge)
 }
 
-// ExpireChallenges updates status of expired challenges
-func (r *GormMatchRepository) ExpireChallenges() error {
+// lockChallenge loads the challenge with a row lock; it must run inside a transaction
+func (r *GormMatchRepository) lockChallenge(challengeID uint) (*Challenge, error) {
+	var challenge Challenge
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&challenge, challengeID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrChallengeNotFound
+		}
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// pendingCounterOffer returns the challenge's latest counter offer still awaiting a response
+func (r *GormMatchRepository) pendingCounterOffer(challengeID uint) (*ChallengeCounterOffer, error) {
+	var offer ChallengeCounterOffer
+	err := r.db.Where("challenge_id = ? AND status = ?", challengeID, CounterOfferPending).
+		Order("created_at desc").
+		First(&offer).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoPendingCounterOffer
+		}
+		return nil, err
+	}
+	return &offer, nil
+}
+
+// CounterChallenge records a counter offer from the receiver and moves the challenge to countered
+func (r *GormMatchRepository) CounterChallenge(offer *ChallengeCounterOffer) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := r.withDB(tx)
+		challenge, err := txRepo.lockChallenge(offer.ChallengeID)
+		if err != nil {
+			return err
+		}
+		if challenge.Status != StatusOpen && challenge.Status != StatusPending {
+			return ErrChallengeNotCounterable
+		}
+
+		offer.Status = CounterOfferPending
+		offer.PreviousStatus = challenge.Status
+		if err := tx.Create(offer).Error; err != nil {
+			return err
+		}
+		return tx.Model(challenge).Updates(map[string]interface{}{"status": StatusCountered, "version": bumpVersion}).Error
+	})
+}
+
+// AcceptCounterOffer applies the pending counter offer's terms to the challenge and schedules the match
+func (r *GormMatchRepository) AcceptCounterOffer(challengeID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := r.withDB(tx)
+		challenge, err := txRepo.lockChallenge(challengeID)
+		if err != nil {
+			return err
+		}
+		if challenge.Status != StatusCountered || challenge.ScheduledMatchID != nil {
+			return ErrNoPendingCounterOffer
+		}
+		offer, err := txRepo.pendingCounterOffer(challengeID)
+		if err != nil {
+			return err
+		}
+
+		if offer.ProposedDateTime != nil {
+			challenge.ProposedDateTime = *offer.ProposedDateTime
+		}
+		if offer.VenueID != nil {
+			challenge.VenueID = offer.VenueID
+		}
+
+		now := time.Now()
+		offer.Status = CounterOfferAccepted
+		offer.RespondedAt = &now
+		if err := tx.Save(offer).Error; err != nil {
+			return err
+		}
+		return txRepo.createMatchFromChallenge(challenge)
+	})
+}
+
+// RejectCounterOffer declines the pending counter offer; the challenge returns to the state it was
+// in before the counter so the original terms still stand
+func (r *GormMatchRepository) RejectCounterOffer(challengeID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		txRepo := r.withDB(tx)
+		challenge, err := txRepo.lockChallenge(challengeID)
+		if err != nil {
+			return err
+		}
+		if challenge.Status != StatusCountered {
+			return ErrNoPendingCounterOffer
+		}
+		offer, err := txRepo.pendingCounterOffer(challengeID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		offer.Status = CounterOfferRejected
+		offer.RespondedAt = &now
+		if err := tx.Save(offer).Error; err != nil {
+			return err
+		}
+		return tx.Model(challenge).Updates(map[string]interface{}{"status": offer.PreviousStatus, "version": bumpVersion}).Error
+	})
+}
+
+// ExpireChallenges updates status of expired challenges and returns how many were expired
+func (r *GormMatchRepository) ExpireChallenges() (int64, error) {
 	now := time.Now()
-	return r.db.Model(&Challenge{}).
-		Where("expires_at < ? AND status IN ?", now, []ChallengeStatus{StatusOpen, StatusPending}).
-		Update("status", StatusExpired).Error
+	result := r.db.Model(&Challenge{}).
+		Where("expires_at < ? AND status IN ?", now, []ChallengeStatus{StatusOpen, StatusPending, StatusCountered}).
+		Updates(map[string]interface{}{"status": StatusExpired, "version": bumpVersion})
+	return result.RowsAffected, result.Error
+}
+
+// GetMatchmakingProfile loads the user's location and sports used to tailor the matchmaking feed.
+// Sports come from the user's registered sports (with their skill level) and from the sport
+// names listed in their preferred sports.
+func (r *GormMatchRepository) GetMatchmakingProfile(userID uint) (*MatchmakingProfile, error) {
+	var u user.User
+	if err := r.db.Select("id", "coordinates", "preferred_sports").First(&u, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	profile := &MatchmakingProfile{
+		Coordinates: u.Coordinates,
+		SportLevels: make(map[uint]string),
+	}
+
+	var userSports []sport.UserSport
+	if err := r.db.Where("user_id = ?", userID).Find(&userSports).Error; err != nil {
+		return nil, err
+	}
+	for _, us := range userSports {
+		profile.SportLevels[us.SportID] = us.Level
+	}
+
+	if len(u.PreferredSports) > 0 {
+		names := make([]string, 0, len(u.PreferredSports))
+		for _, name := range u.PreferredSports {
+			names = append(names, strings.ToLower(strings.TrimSpace(name)))
+		}
+		var sportIDs []uint
+		if err := r.db.Model(&sport.Sport{}).Where("LOWER(name) IN ?", names).Pluck("id", &sportIDs).Error; err != nil {
+			return nil, err
+		}
+		for _, id := range sportIDs {
+			if _, ok := profile.SportLevels[id]; !ok {
+				profile.SportLevels[id] = ""
+			}
+		}
+	}
+
+	return profile, nil
+}
+
+// GetMatchmakingCandidates retrieves up to limit of the soonest open, unexpired challenges for the
+// given sports that were not created by the user, sent by one of their teams or sent by a team in
+// blockingTeamIDs. Ranking and skill filtering happen in the caller.
+func (r *GormMatchRepository) GetMatchmakingCandidates(userID uint, sportIDs, blockingTeamIDs []uint, limit int) ([]Challenge, error) {
+	var challenges []Challenge
+	if len(sportIDs) == 0 {
+		return challenges, nil
+	}
+
+	now := time.Now()
+	query := r.db.Model(&Challenge{})
+	if len(blockingTeamIDs) > 0 {
+		query = query.Where("sender_team_id IS NULL OR sender_team_id NOT IN ?", blockingTeamIDs)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.
+		Where("status = ?", StatusOpen).
+		Where("challenge_type IN ?", []ChallengeType{OpenChallengeTeam, OpenChallengeIndividual}).
+		Where("sport_id IN ?", sportIDs).
+		Where("proposed_date_time > ?", now).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Where("created_by_user_id <> ?", userID).
+		Where("sender_user_id IS NULL OR sender_user_id <> ?", userID).
+		Where("sender_team_id IS NULL OR sender_team_id NOT IN (?)",
+			r.db.Model(&team.TeamMember{}).Select("team_id").Where("user_id = ? AND is_active = ?", userID, true)).
+		Order("proposed_date_time asc, id asc").
+		Preload("Sport").
+		Preload("SenderTeam").
+		Preload("Venue").
+		Find(&challenges).Error
+	if err != nil {
+		return nil, err
+	}
+	return challenges, nil
 }
 
 // Match Repository Methods
@@ -413,18 +795,39 @@ func (r *GormMatchRepository) CreateMatch(match *Match) error {
 	return r.db.Create(match).Error
 }
 
+// selectUserSummary limits a preloaded user to the public fields shown alongside
+// challenges, matches and tournaments
+func selectUserSummary(db *gorm.DB) *gorm.DB {
+	return db.Select("id", "name", "username", "profile_image")
+}
+
+// preloadMatchList loads the associations returned with a match. GORM resolves each preload
+// with a single IN query over the loaded matches, so the number of queries stays the same
+// whatever the page size.
+func preloadMatchList(db *gorm.DB) *gorm.DB {
+	return db.Preload("Sport").
+		Preload("CreatedByUser", selectUserSummary).
+		Preload("Venue").
+		Preload("MatchTeams").
+		Preload("MatchTeams.Team")
+}
+
+// flagAwaitingParticipants sets AwaitingParticipants on matches loaded with their teams
+func flagAwaitingParticipants(matches []Match) {
+	for i := range matches {
+		matches[i].AwaitingParticipants = !matches[i].HasParticipants()
+	}
+}
+
 // GetMatchByID retrieves a match by ID with all related entities
 func (r *GormMatchRepository) GetMatchByID(id uint) (*Match, error) {
 	var match Match
-	result := r.db.Preload("Sport").
-		Preload("CreatedByUser", func(db *gorm.DB) *gorm.DB {
-			return db.Select("ID, Username, FirstName, LastName, Avatar")
-		}).
-		Preload("Venue").
+	result := r.db.Scopes(preloadMatchList).
 		Preload("Challenge").
 		Preload("WinningTeam").
-		Preload("Teams").
-		Preload("Teams.Team").
+		Preload("PeriodScores", func(db *gorm.DB) *gorm.DB {
+			return db.Order("period ASC, team_id ASC")
+		}).
 		First(&match, id)
 
 	if result.Error != nil {
@@ -433,12 +836,13 @@ func (r *GormMatchRepository) GetMatchByID(id uint) (*Match, error) {
 		}
 		return nil, result.Error
 	}
+	match.AwaitingParticipants = !match.HasParticipants()
 	return &match, nil
 }
 
 // UpdateMatch updates an existing match
 func (r *GormMatchRepository) UpdateMatch(match *Match) error {
-	return r.db.Save(match).Error
+	return saveVersioned(r.db, match, &match.Version)
 }
 
 // DeleteMatch soft-deletes a match
@@ -447,11 +851,11 @@ func (r *GormMatchRepository) DeleteMatch(id uint) error {
 }
 
 // GetMatches retrieves matches based on filters with pagination
-func (r *GormMatchRepository) GetMatches(filters map[string]interface{}, page, pageSize int) ([]Match, int64, error) {
+func (r *GormMatchRepository) GetMatches(filters map[string]interface{}, expand MatchExpand, page, pageSize int) ([]Match, int64, error) {
 	var matches []Match
 	var total int64
 
-	query := r.db.Model(&Match{})
+	query := r.db.Clauses(dbresolver.Read).Model(&Match{})
 
 	// Apply filters
 	for key, value := range filters {
@@ -466,152 +870,575 @@ func (r *GormMatchRepository) GetMatches(filters map[string]interface{}, page, p
 
 	// Apply pagination
 	offset := (page - 1) * pageSize
-	result := query.Preload("Sport").
-		Preload("CreatedByUser", func(db *gorm.DB) *gorm.DB {
-			return db.Select("ID, Username, FirstName, LastName, Avatar")
-		}).
-		Preload("Venue").
-		Preload("Teams").
-		Preload("Teams.Team").
+	if expand.WinningTeam {
+		query = query.Preload("WinningTeam", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name", "logo")
+		})
+	}
+	result := query.Scopes(preloadMatchList).
 		Offset(offset).Limit(pageSize).
 		Find(&matches)
 
 	if result.Error != nil {
 		return nil, 0, result.Error
 	}
+	flagAwaitingParticipants(matches)
 
 	return matches, total, nil
 }
 
 // GetUserMatches retrieves matches for a specific user
 func (r *GormMatchRepository) GetUserMatches(userID uint, status string, page, pageSize int) ([]Match, int64, error) {
-	// Find team IDs where the user is a member
-	var teamIDs []uint
-	err := r.db.Table("team_members").
-		Where("user_id = ? AND is_active = ?", userID, true).
-		Pluck("team_id", &teamIDs).Error
+	// Matches created by the user or involving one of the teams they are an active member of
+	teamIDs := r.db.Table("team_members").
+		Select("team_id").
+		Where("user_id = ? AND is_active = ?", userID, true)
+	teamMatchIDs := r.db.Model(&MatchTeam{}).
+		Select("match_id").
+		Where("team_id IN (?)", teamIDs)
 
-	if err != nil {
-		return nil, 0, err
+	query := r.db.Model(&Match{}).
+		Where("matches.created_by_user_id = ? OR matches.id IN (?)", userID, teamMatchIDs)
+
+	return r.findMatchPage(query, status, page, pageSize)
+}
+
+// GetActionRequiredMatches finds matches the user created or manages a team in that are waiting
+// on them: live matches whose scores have not changed for staleScoreAfter, completed matches
+// with no result recorded, and matches past their scheduled time that have not been started.
+func (r *GormMatchRepository) GetActionRequiredMatches(userID uint, now time.Time, staleScoreAfter time.Duration, limit int) ([]Match, error) {
+	managedTeamIDs := r.db.Table("teams").
+		Select("id").
+		Where("created_by_id = ? AND deleted_at IS NULL", userID).
+		Or("id IN (?)", r.db.Table("team_members").
+			Select("team_id").
+			Where("user_id = ? AND is_active = ? AND deleted_at IS NULL", userID, true).
+			Where("role IN ? OR is_captain = ?", []string{"captain", "vice_captain", "moderator"}, true))
+	managedMatchIDs := r.db.Model(&MatchTeam{}).
+		Select("match_id").
+		Where("team_id IN (?)", managedTeamIDs)
+
+	lastScoredAt := `GREATEST(matches.started_at,
+		(SELECT MAX(mt.updated_at) FROM match_teams mt WHERE mt.match_id = matches.id AND mt.deleted_at IS NULL))`
+	noResult := `matches.winning_team_id IS NULL AND NOT EXISTS (
+		SELECT 1 FROM match_teams mt WHERE mt.match_id = matches.id AND mt.deleted_at IS NULL AND mt.result_status <> '')`
+
+	var matches []Match
+	err := r.db.Model(&Match{}).
+		Where("matches.created_by_user_id = ? OR matches.id IN (?)", userID, managedMatchIDs).
+		Where(r.db.Where("matches.status = ? AND COALESCE("+lastScoredAt+", matches.scheduled_at) < ?", StatusMatchLive, now.Add(-staleScoreAfter)).
+			Or("matches.status = ? AND "+noResult, StatusMatchCompleted).
+			Or("matches.status IN ? AND matches.scheduled_at < ?",
+				[]MatchStatus{StatusMatchPending, StatusMatchUpcoming, StatusMatchPreToss, StatusMatchTossDone}, now)).
+		Scopes(preloadMatchList).
+		Order("matches.scheduled_at ASC").
+		Limit(limit).
+		Find(&matches).Error
+	flagAwaitingParticipants(matches)
+	return matches, err
+}
+
+// SetCalendarTokenHash stores the hash of a user's calendar feed token, replacing any previous one
+func (r *GormMatchRepository) SetCalendarTokenHash(userID uint, tokenHash string) error {
+	return r.db.Model(&user.User{}).Where("id = ?", userID).Update("calendar_token_hash", tokenHash).Error
+}
+
+// GetUserIDByCalendarTokenHash finds the user a calendar feed token belongs to, returning 0 if none does
+func (r *GormMatchRepository) GetUserIDByCalendarTokenHash(tokenHash string) (uint, error) {
+	var u user.User
+	err := r.db.Select("id").Where("calendar_token_hash = ?", tokenHash).First(&u).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
 	}
+	return u.ID, err
+}
+
+// GetUserConfirmedBookings lists a user's confirmed bookings ending after from, soonest first,
+// with their court and venue
+func (r *GormMatchRepository) GetUserConfirmedBookings(userID uint, from time.Time, limit int) ([]venue.Booking, error) {
+	var bookings []venue.Booking
+	err := r.db.Preload("Ground.Venue").
+		Where("user_id = ? AND status = ? AND end_time >= ?", userID, "confirmed", from).
+		Order("start_time ASC").
+		Limit(limit).
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// GetTeamMatches retrieves matches for a specific team
+func (r *GormMatchRepository) GetTeamMatches(teamID uint, status string, page, pageSize int) ([]Match, int64, error) {
+	teamMatchIDs := r.db.Model(&MatchTeam{}).
+		Select("match_id").
+		Where("team_id = ?", teamID)
 
-	// Construct query to find matches related to the user
 	query := r.db.Model(&Match{}).
-		Joins("LEFT JOIN match_teams ON match_teams.match_id = matches.id").
-		Where("matches.created_by_user_id = ? OR match_teams.team_id IN ?", userID, teamIDs)
+		Where("matches.id IN (?)", teamMatchIDs)
 
+	return r.findMatchPage(query, status, page, pageSize)
+}
+
+// findMatchPage counts and loads one page of the matches selected by query, optionally
+// filtered by status. Matches are selected through subqueries rather than joins, so no
+// DISTINCT or second lookup by ID is needed.
+func (r *GormMatchRepository) findMatchPage(query *gorm.DB, status string, page, pageSize int) ([]Match, int64, error) {
 	if status != "" {
 		query = query.Where("matches.status = ?", status)
 	}
 
 	var total int64
-	err = query.Distinct("matches.id").Count(&total).Error
-	if err != nil {
+	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	// Get paginated results
+	var matches []Match
 	offset := (page - 1) * pageSize
-	var matchIDs []uint
-	err = query.Distinct("matches.id").
+	err := query.Scopes(preloadMatchList).
 		Offset(offset).Limit(pageSize).
-		Pluck("matches.id", &matchIDs).Error
-
+		Find(&matches).Error
 	if err != nil {
 		return nil, 0, err
 	}
+	flagAwaitingParticipants(matches)
 
-	var matches []Match
-	if len(matchIDs) > 0 {
-		err = r.db.Preload("Sport").
-			Preload("CreatedByUser", func(db *gorm.DB) *gorm.DB {
-				return db.Select("ID, Username, FirstName, LastName, Avatar")
-			}).
-			Preload("Venue").
-			Preload("Teams").
-			Preload("Teams.Team").
-			Where("id IN ?", matchIDs).
-			Find(&matches).Error
+	return matches, total, nil
+}
 
-		if err != nil {
-			return nil, 0, err
+// AddTeamToMatch adds a team to a match
+func (r *GormMatchRepository) AddTeamToMatch(matchTeam *MatchTeam) error {
+	return r.db.Create(matchTeam).Error
+}
+
+// UpdateMatchStatus updates the status of a match
+func (r *GormMatchRepository) UpdateMatchStatus(matchID uint, status MatchStatus) error {
+	return r.db.Model(&Match{}).Where("id = ?", matchID).
+		Updates(map[string]interface{}{"status": status, "version": bumpVersion}).Error
+}
+
+// UpdateMatchScore sets a team's score in a match, or adds to it when increment is true. An
+// increment never takes the score below zero. The result status is only changed when given.
+func (r *GormMatchRepository) UpdateMatchScore(matchID, teamID uint, score int, increment bool, resultStatus string) (*MatchTeam, error) {
+	var periods int64
+	if err := r.db.Model(&MatchPeriodScore{}).
+		Where("match_id = ? AND team_id = ?", matchID, teamID).
+		Count(&periods).Error; err != nil {
+		return nil, err
+	}
+	if periods > 0 {
+		return nil, ErrScoreDerivedFromPeriods
+	}
+
+	updates := map[string]interface{}{"score": score}
+	if increment {
+		updates["score"] = gorm.Expr("GREATEST(score + ?, 0)", score)
+	}
+	if resultStatus != "" {
+		updates["result_status"] = resultStatus
+	}
+
+	result := r.db.Model(&MatchTeam{}).
+		Where("match_id = ? AND team_id = ?", matchID, teamID).
+		Updates(updates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrMatchTeamNotFound
+	}
+
+	var matchTeam MatchTeam
+	if err := r.db.Where("match_id = ? AND team_id = ?", matchID, teamID).First(&matchTeam).Error; err != nil {
+		return nil, err
+	}
+	return &matchTeam, nil
+}
+
+// SetPeriodScore records a team's score for one period of a match and recomputes the team's
+// aggregate score as the sum of its periods. A period may be rewritten, or be the one after the
+// match's highest period so far; skipping ahead returns ErrPeriodOutOfSequence.
+func (r *GormMatchRepository) SetPeriodScore(matchID, teamID uint, period, value int) (*MatchTeam, error) {
+	var matchTeam MatchTeam
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the match so concurrent updates for either team see the same last period
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&Match{}, matchID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("match_id = ? AND team_id = ?", matchID, teamID).First(&matchTeam).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrMatchTeamNotFound
+			}
+			return err
+		}
+
+		var lastPeriod int
+		if err := tx.Model(&MatchPeriodScore{}).
+			Where("match_id = ?", matchID).
+			Select("COALESCE(MAX(period), 0)").
+			Scan(&lastPeriod).Error; err != nil {
+			return err
+		}
+		if period < 1 || period > lastPeriod+1 {
+			return ErrPeriodOutOfSequence
 		}
+
+		periodScore := MatchPeriodScore{MatchID: matchID, TeamID: teamID, Period: period, Value: value}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "match_id"}, {Name: "team_id"}, {Name: "period"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"value": value, "updated_at": time.Now()}),
+		}).Create(&periodScore).Error; err != nil {
+			return err
+		}
+
+		var total int
+		if err := tx.Model(&MatchPeriodScore{}).
+			Where("match_id = ? AND team_id = ?", matchID, teamID).
+			Select("COALESCE(SUM(value), 0)").
+			Scan(&total).Error; err != nil {
+			return err
+		}
+		matchTeam.Score = total
+		return tx.Model(&matchTeam).Update("score", total).Error
+	})
+	if err != nil {
+		return nil, err
 	}
+	return &matchTeam, nil
+}
 
-	return matches, total, nil
+// EndMatch ends a match with the given result and records each side's outcome, so the team
+// record counts every completed match whether or not it belongs to a tournament. A win needs
+// winningTeamID; draws and no-results leave the winning team empty. ranks, given for
+// multi-team matches, records each team's finishing position. Knockout tournament winners
+// move on to their next match.
+func (r *GormMatchRepository) EndMatch(matchID uint, result string, winningTeamID *uint, ranks map[uint]int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Match{}).
+			Where("id = ?", matchID).
+			Updates(map[string]interface{}{
+				"status":          StatusMatchCompleted,
+				"winning_team_id": winningTeamID,
+				"completed_at":    gorm.Expr("COALESCE(completed_at, ?)", time.Now()), // Kept when recording the result of an auto-completed match
+				"version":         bumpVersion,
+			}).Error; err != nil {
+			return err
+		}
+		for teamID, rank := range ranks {
+			if err := tx.Model(&MatchTeam{}).
+				Where("match_id = ? AND team_id = ?", matchID, teamID).
+				Update("rank", rank).Error; err != nil {
+				return err
+			}
+		}
+		if result != ResultWin || winningTeamID == nil {
+			return tx.Model(&MatchTeam{}).
+				Where("match_id = ?", matchID).
+				Update("result_status", result).Error
+		}
+		if err := tx.Model(&MatchTeam{}).
+			Where("match_id = ? AND team_id = ?", matchID, *winningTeamID).
+			Update("result_status", ResultWin).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&MatchTeam{}).
+			Where("match_id = ? AND team_id <> ?", matchID, *winningTeamID).
+			Update("result_status", ResultLoss).Error; err != nil {
+			return err
+		}
+		return advanceKnockoutWinner(tx, matchID, *winningTeamID)
+	})
 }
 
-// GetTeamMatches retrieves matches for a specific team
-func (r *GormMatchRepository) GetTeamMatches(teamID uint, status string, page, pageSize int) ([]Match, int64, error) {
-	query := r.db.Model(&Match{}).
-		Joins("LEFT JOIN match_teams ON match_teams.match_id = matches.id").
-		Where("match_teams.team_id = ?", teamID)
+// GetSportRules retrieves the rules of a sport, or nil if the sport does not exist
+func (r *GormMatchRepository) GetSportRules(sportID uint) (*sport.Rules, error) {
+	var s sport.Sport
+	if err := r.db.Select("id", "rules").First(&s, sportID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &s.Rules, nil
+}
 
-	if status != "" {
-		query = query.Where("matches.status = ?", status)
+// TeamRecord summarises a team's results in completed and forfeited matches
+type TeamRecord struct {
+	TeamID       uint  `json:"team_id"`
+	TournamentID *uint `json:"tournament_id,omitempty"`
+	Played       int64 `json:"played"`
+	Wins         int64 `json:"wins"`
+	Losses       int64 `json:"losses"`
+	Draws        int64 `json:"draws"` // Draws and ties
+	NoResults    int64 `json:"no_results"`
+	Forfeits     int64 `json:"forfeits"` // Matches the team forfeited, also counted in losses
+}
+
+// GetTeamRecord totals the team's results in completed and forfeited matches. A non-nil tournamentID limits
+// the record to that tournament; tournamentOnly limits it to tournament matches in general.
+func (r *GormMatchRepository) GetTeamRecord(teamID uint, tournamentID *uint, tournamentOnly bool) (*TeamRecord, error) {
+	record := TeamRecord{TeamID: teamID, TournamentID: tournamentID}
+	query := r.db.Model(&MatchTeam{}).
+		Select(`COUNT(*) AS played,
+			COUNT(*) FILTER (WHERE match_teams.result_status = ?) AS wins,
+			COUNT(*) FILTER (WHERE match_teams.result_status IN ?) AS losses,
+			COUNT(*) FILTER (WHERE match_teams.result_status IN ?) AS draws,
+			COUNT(*) FILTER (WHERE match_teams.result_status = ?) AS no_results,
+			COUNT(*) FILTER (WHERE match_teams.result_status = ?) AS forfeits`,
+			ResultWin, []string{ResultLoss, ResultForfeit}, []string{ResultDraw, ResultTie}, ResultNoResult, ResultForfeit).
+		Joins("JOIN matches ON matches.id = match_teams.match_id AND matches.deleted_at IS NULL").
+		Where("match_teams.team_id = ? AND matches.status IN ?", teamID, []MatchStatus{StatusMatchCompleted, StatusMatchForfeited})
+	if tournamentID != nil {
+		query = query.Where("matches.tournament_id = ?", *tournamentID)
+	} else if tournamentOnly {
+		query = query.Where("matches.tournament_id IS NOT NULL")
+	}
+	if err := query.Scan(&record).Error; err != nil {
+		return nil, err
 	}
+	return &record, nil
+}
 
+// CountTeamMatchesAround counts the team's active matches scheduled within `window` of `at`
+func (r *GormMatchRepository) CountTeamMatchesAround(teamID uint, at time.Time, window time.Duration) (int64, error) {
+	var count int64
+	err := r.db.Model(&Match{}).
+		Joins("JOIN match_teams ON match_teams.match_id = matches.id AND match_teams.deleted_at IS NULL").
+		Where("match_teams.team_id = ?", teamID).
+		Where("matches.scheduled_at BETWEEN ? AND ?", at.Add(-window), at.Add(window)).
+		Where("matches.status NOT IN ?", []MatchStatus{StatusMatchCancelled, StatusMatchCompleted, StatusMatchAbandoned, StatusMatchForfeited}).
+		Count(&count).Error
+	return count, err
+}
+
+// GetVenueResults retrieves completed public matches played at a venue, newest first
+func (r *GormMatchRepository) GetVenueResults(venueID uint, page, pageSize int) ([]Match, int64, error) {
+	var matches []Match
 	var total int64
-	err := query.Distinct("matches.id").Count(&total).Error
-	if err != nil {
+
+	query := r.db.Clauses(dbresolver.Read).Model(&Match{}).
+		Where("venue_id = ? AND status = ? AND visibility = ?", venueID, StatusMatchCompleted, "public")
+
+	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	// Get paginated results
 	offset := (page - 1) * pageSize
-	var matchIDs []uint
-	err = query.Distinct("matches.id").
+	result := query.Preload("Sport").
+		Preload("WinningTeam").
+		Preload("MatchTeams").
+		Preload("MatchTeams.Team").
+		Preload("Innings", func(db *gorm.DB) *gorm.DB {
+			return db.Order("innings_number asc")
+		}).
+		Order("completed_at desc NULLS LAST").
+		Order("scheduled_at desc").
 		Offset(offset).Limit(pageSize).
-		Pluck("matches.id", &matchIDs).Error
+		Find(&matches)
+
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
 
+	return matches, total, nil
+}
+
+// LockMatch loads the match and its teams with row locks, or nil if the match does not exist.
+// It must run inside a transaction.
+func (r *GormMatchRepository) LockMatch(id uint) (*Match, error) {
+	var match Match
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&match, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("match_id = ?", id).Order("id").Find(&match.MatchTeams).Error; err != nil {
+		return nil, err
+	}
+	return &match, nil
+}
+
+// GetMatchTeam retrieves a team's participation in a match
+func (r *GormMatchRepository) GetMatchTeam(matchID, teamID uint) (*MatchTeam, error) {
+	var matchTeam MatchTeam
+	if err := r.db.Where("match_id = ? AND team_id = ?", matchID, teamID).First(&matchTeam).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &matchTeam, nil
+}
+
+// ReplaceMatchLineup swaps a team's lineup for a match with the given players. The match is
+// locked and its status re-checked so a lineup cannot change once the match has gone live.
+func (r *GormMatchRepository) ReplaceMatchLineup(matchID, teamID uint, lineup []MatchLineup) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var match Match
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "status").First(&match, matchID).Error; err != nil {
+			return err
+		}
+		if isLineupLocked(match.Status) {
+			return ErrLineupLocked
+		}
+		if err := tx.Unscoped().Where("match_id = ? AND team_id = ?", matchID, teamID).Delete(&MatchLineup{}).Error; err != nil {
+			return err
+		}
+		if len(lineup) == 0 {
+			return nil
+		}
+		return tx.Create(&lineup).Error
+	})
+}
+
+// GetMatchLineup retrieves the lineups of all teams in a match
+func (r *GormMatchRepository) GetMatchLineup(matchID uint) ([]MatchLineup, error) {
+	var lineup []MatchLineup
+	err := r.db.Where("match_id = ?", matchID).
+		Preload("User", selectUserSummary).
+		Order("team_id asc, is_starter desc, id asc").
+		Find(&lineup).Error
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
+	return lineup, nil
+}
 
+// GetMatchTeamIDs retrieves the IDs of the teams participating in a match
+func (r *GormMatchRepository) GetMatchTeamIDs(matchID uint) ([]uint, error) {
+	var teamIDs []uint
+	if err := r.db.Model(&MatchTeam{}).Where("match_id = ?", matchID).Pluck("team_id", &teamIDs).Error; err != nil {
+		return nil, err
+	}
+	return teamIDs, nil
+}
+
+// ClaimMatchesForReminder marks upcoming matches starting within leadTime as reminded and
+// returns them. Marking and selecting happen in one UPDATE, so each match is returned once
+// even if several workers run concurrently.
+func (r *GormMatchRepository) ClaimMatchesForReminder(leadTime time.Duration) ([]Match, error) {
+	now := time.Now()
 	var matches []Match
-	if len(matchIDs) > 0 {
-		err = r.db.Preload("Sport").
-			Preload("CreatedByUser", func(db *gorm.DB) *gorm.DB {
-				return db.Select("ID, Username, FirstName, LastName, Avatar")
-			}).
-			Preload("Venue").
-			Preload("Teams").
-			Preload("Teams.Team").
-			Where("id IN ?", matchIDs).
-			Find(&matches).Error
+	err := r.db.Model(&matches).
+		Clauses(clause.Returning{}).
+		Where("status = ? AND reminder_sent_at IS NULL AND scheduled_at > ? AND scheduled_at <= ?",
+			StatusMatchUpcoming, now, now.Add(leadTime)).
+		Updates(map[string]interface{}{"reminder_sent_at": now, "version": bumpVersion}).Error
+	return matches, err
+}
 
-		if err != nil {
-			return nil, 0, err
+// AutoStartDueMatches puts upcoming auto-start matches whose scheduled time has passed live
+func (r *GormMatchRepository) AutoStartDueMatches(now time.Time) (int64, error) {
+	result := r.db.Model(&Match{}).
+		Where("auto_start = ? AND status = ? AND scheduled_at <= ?", true, StatusMatchUpcoming, now).
+		// Matches still awaiting participants stay put
+		Where("(SELECT COUNT(*) FROM match_teams mt WHERE mt.match_id = matches.id AND mt.deleted_at IS NULL) >= 2").
+		Updates(map[string]interface{}{
+			"status":     StatusMatchLive,
+			"started_at": gorm.Expr("COALESCE(started_at, ?)", now),
+			"version":    bumpVersion,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// AutoCompleteDueMatches completes live auto-complete matches that have run for their duration.
+// No winner is set, so the result stays pending until a manager records it.
+func (r *GormMatchRepository) AutoCompleteDueMatches(now time.Time) (int64, error) {
+	result := r.db.Model(&Match{}).
+		Where("auto_complete = ? AND status = ? AND duration > 0", true, StatusMatchLive).
+		Where("COALESCE(started_at, scheduled_at) + duration * INTERVAL '1 minute' <= ?", now).
+		Updates(map[string]interface{}{
+			"status":       StatusMatchCompleted,
+			"completed_at": now,
+			"version":      bumpVersion,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// GetMatchReminderRecipients returns the users to remind about a match: players named in its
+// lineups plus the active members of its teams
+func (r *GormMatchRepository) GetMatchReminderRecipients(matchID uint) ([]uint, error) {
+	var lineupUserIDs []uint
+	if err := r.db.Model(&MatchLineup{}).Where("match_id = ?", matchID).
+		Distinct().Pluck("user_id", &lineupUserIDs).Error; err != nil {
+		return nil, err
+	}
+
+	var memberUserIDs []uint
+	if err := r.db.Model(&team.TeamMember{}).
+		Where("is_active = ? AND team_id IN (?)", true,
+			r.db.Model(&MatchTeam{}).Select("team_id").Where("match_id = ?", matchID)).
+		Distinct().Pluck("user_id", &memberUserIDs).Error; err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uint]bool, len(lineupUserIDs)+len(memberUserIDs))
+	userIDs := make([]uint, 0, len(lineupUserIDs)+len(memberUserIDs))
+	for _, id := range append(lineupUserIDs, memberUserIDs...) {
+		if !seen[id] {
+			seen[id] = true
+			userIDs = append(userIDs, id)
 		}
 	}
+	return userIDs, nil
+}
 
-	return matches, total, nil
+// CreateMatchComment adds a comment to a match thread and loads its author
+func (r *GormMatchRepository) CreateMatchComment(comment *MatchComment) error {
+	if err := r.db.Create(comment).Error; err != nil {
+		return err
+	}
+	return r.db.Select("id", "name", "username", "profile_image").First(&comment.User, comment.UserID).Error
 }
 
-// AddTeamToMatch adds a team to a match
-func (r *GormMatchRepository) AddTeamToMatch(matchTeam *MatchTeam) error {
-	return r.db.Create(matchTeam).Error
+// GetMatchCommentByID retrieves a single match comment
+func (r *GormMatchRepository) GetMatchCommentByID(id uint) (*MatchComment, error) {
+	var comment MatchComment
+	if err := r.db.First(&comment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &comment, nil
 }
 
-// UpdateMatchStatus updates the status of a match
-func (r *GormMatchRepository) UpdateMatchStatus(matchID uint, status MatchStatus) error {
-	return r.db.Model(&Match{}).Where("id = ?", matchID).Update("status", status).Error
+// GetMatchComments retrieves a match thread newest-first with pagination. Unless includeFlagged
+// is set, flagged comments are left out except the viewer's own.
+func (r *GormMatchRepository) GetMatchComments(matchID, viewerID uint, includeFlagged bool, page, pageSize int) ([]MatchComment, int64, error) {
+	var comments []MatchComment
+	var total int64
+
+	query := r.db.Model(&MatchComment{}).Where("match_id = ?", matchID)
+	if !includeFlagged {
+		query = query.Where("flagged = ? OR user_id = ?", false, viewerID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Preload("User", selectUserSummary).
+		Order("created_at desc, id desc").
+		Offset(offset).Limit(pageSize).
+		Find(&comments).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return comments, total, nil
 }
 
-// UpdateMatchScore updates the score for a team in a match
-func (r *GormMatchRepository) UpdateMatchScore(matchTeam *MatchTeam) error {
-	return r.db.Save(matchTeam).Error
+// CountMatchComments counts the comments in a match thread, flagged ones included
+func (r *GormMatchRepository) CountMatchComments(matchID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&MatchComment{}).Where("match_id = ?", matchID).Count(&count).Error
+	return count, err
 }
 
-// EndMatch ends a match and updates the winning team
-func (r *GormMatchRepository) EndMatch(matchID uint, winningTeamID uint) error {
-	return r.db.Model(&Match{}).
-		Where("id = ?", matchID).
-		Updates(map[string]interface{}{
-			"status":          StatusMatchCompleted,
-			"winning_team_id": winningTeamID,
-		}).Error
+// DeleteMatchComment soft-deletes a match comment
+func (r *GormMatchRepository) DeleteMatchComment(id uint) error {
+	return r.db.Delete(&MatchComment{}, id).Error
 }
+
 func (r *GormMatchRepository) CreateTournament(tournament *Tournament) error {
 	return r.db.Create(tournament).Error
 }
@@ -620,15 +1447,13 @@ func (r *GormMatchRepository) CreateTournament(tournament *Tournament) error {
 func (r *GormMatchRepository) GetTournamentByID(id uint) (*Tournament, error) {
 	var tournament Tournament
 	result := r.db.Preload("Sport").
-		Preload("CreatedByUser", func(db *gorm.DB) *gorm.DB {
-			return db.Select("ID, Username, FirstName, LastName, Avatar")
-		}).
+		Preload("CreatedByUser", selectUserSummary).
 		Preload("Teams").
 		Preload("Teams.Team", func(db *gorm.DB) *gorm.DB { // Select specific fields for team to avoid loading too much
-			return db.Select("ID, Name, Avatar")
+			return db.Select("id", "name", "logo")
 		}).
 		Preload("Matches", func(db *gorm.DB) *gorm.DB { // Select specific fields for matches
-			return db.Select("ID, ScheduledAt, Status, TournamentID")
+			return db.Select("id", "scheduled_at", "status", "tournament_id")
 		}).
 		First(&tournament, id)
 
@@ -641,15 +1466,60 @@ func (r *GormMatchRepository) GetTournamentByID(id uint) (*Tournament, error) {
 	return &tournament, nil
 }
 
-// GetTournaments retrieves tournaments based on filters with pagination
+// GetTournamentBracketMatches loads a tournament's matches with their teams in bracket order:
+// by round, then position within the round. Matches not yet placed in the bracket come last.
+func (r *GormMatchRepository) GetTournamentBracketMatches(tournamentID uint) ([]Match, error) {
+	var matches []Match
+	err := r.db.Preload("MatchTeams", func(db *gorm.DB) *gorm.DB {
+		return db.Order("is_home_team DESC, id ASC")
+	}).
+		Preload("MatchTeams.Team", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name", "logo")
+		}).
+		Where("tournament_id = ?", tournamentID).
+		Order("round ASC NULLS LAST, bracket_position ASC NULLS LAST, scheduled_at ASC").
+		Find(&matches).Error
+	return matches, err
+}
+
+// Tournament filters that are not plain column matches
+const (
+	tournamentFilterSearch         = "search"                  // Substring of the name or description
+	tournamentFilterStartingAfter  = "starting_after"          // time.Time; start date on or after
+	tournamentFilterStartingBefore = "starting_before"         // time.Time; start date before
+	tournamentFilterAccepting      = "accepting_registrations" // bool
+)
+
+// acceptingRegistrationsCondition matches tournaments open for registration whose deadline,
+// if set, is still ahead
+const acceptingRegistrationsCondition = "status = ? AND (registration_deadline > ? OR registration_deadline = ?)"
+
+// GetTournaments retrieves tournaments based on filters with pagination, soonest start first
 func (r *GormMatchRepository) GetTournaments(filters map[string]interface{}, page, pageSize int) ([]Tournament, int64, error) {
 	var tournaments []Tournament
 	var total int64
 
-	query := r.db.Model(&Tournament{})
+	query := r.db.Clauses(dbresolver.Read).Model(&Tournament{})
 
 	for key, value := range filters {
-		query = query.Where(key, value)
+		switch key {
+		case tournamentFilterSearch:
+			pattern := "%" + value.(string) + "%"
+			query = query.Where("(name ILIKE ? OR description ILIKE ?)", pattern, pattern)
+		case tournamentFilterStartingAfter:
+			query = query.Where("start_date >= ?", value)
+		case tournamentFilterStartingBefore:
+			query = query.Where("start_date < ?", value)
+		case tournamentFilterAccepting:
+			args := []interface{}{TournamentStatusRegistrationOpen, time.Now(), time.Time{}}
+			if value.(bool) {
+				query = query.Where(acceptingRegistrationsCondition, args...)
+			} else {
+				query = query.Not(acceptingRegistrationsCondition, args...)
+			}
+		default:
+			query = query.Where(key, value)
+		}
 	}
 
 	err := query.Count(&total).Error
@@ -659,10 +1529,8 @@ func (r *GormMatchRepository) GetTournaments(filters map[string]interface{}, pag
 
 	offset := (page - 1) * pageSize
 	result := query.Preload("Sport").
-		Preload("CreatedByUser", func(db *gorm.DB) *gorm.DB {
-			return db.Select("ID, Username, FirstName, LastName, Avatar")
-		}).
-		Order("created_at desc").
+		Preload("CreatedByUser", selectUserSummary).
+		Order("start_date asc, id asc").
 		Offset(offset).Limit(pageSize).
 		Find(&tournaments)
 
@@ -675,7 +1543,7 @@ func (r *GormMatchRepository) GetTournaments(filters map[string]interface{}, pag
 
 // UpdateTournament updates an existing tournament
 func (r *GormMatchRepository) UpdateTournament(tournament *Tournament) error {
-	return r.db.Save(tournament).Error
+	return saveVersioned(r.db, tournament, &tournament.Version)
 }
 
 // DeleteTournament soft-deletes a tournament
@@ -686,16 +1554,19 @@ func (r *GormMatchRepository) DeleteTournament(id uint) error {
 	return r.db.Delete(&Tournament{}, id).Error
 }
 
-// RegisterTeamInTournament registers a team for a tournament
-func (r *GormMatchRepository) RegisterTeamInTournament(tournamentID uint, teamID uint) error {
-	// Use the repository's db field for transactions, not the global db.
-	// The WithTransaction method handles BEGIN/COMMIT/ROLLBACK.
-	// For direct transaction usage:
+// RegisterTeamInTournament registers a team for a tournament on behalf of userID, who must
+// manage the team. The team must play the tournament's sport.
+func (r *GormMatchRepository) RegisterTeamInTournament(tournamentID uint, teamID uint, userID uint) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
+		query := tx
+		if r.countLiveRegistrations {
+			// Serialise registrations for this tournament so concurrent requests cannot overfill it
+			query = tx.Clauses(clause.Locking{Strength: "UPDATE"})
+		}
 		var tournament Tournament
-		if err := tx.First(&tournament, tournamentID).Error; err != nil {
+		if err := query.First(&tournament, tournamentID).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
-				return errors.New("tournament not found")
+				return ErrTournamentNotFound
 			}
 			return err
 		}
@@ -708,14 +1579,38 @@ func (r *GormMatchRepository) RegisterTeamInTournament(tournamentID uint, teamID
 			return errors.New("registration deadline has passed")
 		}
 
-		if tournament.MaxTeams > 0 && tournament.CurrentTeams >= tournament.MaxTeams {
-			return errors.New("tournament has reached its maximum number of teams")
+		var registering team.Team
+		if err := tx.Select("id", "sport_id", "created_by_id").First(&registering, teamID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrRegisteringTeamNotFound
+			}
+			return err
+		}
+		if registering.SportID != tournament.SportID {
+			return ErrTeamSportMismatch
+		}
+		manages, err := r.withDB(tx).managesTeam(&registering, userID)
+		if err != nil {
+			return err
+		}
+		if !manages {
+			return ErrNotTeamManager
+		}
+
+		registered := int64(tournament.CurrentTeams)
+		if r.countLiveRegistrations {
+			if err := tx.Model(&TournamentTeam{}).Where("tournament_id = ?", tournamentID).Count(&registered).Error; err != nil {
+				return err
+			}
+		}
+		if tournament.MaxTeams > 0 && registered >= int64(tournament.MaxTeams) {
+			return ErrTournamentFull
 		}
 
 		var existingReg TournamentTeam
-		err := tx.Where("tournament_id = ? AND team_id = ?", tournamentID, teamID).First(&existingReg).Error
+		err = tx.Where("tournament_id = ? AND team_id = ?", tournamentID, teamID).First(&existingReg).Error
 		if err == nil {
-			return errors.New("team is already registered in this tournament")
+			return ErrTeamAlreadyRegistered
 		}
 		if !errors.Is(err, gorm.ErrRecordNotFound) {
 			return err
@@ -725,21 +1620,138 @@ func (r *GormMatchRepository) RegisterTeamInTournament(tournamentID uint, teamID
 			TournamentID: tournamentID,
 			TeamID:       teamID,
 			RegisteredAt: time.Now(),
-			Status:       "approved", // Default status
+			Status:       TournamentTeamApproved,
 		}
 		if err := tx.Create(&tournamentTeam).Error; err != nil {
 			return err
 		}
 
-		tournament.CurrentTeams++
-		if err := tx.Model(&Tournament{}).Where("id = ?", tournamentID).Update("current_teams", tournament.CurrentTeams).Error; err != nil {
-			// Using tx.Save(&tournament) is also an option if the tournament object is up-to-date
-			// if err := tx.Save(&tournament).Error; err != nil {
+		return tx.Model(&Tournament{}).Where("id = ?", tournamentID).
+			Updates(map[string]interface{}{"current_teams": registered + 1, "version": bumpVersion}).Error
+	})
+}
+
+// WithdrawTeamFromTournament marks the team's registration in an ongoing tournament as withdrawn
+// and forfeits its unfinished tournament matches, awarding them to the opponents. Completed
+// matches are left as they are. It returns the IDs of the forfeited matches.
+func (r *GormMatchRepository) WithdrawTeamFromTournament(tournamentID, teamID uint) ([]uint, error) {
+	var forfeited []uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var tournament Tournament
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&tournament, tournamentID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTournamentNotFound
+			}
+			return err
+		}
+		if tournament.Status != TournamentStatusOngoing {
+			return ErrTournamentNotOngoing
+		}
+
+		var registration TournamentTeam
+		if err := tx.Where("tournament_id = ? AND team_id = ?", tournamentID, teamID).First(&registration).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrTeamNotRegistered
+			}
+			return err
+		}
+		if registration.Status == TournamentTeamWithdrawn {
+			return ErrTeamAlreadyWithdrawn
+		}
+		if err := tx.Model(&registration).Update("status", TournamentTeamWithdrawn).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&Match{}).
+			Where("tournament_id = ? AND status NOT IN ?", tournamentID,
+				[]MatchStatus{StatusMatchCompleted, StatusMatchCancelled, StatusMatchForfeited, StatusMatchAbandoned}).
+			Where("id IN (?)", tx.Model(&MatchTeam{}).Select("match_id").Where("team_id = ?", teamID)).
+			Pluck("id", &forfeited).Error; err != nil {
 			return err
 		}
 
+		now := time.Now()
+		for _, matchID := range forfeited {
+			var opponentID *uint
+			var opponent MatchTeam
+			err := tx.Where("match_id = ? AND team_id <> ?", matchID, teamID).First(&opponent).Error
+			if err == nil {
+				opponentID = &opponent.TeamID
+			} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+
+			if err := tx.Model(&Match{}).Where("id = ?", matchID).Updates(map[string]interface{}{
+				"status":          StatusMatchForfeited,
+				"winning_team_id": opponentID,
+				"completed_at":    now,
+				"result_summary":  "Forfeited: opponent withdrew from the tournament",
+				"version":         bumpVersion,
+			}).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&MatchTeam{}).Where("match_id = ? AND team_id = ?", matchID, teamID).
+				Update("result_status", ResultForfeit).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&MatchTeam{}).Where("match_id = ? AND team_id <> ?", matchID, teamID).
+				Update("result_status", ResultWin).Error; err != nil {
+				return err
+			}
+		}
 		return nil
 	})
+	return forfeited, err
+}
+
+// RecomputeTournamentTeamCount resets current_teams to the number of stored registrations and
+// returns the counter before and after the repair
+func (r *GormMatchRepository) RecomputeTournamentTeamCount(tournamentID uint) (previous, current int, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		previous, current, err = recomputeTournamentTeamCount(tx, tournamentID)
+		return err
+	})
+	return previous, current, err
+}
+
+// RepairTournamentTeamCounts recomputes current_teams for every tournament whose counter has
+// drifted from its stored registrations and returns the IDs of the tournaments it fixed
+func (r *GormMatchRepository) RepairTournamentTeamCounts() ([]uint, error) {
+	var ids []uint
+	err := r.db.Raw(`UPDATE tournaments SET current_teams = live.count, version = version + 1, updated_at = NOW()
+		FROM (
+			SELECT tournaments.id, COUNT(tournament_teams.id) AS count
+			FROM tournaments
+			LEFT JOIN tournament_teams ON tournament_teams.tournament_id = tournaments.id AND tournament_teams.deleted_at IS NULL
+			WHERE tournaments.deleted_at IS NULL
+			GROUP BY tournaments.id
+		) AS live
+		WHERE tournaments.id = live.id AND tournaments.current_teams <> live.count
+		RETURNING tournaments.id`).Scan(&ids).Error
+	return ids, err
+}
+
+// recomputeTournamentTeamCount locks the tournament row and writes back its live registration
+// count. It must run inside a transaction.
+func recomputeTournamentTeamCount(tx *gorm.DB, tournamentID uint) (previous, current int, err error) {
+	var tournament Tournament
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&tournament, tournamentID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, 0, ErrTournamentNotFound
+		}
+		return 0, 0, err
+	}
+	var count int64
+	if err := tx.Model(&TournamentTeam{}).Where("tournament_id = ?", tournamentID).Count(&count).Error; err != nil {
+		return 0, 0, err
+	}
+	if int(count) != tournament.CurrentTeams {
+		if err := tx.Model(&Tournament{}).Where("id = ?", tournamentID).
+			Updates(map[string]interface{}{"current_teams": count, "version": bumpVersion}).Error; err != nil {
+			return 0, 0, err
+		}
+	}
+	return tournament.CurrentTeams, int(count), nil
 }
 
 // UnregisterTeamFromTournament unregisters a team from a tournament
@@ -770,9 +1782,14 @@ func (r *GormMatchRepository) UnregisterTeamFromTournament(tournamentID uint, te
 			return err
 		}
 
+		if r.countLiveRegistrations {
+			_, _, err := recomputeTournamentTeamCount(tx, tournamentID)
+			return err
+		}
 		if tournament.CurrentTeams > 0 {
 			tournament.CurrentTeams--
-			if err := tx.Model(&Tournament{}).Where("id = ?", tournamentID).Update("current_teams", tournament.CurrentTeams).Error; err != nil {
+			if err := tx.Model(&Tournament{}).Where("id = ?", tournamentID).
+				Updates(map[string]interface{}{"current_teams": tournament.CurrentTeams, "version": bumpVersion}).Error; err != nil {
 				// Using tx.Save(&tournament) is also an option
 				// if err := tx.Save(&tournament).Error; err != nil {
 				return err
@@ -781,3 +1798,8 @@ func (r *GormMatchRepository) UnregisterTeamFromTournament(tournamentID uint, te
 		return nil
 	})
 }
+
+// Ping checks that the matches table answers a trivial query, for the readiness probe
+func (r *GormMatchRepository) Ping(ctx context.Context) error {
+	return r.db.WithContext(ctx).Exec("SELECT 1 FROM matches LIMIT 1").Error
+}
@@ -0,0 +1,300 @@
+package match
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/internal/user"
+	"gorm.io/gorm"
+)
+
+func TestAcceptChallengeConcurrentlyCreatesOneMatch(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewGormMatchRepository(db)
+
+	sender := testutil.CreateUser(t, db, "Sender")
+	receiver := testutil.CreateUser(t, db, "Receiver")
+	s := createSport(t, db)
+	challenge := createChallenge(t, db, s.ID, sender.ID, nil, func(ch *Challenge) {
+		ch.ChallengeType = DirectChallengeIndividual
+		ch.Status = StatusPending
+		ch.SenderUserID = &sender.ID
+		ch.ReceiverUserID = &receiver.ID
+	})
+
+	const acceptors = 8
+	errs := make([]error, acceptors)
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < acceptors; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs[i] = repo.AcceptChallenge(challenge.ID, receiver.ID, "individual", 0)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	accepted := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			accepted++
+		case !errors.Is(err, ErrChallengeNotAcceptable):
+			t.Errorf("AcceptChallenge() error = %v, want ErrChallengeNotAcceptable", err)
+		}
+	}
+	if accepted != 1 {
+		t.Errorf("%d acceptances succeeded, want 1", accepted)
+	}
+
+	var matches int64
+	if err := db.Model(&Match{}).Where("challenge_id = ?", challenge.ID).Count(&matches).Error; err != nil {
+		t.Fatalf("failed to count matches: %v", err)
+	}
+	if matches != 1 {
+		t.Errorf("%d matches created, want 1", matches)
+	}
+
+	var stored Challenge
+	if err := db.First(&stored, challenge.ID).Error; err != nil {
+		t.Fatalf("failed to reload challenge: %v", err)
+	}
+	if stored.Status != StatusAccepted || stored.ScheduledMatchID == nil {
+		t.Errorf("challenge status = %q, scheduled match = %v; want accepted with a match", stored.Status, stored.ScheduledMatchID)
+	}
+}
+
+func TestAcceptOpenTeamChallengeTakesOneAcceptingTeam(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewGormMatchRepository(db)
+
+	sender := testutil.CreateUser(t, db, "Sender")
+	s := createSport(t, db)
+	challenge := createChallenge(t, db, s.ID, sender.ID, createTeam(t, db, s.ID, sender.ID))
+
+	outsider := testutil.CreateUser(t, db, "Outsider")
+	managers := []*user.User{testutil.CreateUser(t, db, "First"), testutil.CreateUser(t, db, "Second")}
+	teams := []*team.Team{createTeam(t, db, s.ID, managers[0].ID), createTeam(t, db, s.ID, managers[1].ID)}
+
+	if err := repo.AcceptChallenge(challenge.ID, outsider.ID, "team", teams[0].ID); !errors.Is(err, ErrNotTeamManager) {
+		t.Fatalf("AcceptChallenge() by a non-manager error = %v, want ErrNotTeamManager", err)
+	}
+	if err := repo.AcceptChallenge(challenge.ID, managers[0].ID, "team", 0); !errors.Is(err, ErrAcceptingTeamRequired) {
+		t.Fatalf("AcceptChallenge() without a team error = %v, want ErrAcceptingTeamRequired", err)
+	}
+
+	errs := make([]error, len(teams))
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := range teams {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs[i] = repo.AcceptChallenge(challenge.ID, managers[i].ID, "team", teams[i].ID)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	winner := -1
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			if winner != -1 {
+				t.Fatal("both teams accepted the challenge")
+			}
+			winner = i
+		case !errors.Is(err, ErrChallengeNotAcceptable):
+			t.Errorf("AcceptChallenge() error = %v, want ErrChallengeNotAcceptable", err)
+		}
+	}
+	if winner == -1 {
+		t.Fatal("no team accepted the challenge")
+	}
+
+	var stored Challenge
+	if err := db.First(&stored, challenge.ID).Error; err != nil {
+		t.Fatalf("failed to reload challenge: %v", err)
+	}
+	if stored.Status != StatusAccepted || stored.ScheduledMatchID == nil {
+		t.Fatalf("challenge status = %q, scheduled match = %v; want accepted with a match", stored.Status, stored.ScheduledMatchID)
+	}
+	if stored.ReceiverTeamID == nil || *stored.ReceiverTeamID != teams[winner].ID {
+		t.Errorf("receiver team = %v, want the accepting team %d", stored.ReceiverTeamID, teams[winner].ID)
+	}
+	var matchTeams []uint
+	if err := db.Model(&MatchTeam{}).Where("match_id = ?", *stored.ScheduledMatchID).Order("team_id").Pluck("team_id", &matchTeams).Error; err != nil {
+		t.Fatalf("failed to load match teams: %v", err)
+	}
+	if len(matchTeams) != 2 || (matchTeams[0] != teams[winner].ID && matchTeams[1] != teams[winner].ID) {
+		t.Errorf("match teams = %v, want the sender and team %d", matchTeams, teams[winner].ID)
+	}
+}
+
+func TestAcceptOpenIndividualChallengeTakesTheAcceptor(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewGormMatchRepository(db)
+
+	sender := testutil.CreateUser(t, db, "Sender")
+	acceptor := testutil.CreateUser(t, db, "Acceptor")
+	s := createSport(t, db)
+	challenge := createChallenge(t, db, s.ID, sender.ID, nil, func(ch *Challenge) {
+		ch.ChallengeType = OpenChallengeIndividual
+		ch.SenderUserID = &sender.ID
+	})
+
+	if err := repo.AcceptChallenge(challenge.ID, sender.ID, "individual", 0); !errors.Is(err, ErrNotChallengeReceiver) {
+		t.Fatalf("AcceptChallenge() by the sender error = %v, want ErrNotChallengeReceiver", err)
+	}
+	if err := repo.AcceptChallenge(challenge.ID, acceptor.ID, "individual", 0); err != nil {
+		t.Fatalf("AcceptChallenge() error = %v", err)
+	}
+
+	var stored Challenge
+	if err := db.First(&stored, challenge.ID).Error; err != nil {
+		t.Fatalf("failed to reload challenge: %v", err)
+	}
+	if stored.Status != StatusAccepted || stored.ReceiverUserID == nil || *stored.ReceiverUserID != acceptor.ID {
+		t.Errorf("challenge status = %q, receiver = %v; want accepted by %d", stored.Status, stored.ReceiverUserID, acceptor.ID)
+	}
+}
+
+// queryCounter counts the SELECT statements run through db
+type queryCounter struct {
+	n int64
+}
+
+func newQueryCounter(t *testing.T, db *gorm.DB) *queryCounter {
+	t.Helper()
+	qc := &queryCounter{}
+	if err := db.Callback().Query().After("gorm:query").Register("test:count_queries", func(*gorm.DB) {
+		atomic.AddInt64(&qc.n, 1)
+	}); err != nil {
+		t.Fatalf("failed to register query counter: %v", err)
+	}
+	return qc
+}
+
+// count returns how many queries fn ran
+func (qc *queryCounter) count(fn func()) int64 {
+	before := atomic.LoadInt64(&qc.n)
+	fn()
+	return atomic.LoadInt64(&qc.n) - before
+}
+
+func TestMatchListQueriesDoNotGrowWithPageSize(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewGormMatchRepository(db)
+	qc := newQueryCounter(t, db)
+
+	player := testutil.CreateUser(t, db, "Player")
+	s := createSport(t, db)
+	home := createTeam(t, db, s.ID, player.ID)
+	v := createVenue(t, db, player.ID, 0, 0)
+	for i := 0; i < 12; i++ {
+		creator := testutil.CreateUser(t, db, "Creator")
+		away := createTeam(t, db, s.ID, creator.ID)
+		createMatch(t, db, s.ID, creator.ID, []*team.Team{home, away}, func(m *Match) { m.VenueID = &v.ID })
+	}
+
+	lists := map[string]func(pageSize int) ([]Match, error){
+		"GetMatches": func(pageSize int) ([]Match, error) {
+			matches, _, err := repo.GetMatches(map[string]interface{}{}, MatchExpand{WinningTeam: true}, 1, pageSize)
+			return matches, err
+		},
+		"GetUserMatches": func(pageSize int) ([]Match, error) {
+			matches, _, err := repo.GetUserMatches(player.ID, "", 1, pageSize)
+			return matches, err
+		},
+		"GetTeamMatches": func(pageSize int) ([]Match, error) {
+			matches, _, err := repo.GetTeamMatches(home.ID, "", 1, pageSize)
+			return matches, err
+		},
+	}
+	for name, list := range lists {
+		t.Run(name, func(t *testing.T) {
+			queries := make(map[int]int64)
+			for _, pageSize := range []int{2, 12} {
+				var matches []Match
+				var err error
+				queries[pageSize] = qc.count(func() { matches, err = list(pageSize) })
+				if err != nil {
+					t.Fatalf("page size %d: %v", pageSize, err)
+				}
+				if len(matches) != pageSize {
+					t.Fatalf("page size %d returned %d matches", pageSize, len(matches))
+				}
+				for _, m := range matches {
+					if len(m.MatchTeams) != 2 || m.MatchTeams[0].Team.ID == 0 || m.CreatedByUser.Username == "" {
+						t.Fatalf("match %d loaded without its teams or creator: %+v", m.ID, m)
+					}
+				}
+			}
+			if queries[2] != queries[12] {
+				t.Errorf("%d queries for 2 matches but %d for 12; preloads should be batched", queries[2], queries[12])
+			}
+		})
+	}
+}
+
+func TestUserSummaryPreloads(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewGormMatchRepository(db)
+
+	sender := testutil.CreateUser(t, db, "Sender")
+	receiver := testutil.CreateUser(t, db, "Receiver")
+	s := createSport(t, db)
+	challenge := createChallenge(t, db, s.ID, sender.ID, nil, func(ch *Challenge) {
+		ch.ChallengeType = DirectChallengeIndividual
+		ch.Status = StatusPending
+		ch.SenderUserID = &sender.ID
+		ch.ReceiverUserID = &receiver.ID
+	})
+	home := createTeam(t, db, s.ID, sender.ID)
+	away := createTeam(t, db, s.ID, receiver.ID)
+	m := createMatch(t, db, s.ID, sender.ID, []*team.Team{home, away})
+
+	loaded, err := repo.GetChallengeByID(challenge.ID)
+	if err != nil {
+		t.Fatalf("GetChallengeByID() error = %v", err)
+	}
+	if loaded.SenderUser == nil || loaded.ReceiverUser == nil {
+		t.Fatalf("challenge loaded without its participants: sender %v, receiver %v", loaded.SenderUser, loaded.ReceiverUser)
+	}
+	users := map[string]struct {
+		got  string
+		want string
+		mail string
+	}{
+		"CreatedByUser": {loaded.CreatedByUser.Username, sender.Username, loaded.CreatedByUser.Email},
+		"SenderUser":    {loaded.SenderUser.Username, sender.Username, loaded.SenderUser.Email},
+		"ReceiverUser":  {loaded.ReceiverUser.Username, receiver.Username, loaded.ReceiverUser.Email},
+	}
+	for name, u := range users {
+		if u.got != u.want {
+			t.Errorf("challenge %s username = %q, want %q", name, u.got, u.want)
+		}
+		if u.mail != "" {
+			t.Errorf("challenge %s loaded email %q; only the summary columns should be selected", name, u.mail)
+		}
+	}
+
+	match, err := repo.GetMatchByID(m.ID)
+	if err != nil {
+		t.Fatalf("GetMatchByID() error = %v", err)
+	}
+	if match.CreatedByUser.Username != sender.Username || match.CreatedByUser.Name != sender.Name {
+		t.Errorf("match creator = %+v, want %s", match.CreatedByUser, sender.Username)
+	}
+	if match.CreatedByUser.Email != "" {
+		t.Errorf("match creator loaded email %q; only the summary columns should be selected", match.CreatedByUser.Email)
+	}
+}
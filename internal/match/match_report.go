@@ -0,0 +1,417 @@
+package match
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// maxReportTopPerformers caps the top performers listed in a tournament report
+const maxReportTopPerformers = 10
+
+// TournamentReport summarises a tournament: its standings, bracket, results, top performers
+// and prize winners, computed from its matches when the report is requested
+type TournamentReport struct {
+	TournamentID     uint                 `json:"tournament_id"`
+	Name             string               `json:"name"`
+	Sport            string               `json:"sport"`
+	Format           string               `json:"format"`
+	Status           string               `json:"status"`
+	StartDate        time.Time            `json:"start_date"`
+	EndDate          time.Time            `json:"end_date"`
+	PrizeDescription string               `json:"prize_description,omitempty"`
+	PrizePool        float64              `json:"prize_pool,omitempty"`
+	GeneratedAt      time.Time            `json:"generated_at"`
+	Standings        []TournamentStanding `json:"standings"`
+	Bracket          *TournamentBracket   `json:"bracket,omitempty"` // Knockout tournaments only
+	Results          []TournamentResult   `json:"results"`
+	TopPerformers    []TopPerformer       `json:"top_performers"`
+	Prizes           []TournamentPrize    `json:"prizes"`
+}
+
+// TournamentStanding is a team's record in the tournament's completed and forfeited matches
+type TournamentStanding struct {
+	Position     int    `json:"position"`
+	TeamID       uint   `json:"team_id"`
+	TeamName     string `json:"team_name"`
+	Withdrawn    bool   `json:"withdrawn,omitempty"`
+	Played       int    `json:"played"`
+	Wins         int    `json:"wins"`
+	Losses       int    `json:"losses"` // Including forfeits
+	Draws        int    `json:"draws"`  // Draws and ties
+	NoResults    int    `json:"no_results"`
+	Forfeits     int    `json:"forfeits"`
+	ScoreFor     int    `json:"score_for"`
+	ScoreAgainst int    `json:"score_against"`
+}
+
+// TournamentResult is the outcome of one finished tournament match
+type TournamentResult struct {
+	MatchID         uint          `json:"match_id"`
+	Round           *int          `json:"round,omitempty"`
+	ThirdPlace      bool          `json:"third_place,omitempty"`
+	ScheduledAt     time.Time     `json:"scheduled_at"`
+	CompletedAt     *time.Time    `json:"completed_at,omitempty"`
+	Status          MatchStatus   `json:"status"`
+	Walkover        bool          `json:"walkover,omitempty"`
+	WinningTeamID   *uint         `json:"winning_team_id,omitempty"`
+	ResultSummary   string        `json:"result_summary,omitempty"`
+	ManOfTheMatchID *uint         `json:"man_of_the_match_id,omitempty"`
+	Teams           []BracketSlot `json:"teams"`
+}
+
+// TopPerformer is a player ranked by the player of the match awards won in the tournament
+type TopPerformer struct {
+	UserID       uint   `json:"user_id"`
+	Name         string `json:"name"`
+	Username     string `json:"username,omitempty"`
+	ProfileImage string `json:"profile_image,omitempty"`
+	Awards       int    `json:"awards"`
+}
+
+// TournamentPrize is a podium finish the tournament's prizes go to. Knockouts take it from the
+// final and third place match; other formats from the final standings once completed.
+type TournamentPrize struct {
+	Position int    `json:"position"`
+	TeamID   uint   `json:"team_id"`
+	TeamName string `json:"team_name"`
+}
+
+// TournamentReportRenderer writes a tournament report in a downloadable format. Renderers are
+// registered per format with MatchController.RegisterReportRenderer.
+type TournamentReportRenderer interface {
+	ContentType() string
+	FileExtension() string
+	Render(w io.Writer, report *TournamentReport) error
+}
+
+// GetTournamentTopPerformers ranks the players with the most player of the match awards in
+// the tournament
+func (r *GormMatchRepository) GetTournamentTopPerformers(tournamentID uint, limit int) ([]TopPerformer, error) {
+	var performers []TopPerformer
+	err := r.db.Model(&Match{}).
+		Select("users.id AS user_id, users.name, users.username, users.profile_image, COUNT(*) AS awards").
+		Joins("JOIN users ON users.id = matches.man_of_the_match_id AND users.deleted_at IS NULL").
+		Where("matches.tournament_id = ?", tournamentID).
+		Group("users.id, users.name, users.username, users.profile_image").
+		Order("awards DESC, users.id ASC").
+		Limit(limit).
+		Scan(&performers).Error
+	return performers, err
+}
+
+// isFinishedStatus reports whether a match with the status is over
+func isFinishedStatus(status MatchStatus) bool {
+	switch status {
+	case StatusMatchCompleted, StatusMatchForfeited, StatusMatchAbandoned:
+		return true
+	}
+	return false
+}
+
+// buildTournamentReport computes the report from the tournament, with its registered teams,
+// and its matches in bracket order
+func buildTournamentReport(tournament *Tournament, matches []Match, performers []TopPerformer) *TournamentReport {
+	report := &TournamentReport{
+		TournamentID:     tournament.ID,
+		Name:             tournament.Name,
+		Sport:            tournament.Sport.Name,
+		Format:           tournament.Format,
+		Status:           tournament.Status,
+		StartDate:        tournament.StartDate,
+		EndDate:          tournament.EndDate,
+		PrizeDescription: tournament.PrizeDescription,
+		PrizePool:        tournament.PrizePool,
+		GeneratedAt:      time.Now(),
+		Results:          []TournamentResult{},
+		TopPerformers:    performers,
+		Prizes:           []TournamentPrize{},
+	}
+	if report.TopPerformers == nil {
+		report.TopPerformers = []TopPerformer{}
+	}
+
+	standings := make(map[uint]*TournamentStanding)
+	standing := func(teamID uint, name string) *TournamentStanding {
+		s, ok := standings[teamID]
+		if !ok {
+			s = &TournamentStanding{TeamID: teamID, TeamName: name}
+			standings[teamID] = s
+		}
+		return s
+	}
+	for _, tt := range tournament.Teams {
+		standing(tt.TeamID, tt.Team.Name).Withdrawn = tt.Status == TournamentTeamWithdrawn
+	}
+
+	bracket := buildBracket(tournament, matches)
+	for _, m := range matches {
+		if !isFinishedStatus(m.Status) {
+			continue
+		}
+		result := TournamentResult{
+			MatchID:         m.ID,
+			Round:           m.Round,
+			ThirdPlace:      m.ThirdPlace,
+			ScheduledAt:     m.ScheduledAt,
+			CompletedAt:     m.CompletedAt,
+			Status:          m.Status,
+			Walkover:        m.Walkover,
+			WinningTeamID:   m.WinningTeamID,
+			ResultSummary:   m.ResultSummary,
+			ManOfTheMatchID: m.ManOfTheMatchID,
+			Teams:           make([]BracketSlot, 0, len(m.MatchTeams)),
+		}
+		total := 0
+		for _, mt := range m.MatchTeams {
+			total += mt.Score
+		}
+		for _, mt := range m.MatchTeams {
+			result.Teams = append(result.Teams, BracketSlot{
+				TeamID:       mt.TeamID,
+				TeamName:     mt.Team.Name,
+				TeamLogo:     mt.Team.Logo,
+				Score:        mt.Score,
+				ResultStatus: mt.ResultStatus,
+				IsWinner:     m.WinningTeamID != nil && *m.WinningTeamID == mt.TeamID,
+			})
+			if m.Status == StatusMatchAbandoned {
+				continue
+			}
+
+			s := standing(mt.TeamID, mt.Team.Name)
+			s.Played++
+			s.ScoreFor += mt.Score
+			s.ScoreAgainst += total - mt.Score
+			switch mt.ResultStatus {
+			case ResultWin:
+				s.Wins++
+			case ResultLoss:
+				s.Losses++
+			case ResultForfeit:
+				s.Losses++
+				s.Forfeits++
+			case ResultDraw, ResultTie:
+				s.Draws++
+			case ResultNoResult:
+				s.NoResults++
+			}
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	report.Standings = make([]TournamentStanding, 0, len(standings))
+	for _, s := range standings {
+		report.Standings = append(report.Standings, *s)
+	}
+	sort.Slice(report.Standings, func(i, j int) bool {
+		a, b := report.Standings[i], report.Standings[j]
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		if a.Draws != b.Draws {
+			return a.Draws > b.Draws
+		}
+		if a.Losses != b.Losses {
+			return a.Losses < b.Losses
+		}
+		if da, db := a.ScoreFor-a.ScoreAgainst, b.ScoreFor-b.ScoreAgainst; da != db {
+			return da > db
+		}
+		return a.TeamName < b.TeamName
+	})
+	for i := range report.Standings {
+		report.Standings[i].Position = i + 1
+	}
+
+	if tournament.Format == "knockout" {
+		report.Bracket = &bracket
+		report.Prizes = knockoutPrizes(bracket)
+	} else if tournament.Status == TournamentStatusCompleted {
+		for _, s := range report.Standings {
+			if len(report.Prizes) == 3 || s.Withdrawn || s.Played == 0 {
+				break
+			}
+			report.Prizes = append(report.Prizes, TournamentPrize{Position: s.Position, TeamID: s.TeamID, TeamName: s.TeamName})
+		}
+	}
+	return report
+}
+
+// knockoutPrizes places the winner and runner-up of the final, the match of the last round, and
+// the winner of the third place match. Places whose match has not been won yet are left out.
+func knockoutPrizes(bracket TournamentBracket) []TournamentPrize {
+	prizes := []TournamentPrize{}
+	if len(bracket.Rounds) == 0 {
+		return prizes
+	}
+
+	var final, thirdPlace *BracketMatch
+	last := bracket.Rounds[len(bracket.Rounds)-1]
+	for i := range last.Matches {
+		if last.Matches[i].ThirdPlace {
+			thirdPlace = &last.Matches[i]
+		} else if final == nil {
+			final = &last.Matches[i]
+		}
+	}
+
+	if final != nil && final.WinningTeamID != nil {
+		for _, slot := range final.Slots {
+			if slot.IsWinner {
+				prizes = append(prizes, TournamentPrize{Position: 1, TeamID: slot.TeamID, TeamName: slot.TeamName})
+			}
+		}
+		for _, slot := range final.Slots {
+			if !slot.IsWinner {
+				prizes = append(prizes, TournamentPrize{Position: 2, TeamID: slot.TeamID, TeamName: slot.TeamName})
+			}
+		}
+	}
+	if thirdPlace != nil && thirdPlace.WinningTeamID != nil {
+		for _, slot := range thirdPlace.Slots {
+			if slot.IsWinner {
+				prizes = append(prizes, TournamentPrize{Position: 3, TeamID: slot.TeamID, TeamName: slot.TeamName})
+			}
+		}
+	}
+	return prizes
+}
+
+// CSVReportRenderer writes a tournament report as CSV: one block per section, each with its
+// own header row, separated by blank lines
+type CSVReportRenderer struct{}
+
+// ContentType implements TournamentReportRenderer
+func (CSVReportRenderer) ContentType() string { return "text/csv; charset=utf-8" }
+
+// FileExtension implements TournamentReportRenderer
+func (CSVReportRenderer) FileExtension() string { return "csv" }
+
+// Render implements TournamentReportRenderer
+func (CSVReportRenderer) Render(w io.Writer, report *TournamentReport) error {
+	cw := csv.NewWriter(w)
+	id := func(v uint) string { return strconv.FormatUint(uint64(v), 10) }
+	optionalID := func(v *uint) string {
+		if v == nil {
+			return ""
+		}
+		return id(*v)
+	}
+
+	rows := [][]string{
+		{"tournament_id", "name", "sport", "format", "status", "start_date", "end_date", "prize_description", "prize_pool"},
+		{id(report.TournamentID), report.Name, report.Sport, report.Format, report.Status,
+			report.StartDate.Format(time.RFC3339), report.EndDate.Format(time.RFC3339),
+			report.PrizeDescription, strconv.FormatFloat(report.PrizePool, 'f', 2, 64)},
+		{},
+		{"position", "team_id", "team_name", "withdrawn", "played", "wins", "losses", "draws", "no_results", "forfeits", "score_for", "score_against"},
+	}
+	for _, s := range report.Standings {
+		rows = append(rows, []string{strconv.Itoa(s.Position), id(s.TeamID), s.TeamName, strconv.FormatBool(s.Withdrawn),
+			strconv.Itoa(s.Played), strconv.Itoa(s.Wins), strconv.Itoa(s.Losses), strconv.Itoa(s.Draws),
+			strconv.Itoa(s.NoResults), strconv.Itoa(s.Forfeits), strconv.Itoa(s.ScoreFor), strconv.Itoa(s.ScoreAgainst)})
+	}
+
+	rows = append(rows, []string{}, []string{"match_id", "round", "status", "walkover", "scheduled_at", "teams", "scores", "winning_team_id", "result_summary"})
+	for _, r := range report.Results {
+		round := ""
+		if r.Round != nil {
+			round = strconv.Itoa(*r.Round)
+		}
+		var teams, scores string
+		for i, t := range r.Teams {
+			if i > 0 {
+				teams += " vs "
+				scores += "-"
+			}
+			teams += t.TeamName
+			scores += strconv.Itoa(t.Score)
+		}
+		rows = append(rows, []string{id(r.MatchID), round, string(r.Status), strconv.FormatBool(r.Walkover),
+			r.ScheduledAt.Format(time.RFC3339), teams, scores, optionalID(r.WinningTeamID), r.ResultSummary})
+	}
+
+	rows = append(rows, []string{}, []string{"user_id", "name", "awards"})
+	for _, p := range report.TopPerformers {
+		rows = append(rows, []string{id(p.UserID), p.Name, strconv.Itoa(p.Awards)})
+	}
+
+	rows = append(rows, []string{}, []string{"prize_position", "team_id", "team_name"})
+	for _, p := range report.Prizes {
+		rows = append(rows, []string{strconv.Itoa(p.Position), id(p.TeamID), p.TeamName})
+	}
+
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// RegisterReportRenderer makes tournament reports downloadable in another format, such as a
+// PDF renderer, as ?format=<format>. JSON is always available; CSV is registered by default.
+func (mc *MatchController) RegisterReportRenderer(format string, renderer TournamentReportRenderer) {
+	mc.reportRenderers[format] = renderer
+}
+
+// GetTournamentReport returns a summary of the tournament: standings, bracket, match results,
+// top performers and prize winners. With format set to a registered renderer, such as csv, the
+// report is downloaded as a file instead.
+func (mc *MatchController) GetTournamentReport(c *gin.Context) {
+	tournamentID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid tournament ID")
+		return
+	}
+
+	format := c.DefaultQuery("format", "json")
+	renderer, ok := mc.reportRenderers[format]
+	if format != "json" && !ok {
+		response.Error(c, http.StatusBadRequest, "Unsupported report format: "+format)
+		return
+	}
+
+	tournament, err := mc.repo.GetTournamentByID(uint(tournamentID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch tournament: "+err.Error())
+		return
+	}
+	if tournament == nil {
+		response.Error(c, http.StatusNotFound, "Tournament not found")
+		return
+	}
+
+	matches, err := mc.repo.GetTournamentBracketMatches(tournament.ID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch tournament matches: "+err.Error())
+		return
+	}
+	performers, err := mc.repo.GetTournamentTopPerformers(tournament.ID, maxReportTopPerformers)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to fetch top performers: "+err.Error())
+		return
+	}
+
+	report := buildTournamentReport(tournament, matches, performers)
+	if format == "json" {
+		response.Success(c, http.StatusOK, "", report)
+		return
+	}
+
+	// Rendered in full before anything is sent so a failure can still be reported
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, report); err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to render report: "+err.Error())
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"tournament-%d-report.%s\"", tournament.ID, renderer.FileExtension()))
+	c.Data(http.StatusOK, renderer.ContentType(), buf.Bytes())
+}
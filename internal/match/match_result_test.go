@@ -0,0 +1,163 @@
+package match
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DhavalSuthar-24/miow/internal/sport"
+	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/gin-gonic/gin"
+)
+
+// resultFixture is a live league match between two teams, ended by its creator
+type resultFixture struct {
+	*twoTeamMatch
+	tournament *Tournament
+}
+
+func newResultFixture(t *testing.T) *resultFixture {
+	t.Helper()
+	f := &resultFixture{twoTeamMatch: newTwoTeamMatch(t, withoutMatch())}
+	f.tournament = createTournament(t, f.db, f.sport.ID, f.creator.ID, func(tr *Tournament) {
+		tr.Format = "league"
+		tr.Status = TournamentStatusOngoing
+	})
+	registerTeam(t, f.db, f.tournament.ID, f.home.ID)
+	registerTeam(t, f.db, f.tournament.ID, f.away.ID)
+	f.match = f.newMatch(t, inStatus(StatusMatchLive), func(m *Match) { m.TournamentID = &f.tournament.ID })
+	return f
+}
+
+func (f *resultFixture) end(t *testing.T, req EndMatchRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	r := gin.New()
+	r.POST("/matches/:id/end", asUser(f.creator.ID), f.mc.EndMatch)
+	return testutil.Request(t, r, http.MethodPost, "/matches/"+itoa(f.match.ID)+"/end", req)
+}
+
+func (f *resultFixture) reload(t *testing.T) Match {
+	t.Helper()
+	var m Match
+	if err := f.db.Preload("MatchTeams").First(&m, f.match.ID).Error; err != nil {
+		t.Fatalf("failed to reload match: %v", err)
+	}
+	return m
+}
+
+func TestEndMatchDraw(t *testing.T) {
+	f := newResultFixture(t)
+
+	if w := f.end(t, EndMatchRequest{Result: ResultDraw}); w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	m := f.reload(t)
+	if m.Status != StatusMatchCompleted || m.WinningTeamID != nil || m.CompletedAt == nil {
+		t.Errorf("match = status %q, winner %v, completed %v; want completed without a winner", m.Status, m.WinningTeamID, m.CompletedAt)
+	}
+	for _, mt := range m.MatchTeams {
+		if mt.ResultStatus != ResultDraw {
+			t.Errorf("team %d result = %q, want %q", mt.TeamID, mt.ResultStatus, ResultDraw)
+		}
+	}
+
+	// Both teams' records and the league standings count the draw
+	repo := NewGormMatchRepository(f.db)
+	for _, tm := range []*team.Team{f.home, f.away} {
+		record, err := repo.GetTeamRecord(tm.ID, nil, false)
+		if err != nil {
+			t.Fatalf("GetTeamRecord() error = %v", err)
+		}
+		if record.Played != 1 || record.Draws != 1 || record.Wins != 0 || record.Losses != 0 {
+			t.Errorf("team %d record = %+v, want a single draw", tm.ID, record)
+		}
+	}
+
+	r := gin.New()
+	r.GET("/tournaments/:id/report", f.mc.GetTournamentReport)
+	w := testutil.Request(t, r, http.MethodGet, "/tournaments/"+itoa(f.tournament.ID)+"/report", nil)
+	var report TournamentReport
+	testutil.DecodeData(t, w, &report)
+	if len(report.Standings) != 2 {
+		t.Fatalf("standings = %+v, want both teams", report.Standings)
+	}
+	for _, s := range report.Standings {
+		if s.Played != 1 || s.Draws != 1 || s.Wins != 0 || s.Losses != 0 {
+			t.Errorf("team %d standing = %+v, want a single draw", s.TeamID, s)
+		}
+	}
+	if len(report.Results) != 1 || report.Results[0].WinningTeamID != nil {
+		t.Errorf("results = %+v, want one match without a winner", report.Results)
+	}
+}
+
+func TestEndMatchNoResult(t *testing.T) {
+	f := newResultFixture(t)
+
+	if w := f.end(t, EndMatchRequest{Result: ResultNoResult}); w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	m := f.reload(t)
+	if m.WinningTeamID != nil {
+		t.Errorf("winning team = %v, want none", *m.WinningTeamID)
+	}
+	for _, mt := range m.MatchTeams {
+		if mt.ResultStatus != ResultNoResult {
+			t.Errorf("team %d result = %q, want %q", mt.TeamID, mt.ResultStatus, ResultNoResult)
+		}
+	}
+}
+
+func TestEndMatchDrawRejected(t *testing.T) {
+	noDraws := false
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, f *resultFixture)
+		req   func(f *resultFixture) EndMatchRequest
+	}{
+		{
+			name: "with a winning team",
+			req: func(f *resultFixture) EndMatchRequest {
+				return EndMatchRequest{Result: ResultDraw, WinningTeamID: f.home.ID}
+			},
+		},
+		{
+			name: "sport without draws",
+			setup: func(t *testing.T, f *resultFixture) {
+				if err := f.db.Model(f.sport).Update("rules", sport.Rules{AllowsDraw: &noDraws}).Error; err != nil {
+					t.Fatalf("failed to update sport rules: %v", err)
+				}
+			},
+			req: func(*resultFixture) EndMatchRequest { return EndMatchRequest{Result: ResultDraw} },
+		},
+		{
+			name: "knockout tournament",
+			setup: func(t *testing.T, f *resultFixture) {
+				if err := f.db.Model(f.tournament).Update("format", "knockout").Error; err != nil {
+					t.Fatalf("failed to update tournament format: %v", err)
+				}
+			},
+			req: func(*resultFixture) EndMatchRequest { return EndMatchRequest{Result: ResultDraw} },
+		},
+		{
+			name: "unknown result",
+			req:  func(*resultFixture) EndMatchRequest { return EndMatchRequest{Result: "tie-break"} },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newResultFixture(t)
+			if tt.setup != nil {
+				tt.setup(t, f)
+			}
+			if w := f.end(t, tt.req(f)); w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d: %s", w.Code, http.StatusBadRequest, w.Body)
+			}
+			if m := f.reload(t); m.Status != StatusMatchLive {
+				t.Errorf("rejected result changed the match to %q", m.Status)
+			}
+		})
+	}
+}
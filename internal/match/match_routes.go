@@ -3,16 +3,21 @@ package match
 import (
 	"github.com/DhavalSuthar-24/miow/config"
 	mw "github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/internal/notification"
 	"github.com/DhavalSuthar-24/miow/internal/team"
-	"github.com/DhavalSuthar-24/miow/pkg/rmiddleware"
+	"github.com/DhavalSuthar-24/miow/pkg/cache"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 // MatchRoutes sets up all match-related routes.
 func MatchRoutes(router *gin.RouterGroup, db *gorm.DB, appConfig *config.Config, teamRepo team.TeamRepository, jwtSecret string) {
-	matchRepo := NewGormMatchRepository(db)
-	matchController := NewMatchController(matchRepo, teamRepo, appConfig)
+	gormRepo := NewGormMatchRepository(db).WithLiveRegistrationCount(appConfig.Tournaments.CountLiveRegistrations)
+	matchRepo := NewCachedMatchRepository(gormRepo, cache.Shared(appConfig), cache.TTL(appConfig))
+	matchController := NewMatchController(matchRepo, teamRepo, appConfig, notification.NewDefaultDispatcher(db, appConfig))
+
+	// Public routes
+	router.GET("/venues/:venue_id/results", matchController.GetVenueResults)
 
 	// Authenticated routes
 	authRoutes := router.Group("/matches")
@@ -21,7 +26,9 @@ func MatchRoutes(router *gin.RouterGroup, db *gorm.DB, appConfig *config.Config,
 		// Challenge routes
 		authRoutes.POST("/challenges", matchController.CreateChallenge)
 		authRoutes.GET("/challenges", matchController.GetChallenges)
+		authRoutes.GET("/challenges/matchmaking", matchController.GetMatchmakingChallenges)
 		authRoutes.GET("/challenges/:id", matchController.GetChallengeByID)
+		authRoutes.GET("/challenges/:id/eligible-teams", matchController.GetEligibleTeams)
 		authRoutes.PUT("/challenges/:id", matchController.UpdateChallenge)
 		authRoutes.DELETE("/challenges/:id", matchController.DeleteChallenge)
 		authRoutes.GET("/challenges/user", matchController.GetUserChallenges)
@@ -29,24 +36,59 @@ func MatchRoutes(router *gin.RouterGroup, db *gorm.DB, appConfig *config.Config,
 		authRoutes.POST("/challenges/:id/accept", matchController.AcceptChallenge)
 		authRoutes.POST("/challenges/:id/reject", matchController.RejectChallenge)
 		authRoutes.POST("/challenges/:id/cancel", matchController.CancelChallenge)
+		authRoutes.POST("/challenges/:id/counter", matchController.CounterChallenge)
+		authRoutes.POST("/challenges/:id/counter/accept", matchController.AcceptCounterOffer)
+		authRoutes.POST("/challenges/:id/counter/reject", matchController.RejectCounterOffer)
 
 		// Match routes
 		authRoutes.POST("", matchController.CreateDirectMatch)
+		authRoutes.POST("/multi-team", matchController.CreateMultiTeamMatch)
 		authRoutes.GET("", matchController.GetMatches)
 		authRoutes.GET("/:id", matchController.GetMatchByID)
 		authRoutes.PUT("/:id", matchController.UpdateMatch)
 		authRoutes.DELETE("/:id", matchController.DeleteMatch)
 		authRoutes.GET("/user", matchController.GetUserMatches)
 		authRoutes.GET("/team/:teamId", matchController.GetTeamMatches)
+		authRoutes.GET("/team/:teamId/record", matchController.GetTeamRecord)
 
 		// Match status updates
 		authRoutes.POST("/:id/start", matchController.StartMatch)
 		authRoutes.POST("/:id/end", matchController.EndMatch)
+		authRoutes.POST("/:id/forfeit", matchController.ForfeitMatch)
 		authRoutes.POST("/:id/cancel", matchController.CancelMatch)
 		authRoutes.POST("/:id/postpone", matchController.PostponeMatch)
 
+		// Lineups
+		authRoutes.POST("/:id/teams/:team_id/lineup", matchController.SetMatchLineup)
+		authRoutes.GET("/:id/lineup", matchController.GetMatchLineup)
+
+		// Officials
+		authRoutes.GET("/:id/officials", matchController.GetMatchOfficials)
+		authRoutes.POST("/:id/officials", matchController.AssignMatchOfficial)
+		authRoutes.DELETE("/:id/officials/:user_id", matchController.RemoveMatchOfficial)
+
+		// Match comments
+		authRoutes.POST("/:id/comments", matchController.CreateMatchComment)
+		authRoutes.GET("/:id/comments", matchController.GetMatchComments)
+		authRoutes.DELETE("/:id/comments/:comment_id", matchController.DeleteMatchComment)
+
 		// Match score updates
 		authRoutes.POST("/:id/score", matchController.UpdateMatchScore)
+		authRoutes.POST("/:id/periods", matchController.SetMatchPeriodScore)
+	}
+
+	myMatches := router.Group("/users/me/matches")
+	myMatches.Use(mw.AuthMiddleware(jwtSecret, db))
+	{
+		myMatches.GET("/action-required", matchController.GetActionRequiredMatches)
+	}
+
+	// Calendar apps cannot send a JWT, so the feed is authorised by the token in its URL
+	router.GET("/users/me/calendar.ics", matchController.GetCalendarFeed)
+	myCalendar := router.Group("/users/me/calendar")
+	myCalendar.Use(mw.AuthMiddleware(jwtSecret, db))
+	{
+		myCalendar.POST("/token", matchController.RotateCalendarToken)
 	}
 
 	// Tournament routes
@@ -60,16 +102,37 @@ func MatchRoutes(router *gin.RouterGroup, db *gorm.DB, appConfig *config.Config,
 		tournamentRoutes.DELETE("/:id", matchController.DeleteTournament)
 		tournamentRoutes.POST("/:id/register", matchController.RegisterTeamForTournament)
 		tournamentRoutes.POST("/:id/unregister", matchController.UnregisterTeamFromTournament)
+		tournamentRoutes.POST("/:id/teams/:team_id/withdraw", matchController.WithdrawTeamFromTournament)
 		tournamentRoutes.GET("/:id/matches", matchController.GetTournamentMatches)
+		tournamentRoutes.GET("/:id/bracket", matchController.GetTournamentBracket)
+		tournamentRoutes.GET("/:id/report", matchController.GetTournamentReport)
+		tournamentRoutes.POST("/:id/regenerate-fixtures", matchController.RegenerateTournamentFixtures)
 	}
 
 	// Admin match routes
 	adminRoutes := router.Group("/admin/matches")
 	adminRoutes.Use(mw.AuthMiddleware(jwtSecret, db))
-	adminRoutes.Use(rmiddleware.AdminMiddleware())
+	adminRoutes.Use(mw.RequireRole("admin"))
 	{
 		adminRoutes.POST("/expire-challenges", matchController.ExpireChallenges)
 		adminRoutes.POST("/:id/override-status", matchController.AdminOverrideMatchStatus)
 		adminRoutes.POST("/:id/override-score", matchController.AdminOverrideMatchScore)
 	}
+
+	// Admin audit log of match overrides
+	adminAuditRoutes := router.Group("/admin/audit")
+	adminAuditRoutes.Use(mw.AuthMiddleware(jwtSecret, db))
+	adminAuditRoutes.Use(mw.RequireRole("admin"))
+	{
+		adminAuditRoutes.GET("", matchController.GetAdminAuditLog)
+	}
+
+	// Admin tournament routes
+	adminTournamentRoutes := router.Group("/admin/tournaments")
+	adminTournamentRoutes.Use(mw.AuthMiddleware(jwtSecret, db))
+	adminTournamentRoutes.Use(mw.RequireRole("admin"))
+	{
+		adminTournamentRoutes.POST("/recount-teams", matchController.AdminRepairTournamentTeamCounts)
+		adminTournamentRoutes.POST("/:id/recount-teams", matchController.AdminRecomputeTournamentTeamCount)
+	}
 }
@@ -0,0 +1,97 @@
+package match
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// defaultMatchDuration is assumed for matches scheduled without a duration
+const defaultMatchDuration = 90 * time.Minute
+
+// ErrScheduleConflict is matched by ScheduleConflictError via errors.Is
+var ErrScheduleConflict = errors.New("team already has a match at that time")
+
+// ScheduleConflict describes an existing match that overlaps a proposed time slot for a team
+type ScheduleConflict struct {
+	TeamID      uint      `json:"team_id"`
+	MatchID     uint      `json:"match_id"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+	EndsAt      time.Time `json:"ends_at"`
+}
+
+// ScheduleConflictError is returned when one or more teams are already playing during a
+// proposed slot. Conflicts lists every overlapping match.
+type ScheduleConflictError struct {
+	Conflicts []ScheduleConflict
+}
+
+func (e *ScheduleConflictError) Error() string {
+	return fmt.Sprintf("%s (%d conflicting matches)", ErrScheduleConflict.Error(), len(e.Conflicts))
+}
+
+func (e *ScheduleConflictError) Unwrap() error {
+	return ErrScheduleConflict
+}
+
+// matchEnd returns when a match starting at start and lasting durationMinutes is over,
+// falling back to defaultMatchDuration when no duration was set.
+func matchEnd(start time.Time, durationMinutes int) time.Time {
+	if durationMinutes <= 0 {
+		return start.Add(defaultMatchDuration)
+	}
+	return start.Add(time.Duration(durationMinutes) * time.Minute)
+}
+
+// FindScheduleConflicts returns the active matches of the given teams that overlap [start, end).
+// Matches that end exactly when the slot starts, or start exactly when it ends, do not conflict.
+// A non-zero excludeTournamentID ignores that tournament's own matches.
+func (r *GormMatchRepository) FindScheduleConflicts(teamIDs []uint, start, end time.Time, excludeTournamentID uint) ([]ScheduleConflict, error) {
+	if len(teamIDs) == 0 {
+		return nil, nil
+	}
+
+	var rows []struct {
+		TeamID      uint
+		MatchID     uint
+		ScheduledAt time.Time
+		Duration    int
+	}
+	query := r.db.Model(&Match{}).
+		Select("match_teams.team_id, matches.id AS match_id, matches.scheduled_at, matches.duration").
+		Joins("JOIN match_teams ON match_teams.match_id = matches.id AND match_teams.deleted_at IS NULL").
+		Where("match_teams.team_id IN ?", teamIDs).
+		Where("matches.status NOT IN ?", []MatchStatus{StatusMatchCancelled, StatusMatchCompleted, StatusMatchAbandoned, StatusMatchForfeited}).
+		Where("matches.scheduled_at < ?", end).
+		Where("matches.scheduled_at + COALESCE(NULLIF(matches.duration, 0), ?) * INTERVAL '1 minute' > ?",
+			int(defaultMatchDuration/time.Minute), start)
+	if excludeTournamentID != 0 {
+		query = query.Where("matches.tournament_id IS NULL OR matches.tournament_id <> ?", excludeTournamentID)
+	}
+	if err := query.Order("matches.scheduled_at asc").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	conflicts := make([]ScheduleConflict, 0, len(rows))
+	for _, row := range rows {
+		conflicts = append(conflicts, ScheduleConflict{
+			TeamID:      row.TeamID,
+			MatchID:     row.MatchID,
+			ScheduledAt: row.ScheduledAt,
+			EndsAt:      matchEnd(row.ScheduledAt, row.Duration),
+		})
+	}
+	return conflicts, nil
+}
+
+// checkScheduleConflicts returns a *ScheduleConflictError if any of the teams is busy during [start, end)
+func (r *GormMatchRepository) checkScheduleConflicts(teamIDs []uint, start, end time.Time, excludeTournamentID uint) error {
+	conflicts, err := r.FindScheduleConflicts(teamIDs, start, end, excludeTournamentID)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) > 0 {
+		return &ScheduleConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package match
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+)
+
+func TestMatchEnd(t *testing.T) {
+	start := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	tests := []struct {
+		duration int
+		want     time.Time
+	}{
+		{60, start.Add(time.Hour)},
+		{0, start.Add(defaultMatchDuration)},
+		{-5, start.Add(defaultMatchDuration)},
+	}
+	for _, tt := range tests {
+		if got := matchEnd(start, tt.duration); !got.Equal(tt.want) {
+			t.Errorf("matchEnd(%v, %d) = %v, want %v", start, tt.duration, got, tt.want)
+		}
+	}
+}
+
+func TestFindScheduleConflicts(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewGormMatchRepository(db)
+
+	player := testutil.CreateUser(t, db, "Player")
+	rival := testutil.CreateUser(t, db, "Rival")
+	s := createSport(t, db)
+	home := createTeam(t, db, s.ID, player.ID)
+	away := createTeam(t, db, s.ID, rival.ID)
+
+	kickoff := time.Now().Add(72 * time.Hour).Truncate(time.Hour).UTC()
+	hourLong := createMatch(t, db, s.ID, player.ID, []*team.Team{home, away}, func(m *Match) {
+		m.ScheduledAt = kickoff
+		m.Duration = 60
+	})
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		conflicts  int
+	}{
+		{"same slot", kickoff, kickoff.Add(time.Hour), 2},
+		{"starts during the match", kickoff.Add(30 * time.Minute), kickoff.Add(90 * time.Minute), 2},
+		{"ends during the match", kickoff.Add(-30 * time.Minute), kickoff.Add(30 * time.Minute), 2},
+		{"contains the match", kickoff.Add(-time.Hour), kickoff.Add(2 * time.Hour), 2},
+		{"starts when the match ends", kickoff.Add(time.Hour), kickoff.Add(2 * time.Hour), 0},
+		{"ends when the match starts", kickoff.Add(-time.Hour), kickoff, 0},
+		{"a day later", kickoff.Add(24 * time.Hour), kickoff.Add(25 * time.Hour), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflicts, err := repo.FindScheduleConflicts([]uint{home.ID, away.ID}, tt.start, tt.end, 0)
+			if err != nil {
+				t.Fatalf("FindScheduleConflicts() error = %v", err)
+			}
+			if len(conflicts) != tt.conflicts {
+				t.Fatalf("got %d conflicts, want %d: %+v", len(conflicts), tt.conflicts, conflicts)
+			}
+			for _, c := range conflicts {
+				if c.MatchID != hourLong.ID || !c.EndsAt.Equal(kickoff.Add(time.Hour)) {
+					t.Errorf("conflict = %+v, want match %d ending at %v", c, hourLong.ID, kickoff.Add(time.Hour))
+				}
+			}
+		})
+	}
+}
+
+func TestFindScheduleConflictsDefaultDuration(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewGormMatchRepository(db)
+
+	player := testutil.CreateUser(t, db, "Player")
+	s := createSport(t, db)
+	home := createTeam(t, db, s.ID, player.ID)
+
+	kickoff := time.Now().Add(72 * time.Hour).Truncate(time.Hour).UTC()
+	createMatch(t, db, s.ID, player.ID, []*team.Team{home}, func(m *Match) {
+		m.ScheduledAt = kickoff
+		m.Duration = 0
+	})
+	end := kickoff.Add(defaultMatchDuration)
+
+	conflicts, err := repo.FindScheduleConflicts([]uint{home.ID}, end.Add(-time.Minute), end.Add(time.Hour), 0)
+	if err != nil {
+		t.Fatalf("FindScheduleConflicts() error = %v", err)
+	}
+	if len(conflicts) != 1 || !conflicts[0].EndsAt.Equal(end) {
+		t.Errorf("conflicts = %+v, want one match ending %v after kickoff", conflicts, defaultMatchDuration)
+	}
+
+	conflicts, err = repo.FindScheduleConflicts([]uint{home.ID}, end, end.Add(time.Hour), 0)
+	if err != nil {
+		t.Fatalf("FindScheduleConflicts() error = %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("slot starting when the default duration ends conflicts with %+v", conflicts)
+	}
+}
+
+func TestFindScheduleConflictsIgnoresInactiveAndExcludedMatches(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewGormMatchRepository(db)
+
+	player := testutil.CreateUser(t, db, "Player")
+	s := createSport(t, db)
+	home := createTeam(t, db, s.ID, player.ID)
+
+	kickoff := time.Now().Add(72 * time.Hour).Truncate(time.Hour).UTC()
+	tournament := createTournament(t, db, s.ID, player.ID)
+	createMatch(t, db, s.ID, player.ID, []*team.Team{home}, func(m *Match) {
+		m.ScheduledAt = kickoff
+		m.Status = StatusMatchCancelled
+	})
+	createMatch(t, db, s.ID, player.ID, []*team.Team{home}, func(m *Match) {
+		m.ScheduledAt = kickoff
+		m.TournamentID = &tournament.ID
+	})
+	slotEnd := kickoff.Add(time.Hour)
+
+	conflicts, err := repo.FindScheduleConflicts([]uint{home.ID}, kickoff, slotEnd, tournament.ID)
+	if err != nil {
+		t.Fatalf("FindScheduleConflicts() error = %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("conflicts = %+v; cancelled and excluded tournament matches should not count", conflicts)
+	}
+
+	conflicts, err = repo.FindScheduleConflicts([]uint{home.ID}, kickoff, slotEnd, 0)
+	if err != nil {
+		t.Fatalf("FindScheduleConflicts() error = %v", err)
+	}
+	if len(conflicts) != 1 {
+		t.Errorf("got %d conflicts, want the tournament match once it is not excluded", len(conflicts))
+	}
+}
@@ -0,0 +1,217 @@
+package match
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/gin-gonic/gin"
+)
+
+// scoreFixture is a live match between two teams, scored by its creator
+type scoreFixture struct {
+	*twoTeamMatch
+}
+
+func newScoreFixture(t *testing.T) *scoreFixture {
+	t.Helper()
+	return &scoreFixture{newTwoTeamMatch(t, withMatch(inStatus(StatusMatchLive)))}
+}
+
+func (f *scoreFixture) router() *gin.Engine {
+	r := gin.New()
+	r.Use(asUser(f.creator.ID))
+	r.GET("/matches", f.mc.GetMatches)
+	r.GET("/matches/:id", f.mc.GetMatchByID)
+	r.POST("/matches/:id/score", f.mc.UpdateMatchScore)
+	r.POST("/matches/:id/periods", f.mc.SetMatchPeriodScore)
+	r.POST("/admin/matches/:id/override-score", f.mc.AdminOverrideMatchScore)
+	return r
+}
+
+func (f *scoreFixture) score(t *testing.T, req UpdateMatchScoreRequest) *httptest.ResponseRecorder {
+	t.Helper()
+	return testutil.Request(t, f.router(), http.MethodPost, "/matches/"+itoa(f.match.ID)+"/score", req)
+}
+
+func (f *scoreFixture) setPeriod(t *testing.T, teamID uint, period, value int) *httptest.ResponseRecorder {
+	t.Helper()
+	return testutil.Request(t, f.router(), http.MethodPost, "/matches/"+itoa(f.match.ID)+"/periods",
+		SetPeriodScoreRequest{TeamID: teamID, Period: period, Value: &value})
+}
+
+// scores returns each team's score as GetMatchByID and GetMatches report it
+func (f *scoreFixture) scores(t *testing.T) (byID, listed map[uint]int) {
+	t.Helper()
+	collect := func(m Match) map[uint]int {
+		scores := make(map[uint]int)
+		for _, mt := range m.MatchTeams {
+			scores[mt.TeamID] = mt.Score
+		}
+		return scores
+	}
+
+	var m Match
+	testutil.DecodeData(t, testutil.Request(t, f.router(), http.MethodGet, "/matches/"+itoa(f.match.ID), nil), &m)
+
+	var page struct {
+		Items []Match `json:"items"`
+	}
+	testutil.DecodeData(t, testutil.Request(t, f.router(), http.MethodGet, "/matches", nil), &page)
+	if len(page.Items) != 1 {
+		t.Fatalf("GetMatches returned %d matches, want 1", len(page.Items))
+	}
+	return collect(m), collect(page.Items[0])
+}
+
+func TestUpdateMatchScoreRoundTrips(t *testing.T) {
+	f := newScoreFixture(t)
+
+	steps := []struct {
+		name string
+		req  UpdateMatchScoreRequest
+		want map[uint]int
+	}{
+		{"set home", UpdateMatchScoreRequest{TeamID: f.home.ID, Score: intPtr(3)}, map[uint]int{f.home.ID: 3, f.away.ID: 0}},
+		{"set away", UpdateMatchScoreRequest{TeamID: f.away.ID, Score: intPtr(1)}, map[uint]int{f.home.ID: 3, f.away.ID: 1}},
+		{"add to home", UpdateMatchScoreRequest{TeamID: f.home.ID, Score: intPtr(2), Increment: true}, map[uint]int{f.home.ID: 5, f.away.ID: 1}},
+		{"take from away", UpdateMatchScoreRequest{TeamID: f.away.ID, Score: intPtr(-4), Increment: true}, map[uint]int{f.home.ID: 5, f.away.ID: 0}},
+		{"reset home", UpdateMatchScoreRequest{TeamID: f.home.ID, Score: intPtr(0)}, map[uint]int{f.home.ID: 0, f.away.ID: 0}},
+	}
+	for _, step := range steps {
+		w := f.score(t, step.req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status = %d, want %d: %s", step.name, w.Code, http.StatusOK, w.Body)
+		}
+		var data struct {
+			MatchTeam MatchTeam `json:"match_team"`
+		}
+		testutil.DecodeData(t, w, &data)
+		if data.MatchTeam.Score != step.want[step.req.TeamID] {
+			t.Errorf("%s: returned score = %d, want %d", step.name, data.MatchTeam.Score, step.want[step.req.TeamID])
+		}
+
+		byID, listed := f.scores(t)
+		for teamID, want := range step.want {
+			if byID[teamID] != want || listed[teamID] != want {
+				t.Errorf("%s: team %d score = %d by ID and %d listed, want %d", step.name, teamID, byID[teamID], listed[teamID], want)
+			}
+		}
+	}
+}
+
+func TestUpdateMatchScoreRejectsNegativeAbsoluteScore(t *testing.T) {
+	f := newScoreFixture(t)
+	if w := f.score(t, UpdateMatchScoreRequest{TeamID: f.home.ID, Score: intPtr(-1)}); w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if w := f.score(t, UpdateMatchScoreRequest{TeamID: f.home.ID}); w.Code != http.StatusBadRequest {
+		t.Errorf("status without a score = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestAdminOverrideMatchScoreStoresScores(t *testing.T) {
+	f := newScoreFixture(t)
+	f.score(t, UpdateMatchScoreRequest{TeamID: f.home.ID, Score: intPtr(2)})
+
+	w := testutil.Request(t, f.router(), http.MethodPost, "/admin/matches/"+itoa(f.match.ID)+"/override-score", AdminOverrideScoreRequest{
+		Reason: "Scorer entered the wrong totals",
+		Scores: []UpdateMatchScoreRequest{
+			{TeamID: f.home.ID, Score: intPtr(4)},
+			{TeamID: f.away.ID, Score: intPtr(1), Increment: true},
+		},
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	byID, listed := f.scores(t)
+	want := map[uint]int{f.home.ID: 4, f.away.ID: 1}
+	for teamID, score := range want {
+		if byID[teamID] != score || listed[teamID] != score {
+			t.Errorf("team %d score = %d by ID and %d listed, want %d", teamID, byID[teamID], listed[teamID], score)
+		}
+	}
+}
+
+func TestSetMatchPeriodScoresForMultiSetMatch(t *testing.T) {
+	f := newScoreFixture(t)
+
+	// A three set match: 6-4, 3-6, 7-5
+	sets := [][2]int{{6, 4}, {3, 6}, {7, 5}}
+	for i, set := range sets {
+		for j, tm := range []*team.Team{f.home, f.away} {
+			if w := f.setPeriod(t, tm.ID, i+1, set[j]); w.Code != http.StatusOK {
+				t.Fatalf("set %d team %d: status = %d, want %d: %s", i+1, tm.ID, w.Code, http.StatusOK, w.Body)
+			}
+		}
+	}
+	// Correcting an earlier set recomputes the aggregate
+	if w := f.setPeriod(t, f.home.ID, 2, 4); w.Code != http.StatusOK {
+		t.Fatalf("rewriting set 2: status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	var m Match
+	testutil.DecodeData(t, testutil.Request(t, f.router(), http.MethodGet, "/matches/"+itoa(f.match.ID), nil), &m)
+	wantTotals := map[uint]int{f.home.ID: 17, f.away.ID: 15}
+	for _, mt := range m.MatchTeams {
+		if mt.Score != wantTotals[mt.TeamID] {
+			t.Errorf("team %d aggregate = %d, want %d", mt.TeamID, mt.Score, wantTotals[mt.TeamID])
+		}
+	}
+	if len(m.PeriodScores) != 6 {
+		t.Fatalf("match has %d period scores, want 6: %+v", len(m.PeriodScores), m.PeriodScores)
+	}
+	breakdown := make(map[uint][]int)
+	for _, ps := range m.PeriodScores {
+		breakdown[ps.TeamID] = append(breakdown[ps.TeamID], ps.Value)
+	}
+	if got := breakdown[f.home.ID]; len(got) != 3 || got[0] != 6 || got[1] != 4 || got[2] != 7 {
+		t.Errorf("home sets = %v, want [6 4 7] in period order", got)
+	}
+
+	// The aggregate now comes from the periods
+	if w := f.score(t, UpdateMatchScoreRequest{TeamID: f.home.ID, Score: intPtr(2)}); w.Code != http.StatusConflict {
+		t.Errorf("direct score update status = %d, want %d", w.Code, http.StatusConflict)
+	}
+}
+
+func TestSetMatchPeriodScoreValidation(t *testing.T) {
+	f := newScoreFixture(t)
+	if w := f.setPeriod(t, f.home.ID, 1, 6); w.Code != http.StatusOK {
+		t.Fatalf("set 1: status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	tests := []struct {
+		name   string
+		teamID uint
+		period int
+		value  int
+		status int
+	}{
+		{"skipping a period", f.home.ID, 3, 6, http.StatusBadRequest},
+		{"period zero", f.home.ID, 0, 6, http.StatusBadRequest},
+		{"negative value", f.home.ID, 2, -1, http.StatusBadRequest},
+		{"team outside the match", f.home.ID + f.away.ID + 1000, 1, 6, http.StatusBadRequest},
+		{"next period for the other team", f.away.ID, 2, 3, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := f.setPeriod(t, tt.teamID, tt.period, tt.value); w.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+		})
+	}
+
+	t.Run("finished match", func(t *testing.T) {
+		f := newScoreFixture(t)
+		if err := NewGormMatchRepository(f.db).UpdateMatchStatus(f.match.ID, StatusMatchCompleted); err != nil {
+			t.Fatalf("failed to complete match: %v", err)
+		}
+		if w := f.setPeriod(t, f.home.ID, 1, 6); w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+}
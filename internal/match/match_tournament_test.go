@@ -0,0 +1,390 @@
+package match
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/internal/user"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// storedTeamCount reloads a tournament's current_teams
+func storedTeamCount(t *testing.T, db *gorm.DB, tournamentID uint) int {
+	t.Helper()
+	var tr Tournament
+	if err := db.First(&tr, tournamentID).Error; err != nil {
+		t.Fatalf("failed to reload tournament: %v", err)
+	}
+	return tr.CurrentTeams
+}
+
+func TestRecomputeTournamentTeamCount(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewGormMatchRepository(db)
+
+	creator := testutil.CreateUser(t, db, "Creator")
+	s := createSport(t, db)
+	tr := createTournament(t, db, s.ID, creator.ID, func(tr *Tournament) { tr.CurrentTeams = 5 })
+	registerTeam(t, db, tr.ID, createTeam(t, db, s.ID, creator.ID).ID)
+	registerTeam(t, db, tr.ID, createTeam(t, db, s.ID, creator.ID).ID)
+	dropped := registerTeam(t, db, tr.ID, createTeam(t, db, s.ID, creator.ID).ID)
+	if err := db.Delete(dropped).Error; err != nil {
+		t.Fatalf("failed to delete registration: %v", err)
+	}
+
+	previous, current, err := repo.RecomputeTournamentTeamCount(tr.ID)
+	if err != nil {
+		t.Fatalf("RecomputeTournamentTeamCount() error = %v", err)
+	}
+	if previous != 5 || current != 2 {
+		t.Errorf("RecomputeTournamentTeamCount() = %d, %d; want 5, 2", previous, current)
+	}
+	if stored := storedTeamCount(t, db, tr.ID); stored != 2 {
+		t.Errorf("current_teams = %d after the repair, want 2", stored)
+	}
+
+	if _, _, err := repo.RecomputeTournamentTeamCount(tr.ID + 1000); !errors.Is(err, ErrTournamentNotFound) {
+		t.Errorf("RecomputeTournamentTeamCount(missing) error = %v, want ErrTournamentNotFound", err)
+	}
+}
+
+func TestRepairTournamentTeamCounts(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewGormMatchRepository(db)
+
+	creator := testutil.CreateUser(t, db, "Creator")
+	s := createSport(t, db)
+	drifted := createTournament(t, db, s.ID, creator.ID, func(tr *Tournament) { tr.CurrentTeams = 4 })
+	registerTeam(t, db, drifted.ID, createTeam(t, db, s.ID, creator.ID).ID)
+	consistent := createTournament(t, db, s.ID, creator.ID, func(tr *Tournament) { tr.CurrentTeams = 1 })
+	registerTeam(t, db, consistent.ID, createTeam(t, db, s.ID, creator.ID).ID)
+
+	ids, err := repo.RepairTournamentTeamCounts()
+	if err != nil {
+		t.Fatalf("RepairTournamentTeamCounts() error = %v", err)
+	}
+	if len(ids) != 1 || ids[0] != drifted.ID {
+		t.Errorf("RepairTournamentTeamCounts() = %v, want only tournament %d", ids, drifted.ID)
+	}
+	if stored := storedTeamCount(t, db, drifted.ID); stored != 1 {
+		t.Errorf("drifted current_teams = %d after the repair, want 1", stored)
+	}
+	if stored := storedTeamCount(t, db, consistent.ID); stored != 1 {
+		t.Errorf("consistent current_teams = %d after the repair, want 1", stored)
+	}
+}
+
+func TestAdminRecomputeTournamentTeamCount(t *testing.T) {
+	db := newTestDB(t)
+	mc := newTestController(t, db)
+	admin := testutil.CreateUser(t, db, "Admin")
+	s := createSport(t, db)
+	tr := createTournament(t, db, s.ID, admin.ID, func(tr *Tournament) { tr.CurrentTeams = 3 })
+	registerTeam(t, db, tr.ID, createTeam(t, db, s.ID, admin.ID).ID)
+
+	r := gin.New()
+	r.POST("/admin/tournaments/:id/recount-teams", asUser(admin.ID), mc.AdminRecomputeTournamentTeamCount)
+
+	w := testutil.Request(t, r, http.MethodPost, "/admin/tournaments/"+itoa(tr.ID)+"/recount-teams", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	var repair TournamentTeamCountRepair
+	testutil.DecodeData(t, w, &repair)
+	if repair != (TournamentTeamCountRepair{TournamentID: tr.ID, Previous: 3, Current: 1}) {
+		t.Errorf("repair = %+v, want 3 recounted to 1", repair)
+	}
+
+	w = testutil.Request(t, r, http.MethodPost, "/admin/tournaments/"+itoa(tr.ID+1000)+"/recount-teams", nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("missing tournament status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestRegisterTeamInTournamentWithDriftedCounter(t *testing.T) {
+	db := newTestDB(t)
+	creator := testutil.CreateUser(t, db, "Creator")
+	s := createSport(t, db)
+
+	// The counter claims the tournament is full, but only one team is registered
+	tr := createTournament(t, db, s.ID, creator.ID, func(tr *Tournament) {
+		tr.MaxTeams = 2
+		tr.CurrentTeams = 2
+	})
+	registerTeam(t, db, tr.ID, createTeam(t, db, s.ID, creator.ID).ID)
+	newcomer := createTeam(t, db, s.ID, creator.ID)
+
+	cached := NewGormMatchRepository(db)
+	if err := cached.RegisterTeamInTournament(tr.ID, newcomer.ID, creator.ID); !errors.Is(err, ErrTournamentFull) {
+		t.Fatalf("RegisterTeamInTournament() with the cached counter error = %v, want ErrTournamentFull", err)
+	}
+
+	live := NewGormMatchRepository(db).WithLiveRegistrationCount(true)
+	if err := live.RegisterTeamInTournament(tr.ID, newcomer.ID, creator.ID); err != nil {
+		t.Fatalf("RegisterTeamInTournament() counting live rows error = %v", err)
+	}
+	if stored := storedTeamCount(t, db, tr.ID); stored != 2 {
+		t.Errorf("current_teams = %d after registering, want 2", stored)
+	}
+
+	last := createTeam(t, db, s.ID, creator.ID)
+	if err := live.RegisterTeamInTournament(tr.ID, last.ID, creator.ID); !errors.Is(err, ErrTournamentFull) {
+		t.Errorf("RegisterTeamInTournament() into a full tournament error = %v, want ErrTournamentFull", err)
+	}
+}
+
+// withdrawalFixture is an ongoing tournament between three teams. The leaving team has beaten
+// the bystander and still has to play the opponent, who also has to play the bystander.
+type withdrawalFixture struct {
+	*twoTeamMatch
+	outsider   *user.User
+	tournament *Tournament
+	leaving    *team.Team // Home team, managed by its captain
+	opponent   *team.Team // Away team, captained by the creator
+	bystander  *team.Team
+	completed  *Match
+	upcoming   *Match
+	unrelated  *Match
+}
+
+func newWithdrawalFixture(t *testing.T) *withdrawalFixture {
+	t.Helper()
+	f := &withdrawalFixture{twoTeamMatch: newTwoTeamMatch(t, withHomeCaptain("Manager"), withCreatorAway(), withoutMatch())}
+	f.outsider = testutil.CreateUser(t, f.db, "Outsider")
+	f.leaving, f.opponent = f.home, f.away
+	f.bystander = createTeam(t, f.db, f.sport.ID, f.creator.ID)
+
+	f.tournament = createTournament(t, f.db, f.sport.ID, f.creator.ID, func(tr *Tournament) {
+		tr.Status = TournamentStatusOngoing
+		tr.CurrentTeams = 3
+	})
+	for _, tm := range []*team.Team{f.leaving, f.opponent, f.bystander} {
+		registerTeam(t, f.db, f.tournament.ID, tm.ID)
+	}
+
+	inTournament := func(m *Match) { m.TournamentID = &f.tournament.ID }
+	f.completed = createMatch(t, f.db, f.sport.ID, f.creator.ID, []*team.Team{f.leaving, f.bystander}, inTournament, func(m *Match) {
+		m.Status = StatusMatchCompleted
+		m.WinningTeamID = &f.leaving.ID
+	})
+	setResults(t, f.db, f.completed.ID, map[uint]string{f.leaving.ID: ResultWin, f.bystander.ID: ResultLoss})
+	f.upcoming = f.newMatch(t, inTournament)
+	f.unrelated = createMatch(t, f.db, f.sport.ID, f.creator.ID, []*team.Team{f.opponent, f.bystander}, inTournament)
+	return f
+}
+
+// setResults stores each team's result in the match
+func setResults(t *testing.T, db *gorm.DB, matchID uint, results map[uint]string) {
+	t.Helper()
+	for teamID, result := range results {
+		if err := db.Model(&MatchTeam{}).Where("match_id = ? AND team_id = ?", matchID, teamID).
+			Update("result_status", result).Error; err != nil {
+			t.Fatalf("failed to set match result: %v", err)
+		}
+	}
+}
+
+func (f *withdrawalFixture) withdraw(t *testing.T, userID uint) *httptest.ResponseRecorder {
+	t.Helper()
+	r := gin.New()
+	r.POST("/tournaments/:id/teams/:team_id/withdraw", asUser(userID), f.mc.WithdrawTeamFromTournament)
+	return testutil.Request(t, r, http.MethodPost,
+		"/tournaments/"+itoa(f.tournament.ID)+"/teams/"+itoa(f.leaving.ID)+"/withdraw", nil)
+}
+
+func (f *withdrawalFixture) reload(t *testing.T, matchID uint) Match {
+	t.Helper()
+	var m Match
+	if err := f.db.Preload("MatchTeams").First(&m, matchID).Error; err != nil {
+		t.Fatalf("failed to reload match: %v", err)
+	}
+	return m
+}
+
+func TestWithdrawTeamFromOngoingTournament(t *testing.T) {
+	f := newWithdrawalFixture(t)
+
+	w := f.withdraw(t, f.homeCaptain.ID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	var withdrawal TournamentWithdrawal
+	testutil.DecodeData(t, w, &withdrawal)
+	if len(withdrawal.ForfeitedMatchIDs) != 1 || withdrawal.ForfeitedMatchIDs[0] != f.upcoming.ID {
+		t.Errorf("forfeited matches = %v, want only %d", withdrawal.ForfeitedMatchIDs, f.upcoming.ID)
+	}
+
+	var registration TournamentTeam
+	if err := f.db.Where("tournament_id = ? AND team_id = ?", f.tournament.ID, f.leaving.ID).First(&registration).Error; err != nil {
+		t.Fatalf("failed to reload registration: %v", err)
+	}
+	if registration.Status != TournamentTeamWithdrawn {
+		t.Errorf("registration status = %q, want %q", registration.Status, TournamentTeamWithdrawn)
+	}
+
+	forfeited := f.reload(t, f.upcoming.ID)
+	if forfeited.Status != StatusMatchForfeited || forfeited.WinningTeamID == nil || *forfeited.WinningTeamID != f.opponent.ID || forfeited.CompletedAt == nil {
+		t.Errorf("forfeited match = status %q, winner %v, completed %v; want forfeited to team %d",
+			forfeited.Status, forfeited.WinningTeamID, forfeited.CompletedAt, f.opponent.ID)
+	}
+	for _, mt := range forfeited.MatchTeams {
+		want := ResultWin
+		if mt.TeamID == f.leaving.ID {
+			want = ResultForfeit
+		}
+		if mt.ResultStatus != want {
+			t.Errorf("team %d result = %q, want %q", mt.TeamID, mt.ResultStatus, want)
+		}
+	}
+
+	if completed := f.reload(t, f.completed.ID); completed.Status != StatusMatchCompleted || *completed.WinningTeamID != f.leaving.ID {
+		t.Errorf("completed match changed to status %q, winner %v", completed.Status, completed.WinningTeamID)
+	}
+	if unrelated := f.reload(t, f.unrelated.ID); unrelated.Status != StatusMatchUpcoming {
+		t.Errorf("match without the team changed to status %q", unrelated.Status)
+	}
+
+	// The standings count the forfeit as a loss for the withdrawn team and a win for its opponent
+	r := gin.New()
+	r.GET("/tournaments/:id/report", f.mc.GetTournamentReport)
+	w = testutil.Request(t, r, http.MethodGet, "/tournaments/"+itoa(f.tournament.ID)+"/report", nil)
+	var report TournamentReport
+	testutil.DecodeData(t, w, &report)
+	standings := make(map[uint]TournamentStanding)
+	for _, s := range report.Standings {
+		standings[s.TeamID] = s
+	}
+	if s := standings[f.leaving.ID]; !s.Withdrawn || s.Played != 2 || s.Wins != 1 || s.Forfeits != 1 {
+		t.Errorf("withdrawn team standing = %+v, want withdrawn with a win and a forfeit", s)
+	}
+	if s := standings[f.opponent.ID]; s.Withdrawn || s.Played != 1 || s.Wins != 1 {
+		t.Errorf("opponent standing = %+v, want one win", s)
+	}
+
+	if w := f.withdraw(t, f.homeCaptain.ID); w.Code != http.StatusConflict {
+		t.Errorf("second withdrawal status = %d, want %d", w.Code, http.StatusConflict)
+	}
+}
+
+func TestWithdrawTeamFromTournamentRules(t *testing.T) {
+	t.Run("outsider", func(t *testing.T) {
+		f := newWithdrawalFixture(t)
+		if w := f.withdraw(t, f.outsider.ID); w.Code != http.StatusForbidden {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+		}
+		if m := f.reload(t, f.upcoming.ID); m.Status != StatusMatchUpcoming {
+			t.Errorf("rejected withdrawal changed the match to %q", m.Status)
+		}
+	})
+
+	t.Run("tournament creator", func(t *testing.T) {
+		f := newWithdrawalFixture(t)
+		if w := f.withdraw(t, f.tournament.CreatedByUserID); w.Code != http.StatusOK {
+			t.Errorf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+		}
+	})
+
+	t.Run("before the tournament starts", func(t *testing.T) {
+		f := newWithdrawalFixture(t)
+		if err := f.db.Model(f.tournament).Update("status", TournamentStatusRegistrationOpen).Error; err != nil {
+			t.Fatalf("failed to reopen registration: %v", err)
+		}
+		if w := f.withdraw(t, f.homeCaptain.ID); w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("unregistered team", func(t *testing.T) {
+		f := newWithdrawalFixture(t)
+		if err := f.db.Where("tournament_id = ? AND team_id = ?", f.tournament.ID, f.leaving.ID).
+			Delete(&TournamentTeam{}).Error; err != nil {
+			t.Fatalf("failed to remove registration: %v", err)
+		}
+		if w := f.withdraw(t, f.homeCaptain.ID); w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+}
+
+func TestGetTournamentsFilters(t *testing.T) {
+	db := newTestDB(t)
+	mc := newTestController(t, db)
+	creator := testutil.CreateUser(t, db, "Creator")
+	sp := createSport(t, db)
+
+	base := time.Now().UTC().Truncate(time.Hour)
+	day := 24 * time.Hour
+	tournament := func(name, description, status string, start, deadline time.Time) uint {
+		return createTournament(t, db, sp.ID, creator.ID, func(tr *Tournament) {
+			tr.Name = name
+			tr.Description = description
+			tr.Status = status
+			tr.StartDate = start
+			tr.RegistrationDeadline = deadline
+		}).ID
+	}
+	summer := tournament("Summer Cricket Cup", "Open T20 league", TournamentStatusRegistrationOpen, base.Add(10*day), base.Add(3*day))
+	winter := tournament("Winter League", "Indoor CRICKET nights", TournamentStatusRegistrationOpen, base.Add(5*day), base.Add(-day))
+	spring := tournament("Spring Football Open", "", TournamentStatusOngoing, base.Add(20*day), time.Time{})
+	autumn := tournament("Autumn Football Cup", "Five-a-side", TournamentStatusRegistrationOpen, base.Add(30*day), time.Time{})
+
+	r := gin.New()
+	r.GET("/tournaments", mc.GetTournaments)
+	list := func(t *testing.T, query string) []uint {
+		t.Helper()
+		w := testutil.Request(t, r, http.MethodGet, "/tournaments?"+query, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+		}
+		var page struct {
+			Items []Tournament `json:"items"`
+		}
+		testutil.DecodeData(t, w, &page)
+		ids := []uint{}
+		for _, tr := range page.Items {
+			ids = append(ids, tr.ID)
+		}
+		return ids
+	}
+
+	after := url.QueryEscape(base.Add(15 * day).Format(time.RFC3339))
+	tests := []struct {
+		name  string
+		query string
+		want  []uint
+	}{
+		{"sorted by start date", "", []uint{winter, summer, spring, autumn}},
+		{"text in name or description, any case", "q=cricket", []uint{winter, summer}},
+		{"text in name", "q=" + url.QueryEscape(" football "), []uint{spring, autumn}},
+		{"no text match", "q=hockey", []uint{}},
+		{"accepting registrations", "accepting_registrations=true", []uint{summer, autumn}},
+		{"not accepting registrations", "accepting_registrations=false", []uint{winter, spring}},
+		{"starting after", "starting_after=" + after, []uint{spring, autumn}},
+		{"starting before", "starting_before=" + after, []uint{winter, summer}},
+		{"starting after a date", "starting_after=" + base.Add(25*day).Format("2006-01-02"), []uint{autumn}},
+		{"combined", "q=football&accepting_registrations=true", []uint{autumn}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := list(t, tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tournaments = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for _, query := range []string{"starting_after=soon", "starting_before=2020-13-01", "accepting_registrations=maybe"} {
+		t.Run("rejects "+query, func(t *testing.T) {
+			if w := testutil.Request(t, r, http.MethodGet, "/tournaments?"+query, nil); w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
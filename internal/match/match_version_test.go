@@ -0,0 +1,192 @@
+package match
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/gin-gonic/gin"
+)
+
+func TestUpdateMatchRejectsConcurrentStaleUpdate(t *testing.T) {
+	db := newTestDB(t)
+	mc := newTestController(t, db)
+	creator := testutil.CreateUser(t, db, "Creator")
+	s := createSport(t, db)
+	m := createMatch(t, db, s.ID, creator.ID, []*team.Team{
+		createTeam(t, db, s.ID, creator.ID), createTeam(t, db, s.ID, testutil.CreateUser(t, db, "Rival").ID),
+	})
+
+	r := gin.New()
+	r.Use(asUser(creator.ID))
+	r.PUT("/matches/:id", mc.UpdateMatch)
+
+	// Both requests were prepared from the same read of the match
+	durations := []int{60, 120}
+	codes := make([]int, len(durations))
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i, duration := range durations {
+		wg.Add(1)
+		go func(i, duration int) {
+			defer wg.Done()
+			<-start
+			w := testutil.Request(t, r, http.MethodPut, "/matches/"+itoa(m.ID), gin.H{"duration": duration, "version": m.Version})
+			codes[i] = w.Code
+		}(i, duration)
+	}
+	close(start)
+	wg.Wait()
+
+	sorted := append([]int(nil), codes...)
+	sort.Ints(sorted)
+	if sorted[0] != http.StatusOK || sorted[1] != http.StatusConflict {
+		t.Fatalf("status codes = %v, want one %d and one %d", codes, http.StatusOK, http.StatusConflict)
+	}
+	winner := durations[0]
+	if codes[1] == http.StatusOK {
+		winner = durations[1]
+	}
+
+	var stored Match
+	if err := db.First(&stored, m.ID).Error; err != nil {
+		t.Fatalf("failed to reload match: %v", err)
+	}
+	if stored.Version != m.Version+1 {
+		t.Errorf("version = %d, want %d", stored.Version, m.Version+1)
+	}
+	if stored.Duration != winner {
+		t.Errorf("duration = %d, want the accepted update's %d", stored.Duration, winner)
+	}
+}
+
+func TestPartialMatchUpdatesRejectStaleFullUpdate(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []func(*Match)
+		update func(r *GormMatchRepository, m *Match, home, away *team.Team) error
+	}{
+		{"status change", nil, func(r *GormMatchRepository, m *Match, _, _ *team.Team) error {
+			return r.UpdateMatchStatus(m.ID, StatusMatchCancelled)
+		}},
+		{"result", []func(*Match){func(m *Match) { m.Status = StatusMatchLive }}, func(r *GormMatchRepository, m *Match, home, _ *team.Team) error {
+			return r.EndMatch(m.ID, ResultWin, &home.ID, nil)
+		}},
+		{"forfeit", nil, func(r *GormMatchRepository, m *Match, _, away *team.Team) error {
+			return r.ForfeitMatch(m.ID, away.ID, "Forfeited")
+		}},
+		{"auto start", []func(*Match){func(m *Match) {
+			m.AutoStart = true
+			m.ScheduledAt = time.Now().Add(-time.Minute).Truncate(time.Second)
+		}}, func(r *GormMatchRepository, _ *Match, _, _ *team.Team) error {
+			_, err := r.AutoStartDueMatches(time.Now())
+			return err
+		}},
+		{"reminder claim", []func(*Match){func(m *Match) {
+			m.ScheduledAt = time.Now().Add(30 * time.Minute).Truncate(time.Second)
+		}}, func(r *GormMatchRepository, _ *Match, _, _ *team.Team) error {
+			_, err := r.ClaimMatchesForReminder(time.Hour)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			repo := NewGormMatchRepository(db)
+			creator := testutil.CreateUser(t, db, "Creator")
+			s := createSport(t, db)
+			home := createTeam(t, db, s.ID, creator.ID)
+			away := createTeam(t, db, s.ID, testutil.CreateUser(t, db, "Rival").ID)
+			m := createMatch(t, db, s.ID, creator.ID, []*team.Team{home, away}, tt.opts...)
+
+			stale, err := repo.GetMatchByID(m.ID)
+			if err != nil {
+				t.Fatalf("GetMatchByID() error = %v", err)
+			}
+			if err := tt.update(repo, m, home, away); err != nil {
+				t.Fatalf("partial update failed: %v", err)
+			}
+			var written Match
+			if err := db.First(&written, m.ID).Error; err != nil {
+				t.Fatalf("failed to reload match: %v", err)
+			}
+			if written.Version != stale.Version+1 {
+				t.Fatalf("version after the partial update = %d, want %d", written.Version, stale.Version+1)
+			}
+
+			stale.Description = "Edited from a stale copy"
+			if err := repo.UpdateMatch(stale); !errors.Is(err, ErrVersionConflict) {
+				t.Fatalf("UpdateMatch() error = %v, want ErrVersionConflict", err)
+			}
+			var stored Match
+			if err := db.First(&stored, m.ID).Error; err != nil {
+				t.Fatalf("failed to reload match: %v", err)
+			}
+			if stored.Status != written.Status || stored.Version != written.Version || stored.Description == stale.Description {
+				t.Errorf("stale update overwrote the match: status %q version %d", stored.Status, stored.Version)
+			}
+		})
+	}
+}
+
+func TestPartialChallengeAndTournamentUpdatesRejectStaleFullUpdate(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewGormMatchRepository(db)
+	creator := testutil.CreateUser(t, db, "Creator")
+	s := createSport(t, db)
+	tm := createTeam(t, db, s.ID, creator.ID)
+
+	t.Run("challenge expiry", func(t *testing.T) {
+		ch := createChallenge(t, db, s.ID, creator.ID, tm, func(ch *Challenge) {
+			expired := time.Now().Add(-time.Hour)
+			ch.ExpiresAt = &expired
+		})
+		stale, err := repo.GetChallengeByID(ch.ID)
+		if err != nil {
+			t.Fatalf("GetChallengeByID() error = %v", err)
+		}
+		if _, err := repo.ExpireChallenges(); err != nil {
+			t.Fatalf("ExpireChallenges() error = %v", err)
+		}
+
+		stale.Description = "Edited from a stale copy"
+		if err := repo.UpdateChallenge(stale); !errors.Is(err, ErrVersionConflict) {
+			t.Fatalf("UpdateChallenge() error = %v, want ErrVersionConflict", err)
+		}
+		var stored Challenge
+		if err := db.First(&stored, ch.ID).Error; err != nil {
+			t.Fatalf("failed to reload challenge: %v", err)
+		}
+		if stored.Status != StatusExpired {
+			t.Errorf("status = %q, want %q", stored.Status, StatusExpired)
+		}
+	})
+
+	t.Run("tournament registration", func(t *testing.T) {
+		tr := createTournament(t, db, s.ID, creator.ID, func(tr *Tournament) { tr.MaxTeams = 8 })
+		stale, err := repo.GetTournamentByID(tr.ID)
+		if err != nil {
+			t.Fatalf("GetTournamentByID() error = %v", err)
+		}
+		if err := repo.RegisterTeamInTournament(tr.ID, tm.ID, creator.ID); err != nil {
+			t.Fatalf("RegisterTeamInTournament() error = %v", err)
+		}
+
+		stale.Description = "Edited from a stale copy"
+		if err := repo.UpdateTournament(stale); !errors.Is(err, ErrVersionConflict) {
+			t.Fatalf("UpdateTournament() error = %v, want ErrVersionConflict", err)
+		}
+		var stored Tournament
+		if err := db.First(&stored, tr.ID).Error; err != nil {
+			t.Fatalf("failed to reload tournament: %v", err)
+		}
+		if stored.CurrentTeams != 1 {
+			t.Errorf("current_teams = %d, want 1", stored.CurrentTeams)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package match
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/notification"
+)
+
+// StartChallengeExpiryWorker periodically expires challenges whose deadline has passed.
+// It runs until ctx is cancelled; a non-positive interval disables it. The returned channel is
+// closed once the worker has stopped, after finishing a run in progress.
+func StartChallengeExpiryWorker(ctx context.Context, repo MatchRepository, interval time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+	if interval <= 0 {
+		log.Println("Challenge expiry worker disabled")
+		close(done)
+		return done
+	}
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		log.Printf("Challenge expiry worker started (interval %s)", interval)
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("Challenge expiry worker stopped")
+				return
+			case <-ticker.C:
+				expired, err := repo.ExpireChallenges()
+				if err != nil {
+					log.Printf("Challenge expiry worker: failed to expire challenges: %v", err)
+					continue
+				}
+				log.Printf("Challenge expiry worker: expired %d challenge(s)", expired)
+			}
+		}
+	}()
+	return done
+}
+
+// StartMatchReminderWorker periodically reminds players about upcoming matches starting within
+// leadTime. Each match is reminded once. It runs until ctx is cancelled; a non-positive interval
+// disables it. The returned channel is closed once the worker has stopped.
+func StartMatchReminderWorker(ctx context.Context, repo MatchRepository, notifier notification.Notifier, leadTime, interval time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+	if interval <= 0 {
+		log.Println("Match reminder worker disabled")
+		close(done)
+		return done
+	}
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		log.Printf("Match reminder worker started (interval %s, lead time %s)", interval, leadTime)
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("Match reminder worker stopped")
+				return
+			case <-ticker.C:
+				sent, err := sendMatchReminders(repo, notifier, leadTime)
+				if err != nil {
+					log.Printf("Match reminder worker: failed to send reminders: %v", err)
+					continue
+				}
+				if sent > 0 {
+					log.Printf("Match reminder worker: sent %d reminder(s)", sent)
+				}
+			}
+		}
+	}()
+	return done
+}
+
+// sendMatchReminders notifies the players of every match due for a reminder and returns how
+// many notifications were sent
+func sendMatchReminders(repo MatchRepository, notifier notification.Notifier, leadTime time.Duration) (int, error) {
+	matches, err := repo.ClaimMatchesForReminder(leadTime)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, m := range matches {
+		userIDs, err := repo.GetMatchReminderRecipients(m.ID)
+		if err != nil {
+			log.Printf("Match reminder worker: failed to load players for match %d: %v", m.ID, err)
+			continue
+		}
+		for _, userID := range userIDs {
+			notifier.Notify(notification.Message{
+				UserID:       userID,
+				Type:         notification.TypeMatchStartingSoon,
+				Title:        "Match starting soon",
+				Body:         fmt.Sprintf("Your match starts at %s.", m.ScheduledAt.UTC().Format(time.RFC3339)),
+				ResourceType: "match",
+				ResourceID:   m.ID,
+			})
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// StartMatchAutoStartWorker periodically puts due auto-start matches live and completes
+// auto-complete matches that have run their duration. It runs until ctx is cancelled; a
+// non-positive interval disables it. The returned channel is closed once the worker has stopped.
+func StartMatchAutoStartWorker(ctx context.Context, repo MatchRepository, interval time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+	if interval <= 0 {
+		log.Println("Match auto-start worker disabled")
+		close(done)
+		return done
+	}
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		log.Printf("Match auto-start worker started (interval %s)", interval)
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("Match auto-start worker stopped")
+				return
+			case <-ticker.C:
+				now := time.Now()
+				started, err := repo.AutoStartDueMatches(now)
+				if err != nil {
+					log.Printf("Match auto-start worker: failed to start matches: %v", err)
+				} else if started > 0 {
+					log.Printf("Match auto-start worker: started %d match(es)", started)
+				}
+				completed, err := repo.AutoCompleteDueMatches(now)
+				if err != nil {
+					log.Printf("Match auto-start worker: failed to complete matches: %v", err)
+				} else if completed > 0 {
+					log.Printf("Match auto-start worker: completed %d match(es)", completed)
+				}
+			}
+		}
+	}()
+	return done
+}
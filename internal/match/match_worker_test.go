@@ -0,0 +1,173 @@
+package match
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/notification"
+	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+)
+
+func TestChallengeExpiryWorkerExpiresChallenges(t *testing.T) {
+	db := newTestDB(t)
+	creator := testutil.CreateUser(t, db, "Creator")
+	s := createSport(t, db)
+	expiresAt := func(d time.Duration) func(*Challenge) {
+		return func(ch *Challenge) {
+			at := time.Now().Add(d)
+			ch.ExpiresAt = &at
+		}
+	}
+	expired := createChallenge(t, db, s.ID, creator.ID, nil, expiresAt(-time.Minute))
+	live := createChallenge(t, db, s.ID, creator.ID, nil, expiresAt(time.Hour))
+	accepted := createChallenge(t, db, s.ID, creator.ID, nil, expiresAt(-time.Minute), func(ch *Challenge) {
+		ch.Status = StatusAccepted
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := StartChallengeExpiryWorker(ctx, NewGormMatchRepository(db), 10*time.Millisecond)
+
+	status := func(id uint) ChallengeStatus {
+		var ch Challenge
+		if err := db.Select("status").First(&ch, id).Error; err != nil {
+			t.Fatalf("failed to reload challenge: %v", err)
+		}
+		return ch.Status
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for status(expired.ID) != StatusExpired {
+		if time.Now().After(deadline) {
+			t.Fatal("worker did not expire the challenge")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("worker did not stop after cancellation")
+	}
+
+	if got := status(live.ID); got != StatusOpen {
+		t.Errorf("unexpired challenge status = %q, want %q", got, StatusOpen)
+	}
+	if got := status(accepted.ID); got != StatusAccepted {
+		t.Errorf("accepted challenge status = %q, want %q", got, StatusAccepted)
+	}
+}
+
+func TestChallengeExpiryWorkerDisabled(t *testing.T) {
+	done := StartChallengeExpiryWorker(context.Background(), nil, 0)
+	select {
+	case <-done:
+	default:
+		t.Fatal("disabled worker should report itself stopped")
+	}
+}
+
+func TestSendMatchRemindersOncePerMatch(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewGormMatchRepository(db)
+
+	homeCaptain := testutil.CreateUser(t, db, "Home captain")
+	awayCaptain := testutil.CreateUser(t, db, "Away captain")
+	substitute := testutil.CreateUser(t, db, "Substitute")
+	s := createSport(t, db)
+	home := createTeam(t, db, s.ID, homeCaptain.ID)
+	away := createTeam(t, db, s.ID, awayCaptain.ID)
+	startsIn := func(d time.Duration) func(*Match) {
+		return func(m *Match) { m.ScheduledAt = time.Now().Add(d) }
+	}
+	soon := createMatch(t, db, s.ID, homeCaptain.ID, []*team.Team{home, away}, startsIn(30*time.Minute))
+	createMatch(t, db, s.ID, homeCaptain.ID, []*team.Team{home, away}, startsIn(3*time.Hour))
+	createMatch(t, db, s.ID, homeCaptain.ID, []*team.Team{home, away}, startsIn(30*time.Minute), func(m *Match) {
+		m.Status = StatusMatchCancelled
+	})
+	// A lineup player who is also a member is reminded once
+	for _, userID := range []uint{substitute.ID, homeCaptain.ID} {
+		lineup := &MatchLineup{MatchID: soon.ID, TeamID: home.ID, UserID: userID}
+		if err := db.Omit("User").Create(lineup).Error; err != nil {
+			t.Fatalf("failed to add lineup player: %v", err)
+		}
+	}
+
+	notifier := &recordingNotifier{}
+	sent, err := sendMatchReminders(repo, notifier, time.Hour)
+	if err != nil {
+		t.Fatalf("sendMatchReminders() error = %v", err)
+	}
+	if sent != 3 || len(notifier.messages) != 3 {
+		t.Fatalf("sent %d reminders (%d messages), want 3", sent, len(notifier.messages))
+	}
+	var recipients []uint
+	for _, msg := range notifier.messages {
+		if msg.Type != notification.TypeMatchStartingSoon || msg.ResourceType != "match" || msg.ResourceID != soon.ID {
+			t.Errorf("message = %+v, want a starting soon reminder for match %d", msg, soon.ID)
+		}
+		recipients = append(recipients, msg.UserID)
+	}
+	sort.Slice(recipients, func(i, j int) bool { return recipients[i] < recipients[j] })
+	want := []uint{homeCaptain.ID, awayCaptain.ID, substitute.ID}
+	sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+	for i := range want {
+		if recipients[i] != want[i] {
+			t.Fatalf("reminded users %v, want %v", recipients, want)
+		}
+	}
+
+	sent, err = sendMatchReminders(repo, notifier, time.Hour)
+	if err != nil {
+		t.Fatalf("second sendMatchReminders() error = %v", err)
+	}
+	if sent != 0 {
+		t.Errorf("second scan sent %d reminders, want none", sent)
+	}
+
+	var stored Match
+	if err := db.Select("reminder_sent_at").First(&stored, soon.ID).Error; err != nil {
+		t.Fatalf("failed to reload match: %v", err)
+	}
+	if stored.ReminderSentAt == nil {
+		t.Error("reminded match was not marked as reminded")
+	}
+}
+
+func TestClaimMatchesForReminderConcurrently(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewGormMatchRepository(db)
+	creator := testutil.CreateUser(t, db, "Creator")
+	s := createSport(t, db)
+	m := createMatch(t, db, s.ID, creator.ID, nil, func(m *Match) { m.ScheduledAt = time.Now().Add(10 * time.Minute) })
+
+	const workers = 4
+	claimed := make(chan []Match, workers)
+	start := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		go func() {
+			<-start
+			matches, err := repo.ClaimMatchesForReminder(time.Hour)
+			if err != nil {
+				t.Errorf("ClaimMatchesForReminder() error = %v", err)
+			}
+			claimed <- matches
+		}()
+	}
+	close(start)
+
+	total := 0
+	for i := 0; i < workers; i++ {
+		for _, c := range <-claimed {
+			if c.ID != m.ID {
+				t.Errorf("claimed match %d, want %d", c.ID, m.ID)
+			}
+			total++
+		}
+	}
+	if total != 1 {
+		t.Errorf("match claimed %d times, want once", total)
+	}
+}
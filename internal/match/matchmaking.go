@@ -0,0 +1,93 @@
+package match
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/DhavalSuthar-24/miow/internal/models"
+)
+
+// MatchmakingProfile holds the user data that drives the challenge matchmaking feed
+type MatchmakingProfile struct {
+	Coordinates models.Coordinates
+	SportLevels map[uint]string // Sport ID -> self-reported skill level, empty if unknown
+}
+
+// MatchmakingChallenge is a challenge suggested to a user, with its distance when known
+type MatchmakingChallenge struct {
+	Challenge
+	DistanceKm *float64 `json:"distance_km,omitempty"`
+}
+
+// matchmakingCandidateLimit caps how many of the soonest open challenges are loaded and ranked
+// for a matchmaking feed, so a busy sport does not pull every open challenge into memory
+const matchmakingCandidateLimit = 500
+
+// skillLevels orders the skill levels used by user sports and challenges
+var skillLevels = map[string]int{
+	"beginner":     1,
+	"intermediate": 2,
+	"advanced":     3,
+	"professional": 4,
+}
+
+// skillRank returns the position of a skill level, or 0 if it is unknown
+func skillRank(level string) int {
+	return skillLevels[strings.ToLower(strings.TrimSpace(level))]
+}
+
+// skillInRange reports whether a level falls within a challenge's skill bounds.
+// Unknown levels and open bounds never exclude a challenge.
+func skillInRange(level, min, max string) bool {
+	rank := skillRank(level)
+	if rank == 0 {
+		return true
+	}
+	if minRank := skillRank(min); minRank > 0 && rank < minRank {
+		return false
+	}
+	if maxRank := skillRank(max); maxRank > 0 && rank > maxRank {
+		return false
+	}
+	return true
+}
+
+// hasCoordinates reports whether the coordinates have been set
+func hasCoordinates(c models.Coordinates) bool {
+	return c.Latitude != 0 || c.Longitude != 0
+}
+
+// rankMatchmakingChallenges drops challenges outside the user's skill level for their sport and
+// orders the rest by venue proximity (when both locations are known) and then by the soonest
+// proposed time. Challenges without a known distance come after those with one.
+func rankMatchmakingChallenges(challenges []Challenge, levels map[uint]string, origin models.Coordinates) []MatchmakingChallenge {
+	ranked := make([]MatchmakingChallenge, 0, len(challenges))
+	for _, ch := range challenges {
+		if !skillInRange(levels[ch.SportID], ch.MinSkillLevel, ch.MaxSkillLevel) {
+			continue
+		}
+		item := MatchmakingChallenge{Challenge: ch}
+		if ch.Venue != nil && hasCoordinates(origin) {
+			if coords, ok := models.ParseCoordinates(ch.Venue.Coordinates); ok {
+				d := math.Round(models.DistanceKm(origin, coords)*10) / 10
+				item.DistanceKm = &d
+			}
+		}
+		ranked = append(ranked, item)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		di, dj := ranked[i].DistanceKm, ranked[j].DistanceKm
+		switch {
+		case di != nil && dj != nil && *di != *dj:
+			return *di < *dj
+		case di != nil && dj == nil:
+			return true
+		case di == nil && dj != nil:
+			return false
+		}
+		return ranked[i].ProposedDateTime.Before(ranked[j].ProposedDateTime)
+	})
+	return ranked
+}
@@ -0,0 +1,199 @@
+package match
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/models"
+	"github.com/DhavalSuthar-24/miow/internal/sport"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/internal/venue"
+	"github.com/gin-gonic/gin"
+)
+
+func TestSkillInRange(t *testing.T) {
+	tests := []struct {
+		level, min, max string
+		want            bool
+	}{
+		{"intermediate", "", "", true},
+		{"intermediate", "beginner", "advanced", true},
+		{"Intermediate ", "intermediate", "intermediate", true},
+		{"beginner", "intermediate", "", false},
+		{"professional", "", "advanced", false},
+		{"", "advanced", "professional", true}, // Unknown levels never exclude
+		{"advanced", "expert", "", true},       // Unknown bounds are open
+		{"advanced", "beginner", "intermediate", false},
+	}
+	for _, tt := range tests {
+		if got := skillInRange(tt.level, tt.min, tt.max); got != tt.want {
+			t.Errorf("skillInRange(%q, %q, %q) = %v, want %v", tt.level, tt.min, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestRankMatchmakingChallenges(t *testing.T) {
+	now := time.Now()
+	near := &venue.Venue{Coordinates: `{"latitude":0.1,"longitude":0}`}
+	far := &venue.Venue{Coordinates: `{"latitude":1,"longitude":0}`}
+	unplaced := &venue.Venue{Coordinates: "{}"}
+
+	challenges := []Challenge{
+		{Title: "no venue, soonest", SportID: 1, ProposedDateTime: now.Add(time.Hour)},
+		{Title: "far", SportID: 1, Venue: far, ProposedDateTime: now.Add(2 * time.Hour)},
+		{Title: "near, later", SportID: 1, Venue: near, ProposedDateTime: now.Add(5 * time.Hour)},
+		{Title: "near, sooner", SportID: 1, Venue: near, ProposedDateTime: now.Add(3 * time.Hour)},
+		{Title: "unplaced venue", SportID: 1, Venue: unplaced, ProposedDateTime: now.Add(4 * time.Hour)},
+		{Title: "too advanced", SportID: 1, MinSkillLevel: "advanced", ProposedDateTime: now},
+		{Title: "other sport, any level", SportID: 2, MinSkillLevel: "professional", ProposedDateTime: now.Add(6 * time.Hour)},
+	}
+	levels := map[uint]string{1: "beginner"}
+
+	ranked := rankMatchmakingChallenges(challenges, levels, models.Coordinates{Latitude: 0.0001, Longitude: 0})
+
+	want := []string{"near, sooner", "near, later", "far", "no venue, soonest", "unplaced venue", "other sport, any level"}
+	if len(ranked) != len(want) {
+		t.Fatalf("got %d challenges, want %d", len(ranked), len(want))
+	}
+	for i, title := range want {
+		if ranked[i].Title != title {
+			t.Errorf("position %d = %q, want %q", i, ranked[i].Title, title)
+		}
+	}
+	if d := ranked[0].DistanceKm; d == nil || *d < 11 || *d > 11.2 {
+		t.Errorf("near distance = %v, want about 11.1 km", d)
+	}
+	if ranked[3].DistanceKm != nil {
+		t.Errorf("challenge without a venue has distance %v", *ranked[3].DistanceKm)
+	}
+}
+
+func TestRankMatchmakingChallengesWithoutOrigin(t *testing.T) {
+	now := time.Now()
+	challenges := []Challenge{
+		{Title: "later", SportID: 1, Venue: &venue.Venue{Coordinates: `{"latitude":0.1,"longitude":0}`}, ProposedDateTime: now.Add(2 * time.Hour)},
+		{Title: "sooner", SportID: 1, ProposedDateTime: now.Add(time.Hour)},
+	}
+
+	ranked := rankMatchmakingChallenges(challenges, nil, models.Coordinates{})
+
+	if len(ranked) != 2 || ranked[0].Title != "sooner" || ranked[1].Title != "later" {
+		t.Fatalf("without a location challenges should be ordered by time, got %+v", ranked)
+	}
+	if ranked[1].DistanceKm != nil {
+		t.Errorf("distance computed without a location: %v", *ranked[1].DistanceKm)
+	}
+}
+
+func TestGetMatchmakingChallengesFiltersAndRanks(t *testing.T) {
+	db := newTestDB(t)
+	mc := newTestController(t, db)
+
+	viewer := testutil.CreateUser(t, db, "Viewer")
+	if err := db.Model(viewer).Update("coordinates", models.Coordinates{Latitude: 0.0001}).Error; err != nil {
+		t.Fatalf("failed to set location: %v", err)
+	}
+	other := testutil.CreateUser(t, db, "Other")
+
+	football := createSport(t, db)
+	tennis := createSport(t, db)
+	if err := db.Create(&sport.UserSport{UserID: viewer.ID, SportID: football.ID, Level: "Intermediate"}).Error; err != nil {
+		t.Fatalf("failed to add user sport: %v", err)
+	}
+
+	otherTeam := createTeam(t, db, football.ID, other.ID)
+	viewerTeam := createTeam(t, db, football.ID, viewer.ID)
+	nearVenue := createVenue(t, db, other.ID, 0.1, 0)
+	farVenue := createVenue(t, db, other.ID, 1, 0)
+
+	withVenue := func(v *venue.Venue) func(*Challenge) {
+		return func(ch *Challenge) { ch.VenueID = &v.ID }
+	}
+	at := func(d time.Duration) func(*Challenge) {
+		return func(ch *Challenge) { ch.ProposedDateTime = time.Now().Add(d).Truncate(time.Second) }
+	}
+
+	far := createChallenge(t, db, football.ID, other.ID, otherTeam, withVenue(farVenue), at(24*time.Hour))
+	near := createChallenge(t, db, football.ID, other.ID, otherTeam, withVenue(nearVenue), at(72*time.Hour))
+	anywhere := createChallenge(t, db, football.ID, other.ID, otherTeam, at(12*time.Hour))
+
+	// None of these should be suggested
+	createChallenge(t, db, football.ID, viewer.ID, nil)
+	createChallenge(t, db, football.ID, other.ID, viewerTeam)
+	createChallenge(t, db, tennis.ID, other.ID, nil)
+	createChallenge(t, db, football.ID, other.ID, otherTeam, at(-time.Hour))
+	createChallenge(t, db, football.ID, other.ID, otherTeam, func(ch *Challenge) {
+		expired := time.Now().Add(-time.Minute)
+		ch.ExpiresAt = &expired
+	})
+	createChallenge(t, db, football.ID, other.ID, otherTeam, func(ch *Challenge) { ch.Status = StatusAccepted })
+	createChallenge(t, db, football.ID, other.ID, otherTeam, func(ch *Challenge) {
+		ch.ChallengeType = DirectChallengeTeam
+		ch.ReceiverTeamID = &viewerTeam.ID
+	})
+	createChallenge(t, db, football.ID, other.ID, otherTeam, func(ch *Challenge) { ch.MinSkillLevel = "advanced" })
+
+	r := gin.New()
+	r.GET("/matches/challenges/matchmaking", asUser(viewer.ID), mc.GetMatchmakingChallenges)
+
+	w := testutil.Request(t, r, http.MethodGet, "/matches/challenges/matchmaking", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	var page struct {
+		Items      []MatchmakingChallenge `json:"items"`
+		Pagination struct {
+			TotalItems int64 `json:"total_items"`
+		} `json:"pagination"`
+	}
+	testutil.DecodeData(t, w, &page)
+
+	want := []uint{near.ID, far.ID, anywhere.ID}
+	if len(page.Items) != len(want) {
+		t.Fatalf("got %d challenges, want %d: %+v", len(page.Items), len(want), page.Items)
+	}
+	for i, id := range want {
+		if page.Items[i].ID != id {
+			t.Errorf("position %d = challenge %d, want %d", i, page.Items[i].ID, id)
+		}
+	}
+	if page.Pagination.TotalItems != int64(len(want)) {
+		t.Errorf("total_items = %d, want %d", page.Pagination.TotalItems, len(want))
+	}
+	if page.Items[2].DistanceKm != nil {
+		t.Errorf("challenge without a venue has distance %v", *page.Items[2].DistanceKm)
+	}
+
+	// An explicit skill level overrides the profile
+	w = testutil.Request(t, r, http.MethodGet, "/matches/challenges/matchmaking?skill_level=advanced", nil)
+	testutil.DecodeData(t, w, &page)
+	if len(page.Items) != len(want)+1 {
+		t.Errorf("with skill_level=advanced got %d challenges, want %d", len(page.Items), len(want)+1)
+	}
+
+	w = testutil.Request(t, r, http.MethodGet, "/matches/challenges/matchmaking?skill_level=expert", nil)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unknown skill level status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	// The candidate cap keeps the soonest challenges
+	candidates, err := NewGormMatchRepository(db).GetMatchmakingCandidates(viewer.ID, []uint{football.ID}, nil, 2)
+	if err != nil {
+		t.Fatalf("GetMatchmakingCandidates: %v", err)
+	}
+	if len(candidates) != 2 || candidates[0].ID != anywhere.ID || candidates[1].ID != far.ID {
+		t.Errorf("capped candidates = %+v, want challenges %d and %d", candidates, anywhere.ID, far.ID)
+	}
+
+	// Challenges sent by a team that blocks the viewer are left out
+	candidates, err = NewGormMatchRepository(db).GetMatchmakingCandidates(viewer.ID, []uint{football.ID}, []uint{otherTeam.ID}, 0)
+	if err != nil {
+		t.Fatalf("GetMatchmakingCandidates: %v", err)
+	}
+	for _, ch := range candidates {
+		if ch.SenderTeamID != nil && *ch.SenderTeamID == otherTeam.ID {
+			t.Errorf("challenge %d from a blocking team was suggested", ch.ID)
+		}
+	}
+}
@@ -1,11 +1,10 @@
 package middleware
 
 import (
-	"errors"
-	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/DhavalSuthar-24/miow/pkg/response"
 	"github.com/DhavalSuthar-24/miow/pkg/token"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -13,61 +12,83 @@ import (
 
 const (
 	AuthUserIDKey = "auth_user_id"
+	// ImpersonatorIDKey holds the admin's user ID when the request uses an impersonation token
+	ImpersonatorIDKey = "auth_impersonator_id"
 )
 
 func AuthMiddleware(jwtSecret string, db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
+			response.Error(c, http.StatusUnauthorized, "Authorization header is required")
 			return
 		}
 
 		bearerToken := strings.Split(authHeader, " ")
 		if len(bearerToken) != 2 || strings.ToLower(bearerToken[0]) != "bearer" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid Authorization header format. Expected: Bearer <token>"})
+			response.Error(c, http.StatusUnauthorized, "Invalid Authorization header format. Expected: Bearer <token>")
 			return
 		}
 
 		jwtToken, err := token.ValidateToken(bearerToken[1], jwtSecret)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token: " + err.Error()})
+			response.Error(c, http.StatusUnauthorized, "Invalid or expired token: "+err.Error())
 			return
 		}
 
 		if !jwtToken.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			response.Error(c, http.StatusUnauthorized, "Invalid token")
 			return
 		}
 
 		userID, err := token.ExtractUserID(jwtToken)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Could not extract user ID from token: " + err.Error()})
+			response.Error(c, http.StatusUnauthorized, "Could not extract user ID from token: "+err.Error())
 			return
 		}
 
 		var exists bool
 		if err := db.Table("users").Select("1").Where("id = ? AND deleted_at IS NULL", userID).Scan(&exists).Error; err != nil || !exists {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "User not found or inactive"})
+			response.Error(c, http.StatusUnauthorized, "User not found or inactive")
+			return
+		}
+
+		if adminID, ok := token.ExtractImpersonatorID(jwtToken); ok {
+			Logger(c).Info("impersonated request", "admin_id", adminID, "user_id", userID,
+				"method", c.Request.Method, "path", c.Request.URL.Path)
+			c.Set(ImpersonatorIDKey, adminID)
+		}
+
+		roles, err := loadUserRoles(db, userID)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, "Could not load user roles")
 			return
 		}
 
 		c.Set(AuthUserIDKey, userID)
+		c.Set(UserRolesKey, roles)
 		c.Next()
 	}
 }
 
-// GetUserIDFromContext extracts the user ID from the context
-func GetUserIDFromContext(c *gin.Context) (uint, error) {
+// CurrentUserID returns the authenticated user's ID set by AuthMiddleware. The second
+// value is false when the request has not been authenticated.
+func CurrentUserID(c *gin.Context) (uint, bool) {
 	userID, exists := c.Get(AuthUserIDKey)
 	if !exists {
-		return 0, errors.New("user ID not found in context")
+		return 0, false
 	}
-
 	uid, ok := userID.(uint)
-	if !ok {
-		return 0, fmt.Errorf("user ID has unexpected type: %T", userID)
-	}
+	return uid, ok
+}
 
-	return uid, nil
+// GetImpersonatorIDFromContext returns the admin's user ID if the request is being made with an
+// impersonation token
+func GetImpersonatorIDFromContext(c *gin.Context) (uint, bool) {
+	adminID, exists := c.Get(ImpersonatorIDKey)
+	if !exists {
+		return 0, false
+	}
+	uid, ok := adminID.(uint)
+	return uid, ok
 }
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+)
+
+type rateLimitEntry struct {
+	count   int
+	resetAt time.Time
+}
+
+// rateLimiter is a simple in-memory fixed-window limiter keyed by an arbitrary string.
+type rateLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	window  time.Duration
+	entries map[string]*rateLimitEntry
+}
+
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:   limit,
+		window:  window,
+		entries: make(map[string]*rateLimitEntry),
+	}
+}
+
+// allow records a hit for key and reports whether it is within the limit,
+// along with the time remaining until the current window resets.
+func (rl *rateLimiter) allow(key string) (bool, time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	entry, ok := rl.entries[key]
+	if !ok || now.After(entry.resetAt) {
+		// Opportunistically drop stale entries so the map does not grow unbounded
+		for k, e := range rl.entries {
+			if now.After(e.resetAt) {
+				delete(rl.entries, k)
+			}
+		}
+		rl.entries[key] = &rateLimitEntry{count: 1, resetAt: now.Add(rl.window)}
+		return true, rl.window
+	}
+
+	if entry.count >= rl.limit {
+		return false, entry.resetAt.Sub(now)
+	}
+	entry.count++
+	return true, entry.resetAt.Sub(now)
+}
+
+// RateLimiter limits hits per arbitrary key. Handlers use it when the limit applies to
+// something other than the client IP, such as an email address from the request body.
+type RateLimiter struct {
+	rl *rateLimiter
+}
+
+// NewRateLimiter allows `limit` hits per key in each `window`
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{rl: newRateLimiter(limit, window)}
+}
+
+// Allow records a hit for key and reports whether it is within the limit
+func (l *RateLimiter) Allow(key string) bool {
+	allowed, _ := l.rl.allow(key)
+	return allowed
+}
@@ -0,0 +1,196 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/config"
+	"github.com/DhavalSuthar-24/miow/pkg/cache"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/DhavalSuthar-24/miow/pkg/token"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// RateLimit configures a token bucket: a client may make Burst requests at once, and the
+// bucket refills at RequestsPerMinute.
+type RateLimit struct {
+	RequestsPerMinute int
+	Burst             int
+}
+
+func (l RateLimit) tokensPerSecond() float64 {
+	return float64(l.RequestsPerMinute) / 60
+}
+
+// bucketStore takes one token from the bucket stored under key
+type bucketStore interface {
+	take(ctx context.Context, key string, limit RateLimit) (bool, time.Duration, error)
+}
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// memoryBucketStore keeps buckets in process memory. Limits are per instance.
+type memoryBucketStore struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+}
+
+func newMemoryBucketStore() *memoryBucketStore {
+	return &memoryBucketStore{buckets: make(map[string]*tokenBucket), now: time.Now}
+}
+
+func (s *memoryBucketStore) take(ctx context.Context, key string, limit RateLimit) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	rate := limit.tokensPerSecond()
+	bucket, ok := s.buckets[key]
+	if !ok {
+		// Opportunistically drop buckets that have refilled completely; they are
+		// indistinguishable from new ones
+		fullAfter := time.Duration(float64(limit.Burst) / rate * float64(time.Second))
+		for k, b := range s.buckets {
+			if now.Sub(b.updatedAt) > fullAfter {
+				delete(s.buckets, k)
+			}
+		}
+		bucket = &tokenBucket{tokens: float64(limit.Burst), updatedAt: now}
+		s.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(float64(limit.Burst), bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*rate)
+	bucket.updatedAt = now
+	if bucket.tokens < 1 {
+		return false, time.Duration((1 - bucket.tokens) / rate * float64(time.Second)), nil
+	}
+	bucket.tokens--
+	return true, 0, nil
+}
+
+// tokenBucketScript atomically refills and takes from a bucket stored as a Redis hash.
+// It returns {allowed, retry_after_ms}.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1]) or burst
+local ts = tonumber(bucket[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - ts) / 1000 * rate)
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate * 1000)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return {allowed, retry}
+`)
+
+// redisBucketStore keeps buckets in Redis so every instance shares the same limits. When
+// Redis cannot be reached it falls back to per-instance buckets.
+type redisBucketStore struct {
+	client   *redis.Client
+	fallback *memoryBucketStore
+	// unavailable is set while Redis is failing, so an outage is logged once rather than on
+	// every request
+	unavailable atomic.Bool
+}
+
+func (s *redisBucketStore) take(ctx context.Context, key string, limit RateLimit) (bool, time.Duration, error) {
+	res, err := tokenBucketScript.Run(ctx, s.client, []string{"ratelimit:" + key},
+		limit.tokensPerSecond(), limit.Burst, time.Now().UnixMilli()).Int64Slice()
+	if err == nil && len(res) != 2 {
+		err = fmt.Errorf("unexpected script result %v", res)
+	}
+	if err != nil {
+		if s.unavailable.CompareAndSwap(false, true) {
+			slog.WarnContext(ctx, "Rate limit: Redis unavailable, using in-memory buckets", "error", err)
+		}
+		return s.fallback.take(ctx, key, limit)
+	}
+	if s.unavailable.CompareAndSwap(true, false) {
+		slog.InfoContext(ctx, "Rate limit: Redis available again")
+	}
+	return res[0] == 1, time.Duration(res[1]) * time.Millisecond, nil
+}
+
+// TokenBucketLimiter rate limits requests per user when the request carries a valid access
+// token and per client IP otherwise. Buckets live in Redis when a cache is configured so the
+// limits hold across instances.
+type TokenBucketLimiter struct {
+	store     bucketStore
+	enabled   bool
+	jwtSecret string
+}
+
+// NewTokenBucketLimiter creates a limiter from the rate limit configuration
+func NewTokenBucketLimiter(appConfig *config.Config) *TokenBucketLimiter {
+	limiter := &TokenBucketLimiter{
+		store:     newMemoryBucketStore(),
+		enabled:   appConfig.RateLimit.Enabled,
+		jwtSecret: appConfig.JWT.AccessTokenSecret,
+	}
+	if rc, ok := cache.Shared(appConfig).(*cache.RedisCache); ok {
+		limiter.store = &redisBucketStore{client: rc.Client(), fallback: newMemoryBucketStore()}
+	}
+	return limiter
+}
+
+// Limit returns middleware applying limit to the routes it is attached to. Buckets are kept
+// per scope, so the same client has separate budgets for differently scoped routes.
+func (l *TokenBucketLimiter) Limit(scope string, limit RateLimit) gin.HandlerFunc {
+	if !l.enabled || limit.RequestsPerMinute <= 0 || limit.Burst <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return func(c *gin.Context) {
+		allowed, retryAfter, err := l.store.take(c.Request.Context(), scope+":"+l.clientKey(c), limit)
+		if err != nil {
+			// Never fail a request because the limiter is broken
+			Logger(c).Error("Rate limit failed", "scope", scope, "error", err)
+			c.Next()
+			return
+		}
+		if !allowed {
+			c.Header("Retry-After", fmt.Sprintf("%d", int(math.Ceil(retryAfter.Seconds()))))
+			response.Error(c, http.StatusTooManyRequests, "Too many requests, please try again later")
+			return
+		}
+		c.Next()
+	}
+}
+
+// clientKey identifies the caller: the authenticated user, or the user in a valid bearer
+// token when the limiter runs before AuthMiddleware, or else the client IP
+func (l *TokenBucketLimiter) clientKey(c *gin.Context) string {
+	if userID, ok := CurrentUserID(c); ok {
+		return fmt.Sprintf("user:%d", userID)
+	}
+
+	parts := strings.Split(c.GetHeader("Authorization"), " ")
+	if len(parts) == 2 && strings.EqualFold(parts[0], "bearer") {
+		if jwtToken, err := token.ValidateToken(parts[1], l.jwtSecret); err == nil && jwtToken.Valid {
+			if userID, err := token.ExtractUserID(jwtToken); err == nil {
+				return fmt.Sprintf("user:%d", userID)
+			}
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
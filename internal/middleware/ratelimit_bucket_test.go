@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestBucketStore returns a memory store whose clock only moves when advance is called
+func newTestBucketStore() (*memoryBucketStore, func(time.Duration)) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := newMemoryBucketStore()
+	store.now = func() time.Time { return now }
+	return store, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestMemoryBucketStoreTake(t *testing.T) {
+	type step struct {
+		advance     time.Duration
+		wantAllowed bool
+		wantRetry   time.Duration
+	}
+	tests := []struct {
+		name  string
+		limit RateLimit
+		steps []step
+	}{
+		{
+			name:  "burst exhausted",
+			limit: RateLimit{RequestsPerMinute: 60, Burst: 3},
+			steps: []step{
+				{wantAllowed: true},
+				{wantAllowed: true},
+				{wantAllowed: true},
+				{wantAllowed: false, wantRetry: time.Second},
+			},
+		},
+		{
+			name:  "partial refill",
+			limit: RateLimit{RequestsPerMinute: 60, Burst: 2},
+			steps: []step{
+				{wantAllowed: true},
+				{wantAllowed: true},
+				{advance: 400 * time.Millisecond, wantAllowed: false, wantRetry: 600 * time.Millisecond},
+				{advance: 600 * time.Millisecond, wantAllowed: true},
+				{wantAllowed: false, wantRetry: time.Second},
+			},
+		},
+		{
+			name:  "slow refill",
+			limit: RateLimit{RequestsPerMinute: 30, Burst: 1},
+			steps: []step{
+				{wantAllowed: true},
+				{wantAllowed: false, wantRetry: 2 * time.Second},
+				{advance: 1500 * time.Millisecond, wantAllowed: false, wantRetry: 500 * time.Millisecond},
+				{advance: 500 * time.Millisecond, wantAllowed: true},
+			},
+		},
+		{
+			name:  "refill capped at burst",
+			limit: RateLimit{RequestsPerMinute: 60, Burst: 2},
+			steps: []step{
+				{wantAllowed: true},
+				{advance: time.Hour, wantAllowed: true},
+				{wantAllowed: true},
+				{wantAllowed: false, wantRetry: time.Second},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, advance := newTestBucketStore()
+			for i, s := range tt.steps {
+				advance(s.advance)
+				allowed, retry, err := store.take(context.Background(), "client", tt.limit)
+				if err != nil {
+					t.Fatalf("step %d: take() error = %v", i, err)
+				}
+				if allowed != s.wantAllowed || (retry-s.wantRetry).Abs() > time.Millisecond {
+					t.Fatalf("step %d: take() = %v, %s; want %v, %s", i, allowed, retry, s.wantAllowed, s.wantRetry)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryBucketStoreKeysAreIndependent(t *testing.T) {
+	store, _ := newTestBucketStore()
+	limit := RateLimit{RequestsPerMinute: 60, Burst: 1}
+	for _, key := range []string{"login:ip:1", "login:ip:2", "register:ip:1"} {
+		if allowed, _, _ := store.take(context.Background(), key, limit); !allowed {
+			t.Errorf("first request for %s was limited", key)
+		}
+	}
+}
+
+func TestTokenBucketLimiterRetryAfter(t *testing.T) {
+	tests := []struct {
+		name           string
+		limit          RateLimit
+		wantRetryAfter string
+	}{
+		{"whole seconds", RateLimit{RequestsPerMinute: 60, Burst: 1}, "1"},
+		{"rounded up", RateLimit{RequestsPerMinute: 40, Burst: 1}, "2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, _ := newTestBucketStore()
+			limiter := &TokenBucketLimiter{store: store, enabled: true}
+			r := gin.New()
+			r.GET("/", limiter.Limit("test", tt.limit), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			for i, want := range []int{http.StatusOK, http.StatusTooManyRequests} {
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+				if w.Code != want {
+					t.Fatalf("request %d: status %d, want %d", i+1, w.Code, want)
+				}
+				if want == http.StatusTooManyRequests {
+					if got := w.Header().Get("Retry-After"); got != tt.wantRetryAfter {
+						t.Errorf("Retry-After = %q, want %q", got, tt.wantRetryAfter)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRedisBucketStoreLogsOutageOnce(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	// Nothing listens on port 1, so every call fails straight away
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: time.Second})
+	t.Cleanup(func() { client.Close() })
+	store := &redisBucketStore{client: client, fallback: newMemoryBucketStore()}
+
+	limit := RateLimit{RequestsPerMinute: 60, Burst: 2}
+	for i, want := range []bool{true, true, false} {
+		allowed, _, err := store.take(context.Background(), "client", limit)
+		if err != nil || allowed != want {
+			t.Fatalf("request %d: take() = %v, %v; want %v from the in-memory fallback", i+1, allowed, err, want)
+		}
+	}
+	if n := strings.Count(logs.String(), "Redis unavailable"); n != 1 {
+		t.Errorf("outage logged %d times, want once:\n%s", n, logs.String())
+	}
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// perClientLimit allows each client a single request
+func perClientLimit() gin.HandlerFunc {
+	limiter := &TokenBucketLimiter{store: newMemoryBucketStore(), enabled: true}
+	return limiter.Limit("test", RateLimit{RequestsPerMinute: 1, Burst: 1})
+}
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// TestRateLimitIgnoresUntrustedForwardedFor checks that, with no trusted proxies, rotating
+// X-Forwarded-For does not give a client a fresh limit
+func TestRateLimitIgnoresUntrustedForwardedFor(t *testing.T) {
+	r := gin.New()
+	if err := r.SetTrustedProxies(nil); err != nil {
+		t.Fatal(err)
+	}
+	r.GET("/", perClientLimit(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for i, forwardedFor := range []string{"203.0.113.1", "203.0.113.2"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "198.51.100.7:5000"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		want := http.StatusOK
+		if i > 0 {
+			want = http.StatusTooManyRequests
+		}
+		if w.Code != want {
+			t.Fatalf("request %d with X-Forwarded-For %s: status %d, want %d", i+1, forwardedFor, w.Code, want)
+		}
+	}
+}
+
+// TestRateLimitUsesTrustedProxyForwardedFor checks that clients behind a trusted proxy are
+// limited separately
+func TestRateLimitUsesTrustedProxyForwardedFor(t *testing.T) {
+	r := gin.New()
+	if err := r.SetTrustedProxies([]string{"198.51.100.0/24"}); err != nil {
+		t.Fatal(err)
+	}
+	r.GET("/", perClientLimit(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, forwardedFor := range []string{"203.0.113.1", "203.0.113.2"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "198.51.100.7:5000"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("client %s behind trusted proxy: status %d, want %d", forwardedFor, w.Code, http.StatusOK)
+		}
+	}
+}
+
+func TestRateLimiterLimitsEachKey(t *testing.T) {
+	limiter := NewRateLimiter(2, time.Minute)
+	for i, want := range []bool{true, true, false} {
+		if got := limiter.Allow("sam@example.com"); got != want {
+			t.Fatalf("hit %d for the same key: Allow() = %v, want %v", i+1, got, want)
+		}
+	}
+	if !limiter.Allow("alex@example.com") {
+		t.Error("another key shared the exhausted limit")
+	}
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// logLines decodes the JSON log records written to buf
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("log line %q is not JSON: %v", line, err)
+		}
+		lines = append(lines, record)
+	}
+	return lines
+}
+
+func TestRequestLoggerTagsLogsWithRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		sent     string
+		keepSent bool
+	}{
+		{"generated", "", false},
+		{"client supplied", "trace-42.a_b", true},
+		{"malformed client ID replaced", "bad id\nwith newline", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			r := gin.New()
+			r.Use(RequestLogger(slog.New(slog.NewJSONHandler(&buf, nil))))
+			r.GET("/teams/:id", func(c *gin.Context) {
+				c.Set(AuthUserIDKey, uint(7))
+				Logger(c).Info("loading team")
+				c.Status(http.StatusNotFound)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/teams/3", nil)
+			if tt.sent != "" {
+				req.Header.Set(RequestIDHeader, tt.sent)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			requestID := w.Header().Get(RequestIDHeader)
+			if requestID == "" {
+				t.Fatal("response has no request ID header")
+			}
+			if tt.keepSent != (requestID == tt.sent) {
+				t.Errorf("request ID = %q after sending %q", requestID, tt.sent)
+			}
+
+			lines := logLines(t, &buf)
+			if len(lines) != 2 {
+				t.Fatalf("got %d log lines, want the handler's and the access log:\n%s", len(lines), buf.String())
+			}
+			for _, line := range lines {
+				if line["request_id"] != requestID {
+					t.Errorf("log line %v is not tagged with request ID %s", line, requestID)
+				}
+				if line["user_id"] != float64(7) {
+					t.Errorf("log line %v is not tagged with the user", line)
+				}
+			}
+			access := lines[1]
+			if access["method"] != "GET" || access["path"] != "/teams/3" || access["status"] != float64(http.StatusNotFound) ||
+				access["level"] != "WARN" || access["latency_ms"] == nil {
+				t.Errorf("access log = %v, want method, path, status, latency at warn level", access)
+			}
+		})
+	}
+}
+
+func TestLoggerOutsideRequestLogger(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	if Logger(c) == nil {
+		t.Fatal("Logger() returned nil without RequestLogger")
+	}
+	if RequestID(c) != "" {
+		t.Errorf("RequestID() = %q without RequestLogger, want empty", RequestID(c))
+	}
+}
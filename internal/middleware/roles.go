@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/user"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// UserRolesKey holds the authenticated user's role names, set by AuthMiddleware
+const UserRolesKey = "currentUserRoles"
+
+// roleCacheTTL bounds how long a user's roles are served from memory, and so how long a
+// role change can take to be picked up. The cache is per process: InvalidateUserRoles only
+// clears the instance that handled the change, so when several instances run behind a load
+// balancer the others keep serving the old roles until their entry expires.
+const roleCacheTTL = 30 * time.Second
+
+type roleCacheEntry struct {
+	roles     []string
+	expiresAt time.Time
+}
+
+// roleCache keeps recently loaded role lists so that authenticated requests do not
+// each need a roles query.
+type roleCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[uint]roleCacheEntry
+
+	hits          int64
+	misses        int64
+	invalidations int64
+}
+
+// RoleCacheStats describes the role cache, for debugging
+type RoleCacheStats struct {
+	Entries       int     `json:"entries"`
+	TTLSeconds    float64 `json:"ttl_seconds"`
+	Hits          int64   `json:"hits"`
+	Misses        int64   `json:"misses"`
+	Invalidations int64   `json:"invalidations"`
+}
+
+var userRoleCache = newRoleCache(roleCacheTTL)
+
+func newRoleCache(ttl time.Duration) *roleCache {
+	return &roleCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[uint]roleCacheEntry),
+	}
+}
+
+func (rc *roleCache) get(userID uint) ([]string, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[userID]
+	if !ok || rc.now().After(entry.expiresAt) {
+		rc.misses++
+		return nil, false
+	}
+	rc.hits++
+	return entry.roles, true
+}
+
+func (rc *roleCache) set(userID uint, roles []string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	now := rc.now()
+	// Opportunistically drop stale entries so the map does not grow unbounded
+	for id, e := range rc.entries {
+		if now.After(e.expiresAt) {
+			delete(rc.entries, id)
+		}
+	}
+	rc.entries[userID] = roleCacheEntry{roles: roles, expiresAt: now.Add(rc.ttl)}
+}
+
+func (rc *roleCache) invalidate(userID uint) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	delete(rc.entries, userID)
+	rc.invalidations++
+}
+
+func (rc *roleCache) stats() RoleCacheStats {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	return RoleCacheStats{
+		Entries:       len(rc.entries),
+		TTLSeconds:    rc.ttl.Seconds(),
+		Hits:          rc.hits,
+		Misses:        rc.misses,
+		Invalidations: rc.invalidations,
+	}
+}
+
+// InvalidateUserRoles drops a user's cached roles. Call it whenever roles are assigned to or
+// removed from the user so the change applies to their next request.
+func InvalidateUserRoles(userID uint) {
+	userRoleCache.invalidate(userID)
+}
+
+// GetRoleCacheStats returns a snapshot of the role cache counters
+func GetRoleCacheStats() RoleCacheStats {
+	return userRoleCache.stats()
+}
+
+// loadUserRoles returns the names of the roles assigned to a user, using the cache when possible
+func loadUserRoles(db *gorm.DB, userID uint) ([]string, error) {
+	if roles, ok := userRoleCache.get(userID); ok {
+		return roles, nil
+	}
+
+	roles := []string{}
+	err := db.Model(&user.UserRole{}).
+		Joins("JOIN roles ON user_roles.role_id = roles.id AND roles.deleted_at IS NULL").
+		Where("user_roles.user_id = ?", userID).
+		Pluck("roles.name", &roles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	userRoleCache.set(userID, roles)
+	return roles, nil
+}
+
+// CurrentUserRoles returns the authenticated user's role names, or nil if none were loaded
+func CurrentUserRoles(c *gin.Context) []string {
+	rolesVal, exists := c.Get(UserRolesKey)
+	if !exists {
+		return nil
+	}
+	roles, _ := rolesVal.([]string)
+	return roles
+}
+
+// HasRole reports whether the authenticated user holds the given role
+func HasRole(c *gin.Context, role string) bool {
+	return HasAnyRole(c, role)
+}
+
+// HasAnyRole reports whether the authenticated user holds at least one of the given roles.
+// Role names are compared case-insensitively.
+func HasAnyRole(c *gin.Context, roles ...string) bool {
+	for _, r := range CurrentUserRoles(c) {
+		for _, role := range roles {
+			if strings.EqualFold(r, role) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RequireRole restricts a route to users holding the given role
+func RequireRole(role string) gin.HandlerFunc {
+	return RequireAnyRole(role)
+}
+
+// RequireAnyRole restricts a route to users holding at least one of the given roles. It reads
+// the roles loaded by AuthMiddleware, so it must run after it.
+func RequireAnyRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := CurrentUserID(c); !ok {
+			response.Error(c, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+		if !HasAnyRole(c, roles...) {
+			response.ErrorWithDetails(c, http.StatusForbidden, "Forbidden", gin.H{"required_roles": roles})
+			return
+		}
+		c.Next()
+	}
+}
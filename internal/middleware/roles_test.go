@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/pkg/token"
+	"github.com/gin-gonic/gin"
+)
+
+func TestRoleCacheHitExpiryAndInvalidate(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	rc := newRoleCache(30 * time.Second)
+	rc.now = func() time.Time { return now }
+
+	if _, ok := rc.get(1); ok {
+		t.Fatal("empty cache reported a hit")
+	}
+
+	rc.set(1, []string{"admin"})
+	if roles, ok := rc.get(1); !ok || !reflect.DeepEqual(roles, []string{"admin"}) {
+		t.Fatalf("get() = %v, %v; want the cached roles", roles, ok)
+	}
+
+	now = now.Add(30 * time.Second)
+	if _, ok := rc.get(1); !ok {
+		t.Error("entry expired before its TTL elapsed")
+	}
+	now = now.Add(time.Nanosecond)
+	if _, ok := rc.get(1); ok {
+		t.Error("entry served after its TTL elapsed")
+	}
+
+	rc.set(1, []string{"admin"})
+	rc.invalidate(1)
+	if _, ok := rc.get(1); ok {
+		t.Error("entry served after being invalidated")
+	}
+
+	stats := rc.stats()
+	if stats.Hits != 2 || stats.Misses != 3 || stats.Invalidations != 1 || stats.Entries != 0 {
+		t.Errorf("stats = %+v, want 2 hits, 3 misses, 1 invalidation and no entries", stats)
+	}
+}
+
+func TestRoleCacheSetDropsExpiredEntries(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	rc := newRoleCache(time.Second)
+	rc.now = func() time.Time { return now }
+
+	rc.set(1, []string{"admin"})
+	now = now.Add(2 * time.Second)
+	rc.set(2, []string{"user"})
+
+	if entries := rc.stats().Entries; entries != 1 {
+		t.Errorf("cache holds %d entries, want only the fresh one", entries)
+	}
+}
+
+func TestRequireAnyRole(t *testing.T) {
+	tests := []struct {
+		name   string
+		auth   bool
+		roles  []string
+		status int
+	}{
+		{"no user", false, nil, http.StatusUnauthorized},
+		{"no roles", true, nil, http.StatusForbidden},
+		{"wrong role", true, []string{"player"}, http.StatusForbidden},
+		{"one of the roles", true, []string{"player", "venue_manager"}, http.StatusOK},
+		{"different case", true, []string{"ADMIN"}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reached := false
+			r := gin.New()
+			r.GET("/", func(c *gin.Context) {
+				if tt.auth {
+					c.Set(AuthUserIDKey, uint(7))
+					c.Set(UserRolesKey, tt.roles)
+				}
+			}, RequireAnyRole("admin", "Venue_Manager"), func(c *gin.Context) {
+				reached = true
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if reached != (tt.status == http.StatusOK) {
+				t.Errorf("handler reached = %v with status %d", reached, w.Code)
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewareLoadsRolesForAdminRoutes(t *testing.T) {
+	db := testutil.DB(t, testutil.UserModels...)
+	admin := testutil.CreateUser(t, db, "Admin")
+	player := testutil.CreateUser(t, db, "Player")
+	testutil.GrantRole(t, db, admin.ID, "admin")
+	// IDs repeat across test schemas, so start from an empty cache for these users
+	InvalidateUserRoles(admin.ID)
+	InvalidateUserRoles(player.ID)
+
+	const secret = "test-secret"
+	r := gin.New()
+	r.GET("/admin", AuthMiddleware(secret, db), RequireRole("admin"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	get := func(userID uint) int {
+		t.Helper()
+		jwt, err := token.GenerateJWT(userID, secret, 5)
+		if err != nil {
+			t.Fatalf("GenerateJWT() error = %v", err)
+		}
+		req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+		req.Header.Set("Authorization", "Bearer "+jwt)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := get(admin.ID); code != http.StatusOK {
+		t.Errorf("admin status = %d, want %d", code, http.StatusOK)
+	}
+	if code := get(player.ID); code != http.StatusForbidden {
+		t.Errorf("player status = %d, want %d", code, http.StatusForbidden)
+	}
+
+	// A new role is picked up once the cached roles are invalidated
+	testutil.GrantRole(t, db, player.ID, "admin")
+	InvalidateUserRoles(player.ID)
+	if code := get(player.ID); code != http.StatusOK {
+		t.Errorf("promoted player status = %d, want %d", code, http.StatusOK)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"math"
 
 	"gorm.io/gorm"
 )
@@ -26,6 +27,28 @@ type SocialMedia struct {
 	Twitter   string `json:"twitter"`
 }
 
+// ParseCoordinates decodes a JSON coordinates column, such as a venue's. It reports false
+// when the value is empty, malformed or left at 0,0.
+func ParseCoordinates(raw string) (Coordinates, bool) {
+	var coords Coordinates
+	if raw == "" || json.Unmarshal([]byte(raw), &coords) != nil {
+		return coords, false
+	}
+	return coords, coords.Latitude != 0 || coords.Longitude != 0
+}
+
+// DistanceKm returns the great-circle distance between two points using the haversine formula
+func DistanceKm(a, b Coordinates) float64 {
+	const earthRadiusKm = 6371.0
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(b.Latitude - a.Latitude)
+	dLon := toRad(b.Longitude - a.Longitude)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(a.Latitude))*math.Cos(toRad(b.Latitude))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
 type StringSlice []string
 
 func (s StringSlice) Value() (driver.Value, error) {
@@ -0,0 +1,165 @@
+package notification
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationController handles notification related requests
+type NotificationController struct {
+	repo NotificationRepository
+}
+
+// NewNotificationController creates a new NotificationController
+func NewNotificationController(repo NotificationRepository) *NotificationController {
+	return &NotificationController{repo: repo}
+}
+
+// MarkAllReadResponse reports how many notifications were marked as read
+type MarkAllReadResponse struct {
+	Updated int64 `json:"updated"`
+}
+
+// GetMyNotifications godoc
+// @Summary Get my notifications
+// @Description Retrieves the authenticated user's notifications, newest first.
+// @Tags Notifications
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Param unread query bool false "Only include unread notifications"
+// @Success 200 {object} response.PaginatedResponse{data=response.Page{items=[]Notification}} "List of my notifications"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /notifications [get]
+func (nc *NotificationController) GetMyNotifications(c *gin.Context) {
+	userID, authenticated := middleware.CurrentUserID(c)
+	if !authenticated {
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	unreadOnly, _ := strconv.ParseBool(c.DefaultQuery("unread", "false"))
+
+	notifications, total, err := nc.repo.GetUserNotifications(userID, unreadOnly, page, limit)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve notifications: "+err.Error())
+		return
+	}
+	response.Paginated(c, http.StatusOK, "Notifications retrieved successfully", notifications, total, page, limit)
+}
+
+// MarkNotificationRead godoc
+// @Summary Mark a notification as read
+// @Description Marks one of the authenticated user's notifications as read.
+// @Tags Notifications
+// @Produce json
+// @Param id path uint true "Notification ID"
+// @Success 200 {object} response.SuccessResponse{data=Notification} "Notification marked as read"
+// @Failure 400 {object} response.ErrorResponse "Invalid notification ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Notification not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /notifications/{id}/read [put]
+func (nc *NotificationController) MarkNotificationRead(c *gin.Context) {
+	userID, authenticated := middleware.CurrentUserID(c)
+	if !authenticated {
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid notification ID")
+		return
+	}
+
+	n, err := nc.repo.MarkAsRead(uint(id), userID)
+	if err != nil {
+		if errors.Is(err, ErrNotificationNotFound) {
+			response.Error(c, http.StatusNotFound, "Notification not found")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to update notification: "+err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, "Notification marked as read", n)
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary Mark all notifications as read
+// @Description Marks every unread notification of the authenticated user as read.
+// @Tags Notifications
+// @Produce json
+// @Success 200 {object} response.SuccessResponse{data=MarkAllReadResponse} "Notifications marked as read"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /notifications/read-all [put]
+func (nc *NotificationController) MarkAllNotificationsRead(c *gin.Context) {
+	userID, authenticated := middleware.CurrentUserID(c)
+	if !authenticated {
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	updated, err := nc.repo.MarkAllAsRead(userID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to update notifications: "+err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, "All notifications marked as read", MarkAllReadResponse{Updated: updated})
+}
+
+// MarkNotificationsReadByType godoc
+// @Summary Mark notifications of a type as read
+// @Description Marks every unread notification of the given type of the authenticated user as read.
+// @Tags Notifications
+// @Produce json
+// @Param type query string true "Notification type" Enums(team_invitation, join_request, challenge_accepted, booking_confirmed, match_starting_soon)
+// @Success 200 {object} response.SuccessResponse{data=MarkAllReadResponse} "Notifications marked as read"
+// @Failure 400 {object} response.ErrorResponse "Missing or unknown notification type"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /users/me/notifications/read [post]
+func (nc *NotificationController) MarkNotificationsReadByType(c *gin.Context) {
+	userID, authenticated := middleware.CurrentUserID(c)
+	if !authenticated {
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	notificationType := Type(strings.TrimSpace(c.Query("type")))
+	if notificationType == "" {
+		response.Error(c, http.StatusBadRequest, "Notification type is required")
+		return
+	}
+	if !notificationType.IsValid() {
+		response.Error(c, http.StatusBadRequest, "Unknown notification type: "+string(notificationType))
+		return
+	}
+
+	updated, err := nc.repo.MarkTypeAsRead(userID, notificationType)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to update notifications: "+err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, "Notifications marked as read", MarkAllReadResponse{Updated: updated})
+}
@@ -0,0 +1,60 @@
+package notification
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Type identifies the event a notification is about
+type Type string
+
+const (
+	TypeTeamInvitation    Type = "team_invitation"
+	TypeJoinRequest       Type = "join_request"
+	TypeChallengeAccepted Type = "challenge_accepted"
+	TypeBookingConfirmed  Type = "booking_confirmed"
+	TypeMatchStartingSoon Type = "match_starting_soon"
+)
+
+// knownTypes is every type a notification can be created with
+var knownTypes = []Type{
+	TypeTeamInvitation,
+	TypeJoinRequest,
+	TypeChallengeAccepted,
+	TypeBookingConfirmed,
+	TypeMatchStartingSoon,
+}
+
+// IsValid reports whether t is one of the known notification types
+func (t Type) IsValid() bool {
+	for _, known := range knownTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Notification is an in-app message for a user. ResourceType and ResourceID point at the
+// record the notification is about (e.g. "team_invitation", 12) so clients can link to it.
+type Notification struct {
+	gorm.Model
+	UserID       uint       `json:"user_id" gorm:"index;not null"`
+	Type         Type       `json:"type" gorm:"index;not null"`
+	Title        string     `json:"title" gorm:"not null"`
+	Body         string     `json:"body" gorm:"type:text"`
+	ResourceType string     `json:"resource_type,omitempty"`
+	ResourceID   *uint      `json:"resource_id,omitempty"`
+	ReadAt       *time.Time `json:"read_at,omitempty" gorm:"index"`
+}
+
+// Message describes a notification to deliver to a user over every enabled channel
+type Message struct {
+	UserID       uint
+	Type         Type
+	Title        string
+	Body         string
+	ResourceType string
+	ResourceID   uint
+}
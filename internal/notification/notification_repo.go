@@ -0,0 +1,97 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotificationNotFound is returned when a notification does not exist or belongs to another user
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// NotificationRepository defines methods to interact with notification data
+type NotificationRepository interface {
+	CreateNotification(n *Notification) error
+	GetUserNotifications(userID uint, unreadOnly bool, page, pageSize int) ([]Notification, int64, error)
+	MarkAsRead(id, userID uint) (*Notification, error)
+	MarkAllAsRead(userID uint) (int64, error)
+	MarkTypeAsRead(userID uint, notificationType Type) (int64, error)
+	Ping(ctx context.Context) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new NotificationRepository
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) CreateNotification(n *Notification) error {
+	return r.db.Create(n).Error
+}
+
+func (r *notificationRepository) GetUserNotifications(userID uint, unreadOnly bool, page, pageSize int) ([]Notification, int64, error) {
+	var notifications []Notification
+	var total int64
+
+	query := r.db.Model(&Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at desc").Offset(offset).Limit(pageSize).Find(&notifications).Error; err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+// MarkAsRead marks one of the user's notifications as read. Already read notifications keep
+// their original read time.
+func (r *notificationRepository) MarkAsRead(id, userID uint) (*Notification, error) {
+	var n Notification
+	if err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&n).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, err
+	}
+	if n.ReadAt != nil {
+		return &n, nil
+	}
+
+	now := time.Now()
+	if err := r.db.Model(&n).Update("read_at", now).Error; err != nil {
+		return nil, err
+	}
+	n.ReadAt = &now
+	return &n, nil
+}
+
+// MarkAllAsRead marks every unread notification of the user as read and returns how many changed
+func (r *notificationRepository) MarkAllAsRead(userID uint) (int64, error) {
+	result := r.db.Model(&Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now())
+	return result.RowsAffected, result.Error
+}
+
+// MarkTypeAsRead marks the user's unread notifications of one type as read and returns how many changed
+func (r *notificationRepository) MarkTypeAsRead(userID uint, notificationType Type) (int64, error) {
+	result := r.db.Model(&Notification{}).
+		Where("user_id = ? AND type = ? AND read_at IS NULL", userID, notificationType).
+		Update("read_at", time.Now())
+	return result.RowsAffected, result.Error
+}
+
+// Ping checks that the notifications table answers a trivial query
+func (r *notificationRepository) Ping(ctx context.Context) error {
+	return r.db.WithContext(ctx).Exec("SELECT 1 FROM notifications LIMIT 1").Error
+}
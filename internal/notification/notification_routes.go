@@ -0,0 +1,21 @@
+package notification
+
+import (
+	mw "github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func NotificationRoutes(router *gin.RouterGroup, db *gorm.DB, jwtSecret string) {
+	notificationController := NewNotificationController(NewNotificationRepository(db))
+
+	notifications := router.Group("/notifications")
+	notifications.Use(mw.AuthMiddleware(jwtSecret, db))
+	{
+		notifications.GET("", notificationController.GetMyNotifications)
+		notifications.PUT("/read-all", notificationController.MarkAllNotificationsRead)
+		notifications.PUT("/:id/read", notificationController.MarkNotificationRead)
+	}
+
+	router.POST("/users/me/notifications/read", mw.AuthMiddleware(jwtSecret, db), notificationController.MarkNotificationsReadByType)
+}
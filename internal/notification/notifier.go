@@ -0,0 +1,123 @@
+package notification
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/DhavalSuthar-24/miow/config"
+	"github.com/DhavalSuthar-24/miow/internal/user"
+	"gorm.io/gorm"
+)
+
+// Notifier delivers a notification message over one channel
+type Notifier interface {
+	Notify(msg Message) error
+}
+
+// InAppNotifier stores notifications so users can list them through the API
+type InAppNotifier struct {
+	repo NotificationRepository
+}
+
+// NewInAppNotifier creates a new InAppNotifier
+func NewInAppNotifier(repo NotificationRepository) *InAppNotifier {
+	return &InAppNotifier{repo: repo}
+}
+
+func (n *InAppNotifier) Notify(msg Message) error {
+	record := &Notification{
+		UserID:       msg.UserID,
+		Type:         msg.Type,
+		Title:        msg.Title,
+		Body:         msg.Body,
+		ResourceType: msg.ResourceType,
+	}
+	if msg.ResourceID != 0 {
+		resourceID := msg.ResourceID
+		record.ResourceID = &resourceID
+	}
+	return n.repo.CreateNotification(record)
+}
+
+// EmailNotifier emails notifications to the user's address
+type EmailNotifier struct {
+	db *gorm.DB
+}
+
+// NewEmailNotifier creates a new EmailNotifier
+func NewEmailNotifier(db *gorm.DB) *EmailNotifier {
+	return &EmailNotifier{db: db}
+}
+
+// Notify simulates sending the email. Replace with actual email service.
+func (n *EmailNotifier) Notify(msg Message) error {
+	var u user.User
+	if err := n.db.Select("id", "email").First(&u, msg.UserID).Error; err != nil {
+		return fmt.Errorf("failed to look up email for user %d: %w", msg.UserID, err)
+	}
+	slog.Debug("Email delivery not configured, notification not emailed",
+		"user_id", msg.UserID, "to", u.Email, "type", msg.Type)
+
+	// Integrate with your Email provider here
+	return nil
+}
+
+// PushNotifier sends notifications to the user's devices
+type PushNotifier struct{}
+
+// NewPushNotifier creates a new PushNotifier
+func NewPushNotifier() *PushNotifier {
+	return &PushNotifier{}
+}
+
+// Notify simulates sending a push notification. Replace with actual push service.
+func (n *PushNotifier) Notify(msg Message) error {
+	slog.Debug("Push delivery not configured, notification not pushed", "user_id", msg.UserID, "type", msg.Type)
+
+	// Integrate with your push provider (FCM, APNs, ...) here
+	return nil
+}
+
+// Dispatcher fans a message out to every configured channel in the background, so a slow or
+// failing channel never fails or delays the request that triggered the notification.
+type Dispatcher struct {
+	channels []Notifier
+}
+
+// NewDispatcher creates a Dispatcher delivering over the given channels
+func NewDispatcher(channels ...Notifier) *Dispatcher {
+	return &Dispatcher{channels: channels}
+}
+
+// NewDefaultDispatcher creates a Dispatcher with the in-app channel plus the email and push
+// channels enabled in the configuration
+func NewDefaultDispatcher(db *gorm.DB, appConfig *config.Config) *Dispatcher {
+	channels := []Notifier{NewInAppNotifier(NewNotificationRepository(db))}
+	if appConfig != nil && appConfig.Notifications.EmailEnabled {
+		channels = append(channels, NewEmailNotifier(db))
+	}
+	if appConfig != nil && appConfig.Notifications.PushEnabled {
+		channels = append(channels, NewPushNotifier())
+	}
+	return NewDispatcher(channels...)
+}
+
+// Notify queues the message on every channel and returns immediately. Delivery errors are
+// logged, never returned.
+func (d *Dispatcher) Notify(msg Message) error {
+	for _, ch := range d.channels {
+		go func(ch Notifier) {
+			defer func() {
+				if r := recover(); r != nil {
+					slog.Error("Notification channel panicked",
+						"type", msg.Type, "user_id", msg.UserID, "channel", fmt.Sprintf("%T", ch), "panic", r)
+				}
+			}()
+			if err := ch.Notify(msg); err != nil {
+				slog.Error("Notification channel failed",
+					"type", msg.Type, "user_id", msg.UserID, "channel", fmt.Sprintf("%T", ch), "error", err)
+			}
+		}(ch)
+	}
+	return nil
+}
@@ -7,9 +7,8 @@ import (
 
 	"github.com/DhavalSuthar-24/miow/config"
 
-	"github.com/DhavalSuthar-24/miow/internal/middleware"    // Your middleware package
-	responses "github.com/DhavalSuthar-24/miow/pkg/response" // A common responses package (you might need to create this)
-	"github.com/DhavalSuthar-24/miow/pkg/validator"          // A common validator package (you might need to create this)
+	"github.com/DhavalSuthar-24/miow/internal/middleware" // Your middleware package
+	"github.com/DhavalSuthar-24/miow/pkg/response"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
@@ -77,23 +76,22 @@ type UserSportRequest struct {
 // @Accept json
 // @Produce json
 // @Param sport body CreateSportRequest true "Sport creation request"
-// @Success 201 {object} responses.SuccessResponse{data=Sport}
-// @Failure 400 {object} responses.ErrorResponse "Validation error or bad request"
-// @Failure 409 {object} responses.ErrorResponse "Sport with this name already exists"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 201 {object} response.SuccessResponse{data=Sport}
+// @Failure 400 {object} response.ErrorResponse "Validation error or bad request"
+// @Failure 409 {object} response.ErrorResponse "Sport with this name already exists"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /sports [post]
 // @Security BearerAuth
 func (sc *SportController) CreateSport(c *gin.Context) {
 	var req CreateSportRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		errors := validator.ParseError(err)
-		responses.SendError(c, http.StatusBadRequest, "Validation failed", errors)
+		response.ValidationError(c, err)
 		return
 	}
 
 	existingSport, _ := sc.repo.FindSportByName(req.Name)
 	if existingSport != nil {
-		responses.SendError(c, http.StatusConflict, "Sport with this name already exists", nil)
+		response.Error(c, http.StatusConflict, "Sport with this name already exists")
 		return
 	}
 
@@ -112,11 +110,11 @@ func (sc *SportController) CreateSport(c *gin.Context) {
 	}
 
 	if err := sc.repo.CreateSport(&sport); err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to create sport", err.Error())
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to create sport", err.Error())
 		return
 	}
 
-	responses.SendSuccess(c, http.StatusCreated, "Sport created successfully", sport)
+	response.Success(c, http.StatusCreated, "Sport created successfully", sport)
 }
 
 // GetAllSports godoc
@@ -128,8 +126,8 @@ func (sc *SportController) CreateSport(c *gin.Context) {
 // @Param pageSize query int false "Number of items per page" default(10)
 // @Param search query string false "Search term for name or description"
 // @Param is_active query boolean false "Filter by active status (admin only)"
-// @Success 200 {object} responses.PaginatedResponse{data=[]Sport}
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.PaginatedResponse{data=response.Page{items=[]Sport}}
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /sports [get]
 func (sc *SportController) GetAllSports(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -151,11 +149,11 @@ func (sc *SportController) GetAllSports(c *gin.Context) {
 
 	sports, total, err := sc.repo.GetAllSports(page, pageSize, searchTerm, isActiveFilter)
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to retrieve sports", err.Error())
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to retrieve sports", err.Error())
 		return
 	}
 
-	responses.SendPaginated(c, http.StatusOK, "Sports retrieved successfully", sports, total, page, pageSize)
+	response.Paginated(c, http.StatusOK, "Sports retrieved successfully", sports, total, page, pageSize)
 }
 
 // GetSportByID godoc
@@ -164,33 +162,68 @@ func (sc *SportController) GetAllSports(c *gin.Context) {
 // @Tags Sports
 // @Produce json
 // @Param sport_id path int true "Sport ID"
-// @Success 200 {object} responses.SuccessResponse{data=Sport}
-// @Failure 404 {object} responses.ErrorResponse "Sport not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse{data=Sport}
+// @Failure 404 {object} response.ErrorResponse "Sport not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /sports/{sport_id} [get]
 func (sc *SportController) GetSportByID(c *gin.Context) {
 	sportIDStr := c.Param("sport_id")
 	sportID, err := strconv.ParseUint(sportIDStr, 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid sport ID format", nil)
+		response.Error(c, http.StatusBadRequest, "Invalid sport ID format")
 		return
 	}
 
 	sport, err := sc.repo.GetSportByID(uint(sportID))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) || sport == nil { // Check both error and nil sport
-			responses.SendError(c, http.StatusNotFound, "Sport not found", nil)
+			response.Error(c, http.StatusNotFound, "Sport not found")
 			return
 		}
-		responses.SendError(c, http.StatusInternalServerError, "Failed to retrieve sport", err.Error())
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to retrieve sport", err.Error())
 		return
 	}
 	if sport == nil { // Double check after error handling
-		responses.SendError(c, http.StatusNotFound, "Sport not found", nil)
+		response.Error(c, http.StatusNotFound, "Sport not found")
 		return
 	}
 
-	responses.SendSuccess(c, http.StatusOK, "Sport retrieved successfully", sport)
+	response.Success(c, http.StatusOK, "Sport retrieved successfully", sport)
+}
+
+// GetSportPositions godoc
+// @Summary Get the positions of a sport
+// @Description Lists the positions players can take in a sport. Team invitations and join requests must use one of these when the sport defines any.
+// @Tags Sports
+// @Produce json
+// @Param sport_id path int true "Sport ID"
+// @Success 200 {object} response.SuccessResponse{data=[]Position}
+// @Failure 400 {object} response.ErrorResponse "Invalid sport ID"
+// @Failure 404 {object} response.ErrorResponse "Sport not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /sports/{sport_id}/positions [get]
+func (sc *SportController) GetSportPositions(c *gin.Context) {
+	sportID, err := strconv.ParseUint(c.Param("sport_id"), 10, 32)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid sport ID format")
+		return
+	}
+
+	sport, err := sc.repo.GetSportByID(uint(sportID))
+	if err != nil {
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to retrieve sport", err.Error())
+		return
+	}
+	if sport == nil {
+		response.Error(c, http.StatusNotFound, "Sport not found")
+		return
+	}
+
+	positions := sport.Positions
+	if positions == nil {
+		positions = Positions{}
+	}
+	response.Success(c, http.StatusOK, "Sport positions retrieved successfully", positions)
 }
 
 // UpdateSport godoc
@@ -201,38 +234,37 @@ func (sc *SportController) GetSportByID(c *gin.Context) {
 // @Produce json
 // @Param sport_id path int true "Sport ID"
 // @Param sport body UpdateSportRequest true "Sport update request"
-// @Success 200 {object} responses.SuccessResponse{data=Sport}
-// @Failure 400 {object} responses.ErrorResponse "Validation error or bad request"
-// @Failure 404 {object} responses.ErrorResponse "Sport not found"
-// @Failure 409 {object} responses.ErrorResponse "Sport with this name already exists (if name changed)"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse{data=Sport}
+// @Failure 400 {object} response.ErrorResponse "Validation error or bad request"
+// @Failure 404 {object} response.ErrorResponse "Sport not found"
+// @Failure 409 {object} response.ErrorResponse "Sport with this name already exists (if name changed)"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /sports/{sport_id} [put]
 // @Security BearerAuth
 func (sc *SportController) UpdateSport(c *gin.Context) {
 	sportIDStr := c.Param("sport_id")
 	sportID, err := strconv.ParseUint(sportIDStr, 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid sport ID format", nil)
+		response.Error(c, http.StatusBadRequest, "Invalid sport ID format")
 		return
 	}
 
 	var req UpdateSportRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		errors := validator.ParseError(err)
-		responses.SendError(c, http.StatusBadRequest, "Validation failed", errors)
+		response.ValidationError(c, err)
 		return
 	}
 
 	sport, err := sc.repo.GetSportByID(uint(sportID))
 	if err != nil || sport == nil {
-		responses.SendError(c, http.StatusNotFound, "Sport not found", nil)
+		response.Error(c, http.StatusNotFound, "Sport not found")
 		return
 	}
 
 	if req.Name != "" && req.Name != sport.Name {
 		existingSport, _ := sc.repo.FindSportByName(req.Name)
 		if existingSport != nil && existingSport.ID != sport.ID {
-			responses.SendError(c, http.StatusConflict, "Another sport with this name already exists", nil)
+			response.Error(c, http.StatusConflict, "Another sport with this name already exists")
 			return
 		}
 		sport.Name = req.Name
@@ -257,11 +289,11 @@ func (sc *SportController) UpdateSport(c *gin.Context) {
 	// }
 
 	if err := sc.repo.UpdateSport(sport); err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to update sport", err.Error())
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to update sport", err.Error())
 		return
 	}
 
-	responses.SendSuccess(c, http.StatusOK, "Sport updated successfully", sport)
+	response.Success(c, http.StatusOK, "Sport updated successfully", sport)
 }
 
 // DeleteSport godoc
@@ -270,32 +302,32 @@ func (sc *SportController) UpdateSport(c *gin.Context) {
 // @Tags Sports
 // @Produce json
 // @Param sport_id path int true "Sport ID"
-// @Success 200 {object} responses.SuccessResponse "Sport deleted successfully"
-// @Failure 400 {object} responses.ErrorResponse "Invalid sport ID"
-// @Failure 404 {object} responses.ErrorResponse "Sport not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse "Sport deleted successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid sport ID"
+// @Failure 404 {object} response.ErrorResponse "Sport not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /sports/{sport_id} [delete]
 // @Security BearerAuth
 func (sc *SportController) DeleteSport(c *gin.Context) {
 	sportIDStr := c.Param("sport_id")
 	sportID, err := strconv.ParseUint(sportIDStr, 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid sport ID format", nil)
+		response.Error(c, http.StatusBadRequest, "Invalid sport ID format")
 		return
 	}
 
 	sport, err := sc.repo.GetSportByID(uint(sportID))
 	if err != nil || sport == nil { // Check both error and nil sport
-		responses.SendError(c, http.StatusNotFound, "Sport not found to delete", nil)
+		response.Error(c, http.StatusNotFound, "Sport not found to delete")
 		return
 	}
 
 	if err := sc.repo.DeleteSport(uint(sportID)); err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to delete sport", err.Error())
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to delete sport", err.Error())
 		return
 	}
 
-	responses.SendSuccess(c, http.StatusOK, "Sport deleted successfully", nil)
+	response.Success(c, http.StatusOK, "Sport deleted successfully", nil)
 }
 
 // --- Skill Handlers ---
@@ -308,37 +340,36 @@ func (sc *SportController) DeleteSport(c *gin.Context) {
 // @Produce json
 // @Param sport_id path int true "Sport ID"
 // @Param skill body CreateSkillRequest true "Skill creation request"
-// @Success 201 {object} responses.SuccessResponse{data=Skill}
-// @Failure 400 {object} responses.ErrorResponse "Validation error or bad request"
-// @Failure 404 {object} responses.ErrorResponse "Sport not found"
-// @Failure 409 {object} responses.ErrorResponse "Skill with this name already exists for this sport"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 201 {object} response.SuccessResponse{data=Skill}
+// @Failure 400 {object} response.ErrorResponse "Validation error or bad request"
+// @Failure 404 {object} response.ErrorResponse "Sport not found"
+// @Failure 409 {object} response.ErrorResponse "Skill with this name already exists for this sport"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /sports/{sport_id}/skills [post]
 // @Security BearerAuth
 func (sc *SportController) AddSkillToSport(c *gin.Context) {
 	sportIDStr := c.Param("sport_id")
 	sportID, err := strconv.ParseUint(sportIDStr, 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid sport ID format", nil)
+		response.Error(c, http.StatusBadRequest, "Invalid sport ID format")
 		return
 	}
 
 	var req CreateSkillRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		errors := validator.ParseError(err)
-		responses.SendError(c, http.StatusBadRequest, "Validation failed", errors)
+		response.ValidationError(c, err)
 		return
 	}
 
 	sport, err := sc.repo.GetSportByID(uint(sportID))
 	if err != nil || sport == nil {
-		responses.SendError(c, http.StatusNotFound, "Sport not found", nil)
+		response.Error(c, http.StatusNotFound, "Sport not found")
 		return
 	}
 
 	existingSkill, _ := sc.repo.FindSkillByNameAndSportID(req.Name, uint(sportID))
 	if existingSkill != nil {
-		responses.SendError(c, http.StatusConflict, "Skill with this name already exists for this sport", nil)
+		response.Error(c, http.StatusConflict, "Skill with this name already exists for this sport")
 		return
 	}
 
@@ -353,11 +384,11 @@ func (sc *SportController) AddSkillToSport(c *gin.Context) {
 	}
 
 	if err := sc.repo.CreateSkill(&skill); err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to add skill", err.Error())
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to add skill", err.Error())
 		return
 	}
 
-	responses.SendSuccess(c, http.StatusCreated, "Skill added successfully", skill)
+	response.Success(c, http.StatusCreated, "Skill added successfully", skill)
 }
 
 // GetSkillsForSport godoc
@@ -368,16 +399,16 @@ func (sc *SportController) AddSkillToSport(c *gin.Context) {
 // @Param sport_id path int true "Sport ID"
 // @Param page query int false "Page number" default(1)
 // @Param pageSize query int false "Number of items per page" default(10)
-// @Success 200 {object} responses.PaginatedResponse{data=[]Skill}
-// @Failure 400 {object} responses.ErrorResponse "Invalid sport ID"
-// @Failure 404 {object} responses.ErrorResponse "Sport not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.PaginatedResponse{data=response.Page{items=[]Skill}}
+// @Failure 400 {object} response.ErrorResponse "Invalid sport ID"
+// @Failure 404 {object} response.ErrorResponse "Sport not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /sports/{sport_id}/skills [get]
 func (sc *SportController) GetSkillsForSport(c *gin.Context) {
 	sportIDStr := c.Param("sport_id")
 	sportID, err := strconv.ParseUint(sportIDStr, 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid sport ID format", nil)
+		response.Error(c, http.StatusBadRequest, "Invalid sport ID format")
 		return
 	}
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -385,17 +416,17 @@ func (sc *SportController) GetSkillsForSport(c *gin.Context) {
 
 	sport, err := sc.repo.GetSportByID(uint(sportID))
 	if err != nil || sport == nil {
-		responses.SendError(c, http.StatusNotFound, "Sport not found", nil)
+		response.Error(c, http.StatusNotFound, "Sport not found")
 		return
 	}
 
 	skills, total, err := sc.repo.GetSkillsBySportID(uint(sportID), page, pageSize)
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to retrieve skills", err.Error())
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to retrieve skills", err.Error())
 		return
 	}
 
-	responses.SendPaginated(c, http.StatusOK, "Skills retrieved successfully", skills, total, page, pageSize)
+	response.Paginated(c, http.StatusOK, "Skills retrieved successfully", skills, total, page, pageSize)
 }
 
 // UpdateSkill godoc
@@ -406,38 +437,37 @@ func (sc *SportController) GetSkillsForSport(c *gin.Context) {
 // @Produce json
 // @Param skill_id path int true "Skill ID"
 // @Param skill body UpdateSkillRequest true "Skill update request"
-// @Success 200 {object} responses.SuccessResponse{data=Skill}
-// @Failure 400 {object} responses.ErrorResponse "Validation error or bad request"
-// @Failure 404 {object} responses.ErrorResponse "Skill not found"
-// @Failure 409 {object} responses.ErrorResponse "Skill with this name already exists for its sport"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse{data=Skill}
+// @Failure 400 {object} response.ErrorResponse "Validation error or bad request"
+// @Failure 404 {object} response.ErrorResponse "Skill not found"
+// @Failure 409 {object} response.ErrorResponse "Skill with this name already exists for its sport"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /skills/{skill_id} [put]
 // @Security BearerAuth
 func (sc *SportController) UpdateSkill(c *gin.Context) {
 	skillIDStr := c.Param("skill_id")
 	skillID, err := strconv.ParseUint(skillIDStr, 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid skill ID format", nil)
+		response.Error(c, http.StatusBadRequest, "Invalid skill ID format")
 		return
 	}
 
 	var req UpdateSkillRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		errors := validator.ParseError(err)
-		responses.SendError(c, http.StatusBadRequest, "Validation failed", errors)
+		response.ValidationError(c, err)
 		return
 	}
 
 	skill, err := sc.repo.GetSkillByID(uint(skillID))
 	if err != nil || skill == nil {
-		responses.SendError(c, http.StatusNotFound, "Skill not found", nil)
+		response.Error(c, http.StatusNotFound, "Skill not found")
 		return
 	}
 
 	if req.Name != "" && req.Name != skill.Name {
 		existingSkill, _ := sc.repo.FindSkillByNameAndSportID(req.Name, skill.SportID)
 		if existingSkill != nil && existingSkill.ID != skill.ID {
-			responses.SendError(c, http.StatusConflict, "Another skill with this name already exists for this sport", nil)
+			response.Error(c, http.StatusConflict, "Another skill with this name already exists for this sport")
 			return
 		}
 		skill.Name = req.Name
@@ -450,11 +480,11 @@ func (sc *SportController) UpdateSkill(c *gin.Context) {
 	}
 
 	if err := sc.repo.UpdateSkill(skill); err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to update skill", err.Error())
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to update skill", err.Error())
 		return
 	}
 
-	responses.SendSuccess(c, http.StatusOK, "Skill updated successfully", skill)
+	response.Success(c, http.StatusOK, "Skill updated successfully", skill)
 }
 
 // DeleteSkill godoc
@@ -463,32 +493,32 @@ func (sc *SportController) UpdateSkill(c *gin.Context) {
 // @Tags Skills
 // @Produce json
 // @Param skill_id path int true "Skill ID"
-// @Success 200 {object} responses.SuccessResponse "Skill deleted successfully"
-// @Failure 400 {object} responses.ErrorResponse "Invalid skill ID"
-// @Failure 404 {object} responses.ErrorResponse "Skill not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse "Skill deleted successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid skill ID"
+// @Failure 404 {object} response.ErrorResponse "Skill not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /skills/{skill_id} [delete]
 // @Security BearerAuth
 func (sc *SportController) DeleteSkill(c *gin.Context) {
 	skillIDStr := c.Param("skill_id")
 	skillID, err := strconv.ParseUint(skillIDStr, 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid skill ID format", nil)
+		response.Error(c, http.StatusBadRequest, "Invalid skill ID format")
 		return
 	}
 
 	skill, errRepo := sc.repo.GetSkillByID(uint(skillID))
 	if errRepo != nil || skill == nil {
-		responses.SendError(c, http.StatusNotFound, "Skill not found to delete", nil)
+		response.Error(c, http.StatusNotFound, "Skill not found to delete")
 		return
 	}
 
 	if err := sc.repo.DeleteSkill(uint(skillID)); err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to delete skill", err.Error())
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to delete skill", err.Error())
 		return
 	}
 
-	responses.SendSuccess(c, http.StatusOK, "Skill deleted successfully", nil)
+	response.Success(c, http.StatusOK, "Skill deleted successfully", nil)
 }
 
 // --- UserSport Handlers ---
@@ -500,31 +530,30 @@ func (sc *SportController) DeleteSkill(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param preference body UserSportRequest true "User sport preference request"
-// @Success 200 {object} responses.SuccessResponse{data=UserSport} "Preference updated"
-// @Success 201 {object} responses.SuccessResponse{data=UserSport} "Preference added"
-// @Failure 400 {object} responses.ErrorResponse "Validation error or bad request"
-// @Failure 404 {object} responses.ErrorResponse "Sport not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse{data=UserSport} "Preference updated"
+// @Success 201 {object} response.SuccessResponse{data=UserSport} "Preference added"
+// @Failure 400 {object} response.ErrorResponse "Validation error or bad request"
+// @Failure 404 {object} response.ErrorResponse "Sport not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /users/me/sports [post]
 // @Security BearerAuth
 func (sc *SportController) AddUserSportPreference(c *gin.Context) {
-	userID, err := middleware.GetUserIDFromContext(c)
-	if err != nil {
-		responses.SendError(c, http.StatusUnauthorized, "Unauthorized", err.Error())
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.ErrorWithDetails(c, http.StatusUnauthorized, "Unauthorized", "authentication required")
 		return
 	}
 
 	var req UserSportRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		errors := validator.ParseError(err)
-		responses.SendError(c, http.StatusBadRequest, "Validation failed", errors)
+		response.ValidationError(c, err)
 		return
 	}
 
 	// Check if sport exists
 	sport, err := sc.repo.GetSportByID(req.SportID)
 	if err != nil || sport == nil {
-		responses.SendError(c, http.StatusNotFound, "Sport not found", nil)
+		response.Error(c, http.StatusNotFound, "Sport not found")
 		return
 	}
 
@@ -537,20 +566,20 @@ func (sc *SportController) AddUserSportPreference(c *gin.Context) {
 
 	// Use AddUserSport which handles upsert logic
 	if err := sc.repo.AddUserSport(&userSport); err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to add/update user sport preference", err.Error())
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to add/update user sport preference", err.Error())
 		return
 	}
 
 	// Fetch the newly created/updated record to include sport details if needed
 	createdOrUpdatedUserSport, err := sc.repo.GetUserSportBySportID(userID, req.SportID)
 	if err != nil || createdOrUpdatedUserSport == nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to retrieve user sport preference after update", err.Error())
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to retrieve user sport preference after update", err.Error())
 		return
 	}
 
 	// Determine if it was a create or update for the status code (optional)
 	// For simplicity, we can return 200 OK for upsert. GORM doesn't easily tell if it was an insert or update in this upsert.
-	responses.SendSuccess(c, http.StatusOK, "User sport preference saved successfully", createdOrUpdatedUserSport)
+	response.Success(c, http.StatusOK, "User sport preference saved successfully", createdOrUpdatedUserSport)
 }
 
 // GetUserSportPreferences godoc
@@ -558,25 +587,25 @@ func (sc *SportController) AddUserSportPreference(c *gin.Context) {
 // @Description Authenticated user can retrieve their list of sport preferences
 // @Tags UserSports
 // @Produce json
-// @Success 200 {object} responses.SuccessResponse{data=[]UserSport}
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse{data=[]UserSport}
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /users/me/sports [get]
 // @Security BearerAuth
 func (sc *SportController) GetUserSportPreferences(c *gin.Context) {
-	userID, err := middleware.GetUserIDFromContext(c)
-	if err != nil {
-		responses.SendError(c, http.StatusUnauthorized, "Unauthorized", err.Error())
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.ErrorWithDetails(c, http.StatusUnauthorized, "Unauthorized", "authentication required")
 		return
 	}
 
 	preferences, err := sc.repo.GetUserSports(userID)
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to retrieve user sport preferences", err.Error())
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to retrieve user sport preferences", err.Error())
 		return
 	}
 
-	responses.SendSuccess(c, http.StatusOK, "User sport preferences retrieved successfully", preferences)
+	response.Success(c, http.StatusOK, "User sport preferences retrieved successfully", preferences)
 }
 
 // RemoveUserSportPreference godoc
@@ -585,42 +614,211 @@ func (sc *SportController) GetUserSportPreferences(c *gin.Context) {
 // @Tags UserSports
 // @Produce json
 // @Param sport_id path int true "Sport ID to remove preference for"
-// @Success 200 {object} responses.SuccessResponse "Preference removed successfully"
-// @Failure 400 {object} responses.ErrorResponse "Invalid sport ID"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 404 {object} responses.ErrorResponse "Sport preference not found for this user"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse "Preference removed successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid sport ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Sport preference not found for this user"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /users/me/sports/{sport_id} [delete]
 // @Security BearerAuth
 func (sc *SportController) RemoveUserSportPreference(c *gin.Context) {
-	userID, err := middleware.GetUserIDFromContext(c)
-	if err != nil {
-		responses.SendError(c, http.StatusUnauthorized, "Unauthorized", err.Error())
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.ErrorWithDetails(c, http.StatusUnauthorized, "Unauthorized", "authentication required")
 		return
 	}
 
 	sportIDStr := c.Param("sport_id")
 	sportID, err := strconv.ParseUint(sportIDStr, 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid sport ID format", nil)
+		response.Error(c, http.StatusBadRequest, "Invalid sport ID format")
 		return
 	}
 
 	// Check if the preference exists before deleting
 	existingPreference, err := sc.repo.GetUserSportBySportID(userID, uint(sportID))
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		responses.SendError(c, http.StatusInternalServerError, "Error checking preference", err.Error())
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Error checking preference", err.Error())
 		return
 	}
 	if existingPreference == nil {
-		responses.SendError(c, http.StatusNotFound, "Sport preference not found for this user", nil)
+		response.Error(c, http.StatusNotFound, "Sport preference not found for this user")
 		return
 	}
 
 	if err := sc.repo.RemoveUserSport(userID, uint(sportID)); err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to remove user sport preference", err.Error())
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to remove user sport preference", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "User sport preference removed successfully", nil)
+}
+
+// --- Skill Endorsement Handlers ---
+
+// EndorseUserSkill godoc
+// @Summary Endorse a user's skill
+// @Description Authenticated user endorses another user's skill. Only users who have played a completed match of the skill's sport together may endorse each other.
+// @Tags SkillEndorsements
+// @Produce json
+// @Param id path int true "User ID to endorse"
+// @Param skill_id path int true "Skill ID"
+// @Success 201 {object} response.SuccessResponse{data=SkillEndorsement} "Skill endorsed"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID or self-endorsement"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "No shared completed match"
+// @Failure 404 {object} response.ErrorResponse "Skill not found or user does not play the sport"
+// @Failure 409 {object} response.ErrorResponse "Skill already endorsed"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /users/{id}/skills/{skill_id}/endorsements [post]
+// @Security BearerAuth
+func (sc *SportController) EndorseUserSkill(c *gin.Context) {
+	endorserID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.ErrorWithDetails(c, http.StatusUnauthorized, "Unauthorized", "authentication required")
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+	skillID, err := strconv.ParseUint(c.Param("skill_id"), 10, 32)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid skill ID format")
+		return
+	}
+	if uint(userID) == endorserID {
+		response.Error(c, http.StatusBadRequest, "You cannot endorse your own skills")
+		return
+	}
+
+	skill, err := sc.repo.GetSkillByID(uint(skillID))
+	if err != nil {
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to retrieve skill", err.Error())
+		return
+	}
+	if skill == nil {
+		response.Error(c, http.StatusNotFound, "Skill not found")
+		return
+	}
+
+	userSport, err := sc.repo.GetUserSportBySportID(uint(userID), skill.SportID)
+	if err != nil {
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Error checking user sport", err.Error())
+		return
+	}
+	if userSport == nil {
+		response.Error(c, http.StatusNotFound, "User does not play this sport")
+		return
+	}
+
+	shared, err := sc.repo.HaveSharedCompletedMatch(endorserID, uint(userID), skill.SportID)
+	if err != nil {
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to check match history", err.Error())
+		return
+	}
+	if !shared {
+		response.Error(c, http.StatusForbidden, "You can only endorse users you have played a completed match with")
+		return
+	}
+
+	endorsement := SkillEndorsement{
+		UserID:     uint(userID),
+		SportID:    skill.SportID,
+		SkillID:    skill.ID,
+		EndorserID: endorserID,
+	}
+	if err := sc.repo.CreateEndorsement(&endorsement); err != nil {
+		if errors.Is(err, ErrAlreadyEndorsed) {
+			response.Error(c, http.StatusConflict, "You have already endorsed this skill")
+			return
+		}
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to endorse skill", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusCreated, "Skill endorsed successfully", endorsement)
+}
+
+// WithdrawSkillEndorsement godoc
+// @Summary Withdraw a skill endorsement
+// @Description Authenticated user withdraws an endorsement they gave for another user's skill
+// @Tags SkillEndorsements
+// @Produce json
+// @Param id path int true "Endorsed user ID"
+// @Param skill_id path int true "Skill ID"
+// @Success 200 {object} response.SuccessResponse "Endorsement withdrawn"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 404 {object} response.ErrorResponse "Endorsement not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /users/{id}/skills/{skill_id}/endorsements [delete]
+// @Security BearerAuth
+func (sc *SportController) WithdrawSkillEndorsement(c *gin.Context) {
+	endorserID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		response.ErrorWithDetails(c, http.StatusUnauthorized, "Unauthorized", "authentication required")
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+	skillID, err := strconv.ParseUint(c.Param("skill_id"), 10, 32)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid skill ID format")
+		return
+	}
+
+	if err := sc.repo.DeleteEndorsement(endorserID, uint(userID), uint(skillID)); err != nil {
+		if errors.Is(err, ErrEndorsementNotFound) {
+			response.Error(c, http.StatusNotFound, "Endorsement not found")
+			return
+		}
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to withdraw endorsement", err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Endorsement withdrawn successfully", nil)
+}
+
+// GetUserEndorsements godoc
+// @Summary Get a user's skill endorsement counts
+// @Description Lists how many endorsements a user has for each skill, optionally for a single sport
+// @Tags SkillEndorsements
+// @Produce json
+// @Param id path int true "User ID"
+// @Param sport_id query int false "Filter by sport ID"
+// @Success 200 {object} response.SuccessResponse{data=[]SkillEndorsementCount}
+// @Failure 400 {object} response.ErrorResponse "Invalid ID"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /users/{id}/endorsements [get]
+// @Security BearerAuth
+func (sc *SportController) GetUserEndorsements(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid user ID format")
+		return
+	}
+
+	var sportID uint64
+	if raw := c.Query("sport_id"); raw != "" {
+		sportID, err = strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid sport ID format")
+			return
+		}
+	}
+
+	counts, err := sc.repo.GetEndorsementCounts(uint(userID), uint(sportID))
+	if err != nil {
+		response.ErrorWithDetails(c, http.StatusInternalServerError, "Failed to retrieve endorsements", err.Error())
 		return
 	}
 
-	responses.SendSuccess(c, http.StatusOK, "User sport preference removed successfully", nil)
+	response.Success(c, http.StatusOK, "Endorsements retrieved successfully", counts)
 }
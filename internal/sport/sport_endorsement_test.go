@@ -0,0 +1,174 @@
+package sport_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/config"
+	"github.com/DhavalSuthar-24/miow/internal/match"
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/internal/sport"
+	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/internal/user"
+	"github.com/DhavalSuthar-24/miow/internal/venue"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// endorsementFixture is a player with a skill in a sport they play, and the users who may or
+// may not endorse it
+type endorsementFixture struct {
+	db     *gorm.DB
+	sc     *sport.SportController
+	sport  *sport.Sport
+	skill  *sport.Skill
+	player *user.User
+}
+
+func newEndorsementFixture(t *testing.T) *endorsementFixture {
+	t.Helper()
+	models := append(testutil.UserModels,
+		&sport.Sport{}, &sport.UserSport{}, &sport.Skill{}, &sport.SkillEndorsement{},
+		&team.Team{}, &venue.Venue{}, &match.Challenge{}, &match.Match{}, &match.MatchLineup{})
+	db := testutil.DB(t, models...)
+
+	f := &endorsementFixture{db: db, sc: sport.NewSportController(sport.NewSportRepository(db), &config.Config{})}
+	f.sport = f.createSport(t)
+	f.skill = &sport.Skill{Name: "Batting", SportID: f.sport.ID}
+	if err := db.Omit("Sport").Create(f.skill).Error; err != nil {
+		t.Fatalf("failed to create skill: %v", err)
+	}
+	f.player = testutil.CreateUser(t, db, "Player")
+	if err := db.Omit("Sport").Create(&sport.UserSport{UserID: f.player.ID, SportID: f.sport.ID}).Error; err != nil {
+		t.Fatalf("failed to add user sport: %v", err)
+	}
+	return f
+}
+
+func (f *endorsementFixture) createSport(t *testing.T) *sport.Sport {
+	t.Helper()
+	s := &sport.Sport{Name: fmt.Sprintf("Sport %d", testutil.Seq()), IsActive: true}
+	if err := f.db.Omit("Rules", "Positions", "Equipment").Create(s).Error; err != nil {
+		t.Fatalf("failed to create sport: %v", err)
+	}
+	return s
+}
+
+// playMatch records a match of the sport in the given status with the users in its lineup
+func (f *endorsementFixture) playMatch(t *testing.T, sportID uint, status match.MatchStatus, players ...*user.User) {
+	t.Helper()
+	m := &match.Match{
+		CreatedByUserID: players[0].ID,
+		SportID:         sportID,
+		ScheduledAt:     time.Now().Add(-2 * time.Hour),
+		CustomRules:     "{}",
+		Status:          status,
+	}
+	if err := f.db.Omit("CreatedByUser", "Sport", "Venue", "Challenge", "TossWinnerTeam", "WinningTeam", "ManOfTheMatch", "MatchTeams").
+		Create(m).Error; err != nil {
+		t.Fatalf("failed to create match: %v", err)
+	}
+	for _, p := range players {
+		if err := f.db.Omit("User").Create(&match.MatchLineup{MatchID: m.ID, UserID: p.ID, IsStarter: true}).Error; err != nil {
+			t.Fatalf("failed to add player to lineup: %v", err)
+		}
+	}
+}
+
+// endorse sends method to the player's skill endorsement endpoint as the endorser
+func (f *endorsementFixture) endorse(t *testing.T, method string, endorserID uint) *httptest.ResponseRecorder {
+	t.Helper()
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(middleware.AuthUserIDKey, endorserID) })
+	r.POST("/users/:id/skills/:skill_id/endorsements", f.sc.EndorseUserSkill)
+	r.DELETE("/users/:id/skills/:skill_id/endorsements", f.sc.WithdrawSkillEndorsement)
+	return testutil.Request(t, r, method, fmt.Sprintf("/users/%d/skills/%d/endorsements", f.player.ID, f.skill.ID), nil)
+}
+
+// endorsementCount returns the player's endorsement count for the skill
+func (f *endorsementFixture) endorsementCount(t *testing.T) int64 {
+	t.Helper()
+	r := gin.New()
+	r.GET("/users/:id/endorsements", f.sc.GetUserEndorsements)
+	var counts []sport.SkillEndorsementCount
+	testutil.DecodeData(t, testutil.Request(t, r, http.MethodGet, fmt.Sprintf("/users/%d/endorsements", f.player.ID), nil), &counts)
+	for _, c := range counts {
+		if c.SkillID == f.skill.ID {
+			return c.Count
+		}
+	}
+	return 0
+}
+
+func TestEndorseUserSkillRequiresSharedCompletedMatch(t *testing.T) {
+	f := newEndorsementFixture(t)
+
+	stranger := testutil.CreateUser(t, f.db, "Stranger")
+	upcomingTeammate := testutil.CreateUser(t, f.db, "Upcoming")
+	f.playMatch(t, f.sport.ID, match.StatusMatchUpcoming, f.player, upcomingTeammate)
+	otherSportTeammate := testutil.CreateUser(t, f.db, "Other sport")
+	f.playMatch(t, f.createSport(t).ID, match.StatusMatchCompleted, f.player, otherSportTeammate)
+	opponentElsewhere := testutil.CreateUser(t, f.db, "Elsewhere")
+	f.playMatch(t, f.sport.ID, match.StatusMatchCompleted, opponentElsewhere, stranger)
+
+	tests := []struct {
+		name       string
+		endorserID uint
+		status     int
+	}{
+		{"never played together", stranger.ID, http.StatusForbidden},
+		{"match not completed", upcomingTeammate.ID, http.StatusForbidden},
+		{"completed match of another sport", otherSportTeammate.ID, http.StatusForbidden},
+		{"completed match without the player", opponentElsewhere.ID, http.StatusForbidden},
+		{"own skill", f.player.ID, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := f.endorse(t, http.MethodPost, tt.endorserID); w.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+		})
+	}
+	if count := f.endorsementCount(t); count != 0 {
+		t.Errorf("player has %d endorsements after rejected attempts, want 0", count)
+	}
+}
+
+func TestEndorseUserSkillOncePerTeammate(t *testing.T) {
+	f := newEndorsementFixture(t)
+	teammate := testutil.CreateUser(t, f.db, "Teammate")
+	f.playMatch(t, f.sport.ID, match.StatusMatchCompleted, f.player, teammate)
+
+	if w := f.endorse(t, http.MethodPost, teammate.ID); w.Code != http.StatusCreated {
+		t.Fatalf("endorse status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+	}
+	if w := f.endorse(t, http.MethodPost, teammate.ID); w.Code != http.StatusConflict {
+		t.Errorf("duplicate endorse status = %d, want %d", w.Code, http.StatusConflict)
+	}
+	if count := f.endorsementCount(t); count != 1 {
+		t.Errorf("player has %d endorsements, want 1", count)
+	}
+
+	if w := f.endorse(t, http.MethodDelete, teammate.ID); w.Code != http.StatusOK {
+		t.Fatalf("withdraw status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	if w := f.endorse(t, http.MethodDelete, teammate.ID); w.Code != http.StatusNotFound {
+		t.Errorf("second withdraw status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+	if count := f.endorsementCount(t); count != 0 {
+		t.Errorf("player has %d endorsements after the withdrawal, want 0", count)
+	}
+
+	// A withdrawn endorsement can be given again
+	if w := f.endorse(t, http.MethodPost, teammate.ID); w.Code != http.StatusCreated {
+		t.Errorf("re-endorse status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+	}
+}
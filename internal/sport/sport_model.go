@@ -1,6 +1,10 @@
 package sport
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -29,6 +33,54 @@ type Rules struct {
 	MinPlayers   int    `json:"min_players,omitempty"`
 	GameDuration string `json:"game_duration,omitempty"` // e.g., "90 minutes", "4 quarters of 12 minutes"
 	Other        string `json:"other,omitempty"`
+	AllowsDraw   *bool  `json:"allows_draw,omitempty"` // Whether a match may end level; unset means draws are allowed
+	MinTeams     int    `json:"min_teams,omitempty"`   // Teams per match; unset means 2
+	MaxTeams     int    `json:"max_teams,omitempty"`   // Above 2 for multi-team formats such as relays; unset means MinTeams
+}
+
+// Value stores the rules as JSON
+func (r Rules) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+// Scan unmarshals the JSON rules column. A NULL column leaves the rules empty, so every
+// default applies.
+func (r *Rules) Scan(src interface{}) error {
+	*r = Rules{}
+	return scanJSON("Rules", src, r)
+}
+
+// scanJSON unmarshals a JSON column into dest, leaving dest untouched for NULL
+func scanJSON(name string, src, dest interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	}
+	return fmt.Errorf("%s: expected []byte, got %T", name, src)
+}
+
+// defaultTeamsPerMatch is the number of teams in a match when the sport does not say otherwise
+const defaultTeamsPerMatch = 2
+
+// DrawsAllowed reports whether matches of the sport may end in a draw
+func (r Rules) DrawsAllowed() bool {
+	return r.AllowsDraw == nil || *r.AllowsDraw
+}
+
+// TeamsPerMatch returns the minimum and maximum number of teams in a match of the sport
+func (r Rules) TeamsPerMatch() (min, max int) {
+	min, max = r.MinTeams, r.MaxTeams
+	if min < defaultTeamsPerMatch {
+		min = defaultTeamsPerMatch
+	}
+	if max < min {
+		max = min
+	}
+	return min, max
 }
 
 // Position defines a player position within a sport.
@@ -48,9 +100,57 @@ type Equipment struct {
 // Positions is a slice of Position, implementing Scanner and Valuer for GORM.
 type Positions []Position
 
+// Lookup finds a position by name or abbreviation, ignoring case and surrounding spaces
+func (p Positions) Lookup(name string) (Position, bool) {
+	name = strings.TrimSpace(name)
+	for _, pos := range p {
+		if strings.EqualFold(pos.Name, name) || (pos.Abbreviation != "" && strings.EqualFold(pos.Abbreviation, name)) {
+			return pos, true
+		}
+	}
+	return Position{}, false
+}
+
+// Value stores the positions as a JSON array
+func (p Positions) Value() (driver.Value, error) {
+	if p == nil {
+		return "[]", nil
+	}
+	return json.Marshal(p)
+}
+
+// Scan unmarshals the JSON positions column
+func (p *Positions) Scan(src interface{}) error {
+	*p = nil
+	return scanJSON("Positions", src, p)
+}
+
+// Names lists the position names in catalog order
+func (p Positions) Names() []string {
+	names := make([]string, len(p))
+	for i, pos := range p {
+		names[i] = pos.Name
+	}
+	return names
+}
+
 // Equipments is a slice of Equipment, implementing Scanner and Valuer for GORM.
 type Equipments []Equipment
 
+// Value stores the equipment as a JSON array
+func (e Equipments) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	return json.Marshal(e)
+}
+
+// Scan unmarshals the JSON equipment column
+func (e *Equipments) Scan(src interface{}) error {
+	*e = nil
+	return scanJSON("Equipments", src, e)
+}
+
 // Skill represents a specific skill related to a sport.
 type Skill struct {
 	BaseModel
@@ -71,3 +171,21 @@ type UserSport struct {
 	Level    string `json:"level,omitempty" gorm:"size:50"`     // e.g., "Beginner", "Intermediate", "Advanced", "Professional"
 	// User User `json:"-" gorm:"foreignKey:UserID"` // Belongs to User (if User model is in a different package, manage carefully or use IDs)
 }
+
+// SkillEndorsement records one user vouching for another user's skill. Endorsers must have
+// played a completed match of the skill's sport with the endorsed user.
+type SkillEndorsement struct {
+	BaseModel
+	UserID     uint `json:"user_id" gorm:"not null;uniqueIndex:idx_skill_endorsement;index:idx_endorsement_user_sport"` // Endorsed user
+	SportID    uint `json:"sport_id" gorm:"not null;index:idx_endorsement_user_sport"`
+	SkillID    uint `json:"skill_id" gorm:"not null;uniqueIndex:idx_skill_endorsement"`
+	EndorserID uint `json:"endorser_id" gorm:"not null;uniqueIndex:idx_skill_endorsement"`
+}
+
+// SkillEndorsementCount is the number of endorsements a user has for one skill
+type SkillEndorsementCount struct {
+	SkillID   uint   `json:"skill_id"`
+	SkillName string `json:"skill_name"`
+	SportID   uint   `json:"sport_id"`
+	Count     int64  `json:"count"`
+}
@@ -1,10 +1,12 @@
 package sport
 
 import (
+	"context"
 	"errors"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
 )
 
 type SportRepository interface {
@@ -29,8 +31,20 @@ type SportRepository interface {
 	GetUserSportBySportID(userID, sportID uint) (*UserSport, error) // Changed to pointer
 	UpdateUserSport(userSport *UserSport) error                     // Changed to pointer
 	RemoveUserSport(userID, sportID uint) error
+
+	// Skill endorsement methods
+	CreateEndorsement(endorsement *SkillEndorsement) error
+	DeleteEndorsement(endorserID, userID, skillID uint) error
+	GetEndorsementCounts(userID, sportID uint) ([]SkillEndorsementCount, error)
+	HaveSharedCompletedMatch(userID, otherUserID, sportID uint) (bool, error)
+	Ping(ctx context.Context) error
 }
 
+var (
+	ErrAlreadyEndorsed     = errors.New("skill already endorsed")
+	ErrEndorsementNotFound = errors.New("endorsement not found")
+)
+
 type sportRepository struct {
 	db *gorm.DB
 }
@@ -63,7 +77,7 @@ func (r *sportRepository) GetAllSports(page, pageSize int, searchTerm string, is
 	var sports []Sport
 	var total int64
 
-	query := r.db.Model(&Sport{})
+	query := r.db.Clauses(dbresolver.Read).Model(&Sport{})
 
 	if searchTerm != "" {
 		query = query.Where("name ILIKE ? OR description ILIKE ?", "%"+searchTerm+"%", "%"+searchTerm+"%")
@@ -199,3 +213,71 @@ func (r *sportRepository) UpdateUserSport(userSport *UserSport) error {
 func (r *sportRepository) RemoveUserSport(userID, sportID uint) error {
 	return r.db.Where("user_id = ? AND sport_id = ?", userID, sportID).Delete(&UserSport{}).Error
 }
+
+// --- Skill Endorsement Methods ---
+
+// CreateEndorsement stores an endorsement, returning ErrAlreadyEndorsed if the endorser
+// has already endorsed this skill for the user.
+func (r *sportRepository) CreateEndorsement(endorsement *SkillEndorsement) error {
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "skill_id"}, {Name: "endorser_id"}},
+		DoNothing: true,
+	}).Create(endorsement)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAlreadyEndorsed
+	}
+	return nil
+}
+
+// DeleteEndorsement withdraws an endorsement. The row is removed so the endorser can endorse again later.
+func (r *sportRepository) DeleteEndorsement(endorserID, userID, skillID uint) error {
+	result := r.db.Where("endorser_id = ? AND user_id = ? AND skill_id = ?", endorserID, userID, skillID).
+		Delete(&SkillEndorsement{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrEndorsementNotFound
+	}
+	return nil
+}
+
+// GetEndorsementCounts counts a user's endorsements per skill, optionally limited to one sport (sportID 0 means all)
+func (r *sportRepository) GetEndorsementCounts(userID, sportID uint) ([]SkillEndorsementCount, error) {
+	query := r.db.Model(&SkillEndorsement{}).
+		Select("skill_endorsements.skill_id, skills.name AS skill_name, skill_endorsements.sport_id, COUNT(*) AS count").
+		Joins("JOIN skills ON skills.id = skill_endorsements.skill_id").
+		Where("skill_endorsements.user_id = ?", userID)
+	if sportID != 0 {
+		query = query.Where("skill_endorsements.sport_id = ?", sportID)
+	}
+
+	var counts []SkillEndorsementCount
+	err := query.Group("skill_endorsements.skill_id, skills.name, skill_endorsements.sport_id").
+		Order("count DESC, skills.name ASC").
+		Scan(&counts).Error
+	return counts, err
+}
+
+// HaveSharedCompletedMatch reports whether both users were in the lineup of the same completed
+// match of the sport. Lineups are read from the match tables directly since the match package
+// depends on this one.
+func (r *sportRepository) HaveSharedCompletedMatch(userID, otherUserID, sportID uint) (bool, error) {
+	var shared bool
+	err := r.db.Raw(`SELECT EXISTS (
+		SELECT 1 FROM match_lineups a
+		JOIN match_lineups b ON b.match_id = a.match_id AND b.deleted_at IS NULL
+		JOIN matches m ON m.id = a.match_id AND m.deleted_at IS NULL
+		WHERE a.user_id = ? AND b.user_id = ? AND a.deleted_at IS NULL
+			AND m.sport_id = ? AND m.status = ?
+	)`, userID, otherUserID, sportID, "completed").Scan(&shared).Error
+	return shared, err
+}
+
+// Ping checks that the sports table answers a trivial query
+func (r *sportRepository) Ping(ctx context.Context) error {
+	return r.db.WithContext(ctx).Exec("SELECT 1 FROM sports LIMIT 1").Error
+}
@@ -3,7 +3,6 @@ package sport
 import (
 	"github.com/DhavalSuthar-24/miow/config"
 	mw "github.com/DhavalSuthar-24/miow/internal/middleware" // Your middleware package
-	"github.com/DhavalSuthar-24/miow/pkg/rmiddleware"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -19,6 +18,7 @@ func RegisterSportRoutes(router *gin.RouterGroup, db *gorm.DB, appConfig *config
 		publicSports.GET("", sportController.GetAllSports)                       // Get all active sports
 		publicSports.GET("/:sport_id", sportController.GetSportByID)             // Get a specific sport
 		publicSports.GET("/:sport_id/skills", sportController.GetSkillsForSport) // Get skills for a sport
+		publicSports.GET("/:sport_id/positions", sportController.GetSportPositions)
 	}
 
 	// Authenticated routes (requires a valid token)
@@ -32,7 +32,7 @@ func RegisterSportRoutes(router *gin.RouterGroup, db *gorm.DB, appConfig *config
 	{
 		// Sport management - Admin only
 		adminSports := authenticated.Group("/sports")
-		adminSports.Use(rmiddleware.AdminMiddleware()) // Requires "admin" role
+		adminSports.Use(mw.RequireRole("admin")) // Requires "admin" role
 		{
 			adminSports.POST("", sportController.CreateSport)
 			adminSports.PUT("/:sport_id", sportController.UpdateSport)
@@ -42,7 +42,7 @@ func RegisterSportRoutes(router *gin.RouterGroup, db *gorm.DB, appConfig *config
 
 		// Skill management - Admin only
 		adminSkills := authenticated.Group("/skills")
-		adminSkills.Use(rmiddleware.AdminMiddleware()) // Requires "admin" role
+		adminSkills.Use(mw.RequireRole("admin")) // Requires "admin" role
 		{
 			// Note: AddSkillToSport is nested under /sports/:sport_id/skills for better RESTful design
 			// This route group is for managing skills directly by skill_id if ever needed,
@@ -52,7 +52,7 @@ func RegisterSportRoutes(router *gin.RouterGroup, db *gorm.DB, appConfig *config
 		}
 		// Add skill to sport (Admin only) - nested under sports
 		adminSportSkills := authenticated.Group("/sports/:sport_id/skills")
-		adminSportSkills.Use(rmiddleware.AdminMiddleware())
+		adminSportSkills.Use(mw.RequireRole("admin"))
 		{
 			adminSportSkills.POST("", sportController.AddSkillToSport)
 		}
@@ -61,11 +61,20 @@ func RegisterSportRoutes(router *gin.RouterGroup, db *gorm.DB, appConfig *config
 		userSports := authenticated.Group("/users/me/sports")
 		// No specific role middleware here if AuthMiddleware is enough and any authenticated user can manage their own.
 		// If you need PlayerOrCoachOrAdmin, you could add:
-		userSports.Use(rmiddleware.RoleMiddleware("player", "coach", "admin"))
+		userSports.Use(mw.RequireAnyRole("player", "coach", "admin"))
 		{
 			userSports.POST("", sportController.AddUserSportPreference)
 			userSports.GET("", sportController.GetUserSportPreferences)
 			userSports.DELETE("/:sport_id", sportController.RemoveUserSportPreference)
 		}
+
+		// Skill endorsements - any authenticated user; the shared-match rule is checked in the handler.
+		// The parameter is :id to match the /users/:id profile route registered by the auth package.
+		endorsements := authenticated.Group("/users/:id")
+		{
+			endorsements.GET("/endorsements", sportController.GetUserEndorsements)
+			endorsements.POST("/skills/:skill_id/endorsements", sportController.EndorseUserSkill)
+			endorsements.DELETE("/skills/:skill_id/endorsements", sportController.WithdrawSkillEndorsement)
+		}
 	}
 }
@@ -0,0 +1,88 @@
+package team
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/config"
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/internal/notification"
+	"github.com/DhavalSuthar-24/miow/internal/sport"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/pkg/storage"
+	"github.com/DhavalSuthar-24/miow/pkg/validator"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+	validator.RegisterJSONStringRules()
+}
+
+// newTestDB opens a test database with the team and notification tables
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	models := append(testutil.UserModels,
+		&sport.Sport{}, &Team{}, &TeamMember{}, &TeamBlock{}, &TeamInvitation{}, &JoinRequest{},
+		&notification.Notification{})
+	return testutil.DB(t, models...)
+}
+
+// newTestController creates a controller over db that delivers notifications in-app
+func newTestController(t *testing.T, db *gorm.DB) *TeamController {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.Teams.InvitationExpiryHours = 72
+	return NewTeamController(NewTeamRepository(db), cfg, notification.NewDefaultDispatcher(db, cfg),
+		storage.NewLocalStorage(t.TempDir(), "/uploads"))
+}
+
+// asUser authenticates every request as the user, as AuthMiddleware would
+func asUser(userID uint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(middleware.AuthUserIDKey, userID)
+		c.Next()
+	}
+}
+
+// createTeam inserts a team with room for ten players, with creatorID as its captain
+func createTeam(t *testing.T, db *gorm.DB, creatorID uint) *Team {
+	t.Helper()
+	s := &sport.Sport{Name: fmt.Sprintf("Sport %d", testutil.Seq()), IsActive: true}
+	if err := db.Omit("Rules", "Positions", "Equipment").Create(s).Error; err != nil {
+		t.Fatalf("failed to create sport: %v", err)
+	}
+	tm := &Team{
+		Name:         fmt.Sprintf("Team %d", testutil.Seq()),
+		CreatedByID:  creatorID,
+		SportID:      s.ID,
+		MinPlayers:   1,
+		MaxPlayers:   10,
+		Requirements: "{}",
+		Achievements: "[]",
+		SocialLinks:  "{}",
+		MatchHistory: "[]",
+	}
+	if err := db.Omit("Sport").Create(tm).Error; err != nil {
+		t.Fatalf("failed to create team: %v", err)
+	}
+	member := &TeamMember{TeamID: tm.ID, UserID: creatorID, Role: RoleCaptain, JoinedAt: time.Now(), IsActive: true, IsCaptain: true, Stats: "{}"}
+	if err := db.Omit("Team").Create(member).Error; err != nil {
+		t.Fatalf("failed to add team captain: %v", err)
+	}
+	return tm
+}
+
+// setPositions gives the sport a position catalog
+func setPositions(t *testing.T, db *gorm.DB, sportID uint, positions ...sport.Position) {
+	t.Helper()
+	if err := db.Model(&sport.Sport{}).Where("id = ?", sportID).Update("positions", sport.Positions(positions)).Error; err != nil {
+		t.Fatalf("failed to set sport positions: %v", err)
+	}
+}
+
+func itoa(id uint) string {
+	return fmt.Sprintf("%d", id)
+}
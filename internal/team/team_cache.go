@@ -0,0 +1,90 @@
+package team
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/pkg/cache"
+)
+
+// cachedTeamRepository serves GetTeamByID from the cache, dropping the entry whenever the team
+// is updated or deleted through the repository
+type cachedTeamRepository struct {
+	TeamRepository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachedTeamRepository wraps a TeamRepository with cache-aside reads of team details
+func NewCachedTeamRepository(repo TeamRepository, c cache.Cache, ttl time.Duration) TeamRepository {
+	return &cachedTeamRepository{TeamRepository: repo, cache: c, ttl: ttl}
+}
+
+// similarTeamsCacheTTL is how long similar team rankings are served from the cache
+const similarTeamsCacheTTL = 2 * time.Minute
+
+func teamCacheKey(id uint) string {
+	return cache.Key("team", id)
+}
+
+func (r *cachedTeamRepository) GetTeamByID(id uint) (*Team, error) {
+	ctx := context.Background()
+	var team Team
+	if cache.GetJSON(ctx, r.cache, teamCacheKey(id), &team) {
+		return &team, nil
+	}
+
+	found, err := r.TeamRepository.GetTeamByID(id)
+	if err != nil || found == nil {
+		return found, err
+	}
+	cache.SetJSON(ctx, r.cache, teamCacheKey(id), found, r.ttl)
+	return found, nil
+}
+
+func (r *cachedTeamRepository) UpdateTeam(team *Team) error {
+	err := r.TeamRepository.UpdateTeam(team)
+	cache.Invalidate(context.Background(), r.cache, teamCacheKey(team.ID))
+	return err
+}
+
+func (r *cachedTeamRepository) RestoreTeam(id uint) error {
+	err := r.TeamRepository.RestoreTeam(id)
+	cache.Invalidate(context.Background(), r.cache, teamCacheKey(id))
+	return err
+}
+
+func (r *cachedTeamRepository) PurgeTeam(id uint) error {
+	err := r.TeamRepository.PurgeTeam(id)
+	cache.Invalidate(context.Background(), r.cache, teamCacheKey(id))
+	return err
+}
+
+func (r *cachedTeamRepository) DeleteTeam(id uint, hardDelete, force bool) error {
+	err := r.TeamRepository.DeleteTeam(id, hardDelete, force)
+	cache.Invalidate(context.Background(), r.cache, teamCacheKey(id))
+	return err
+}
+
+func similarTeamsCacheKey(teamID uint, query SimilarTeamsQuery) string {
+	return fmt.Sprintf("%s:%g:%g", cache.Key("team_similar", teamID), query.RatingBand, query.RadiusKm)
+}
+
+// GetSimilarTeams caches rankings for a short, fixed time rather than invalidating them, since
+// they depend on every candidate team's rating and home venue
+func (r *cachedTeamRepository) GetSimilarTeams(teamID uint, query SimilarTeamsQuery) ([]SimilarTeam, error) {
+	ctx := context.Background()
+	key := similarTeamsCacheKey(teamID, query)
+	var similar []SimilarTeam
+	if cache.GetJSON(ctx, r.cache, key, &similar) {
+		return similar, nil
+	}
+
+	found, err := r.TeamRepository.GetSimilarTeams(teamID, query)
+	if err != nil || found == nil {
+		return found, err
+	}
+	cache.SetJSON(ctx, r.cache, key, found, similarTeamsCacheTTL)
+	return found, nil
+}
@@ -0,0 +1,99 @@
+package team
+
+import (
+	"testing"
+
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+)
+
+// stubTeamRepository serves teams from memory and counts the reads that reach it
+type stubTeamRepository struct {
+	TeamRepository
+	teams map[uint]*Team
+	reads int
+}
+
+func (r *stubTeamRepository) GetTeamByID(id uint) (*Team, error) {
+	r.reads++
+	if team, ok := r.teams[id]; ok {
+		copied := *team
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (r *stubTeamRepository) UpdateTeam(team *Team) error {
+	r.teams[team.ID] = team
+	return nil
+}
+
+func (r *stubTeamRepository) DeleteTeam(id uint, hardDelete, force bool) error {
+	delete(r.teams, id)
+	return nil
+}
+
+func TestCachedTeamRepository(t *testing.T) {
+	stub := &stubTeamRepository{teams: map[uint]*Team{7: {Name: "Tigers"}}}
+	stub.teams[7].ID = 7
+	c := testutil.NewMemoryCache()
+	repo := NewCachedTeamRepository(stub, c, 0)
+
+	get := func() *Team {
+		t.Helper()
+		team, err := repo.GetTeamByID(7)
+		if err != nil {
+			t.Fatalf("GetTeamByID() error = %v", err)
+		}
+		return team
+	}
+
+	// A miss reads through and fills the cache; the next read is a hit
+	if team := get(); team == nil || team.Name != "Tigers" {
+		t.Fatalf("GetTeamByID() = %+v, want Tigers", team)
+	}
+	if team := get(); team == nil || team.Name != "Tigers" {
+		t.Fatalf("cached GetTeamByID() = %+v, want Tigers", team)
+	}
+	if stub.reads != 1 || c.Hits != 1 || c.Misses != 1 {
+		t.Errorf("%d database reads, %d hits, %d misses; want 1, 1, 1", stub.reads, c.Hits, c.Misses)
+	}
+
+	// An update drops the entry, so the new name is read from the database
+	if err := repo.UpdateTeam(&Team{Model: stub.teams[7].Model, Name: "Lions"}); err != nil {
+		t.Fatalf("UpdateTeam() error = %v", err)
+	}
+	if c.Has(teamCacheKey(7)) {
+		t.Error("UpdateTeam left the team cached")
+	}
+	if team := get(); team.Name != "Lions" || stub.reads != 2 {
+		t.Errorf("after update GetTeamByID() = %q with %d reads, want Lions with 2", team.Name, stub.reads)
+	}
+
+	// A deleted team is neither served from the cache nor cached as missing
+	if err := repo.DeleteTeam(7, false, false); err != nil {
+		t.Fatalf("DeleteTeam() error = %v", err)
+	}
+	if team := get(); team != nil {
+		t.Errorf("deleted team served as %+v", team)
+	}
+	if c.Has(teamCacheKey(7)) {
+		t.Error("a missing team was cached")
+	}
+}
+
+func TestCachedTeamRepositoryFallsBackWhenCacheIsDown(t *testing.T) {
+	stub := &stubTeamRepository{teams: map[uint]*Team{3: {Name: "Hawks"}}}
+	c := testutil.NewMemoryCache()
+	c.Err = testutil.ErrCacheDown
+	repo := NewCachedTeamRepository(stub, c, 0)
+
+	for i := 0; i < 2; i++ {
+		team, err := repo.GetTeamByID(3)
+		if err != nil || team == nil || team.Name != "Hawks" {
+			t.Fatalf("GetTeamByID() = %+v, %v; want Hawks from the database", team, err)
+		}
+	}
+	if stub.reads != 2 {
+		t.Errorf("%d database reads, want every read to reach the database", stub.reads)
+	}
+}
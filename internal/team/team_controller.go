@@ -1,16 +1,21 @@
 package team
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/DhavalSuthar-24/miow/config" // Assuming your config package
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/internal/notification"
 
 	// "github.com/DhavalSuthar-24/miow/internal/user" // Assuming user package for User model if needed for responses
 	// Generic response package
-	"github.com/DhavalSuthar-24/miow/pkg/responses"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/DhavalSuthar-24/miow/pkg/storage"
 	"github.com/gin-gonic/gin"
 )
 
@@ -31,29 +36,23 @@ const (
 type TeamController struct {
 	repo      TeamRepository
 	appConfig *config.Config
+	notifier  notification.Notifier
+	storage   storage.Storage
 	// userRepo user.UserRepository
 }
 
 // NewTeamController creates a new team controller
-func NewTeamController(repo TeamRepository, appConfig *config.Config /*, userRepo user.UserRepository*/) *TeamController {
+func NewTeamController(repo TeamRepository, appConfig *config.Config, notifier notification.Notifier, store storage.Storage /*, userRepo user.UserRepository*/) *TeamController {
 	return &TeamController{
 		repo:      repo,
 		appConfig: appConfig,
+		notifier:  notifier,
+		storage:   store,
 		// userRepo: userRepo,
 	}
 }
 
 // --- Helper Functions for Auth ---
-// These would typically be part of a middleware or a shared auth utility package
-
-func getCurrentUserID(c *gin.Context) (uint, bool) {
-	userIDVal, exists := c.Get("currentUserID")
-	if !exists {
-		return 0, false
-	}
-	userID, ok := userIDVal.(uint)
-	return userID, ok
-}
 
 // isTeamManager checks if the user is creator, captain, vice_captain or moderator of the team
 func (tc *TeamController) isTeamManager(teamID, userID uint) (bool, error) {
@@ -80,22 +79,20 @@ func (tc *TeamController) isTeamCreator(teamID, userID uint) (bool, error) {
 	return tc.repo.IsUserTeamCreator(teamID, userID)
 }
 
-// isAdminUser checks if the current user has admin privileges
-func isAdminUser(c *gin.Context) bool {
-	rolesVal, exists := c.Get("currentUserRoles")
-	if !exists {
-		return false
-	}
-	roles, ok := rolesVal.([]string)
-	if !ok {
-		return false
+// resolvePosition checks a requested position against the positions of the team's sport and
+// returns it spelled as in the catalog. Sports without a position catalog accept any value.
+// It writes a 400 response and returns false when the position is not allowed.
+func resolvePosition(c *gin.Context, team *Team, position string) (string, bool) {
+	position = strings.TrimSpace(position)
+	if position == "" || len(team.Sport.Positions) == 0 {
+		return position, true
 	}
-	for _, role := range roles {
-		if role == "admin" { // Assuming "admin" is the role name for administrators
-			return true
-		}
+	if pos, ok := team.Sport.Positions.Lookup(position); ok {
+		return pos.Name, true
 	}
-	return false
+	response.ErrorWithDetails(c, http.StatusBadRequest, fmt.Sprintf("Invalid position %q for %s", position, team.Sport.Name),
+		gin.H{"allowed_positions": team.Sport.Positions.Names()})
+	return "", false
 }
 
 // --- DTOs for requests ---
@@ -107,9 +104,10 @@ type CreateTeamRequest struct {
 	SportID      uint   `json:"sport_id" binding:"required"`
 	MinPlayers   int    `json:"min_players" binding:"gte=1"`
 	MaxPlayers   int    `json:"max_players" binding:"gtefield=MinPlayers"`
-	Requirements string `json:"requirements"` // JSON string
+	Requirements string `json:"requirements" binding:"json_object"` // JSON object string
 	Level        string `json:"level"`
-	SocialLinks  string `json:"social_links"` // JSON string
+	SocialLinks  string `json:"social_links" binding:"json_string_map"` // JSON object of network to link
+	HomeVenueID  *uint  `json:"home_venue_id"`
 }
 
 type UpdateTeamRequest struct {
@@ -118,9 +116,11 @@ type UpdateTeamRequest struct {
 	Logo         *string `json:"logo"`
 	MinPlayers   *int    `json:"min_players" binding:"omitempty,gte=1"`
 	MaxPlayers   *int    `json:"max_players" binding:"omitempty,gtefield=MinPlayers"` // This validation might need custom logic if MinPlayers is not also updated
-	Requirements *string `json:"requirements"`                                        // JSON string
+	Requirements *string `json:"requirements" binding:"omitempty,json_object"`        // JSON object string; merged key by key with ?merge=true
 	Level        *string `json:"level"`
-	SocialLinks  *string `json:"social_links"` // JSON string
+	SocialLinks  *string `json:"social_links" binding:"omitempty,json_object"` // JSON object string; merged key by key with ?merge=true
+	HomeVenueID  *uint   `json:"home_venue_id"`                                // 0 clears the home venue
+	Version      *int    `json:"version"`                                      // Version last read; a changed team is not overwritten
 }
 
 type InviteUserRequest struct {
@@ -130,10 +130,15 @@ type InviteUserRequest struct {
 	Message  string `json:"message" binding:"max=500"`
 }
 
+type BlockTeamRequest struct {
+	TeamID uint   `json:"team_id" binding:"required"`
+	Reason string `json:"reason" binding:"max=500"`
+}
+
 type CreateJoinRequest struct {
 	Message  string `json:"message" binding:"max=500"`
 	Position string `json:"position"`
-	Skills   string `json:"skills"` // JSON string
+	Skills   string `json:"skills" binding:"json_string_array"` // JSON array of strings
 }
 
 type UpdateMemberRoleRequest struct {
@@ -141,8 +146,45 @@ type UpdateMemberRoleRequest struct {
 	IsCaptain *bool  `json:"is_captain"` // Explicitly set captain status
 }
 
+// TeamNameAvailability reports whether a team name can be used for a new team
+type TeamNameAvailability struct {
+	Name      string `json:"name"`
+	Available bool   `json:"available"`
+}
+
+// TeamWithCounts is a team list entry with its member count, returned when include_counts=true
+type TeamWithCounts struct {
+	Team
+	ActiveMemberCount int64 `json:"active_member_count"`
+}
+
 // --- Team Handlers ---
 
+// CheckTeamNameAvailability godoc
+// @Summary Check if a team name is available
+// @Description Reports whether no active team already uses the name. Names are compared case-insensitively.
+// @Tags Teams
+// @Produce json
+// @Param name query string true "Team name to check"
+// @Success 200 {object} response.SuccessResponse{data=TeamNameAvailability} "Name availability"
+// @Failure 400 {object} response.ErrorResponse "Missing name"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/available [get]
+func (tc *TeamController) CheckTeamNameAvailability(c *gin.Context) {
+	name := strings.TrimSpace(c.Query("name"))
+	if name == "" {
+		response.Error(c, http.StatusBadRequest, "Team name is required")
+		return
+	}
+
+	existingTeam, err := tc.repo.GetTeamByName(name)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check team name: "+err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, "", TeamNameAvailability{Name: name, Available: existingTeam == nil})
+}
+
 // CreateTeam godoc
 // @Summary Create a new team
 // @Description Creates a new team with the authenticated user as the creator and captain.
@@ -150,29 +192,36 @@ type UpdateMemberRoleRequest struct {
 // @Accept json
 // @Produce json
 // @Param team body CreateTeamRequest true "Team Creation Data"
-// @Success 201 {object} responses.SuccessResponse{data=Team} "Team created successfully"
-// @Failure 400 {object} responses.ErrorResponse "Invalid input"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 201 {object} response.SuccessResponse{data=Team} "Team created successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /teams [post]
 func (tc *TeamController) CreateTeam(c *gin.Context) {
-	userID, authenticated := getCurrentUserID(c)
+	userID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
 	var req CreateTeamRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		response.ValidationError(c, err)
 		return
 	}
 
 	// Check if team name already exists
 	existingTeam, _ := tc.repo.GetTeamByName(req.Name)
 	if existingTeam != nil {
-		responses.SendError(c, http.StatusConflict, "Team name already exists")
+		response.Error(c, http.StatusConflict, "Team name already exists")
+		return
+	}
+
+	if req.HomeVenueID != nil && *req.HomeVenueID == 0 {
+		req.HomeVenueID = nil
+	}
+	if req.HomeVenueID != nil && !tc.checkHomeVenue(c, *req.HomeVenueID) {
 		return
 	}
 
@@ -184,9 +233,10 @@ func (tc *TeamController) CreateTeam(c *gin.Context) {
 		SportID:      req.SportID,
 		MinPlayers:   req.MinPlayers,
 		MaxPlayers:   req.MaxPlayers,
-		Requirements: req.Requirements,
+		Requirements: normalizeJSON(req.Requirements, emptyJSONObject),
 		Level:        req.Level,
-		SocialLinks:  req.SocialLinks,
+		SocialLinks:  normalizeJSON(req.SocialLinks, emptyJSONObject),
+		HomeVenueID:  req.HomeVenueID,
 		Rating:       1000.0, // Default rating
 	}
 	if team.Logo == "" {
@@ -213,13 +263,13 @@ func (tc *TeamController) CreateTeam(c *gin.Context) {
 	})
 
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to create team: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to create team: "+err.Error())
 		return
 	}
 
 	// Reload team to get Sport populated
 	createdTeam, _ := tc.repo.GetTeamByID(team.ID)
-	responses.SendSuccess(c, http.StatusCreated, "Team created successfully", createdTeam)
+	response.Success(c, http.StatusCreated, "Team created successfully", createdTeam)
 }
 
 // GetTeamByID godoc
@@ -228,27 +278,27 @@ func (tc *TeamController) CreateTeam(c *gin.Context) {
 // @Tags Teams
 // @Produce json
 // @Param team_id path uint true "Team ID"
-// @Success 200 {object} responses.SuccessResponse{data=Team} "Team details"
-// @Failure 404 {object} responses.ErrorResponse "Team not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse{data=Team} "Team details"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /teams/{team_id} [get]
 func (tc *TeamController) GetTeamByID(c *gin.Context) {
 	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid team ID")
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
 	team, err := tc.repo.GetTeamByID(uint(teamID))
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to retrieve team: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve team: "+err.Error())
 		return
 	}
 	if team == nil || team.IsDeleted {
-		responses.SendError(c, http.StatusNotFound, "Team not found")
+		response.Error(c, http.StatusNotFound, "Team not found")
 		return
 	}
-	responses.SendSuccess(c, http.StatusOK, "Team retrieved successfully", team)
+	response.Success(c, http.StatusOK, "Team retrieved successfully", team)
 }
 
 // GetAllTeams godoc
@@ -261,8 +311,9 @@ func (tc *TeamController) GetTeamByID(c *gin.Context) {
 // @Param sport_id query int false "Filter by Sport ID"
 // @Param level query string false "Filter by team level (e.g., 'Amateur', 'Professional')"
 // @Param name query string false "Search by team name (case-insensitive, partial match)"
-// @Success 200 {object} responses.PaginatedResponse{data=[]Team} "List of teams"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Param include_counts query bool false "Add active_member_count to each team" default(false)
+// @Success 200 {object} response.PaginatedResponse{data=response.Page{items=[]TeamWithCounts}} "List of teams"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /teams [get]
 func (tc *TeamController) GetAllTeams(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -293,48 +344,70 @@ func (tc *TeamController) GetAllTeams(c *gin.Context) {
 
 	teams, total, err := tc.repo.GetAllTeams(page, limit, filters)
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to retrieve teams: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve teams: "+err.Error())
 		return
 	}
-	responses.SendPaginated(c, http.StatusOK, "Teams retrieved successfully", teams, total, page, limit)
+
+	if includeCounts, _ := strconv.ParseBool(c.DefaultQuery("include_counts", "false")); includeCounts {
+		teamIDs := make([]uint, len(teams))
+		for i, t := range teams {
+			teamIDs[i] = t.ID
+		}
+		counts, err := tc.repo.CountActiveTeamMembersByTeam(teamIDs)
+		if err != nil {
+			response.Error(c, http.StatusInternalServerError, "Failed to count team members: "+err.Error())
+			return
+		}
+		items := make([]TeamWithCounts, len(teams))
+		for i, t := range teams {
+			items[i] = TeamWithCounts{Team: t, ActiveMemberCount: counts[t.ID]}
+		}
+		response.Paginated(c, http.StatusOK, "Teams retrieved successfully", items, total, page, limit)
+		return
+	}
+	response.Paginated(c, http.StatusOK, "Teams retrieved successfully", teams, total, page, limit)
 }
 
 // UpdateTeam godoc
 // @Summary Update a team
 // @Description Updates details of an existing team. Only team creator or captain can update.
+// @Description With merge=true, requirements and social_links are merged into the stored objects key by key
+// @Description instead of replacing them; a key set to null is removed.
 // @Tags Teams
 // @Accept json
 // @Produce json
 // @Param team_id path uint true "Team ID"
+// @Param merge query bool false "Merge requirements and social_links instead of replacing them" default(false)
 // @Param team body UpdateTeamRequest true "Team Update Data"
-// @Success 200 {object} responses.SuccessResponse{data=Team} "Team updated successfully"
-// @Failure 400 {object} responses.ErrorResponse "Invalid input or team ID"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not team creator or captain"
-// @Failure 404 {object} responses.ErrorResponse "Team not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse{data=Team} "Team updated successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid input or team ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Not team creator or captain"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 409 {object} response.ErrorResponse "Team was changed since the version sent"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /teams/{team_id} [put]
 func (tc *TeamController) UpdateTeam(c *gin.Context) {
-	userID, authenticated := getCurrentUserID(c)
+	userID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
 	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid team ID")
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
 	team, err := tc.repo.GetTeamByID(uint(teamID))
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to retrieve team: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve team: "+err.Error())
 		return
 	}
 	if team == nil || team.IsDeleted {
-		responses.SendError(c, http.StatusNotFound, "Team not found")
+		response.Error(c, http.StatusNotFound, "Team not found")
 		return
 	}
 
@@ -343,17 +416,27 @@ func (tc *TeamController) UpdateTeam(c *gin.Context) {
 	memberRole, _ := tc.repo.GetUserTeamRole(uint(teamID), userID)
 
 	if !isCreator && memberRole != RoleCaptain {
-		responses.SendError(c, http.StatusForbidden, "Only the team creator or captain can update the team")
+		response.Error(c, http.StatusForbidden, "Only the team creator or captain can update the team")
 		return
 	}
 
 	var req UpdateTeamRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		response.ValidationError(c, err)
+		return
+	}
+
+	merge, err := strconv.ParseBool(c.DefaultQuery("merge", "false"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid merge parameter")
 		return
 	}
 
 	if req.Name != nil {
+		if existingTeam, _ := tc.repo.GetTeamByName(*req.Name); existingTeam != nil && existingTeam.ID != team.ID {
+			response.Error(c, http.StatusConflict, "Team name already exists")
+			return
+		}
 		team.Name = *req.Name
 	}
 	if req.Description != nil {
@@ -369,88 +452,132 @@ func (tc *TeamController) UpdateTeam(c *gin.Context) {
 		team.MaxPlayers = *req.MaxPlayers
 	}
 	if req.Requirements != nil {
-		team.Requirements = *req.Requirements
+		requirements, err := applyJSONFieldUpdate(team.Requirements, *req.Requirements, merge)
+		if err == nil {
+			err = validateRequirements(requirements)
+		}
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid requirements: "+err.Error())
+			return
+		}
+		team.Requirements = normalizeJSON(requirements, emptyJSONObject)
 	}
 	if req.Level != nil {
 		team.Level = *req.Level
 	}
 	if req.SocialLinks != nil {
-		team.SocialLinks = *req.SocialLinks
+		socialLinks, err := applyJSONFieldUpdate(team.SocialLinks, *req.SocialLinks, merge)
+		if err == nil {
+			err = validateSocialLinks(socialLinks)
+		}
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid social links: "+err.Error())
+			return
+		}
+		team.SocialLinks = normalizeJSON(socialLinks, emptyJSONObject)
+	}
+	if req.HomeVenueID != nil {
+		team.HomeVenueID = nil
+		if *req.HomeVenueID != 0 {
+			if !tc.checkHomeVenue(c, *req.HomeVenueID) {
+				return
+			}
+			team.HomeVenueID = req.HomeVenueID
+		}
 	}
 
 	if req.MaxPlayers != nil && req.MinPlayers == nil && *req.MaxPlayers < team.MinPlayers {
-		responses.SendError(c, http.StatusBadRequest, "Max players cannot be less than current min players without updating min players")
+		response.Error(c, http.StatusBadRequest, "Max players cannot be less than current min players without updating min players")
 		return
 	}
 	if req.MinPlayers != nil && req.MaxPlayers == nil && *req.MinPlayers > team.MaxPlayers {
-		responses.SendError(c, http.StatusBadRequest, "Min players cannot be greater than current max players without updating max players")
+		response.Error(c, http.StatusBadRequest, "Min players cannot be greater than current max players without updating max players")
 		return
 	}
 	if req.MinPlayers != nil && req.MaxPlayers != nil && *req.MinPlayers > *req.MaxPlayers {
-		responses.SendError(c, http.StatusBadRequest, "Min players cannot be greater than max players")
+		response.Error(c, http.StatusBadRequest, "Min players cannot be greater than max players")
 		return
 	}
 
+	if req.Version != nil {
+		team.Version = *req.Version
+	}
+
 	if err := tc.repo.UpdateTeam(team); err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to update team: "+err.Error())
+		if errors.Is(err, ErrTeamVersionConflict) {
+			response.Error(c, http.StatusConflict, "Team was changed by someone else; fetch it again and retry")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to update team: "+err.Error())
 		return
 	}
 	// Reload team to get Sport populated if necessary
 	updatedTeam, _ := tc.repo.GetTeamByID(team.ID)
-	responses.SendSuccess(c, http.StatusOK, "Team updated successfully", updatedTeam)
+	response.Success(c, http.StatusOK, "Team updated successfully", updatedTeam)
 }
 
 // DeleteTeam godoc
 // @Summary Delete a team
 // @Description Deletes a team. Only team creator or an admin can delete. Soft delete by default.
+// @Description A hard delete removes memberships, invitations and join requests, cancels open challenges and is refused while the team has upcoming or live matches unless force is set. Teams with played matches can only be soft deleted.
 // @Tags Teams
 // @Produce json
 // @Param team_id path uint true "Team ID"
 // @Param hard_delete query bool false "Hard delete team and associated data" default(false)
-// @Success 200 {object} responses.SuccessResponse "Team deleted successfully"
-// @Failure 400 {object} responses.ErrorResponse "Invalid team ID"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not team creator or admin"
-// @Failure 404 {object} responses.ErrorResponse "Team not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Param force query bool false "Cancel the team's upcoming and live matches instead of refusing a hard delete" default(false)
+// @Success 200 {object} response.SuccessResponse "Team deleted successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Not team creator or admin"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 409 {object} response.ErrorResponse "Team has upcoming or live matches, or match history"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /teams/{team_id} [delete]
 func (tc *TeamController) DeleteTeam(c *gin.Context) {
-	userID, authenticated := getCurrentUserID(c)
+	userID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
 	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid team ID")
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
 	hardDelete, _ := strconv.ParseBool(c.DefaultQuery("hard_delete", "false"))
+	force, _ := strconv.ParseBool(c.DefaultQuery("force", "false"))
 
 	team, err := tc.repo.GetTeamByID(uint(teamID))
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to retrieve team: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve team: "+err.Error())
 		return
 	}
 	if team == nil { // Check for nil explicitly, IsDeleted handled by GetTeamByID if strict
-		responses.SendError(c, http.StatusNotFound, "Team not found")
+		response.Error(c, http.StatusNotFound, "Team not found")
 		return
 	}
 
 	isCreator, _ := tc.isTeamCreator(uint(teamID), userID)
-	if !isCreator && !isAdminUser(c) {
-		responses.SendError(c, http.StatusForbidden, "Only the team creator or an admin can delete the team")
+	if !isCreator && !middleware.HasRole(c, "admin") {
+		response.Error(c, http.StatusForbidden, "Only the team creator or an admin can delete the team")
 		return
 	}
 
-	if err := tc.repo.DeleteTeam(uint(teamID), hardDelete); err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to delete team: "+err.Error())
+	if err := tc.repo.DeleteTeam(uint(teamID), hardDelete, force); err != nil {
+		switch {
+		case errors.Is(err, ErrTeamHasActiveMatches):
+			response.Error(c, http.StatusConflict, "Team has upcoming or live matches; pass force=true to cancel them and delete the team")
+		case errors.Is(err, ErrTeamHasMatchHistory):
+			response.Error(c, http.StatusConflict, "Team has played matches and can only be soft deleted")
+		default:
+			response.Error(c, http.StatusInternalServerError, "Failed to delete team: "+err.Error())
+		}
 		return
 	}
-	responses.SendSuccess(c, http.StatusOK, "Team deleted successfully", nil)
+	response.Success(c, http.StatusOK, "Team deleted successfully", nil)
 }
 
 // GetMyTeams godoc
@@ -460,15 +587,15 @@ func (tc *TeamController) DeleteTeam(c *gin.Context) {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
-// @Success 200 {object} responses.PaginatedResponse{data=[]Team} "List of user's teams"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.PaginatedResponse{data=response.Page{items=[]Team}} "List of user's teams"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /users/me/teams [get]
 func (tc *TeamController) GetMyTeams(c *gin.Context) {
-	userID, authenticated := getCurrentUserID(c)
+	userID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
@@ -486,10 +613,10 @@ func (tc *TeamController) GetMyTeams(c *gin.Context) {
 
 	teams, total, err := tc.repo.GetTeamsByUserID(userID, page, limit)
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to retrieve your teams: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve your teams: "+err.Error())
 		return
 	}
-	responses.SendPaginated(c, http.StatusOK, "Your teams retrieved successfully", teams, total, page, limit)
+	response.Paginated(c, http.StatusOK, "Your teams retrieved successfully", teams, total, page, limit)
 }
 
 // GetTeamsCreatedByMe godoc
@@ -499,15 +626,15 @@ func (tc *TeamController) GetMyTeams(c *gin.Context) {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
-// @Success 200 {object} responses.PaginatedResponse{data=[]Team} "List of teams created by user"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.PaginatedResponse{data=response.Page{items=[]Team}} "List of teams created by user"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /users/me/teams/created [get]
 func (tc *TeamController) GetTeamsCreatedByMe(c *gin.Context) {
-	userID, authenticated := getCurrentUserID(c)
+	userID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
@@ -525,10 +652,10 @@ func (tc *TeamController) GetTeamsCreatedByMe(c *gin.Context) {
 
 	teams, total, err := tc.repo.GetTeamsCreatedByUserID(userID, page, limit)
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to retrieve created teams: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve created teams: "+err.Error())
 		return
 	}
-	responses.SendPaginated(c, http.StatusOK, "Teams created by you retrieved successfully", teams, total, page, limit)
+	response.Paginated(c, http.StatusOK, "Teams created by you retrieved successfully", teams, total, page, limit)
 }
 
 // --- Team Member Handlers ---
@@ -542,21 +669,21 @@ func (tc *TeamController) GetTeamsCreatedByMe(c *gin.Context) {
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Param role query string false "Filter by member role (e.g., 'player', 'captain')"
-// @Success 200 {object} responses.PaginatedResponse{data=[]TeamMember} "List of team members"
-// @Failure 400 {object} responses.ErrorResponse "Invalid team ID"
-// @Failure 404 {object} responses.ErrorResponse "Team not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.PaginatedResponse{data=response.Page{items=[]TeamMember}} "List of team members"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /teams/{team_id}/members [get]
 func (tc *TeamController) GetTeamMembers(c *gin.Context) {
 	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid team ID")
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
 	team, err := tc.repo.GetTeamByID(uint(teamID))
 	if err != nil || team == nil || team.IsDeleted {
-		responses.SendError(c, http.StatusNotFound, "Team not found")
+		response.Error(c, http.StatusNotFound, "Team not found")
 		return
 	}
 
@@ -583,10 +710,10 @@ func (tc *TeamController) GetTeamMembers(c *gin.Context) {
 	}
 
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to retrieve team members: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve team members: "+err.Error())
 		return
 	}
-	responses.SendPaginated(c, http.StatusOK, "Team members retrieved successfully", members, total, page, limit)
+	response.Paginated(c, http.StatusOK, "Team members retrieved successfully", members, total, page, limit)
 }
 
 // RemoveTeamMember godoc
@@ -596,35 +723,35 @@ func (tc *TeamController) GetTeamMembers(c *gin.Context) {
 // @Produce json
 // @Param team_id path uint true "Team ID"
 // @Param user_id path uint true "User ID of the member to remove"
-// @Success 200 {object} responses.SuccessResponse "Member removed successfully"
-// @Failure 400 {object} responses.ErrorResponse "Invalid ID(s)"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - Insufficient permissions"
-// @Failure 404 {object} responses.ErrorResponse "Team or member not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse "Member removed successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID(s)"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} response.ErrorResponse "Team or member not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /teams/{team_id}/members/{user_id} [delete]
 func (tc *TeamController) RemoveTeamMember(c *gin.Context) {
-	currentUserID, authenticated := getCurrentUserID(c)
+	currentUserID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
 	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid team ID")
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 	memberUserID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid user ID")
+		response.Error(c, http.StatusBadRequest, "Invalid user ID")
 		return
 	}
 
 	team, err := tc.repo.GetTeamByID(uint(teamID))
 	if err != nil || team == nil || team.IsDeleted {
-		responses.SendError(c, http.StatusNotFound, "Team not found")
+		response.Error(c, http.StatusNotFound, "Team not found")
 		return
 	}
 
@@ -633,13 +760,13 @@ func (tc *TeamController) RemoveTeamMember(c *gin.Context) {
 	currentUserRole, _ := tc.repo.GetUserTeamRole(uint(teamID), currentUserID)
 
 	if !isCreator && currentUserRole != RoleCaptain {
-		responses.SendError(c, http.StatusForbidden, "Only team creator or captain can remove members")
+		response.Error(c, http.StatusForbidden, "Only team creator or captain can remove members")
 		return
 	}
 
 	// Prevent creator from being removed by captain through this endpoint
 	if team.CreatedByID == uint(memberUserID) && !isCreator {
-		responses.SendError(c, http.StatusForbidden, "Captain cannot remove the team creator")
+		response.Error(c, http.StatusForbidden, "Captain cannot remove the team creator")
 		return
 	}
 	// Prevent self-removal if you are the creator and sole captain (team would be orphaned)
@@ -647,22 +774,22 @@ func (tc *TeamController) RemoveTeamMember(c *gin.Context) {
 		// Check if other captains exist
 		captains, _, _ := tc.repo.GetTeamMembersByRole(uint(teamID), RoleCaptain, 1, 2) // page 1, limit 2
 		if len(captains) <= 1 && captains[0].UserID == currentUserID {                  // Only self as captain
-			responses.SendError(c, http.StatusForbidden, "Creator cannot leave if they are the sole captain. Promote another captain first or delete the team.")
+			response.Error(c, http.StatusForbidden, "Creator cannot leave if they are the sole captain. Promote another captain first or delete the team.")
 			return
 		}
 	}
 
 	memberToRemove, err := tc.repo.GetTeamMember(uint(teamID), uint(memberUserID))
 	if err != nil || memberToRemove == nil || !memberToRemove.IsActive {
-		responses.SendError(c, http.StatusNotFound, "Member not found in this team or already inactive")
+		response.Error(c, http.StatusNotFound, "Member not found in this team or already inactive")
 		return
 	}
 
 	if err := tc.repo.RemoveTeamMember(uint(teamID), uint(memberUserID)); err != nil { // This now means setting IsActive to false
-		responses.SendError(c, http.StatusInternalServerError, "Failed to remove member: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to remove member: "+err.Error())
 		return
 	}
-	responses.SendSuccess(c, http.StatusOK, "Member removed (deactivated) successfully", nil)
+	response.Success(c, http.StatusOK, "Member removed (deactivated) successfully", nil)
 }
 
 // UpdateTeamMemberRole godoc
@@ -675,41 +802,41 @@ func (tc *TeamController) RemoveTeamMember(c *gin.Context) {
 // @Param team_id path uint true "Team ID"
 // @Param user_id path uint true "User ID of the member whose role is to be updated"
 // @Param role_update body UpdateMemberRoleRequest true "Role Update Data"
-// @Success 200 {object} responses.SuccessResponse{data=TeamMember} "Member role updated successfully"
-// @Failure 400 {object} responses.ErrorResponse "Invalid input or IDs"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - Insufficient permissions"
-// @Failure 404 {object} responses.ErrorResponse "Team or member not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse{data=TeamMember} "Member role updated successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid input or IDs"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} response.ErrorResponse "Team or member not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /teams/{team_id}/members/{user_id}/role [put]
 func (tc *TeamController) UpdateTeamMemberRole(c *gin.Context) {
-	currentUserID, authenticated := getCurrentUserID(c)
+	currentUserID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
 	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid team ID")
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 	memberUserID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid user ID")
+		response.Error(c, http.StatusBadRequest, "Invalid user ID")
 		return
 	}
 
 	var req UpdateMemberRoleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		response.ValidationError(c, err)
 		return
 	}
 
 	team, err := tc.repo.GetTeamByID(uint(teamID))
 	if err != nil || team == nil || team.IsDeleted {
-		responses.SendError(c, http.StatusNotFound, "Team not found")
+		response.Error(c, http.StatusNotFound, "Team not found")
 		return
 	}
 
@@ -718,24 +845,24 @@ func (tc *TeamController) UpdateTeamMemberRole(c *gin.Context) {
 	currentUserRole, _ := tc.repo.GetUserTeamRole(uint(teamID), currentUserID)
 
 	if !isCreator && currentUserRole != RoleCaptain {
-		responses.SendError(c, http.StatusForbidden, "Only team creator or captain can change member roles")
+		response.Error(c, http.StatusForbidden, "Only team creator or captain can change member roles")
 		return
 	}
 
 	memberToUpdate, err := tc.repo.GetTeamMember(uint(teamID), uint(memberUserID))
 	if err != nil || memberToUpdate == nil || !memberToUpdate.IsActive {
-		responses.SendError(c, http.StatusNotFound, "Member not found in this team or is inactive")
+		response.Error(c, http.StatusNotFound, "Member not found in this team or is inactive")
 		return
 	}
 
 	// Creator's role cannot be changed to non-captain by another captain
 	if team.CreatedByID == uint(memberUserID) && req.Role != RoleCaptain && !isCreator {
-		responses.SendError(c, http.StatusForbidden, "Cannot change the role of the team creator to non-captain.")
+		response.Error(c, http.StatusForbidden, "Cannot change the role of the team creator to non-captain.")
 		return
 	}
 	// Creator can only be 'captain'
 	if team.CreatedByID == uint(memberUserID) && req.Role != RoleCaptain {
-		responses.SendError(c, http.StatusForbidden, "Team creator must have the 'captain' role.")
+		response.Error(c, http.StatusForbidden, "Team creator must have the 'captain' role.")
 		return
 	}
 
@@ -747,10 +874,10 @@ func (tc *TeamController) UpdateTeamMemberRole(c *gin.Context) {
 	}
 
 	if err := tc.repo.UpdateTeamMember(memberToUpdate); err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to update member role: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to update member role: "+err.Error())
 		return
 	}
-	responses.SendSuccess(c, http.StatusOK, "Member role updated successfully", memberToUpdate)
+	response.Success(c, http.StatusOK, "Member role updated successfully", memberToUpdate)
 }
 
 // LeaveTeam godoc
@@ -760,36 +887,36 @@ func (tc *TeamController) UpdateTeamMemberRole(c *gin.Context) {
 // @Tags Team Members
 // @Produce json
 // @Param team_id path uint true "Team ID"
-// @Success 200 {object} responses.SuccessResponse "Successfully left the team"
-// @Failure 400 {object} responses.ErrorResponse "Invalid team ID"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - Cannot leave (e.g., creator is sole captain)"
-// @Failure 404 {object} responses.ErrorResponse "Team or member not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse "Successfully left the team"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Cannot leave (e.g., creator is sole captain)"
+// @Failure 404 {object} response.ErrorResponse "Team or member not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /teams/{team_id}/leave [post]
 func (tc *TeamController) LeaveTeam(c *gin.Context) {
-	userID, authenticated := getCurrentUserID(c)
+	userID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
 	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid team ID")
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
 	team, err := tc.repo.GetTeamByID(uint(teamID))
 	if err != nil || team == nil || team.IsDeleted {
-		responses.SendError(c, http.StatusNotFound, "Team not found")
+		response.Error(c, http.StatusNotFound, "Team not found")
 		return
 	}
 
 	member, err := tc.repo.GetTeamMember(uint(teamID), userID)
 	if err != nil || member == nil || !member.IsActive {
-		responses.SendError(c, http.StatusNotFound, "You are not an active member of this team")
+		response.Error(c, http.StatusNotFound, "You are not an active member of this team")
 		return
 	}
 
@@ -799,7 +926,7 @@ func (tc *TeamController) LeaveTeam(c *gin.Context) {
 		// Check if other captains exist
 		captains, _, err := tc.repo.GetTeamMembersByRole(uint(teamID), RoleCaptain, 1, 2) // page 1, limit 2 (includes self if captain)
 		if err != nil {
-			responses.SendError(c, http.StatusInternalServerError, "Failed to check team captains: "+err.Error())
+			response.Error(c, http.StatusInternalServerError, "Failed to check team captains: "+err.Error())
 			return
 		}
 
@@ -811,7 +938,7 @@ func (tc *TeamController) LeaveTeam(c *gin.Context) {
 		}
 
 		if soleCaptain {
-			responses.SendError(c, http.StatusForbidden, "Team creator cannot leave if they are the sole captain. Please promote another member to captain first or delete the team.")
+			response.Error(c, http.StatusForbidden, "Team creator cannot leave if they are the sole captain. Please promote another member to captain first or delete the team.")
 			return
 		}
 		// If creator leaves and there are other captains, ownership doesn't automatically transfer here.
@@ -821,10 +948,10 @@ func (tc *TeamController) LeaveTeam(c *gin.Context) {
 
 	// Set member to inactive
 	if err := tc.repo.RemoveTeamMember(uint(teamID), userID); err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to leave team: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to leave team: "+err.Error())
 		return
 	}
-	responses.SendSuccess(c, http.StatusOK, "Successfully left the team", nil)
+	response.Success(c, http.StatusOK, "Successfully left the team", nil)
 }
 
 // --- Join Request Handlers ---
@@ -837,57 +964,61 @@ func (tc *TeamController) LeaveTeam(c *gin.Context) {
 // @Produce json
 // @Param team_id path uint true "Team ID"
 // @Param join_request body CreateJoinRequest true "Join Request Details"
-// @Success 201 {object} responses.SuccessResponse{data=JoinRequest} "Join request sent successfully"
-// @Failure 400 {object} responses.ErrorResponse "Invalid input or team ID"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - Already a member, or pending request/invitation exists"
-// @Failure 404 {object} responses.ErrorResponse "Team not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 201 {object} response.SuccessResponse{data=JoinRequest} "Join request sent successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid input or team ID, or position not allowed for the sport"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Already a member, or pending request/invitation exists"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /teams/{team_id}/join-requests [post]
 func (tc *TeamController) RequestToJoinTeam(c *gin.Context) {
-	userID, authenticated := getCurrentUserID(c)
+	userID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
 	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid team ID")
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
 	var req CreateJoinRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		response.ValidationError(c, err)
 		return
 	}
 
 	team, err := tc.repo.GetTeamByID(uint(teamID))
 	if err != nil || team == nil || team.IsDeleted {
-		responses.SendError(c, http.StatusNotFound, "Team not found")
+		response.Error(c, http.StatusNotFound, "Team not found")
+		return
+	}
+	position, ok := resolvePosition(c, team, req.Position)
+	if !ok {
 		return
 	}
 
 	// Check if already a member
 	isMember, _ := tc.repo.IsUserTeamMember(uint(teamID), userID)
 	if isMember {
-		responses.SendError(c, http.StatusForbidden, "You are already a member of this team")
+		response.Error(c, http.StatusForbidden, "You are already a member of this team")
 		return
 	}
 
 	// Check for existing pending join request
 	existingRequest, _ := tc.repo.GetPendingJoinRequest(uint(teamID), userID)
 	if existingRequest != nil {
-		responses.SendError(c, http.StatusForbidden, "You already have a pending join request for this team")
+		response.Error(c, http.StatusForbidden, "You already have a pending join request for this team")
 		return
 	}
 
 	// Check for existing pending invitation
 	existingInvitation, _ := tc.repo.GetPendingInvitation(uint(teamID), userID)
 	if existingInvitation != nil {
-		responses.SendError(c, http.StatusForbidden, "You have a pending invitation from this team. Please respond to it.")
+		response.Error(c, http.StatusForbidden, "You have a pending invitation from this team. Please respond to it.")
 		return
 	}
 
@@ -895,17 +1026,25 @@ func (tc *TeamController) RequestToJoinTeam(c *gin.Context) {
 		TeamID:    uint(teamID),
 		UserID:    userID,
 		Message:   req.Message,
-		Position:  req.Position,
-		Skills:    req.Skills,
+		Position:  position,
+		Skills:    normalizeJSON(req.Skills, emptyJSONArray),
 		Status:    StatusPending,
-		ExpiresAt: time.Now().Add(7 * 24 * time.Hour), // Example: 7 days expiry
+		ExpiresAt: time.Now().Add(time.Duration(tc.appConfig.Teams.JoinRequestExpiryHours) * time.Hour),
 	}
 
 	if err := tc.repo.CreateJoinRequest(&joinRequest); err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to send join request: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to send join request: "+err.Error())
 		return
 	}
-	responses.SendSuccess(c, http.StatusCreated, "Join request sent successfully", joinRequest)
+	tc.notifier.Notify(notification.Message{
+		UserID:       team.CreatedByID,
+		Type:         notification.TypeJoinRequest,
+		Title:        "New join request",
+		Body:         fmt.Sprintf("Someone asked to join %s.", team.Name),
+		ResourceType: "join_request",
+		ResourceID:   joinRequest.ID,
+	})
+	response.Success(c, http.StatusCreated, "Join request sent successfully", joinRequest)
 }
 
 // GetJoinRequestsForTeam godoc
@@ -917,41 +1056,41 @@ func (tc *TeamController) RequestToJoinTeam(c *gin.Context) {
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Param status query string false "Filter by status (e.g., 'pending', 'approved', 'rejected')"
-// @Success 200 {object} responses.PaginatedResponse{data=[]JoinRequest} "List of join requests"
-// @Failure 400 {object} responses.ErrorResponse "Invalid team ID"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - Insufficient permissions"
-// @Failure 404 {object} responses.ErrorResponse "Team not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.PaginatedResponse{data=response.Page{items=[]JoinRequest}} "List of join requests"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /teams/{team_id}/join-requests [get]
 func (tc *TeamController) GetJoinRequestsForTeam(c *gin.Context) {
-	currentUserID, authenticated := getCurrentUserID(c)
+	currentUserID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
 	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid team ID")
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
 	team, err := tc.repo.GetTeamByID(uint(teamID))
 	if err != nil || team == nil || team.IsDeleted {
-		responses.SendError(c, http.StatusNotFound, "Team not found")
+		response.Error(c, http.StatusNotFound, "Team not found")
 		return
 	}
 
 	// Authorization: Check if current user is a manager of this team
 	isManager, err := tc.isTeamManager(uint(teamID), currentUserID)
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Error checking permissions: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Error checking permissions: "+err.Error())
 		return
 	}
 	if !isManager {
-		responses.SendError(c, http.StatusForbidden, "Only team managers can view join requests")
+		response.Error(c, http.StatusForbidden, "Only team managers can view join requests")
 		return
 	}
 
@@ -961,10 +1100,10 @@ func (tc *TeamController) GetJoinRequestsForTeam(c *gin.Context) {
 
 	requests, total, err := tc.repo.GetJoinRequestsByTeamID(uint(teamID), statusFilter, page, limit)
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to retrieve join requests: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve join requests: "+err.Error())
 		return
 	}
-	responses.SendPaginated(c, http.StatusOK, "Join requests retrieved successfully", requests, total, page, limit)
+	response.Paginated(c, http.StatusOK, "Join requests retrieved successfully", requests, total, page, limit)
 }
 
 // GetMyJoinRequests godoc
@@ -975,15 +1114,16 @@ func (tc *TeamController) GetJoinRequestsForTeam(c *gin.Context) {
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Param status query string false "Filter by status (e.g., 'pending', 'approved', 'rejected')"
-// @Success 200 {object} responses.PaginatedResponse{data=[]JoinRequest} "List of my join requests"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Param team_id query int false "Only include this team"
+// @Success 200 {object} response.PaginatedResponse{data=response.Page{items=[]JoinRequest}} "List of my join requests"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /users/me/join-requests [get]
 func (tc *TeamController) GetMyJoinRequests(c *gin.Context) {
-	userID, authenticated := getCurrentUserID(c)
+	userID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
@@ -991,12 +1131,22 @@ func (tc *TeamController) GetMyJoinRequests(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	statusFilter := strings.ToLower(c.Query("status"))
 
-	requests, total, err := tc.repo.GetJoinRequestsByUserID(userID, statusFilter, page, limit)
+	var teamID uint
+	if raw := c.Query("team_id"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid team ID")
+			return
+		}
+		teamID = uint(parsed)
+	}
+
+	requests, total, err := tc.repo.GetJoinRequestsByUserID(userID, teamID, statusFilter, page, limit)
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to retrieve your join requests: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve your join requests: "+err.Error())
 		return
 	}
-	responses.SendPaginated(c, http.StatusOK, "Your join requests retrieved successfully", requests, total, page, limit)
+	response.Paginated(c, http.StatusOK, "Your join requests retrieved successfully", requests, total, page, limit)
 }
 
 // RespondToJoinRequest godoc
@@ -1007,65 +1157,65 @@ func (tc *TeamController) GetMyJoinRequests(c *gin.Context) {
 // @Param team_id path uint true "Team ID"
 // @Param request_id path uint true "Join Request ID"
 // @Param action path string true "Action to perform: 'approve' or 'reject'"
-// @Success 200 {object} responses.SuccessResponse "Join request processed"
-// @Failure 400 {object} responses.ErrorResponse "Invalid input, ID(s), or action"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - Insufficient permissions or request not pending"
-// @Failure 404 {object} responses.ErrorResponse "Team or join request not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse "Join request processed"
+// @Failure 400 {object} response.ErrorResponse "Invalid input, ID(s), or action"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Insufficient permissions or request not pending"
+// @Failure 404 {object} response.ErrorResponse "Team or join request not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /teams/{team_id}/join-requests/{request_id}/{action} [put]
 func (tc *TeamController) RespondToJoinRequest(c *gin.Context) {
-	currentUserID, authenticated := getCurrentUserID(c)
+	currentUserID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
 	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid team ID")
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 	requestID, err := strconv.ParseUint(c.Param("request_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid request ID")
+		response.Error(c, http.StatusBadRequest, "Invalid request ID")
 		return
 	}
 	action := strings.ToLower(c.Param("action"))
 	if action != "approve" && action != "reject" {
-		responses.SendError(c, http.StatusBadRequest, "Invalid action. Must be 'approve' or 'reject'.")
+		response.Error(c, http.StatusBadRequest, "Invalid action. Must be 'approve' or 'reject'.")
 		return
 	}
 
 	team, err := tc.repo.GetTeamByID(uint(teamID))
 	if err != nil || team == nil || team.IsDeleted {
-		responses.SendError(c, http.StatusNotFound, "Team not found")
+		response.Error(c, http.StatusNotFound, "Team not found")
 		return
 	}
 
 	isManager, err := tc.isTeamManager(uint(teamID), currentUserID)
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Error checking permissions: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Error checking permissions: "+err.Error())
 		return
 	}
 	if !isManager {
-		responses.SendError(c, http.StatusForbidden, "Only team managers can respond to join requests")
+		response.Error(c, http.StatusForbidden, "Only team managers can respond to join requests")
 		return
 	}
 
 	joinRequest, err := tc.repo.GetJoinRequestByID(uint(requestID))
 	if err != nil || joinRequest == nil {
-		responses.SendError(c, http.StatusNotFound, "Join request not found")
+		response.Error(c, http.StatusNotFound, "Join request not found")
 		return
 	}
 
 	if joinRequest.TeamID != uint(teamID) {
-		responses.SendError(c, http.StatusBadRequest, "Join request does not belong to this team")
+		response.Error(c, http.StatusBadRequest, "Join request does not belong to this team")
 		return
 	}
 	if joinRequest.Status != StatusPending {
-		responses.SendError(c, http.StatusForbidden, "Join request is not pending, cannot be processed.")
+		response.Error(c, http.StatusForbidden, "Join request is not pending, cannot be processed.")
 		return
 	}
 
@@ -1073,7 +1223,7 @@ func (tc *TeamController) RespondToJoinRequest(c *gin.Context) {
 		// Check team max player limit
 		currentMembers, _, _ := tc.repo.GetTeamMembers(uint(teamID), 1, team.MaxPlayers+1) // get all members
 		if len(currentMembers) >= team.MaxPlayers {
-			responses.SendError(c, http.StatusForbidden, "Team has reached its maximum player capacity.")
+			response.Error(c, http.StatusForbidden, "Team has reached its maximum player capacity.")
 			return
 		}
 
@@ -1096,18 +1246,18 @@ func (tc *TeamController) RespondToJoinRequest(c *gin.Context) {
 			return repo.AddTeamMember(&newMember)
 		})
 		if txErr != nil {
-			responses.SendError(c, http.StatusInternalServerError, "Failed to approve join request: "+txErr.Error())
+			response.Error(c, http.StatusInternalServerError, "Failed to approve join request: "+txErr.Error())
 			return
 		}
-		responses.SendSuccess(c, http.StatusOK, "Join request approved and member added", joinRequest)
+		response.Success(c, http.StatusOK, "Join request approved and member added", joinRequest)
 
 	} else { // action == "reject"
 		joinRequest.Status = StatusRejected
 		if err := tc.repo.UpdateJoinRequest(joinRequest); err != nil {
-			responses.SendError(c, http.StatusInternalServerError, "Failed to reject join request: "+err.Error())
+			response.Error(c, http.StatusInternalServerError, "Failed to reject join request: "+err.Error())
 			return
 		}
-		responses.SendSuccess(c, http.StatusOK, "Join request rejected", joinRequest)
+		response.Success(c, http.StatusOK, "Join request rejected", joinRequest)
 	}
 }
 
@@ -1117,39 +1267,39 @@ func (tc *TeamController) RespondToJoinRequest(c *gin.Context) {
 // @Tags Join Requests
 // @Produce json
 // @Param request_id path uint true "Join Request ID"
-// @Success 200 {object} responses.SuccessResponse "Join request cancelled"
-// @Failure 400 {object} responses.ErrorResponse "Invalid request ID"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not owner or request not pending"
-// @Failure 404 {object} responses.ErrorResponse "Join request not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse "Join request cancelled"
+// @Failure 400 {object} response.ErrorResponse "Invalid request ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Not owner or request not pending"
+// @Failure 404 {object} response.ErrorResponse "Join request not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /join-requests/{request_id} [delete]
 func (tc *TeamController) CancelJoinRequest(c *gin.Context) {
-	userID, authenticated := getCurrentUserID(c)
+	userID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
 	requestID, err := strconv.ParseUint(c.Param("request_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid request ID")
+		response.Error(c, http.StatusBadRequest, "Invalid request ID")
 		return
 	}
 
 	joinRequest, err := tc.repo.GetJoinRequestByID(uint(requestID))
 	if err != nil || joinRequest == nil {
-		responses.SendError(c, http.StatusNotFound, "Join request not found")
+		response.Error(c, http.StatusNotFound, "Join request not found")
 		return
 	}
 
 	if joinRequest.UserID != userID {
-		responses.SendError(c, http.StatusForbidden, "You can only cancel your own join requests")
+		response.Error(c, http.StatusForbidden, "You can only cancel your own join requests")
 		return
 	}
 	if joinRequest.Status != StatusPending {
-		responses.SendError(c, http.StatusForbidden, "Only pending join requests can be cancelled")
+		response.Error(c, http.StatusForbidden, "Only pending join requests can be cancelled")
 		return
 	}
 
@@ -1159,10 +1309,10 @@ func (tc *TeamController) CancelJoinRequest(c *gin.Context) {
 
 	joinRequest.Status = StatusCancelled
 	if err := tc.repo.UpdateJoinRequest(joinRequest); err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to cancel join request: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to cancel join request: "+err.Error())
 		return
 	}
-	responses.SendSuccess(c, http.StatusOK, "Join request cancelled successfully", joinRequest)
+	response.Success(c, http.StatusOK, "Join request cancelled successfully", joinRequest)
 }
 
 // --- Team Invitation Handlers ---
@@ -1175,30 +1325,30 @@ func (tc *TeamController) CancelJoinRequest(c *gin.Context) {
 // @Produce json
 // @Param team_id path uint true "Team ID"
 // @Param invite_request body InviteUserRequest true "Invitation Details"
-// @Success 201 {object} responses.SuccessResponse{data=TeamInvitation} "Invitation sent successfully"
-// @Failure 400 {object} responses.ErrorResponse "Invalid input or team ID"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - Insufficient permissions, user already member, or pending request/invitation exists"
-// @Failure 404 {object} responses.ErrorResponse "Team or user to invite not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 201 {object} response.SuccessResponse{data=TeamInvitation} "Invitation sent successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid input or team ID, or position not allowed for the sport"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Insufficient permissions, user already member, or pending request/invitation exists"
+// @Failure 404 {object} response.ErrorResponse "Team or user to invite not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /teams/{team_id}/invitations [post]
 func (tc *TeamController) InviteUserToTeam(c *gin.Context) {
-	currentUserID, authenticated := getCurrentUserID(c)
+	currentUserID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
 	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid team ID")
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
 	var req InviteUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid request payload: "+err.Error())
+		response.ValidationError(c, err)
 		return
 	}
 	if req.Role == "" {
@@ -1207,52 +1357,56 @@ func (tc *TeamController) InviteUserToTeam(c *gin.Context) {
 
 	team, err := tc.repo.GetTeamByID(uint(teamID))
 	if err != nil || team == nil || team.IsDeleted {
-		responses.SendError(c, http.StatusNotFound, "Team not found")
+		response.Error(c, http.StatusNotFound, "Team not found")
 		return
 	}
 
 	isManager, err := tc.isTeamManager(uint(teamID), currentUserID)
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Error checking permissions: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Error checking permissions: "+err.Error())
 		return
 	}
 	if !isManager {
-		responses.SendError(c, http.StatusForbidden, "Only team managers (creator, captain, vice-captain, moderator) can send invitations")
+		response.Error(c, http.StatusForbidden, "Only team managers (creator, captain, vice-captain, moderator) can send invitations")
+		return
+	}
+	position, ok := resolvePosition(c, team, req.Position)
+	if !ok {
 		return
 	}
 
 	// Check if invited user exists (optional, depends on user service availability)
 	// _, err = tc.userRepo.GetUserByID(req.UserID)
 	// if err != nil || invitedUser == nil {
-	// 	responses.SendError(c, http.StatusNotFound, "User to invite not found")
+	// 	response.Error(c, http.StatusNotFound, "User to invite not found")
 	// 	return
 	// }
 
 	// Check if user is already a member
 	isMember, _ := tc.repo.IsUserTeamMember(uint(teamID), req.UserID)
 	if isMember {
-		responses.SendError(c, http.StatusForbidden, "User is already a member of this team")
+		response.Error(c, http.StatusForbidden, "User is already a member of this team")
 		return
 	}
 
 	// Check for existing pending invitation
 	existingInvite, _ := tc.repo.GetPendingInvitation(uint(teamID), req.UserID)
 	if existingInvite != nil {
-		responses.SendError(c, http.StatusForbidden, "User already has a pending invitation for this team")
+		response.Error(c, http.StatusForbidden, "User already has a pending invitation for this team")
 		return
 	}
 
 	// Check for existing pending join request from this user to this team
 	existingJoinRequest, _ := tc.repo.GetPendingJoinRequest(uint(teamID), req.UserID)
 	if existingJoinRequest != nil {
-		responses.SendError(c, http.StatusForbidden, "This user has a pending join request for your team. Please process it instead.")
+		response.Error(c, http.StatusForbidden, "This user has a pending join request for your team. Please process it instead.")
 		return
 	}
 
 	// Check team max player limit
 	currentMembers, _, _ := tc.repo.GetTeamMembers(uint(teamID), 1, team.MaxPlayers+1)
 	if len(currentMembers) >= team.MaxPlayers {
-		responses.SendError(c, http.StatusForbidden, "Team has reached its maximum player capacity. Cannot invite more players.")
+		response.Error(c, http.StatusForbidden, "Team has reached its maximum player capacity. Cannot invite more players.")
 		return
 	}
 
@@ -1260,17 +1414,25 @@ func (tc *TeamController) InviteUserToTeam(c *gin.Context) {
 		TeamID:    uint(teamID),
 		UserID:    req.UserID,
 		Role:      req.Role,
-		Position:  req.Position,
+		Position:  position,
 		Message:   req.Message,
 		Status:    StatusPending,
-		ExpiresAt: time.Now().Add(7 * 24 * time.Hour), // Example: 7 days expiry
+		ExpiresAt: time.Now().Add(time.Duration(tc.appConfig.Teams.InvitationExpiryHours) * time.Hour),
 	}
 
 	if err := tc.repo.CreateTeamInvitation(&invitation); err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to send invitation: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to send invitation: "+err.Error())
 		return
 	}
-	responses.SendSuccess(c, http.StatusCreated, "Invitation sent successfully", invitation)
+	tc.notifier.Notify(notification.Message{
+		UserID:       req.UserID,
+		Type:         notification.TypeTeamInvitation,
+		Title:        "Team invitation",
+		Body:         fmt.Sprintf("You have been invited to join %s.", team.Name),
+		ResourceType: "team_invitation",
+		ResourceID:   invitation.ID,
+	})
+	response.Success(c, http.StatusCreated, "Invitation sent successfully", invitation)
 }
 
 // GetInvitationsForTeam godoc
@@ -1282,40 +1444,40 @@ func (tc *TeamController) InviteUserToTeam(c *gin.Context) {
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Param status query string false "Filter by status (e.g., 'pending', 'accepted', 'rejected')"
-// @Success 200 {object} responses.PaginatedResponse{data=[]TeamInvitation} "List of team invitations"
-// @Failure 400 {object} responses.ErrorResponse "Invalid team ID"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - Insufficient permissions"
-// @Failure 404 {object} responses.ErrorResponse "Team not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.PaginatedResponse{data=response.Page{items=[]TeamInvitation}} "List of team invitations"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /teams/{team_id}/invitations [get]
 func (tc *TeamController) GetInvitationsForTeam(c *gin.Context) {
-	currentUserID, authenticated := getCurrentUserID(c)
+	currentUserID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
 	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid team ID")
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
 		return
 	}
 
 	team, err := tc.repo.GetTeamByID(uint(teamID))
 	if err != nil || team == nil || team.IsDeleted {
-		responses.SendError(c, http.StatusNotFound, "Team not found")
+		response.Error(c, http.StatusNotFound, "Team not found")
 		return
 	}
 
 	isManager, err := tc.isTeamManager(uint(teamID), currentUserID)
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Error checking permissions: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Error checking permissions: "+err.Error())
 		return
 	}
 	if !isManager {
-		responses.SendError(c, http.StatusForbidden, "Only team managers can view team invitations")
+		response.Error(c, http.StatusForbidden, "Only team managers can view team invitations")
 		return
 	}
 
@@ -1325,10 +1487,10 @@ func (tc *TeamController) GetInvitationsForTeam(c *gin.Context) {
 
 	invitations, total, err := tc.repo.GetTeamInvitationsByTeamID(uint(teamID), statusFilter, page, limit)
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to retrieve team invitations: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve team invitations: "+err.Error())
 		return
 	}
-	responses.SendPaginated(c, http.StatusOK, "Team invitations retrieved successfully", invitations, total, page, limit)
+	response.Paginated(c, http.StatusOK, "Team invitations retrieved successfully", invitations, total, page, limit)
 }
 
 // GetMyTeamInvitations godoc
@@ -1339,15 +1501,16 @@ func (tc *TeamController) GetInvitationsForTeam(c *gin.Context) {
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Param status query string false "Filter by status (e.g., 'pending', 'accepted', 'rejected')"
-// @Success 200 {object} responses.PaginatedResponse{data=[]TeamInvitation} "List of my team invitations"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Param team_id query int false "Only include this team"
+// @Success 200 {object} response.PaginatedResponse{data=response.Page{items=[]TeamInvitation}} "List of my team invitations"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /users/me/invitations [get]
 func (tc *TeamController) GetMyTeamInvitations(c *gin.Context) {
-	userID, authenticated := getCurrentUserID(c)
+	userID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
@@ -1355,12 +1518,22 @@ func (tc *TeamController) GetMyTeamInvitations(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	statusFilter := strings.ToLower(c.DefaultQuery("status", StatusPending)) // Default to pending
 
-	invitations, total, err := tc.repo.GetTeamInvitationsByUserID(userID, statusFilter, page, limit)
+	var teamID uint
+	if raw := c.Query("team_id"); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "Invalid team ID")
+			return
+		}
+		teamID = uint(parsed)
+	}
+
+	invitations, total, err := tc.repo.GetTeamInvitationsByUserID(userID, teamID, statusFilter, page, limit)
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to retrieve your team invitations: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve your team invitations: "+err.Error())
 		return
 	}
-	responses.SendPaginated(c, http.StatusOK, "Your team invitations retrieved successfully", invitations, total, page, limit)
+	response.Paginated(c, http.StatusOK, "Your team invitations retrieved successfully", invitations, total, page, limit)
 }
 
 // RespondToTeamInvitation godoc
@@ -1370,57 +1543,57 @@ func (tc *TeamController) GetMyTeamInvitations(c *gin.Context) {
 // @Produce json
 // @Param invitation_id path uint true "Invitation ID"
 // @Param action path string true "Action to perform: 'accept' or 'reject'"
-// @Success 200 {object} responses.SuccessResponse "Invitation processed"
-// @Failure 400 {object} responses.ErrorResponse "Invalid input, ID, or action"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not recipient, invitation not pending, or team full"
-// @Failure 404 {object} responses.ErrorResponse "Invitation not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse "Invitation processed"
+// @Failure 400 {object} response.ErrorResponse "Invalid input, ID, or action"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Not recipient, invitation not pending, or team full"
+// @Failure 404 {object} response.ErrorResponse "Invitation not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /invitations/{invitation_id}/{action} [put]
 func (tc *TeamController) RespondToTeamInvitation(c *gin.Context) {
-	userID, authenticated := getCurrentUserID(c)
+	userID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
 	invitationID, err := strconv.ParseUint(c.Param("invitation_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid invitation ID")
+		response.Error(c, http.StatusBadRequest, "Invalid invitation ID")
 		return
 	}
 	action := strings.ToLower(c.Param("action"))
 	if action != "accept" && action != "reject" {
-		responses.SendError(c, http.StatusBadRequest, "Invalid action. Must be 'accept' or 'reject'.")
+		response.Error(c, http.StatusBadRequest, "Invalid action. Must be 'accept' or 'reject'.")
 		return
 	}
 
 	invitation, err := tc.repo.GetTeamInvitationByID(uint(invitationID))
 	if err != nil || invitation == nil {
-		responses.SendError(c, http.StatusNotFound, "Invitation not found")
+		response.Error(c, http.StatusNotFound, "Invitation not found")
 		return
 	}
 
 	if invitation.UserID != userID {
-		responses.SendError(c, http.StatusForbidden, "This invitation is not for you")
+		response.Error(c, http.StatusForbidden, "This invitation is not for you")
 		return
 	}
 	if invitation.Status != StatusPending {
-		responses.SendError(c, http.StatusForbidden, "Invitation is not pending, cannot be processed.")
+		response.Error(c, http.StatusForbidden, "Invitation is not pending, cannot be processed.")
 		return
 	}
 	if time.Now().After(invitation.ExpiresAt) {
 		invitation.Status = StatusRejected // Or a new "expired" status
 		tc.repo.UpdateTeamInvitation(invitation)
-		responses.SendError(c, http.StatusForbidden, "Invitation has expired.")
+		response.Error(c, http.StatusForbidden, "Invitation has expired.")
 		return
 	}
 
 	if action == "accept" {
 		team, err := tc.repo.GetTeamByID(invitation.TeamID)
 		if err != nil || team == nil || team.IsDeleted {
-			responses.SendError(c, http.StatusNotFound, "Associated team not found or has been deleted")
+			response.Error(c, http.StatusNotFound, "Associated team not found or has been deleted")
 			return
 		}
 		// Check team max player limit
@@ -1428,7 +1601,7 @@ func (tc *TeamController) RespondToTeamInvitation(c *gin.Context) {
 		if len(currentMembers) >= team.MaxPlayers {
 			invitation.Status = StatusRejected // Or a new "team_full" status
 			tc.repo.UpdateTeamInvitation(invitation)
-			responses.SendError(c, http.StatusForbidden, "Team has reached its maximum player capacity. Cannot join.")
+			response.Error(c, http.StatusForbidden, "Team has reached its maximum player capacity. Cannot join.")
 			return
 		}
 
@@ -1450,18 +1623,18 @@ func (tc *TeamController) RespondToTeamInvitation(c *gin.Context) {
 		})
 
 		if txErr != nil {
-			responses.SendError(c, http.StatusInternalServerError, "Failed to accept invitation: "+txErr.Error())
+			response.Error(c, http.StatusInternalServerError, "Failed to accept invitation: "+txErr.Error())
 			return
 		}
-		responses.SendSuccess(c, http.StatusOK, "Invitation accepted and you have joined the team", invitation)
+		response.Success(c, http.StatusOK, "Invitation accepted and you have joined the team", invitation)
 
 	} else { // action == "reject"
 		invitation.Status = StatusRejected
 		if err := tc.repo.UpdateTeamInvitation(invitation); err != nil {
-			responses.SendError(c, http.StatusInternalServerError, "Failed to reject invitation: "+err.Error())
+			response.Error(c, http.StatusInternalServerError, "Failed to reject invitation: "+err.Error())
 			return
 		}
-		responses.SendSuccess(c, http.StatusOK, "Invitation rejected", invitation)
+		response.Success(c, http.StatusOK, "Invitation rejected", invitation)
 	}
 }
 
@@ -1471,46 +1644,46 @@ func (tc *TeamController) RespondToTeamInvitation(c *gin.Context) {
 // @Tags Team Invitations
 // @Produce json
 // @Param invitation_id path uint true "Invitation ID"
-// @Success 200 {object} responses.SuccessResponse "Invitation cancelled"
-// @Failure 400 {object} responses.ErrorResponse "Invalid invitation ID"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - Not manager of the team or invitation not pending"
-// @Failure 404 {object} responses.ErrorResponse "Invitation not found"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse "Invitation cancelled"
+// @Failure 400 {object} response.ErrorResponse "Invalid invitation ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Not manager of the team or invitation not pending"
+// @Failure 404 {object} response.ErrorResponse "Invitation not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /invitations/{invitation_id} [delete]
 func (tc *TeamController) CancelTeamInvitation(c *gin.Context) {
-	currentUserID, authenticated := getCurrentUserID(c)
+	currentUserID, authenticated := middleware.CurrentUserID(c)
 	if !authenticated {
-		responses.SendError(c, http.StatusUnauthorized, "User not authenticated")
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
 		return
 	}
 
 	invitationID, err := strconv.ParseUint(c.Param("invitation_id"), 10, 32)
 	if err != nil {
-		responses.SendError(c, http.StatusBadRequest, "Invalid invitation ID")
+		response.Error(c, http.StatusBadRequest, "Invalid invitation ID")
 		return
 	}
 
 	invitation, err := tc.repo.GetTeamInvitationByID(uint(invitationID))
 	if err != nil || invitation == nil {
-		responses.SendError(c, http.StatusNotFound, "Invitation not found")
+		response.Error(c, http.StatusNotFound, "Invitation not found")
 		return
 	}
 
 	// Authorization: Check if current user is a manager of the team that sent the invitation
 	isManager, err := tc.isTeamManager(invitation.TeamID, currentUserID)
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Error checking permissions: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Error checking permissions: "+err.Error())
 		return
 	}
 	if !isManager {
-		responses.SendError(c, http.StatusForbidden, "Only managers of the inviting team can cancel the invitation")
+		response.Error(c, http.StatusForbidden, "Only managers of the inviting team can cancel the invitation")
 		return
 	}
 
 	if invitation.Status != StatusPending {
-		responses.SendError(c, http.StatusForbidden, "Only pending invitations can be cancelled")
+		response.Error(c, http.StatusForbidden, "Only pending invitations can be cancelled")
 		return
 	}
 
@@ -1519,10 +1692,10 @@ func (tc *TeamController) CancelTeamInvitation(c *gin.Context) {
 	// if err := tc.repo.DeleteTeamInvitation(uint(invitationID)); err != nil { ... }
 	invitation.Status = StatusCancelled
 	if err := tc.repo.UpdateTeamInvitation(invitation); err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to cancel invitation: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to cancel invitation: "+err.Error())
 		return
 	}
-	responses.SendSuccess(c, http.StatusOK, "Team invitation cancelled successfully", invitation)
+	response.Success(c, http.StatusOK, "Team invitation cancelled successfully", invitation)
 }
 
 // --- Admin Specific Endpoints (Example) ---
@@ -1535,26 +1708,326 @@ func (tc *TeamController) CancelTeamInvitation(c *gin.Context) {
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Param include_deleted query bool false "Include soft-deleted teams" default(false)
-// @Success 200 {object} responses.PaginatedResponse{data=[]Team} "List of all teams"
-// @Failure 401 {object} responses.ErrorResponse "Unauthorized"
-// @Failure 403 {object} responses.ErrorResponse "Forbidden - Admin access required"
-// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Success 200 {object} response.PaginatedResponse{data=response.Page{items=[]Team}} "List of all teams"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Admin access required"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /admin/teams [get]
 func (tc *TeamController) AdminGetAllTeams(c *gin.Context) {
-	if !isAdminUser(c) {
-		responses.SendError(c, http.StatusForbidden, "Admin access required")
-		return
-	}
-
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	includeDeleted, _ := strconv.ParseBool(c.DefaultQuery("include_deleted", "false"))
 
 	teams, total, err := tc.repo.GetAllTeamsAdmin(page, limit, includeDeleted)
 	if err != nil {
-		responses.SendError(c, http.StatusInternalServerError, "Failed to retrieve teams: "+err.Error())
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve teams: "+err.Error())
+		return
+	}
+	response.Paginated(c, http.StatusOK, "All teams retrieved successfully", teams, total, page, limit)
+}
+
+// AdminRestoreTeam godoc
+// @Summary (Admin) Restore a deleted team
+// @Description (Admin) Restores a soft-deleted team and reactivates its creator as captain. Fails if an active team now uses the same name.
+// @Tags Admin-Teams
+// @Produce json
+// @Param team_id path uint true "Team ID"
+// @Success 200 {object} response.SuccessResponse{data=Team} "Team restored successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 409 {object} response.ErrorResponse "Team is not deleted, or its name is taken by an active team"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/teams/{team_id}/restore [post]
+func (tc *TeamController) AdminRestoreTeam(c *gin.Context) {
+	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+
+	team, err := tc.repo.GetTeamByIDIncludingDeleted(uint(teamID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve team: "+err.Error())
+		return
+	}
+	if team == nil {
+		response.Error(c, http.StatusNotFound, "Team not found")
+		return
+	}
+	if !team.IsDeleted && !team.DeletedAt.Valid {
+		response.Error(c, http.StatusConflict, "Team is not deleted")
+		return
+	}
+
+	existingTeam, err := tc.repo.GetTeamByName(team.Name)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check team name: "+err.Error())
+		return
+	}
+	if existingTeam != nil && existingTeam.ID != team.ID {
+		response.Error(c, http.StatusConflict, "An active team already uses this name; rename it before restoring")
+		return
+	}
+
+	if err := tc.repo.RestoreTeam(uint(teamID)); err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to restore team: "+err.Error())
+		return
+	}
+
+	restored, err := tc.repo.GetTeamByID(uint(teamID))
+	if err != nil || restored == nil {
+		response.Error(c, http.StatusInternalServerError, "Team restored but could not be reloaded")
+		return
+	}
+	response.Success(c, http.StatusOK, "Team restored successfully", restored)
+}
+
+// AdminPurgeTeam godoc
+// @Summary (Admin) Permanently delete a team
+// @Description (Admin) Permanently removes a soft-deleted team with its members, invitations and join requests. Active teams must be deleted first.
+// @Tags Admin-Teams
+// @Produce json
+// @Param team_id path uint true "Team ID"
+// @Success 200 {object} response.SuccessResponse "Team purged successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Admin access required"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 409 {object} response.ErrorResponse "Team has not been deleted, has upcoming or live matches, or has match history"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /admin/teams/{team_id}/purge [delete]
+func (tc *TeamController) AdminPurgeTeam(c *gin.Context) {
+	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+
+	team, err := tc.repo.GetTeamByIDIncludingDeleted(uint(teamID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve team: "+err.Error())
+		return
+	}
+	if team == nil {
+		response.Error(c, http.StatusNotFound, "Team not found")
+		return
+	}
+	if !team.IsDeleted && !team.DeletedAt.Valid {
+		response.Error(c, http.StatusConflict, "Only deleted teams can be purged; delete the team first")
+		return
+	}
+
+	if err := tc.repo.PurgeTeam(uint(teamID)); err != nil {
+		switch {
+		case errors.Is(err, ErrTeamHasActiveMatches):
+			response.Error(c, http.StatusConflict, "Team still has upcoming or live matches")
+			return
+		case errors.Is(err, ErrTeamHasMatchHistory):
+			response.Error(c, http.StatusConflict, "Team has played matches and cannot be purged")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to purge team: "+err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, "Team purged successfully", nil)
+}
+
+// --- Team Blocks ---
+
+// BlockTeam godoc
+// @Summary Block an opponent team
+// @Description Blocks another team so it can no longer send direct challenges to this team or see this team's open challenges. Only for team managers.
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param team_id path uint true "Team ID"
+// @Param block body BlockTeamRequest true "Team to block"
+// @Success 201 {object} response.SuccessResponse{data=TeamBlock} "Team blocked successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid input or a team blocking itself"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 409 {object} response.ErrorResponse "Team already blocked"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /teams/{team_id}/blocks [post]
+func (tc *TeamController) BlockTeam(c *gin.Context) {
+	currentUserID, authenticated := middleware.CurrentUserID(c)
+	if !authenticated {
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+
+	var req BlockTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+	if req.TeamID == uint(teamID) {
+		response.Error(c, http.StatusBadRequest, "A team cannot block itself")
+		return
+	}
+
+	team, err := tc.repo.GetTeamByID(uint(teamID))
+	if err != nil || team == nil || team.IsDeleted {
+		response.Error(c, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	isManager, err := tc.isTeamManager(uint(teamID), currentUserID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Error checking permissions: "+err.Error())
+		return
+	}
+	if !isManager {
+		response.Error(c, http.StatusForbidden, "Only team managers can block teams")
+		return
+	}
+
+	blockedTeam, err := tc.repo.GetTeamByID(req.TeamID)
+	if err != nil || blockedTeam == nil || blockedTeam.IsDeleted {
+		response.Error(c, http.StatusNotFound, "Team to block not found")
+		return
+	}
+
+	block := &TeamBlock{
+		BlockerTeamID: uint(teamID),
+		BlockedTeamID: req.TeamID,
+		CreatedByID:   currentUserID,
+		Reason:        strings.TrimSpace(req.Reason),
+	}
+	if err := tc.repo.BlockTeam(block); err != nil {
+		if errors.Is(err, ErrTeamAlreadyBlocked) {
+			response.Error(c, http.StatusConflict, "Team is already blocked")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to block team: "+err.Error())
+		return
+	}
+	block.BlockedTeam = *blockedTeam
+
+	response.Success(c, http.StatusCreated, "Team blocked successfully", block)
+}
+
+// GetTeamBlocks godoc
+// @Summary List blocked teams
+// @Description Lists the teams this team has blocked. Only for team managers.
+// @Tags Teams
+// @Produce json
+// @Param team_id path uint true "Team ID"
+// @Success 200 {object} response.SuccessResponse{data=[]TeamBlock} "Blocked teams"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /teams/{team_id}/blocks [get]
+func (tc *TeamController) GetTeamBlocks(c *gin.Context) {
+	currentUserID, authenticated := middleware.CurrentUserID(c)
+	if !authenticated {
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+
+	team, err := tc.repo.GetTeamByID(uint(teamID))
+	if err != nil || team == nil || team.IsDeleted {
+		response.Error(c, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	isManager, err := tc.isTeamManager(uint(teamID), currentUserID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Error checking permissions: "+err.Error())
+		return
+	}
+	if !isManager && !middleware.HasRole(c, "admin") {
+		response.Error(c, http.StatusForbidden, "Only team managers can view blocked teams")
+		return
+	}
+
+	blocks, err := tc.repo.GetTeamBlocks(uint(teamID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve blocked teams: "+err.Error())
+		return
+	}
+	response.Success(c, http.StatusOK, "Blocked teams retrieved successfully", blocks)
+}
+
+// UnblockTeam godoc
+// @Summary Unblock a team
+// @Description Removes a block so the other team can challenge this team again. Only for team managers.
+// @Tags Teams
+// @Produce json
+// @Param team_id path uint true "Team ID"
+// @Param blocked_team_id path uint true "ID of the blocked team"
+// @Success 200 {object} response.SuccessResponse "Team unblocked successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID(s)"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Insufficient permissions"
+// @Failure 404 {object} response.ErrorResponse "Team not found or not blocked"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /teams/{team_id}/blocks/{blocked_team_id} [delete]
+func (tc *TeamController) UnblockTeam(c *gin.Context) {
+	currentUserID, authenticated := middleware.CurrentUserID(c)
+	if !authenticated {
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+	blockedTeamID, err := strconv.ParseUint(c.Param("blocked_team_id"), 10, 32)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid blocked team ID")
+		return
+	}
+
+	team, err := tc.repo.GetTeamByID(uint(teamID))
+	if err != nil || team == nil || team.IsDeleted {
+		response.Error(c, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	isManager, err := tc.isTeamManager(uint(teamID), currentUserID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Error checking permissions: "+err.Error())
+		return
+	}
+	if !isManager {
+		response.Error(c, http.StatusForbidden, "Only team managers can unblock teams")
+		return
+	}
+
+	removed, err := tc.repo.UnblockTeam(uint(teamID), uint(blockedTeamID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to unblock team: "+err.Error())
+		return
+	}
+	if !removed {
+		response.Error(c, http.StatusNotFound, "Team is not blocked")
 		return
 	}
-	responses.SendPaginated(c, http.StatusOK, "All teams retrieved successfully", teams, total, page, limit)
+	response.Success(c, http.StatusOK, "Team unblocked successfully", nil)
 }
@@ -0,0 +1,148 @@
+package team
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/notification"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func TestInviteUserToTeamNotifiesTheInvitee(t *testing.T) {
+	db := newTestDB(t)
+	captain := testutil.CreateUser(t, db, "Captain")
+	invitee := testutil.CreateUser(t, db, "Invitee")
+	tm := createTeam(t, db, captain.ID)
+
+	r := gin.New()
+	r.POST("/teams/:team_id/invitations", asUser(captain.ID), newTestController(t, db).InviteUserToTeam)
+	w := testutil.Request(t, r, http.MethodPost, "/teams/"+itoa(tm.ID)+"/invitations", map[string]interface{}{
+		"user_id": invitee.ID,
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, body = %s; want %d", w.Code, w.Body.String(), http.StatusCreated)
+	}
+	var invitation TeamInvitation
+	testutil.DecodeData(t, w, &invitation)
+
+	// Notifications are delivered in the background
+	var notes []notification.Notification
+	deadline := time.Now().Add(5 * time.Second)
+	for len(notes) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("no in-app notification was created for the invitee")
+		}
+		time.Sleep(10 * time.Millisecond)
+		if err := db.Where("user_id = ?", invitee.ID).Find(&notes).Error; err != nil {
+			t.Fatalf("failed to load notifications: %v", err)
+		}
+	}
+
+	n := notes[0]
+	if len(notes) != 1 || n.Type != notification.TypeTeamInvitation || n.ReadAt != nil {
+		t.Errorf("notifications = %+v, want one unread team invitation", notes)
+	}
+	if n.ResourceType != "team_invitation" || n.ResourceID == nil || *n.ResourceID != invitation.ID {
+		t.Errorf("notification points at %s %v, want team_invitation %d", n.ResourceType, n.ResourceID, invitation.ID)
+	}
+	var captainNotes int64
+	if err := db.Model(&notification.Notification{}).Where("user_id = ?", captain.ID).Count(&captainNotes).Error; err != nil {
+		t.Fatalf("failed to count notifications: %v", err)
+	}
+	if captainNotes != 0 {
+		t.Errorf("the inviting captain received %d notifications, want none", captainNotes)
+	}
+}
+
+// deleteTeam soft deletes the team and deactivates its members, as leaving and deleting would
+func deleteTeam(t *testing.T, db *gorm.DB, tm *Team) {
+	t.Helper()
+	if err := NewTeamRepository(db).DeleteTeam(tm.ID, false, false); err != nil {
+		t.Fatalf("failed to delete team: %v", err)
+	}
+	if err := db.Model(&TeamMember{}).Where("team_id = ?", tm.ID).Update("is_active", false).Error; err != nil {
+		t.Fatalf("failed to deactivate members: %v", err)
+	}
+}
+
+// adminRouter serves the admin team routes without the admin check, which is tested separately
+func adminRouter(tc *TeamController) *gin.Engine {
+	r := gin.New()
+	r.POST("/admin/teams/:team_id/restore", tc.AdminRestoreTeam)
+	r.DELETE("/admin/teams/:team_id/purge", tc.AdminPurgeTeam)
+	return r
+}
+
+func TestAdminRestoreTeam(t *testing.T) {
+	db := newTestDB(t)
+	captain := testutil.CreateUser(t, db, "Captain")
+	tm := createTeam(t, db, captain.ID)
+	r := adminRouter(newTestController(t, db))
+	path := "/admin/teams/" + itoa(tm.ID) + "/restore"
+
+	if w := testutil.Request(t, r, http.MethodPost, path, nil); w.Code != http.StatusConflict {
+		t.Errorf("restoring an active team: status %d, want %d", w.Code, http.StatusConflict)
+	}
+
+	deleteTeam(t, db, tm)
+	w := testutil.Request(t, r, http.MethodPost, path, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s; want %d", w.Code, w.Body.String(), http.StatusOK)
+	}
+	var restored Team
+	testutil.DecodeData(t, w, &restored)
+	if restored.ID != tm.ID || restored.IsDeleted {
+		t.Errorf("restored team = %+v, want team %d not deleted", restored, tm.ID)
+	}
+
+	var member TeamMember
+	if err := db.Where("team_id = ? AND user_id = ?", tm.ID, captain.ID).First(&member).Error; err != nil {
+		t.Fatalf("failed to load creator membership: %v", err)
+	}
+	if !member.IsActive {
+		t.Error("restore did not reactivate the creator's membership")
+	}
+	var members int64
+	if err := db.Model(&TeamMember{}).Where("team_id = ?", tm.ID).Count(&members).Error; err != nil {
+		t.Fatalf("failed to count members: %v", err)
+	}
+	if members != 1 {
+		t.Errorf("team has %d memberships after restore, want the creator's only", members)
+	}
+
+	if w := testutil.Request(t, r, http.MethodPost, "/admin/teams/999999/restore", nil); w.Code != http.StatusNotFound {
+		t.Errorf("restoring a missing team: status %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestAdminRestoreTeamRejectsNameCollision(t *testing.T) {
+	db := newTestDB(t)
+	captain := testutil.CreateUser(t, db, "Captain")
+	deleted := createTeam(t, db, captain.ID)
+	deleteTeam(t, db, deleted)
+
+	other := testutil.CreateUser(t, db, "Other captain")
+	active := createTeam(t, db, other.ID)
+	// Names are compared case-insensitively
+	if err := db.Model(active).Update("name", strings.ToUpper(deleted.Name)).Error; err != nil {
+		t.Fatalf("failed to rename team: %v", err)
+	}
+
+	r := adminRouter(newTestController(t, db))
+	w := testutil.Request(t, r, http.MethodPost, "/admin/teams/"+itoa(deleted.ID)+"/restore", nil)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status = %d, body = %s; want %d", w.Code, w.Body.String(), http.StatusConflict)
+	}
+
+	var stored Team
+	if err := db.First(&stored, deleted.ID).Error; err != nil {
+		t.Fatalf("failed to reload team: %v", err)
+	}
+	if !stored.IsDeleted {
+		t.Error("a team whose name is taken was restored")
+	}
+}
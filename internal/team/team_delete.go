@@ -0,0 +1,103 @@
+package team
+
+import (
+	"gorm.io/gorm"
+)
+
+// Match and challenge statuses as stored by the match package, which imports this package and
+// therefore cannot be imported here.
+var (
+	finishedMatchStatuses   = []string{"completed", "cancelled", "forfeited", "abandoned"}
+	activeChallengeStatuses = []string{"open", "pending", "countered"}
+)
+
+// hardDeleteTeam permanently removes a team and everything that only exists because of it.
+// Upcoming or live matches block the delete unless force is set, in which case they are
+// cancelled and the team is detached from them. Played matches are never rewritten, so a
+// team with match history returns ErrTeamHasMatchHistory. Must run inside a transaction.
+func hardDeleteTeam(tx *gorm.DB, id uint, force bool) error {
+	var activeMatchIDs []uint
+	if err := tx.Table("match_teams").
+		Joins("JOIN matches ON matches.id = match_teams.match_id").
+		Where("match_teams.team_id = ? AND match_teams.deleted_at IS NULL AND matches.deleted_at IS NULL", id).
+		Where("matches.status NOT IN ?", finishedMatchStatuses).
+		Distinct().Pluck("matches.id", &activeMatchIDs).Error; err != nil {
+		return err
+	}
+	if len(activeMatchIDs) > 0 {
+		if !force {
+			return ErrTeamHasActiveMatches
+		}
+		if err := tx.Table("matches").Where("id IN ?", activeMatchIDs).
+			Updates(map[string]interface{}{"status": "cancelled", "version": gorm.Expr("version + 1"), "updated_at": gorm.Expr("NOW()")}).Error; err != nil {
+			return err
+		}
+	}
+
+	// Cancelled matches were never played, so the team's side of them can go.
+	cancelledMatchIDs := tx.Table("matches").Select("id").Where("status = ?", "cancelled")
+	teamSides := tx.Table("match_teams").Select("id").Where("team_id = ? AND match_id IN (?)", id, cancelledMatchIDs)
+	if err := tx.Exec("DELETE FROM match_players WHERE match_team_id IN (?)", teamSides).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM match_lineups WHERE team_id = ? AND match_id IN (?)", id, cancelledMatchIDs).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM match_teams WHERE team_id = ? AND match_id IN (?)", id, cancelledMatchIDs).Error; err != nil {
+		return err
+	}
+
+	var history int64
+	if err := tx.Raw(`SELECT
+		(SELECT COUNT(*) FROM match_teams WHERE team_id = ?) +
+		(SELECT COUNT(*) FROM innings WHERE batting_team_id = ? OR bowling_team_id = ?) +
+		(SELECT COUNT(*) FROM player_match_stats WHERE team_id = ?)`, id, id, id, id).
+		Scan(&history).Error; err != nil {
+		return err
+	}
+	if history > 0 {
+		return ErrTeamHasMatchHistory
+	}
+
+	if err := tx.Exec("DELETE FROM match_lineups WHERE team_id = ?", id).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("UPDATE matches SET winning_team_id = NULL, version = version + 1 WHERE winning_team_id = ?", id).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("UPDATE matches SET toss_winner_team_id = NULL, version = version + 1 WHERE toss_winner_team_id = ?", id).Error; err != nil {
+		return err
+	}
+
+	// Challenges the team was still negotiating are cancelled; all of them lose the reference.
+	if err := tx.Exec("UPDATE challenges SET status = 'cancelled', version = version + 1, updated_at = NOW() WHERE (sender_team_id = ? OR receiver_team_id = ?) AND status IN ?",
+		id, id, activeChallengeStatuses).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("UPDATE challenges SET sender_team_id = NULL, version = version + 1 WHERE sender_team_id = ?", id).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("UPDATE challenges SET receiver_team_id = NULL, version = version + 1 WHERE receiver_team_id = ?", id).Error; err != nil {
+		return err
+	}
+
+	// Free the tournament slots the team held before dropping its registrations.
+	if err := tx.Exec(`UPDATE tournaments SET current_teams = GREATEST(current_teams - 1, 0), version = version + 1
+		WHERE id IN (SELECT tournament_id FROM tournament_teams WHERE team_id = ? AND deleted_at IS NULL)`, id).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM tournament_teams WHERE team_id = ?", id).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Unscoped().Where("blocker_team_id = ? OR blocked_team_id = ?", id, id).Delete(&TeamBlock{}).Error; err != nil {
+		return err
+	}
+
+	for _, model := range []interface{}{&TeamMember{}, &TeamInvitation{}, &JoinRequest{}} {
+		if err := tx.Unscoped().Where("team_id = ?", id).Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Unscoped().Delete(&Team{}, id).Error
+}
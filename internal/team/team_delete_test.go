@@ -0,0 +1,321 @@
+package team_test
+
+// These tests live outside package team so they can migrate the match tables, which the match
+// package owns and which a hard delete cleans up.
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/config"
+	"github.com/DhavalSuthar-24/miow/internal/match"
+	"github.com/DhavalSuthar-24/miow/internal/notification"
+	"github.com/DhavalSuthar-24/miow/internal/sport"
+	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// deleteFixture is a team with a member, a pending invitation and a pending join request
+type deleteFixture struct {
+	db      *gorm.DB
+	repo    team.TeamRepository
+	sportID uint
+	captain uint
+	team    *team.Team
+}
+
+func newDeleteFixture(t *testing.T) *deleteFixture {
+	t.Helper()
+	models := append(testutil.UserModels,
+		&sport.Sport{},
+		&team.Team{}, &team.TeamMember{}, &team.TeamBlock{}, &team.TeamInvitation{}, &team.JoinRequest{},
+		&match.Challenge{}, &match.Match{}, &match.MatchTeam{}, &match.MatchLineup{}, &match.MatchPlayer{},
+		&match.Inning{}, &match.PlayerMatchStat{}, &match.Tournament{}, &match.TournamentTeam{})
+	db := testutil.DB(t, models...)
+
+	f := &deleteFixture{db: db, repo: team.NewTeamRepository(db)}
+	s := &sport.Sport{Name: fmt.Sprintf("Sport %d", testutil.Seq()), IsActive: true}
+	if err := db.Omit("Rules", "Positions", "Equipment").Create(s).Error; err != nil {
+		t.Fatalf("failed to create sport: %v", err)
+	}
+	f.sportID = s.ID
+	f.captain = testutil.CreateUser(t, db, "Captain").ID
+	f.team = f.createTeam(t, f.captain)
+
+	player := testutil.CreateUser(t, db, "Player")
+	invitee := testutil.CreateUser(t, db, "Invitee")
+	applicant := testutil.CreateUser(t, db, "Applicant")
+	f.create(t, &team.TeamMember{TeamID: f.team.ID, UserID: player.ID, Role: team.RolePlayer, IsActive: true, JoinedAt: time.Now(), Stats: "{}"}, "Team")
+	f.create(t, &team.TeamInvitation{TeamID: f.team.ID, UserID: invitee.ID, Status: team.StatusPending, ExpiresAt: time.Now().Add(time.Hour)})
+	f.create(t, &team.JoinRequest{TeamID: f.team.ID, UserID: applicant.ID, Status: team.StatusPending, Skills: "[]", ExpiresAt: time.Now().Add(time.Hour)})
+	return f
+}
+
+func (f *deleteFixture) create(t *testing.T, value interface{}, omit ...string) {
+	t.Helper()
+	db := f.db
+	if len(omit) > 0 {
+		db = db.Omit(omit...)
+	}
+	if err := db.Create(value).Error; err != nil {
+		t.Fatalf("failed to create %T: %v", value, err)
+	}
+}
+
+// createTeam inserts a team with its creator as captain
+func (f *deleteFixture) createTeam(t *testing.T, creatorID uint) *team.Team {
+	t.Helper()
+	tm := &team.Team{
+		Name:         fmt.Sprintf("Team %d", testutil.Seq()),
+		CreatedByID:  creatorID,
+		SportID:      f.sportID,
+		MaxPlayers:   10,
+		Requirements: "{}",
+		Achievements: "[]",
+		SocialLinks:  "{}",
+		MatchHistory: "[]",
+	}
+	f.create(t, tm, "Sport")
+	f.create(t, &team.TeamMember{TeamID: tm.ID, UserID: creatorID, Role: team.RoleCaptain, IsCaptain: true, IsActive: true, JoinedAt: time.Now(), Stats: "{}"}, "Team")
+	return tm
+}
+
+// createMatch inserts a match with the given status between the teams
+func (f *deleteFixture) createMatch(t *testing.T, status match.MatchStatus, teams ...*team.Team) *match.Match {
+	t.Helper()
+	m := &match.Match{
+		CreatedByUserID: f.captain,
+		SportID:         f.sportID,
+		ScheduledAt:     time.Now().Add(24 * time.Hour),
+		CustomRules:     "{}",
+		Status:          status,
+	}
+	f.create(t, m, "CreatedByUser", "Sport", "Venue", "Challenge", "TossWinnerTeam", "WinningTeam", "ManOfTheMatch", "MatchTeams")
+	for _, tm := range teams {
+		f.create(t, &match.MatchTeam{MatchID: m.ID, TeamID: tm.ID, TeamDetails: "{}"}, "Match", "Team")
+	}
+	return m
+}
+
+// softDelete deletes the team the way DELETE /teams/{team_id} does by default
+func (f *deleteFixture) softDelete(t *testing.T) {
+	t.Helper()
+	if err := f.repo.DeleteTeam(f.team.ID, false, false); err != nil {
+		t.Fatalf("failed to soft delete team: %v", err)
+	}
+}
+
+// count returns how many rows of model match the query, including soft-deleted ones
+func (f *deleteFixture) count(t *testing.T, model interface{}, query string, args ...interface{}) int64 {
+	t.Helper()
+	var n int64
+	if err := f.db.Unscoped().Model(model).Where(query, args...).Count(&n).Error; err != nil {
+		t.Fatalf("failed to count %T: %v", model, err)
+	}
+	return n
+}
+
+// assertTeamRowsRemain checks whether the team and its dependent rows still exist
+func (f *deleteFixture) assertTeamRowsRemain(t *testing.T, want bool) {
+	t.Helper()
+	for _, model := range []interface{}{&team.TeamMember{}, &team.TeamInvitation{}, &team.JoinRequest{}} {
+		if n := f.count(t, model, "team_id = ?", f.team.ID); (n > 0) != want {
+			t.Errorf("%d %T rows left for the team, want rows to remain: %v", n, model, want)
+		}
+	}
+	if n := f.count(t, &team.Team{}, "id = ?", f.team.ID); (n > 0) != want {
+		t.Errorf("team row exists: %v, want %v", n > 0, want)
+	}
+}
+
+func (f *deleteFixture) purgeRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	tc := team.NewTeamController(f.repo, &config.Config{}, notification.NewDispatcher(), storage.NewLocalStorage(t.TempDir(), "/uploads"))
+	r := gin.New()
+	r.DELETE("/admin/teams/:team_id/purge", tc.AdminPurgeTeam)
+	return r
+}
+
+func (f *deleteFixture) purge(t *testing.T) int {
+	t.Helper()
+	w := testutil.Request(t, f.purgeRouter(t), http.MethodDelete, fmt.Sprintf("/admin/teams/%d/purge", f.team.ID), nil)
+	return w.Code
+}
+
+func TestAdminPurgeTeamRequiresSoftDelete(t *testing.T) {
+	f := newDeleteFixture(t)
+	if code := f.purge(t); code != http.StatusConflict {
+		t.Errorf("purging an active team: status %d, want %d", code, http.StatusConflict)
+	}
+	f.assertTeamRowsRemain(t, true)
+}
+
+func TestAdminPurgeTeam(t *testing.T) {
+	f := newDeleteFixture(t)
+	f.softDelete(t)
+	if code := f.purge(t); code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", code, http.StatusOK)
+	}
+	f.assertTeamRowsRemain(t, false)
+
+	if code := f.purge(t); code != http.StatusNotFound {
+		t.Errorf("purging a purged team: status %d, want %d", code, http.StatusNotFound)
+	}
+}
+
+func TestAdminPurgeTeamBlockedByMatches(t *testing.T) {
+	for _, status := range []match.MatchStatus{match.StatusMatchUpcoming, match.StatusMatchCompleted} {
+		t.Run(string(status), func(t *testing.T) {
+			f := newDeleteFixture(t)
+			opponent := f.createTeam(t, testutil.CreateUser(t, f.db, "Opponent").ID)
+			f.createMatch(t, status, f.team, opponent)
+			f.softDelete(t)
+
+			if code := f.purge(t); code != http.StatusConflict {
+				t.Errorf("status = %d, want %d", code, http.StatusConflict)
+			}
+			f.assertTeamRowsRemain(t, true)
+		})
+	}
+}
+
+// createChallenge inserts a team challenge from sender to receiver with the given status
+func (f *deleteFixture) createChallenge(t *testing.T, status match.ChallengeStatus, sender, receiver *team.Team) *match.Challenge {
+	t.Helper()
+	ch := &match.Challenge{
+		Title:            fmt.Sprintf("Challenge %d", testutil.Seq()),
+		SportID:          f.sportID,
+		CreatedByUserID:  f.captain,
+		ChallengeType:    match.DirectChallengeTeam,
+		Status:           status,
+		SenderTeamID:     &sender.ID,
+		ReceiverTeamID:   &receiver.ID,
+		ProposedDateTime: time.Now().Add(48 * time.Hour),
+		AdditionalRules:  "{}",
+	}
+	f.create(t, ch, "Sport", "CreatedByUser", "SenderTeam", "ReceiverTeam", "SenderUser", "ReceiverUser", "Venue")
+	return ch
+}
+
+func TestHardDeleteTeamLeavesNoOrphans(t *testing.T) {
+	f := newDeleteFixture(t)
+	opponent := f.createTeam(t, testutil.CreateUser(t, f.db, "Opponent").ID)
+
+	pending := f.createChallenge(t, match.StatusPending, f.team, opponent)
+	received := f.createChallenge(t, match.StatusOpen, opponent, f.team)
+	rejected := f.createChallenge(t, match.StatusRejected, opponent, f.team)
+
+	// A cancelled match was never played, so the team's side of it goes too
+	cancelled := f.createMatch(t, match.StatusMatchCancelled, f.team, opponent)
+	var side match.MatchTeam
+	if err := f.db.Where("match_id = ? AND team_id = ?", cancelled.ID, f.team.ID).First(&side).Error; err != nil {
+		t.Fatalf("failed to load match side: %v", err)
+	}
+	f.create(t, &match.MatchPlayer{MatchTeamID: side.ID, UserID: f.captain}, "MatchTeam", "User")
+	f.create(t, &match.MatchLineup{MatchID: cancelled.ID, TeamID: f.team.ID, UserID: f.captain}, "User")
+
+	tournament := &match.Tournament{Name: "Cup", CreatedByUserID: f.captain, SportID: f.sportID, CurrentTeams: 2, FormatDetails: "{}", Bracket: "{}"}
+	f.create(t, tournament, "CreatedByUser", "Sport", "Teams", "Matches")
+	for _, tm := range []*team.Team{f.team, opponent} {
+		f.create(t, &match.TournamentTeam{TournamentID: tournament.ID, TeamID: tm.ID, RegisteredAt: time.Now()}, "Tournament", "Team")
+	}
+	f.create(t, &team.TeamBlock{BlockerTeamID: opponent.ID, BlockedTeamID: f.team.ID}, "BlockedTeam")
+
+	if err := f.repo.DeleteTeam(f.team.ID, true, false); err != nil {
+		t.Fatalf("DeleteTeam() error = %v", err)
+	}
+
+	f.assertTeamRowsRemain(t, false)
+	id := f.team.ID
+	orphans := []struct {
+		model interface{}
+		query string
+		args  []interface{}
+	}{
+		{&match.MatchTeam{}, "team_id = ?", []interface{}{id}},
+		{&match.MatchLineup{}, "team_id = ?", []interface{}{id}},
+		{&match.TournamentTeam{}, "team_id = ?", []interface{}{id}},
+		{&team.TeamBlock{}, "blocker_team_id = ? OR blocked_team_id = ?", []interface{}{id, id}},
+		{&match.Challenge{}, "sender_team_id = ? OR receiver_team_id = ?", []interface{}{id, id}},
+	}
+	for _, o := range orphans {
+		if n := f.count(t, o.model, o.query, o.args...); n != 0 {
+			t.Errorf("%d %T rows still reference the deleted team", n, o.model)
+		}
+	}
+	if n := f.count(t, &match.MatchPlayer{}, "match_team_id = ?", side.ID); n != 0 {
+		t.Errorf("%d match players left for the deleted team's side", n)
+	}
+	if n := f.count(t, &match.MatchTeam{}, "team_id = ?", opponent.ID); n != 1 {
+		t.Errorf("opponent has %d match sides, want its own side kept", n)
+	}
+
+	wantStatus := map[uint]match.ChallengeStatus{
+		pending.ID:  match.StatusCancelled,
+		received.ID: match.StatusCancelled,
+		rejected.ID: match.StatusRejected,
+	}
+	for id, want := range wantStatus {
+		var ch match.Challenge
+		if err := f.db.First(&ch, id).Error; err != nil {
+			t.Fatalf("failed to reload challenge: %v", err)
+		}
+		if ch.Status != want {
+			t.Errorf("challenge %d status = %q, want %q", id, ch.Status, want)
+		}
+	}
+
+	var stored match.Tournament
+	if err := f.db.First(&stored, tournament.ID).Error; err != nil {
+		t.Fatalf("failed to reload tournament: %v", err)
+	}
+	if stored.CurrentTeams != 1 {
+		t.Errorf("tournament has %d teams, want the deleted team's slot freed", stored.CurrentTeams)
+	}
+}
+
+func TestHardDeleteTeamWithUpcomingMatch(t *testing.T) {
+	f := newDeleteFixture(t)
+	opponent := f.createTeam(t, testutil.CreateUser(t, f.db, "Opponent").ID)
+	upcoming := f.createMatch(t, match.StatusMatchUpcoming, f.team, opponent)
+
+	if err := f.repo.DeleteTeam(f.team.ID, true, false); !errors.Is(err, team.ErrTeamHasActiveMatches) {
+		t.Fatalf("DeleteTeam() error = %v, want ErrTeamHasActiveMatches", err)
+	}
+	f.assertTeamRowsRemain(t, true)
+
+	if err := f.repo.DeleteTeam(f.team.ID, true, true); err != nil {
+		t.Fatalf("forced DeleteTeam() error = %v", err)
+	}
+	f.assertTeamRowsRemain(t, false)
+	if n := f.count(t, &match.MatchTeam{}, "team_id = ?", f.team.ID); n != 0 {
+		t.Errorf("%d match sides still reference the deleted team", n)
+	}
+	var stored match.Match
+	if err := f.db.First(&stored, upcoming.ID).Error; err != nil {
+		t.Fatalf("failed to reload match: %v", err)
+	}
+	if stored.Status != match.StatusMatchCancelled {
+		t.Errorf("match status = %q, want %q", stored.Status, match.StatusMatchCancelled)
+	}
+}
+
+func TestHardDeleteTeamKeepsMatchHistory(t *testing.T) {
+	f := newDeleteFixture(t)
+	opponent := f.createTeam(t, testutil.CreateUser(t, f.db, "Opponent").ID)
+	f.createMatch(t, match.StatusMatchCompleted, f.team, opponent)
+
+	for _, force := range []bool{false, true} {
+		if err := f.repo.DeleteTeam(f.team.ID, true, force); !errors.Is(err, team.ErrTeamHasMatchHistory) {
+			t.Errorf("DeleteTeam(force=%v) error = %v, want ErrTeamHasMatchHistory", force, err)
+		}
+	}
+	f.assertTeamRowsRemain(t, true)
+}
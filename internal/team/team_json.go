@@ -0,0 +1,103 @@
+package team
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Requirements, SocialLinks and join request Skills are stored as JSON strings. Their shape is
+// checked at binding (see pkg/validator); these helpers normalize them, validate merged values
+// and let clients change single keys without resending the whole object.
+
+const (
+	emptyJSONObject = "{}"
+	emptyJSONArray  = "[]"
+)
+
+// errNotJSONObject is returned when a field that must hold a JSON object holds anything else
+var errNotJSONObject = errors.New("must be a JSON object")
+
+// parseJSONObject decodes a JSON object string; an empty string is an empty object
+func parseJSONObject(raw string) (map[string]interface{}, error) {
+	obj := map[string]interface{}{}
+	if raw == "" {
+		return obj, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &obj); err != nil || obj == nil {
+		return nil, errNotJSONObject
+	}
+	return obj, nil
+}
+
+// mergeJSONObject applies patch to current key by key: keys in patch overwrite those in current
+// and keys set to null are removed. Keys not mentioned in patch are kept.
+func mergeJSONObject(current, patch string) (string, error) {
+	patchObj, err := parseJSONObject(patch)
+	if err != nil {
+		return "", err
+	}
+	merged, err := parseJSONObject(current)
+	if err != nil {
+		// Legacy values that are not objects are replaced rather than merged
+		merged = map[string]interface{}{}
+	}
+
+	for key, value := range patchObj {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = value
+	}
+
+	out, err := json.Marshal(merged)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// validateSocialLinks checks social links are a JSON object mapping each network to a link,
+// e.g. {"instagram": "https://instagram.com/warriors"}
+func validateSocialLinks(raw string) error {
+	links, err := parseJSONObject(raw)
+	if err != nil {
+		return err
+	}
+	for network, link := range links {
+		if _, ok := link.(string); !ok {
+			return fmt.Errorf("link for %q must be a string", network)
+		}
+	}
+	return nil
+}
+
+// validateRequirements checks requirements are a JSON object
+func validateRequirements(raw string) error {
+	_, err := parseJSONObject(raw)
+	return err
+}
+
+// normalizeJSON compacts an already validated JSON string, storing empty as the given value so
+// the json columns never hold an empty string
+func normalizeJSON(raw, empty string) string {
+	if raw == "" {
+		return empty
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(raw)); err != nil {
+		return raw
+	}
+	return buf.String()
+}
+
+// applyJSONFieldUpdate returns the new value of a JSON string field. With merge the update is
+// merged into the current value key by key, otherwise it replaces it.
+func applyJSONFieldUpdate(current, update string, merge bool) (string, error) {
+	if !merge {
+		return update, nil
+	}
+	return mergeJSONObject(current, update)
+}
@@ -0,0 +1,166 @@
+package team
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/DhavalSuthar-24/miow/pkg/validator"
+	"github.com/gin-gonic/gin"
+)
+
+func TestMergeJSONObject(t *testing.T) {
+	tests := []struct {
+		name, current, patch, want string
+	}{
+		{"adds and overwrites keys", `{"a":"1","b":"2"}`, `{"b":"3","c":"4"}`, `{"a":"1","b":"3","c":"4"}`},
+		{"null removes a key", `{"a":"1","b":"2"}`, `{"a":null}`, `{"b":"2"}`},
+		{"empty current", "", `{"a":"1"}`, `{"a":"1"}`},
+		{"legacy value is replaced", `["not","an","object"]`, `{"a":"1"}`, `{"a":"1"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergeJSONObject(tt.current, tt.patch)
+			if err != nil {
+				t.Fatalf("mergeJSONObject() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("mergeJSONObject() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+	if _, err := mergeJSONObject(`{}`, `[1]`); err == nil {
+		t.Error("mergeJSONObject() accepted a patch that is not an object")
+	}
+}
+
+func TestNormalizeJSON(t *testing.T) {
+	tests := []struct{ raw, empty, want string }{
+		{"", emptyJSONObject, "{}"},
+		{"", emptyJSONArray, "[]"},
+		{"{ \"a\" : [ 1, 2 ] }\n", emptyJSONObject, `{"a":[1,2]}`},
+		{` [ "x" , "y" ] `, emptyJSONArray, `["x","y"]`},
+	}
+	for _, tt := range tests {
+		if got := normalizeJSON(tt.raw, tt.empty); got != tt.want {
+			t.Errorf("normalizeJSON(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
+
+// fieldErrors returns the field and tag of each validation error in a 400 response
+func fieldErrors(t *testing.T, env testutil.Envelope) map[string]string {
+	t.Helper()
+	var details []response.FieldError
+	if err := json.Unmarshal(env.Error.Details, &details); err != nil {
+		t.Fatalf("details %s are not field errors: %v", env.Error.Details, err)
+	}
+	fields := make(map[string]string, len(details))
+	for _, d := range details {
+		fields[d.Field] = d.Tag
+	}
+	return fields
+}
+
+func TestCreateTeamValidatesJSONFields(t *testing.T) {
+	validator.RegisterJSONFieldNames()
+	db := newTestDB(t)
+	creator := testutil.CreateUser(t, db, "Creator")
+	sportID := createTeam(t, db, creator.ID).SportID
+
+	r := gin.New()
+	r.POST("/teams", asUser(creator.ID), newTestController(t, db).CreateTeam)
+	create := func(name, requirements, socialLinks string) (int, testutil.Envelope) {
+		w := testutil.Request(t, r, http.MethodPost, "/teams", CreateTeamRequest{
+			Name: name, SportID: sportID, MinPlayers: 1, MaxPlayers: 5,
+			Requirements: requirements, SocialLinks: socialLinks,
+		})
+		return w.Code, testutil.DecodeEnvelope(t, w)
+	}
+
+	tests := []struct {
+		name         string
+		requirements string
+		socialLinks  string
+		field, tag   string
+	}{
+		{"malformed requirements", `{"min_age": 16`, "", "requirements", "json_object"},
+		{"requirements as a list", `["boots"]`, "", "requirements", "json_object"},
+		{"malformed social links", "", `{"instagram"}`, "social_links", "json_string_map"},
+		{"social link that is not a string", "", `{"instagram": 42}`, "social_links", "json_string_map"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, env := create("Rejected "+tt.name, tt.requirements, tt.socialLinks)
+			if status != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", status, http.StatusBadRequest)
+			}
+			if fields := fieldErrors(t, env); len(fields) != 1 || fields[tt.field] != tt.tag {
+				t.Errorf("field errors = %v, want %s failing %s", fields, tt.field, tt.tag)
+			}
+		})
+	}
+
+	// Valid values are stored compacted, and missing ones as empty objects
+	status, env := create("Warriors", "{ \"min_age\" : 16 }", "")
+	if status != http.StatusCreated {
+		t.Fatalf("status = %d, want %d: %+v", status, http.StatusCreated, env)
+	}
+	var stored Team
+	if err := db.Where("name = ?", "Warriors").First(&stored).Error; err != nil {
+		t.Fatalf("failed to load team: %v", err)
+	}
+	if stored.Requirements != `{"min_age":16}` || stored.SocialLinks != "{}" {
+		t.Errorf("stored requirements = %q and social links = %q", stored.Requirements, stored.SocialLinks)
+	}
+}
+
+func TestUpdateTeamMergesSocialLinks(t *testing.T) {
+	db := newTestDB(t)
+	captain := testutil.CreateUser(t, db, "Captain")
+	tm := createTeam(t, db, captain.ID)
+	if err := db.Model(tm).Update("social_links", `{"instagram":"https://instagram.com/a","x":"https://x.com/a"}`).Error; err != nil {
+		t.Fatalf("failed to set social links: %v", err)
+	}
+
+	r := gin.New()
+	r.PUT("/teams/:team_id", asUser(captain.ID), newTestController(t, db).UpdateTeam)
+	update := func(query, links string) int {
+		return testutil.Request(t, r, http.MethodPut, "/teams/"+itoa(tm.ID)+query, UpdateTeamRequest{SocialLinks: &links}).Code
+	}
+	stored := func() string {
+		var reloaded Team
+		if err := db.First(&reloaded, tm.ID).Error; err != nil {
+			t.Fatalf("failed to reload team: %v", err)
+		}
+		return reloaded.SocialLinks
+	}
+
+	if status := update("?merge=true", `{"x": null, "youtube": "https://youtube.com/a"}`); status != http.StatusOK {
+		t.Fatalf("merge status = %d, want %d", status, http.StatusOK)
+	}
+	if got, want := stored(), `{"instagram":"https://instagram.com/a","youtube":"https://youtube.com/a"}`; got != want {
+		t.Errorf("social links after merge = %s, want %s", got, want)
+	}
+
+	// A patch that merges into the wrong shape is refused and leaves the links alone
+	before := stored()
+	if status := update("?merge=true", `{"instagram": 7}`); status != http.StatusBadRequest {
+		t.Errorf("merging a number: status = %d, want %d", status, http.StatusBadRequest)
+	}
+	if status := update("", `"just a string"`); status != http.StatusBadRequest {
+		t.Errorf("replacing with a string: status = %d, want %d", status, http.StatusBadRequest)
+	}
+	if got := stored(); got != before {
+		t.Errorf("social links after rejected updates = %s, want %s", got, before)
+	}
+
+	if status := update("", `{"site": "https://a.example"}`); status != http.StatusOK {
+		t.Fatalf("replace status = %d, want %d", status, http.StatusOK)
+	}
+	if got := stored(); got != `{"site":"https://a.example"}` {
+		t.Errorf("social links after replacing = %s", got)
+	}
+}
@@ -0,0 +1,104 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/DhavalSuthar-24/miow/pkg/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// UploadTeamLogo godoc
+// @Summary Upload a team logo
+// @Description Stores a JPEG, PNG, GIF or WebP image and makes it the team's logo, replacing the previous one. The file's content decides its type, and its size is limited by MAX_IMAGE_SIZE_MB. Only the team creator or captain can upload.
+// @Tags Teams
+// @Accept multipart/form-data
+// @Produce json
+// @Param team_id path uint true "Team ID"
+// @Param logo formData file true "Logo image"
+// @Success 200 {object} response.SuccessResponse{data=Team} "Team logo updated"
+// @Failure 400 {object} response.ErrorResponse "Invalid team ID or missing logo"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - Not team creator or captain"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 413 {object} response.ErrorResponse "Logo too large"
+// @Failure 415 {object} response.ErrorResponse "Unsupported image type"
+// @Failure 409 {object} response.ErrorResponse "Team was changed by another request"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /teams/{team_id}/logo [post]
+func (tc *TeamController) UploadTeamLogo(c *gin.Context) {
+	userID, authenticated := middleware.CurrentUserID(c)
+	if !authenticated {
+		response.Error(c, http.StatusUnauthorized, "User not authenticated")
+		return
+	}
+
+	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+
+	team, err := tc.repo.GetTeamByID(uint(teamID))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to retrieve team: "+err.Error())
+		return
+	}
+	if team == nil || team.IsDeleted {
+		response.Error(c, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	isCreator, _ := tc.isTeamCreator(team.ID, userID)
+	memberRole, _ := tc.repo.GetUserTeamRole(team.ID, userID)
+	if !isCreator && memberRole != RoleCaptain {
+		response.Error(c, http.StatusForbidden, "Only the team creator or captain can update the team logo")
+		return
+	}
+
+	file, err := c.FormFile("logo")
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Logo file is required: "+err.Error())
+		return
+	}
+	img, err := storage.ReadImage(file, storage.MaxImageBytes(tc.appConfig))
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrFileTooLarge):
+			response.Error(c, http.StatusRequestEntityTooLarge, err.Error())
+		case errors.Is(err, storage.ErrUnsupportedImageType):
+			response.Error(c, http.StatusUnsupportedMediaType, err.Error())
+		default:
+			response.Error(c, http.StatusBadRequest, "Failed to read logo: "+err.Error())
+		}
+		return
+	}
+
+	key := storage.ImageKey(fmt.Sprintf("teams/%d/logo", team.ID), img)
+	url, err := tc.storage.Put(c.Request.Context(), key, img.Data, img.ContentType)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to store logo: "+err.Error())
+		return
+	}
+
+	team.Logo = url
+	if err := tc.repo.UpdateTeam(team); err != nil {
+		if delErr := tc.storage.Delete(context.Background(), key); delErr != nil {
+			middleware.Logger(c).Error("failed to remove orphaned logo", "key", key, "error", delErr)
+		}
+		if errors.Is(err, ErrTeamVersionConflict) {
+			response.Error(c, http.StatusConflict, "Team was changed by someone else; fetch it again and retry")
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "Failed to update team: "+err.Error())
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Team logo updated successfully", team)
+}
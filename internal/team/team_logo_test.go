@@ -0,0 +1,73 @@
+package team
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/config"
+	"github.com/DhavalSuthar-24/miow/internal/notification"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/pkg/storage"
+	"github.com/gin-gonic/gin"
+)
+
+func TestUploadTeamLogo(t *testing.T) {
+	db := newTestDB(t)
+	captain := testutil.CreateUser(t, db, "Captain")
+	player := testutil.CreateUser(t, db, "Player")
+	tm := createTeam(t, db, captain.ID)
+	if err := db.Omit("Team").Create(&TeamMember{TeamID: tm.ID, UserID: player.ID, Role: RolePlayer,
+		JoinedAt: time.Now(), IsActive: true, Stats: "{}"}).Error; err != nil {
+		t.Fatalf("failed to add member: %v", err)
+	}
+
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	tc := NewTeamController(NewTeamRepository(db), cfg, notification.NewDefaultDispatcher(db, cfg),
+		storage.NewLocalStorage(dir, "/uploads"))
+	upload := func(userID uint, data []byte) *httptest.ResponseRecorder {
+		t.Helper()
+		r := gin.New()
+		r.POST("/teams/:team_id/logo", asUser(userID), tc.UploadTeamLogo)
+		return testutil.Upload(t, r, "/teams/"+itoa(tm.ID)+"/logo", "logo", "logo.png", data)
+	}
+	logo := func() string {
+		t.Helper()
+		var stored Team
+		if err := db.First(&stored, tm.ID).Error; err != nil {
+			t.Fatalf("failed to reload team: %v", err)
+		}
+		return stored.Logo
+	}
+
+	if w := upload(captain.ID, testutil.PNG); w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	first := logo()
+	if !strings.HasPrefix(first, "/uploads/teams/"+itoa(tm.ID)+"/logo/") || !strings.HasSuffix(first, ".png") {
+		t.Fatalf("team logo = %q, want the uploaded PNG's URL", first)
+	}
+	if _, err := os.Stat(filepath.Join(dir, strings.TrimPrefix(first, "/uploads/"))); err != nil {
+		t.Errorf("logo file not stored: %v", err)
+	}
+
+	// A new upload replaces the logo
+	if w := upload(captain.ID, testutil.PNG); w.Code != http.StatusOK {
+		t.Fatalf("second upload status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	if second := logo(); second == first || second == "" {
+		t.Errorf("team logo after a second upload = %q, want a new URL", second)
+	}
+
+	if w := upload(player.ID, testutil.PNG); w.Code != http.StatusForbidden {
+		t.Errorf("player upload status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if w := upload(captain.ID, []byte("GIF? no, plain text")); w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("text upload status = %d, want %d", w.Code, http.StatusUnsupportedMediaType)
+	}
+}
@@ -0,0 +1,74 @@
+package team
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DhavalSuthar-24/miow/internal/sport"
+	"gorm.io/gorm"
+)
+
+// EnsureTeamNameIndex adds the unique index that makes active team names case-insensitively
+// unique, mirroring GetTeamByName. It fails with a list of the clashing names if existing
+// teams already differ only by case; rename them and restart. It is safe to run on every
+// startup.
+func EnsureTeamNameIndex(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&Team{}) {
+		return nil
+	}
+
+	var duplicates []string
+	if err := db.Model(&Team{}).
+		Select("LOWER(name)").
+		Where("is_deleted = ?", false).
+		Group("LOWER(name)").
+		Having("COUNT(*) > 1").
+		Pluck("LOWER(name)", &duplicates).Error; err != nil {
+		return fmt.Errorf("failed to check for duplicate team names: %w", err)
+	}
+	if len(duplicates) > 0 {
+		return fmt.Errorf("teams with names differing only by case must be renamed first: %v", duplicates)
+	}
+
+	if err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_teams_name_lower
+		ON teams (LOWER(name)) WHERE is_deleted = false AND deleted_at IS NULL`).Error; err != nil {
+		return fmt.Errorf("failed to create team name index: %w", err)
+	}
+	return nil
+}
+
+// NormalizePositions rewrites free-text positions on team members, invitations and join
+// requests to the spelling used by their sport's position catalog, matching names and
+// abbreviations case-insensitively. Positions that match nothing are left as they are.
+// It is safe to run on every startup.
+func NormalizePositions(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&Team{}) {
+		return nil
+	}
+
+	var sports []sport.Sport
+	if err := db.Find(&sports).Error; err != nil {
+		return fmt.Errorf("failed to load sport positions: %w", err)
+	}
+
+	for _, model := range []interface{}{&TeamMember{}, &TeamInvitation{}, &JoinRequest{}} {
+		if !db.Migrator().HasTable(model) {
+			continue
+		}
+		for _, s := range sports {
+			for _, pos := range s.Positions {
+				aliases := []string{strings.ToLower(pos.Name)}
+				if pos.Abbreviation != "" {
+					aliases = append(aliases, strings.ToLower(pos.Abbreviation))
+				}
+				if err := db.Model(model).
+					Where("team_id IN (?)", db.Model(&Team{}).Select("id").Where("sport_id = ?", s.ID)).
+					Where("LOWER(TRIM(position)) IN ? AND position <> ?", aliases, pos.Name).
+					UpdateColumn("position", pos.Name).Error; err != nil {
+					return fmt.Errorf("failed to normalize %s positions: %w", pos.Name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@
 package team
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/DhavalSuthar-24/miow/internal/sport"
@@ -11,12 +12,16 @@ import (
 // Team represents a sports team
 type Team struct {
 	gorm.Model
+	// Version is bumped by every write to the row so a stale full update can be detected
+	Version int `json:"version" gorm:"not null;default:1"`
+
 	Name           string      `json:"name" gorm:"not null"`
 	Description    string      `json:"description"`
 	Logo           string      `json:"logo"`
 	CreatedByID    uint        `json:"created_by_id" gorm:"index"`
 	Sport          sport.Sport `json:"sport" gorm:"foreignKey:SportID"`
 	SportID        uint        `json:"sport_id" gorm:"index"`
+	HomeVenueID    *uint       `json:"home_venue_id,omitempty" gorm:"index"` // Where the team usually plays
 	MinPlayers     int         `json:"min_players"`
 	MaxPlayers     int         `json:"max_players"`
 	Requirements   string      `json:"requirements" gorm:"type:json"`
@@ -34,9 +39,9 @@ type Team struct {
 // TeamMember represents a user's membership in a team
 type TeamMember struct {
 	gorm.Model
-	TeamID       uint      `json:"team_id" gorm:"index"`
+	TeamID       uint      `json:"team_id" gorm:"index;uniqueIndex:idx_team_members_team_user"` // AddTeamMember upserts on (team_id, user_id)
 	Team         Team      `json:"team" gorm:"foreignKey:TeamID"`
-	UserID       uint      `json:"user_id" gorm:"index"`
+	UserID       uint      `json:"user_id" gorm:"index;uniqueIndex:idx_team_members_team_user"`
 	Role         string    `json:"role" gorm:"default:'player'"`
 	Position     string    `json:"position"`
 	JoinedAt     time.Time `json:"joined_at"`
@@ -46,6 +51,17 @@ type TeamMember struct {
 	Stats        string    `json:"stats" gorm:"type:json"`
 }
 
+// TeamBlock records that a team does not want to play another team. The blocked team cannot
+// send direct challenges to the blocker and does not see the blocker's open challenges.
+type TeamBlock struct {
+	gorm.Model
+	BlockerTeamID uint   `json:"blocker_team_id" gorm:"not null;uniqueIndex:idx_team_block"`
+	BlockedTeamID uint   `json:"blocked_team_id" gorm:"not null;uniqueIndex:idx_team_block;index"`
+	BlockedTeam   Team   `json:"blocked_team" gorm:"foreignKey:BlockedTeamID"`
+	CreatedByID   uint   `json:"created_by_id"`
+	Reason        string `json:"reason,omitempty"`
+}
+
 // TeamInvitation for inviting users to join teams
 type TeamInvitation struct {
 	gorm.Model
@@ -69,3 +85,42 @@ type JoinRequest struct {
 	Skills    string    `json:"skills" gorm:"type:json"`
 	ExpiresAt time.Time `json:"expires_at"`
 }
+
+// expiryInfo is the computed expiry state included with invitations and join requests
+// so clients can show e.g. "expires in 2 days" without doing the arithmetic themselves.
+type expiryInfo struct {
+	IsExpired          bool   `json:"is_expired"`
+	SecondsUntilExpiry *int64 `json:"seconds_until_expiry,omitempty"` // Omitted when no expiry is set
+}
+
+func newExpiryInfo(expiresAt time.Time) expiryInfo {
+	if expiresAt.IsZero() {
+		return expiryInfo{}
+	}
+	remaining := int64(time.Until(expiresAt).Seconds())
+	if remaining < 0 {
+		remaining = 0
+	}
+	return expiryInfo{
+		IsExpired:          !time.Now().Before(expiresAt),
+		SecondsUntilExpiry: &remaining,
+	}
+}
+
+// MarshalJSON adds the computed expiry fields to the invitation
+func (ti TeamInvitation) MarshalJSON() ([]byte, error) {
+	type invitation TeamInvitation
+	return json.Marshal(struct {
+		invitation
+		expiryInfo
+	}{invitation(ti), newExpiryInfo(ti.ExpiresAt)})
+}
+
+// MarshalJSON adds the computed expiry fields to the join request
+func (jr JoinRequest) MarshalJSON() ([]byte, error) {
+	type joinRequest JoinRequest
+	return json.Marshal(struct {
+		joinRequest
+		expiryInfo
+	}{joinRequest(jr), newExpiryInfo(jr.ExpiresAt)})
+}
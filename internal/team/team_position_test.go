@@ -0,0 +1,178 @@
+package team
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/sport"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/gin-gonic/gin"
+)
+
+var footballPositions = []sport.Position{
+	{Name: "Goalkeeper", Abbreviation: "GK"},
+	{Name: "Striker", Abbreviation: "ST"},
+}
+
+// positionCases are requested positions and how they are stored; an empty stored position
+// with status 400 means the request is rejected
+var positionCases = []struct {
+	name      string
+	requested string
+	status    int
+	stored    string
+}{
+	{"catalog name", "Goalkeeper", http.StatusCreated, "Goalkeeper"},
+	{"different case and spaces", "  striker ", http.StatusCreated, "Striker"},
+	{"abbreviation", "gk", http.StatusCreated, "Goalkeeper"},
+	{"no position", "", http.StatusCreated, ""},
+	{"unknown position", "Quarterback", http.StatusBadRequest, ""},
+}
+
+// assertAllowedPositions checks that a rejection lists the sport's positions
+func assertAllowedPositions(t *testing.T, details json.RawMessage) {
+	t.Helper()
+	var allowed struct {
+		AllowedPositions []string `json:"allowed_positions"`
+	}
+	if err := json.Unmarshal(details, &allowed); err != nil || len(allowed.AllowedPositions) != 2 ||
+		allowed.AllowedPositions[0] != "Goalkeeper" || allowed.AllowedPositions[1] != "Striker" {
+		t.Errorf("error details = %s, want the allowed positions", details)
+	}
+}
+
+func TestInviteUserToTeamValidatesPosition(t *testing.T) {
+	db := newTestDB(t)
+	captain := testutil.CreateUser(t, db, "Captain")
+	tm := createTeam(t, db, captain.ID)
+	setPositions(t, db, tm.SportID, footballPositions...)
+
+	r := gin.New()
+	r.POST("/teams/:team_id/invitations", asUser(captain.ID), newTestController(t, db).InviteUserToTeam)
+	for _, tt := range positionCases {
+		t.Run(tt.name, func(t *testing.T) {
+			invitee := testutil.CreateUser(t, db, "Invitee")
+			w := testutil.Request(t, r, http.MethodPost, "/teams/"+itoa(tm.ID)+"/invitations", InviteUserRequest{
+				UserID:   invitee.ID,
+				Position: tt.requested,
+			})
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if tt.status != http.StatusCreated {
+				assertAllowedPositions(t, testutil.DecodeEnvelope(t, w).Error.Details)
+				return
+			}
+			var invitation TeamInvitation
+			if err := db.Where("team_id = ? AND user_id = ?", tm.ID, invitee.ID).First(&invitation).Error; err != nil {
+				t.Fatalf("failed to load invitation: %v", err)
+			}
+			if invitation.Position != tt.stored {
+				t.Errorf("stored position = %q, want %q", invitation.Position, tt.stored)
+			}
+		})
+	}
+}
+
+func TestRequestToJoinTeamValidatesPosition(t *testing.T) {
+	db := newTestDB(t)
+	captain := testutil.CreateUser(t, db, "Captain")
+	tm := createTeam(t, db, captain.ID)
+	setPositions(t, db, tm.SportID, footballPositions...)
+	tc := newTestController(t, db)
+
+	for _, tt := range positionCases {
+		t.Run(tt.name, func(t *testing.T) {
+			player := testutil.CreateUser(t, db, "Player")
+			r := gin.New()
+			r.POST("/teams/:team_id/join-requests", asUser(player.ID), tc.RequestToJoinTeam)
+			w := testutil.Request(t, r, http.MethodPost, "/teams/"+itoa(tm.ID)+"/join-requests", CreateJoinRequest{
+				Position: tt.requested,
+			})
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if tt.status != http.StatusCreated {
+				assertAllowedPositions(t, testutil.DecodeEnvelope(t, w).Error.Details)
+				return
+			}
+			var request JoinRequest
+			if err := db.Where("team_id = ? AND user_id = ?", tm.ID, player.ID).First(&request).Error; err != nil {
+				t.Fatalf("failed to load join request: %v", err)
+			}
+			if request.Position != tt.stored {
+				t.Errorf("stored position = %q, want %q", request.Position, tt.stored)
+			}
+		})
+	}
+}
+
+func TestSportWithoutPositionsAcceptsAnyPosition(t *testing.T) {
+	db := newTestDB(t)
+	captain := testutil.CreateUser(t, db, "Captain")
+	invitee := testutil.CreateUser(t, db, "Invitee")
+	tm := createTeam(t, db, captain.ID)
+
+	r := gin.New()
+	r.POST("/teams/:team_id/invitations", asUser(captain.ID), newTestController(t, db).InviteUserToTeam)
+	w := testutil.Request(t, r, http.MethodPost, "/teams/"+itoa(tm.ID)+"/invitations", InviteUserRequest{
+		UserID:   invitee.ID,
+		Position: "Sweeper keeper",
+	})
+	if w.Code != http.StatusCreated {
+		t.Errorf("status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+	}
+}
+
+func TestNormalizePositions(t *testing.T) {
+	db := newTestDB(t)
+	captain := testutil.CreateUser(t, db, "Captain")
+	tm := createTeam(t, db, captain.ID)
+	setPositions(t, db, tm.SportID, footballPositions...)
+	uncatalogued := createTeam(t, db, captain.ID)
+
+	members := map[string]string{"gk": "Goalkeeper", " STRIKER": "Striker", "Winger": "Winger"}
+	for free := range members {
+		player := testutil.CreateUser(t, db, "Player")
+		if err := db.Omit("Team").Create(&TeamMember{TeamID: tm.ID, UserID: player.ID, Role: RolePlayer,
+			Position: free, JoinedAt: time.Now(), IsActive: true, Stats: "{}"}).Error; err != nil {
+			t.Fatalf("failed to add member: %v", err)
+		}
+	}
+	other := testutil.CreateUser(t, db, "Other")
+	if err := db.Omit("Team").Create(&TeamMember{TeamID: uncatalogued.ID, UserID: other.ID, Role: RolePlayer,
+		Position: "gk", JoinedAt: time.Now(), IsActive: true, Stats: "{}"}).Error; err != nil {
+		t.Fatalf("failed to add member: %v", err)
+	}
+
+	// Running it twice must not change anything further
+	for i := 0; i < 2; i++ {
+		if err := NormalizePositions(db); err != nil {
+			t.Fatalf("NormalizePositions() error = %v", err)
+		}
+	}
+
+	var stored []TeamMember
+	if err := db.Where("team_id = ? AND user_id <> ?", tm.ID, captain.ID).Find(&stored).Error; err != nil {
+		t.Fatalf("failed to load members: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, m := range stored {
+		got[m.Position] = true
+	}
+	for _, want := range members {
+		if !got[want] {
+			t.Errorf("positions after normalizing = %v, want %q among them", got, want)
+		}
+	}
+
+	var untouched TeamMember
+	if err := db.Where("team_id = ?", uncatalogued.ID).Where("user_id = ?", other.ID).First(&untouched).Error; err != nil {
+		t.Fatalf("failed to load member: %v", err)
+	}
+	if untouched.Position != "gk" {
+		t.Errorf("position on a sport without a catalog = %q, want it left as gk", untouched.Position)
+	}
+}
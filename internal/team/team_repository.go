@@ -1,22 +1,43 @@
 package team
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
 )
 
+// ErrTeamHasActiveMatches is returned when a hard delete is attempted on a team with upcoming or
+// live matches and the caller did not force it.
+var ErrTeamHasActiveMatches = errors.New("team has upcoming or live matches")
+
+// ErrTeamHasMatchHistory is returned when a hard delete would erase played matches, innings or
+// player stats recorded for the team. Such teams can only be soft deleted.
+var ErrTeamHasMatchHistory = errors.New("team has recorded match history")
+
+// ErrTeamAlreadyBlocked is returned when a team blocks a team it has already blocked.
+var ErrTeamAlreadyBlocked = errors.New("team is already blocked")
+
+// ErrTeamVersionConflict is returned by UpdateTeam when the team was changed by another request
+// since it was loaded.
+var ErrTeamVersionConflict = errors.New("team was modified by another request")
+
 type TeamRepository interface {
 	// Team operations
 	CreateTeam(team *Team) error
 	GetTeamByID(id uint) (*Team, error)
 	GetTeamByName(name string) (*Team, error)
 	GetAllTeams(page, limit int, filters map[string]interface{}) ([]Team, int64, error)
+	GetSimilarTeams(teamID uint, query SimilarTeamsQuery) ([]SimilarTeam, error)
+	VenueExists(venueID uint) (bool, error)
 	UpdateTeam(team *Team) error
-	DeleteTeam(id uint, hardDelete bool) error
+	DeleteTeam(id uint, hardDelete, force bool) error
 	GetTeamsByUserID(userID uint, page, limit int) ([]Team, int64, error) // Teams user is a member of
 	GetTeamsCreatedByUserID(userID uint, page, limit int) ([]Team, int64, error)
+	GetTeamsManagedByUserID(userID, sportID uint) ([]Team, error)
 
 	// TeamMember operations
 	AddTeamMember(member *TeamMember) error
@@ -29,12 +50,15 @@ type TeamRepository interface {
 	IsUserTeamCreator(teamID, userID uint) (bool, error)
 	GetUserTeamRole(teamID, userID uint) (string, error)
 	GetTeamCaptainsAndModerators(teamID uint) ([]TeamMember, error) // Includes creator, captains, vice-captains, moderators
+	CountActiveTeamMembers(teamID uint) (int64, error)
+	CountActiveTeamMembersByTeam(teamIDs []uint) (map[uint]int64, error)
+	GetActiveTeamMemberIDs(teamID uint, userIDs []uint) (map[uint]bool, error)
 
 	// TeamInvitation operations
 	CreateTeamInvitation(invitation *TeamInvitation) error
 	GetTeamInvitationByID(id uint) (*TeamInvitation, error)
 	GetTeamInvitationsByTeamID(teamID uint, status string, page, limit int) ([]TeamInvitation, int64, error)
-	GetTeamInvitationsByUserID(userID uint, status string, page, limit int) ([]TeamInvitation, int64, error)
+	GetTeamInvitationsByUserID(userID, teamID uint, status string, page, limit int) ([]TeamInvitation, int64, error)
 	UpdateTeamInvitation(invitation *TeamInvitation) error
 	DeleteTeamInvitation(id uint) error
 	GetPendingInvitation(teamID, userID uint) (*TeamInvitation, error)
@@ -43,12 +67,26 @@ type TeamRepository interface {
 	CreateJoinRequest(request *JoinRequest) error
 	GetJoinRequestByID(id uint) (*JoinRequest, error)
 	GetJoinRequestsByTeamID(teamID uint, status string, page, limit int) ([]JoinRequest, int64, error)
-	GetJoinRequestsByUserID(userID uint, status string, page, limit int) ([]JoinRequest, int64, error)
+	GetJoinRequestsByUserID(userID, teamID uint, status string, page, limit int) ([]JoinRequest, int64, error)
 	UpdateJoinRequest(request *JoinRequest) error
 	DeleteJoinRequest(id uint) error
 	GetPendingJoinRequest(teamID, userID uint) (*JoinRequest, error)
+
+	// TeamBlock operations
+	BlockTeam(block *TeamBlock) error
+	UnblockTeam(blockerTeamID, blockedTeamID uint) (bool, error)
+	GetTeamBlocks(blockerTeamID uint) ([]TeamBlock, error)
+	IsTeamBlocked(blockerTeamID, blockedTeamID uint) (bool, error)
+	GetTeamsBlockingUser(userID uint) ([]uint, error) // Teams that blocked a team the user plays for
+
 	WithTransaction(txFunc func(TeamRepository) error) error
 	GetAllTeamsAdmin(page, limit int, includeDeleted bool) ([]Team, int64, error)
+	GetTeamByIDIncludingDeleted(id uint) (*Team, error)
+	RestoreTeam(id uint) error
+	PurgeTeam(id uint) error
+
+	// Health check
+	Ping(ctx context.Context) error
 }
 
 type teamRepository struct {
@@ -77,9 +115,11 @@ func (r *teamRepository) GetTeamByID(id uint) (*Team, error) {
 	return &team, nil
 }
 
+// GetTeamByName finds an active team by name, ignoring case, so "Warriors" and "warriors"
+// are treated as the same name. This matches the idx_teams_name_lower unique index.
 func (r *teamRepository) GetTeamByName(name string) (*Team, error) {
 	var team Team
-	if err := r.db.Preload("Sport").Where("name = ?", name).First(&team).Error; err != nil {
+	if err := r.db.Preload("Sport").Where("LOWER(name) = LOWER(?) AND is_deleted = ?", name, false).First(&team).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil
 		}
@@ -92,7 +132,7 @@ func (r *teamRepository) GetAllTeams(page, limit int, filters map[string]interfa
 	var teams []Team
 	var total int64
 
-	query := r.db.Model(&Team{}).Preload("Sport").Where("is_deleted = ?", false)
+	query := r.db.Clauses(dbresolver.Read).Model(&Team{}).Preload("Sport").Where("is_deleted = ?", false)
 
 	if sportID, ok := filters["sport_id"]; ok {
 		query = query.Where("sport_id = ?", sportID)
@@ -112,26 +152,86 @@ func (r *teamRepository) GetAllTeams(page, limit int, filters map[string]interfa
 	return teams, total, nil
 }
 
-func (r *teamRepository) UpdateTeam(team *Team) error {
-	return r.db.Save(team).Error
-}
+// GetSimilarTeams ranks the active teams of the same sport whose rating is within the query's
+// band of the team's, closest first. See rankSimilarTeams for the ordering and distance rules.
+// It returns nil when the team does not exist.
+func (r *teamRepository) GetSimilarTeams(teamID uint, query SimilarTeamsQuery) ([]SimilarTeam, error) {
+	var team Team
+	if err := r.db.Where("is_deleted = ?", false).First(&team, teamID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
 
-func (r *teamRepository) DeleteTeam(id uint, hardDelete bool) error {
-	if hardDelete {
-		// Hard delete related records first if necessary, or rely on GORM's cascade if setup
-		// For example, delete members, invitations, join requests
-		if err := r.db.Where("team_id = ?", id).Delete(&TeamMember{}).Error; err != nil {
-			// Log or handle error, but proceed to delete team
+	var candidates []Team
+	if err := r.db.Preload("Sport").
+		Where("is_deleted = ? AND sport_id = ? AND id <> ?", false, team.SportID, team.ID).
+		Where("rating BETWEEN ? AND ?", team.Rating-query.RatingBand, team.Rating+query.RatingBand).
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: "ABS(rating - ?), id", Vars: []interface{}{team.Rating}}}).
+		Limit(maxSimilarTeamCandidates).
+		Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	venueIDs := make([]uint, 0, len(candidates)+1)
+	if team.HomeVenueID != nil {
+		venueIDs = append(venueIDs, *team.HomeVenueID)
+	}
+	for _, t := range candidates {
+		if t.HomeVenueID != nil {
+			venueIDs = append(venueIDs, *t.HomeVenueID)
 		}
-		if err := r.db.Where("team_id = ?", id).Delete(&TeamInvitation{}).Error; err != nil {
-			// Log or handle error
+	}
+	coordinates := map[uint]string{}
+	if len(venueIDs) > 0 {
+		var venues []struct {
+			ID          uint
+			Coordinates string
+		}
+		if err := r.db.Table("venues").Select("id, coordinates").Where("id IN ?", venueIDs).Scan(&venues).Error; err != nil {
+			return nil, err
 		}
-		if err := r.db.Where("team_id = ?", id).Delete(&JoinRequest{}).Error; err != nil {
-			// Log or handle error
+		for _, v := range venues {
+			coordinates[v.ID] = v.Coordinates
 		}
-		return r.db.Unscoped().Delete(&Team{}, id).Error
 	}
-	return r.db.Model(&Team{}).Where("id = ?", id).Update("is_deleted", true).Error
+
+	return rankSimilarTeams(&team, candidates, coordinates, query), nil
+}
+
+// VenueExists reports whether a venue with the given ID exists
+func (r *teamRepository) VenueExists(venueID uint) (bool, error) {
+	var count int64
+	if err := r.db.Table("venues").Where("id = ? AND is_deleted = ?", venueID, false).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// UpdateTeam saves every field of the team, provided it still has the version it was loaded
+// with, and bumps the version
+func (r *teamRepository) UpdateTeam(team *Team) error {
+	loaded := team.Version
+	team.Version++
+	result := r.db.Model(team).Where("version = ?", loaded).Select("*").Updates(team)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrTeamVersionConflict
+	}
+	if result.Error != nil {
+		team.Version = loaded
+	}
+	return result.Error
+}
+
+func (r *teamRepository) DeleteTeam(id uint, hardDelete, force bool) error {
+	if hardDelete {
+		return r.db.Transaction(func(tx *gorm.DB) error {
+			return hardDeleteTeam(tx, id, force)
+		})
+	}
+	return r.db.Model(&Team{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"is_deleted": true, "version": gorm.Expr("version + 1")}).Error
 }
 
 func (r *teamRepository) GetTeamsByUserID(userID uint, page, limit int) ([]Team, int64, error) {
@@ -163,6 +263,25 @@ func (r *teamRepository) GetTeamsCreatedByUserID(userID uint, page, limit int) (
 	return teams, total, nil
 }
 
+// GetTeamsManagedByUserID returns the active teams the user created or manages as captain,
+// vice-captain or moderator. A non-zero sportID limits the result to that sport.
+func (r *teamRepository) GetTeamsManagedByUserID(userID, sportID uint) ([]Team, error) {
+	var teams []Team
+	managerRoles := []string{RoleCaptain, RoleViceCaptain, RoleModerator}
+	query := r.db.Model(&Team{}).Preload("Sport").
+		Where("is_deleted = ?", false).
+		Where("created_by_id = ? OR id IN (?)", userID,
+			r.db.Model(&TeamMember{}).Select("team_id").
+				Where("user_id = ? AND is_active = ? AND (role IN ? OR is_captain = ?)", userID, true, managerRoles, true))
+	if sportID != 0 {
+		query = query.Where("sport_id = ?", sportID)
+	}
+	if err := query.Order("name asc").Find(&teams).Error; err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
 // --- TeamMember Operations ---
 
 func (r *teamRepository) AddTeamMember(member *TeamMember) error {
@@ -256,6 +375,53 @@ func (r *teamRepository) GetTeamCaptainsAndModerators(teamID uint) ([]TeamMember
 	return members, nil
 }
 
+func (r *teamRepository) CountActiveTeamMembers(teamID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&TeamMember{}).Where("team_id = ? AND is_active = ?", teamID, true).Count(&count).Error
+	return count, err
+}
+
+// CountActiveTeamMembersByTeam counts active members for several teams in one grouped query.
+// Teams without active members are absent from the result.
+func (r *teamRepository) CountActiveTeamMembersByTeam(teamIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(teamIDs))
+	if len(teamIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		TeamID uint
+		Count  int64
+	}
+	if err := r.db.Model(&TeamMember{}).Select("team_id, COUNT(*) AS count").
+		Where("team_id IN ? AND is_active = ?", teamIDs, true).
+		Group("team_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.TeamID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetActiveTeamMemberIDs reports which of the given users are active members of the team
+// in one query. Users who are not active members are absent from the result.
+func (r *teamRepository) GetActiveTeamMemberIDs(teamID uint, userIDs []uint) (map[uint]bool, error) {
+	members := make(map[uint]bool, len(userIDs))
+	if len(userIDs) == 0 {
+		return members, nil
+	}
+	var ids []uint
+	if err := r.db.Model(&TeamMember{}).
+		Where("team_id = ? AND user_id IN ? AND is_active = ?", teamID, userIDs, true).
+		Pluck("user_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		members[id] = true
+	}
+	return members, nil
+}
+
 // --- TeamInvitation Operations ---
 
 func (r *teamRepository) CreateTeamInvitation(invitation *TeamInvitation) error {
@@ -288,10 +454,13 @@ func (r *teamRepository) GetTeamInvitationsByTeamID(teamID uint, status string,
 	return invitations, total, nil
 }
 
-func (r *teamRepository) GetTeamInvitationsByUserID(userID uint, status string, page, limit int) ([]TeamInvitation, int64, error) {
+func (r *teamRepository) GetTeamInvitationsByUserID(userID, teamID uint, status string, page, limit int) ([]TeamInvitation, int64, error) {
 	var invitations []TeamInvitation
 	var total int64
 	query := r.db.Model(&TeamInvitation{}).Where("user_id = ?", userID)
+	if teamID != 0 {
+		query = query.Where("team_id = ?", teamID)
+	}
 	if status != "" {
 		query = query.Where("status = ?", status)
 	}
@@ -355,10 +524,13 @@ func (r *teamRepository) GetJoinRequestsByTeamID(teamID uint, status string, pag
 	return requests, total, nil
 }
 
-func (r *teamRepository) GetJoinRequestsByUserID(userID uint, status string, page, limit int) ([]JoinRequest, int64, error) {
+func (r *teamRepository) GetJoinRequestsByUserID(userID, teamID uint, status string, page, limit int) ([]JoinRequest, int64, error) {
 	var requests []JoinRequest
 	var total int64
 	query := r.db.Model(&JoinRequest{}).Where("user_id = ?", userID)
+	if teamID != 0 {
+		query = query.Where("team_id = ?", teamID)
+	}
 	if status != "" {
 		query = query.Where("status = ?", status)
 	}
@@ -399,6 +571,58 @@ func (r *teamRepository) WithTransaction(txFunc func(TeamRepository) error) erro
 	})
 }
 
+// GetTeamByIDIncludingDeleted finds a team whether or not it has been deleted
+func (r *teamRepository) GetTeamByIDIncludingDeleted(id uint) (*Team, error) {
+	var team Team
+	if err := r.db.Unscoped().Preload("Sport").First(&team, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &team, nil
+}
+
+// RestoreTeam undoes a soft delete and reactivates the creator's membership as captain,
+// creating it if it no longer exists
+func (r *teamRepository) RestoreTeam(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var team Team
+		if err := tx.Unscoped().First(&team, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&Team{}).Where("id = ?", id).
+			Updates(map[string]interface{}{"is_deleted": false, "deleted_at": nil, "version": gorm.Expr("version + 1")}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Model(&TeamMember{}).
+			Where("team_id = ? AND user_id = ?", id, team.CreatedByID).
+			Updates(map[string]interface{}{"is_active": true, "deleted_at": nil})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			return nil
+		}
+		return tx.Create(&TeamMember{
+			TeamID:    id,
+			UserID:    team.CreatedByID,
+			Role:      RoleCaptain,
+			IsCaptain: true,
+			IsActive:  true,
+			JoinedAt:  time.Now(),
+		}).Error
+	})
+}
+
+// PurgeTeam permanently removes a team along with its members, invitations and join requests
+func (r *teamRepository) PurgeTeam(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return hardDeleteTeam(tx, id, false)
+	})
+}
+
 func (r *teamRepository) GetAllTeamsAdmin(page, limit int, includeDeleted bool) ([]Team, int64, error) {
 	var teams []Team
 	var total int64
@@ -416,3 +640,57 @@ func (r *teamRepository) GetAllTeamsAdmin(page, limit int, includeDeleted bool)
 	}
 	return teams, total, nil
 }
+
+// --- TeamBlock Operations ---
+
+func (r *teamRepository) BlockTeam(block *TeamBlock) error {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(block)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTeamAlreadyBlocked
+	}
+	return nil
+}
+
+// UnblockTeam removes a block, reporting whether one existed. The row is deleted outright so
+// that the team can be blocked again later without tripping the unique index.
+func (r *teamRepository) UnblockTeam(blockerTeamID, blockedTeamID uint) (bool, error) {
+	result := r.db.Unscoped().
+		Where("blocker_team_id = ? AND blocked_team_id = ?", blockerTeamID, blockedTeamID).
+		Delete(&TeamBlock{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *teamRepository) GetTeamBlocks(blockerTeamID uint) ([]TeamBlock, error) {
+	var blocks []TeamBlock
+	err := r.db.Preload("BlockedTeam").
+		Where("blocker_team_id = ?", blockerTeamID).
+		Order("created_at DESC").
+		Find(&blocks).Error
+	return blocks, err
+}
+
+func (r *teamRepository) IsTeamBlocked(blockerTeamID, blockedTeamID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&TeamBlock{}).
+		Where("blocker_team_id = ? AND blocked_team_id = ?", blockerTeamID, blockedTeamID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *teamRepository) GetTeamsBlockingUser(userID uint) ([]uint, error) {
+	userTeams := r.db.Model(&TeamMember{}).Select("team_id").Where("user_id = ? AND is_active = ?", userID, true)
+	var teamIDs []uint
+	err := r.db.Model(&TeamBlock{}).
+		Where("blocked_team_id IN (?)", userTeams).
+		Distinct().
+		Pluck("blocker_team_id", &teamIDs).Error
+	return teamIDs, err
+}
+
+// Ping checks that the teams table answers a trivial query, for the readiness probe
+func (r *teamRepository) Ping(ctx context.Context) error {
+	return r.db.WithContext(ctx).Exec("SELECT 1 FROM teams LIMIT 1").Error
+}
@@ -3,21 +3,25 @@ package team
 import (
 	"github.com/DhavalSuthar-24/miow/config"                 // Assuming your config package
 	mw "github.com/DhavalSuthar-24/miow/internal/middleware" // Assuming your middleware package
+	"github.com/DhavalSuthar-24/miow/internal/notification"
+	"github.com/DhavalSuthar-24/miow/pkg/cache"
+	"github.com/DhavalSuthar-24/miow/pkg/storage"
 
-	"github.com/DhavalSuthar-24/miow/pkg/rmiddleware"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 func TeamRoutes(router *gin.RouterGroup, db *gorm.DB, appConfig *config.Config, jwtSecret string,
 ) {
-	teamRepo := NewTeamRepository(db)
-	teamController := NewTeamController(teamRepo, appConfig)
+	teamRepo := NewCachedTeamRepository(NewTeamRepository(db), cache.Shared(appConfig), cache.TTL(appConfig))
+	teamController := NewTeamController(teamRepo, appConfig, notification.NewDefaultDispatcher(db, appConfig), storage.Shared(appConfig))
 
 	// Public team routes
 	router.GET("/teams", teamController.GetAllTeams)
+	router.GET("/teams/available", teamController.CheckTeamNameAvailability)
 	router.GET("/teams/:team_id", teamController.GetTeamByID)
 	router.GET("/teams/:team_id/members", teamController.GetTeamMembers) // Publicly viewable members
+	router.GET("/teams/:team_id/similar", teamController.GetSimilarTeams)
 
 	// Authenticated user routes
 	authRoutes := router.Group("/")
@@ -25,8 +29,9 @@ func TeamRoutes(router *gin.RouterGroup, db *gorm.DB, appConfig *config.Config,
 	{
 		// Team CRUD by authenticated users
 		authRoutes.POST("/teams", teamController.CreateTeam)
-		authRoutes.PUT("/teams/:team_id", teamController.UpdateTeam)    // Authorization within handler
-		authRoutes.DELETE("/teams/:team_id", teamController.DeleteTeam) // Authorization within handler
+		authRoutes.PUT("/teams/:team_id", teamController.UpdateTeam)           // Authorization within handler
+		authRoutes.DELETE("/teams/:team_id", teamController.DeleteTeam)        // Authorization within handler
+		authRoutes.POST("/teams/:team_id/logo", teamController.UploadTeamLogo) // Authorization within handler
 
 		// User's perspective on teams
 		authRoutes.GET("/users/me/teams", teamController.GetMyTeams)
@@ -52,14 +57,21 @@ func TeamRoutes(router *gin.RouterGroup, db *gorm.DB, appConfig *config.Config,
 		authRoutes.PUT("/invitations/:invitation_id/:action", teamController.RespondToTeamInvitation) // User responds (action: accept/reject)
 		authRoutes.DELETE("/invitations/:invitation_id", teamController.CancelTeamInvitation)         // Manager cancels their invitation
 
+		// Team blocks
+		authRoutes.POST("/teams/:team_id/blocks", teamController.BlockTeam)                      // Manager access
+		authRoutes.GET("/teams/:team_id/blocks", teamController.GetTeamBlocks)                   // Manager access
+		authRoutes.DELETE("/teams/:team_id/blocks/:blocked_team_id", teamController.UnblockTeam) // Manager access
+
 	}
 
 	// Admin routes (example, could be a separate group with admin-specific middleware)
 	adminRoutes := router.Group("/admin")
 	adminRoutes.Use(mw.AuthMiddleware(jwtSecret, db)) // General auth
-	adminRoutes.Use(rmiddleware.AdminMiddleware())    // Admin-specific role check middleware
+	adminRoutes.Use(mw.RequireRole("admin"))          // Admin-specific role check middleware
 	{
 		adminRoutes.GET("/teams", teamController.AdminGetAllTeams)
+		adminRoutes.POST("/teams/:team_id/restore", teamController.AdminRestoreTeam)
+		adminRoutes.DELETE("/teams/:team_id/purge", teamController.AdminPurgeTeam)
 		// Add more admin-specific team management routes here:
 		// adminRoutes.PUT("/teams/:team_id", teamController.AdminUpdateTeam)
 		// adminRoutes.DELETE("/teams/:team_id", teamController.AdminDeleteTeam)
@@ -0,0 +1,168 @@
+package team
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/DhavalSuthar-24/miow/internal/models"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultSimilarRatingBand = 200.0
+	maxSimilarRatingBand     = 1000.0
+	defaultSimilarRadiusKm   = 50.0
+	maxSimilarRadiusKm       = 500.0
+	maxSimilarTeamCandidates = 500 // Closest ratings considered before distance is applied
+)
+
+// SimilarTeamsQuery bounds a similar teams search
+type SimilarTeamsQuery struct {
+	RatingBand float64 // Maximum rating difference
+	RadiusKm   float64 // Maximum distance between home venues, when the team's is known
+}
+
+// SimilarTeam is a team recommended as similar to another, with how close it is
+type SimilarTeam struct {
+	Team       Team     `json:"team"`
+	RatingDiff float64  `json:"rating_diff"`
+	DistanceKm *float64 `json:"distance_km,omitempty"`
+	Score      float64  `json:"score"` // Lower is more similar
+}
+
+// rankSimilarTeams scores candidates by rating closeness relative to the band and, when the
+// team's home venue location is known, by distance relative to the radius, adding the two.
+// With a known location, candidates without one or outside the radius are dropped.
+func rankSimilarTeams(team *Team, candidates []Team, coordinates map[uint]string, query SimilarTeamsQuery) []SimilarTeam {
+	var origin models.Coordinates
+	hasOrigin := false
+	if team.HomeVenueID != nil {
+		origin, hasOrigin = models.ParseCoordinates(coordinates[*team.HomeVenueID])
+	}
+
+	similar := make([]SimilarTeam, 0, len(candidates))
+	for _, t := range candidates {
+		diff := math.Abs(t.Rating - team.Rating)
+		st := SimilarTeam{
+			Team:       t,
+			RatingDiff: math.Round(diff*10) / 10,
+			Score:      diff / query.RatingBand,
+		}
+		if hasOrigin {
+			if t.HomeVenueID == nil {
+				continue
+			}
+			coords, ok := models.ParseCoordinates(coordinates[*t.HomeVenueID])
+			if !ok {
+				continue
+			}
+			d := models.DistanceKm(origin, coords)
+			if d > query.RadiusKm {
+				continue
+			}
+			rounded := math.Round(d*10) / 10
+			st.DistanceKm = &rounded
+			st.Score += d / query.RadiusKm
+		}
+		st.Score = math.Round(st.Score*1000) / 1000
+		similar = append(similar, st)
+	}
+
+	sort.SliceStable(similar, func(i, j int) bool {
+		if similar[i].Score != similar[j].Score {
+			return similar[i].Score < similar[j].Score
+		}
+		return similar[i].Team.ID < similar[j].Team.ID
+	})
+	return similar
+}
+
+// checkHomeVenue verifies that a requested home venue exists. It writes the error response and
+// returns false when it does not.
+func (tc *TeamController) checkHomeVenue(c *gin.Context, venueID uint) bool {
+	exists, err := tc.repo.VenueExists(venueID)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to check home venue: "+err.Error())
+		return false
+	}
+	if !exists {
+		response.Error(c, http.StatusBadRequest, "Home venue not found")
+		return false
+	}
+	return true
+}
+
+// GetSimilarTeams godoc
+// @Summary Get similar teams
+// @Description Recommends teams of the same sport with a rating within rating_band of this team's. When the team has a home venue with coordinates, only teams whose home venue is within radius_km are included. Teams are ranked by rating and distance closeness combined; results are cached briefly.
+// @Tags Teams
+// @Produce json
+// @Param team_id path uint true "Team ID"
+// @Param rating_band query number false "Maximum rating difference" default(200)
+// @Param radius_km query number false "Maximum distance between home venues in km" default(50)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} response.PaginatedResponse{data=response.Page{items=[]SimilarTeam}} "Similar teams"
+// @Failure 400 {object} response.ErrorResponse "Invalid parameters"
+// @Failure 404 {object} response.ErrorResponse "Team not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /teams/{team_id}/similar [get]
+func (tc *TeamController) GetSimilarTeams(c *gin.Context) {
+	teamID, err := strconv.ParseUint(c.Param("team_id"), 10, 32)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+
+	query := SimilarTeamsQuery{RatingBand: defaultSimilarRatingBand, RadiusKm: defaultSimilarRadiusKm}
+	if raw := c.Query("rating_band"); raw != "" {
+		band, err := strconv.ParseFloat(raw, 64)
+		if err != nil || band <= 0 || band > maxSimilarRatingBand {
+			response.Error(c, http.StatusBadRequest, fmt.Sprintf("rating_band must be greater than 0 and at most %g", maxSimilarRatingBand))
+			return
+		}
+		query.RatingBand = band
+	}
+	if raw := c.Query("radius_km"); raw != "" {
+		radius, err := strconv.ParseFloat(raw, 64)
+		if err != nil || radius <= 0 || radius > maxSimilarRadiusKm {
+			response.Error(c, http.StatusBadRequest, fmt.Sprintf("radius_km must be greater than 0 and at most %g", maxSimilarRadiusKm))
+			return
+		}
+		query.RadiusKm = radius
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	similar, err := tc.repo.GetSimilarTeams(uint(teamID), query)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "Failed to find similar teams: "+err.Error())
+		return
+	}
+	if similar == nil {
+		response.Error(c, http.StatusNotFound, "Team not found")
+		return
+	}
+
+	total := len(similar)
+	start := (page - 1) * limit
+	if start > total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+	response.Paginated(c, http.StatusOK, "", similar[start:end], int64(total), page, limit)
+}
@@ -0,0 +1,75 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/pkg/cache"
+)
+
+// MemoryCache is an in-memory cache.Cache that counts hits, misses and deletes. Setting Err
+// makes every call fail with it, as an unreachable Redis would.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+	Err     error
+
+	Hits, Misses, Sets, Deletes int
+}
+
+// NewMemoryCache creates an empty MemoryCache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string][]byte)}
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	value, ok := c.entries[key]
+	if !ok {
+		c.Misses++
+		return nil, cache.ErrMiss
+	}
+	c.Hits++
+	return value, nil
+}
+
+func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return c.Err
+	}
+	c.Sets++
+	c.entries[key] = value
+	return nil
+}
+
+func (c *MemoryCache) Delete(ctx context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.Err != nil {
+		return c.Err
+	}
+	for _, key := range keys {
+		c.Deletes++
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+// Has reports whether key is cached
+func (c *MemoryCache) Has(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.entries[key]
+	return ok
+}
+
+// ErrCacheDown is a convenient MemoryCache.Err for simulating an unavailable cache
+var ErrCacheDown = errors.New("cache unavailable")
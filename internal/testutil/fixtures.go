@@ -0,0 +1,54 @@
+package testutil
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/DhavalSuthar-24/miow/internal/models"
+	"github.com/DhavalSuthar-24/miow/internal/user"
+	"gorm.io/gorm"
+)
+
+var fixtureSeq atomic.Int64
+
+// Seq returns a number unique within the test binary, for unique names in fixtures
+func Seq() int64 {
+	return fixtureSeq.Add(1)
+}
+
+// UserModels are the models CreateUser needs migrated
+var UserModels = []interface{}{&user.User{}, &user.Role{}, &user.UserRole{}}
+
+// CreateUser inserts a user with a unique email, phone and username
+func CreateUser(t testing.TB, db *gorm.DB, name string) *user.User {
+	t.Helper()
+
+	n := Seq()
+	u := &user.User{
+		Name:     name,
+		Username: fmt.Sprintf("user%d", n),
+		Email:    fmt.Sprintf("user%d@example.com", n),
+		Phone:    fmt.Sprintf("+1555%07d", n),
+		Password: "not-a-real-hash",
+		// The column default '{}' does not decode into a list
+		PreferredSports: models.StringSlice{},
+	}
+	if err := db.Create(u).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	return u
+}
+
+// GrantRole gives the user the named role, creating the role if needed
+func GrantRole(t testing.TB, db *gorm.DB, userID uint, roleName string) {
+	t.Helper()
+
+	role := user.Role{Name: roleName}
+	if err := db.Where(user.Role{Name: roleName}).FirstOrCreate(&role).Error; err != nil {
+		t.Fatalf("failed to create role %s: %v", roleName, err)
+	}
+	if err := db.Create(&user.UserRole{UserID: userID, RoleID: role.ID}).Error; err != nil {
+		t.Fatalf("failed to grant role %s: %v", roleName, err)
+	}
+}
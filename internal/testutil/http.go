@@ -0,0 +1,94 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Request sends a JSON request to handler and records the response. A nil body sends none.
+func Request(t testing.TB, handler http.Handler, method, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("failed to encode request body: %v", err)
+		}
+		reader = bytes.NewReader(payload)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+// Upload sends a multipart POST with one file in field to handler and records the response
+func Upload(t testing.TB, handler http.Handler, path, field, filename string, data []byte) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	part, err := mw.CreateFormFile(field, filename)
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		t.Fatalf("failed to write form file: %v", err)
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatalf("failed to close multipart body: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, path, &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+// PNG is the start of a PNG file, enough for content sniffing to report image/png
+var PNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01")
+
+// Envelope is the response envelope written by pkg/response
+type Envelope struct {
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+	Error   struct {
+		Code    int             `json:"code"`
+		Message string          `json:"message"`
+		Details json.RawMessage `json:"details"`
+	} `json:"error"`
+}
+
+// DecodeEnvelope decodes the response envelope
+func DecodeEnvelope(t testing.TB, w *httptest.ResponseRecorder) Envelope {
+	t.Helper()
+
+	var env Envelope
+	if err := json.Unmarshal(w.Body.Bytes(), &env); err != nil {
+		t.Fatalf("failed to decode response %q: %v", w.Body.String(), err)
+	}
+	return env
+}
+
+// DecodeData decodes the envelope's data into v
+func DecodeData(t testing.TB, w *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+
+	env := DecodeEnvelope(t, w)
+	if err := json.Unmarshal(env.Data, v); err != nil {
+		t.Fatalf("failed to decode response data %s: %v", env.Data, err)
+	}
+}
@@ -34,7 +34,11 @@ type User struct {
 	Coordinates     models.Coordinates `json:"coordinates,omitempty" gorm:"type:jsonb;default:'{}'"`
 	PreferredSports models.StringSlice `json:"preferred_sports,omitempty" gorm:"type:jsonb;default:'{}'"`
 	SocialMedia     models.SocialMedia `json:"social_media,omitempty" gorm:"type:jsonb;default:'{}'"`
+	ProfilePrivate  bool               `json:"profile_private" gorm:"default:false"` // Hides the public profile from other users
 	RefreshTokens   []RefreshToken     `json:"-" gorm:"foreignKey:UserID"`
+
+	// SHA-256 of the token that authorises the user's calendar feed; nil until one is issued
+	CalendarTokenHash *string `json:"-" gorm:"uniqueIndex;size:64"`
 }
 
 type Role struct {
@@ -0,0 +1,87 @@
+package venue
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/config"
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTestDB opens a test database with the venue tables
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	models := append(testutil.UserModels,
+		&Venue{}, &Ground{}, &Booking{}, &TimeSlot{}, &VenueClosure{}, &VenueManager{}, &VenueSchedule{})
+	return testutil.DB(t, models...)
+}
+
+// newTestController creates a controller over db with the default configuration
+func newTestController(t *testing.T, db *gorm.DB) *VenueController {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.Storage.MaxImageSizeMB = 5
+	return NewVenueController(NewVenueRepository(db), cfg, nil, storage.NewLocalStorage(t.TempDir(), "/uploads"))
+}
+
+// asUser authenticates every request as the user, as AuthMiddleware would
+func asUser(userID uint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(middleware.AuthUserIDKey, userID)
+		c.Next()
+	}
+}
+
+// createVenue inserts a venue managed by managerID in the given IANA timezone
+func createVenue(t *testing.T, db *gorm.DB, managerID uint, timezone string) *Venue {
+	t.Helper()
+	venue := &Venue{
+		Name:        fmt.Sprintf("Venue %d", testutil.Seq()),
+		Location:    "Test Street",
+		Coordinates: "{}",
+		Facilities:  "[]",
+		Images:      "[]",
+		SocialHours: "{}",
+		Available:   true,
+		HourlyRate:  20,
+		Timezone:    timezone,
+		ManagerID:   managerID,
+	}
+	if err := db.Omit("Manager").Create(venue).Error; err != nil {
+		t.Fatalf("failed to create venue: %v", err)
+	}
+	return venue
+}
+
+// createGround inserts a court at the venue
+func createGround(t *testing.T, db *gorm.DB, venueID uint, name string) *Ground {
+	t.Helper()
+	ground := &Ground{VenueID: venueID, Name: name, Type: "court"}
+	if err := db.Omit("Venue").Create(ground).Error; err != nil {
+		t.Fatalf("failed to create ground: %v", err)
+	}
+	return ground
+}
+
+// createTimeSlot inserts a time slot on the ground
+func createTimeSlot(t *testing.T, db *gorm.DB, venueID, groundID uint, start, end time.Time) *TimeSlot {
+	t.Helper()
+	slot := &TimeSlot{VenueID: venueID, GroundID: groundID, StartTime: start, EndTime: end, Price: 10, Equipment: "[]"}
+	if err := db.Omit("Ground").Create(slot).Error; err != nil {
+		t.Fatalf("failed to create time slot: %v", err)
+	}
+	return slot
+}
+
+func itoa(id uint) string {
+	return fmt.Sprintf("%d", id)
+}
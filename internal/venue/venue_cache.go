@@ -0,0 +1,70 @@
+package venue
+
+import (
+	"context"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/pkg/cache"
+)
+
+// cachedVenueRepository serves GetVenueByID from the cache, dropping the entry whenever the
+// venue is updated or deleted through the repository
+type cachedVenueRepository struct {
+	VenueRepository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachedVenueRepository wraps a VenueRepository with cache-aside reads of venue details
+func NewCachedVenueRepository(repo VenueRepository, c cache.Cache, ttl time.Duration) VenueRepository {
+	return &cachedVenueRepository{VenueRepository: repo, cache: c, ttl: ttl}
+}
+
+func venueCacheKey(id uint) string {
+	return cache.Key("venue", id)
+}
+
+func (r *cachedVenueRepository) GetVenueByID(id uint) (*Venue, error) {
+	ctx := context.Background()
+	var venue Venue
+	if cache.GetJSON(ctx, r.cache, venueCacheKey(id), &venue) {
+		return &venue, nil
+	}
+
+	found, err := r.VenueRepository.GetVenueByID(id)
+	if err != nil {
+		return nil, err
+	}
+	cache.SetJSON(ctx, r.cache, venueCacheKey(id), found, r.ttl)
+	return found, nil
+}
+
+func (r *cachedVenueRepository) UpdateVenue(venue *Venue) error {
+	err := r.VenueRepository.UpdateVenue(venue)
+	cache.Invalidate(context.Background(), r.cache, venueCacheKey(venue.ID))
+	return err
+}
+
+func (r *cachedVenueRepository) AppendVenueImage(venueID uint, url string) (*Venue, error) {
+	venue, err := r.VenueRepository.AppendVenueImage(venueID, url)
+	cache.Invalidate(context.Background(), r.cache, venueCacheKey(venueID))
+	return venue, err
+}
+
+func (r *cachedVenueRepository) DeleteVenue(id uint, hardDelete bool) error {
+	err := r.VenueRepository.DeleteVenue(id, hardDelete)
+	cache.Invalidate(context.Background(), r.cache, venueCacheKey(id))
+	return err
+}
+
+func (r *cachedVenueRepository) RestoreVenue(id uint) error {
+	err := r.VenueRepository.RestoreVenue(id)
+	cache.Invalidate(context.Background(), r.cache, venueCacheKey(id))
+	return err
+}
+
+func (r *cachedVenueRepository) TransferVenueOwnership(venueID, newOwnerID uint) error {
+	err := r.VenueRepository.TransferVenueOwnership(venueID, newOwnerID)
+	cache.Invalidate(context.Background(), r.cache, venueCacheKey(venueID))
+	return err
+}
@@ -0,0 +1,79 @@
+package venue
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+)
+
+// stubVenueRepository serves venues from memory and counts the reads that reach it
+type stubVenueRepository struct {
+	VenueRepository
+	venues map[uint]*Venue
+	reads  int
+}
+
+func (r *stubVenueRepository) GetVenueByID(id uint) (*Venue, error) {
+	r.reads++
+	if venue, ok := r.venues[id]; ok {
+		copied := *venue
+		return &copied, nil
+	}
+	return nil, errors.New("venue not found")
+}
+
+func (r *stubVenueRepository) UpdateVenue(venue *Venue) error {
+	r.venues[venue.ID] = venue
+	return nil
+}
+
+func (r *stubVenueRepository) TransferVenueOwnership(venueID, newOwnerID uint) error {
+	r.venues[venueID].ManagerID = newOwnerID
+	return nil
+}
+
+func TestCachedVenueRepository(t *testing.T) {
+	stub := &stubVenueRepository{venues: map[uint]*Venue{4: {Name: "Arena", ManagerID: 1}}}
+	stub.venues[4].ID = 4
+	c := testutil.NewMemoryCache()
+	repo := NewCachedVenueRepository(stub, c, 0)
+
+	get := func() *Venue {
+		t.Helper()
+		venue, err := repo.GetVenueByID(4)
+		if err != nil {
+			t.Fatalf("GetVenueByID() error = %v", err)
+		}
+		return venue
+	}
+
+	get()
+	if venue := get(); venue.Name != "Arena" || stub.reads != 1 || c.Hits != 1 || c.Misses != 1 {
+		t.Errorf("GetVenueByID() = %q with %d reads, %d hits, %d misses; want Arena with 1, 1, 1",
+			venue.Name, stub.reads, c.Hits, c.Misses)
+	}
+
+	updated := *stub.venues[4]
+	updated.Name = "Stadium"
+	if err := repo.UpdateVenue(&updated); err != nil {
+		t.Fatalf("UpdateVenue() error = %v", err)
+	}
+	if venue := get(); venue.Name != "Stadium" || stub.reads != 2 {
+		t.Errorf("after update GetVenueByID() = %q with %d reads, want Stadium with 2", venue.Name, stub.reads)
+	}
+
+	if err := repo.TransferVenueOwnership(4, 9); err != nil {
+		t.Fatalf("TransferVenueOwnership() error = %v", err)
+	}
+	if venue := get(); venue.ManagerID != 9 || stub.reads != 3 {
+		t.Errorf("after transfer GetVenueByID() has manager %d with %d reads, want 9 with 3", venue.ManagerID, stub.reads)
+	}
+
+	if _, err := repo.GetVenueByID(5); err == nil {
+		t.Error("GetVenueByID() of a missing venue succeeded")
+	}
+	if c.Has(venueCacheKey(5)) {
+		t.Error("a missing venue was cached")
+	}
+}
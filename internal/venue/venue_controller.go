@@ -1,6 +1,8 @@
 package venue
 
 import (
+	"encoding/csv"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -8,21 +10,41 @@ import (
 	"time"
 
 	"github.com/DhavalSuthar-24/miow/config"
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/internal/notification"
+	"github.com/DhavalSuthar-24/miow/pkg/i18n"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/DhavalSuthar-24/miow/pkg/storage"
 	"github.com/DhavalSuthar-24/miow/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
+// availabilityLookahead is how far ahead has_availability_this_week looks for a free slot
+const availabilityLookahead = 7 * 24 * time.Hour
+
+const (
+	defaultVenueRadiusKm = 10.0 // Search radius when lat and lng are given without radius_km
+	maxVenueRadiusKm     = 500.0
+)
+
+// bookingShortNotice is how close to its start a booking can be made before a warning is returned
+const bookingShortNotice = 60 * time.Minute
+
 // VenueController handles venue-related HTTP requests
 type VenueController struct {
 	repo      VenueRepository
 	appConfig *config.Config
+	notifier  notification.Notifier
+	storage   storage.Storage
 }
 
 // NewVenueController creates a new venue controller
-func NewVenueController(repo VenueRepository, appConfig *config.Config) *VenueController {
+func NewVenueController(repo VenueRepository, appConfig *config.Config, notifier notification.Notifier, store storage.Storage) *VenueController {
 	return &VenueController{
 		repo:      repo,
 		appConfig: appConfig,
+		notifier:  notifier,
+		storage:   store,
 	}
 }
 
@@ -33,23 +55,34 @@ func NewVenueController(repo VenueRepository, appConfig *config.Config) *VenueCo
 // @Accept json
 // @Produce json
 // @Param venue body VenueInput true "Venue information"
-// @Success 201 {object} Venue "Venue created successfully"
-// @Failure 400 {object} utils.ErrorResponse "Invalid input"
-// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
-// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Success 201 {object} response.SuccessResponse{data=Venue} "Venue created successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /manager/venues [post]
 // @Security Bearer
 func (c *VenueController) CreateVenue(ctx *gin.Context) {
 	var input VenueInput
 	if err := ctx.ShouldBindJSON(&input); err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: err.Error()})
+		response.ValidationError(ctx, err)
 		return
 	}
 
 	// Get user ID from context (set by auth middleware)
-	userID, exists := ctx.Get("userID")
+	userID, exists := middleware.CurrentUserID(ctx)
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, utils.ErrorResponse{Error: "unauthorized"})
+		response.Error(ctx, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	timezone, err := normalizeTimezone(input.Timezone)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, err.Error())
+		return
+	}
+	coordinates, err := normalizeCoordinates(input.Coordinates)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -57,7 +90,7 @@ func (c *VenueController) CreateVenue(ctx *gin.Context) {
 	venue := &Venue{
 		Name:        input.Name,
 		Location:    input.Location,
-		Coordinates: input.Coordinates,
+		Coordinates: coordinates,
 		Facilities:  input.Facilities,
 		Available:   input.Available,
 		ContactInfo: input.ContactInfo,
@@ -67,16 +100,17 @@ func (c *VenueController) CreateVenue(ctx *gin.Context) {
 		HourlyRate:  input.HourlyRate,
 		CourtCount:  input.CourtCount,
 		SocialHours: input.SocialHours,
-		ManagerID:   userID.(uint),
+		Timezone:    timezone,
+		ManagerID:   userID,
 	}
 
 	// Save venue to database
 	if err := c.repo.CreateVenue(venue); err != nil {
-		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to create venue: " + err.Error()})
+		response.Error(ctx, http.StatusInternalServerError, "failed to create venue: "+err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, venue)
+	response.Success(ctx, http.StatusCreated, "", venue)
 }
 
 // GetVenueByID godoc
@@ -85,29 +119,29 @@ func (c *VenueController) CreateVenue(ctx *gin.Context) {
 // @Tags venues
 // @Produce json
 // @Param venue_id path int true "Venue ID"
-// @Success 200 {object} Venue "Venue details"
-// @Failure 400 {object} utils.ErrorResponse "Invalid venue ID"
-// @Failure 404 {object} utils.ErrorResponse "Venue not found"
-// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse{data=Venue} "Venue details"
+// @Failure 400 {object} response.ErrorResponse "Invalid venue ID"
+// @Failure 404 {object} response.ErrorResponse "Venue not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /venues/{venue_id} [get]
 func (c *VenueController) GetVenueByID(ctx *gin.Context) {
 	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid venue ID"})
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
 		return
 	}
 
 	venue, err := c.repo.GetVenueByID(uint(venueID))
 	if err != nil {
 		if err.Error() == "venue not found" {
-			ctx.JSON(http.StatusNotFound, utils.ErrorResponse{Error: "venue not found"})
+			response.Error(ctx, http.StatusNotFound, "venue not found")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get venue: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
 		}
 		return
 	}
 
-	ctx.JSON(http.StatusOK, venue)
+	response.Success(ctx, http.StatusOK, "", venue)
 }
 
 // GetAllVenues godoc
@@ -121,26 +155,46 @@ func (c *VenueController) GetVenueByID(ctx *gin.Context) {
 // @Param location query string false "Filter by location (partial match)"
 // @Param min_courts query int false "Filter by minimum number of courts"
 // @Param max_price query number false "Filter by maximum hourly rate"
-// @Success 200 {object} utils.PaginatedResponse{data=[]Venue} "List of venues"
-// @Failure 400 {object} utils.ErrorResponse "Invalid query parameters"
-// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Param has_availability_this_week query boolean false "Only venues with a free, unclosed time slot starting in the next 7 days"
+// @Param lat query number false "Latitude to search around; requires lng"
+// @Param lng query number false "Longitude to search around; requires lat"
+// @Param radius_km query number false "Search radius around lat and lng in km (default: 10, max: 500)"
+// @Success 200 {object} response.PaginatedResponse{data=response.Page{items=[]Venue}} "List of venues"
+// @Failure 400 {object} response.ErrorResponse "Invalid query parameters"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /venues [get]
 func (c *VenueController) GetAllVenues(ctx *gin.Context) {
 	var pagination PaginationInput
 	if err := ctx.ShouldBindQuery(&pagination); err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: err.Error()})
+		response.ValidationError(ctx, err)
+		return
+	}
+
+	filters, ok := venueFilters(ctx)
+	if !ok {
+		return
+	}
+
+	venues, totalCount, err := c.repo.GetAllVenues(pagination.Page, pagination.Limit, filters)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to get venues: "+err.Error())
 		return
 	}
 
-	// Build filters
+	response.Paginated(ctx, http.StatusOK, "", venues, totalCount, pagination.Page, pagination.Limit)
+}
+
+// venueFilters builds the venue listing filters from the query string. It writes the error
+// response and returns false when a parameter is invalid.
+func venueFilters(ctx *gin.Context) (map[string]interface{}, bool) {
 	filters := make(map[string]interface{})
 
 	// Check if available filter is provided
 	if availableStr := ctx.Query("available"); availableStr != "" {
 		available, err := strconv.ParseBool(availableStr)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid available parameter"})
-			return
+			response.Error(ctx, http.StatusBadRequest, "invalid available parameter")
+			return nil, false
 		}
 		filters["available"] = available
 	}
@@ -154,8 +208,8 @@ func (c *VenueController) GetAllVenues(ctx *gin.Context) {
 	if minCourtsStr := ctx.Query("min_courts"); minCourtsStr != "" {
 		minCourts, err := strconv.Atoi(minCourtsStr)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid min_courts parameter"})
-			return
+			response.Error(ctx, http.StatusBadRequest, "invalid min_courts parameter")
+			return nil, false
 		}
 		filters["min_courts"] = minCourts
 	}
@@ -164,28 +218,53 @@ func (c *VenueController) GetAllVenues(ctx *gin.Context) {
 	if maxPriceStr := ctx.Query("max_price"); maxPriceStr != "" {
 		maxPrice, err := strconv.ParseFloat(maxPriceStr, 64)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid max_price parameter"})
-			return
+			response.Error(ctx, http.StatusBadRequest, "invalid max_price parameter")
+			return nil, false
 		}
 		filters["max_price"] = maxPrice
 	}
 
-	venues, totalCount, err := c.repo.GetAllVenues(pagination.Page, pagination.Limit, filters)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get venues: " + err.Error()})
-		return
+	// Check if has_availability_this_week filter is provided
+	if availabilityStr := ctx.Query("has_availability_this_week"); availabilityStr != "" {
+		hasAvailability, err := strconv.ParseBool(availabilityStr)
+		if err != nil {
+			response.Error(ctx, http.StatusBadRequest, "invalid has_availability_this_week parameter")
+			return nil, false
+		}
+		if hasAvailability {
+			now := time.Now()
+			filters["free_slot_window"] = AvailabilityWindow{From: now, To: now.Add(availabilityLookahead)}
+		}
 	}
 
-	ctx.JSON(http.StatusOK, utils.PaginatedResponse{
-		Data: venues,
-		Pagination: utils.PaginationData{
-			Total:      totalCount,
-			Page:       pagination.Page,
-			Limit:      pagination.Limit,
-			TotalPages: int64((int(totalCount) + pagination.Limit - 1) / pagination.Limit),
-		},
-	})
+	// Check if a location radius is provided
+	lat, lng := ctx.Query("lat"), ctx.Query("lng")
+	if lat != "" || lng != "" {
+		latitude, err := strconv.ParseFloat(lat, 64)
+		if err != nil || latitude < -90 || latitude > 90 {
+			response.Error(ctx, http.StatusBadRequest, "lat must be a number between -90 and 90")
+			return nil, false
+		}
+		longitude, err := strconv.ParseFloat(lng, 64)
+		if err != nil || longitude < -180 || longitude > 180 {
+			response.Error(ctx, http.StatusBadRequest, "lng must be a number between -180 and 180")
+			return nil, false
+		}
+		radius := defaultVenueRadiusKm
+		if radiusStr := ctx.Query("radius_km"); radiusStr != "" {
+			radius, err = strconv.ParseFloat(radiusStr, 64)
+			if err != nil || radius <= 0 || radius > maxVenueRadiusKm {
+				response.Error(ctx, http.StatusBadRequest, fmt.Sprintf("radius_km must be greater than 0 and at most %g", maxVenueRadiusKm))
+				return nil, false
+			}
+		}
+		filters["near"] = GeoFilter{Latitude: latitude, Longitude: longitude, RadiusKm: radius}
+	} else if ctx.Query("radius_km") != "" {
+		response.Error(ctx, http.StatusBadRequest, "radius_km requires lat and lng")
+		return nil, false
+	}
 
+	return filters, true
 }
 
 // UpdateVenue godoc
@@ -196,31 +275,31 @@ func (c *VenueController) GetAllVenues(ctx *gin.Context) {
 // @Produce json
 // @Param venue_id path int true "Venue ID"
 // @Param venue body VenueInput true "Updated venue information"
-// @Success 200 {object} Venue "Venue updated successfully"
-// @Failure 400 {object} utils.ErrorResponse "Invalid input"
-// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
-// @Failure 403 {object} utils.ErrorResponse "Forbidden - not the venue manager"
-// @Failure 404 {object} utils.ErrorResponse "Venue not found"
-// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse{data=Venue} "Venue updated successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - not the venue manager"
+// @Failure 404 {object} response.ErrorResponse "Venue not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /manager/venues/{venue_id} [put]
 // @Security Bearer
 func (c *VenueController) UpdateVenue(ctx *gin.Context) {
 	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid venue ID"})
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
 		return
 	}
 
 	var input VenueInput
 	if err := ctx.ShouldBindJSON(&input); err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: err.Error()})
+		response.ValidationError(ctx, err)
 		return
 	}
 
 	// Get user ID from context (set by auth middleware)
-	_, exists := ctx.Get("userID")
+	userID, exists := middleware.CurrentUserID(ctx)
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, utils.ErrorResponse{Error: "unauthorized"})
+		response.Error(ctx, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
@@ -228,17 +307,42 @@ func (c *VenueController) UpdateVenue(ctx *gin.Context) {
 	venue, err := c.repo.GetVenueByID(uint(venueID))
 	if err != nil {
 		if err.Error() == "venue not found" {
-			ctx.JSON(http.StatusNotFound, utils.ErrorResponse{Error: "venue not found"})
+			response.Error(ctx, http.StatusNotFound, "venue not found")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get venue: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
+		}
+		return
+	}
+
+	// Check if the user manages the venue
+	manages, err := c.managesVenue(venue, userID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to check venue access: "+err.Error())
+		return
+	}
+	if !manages {
+		response.Error(ctx, http.StatusForbidden, "you are not authorized to update this venue")
+		return
+	}
+
+	// An omitted timezone keeps the venue's current one instead of resetting it to UTC
+	timezone := venue.Timezone
+	if strings.TrimSpace(input.Timezone) != "" {
+		if timezone, err = normalizeTimezone(input.Timezone); err != nil {
+			response.Error(ctx, http.StatusBadRequest, err.Error())
+			return
 		}
+	}
+	coordinates, err := normalizeCoordinates(input.Coordinates)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// Update venue fields
 	venue.Name = input.Name
 	venue.Location = input.Location
-	venue.Coordinates = input.Coordinates
+	venue.Coordinates = coordinates
 	venue.Facilities = input.Facilities
 	venue.Available = input.Available
 	venue.ContactInfo = input.ContactInfo
@@ -248,68 +352,143 @@ func (c *VenueController) UpdateVenue(ctx *gin.Context) {
 	venue.HourlyRate = input.HourlyRate
 	venue.CourtCount = input.CourtCount
 	venue.SocialHours = input.SocialHours
+	venue.Timezone = timezone
 
 	// Save updated venue
 	if err := c.repo.UpdateVenue(venue); err != nil {
-		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to update venue: " + err.Error()})
+		response.Error(ctx, http.StatusInternalServerError, "failed to update venue: "+err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, venue)
+	response.Success(ctx, http.StatusOK, "", venue)
 }
 
 // DeleteVenue godoc
 // @Summary Delete venue
-// @Description Delete an existing venue and all its associated data
+// @Description Soft deletes a venue, hiding it from listings until an admin restores it. Refused while the venue has future confirmed bookings. Admins may pass hard_delete to remove the venue and all its associated data instead.
 // @Tags venues
 // @Produce json
 // @Param venue_id path int true "Venue ID"
-// @Success 200 {object} utils.SuccessResponse "Venue deleted successfully"
-// @Failure 400 {object} utils.ErrorResponse "Invalid venue ID"
-// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
-// @Failure 403 {object} utils.ErrorResponse "Forbidden - not the venue manager"
-// @Failure 404 {object} utils.ErrorResponse "Venue not found"
-// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Param hard_delete query bool false "Permanently remove the venue and its data (admin only)" default(false)
+// @Success 200 {object} response.SuccessResponse "Venue deleted successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid venue ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - not the venue owner, or hard delete without the admin role"
+// @Failure 404 {object} response.ErrorResponse "Venue not found"
+// @Failure 409 {object} response.ErrorResponse "Venue has upcoming confirmed bookings"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /manager/venues/{venue_id} [delete]
 // @Security Bearer
 func (c *VenueController) DeleteVenue(ctx *gin.Context) {
 	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid venue ID"})
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
+		return
+	}
+
+	hardDelete, err := strconv.ParseBool(ctx.DefaultQuery("hard_delete", "false"))
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, "invalid hard_delete parameter")
+		return
+	}
+	isAdmin := middleware.HasRole(ctx, "admin")
+	if hardDelete && !isAdmin {
+		response.Error(ctx, http.StatusForbidden, "only admins can hard delete a venue")
 		return
 	}
 
 	// Get user ID from context (set by auth middleware)
-	userID, exists := ctx.Get("userID")
+	userID, exists := middleware.CurrentUserID(ctx)
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, utils.ErrorResponse{Error: "unauthorized"})
+		response.Error(ctx, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
-	// Get existing venue
-	venue, err := c.repo.GetVenueByID(uint(venueID))
+	// Get existing venue; admins may hard delete one that is already soft deleted
+	var venue *Venue
+	if hardDelete {
+		venue, err = c.repo.GetVenueByIDIncludingDeleted(uint(venueID))
+	} else {
+		venue, err = c.repo.GetVenueByID(uint(venueID))
+	}
 	if err != nil {
 		if err.Error() == "venue not found" {
-			ctx.JSON(http.StatusNotFound, utils.ErrorResponse{Error: "venue not found"})
+			response.Error(ctx, http.StatusNotFound, "venue not found")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get venue: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
 		}
 		return
 	}
 
-	// Check if the user is the venue manager
-	if venue.ManagerID != userID.(uint) {
-		ctx.JSON(http.StatusForbidden, utils.ErrorResponse{Error: "you are not authorized to delete this venue"})
+	// Only the owner or an admin can delete the venue; staff cannot
+	if !isAdmin {
+		role, err := c.venueRole(venue, userID)
+		if err != nil {
+			response.Error(ctx, http.StatusInternalServerError, "failed to check venue access: "+err.Error())
+			return
+		}
+		if role != VenueManagerOwner {
+			response.Error(ctx, http.StatusForbidden, "you are not authorized to delete this venue")
+			return
+		}
+	}
+
+	if err := c.repo.DeleteVenue(venue.ID, hardDelete); err != nil {
+		if errors.Is(err, ErrVenueHasUpcomingBookings) {
+			response.Error(ctx, http.StatusConflict, "venue has upcoming confirmed bookings; cancel them before deleting the venue")
+		} else {
+			response.Error(ctx, http.StatusInternalServerError, "failed to delete venue: "+err.Error())
+		}
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "venue deleted successfully", nil)
+}
+
+// RestoreVenue godoc
+// @Summary (Admin) Restore a deleted venue
+// @Description (Admin) Restores a soft deleted venue so it shows in listings again
+// @Tags venues
+// @Produce json
+// @Param venue_id path int true "Venue ID"
+// @Success 200 {object} response.SuccessResponse{data=Venue} "Venue restored successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid venue ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - admin access required"
+// @Failure 404 {object} response.ErrorResponse "Venue not found"
+// @Failure 409 {object} response.ErrorResponse "Venue is not deleted"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /admin/venues/{venue_id}/restore [post]
+// @Security Bearer
+func (c *VenueController) RestoreVenue(ctx *gin.Context) {
+	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
+		return
+	}
+
+	venue, err := c.repo.GetVenueByIDIncludingDeleted(uint(venueID))
+	if err != nil {
+		if err.Error() == "venue not found" {
+			response.Error(ctx, http.StatusNotFound, "venue not found")
+		} else {
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
+		}
+		return
+	}
+	if !venue.IsDeleted {
+		response.Error(ctx, http.StatusConflict, "venue is not deleted")
 		return
 	}
 
-	// Delete venue and related data
-	if err := c.repo.DeleteVenue(uint(venueID)); err != nil {
-		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to delete venue: " + err.Error()})
+	if err := c.repo.RestoreVenue(venue.ID); err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to restore venue: "+err.Error())
 		return
 	}
+	venue.IsDeleted = false
+	venue.DeletedAt = time.Time{}
 
-	ctx.JSON(http.StatusOK, utils.SuccessResponse{Message: "venue deleted successfully"})
+	response.Success(ctx, http.StatusOK, "venue restored successfully", venue)
 }
 
 // AddCourt godoc
@@ -320,31 +499,31 @@ func (c *VenueController) DeleteVenue(ctx *gin.Context) {
 // @Produce json
 // @Param venue_id path int true "Venue ID"
 // @Param court body CourtInput true "Court information"
-// @Success 201 {object} Ground "Court added successfully"
-// @Failure 400 {object} utils.ErrorResponse "Invalid input"
-// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
-// @Failure 403 {object} utils.ErrorResponse "Forbidden - not the venue manager"
-// @Failure 404 {object} utils.ErrorResponse "Venue not found"
-// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Success 201 {object} response.SuccessResponse{data=Ground} "Court added successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - not the venue manager"
+// @Failure 404 {object} response.ErrorResponse "Venue not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /manager/venues/{venue_id}/courts [post]
 // @Security Bearer
 func (c *VenueController) AddCourt(ctx *gin.Context) {
 	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid venue ID"})
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
 		return
 	}
 
 	var input CourtInput
 	if err := ctx.ShouldBindJSON(&input); err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: err.Error()})
+		response.ValidationError(ctx, err)
 		return
 	}
 
 	// Get user ID from context (set by auth middleware)
-	userID, exists := ctx.Get("userID")
+	userID, exists := middleware.CurrentUserID(ctx)
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, utils.ErrorResponse{Error: "unauthorized"})
+		response.Error(ctx, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
@@ -352,16 +531,25 @@ func (c *VenueController) AddCourt(ctx *gin.Context) {
 	venue, err := c.repo.GetVenueByID(uint(venueID))
 	if err != nil {
 		if err.Error() == "venue not found" {
-			ctx.JSON(http.StatusNotFound, utils.ErrorResponse{Error: "venue not found"})
+			response.Error(ctx, http.StatusNotFound, "venue not found")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get venue: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
 		}
 		return
 	}
 
-	// Check if the user is the venue manager
-	if venue.ManagerID != userID.(uint) {
-		ctx.JSON(http.StatusForbidden, utils.ErrorResponse{Error: "you are not authorized to add courts to this venue"})
+	// Check if the user manages the venue
+	manages, err := c.managesVenue(venue, userID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to check venue access: "+err.Error())
+		return
+	}
+	if !manages {
+		response.Error(ctx, http.StatusForbidden, "you are not authorized to add courts to this venue")
+		return
+	}
+
+	if !c.checkCourtSport(ctx, input.SportID) {
 		return
 	}
 
@@ -371,15 +559,133 @@ func (c *VenueController) AddCourt(ctx *gin.Context) {
 		Name:        input.Name,
 		Type:        input.Type,
 		Description: input.Description,
+		AutoConfirm: input.AutoConfirm,
+		SportID:     input.SportID,
 	}
 
 	// Save court to database
 	if err := c.repo.AddCourt(court); err != nil {
-		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to add court: " + err.Error()})
+		response.Error(ctx, http.StatusInternalServerError, "failed to add court: "+err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusCreated, "", court)
+}
+
+// BulkAddCourts godoc
+// @Summary Add several courts to a venue
+// @Description Create up to 50 courts for a venue in one transaction. Every entry is validated first; court names must be unique within the venue.
+// @Tags venues
+// @Accept json
+// @Produce json
+// @Param venue_id path int true "Venue ID"
+// @Param courts body BulkCourtInput true "Courts to create"
+// @Success 201 {object} response.SuccessResponse{data=[]Ground} "Courts added successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid input or per-court validation errors"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - not the venue manager"
+// @Failure 404 {object} response.ErrorResponse "Venue not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /manager/venues/{venue_id}/courts/bulk [post]
+// @Security Bearer
+func (c *VenueController) BulkAddCourts(ctx *gin.Context) {
+	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
+		return
+	}
+
+	var input BulkCourtInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		response.ValidationError(ctx, err)
+		return
+	}
+
+	userID, exists := middleware.CurrentUserID(ctx)
+	if !exists {
+		response.Error(ctx, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	venue, err := c.repo.GetVenueByID(uint(venueID))
+	if err != nil {
+		if err.Error() == "venue not found" {
+			response.Error(ctx, http.StatusNotFound, "venue not found")
+		} else {
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
+		}
+		return
+	}
+
+	manages, err := c.managesVenue(venue, userID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to check venue access: "+err.Error())
+		return
+	}
+	if !manages {
+		response.Error(ctx, http.StatusForbidden, "you are not authorized to add courts to this venue")
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, court)
+	existing, err := c.repo.GetCourtsByVenueID(uint(venueID))
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to get courts: "+err.Error())
+		return
+	}
+	taken := make(map[string]bool, len(existing)+len(input.Courts))
+	for _, court := range existing {
+		taken[strings.ToLower(strings.TrimSpace(court.Name))] = true
+	}
+
+	var problems []CourtInputError
+	sports := make(map[uint]bool) // Sport existence, checked once per sport
+	courts := make([]Ground, 0, len(input.Courts))
+	for i, in := range input.Courts {
+		name := strings.TrimSpace(in.Name)
+		switch key := strings.ToLower(name); {
+		case name == "":
+			problems = append(problems, CourtInputError{Index: i, Field: "name", Message: "name must not be blank"})
+		case taken[key]:
+			problems = append(problems, CourtInputError{Index: i, Field: "name", Message: "a court named " + name + " already exists at this venue"})
+		default:
+			taken[key] = true
+		}
+		if strings.TrimSpace(in.Type) == "" {
+			problems = append(problems, CourtInputError{Index: i, Field: "type", Message: "type must not be blank"})
+		}
+		if in.SportID != nil {
+			exists, checked := sports[*in.SportID]
+			if !checked {
+				if exists, err = c.repo.SportExists(*in.SportID); err != nil {
+					response.Error(ctx, http.StatusInternalServerError, "failed to check sport: "+err.Error())
+					return
+				}
+				sports[*in.SportID] = exists
+			}
+			if !exists {
+				problems = append(problems, CourtInputError{Index: i, Field: "sport_id", Message: "sport not found"})
+			}
+		}
+		courts = append(courts, Ground{
+			VenueID:     uint(venueID),
+			Name:        name,
+			Type:        strings.TrimSpace(in.Type),
+			Description: in.Description,
+			AutoConfirm: in.AutoConfirm,
+			SportID:     in.SportID,
+		})
+	}
+	if len(problems) > 0 {
+		response.ErrorWithDetails(ctx, http.StatusBadRequest, "invalid courts", problems)
+		return
+	}
+
+	if err := c.repo.AddCourts(courts); err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to add courts: "+err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusCreated, "", courts)
 }
 
 // GetVenueCourts godoc
@@ -388,15 +694,15 @@ func (c *VenueController) AddCourt(ctx *gin.Context) {
 // @Tags venues
 // @Produce json
 // @Param venue_id path int true "Venue ID"
-// @Success 200 {array} Ground "List of courts"
-// @Failure 400 {object} utils.ErrorResponse "Invalid venue ID"
-// @Failure 404 {object} utils.ErrorResponse "Venue not found"
-// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse{data=[]Ground} "List of courts"
+// @Failure 400 {object} response.ErrorResponse "Invalid venue ID"
+// @Failure 404 {object} response.ErrorResponse "Venue not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /venues/{venue_id}/courts [get]
 func (c *VenueController) GetVenueCourts(ctx *gin.Context) {
 	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid venue ID"})
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
 		return
 	}
 
@@ -404,20 +710,20 @@ func (c *VenueController) GetVenueCourts(ctx *gin.Context) {
 	_, err = c.repo.GetVenueByID(uint(venueID))
 	if err != nil {
 		if err.Error() == "venue not found" {
-			ctx.JSON(http.StatusNotFound, utils.ErrorResponse{Error: "venue not found"})
+			response.Error(ctx, http.StatusNotFound, "venue not found")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get venue: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
 		}
 		return
 	}
 
 	courts, err := c.repo.GetCourtsByVenueID(uint(venueID))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get courts: " + err.Error()})
+		response.Error(ctx, http.StatusInternalServerError, "failed to get courts: "+err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, courts)
+	response.Success(ctx, http.StatusOK, "", courts)
 }
 
 // UpdateCourt godoc
@@ -429,37 +735,37 @@ func (c *VenueController) GetVenueCourts(ctx *gin.Context) {
 // @Param venue_id path int true "Venue ID"
 // @Param court_id path int true "Court ID"
 // @Param court body CourtInput true "Updated court information"
-// @Success 200 {object} Ground "Court updated successfully"
-// @Failure 400 {object} utils.ErrorResponse "Invalid input"
-// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
-// @Failure 403 {object} utils.ErrorResponse "Forbidden - not the venue manager"
-// @Failure 404 {object} utils.ErrorResponse "Court or venue not found"
-// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse{data=Ground} "Court updated successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - not the venue manager"
+// @Failure 404 {object} response.ErrorResponse "Court or venue not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /manager/venues/{venue_id}/courts/{court_id} [put]
 // @Security Bearer
 func (c *VenueController) UpdateCourt(ctx *gin.Context) {
 	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid venue ID"})
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
 		return
 	}
 
 	courtID, err := strconv.ParseUint(ctx.Param("court_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid court ID"})
+		response.Error(ctx, http.StatusBadRequest, "invalid court ID")
 		return
 	}
 
 	var input CourtInput
 	if err := ctx.ShouldBindJSON(&input); err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: err.Error()})
+		response.ValidationError(ctx, err)
 		return
 	}
 
 	// Get user ID from context (set by auth middleware)
-	userID, exists := ctx.Get("userID")
+	userID, exists := middleware.CurrentUserID(ctx)
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, utils.ErrorResponse{Error: "unauthorized"})
+		response.Error(ctx, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
@@ -467,16 +773,21 @@ func (c *VenueController) UpdateCourt(ctx *gin.Context) {
 	venue, err := c.repo.GetVenueByID(uint(venueID))
 	if err != nil {
 		if err.Error() == "venue not found" {
-			ctx.JSON(http.StatusNotFound, utils.ErrorResponse{Error: "venue not found"})
+			response.Error(ctx, http.StatusNotFound, "venue not found")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get venue: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
 		}
 		return
 	}
 
-	// Check if the user is the venue manager
-	if venue.ManagerID != userID.(uint) {
-		ctx.JSON(http.StatusForbidden, utils.ErrorResponse{Error: "you are not authorized to update courts in this venue"})
+	// Check if the user manages the venue
+	manages, err := c.managesVenue(venue, userID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to check venue access: "+err.Error())
+		return
+	}
+	if !manages {
+		response.Error(ctx, http.StatusForbidden, "you are not authorized to update courts in this venue")
 		return
 	}
 
@@ -484,16 +795,20 @@ func (c *VenueController) UpdateCourt(ctx *gin.Context) {
 	court, err := c.repo.GetCourtByID(uint(courtID))
 	if err != nil {
 		if err.Error() == "court not found" {
-			ctx.JSON(http.StatusNotFound, utils.ErrorResponse{Error: "court not found"})
+			response.Error(ctx, http.StatusNotFound, "court not found")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get court: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to get court: "+err.Error())
 		}
 		return
 	}
 
 	// Verify court belongs to the venue
 	if court.VenueID != uint(venueID) {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "court does not belong to the specified venue"})
+		response.Error(ctx, http.StatusBadRequest, "court does not belong to the specified venue")
+		return
+	}
+
+	if !c.checkCourtSport(ctx, input.SportID) {
 		return
 	}
 
@@ -501,14 +816,16 @@ func (c *VenueController) UpdateCourt(ctx *gin.Context) {
 	court.Name = input.Name
 	court.Type = input.Type
 	court.Description = input.Description
+	court.AutoConfirm = input.AutoConfirm
+	court.SportID = input.SportID
 
 	// Save updated court
 	if err := c.repo.UpdateCourt(court); err != nil {
-		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to update court: " + err.Error()})
+		response.Error(ctx, http.StatusInternalServerError, "failed to update court: "+err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, court)
+	response.Success(ctx, http.StatusOK, "", court)
 }
 
 // DeleteCourt godoc
@@ -518,31 +835,31 @@ func (c *VenueController) UpdateCourt(ctx *gin.Context) {
 // @Produce json
 // @Param venue_id path int true "Venue ID"
 // @Param court_id path int true "Court ID"
-// @Success 200 {object} utils.SuccessResponse "Court deleted successfully"
-// @Failure 400 {object} utils.ErrorResponse "Invalid input or court doesn't belong to venue"
-// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
-// @Failure 403 {object} utils.ErrorResponse "Forbidden - not the venue manager"
-// @Failure 404 {object} utils.ErrorResponse "Court or venue not found"
-// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse "Court deleted successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid input or court doesn't belong to venue"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - not the venue manager"
+// @Failure 404 {object} response.ErrorResponse "Court or venue not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /manager/venues/{venue_id}/courts/{court_id} [delete]
 // @Security Bearer
 func (c *VenueController) DeleteCourt(ctx *gin.Context) {
 	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid venue ID"})
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
 		return
 	}
 
 	courtID, err := strconv.ParseUint(ctx.Param("court_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid court ID"})
+		response.Error(ctx, http.StatusBadRequest, "invalid court ID")
 		return
 	}
 
 	// Get user ID from context (set by auth middleware)
-	userID, exists := ctx.Get("userID")
+	userID, exists := middleware.CurrentUserID(ctx)
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, utils.ErrorResponse{Error: "unauthorized"})
+		response.Error(ctx, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
@@ -550,16 +867,21 @@ func (c *VenueController) DeleteCourt(ctx *gin.Context) {
 	venue, err := c.repo.GetVenueByID(uint(venueID))
 	if err != nil {
 		if err.Error() == "venue not found" {
-			ctx.JSON(http.StatusNotFound, utils.ErrorResponse{Error: "venue not found"})
+			response.Error(ctx, http.StatusNotFound, "venue not found")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get venue: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
 		}
 		return
 	}
 
-	// Check if the user is the venue manager
-	if venue.ManagerID != userID.(uint) {
-		ctx.JSON(http.StatusForbidden, utils.ErrorResponse{Error: "you are not authorized to delete courts from this venue"})
+	// Check if the user manages the venue
+	manages, err := c.managesVenue(venue, userID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to check venue access: "+err.Error())
+		return
+	}
+	if !manages {
+		response.Error(ctx, http.StatusForbidden, "you are not authorized to delete courts from this venue")
 		return
 	}
 
@@ -567,26 +889,26 @@ func (c *VenueController) DeleteCourt(ctx *gin.Context) {
 	court, err := c.repo.GetCourtByID(uint(courtID))
 	if err != nil {
 		if err.Error() == "court not found" {
-			ctx.JSON(http.StatusNotFound, utils.ErrorResponse{Error: "court not found"})
+			response.Error(ctx, http.StatusNotFound, "court not found")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get court: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to get court: "+err.Error())
 		}
 		return
 	}
 
 	// Verify court belongs to the venue
 	if court.VenueID != uint(venueID) {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "court does not belong to the specified venue"})
+		response.Error(ctx, http.StatusBadRequest, "court does not belong to the specified venue")
 		return
 	}
 
 	// Delete court
 	if err := c.repo.DeleteCourt(uint(courtID)); err != nil {
-		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to delete court: " + err.Error()})
+		response.Error(ctx, http.StatusInternalServerError, "failed to delete court: "+err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, utils.SuccessResponse{Message: "court deleted successfully"})
+	response.Success(ctx, http.StatusOK, "court deleted successfully", nil)
 }
 
 // CreateTimeSlots godoc
@@ -597,37 +919,37 @@ func (c *VenueController) DeleteCourt(ctx *gin.Context) {
 // @Produce json
 // @Param venue_id path int true "Venue ID"
 // @Param timeSlots body []TimeSlotInput true "Time slot information"
-// @Success 201 {array} TimeSlot "Time slots created successfully"
-// @Failure 400 {object} utils.ErrorResponse "Invalid input"
-// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
-// @Failure 403 {object} utils.ErrorResponse "Forbidden - not the venue manager"
-// @Failure 404 {object} utils.ErrorResponse "Venue not found"
-// @Failure 409 {object} utils.ErrorResponse "Time slot conflict"
-// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Success 201 {object} response.SuccessResponse{data=[]TimeSlotResponse} "Time slots created successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - not the venue manager"
+// @Failure 404 {object} response.ErrorResponse "Venue not found"
+// @Failure 409 {object} response.ErrorResponse "Time slot conflict"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /manager/venues/{venue_id}/timeslots [post]
 // @Security Bearer
 func (c *VenueController) CreateTimeSlots(ctx *gin.Context) {
 	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid venue ID"})
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
 		return
 	}
 
 	var inputs []TimeSlotInput
 	if err := ctx.ShouldBindJSON(&inputs); err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: err.Error()})
+		response.ValidationError(ctx, err)
 		return
 	}
 
 	if len(inputs) == 0 {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "at least one time slot is required"})
+		response.Error(ctx, http.StatusBadRequest, "at least one time slot is required")
 		return
 	}
 
 	// Get user ID from context (set by auth middleware)
-	userID, exists := ctx.Get("userID")
+	userID, exists := middleware.CurrentUserID(ctx)
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, utils.ErrorResponse{Error: "unauthorized"})
+		response.Error(ctx, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
@@ -635,16 +957,27 @@ func (c *VenueController) CreateTimeSlots(ctx *gin.Context) {
 	venue, err := c.repo.GetVenueByID(uint(venueID))
 	if err != nil {
 		if err.Error() == "venue not found" {
-			ctx.JSON(http.StatusNotFound, utils.ErrorResponse{Error: "venue not found"})
+			response.Error(ctx, http.StatusNotFound, "venue not found")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get venue: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
 		}
 		return
 	}
 
-	// Check if the user is the venue manager
-	if venue.ManagerID != userID.(uint) {
-		ctx.JSON(http.StatusForbidden, utils.ErrorResponse{Error: "you are not authorized to create time slots for this venue"})
+	// Check if the user manages the venue
+	manages, err := c.managesVenue(venue, userID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to check venue access: "+err.Error())
+		return
+	}
+	if !manages {
+		response.Error(ctx, http.StatusForbidden, "you are not authorized to create time slots for this venue")
+		return
+	}
+
+	groundIDs, err := c.venueGroundIDs(venue.ID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to get venue courts: "+err.Error())
 		return
 	}
 
@@ -652,13 +985,13 @@ func (c *VenueController) CreateTimeSlots(ctx *gin.Context) {
 	for _, input := range inputs {
 		// Check if start time is before end time
 		if !input.StartTime.Before(input.EndTime) {
-			ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "start time must be before end time"})
+			response.Error(ctx, http.StatusBadRequest, "start time must be before end time")
 			return
 		}
 
-		// Check if court number is within venue's court count
-		if input.CourtNumber > venue.CourtCount {
-			ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: fmt.Sprintf("court number %d exceeds venue's court count of %d", input.CourtNumber, venue.CourtCount)})
+		// Check if the ground belongs to this venue
+		if !groundIDs[input.GroundID] {
+			response.Error(ctx, http.StatusBadRequest, fmt.Sprintf("ground %d does not belong to this venue", input.GroundID))
 			return
 		}
 	}
@@ -668,7 +1001,7 @@ func (c *VenueController) CreateTimeSlots(ctx *gin.Context) {
 	for _, input := range inputs {
 		timeSlot := TimeSlot{
 			VenueID:     uint(venueID),
-			CourtNumber: input.CourtNumber,
+			GroundID:    input.GroundID,
 			StartTime:   input.StartTime,
 			EndTime:     input.EndTime,
 			Price:       input.Price,
@@ -683,14 +1016,27 @@ func (c *VenueController) CreateTimeSlots(ctx *gin.Context) {
 	err = c.repo.CreateTimeSlots(timeSlots)
 	if err != nil {
 		if err.Error() == "overlapping time slot exists" {
-			ctx.JSON(http.StatusConflict, utils.ErrorResponse{Error: "one or more time slots overlap with existing time slots"})
+			response.Error(ctx, http.StatusConflict, "one or more time slots overlap with existing time slots")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to create time slots: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to create time slots: "+err.Error())
 		}
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, timeSlots)
+	response.Success(ctx, http.StatusCreated, "", newTimeSlotResponses(timeSlots, venue.TimeLocation()))
+}
+
+// venueGroundIDs returns the set of ground (court) IDs that belong to a venue
+func (c *VenueController) venueGroundIDs(venueID uint) (map[uint]bool, error) {
+	courts, err := c.repo.GetCourtsByVenueID(venueID)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[uint]bool, len(courts))
+	for _, court := range courts {
+		ids[court.ID] = true
+	}
+	return ids, nil
 }
 
 // GenerateAutoTimeSlots godoc
@@ -701,32 +1047,32 @@ func (c *VenueController) CreateTimeSlots(ctx *gin.Context) {
 // @Produce json
 // @Param venue_id path int true "Venue ID"
 // @Param autoSlots body AutoTimeSlotInput true "Auto time slot generation parameters"
-// @Success 201 {array} TimeSlot "Time slots created successfully"
-// @Failure 400 {object} utils.ErrorResponse "Invalid input"
-// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
-// @Failure 403 {object} utils.ErrorResponse "Forbidden - not the venue manager"
-// @Failure 404 {object} utils.ErrorResponse "Venue not found"
-// @Failure 409 {object} utils.ErrorResponse "Time slot conflict"
-// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Success 201 {object} response.SuccessResponse{data=AutoTimeSlotResult} "Time slots created; overlapping ones skipped"
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - not the venue manager"
+// @Failure 404 {object} response.ErrorResponse "Venue not found"
+// @Failure 409 {object} response.ErrorResponse "All generated time slots conflict"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /manager/venues/{venue_id}/timeslots/auto [post]
 // @Security Bearer
 func (c *VenueController) GenerateAutoTimeSlots(ctx *gin.Context) {
 	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid venue ID"})
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
 		return
 	}
 
 	var input AutoTimeSlotInput
 	if err := ctx.ShouldBindJSON(&input); err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: err.Error()})
+		response.ValidationError(ctx, err)
 		return
 	}
 
 	// Get user ID from context (set by auth middleware)
-	userID, exists := ctx.Get("userID")
+	userID, exists := middleware.CurrentUserID(ctx)
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, utils.ErrorResponse{Error: "unauthorized"})
+		response.Error(ctx, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
@@ -734,41 +1080,51 @@ func (c *VenueController) GenerateAutoTimeSlots(ctx *gin.Context) {
 	venue, err := c.repo.GetVenueByID(uint(venueID))
 	if err != nil {
 		if err.Error() == "venue not found" {
-			ctx.JSON(http.StatusNotFound, utils.ErrorResponse{Error: "venue not found"})
+			response.Error(ctx, http.StatusNotFound, "venue not found")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get venue: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
 		}
 		return
 	}
 
-	// Check if the user is the venue manager
-	if venue.ManagerID != userID.(uint) {
-		ctx.JSON(http.StatusForbidden, utils.ErrorResponse{Error: "you are not authorized to create time slots for this venue"})
+	// Check if the user manages the venue
+	manages, err := c.managesVenue(venue, userID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to check venue access: "+err.Error())
+		return
+	}
+	if !manages {
+		response.Error(ctx, http.StatusForbidden, "you are not authorized to create time slots for this venue")
 		return
 	}
 
 	// Validate input
 	startDate, err := time.Parse("2006-01-02", input.StartDate)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid start date format (use YYYY-MM-DD)"})
+		response.Error(ctx, http.StatusBadRequest, "invalid start date format (use YYYY-MM-DD)")
 		return
 	}
 
 	endDate, err := time.Parse("2006-01-02", input.EndDate)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid end date format (use YYYY-MM-DD)"})
+		response.Error(ctx, http.StatusBadRequest, "invalid end date format (use YYYY-MM-DD)")
 		return
 	}
 
 	if startDate.After(endDate) {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "start date must be before or equal to end date"})
+		response.Error(ctx, http.StatusBadRequest, "start date must be before or equal to end date")
 		return
 	}
 
-	// Validate court numbers
-	for _, courtNum := range input.CourtNumbers {
-		if courtNum <= 0 || courtNum > venue.CourtCount {
-			ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: fmt.Sprintf("court number %d is invalid (must be between 1 and %d)", courtNum, venue.CourtCount)})
+	// Validate that every ground belongs to this venue
+	groundIDs, err := c.venueGroundIDs(venue.ID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to get venue courts: "+err.Error())
+		return
+	}
+	for _, groundID := range input.GroundIDs {
+		if !groundIDs[groundID] {
+			response.Error(ctx, http.StatusBadRequest, fmt.Sprintf("ground %d does not belong to this venue", groundID))
 			return
 		}
 	}
@@ -777,19 +1133,19 @@ func (c *VenueController) GenerateAutoTimeSlots(ctx *gin.Context) {
 	startTimeStr := input.StartDate + "T" + input.StartTime + ":00Z"
 	dailyStartTime, err := time.Parse("2006-01-02T15:04:05Z", startTimeStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid start time format (use HH:MM)"})
+		response.Error(ctx, http.StatusBadRequest, "invalid start time format (use HH:MM)")
 		return
 	}
 
 	endTimeStr := input.StartDate + "T" + input.EndTime + ":00Z"
 	dailyEndTime, err := time.Parse("2006-01-02T15:04:05Z", endTimeStr)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid end time format (use HH:MM)"})
+		response.Error(ctx, http.StatusBadRequest, "invalid end time format (use HH:MM)")
 		return
 	}
 
 	if !dailyStartTime.Before(dailyEndTime) {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "daily start time must be before daily end time"})
+		response.Error(ctx, http.StatusBadRequest, "daily start time must be before daily end time")
 		return
 	}
 
@@ -801,11 +1157,16 @@ func (c *VenueController) GenerateAutoTimeSlots(ctx *gin.Context) {
 	for _, day := range input.DaysOfWeek {
 		day = strings.ToLower(day)
 		if _, valid := validDays[day]; !valid {
-			ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid day of week: " + day})
+			response.Error(ctx, http.StatusBadRequest, "invalid day of week: "+day)
 			return
 		}
 	}
 
+	// Slot times are interpreted as wall-clock times in the venue's timezone.
+	// time.Date normalises DST gaps/overlaps, and slots advance by absolute
+	// durations, so days with 23 or 25 hours produce the correct slots.
+	loc := venue.TimeLocation()
+
 	// Generate time slots
 	var timeSlots []TimeSlot
 
@@ -825,19 +1186,19 @@ func (c *VenueController) GenerateAutoTimeSlots(ctx *gin.Context) {
 			continue
 		}
 
-		// For each court
-		for _, courtNum := range input.CourtNumbers {
+		// For each ground
+		for _, groundID := range input.GroundIDs {
 			// Set times for this day
 			currentStart := time.Date(
 				d.Year(), d.Month(), d.Day(),
 				dailyStartTime.Hour(), dailyStartTime.Minute(), 0, 0,
-				time.UTC,
+				loc,
 			)
 
 			dailyEnd := time.Date(
 				d.Year(), d.Month(), d.Day(),
 				dailyEndTime.Hour(), dailyEndTime.Minute(), 0, 0,
-				time.UTC,
+				loc,
 			)
 
 			// Generate slots until we reach the end time
@@ -852,9 +1213,9 @@ func (c *VenueController) GenerateAutoTimeSlots(ctx *gin.Context) {
 				if slotEnd.After(currentStart) {
 					timeSlot := TimeSlot{
 						VenueID:     uint(venueID),
-						CourtNumber: courtNum,
-						StartTime:   currentStart,
-						EndTime:     slotEnd,
+						GroundID:    groundID,
+						StartTime:   currentStart.UTC(),
+						EndTime:     slotEnd.UTC(),
 						Price:       input.Price,
 						BookingType: input.BookingType,
 						Equipment:   input.Equipment,
@@ -871,82 +1232,136 @@ func (c *VenueController) GenerateAutoTimeSlots(ctx *gin.Context) {
 
 	// Save generated time slots
 	if len(timeSlots) == 0 {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "no valid time slots could be generated with the provided parameters"})
+		response.Error(ctx, http.StatusBadRequest, "no valid time slots could be generated with the provided parameters")
+		return
+	}
+
+	// Drop slots that clash with existing ones instead of failing the whole batch
+	timeSlots, skipped, err := c.repo.FilterOverlappingTimeSlots(uint(venueID), timeSlots)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to check existing time slots: "+err.Error())
+		return
+	}
+	if len(timeSlots) == 0 {
+		response.Error(ctx, http.StatusConflict, fmt.Sprintf("all %d generated time slots overlap with existing time slots", skipped))
 		return
 	}
 
 	err = c.repo.CreateTimeSlots(timeSlots)
 	if err != nil {
-		if err.Error() == "overlapping time slot exists" {
-			ctx.JSON(http.StatusConflict, utils.ErrorResponse{Error: "one or more generated time slots overlap with existing time slots"})
-		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to create time slots: " + err.Error()})
-		}
+		response.Error(ctx, http.StatusInternalServerError, "failed to create time slots: "+err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, timeSlots)
+	response.Success(ctx, http.StatusCreated, "", AutoTimeSlotResult{
+		Created: len(timeSlots),
+		Skipped: skipped,
+		Slots:   newTimeSlotResponses(timeSlots, loc),
+	})
 }
 
 // GetVenueTimeSlots godoc
 // @Summary Get venue time slots
-// @Description Get time slots for a specific venue, optionally filtered by date and court number
+// @Description Get time slots for a specific venue, optionally filtered by date and ground
 // @Tags venues
 // @Produce json
 // @Param venue_id path int true "Venue ID"
 // @Param date query string false "Filter by date (YYYY-MM-DD format)"
-// @Param court_number query int false "Filter by court number"
-// @Success 200 {array} TimeSlot "List of time slots"
-// @Failure 400 {object} utils.ErrorResponse "Invalid input parameters"
-// @Failure 404 {object} utils.ErrorResponse "Venue not found"
-// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Param ground_id query int false "Filter by ground (court) ID"
+// @Success 200 {object} response.SuccessResponse{data=[]TimeSlotResponse} "List of time slots"
+// @Failure 400 {object} response.ErrorResponse "Invalid input parameters"
+// @Failure 404 {object} response.ErrorResponse "Venue not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /venues/{venue_id}/timeslots [get]
 func (c *VenueController) GetVenueTimeSlots(ctx *gin.Context) {
 	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid venue ID"})
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
 		return
 	}
 
 	// Verify venue exists
-	_, err = c.repo.GetVenueByID(uint(venueID))
+	venue, err := c.repo.GetVenueByID(uint(venueID))
 	if err != nil {
 		if err.Error() == "venue not found" {
-			ctx.JSON(http.StatusNotFound, utils.ErrorResponse{Error: "venue not found"})
+			response.Error(ctx, http.StatusNotFound, "venue not found")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get venue: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
 		}
 		return
 	}
 
-	// Parse date filter if provided
+	// Parse date filter if provided (a calendar day in the venue's timezone)
 	var dateFilter time.Time
 	if dateStr := ctx.Query("date"); dateStr != "" {
-		dateFilter, err = time.Parse("2006-01-02", dateStr)
+		dateFilter, err = time.ParseInLocation("2006-01-02", dateStr, venue.TimeLocation())
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid date format (use YYYY-MM-DD)"})
+			response.Error(ctx, http.StatusBadRequest, "invalid date format (use YYYY-MM-DD)")
 			return
 		}
 	}
 
-	// Parse court number filter if provided
-	courtNumber := 0
-	if courtNumberStr := ctx.Query("court_number"); courtNumberStr != "" {
-		courtNumber, err = strconv.Atoi(courtNumberStr)
-		if err != nil || courtNumber < 0 {
-			ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid court number"})
+	// Parse ground filter if provided
+	var groundID uint64
+	if groundIDStr := ctx.Query("ground_id"); groundIDStr != "" {
+		groundID, err = strconv.ParseUint(groundIDStr, 10, 32)
+		if err != nil {
+			response.Error(ctx, http.StatusBadRequest, "invalid ground ID")
 			return
 		}
 	}
 
 	// Get time slots
-	timeSlots, err := c.repo.GetTimeSlotsByVenueID(uint(venueID), dateFilter, courtNumber)
+	timeSlots, err := c.repo.GetTimeSlotsByVenueID(uint(venueID), dateFilter, uint(groundID))
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to get time slots: "+err.Error())
+		return
+	}
+
+	timeSlots, err = c.withoutClosedSlots(uint(venueID), timeSlots)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get time slots: " + err.Error()})
+		response.Error(ctx, http.StatusInternalServerError, "failed to get closures: "+err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, timeSlots)
+	response.Success(ctx, http.StatusOK, "", newTimeSlotResponses(timeSlots, venue.TimeLocation()))
+}
+
+// withoutClosedSlots drops the time slots that fall inside a closure of the venue or their court
+func (c *VenueController) withoutClosedSlots(venueID uint, slots []TimeSlot) ([]TimeSlot, error) {
+	if len(slots) == 0 {
+		return slots, nil
+	}
+
+	start, end := slots[0].StartTime, slots[0].EndTime
+	for _, slot := range slots[1:] {
+		if slot.StartTime.Before(start) {
+			start = slot.StartTime
+		}
+		if slot.EndTime.After(end) {
+			end = slot.EndTime
+		}
+	}
+
+	closures, err := c.repo.GetActiveClosures(venueID, 0, start, end)
+	if err != nil || len(closures) == 0 {
+		return slots, err
+	}
+
+	open := make([]TimeSlot, 0, len(slots))
+	for _, slot := range slots {
+		closed := false
+		for i := range closures {
+			if closures[i].Overlaps(slot.GroundID, slot.StartTime, slot.EndTime) {
+				closed = true
+				break
+			}
+		}
+		if !closed {
+			open = append(open, slot)
+		}
+	}
+	return open, nil
 }
 
 // UpdateTimeSlot godoc
@@ -958,38 +1373,38 @@ func (c *VenueController) GetVenueTimeSlots(ctx *gin.Context) {
 // @Param venue_id path int true "Venue ID"
 // @Param timeslot_id path int true "Time Slot ID"
 // @Param timeSlot body TimeSlotInput true "Updated time slot information"
-// @Success 200 {object} TimeSlot "Time slot updated successfully"
-// @Failure 400 {object} utils.ErrorResponse "Invalid input"
-// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
-// @Failure 403 {object} utils.ErrorResponse "Forbidden - not the venue manager"
-// @Failure 404 {object} utils.ErrorResponse "Time slot or venue not found"
-// @Failure 409 {object} utils.ErrorResponse "Time slot conflict"
-// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse{data=TimeSlot} "Time slot updated successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - not the venue manager"
+// @Failure 404 {object} response.ErrorResponse "Time slot or venue not found"
+// @Failure 409 {object} response.ErrorResponse "Time slot conflict"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /manager/venues/{venue_id}/timeslots/{timeslot_id} [put]
 // @Security Bearer
 func (c *VenueController) UpdateTimeSlot(ctx *gin.Context) {
 	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid venue ID"})
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
 		return
 	}
 
 	timeSlotID, err := strconv.ParseUint(ctx.Param("timeslot_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid time slot ID"})
+		response.Error(ctx, http.StatusBadRequest, "invalid time slot ID")
 		return
 	}
 
 	var input TimeSlotInput
 	if err := ctx.ShouldBindJSON(&input); err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: err.Error()})
+		response.ValidationError(ctx, err)
 		return
 	}
 
 	// Get user ID from context (set by auth middleware)
-	userID, exists := ctx.Get("userID")
+	userID, exists := middleware.CurrentUserID(ctx)
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, utils.ErrorResponse{Error: "unauthorized"})
+		response.Error(ctx, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
@@ -997,16 +1412,21 @@ func (c *VenueController) UpdateTimeSlot(ctx *gin.Context) {
 	venue, err := c.repo.GetVenueByID(uint(venueID))
 	if err != nil {
 		if err.Error() == "venue not found" {
-			ctx.JSON(http.StatusNotFound, utils.ErrorResponse{Error: "venue not found"})
+			response.Error(ctx, http.StatusNotFound, "venue not found")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get venue: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
 		}
 		return
 	}
 
-	// Check if the user is the venue manager
-	if venue.ManagerID != userID.(uint) {
-		ctx.JSON(http.StatusForbidden, utils.ErrorResponse{Error: "you are not authorized to update time slots for this venue"})
+	// Check if the user manages the venue
+	manages, err := c.managesVenue(venue, userID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to check venue access: "+err.Error())
+		return
+	}
+	if !manages {
+		response.Error(ctx, http.StatusForbidden, "you are not authorized to update time slots for this venue")
 		return
 	}
 
@@ -1014,39 +1434,40 @@ func (c *VenueController) UpdateTimeSlot(ctx *gin.Context) {
 	timeSlot, err := c.repo.GetTimeSlotByID(uint(timeSlotID))
 	if err != nil {
 		if err.Error() == "time slot not found" {
-			ctx.JSON(http.StatusNotFound, utils.ErrorResponse{Error: "time slot not found"})
+			response.Error(ctx, http.StatusNotFound, "time slot not found")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get time slot: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to get time slot: "+err.Error())
 		}
 		return
 	}
 
 	// Verify time slot belongs to the venue
 	if timeSlot.VenueID != uint(venueID) {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "time slot does not belong to the specified venue"})
+		response.Error(ctx, http.StatusBadRequest, "time slot does not belong to the specified venue")
 		return
 	}
 
 	// Check if the time slot is already booked
 	if timeSlot.IsBooked {
-		ctx.JSON(http.StatusConflict, utils.ErrorResponse{Error: "cannot update a time slot that is already booked"})
+		response.Error(ctx, http.StatusConflict, "cannot update a time slot that is already booked")
 		return
 	}
 
-	// Check if court number is within venue's court count
-	if input.CourtNumber > venue.CourtCount {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: fmt.Sprintf("court number %d exceeds venue's court count of %d", input.CourtNumber, venue.CourtCount)})
+	// Check if the ground belongs to this venue
+	ground, err := c.repo.GetCourtByID(input.GroundID)
+	if err != nil || ground.VenueID != venue.ID {
+		response.Error(ctx, http.StatusBadRequest, fmt.Sprintf("ground %d does not belong to this venue", input.GroundID))
 		return
 	}
 
 	// Check if start time is before end time
 	if !input.StartTime.Before(input.EndTime) {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "start time must be before end time"})
+		response.Error(ctx, http.StatusBadRequest, "start time must be before end time")
 		return
 	}
 
 	// Update time slot fields
-	timeSlot.CourtNumber = input.CourtNumber
+	timeSlot.GroundID = input.GroundID
 	timeSlot.StartTime = input.StartTime
 	timeSlot.EndTime = input.EndTime
 	timeSlot.Price = input.Price
@@ -1056,14 +1477,14 @@ func (c *VenueController) UpdateTimeSlot(ctx *gin.Context) {
 	// Save updated time slot
 	if err := c.repo.UpdateTimeSlot(timeSlot); err != nil {
 		if err.Error() == "overlapping time slot exists" {
-			ctx.JSON(http.StatusConflict, utils.ErrorResponse{Error: "the updated time slot overlaps with an existing time slot"})
+			response.Error(ctx, http.StatusConflict, "the updated time slot overlaps with an existing time slot")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to update time slot: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to update time slot: "+err.Error())
 		}
 		return
 	}
 
-	ctx.JSON(http.StatusOK, timeSlot)
+	response.Success(ctx, http.StatusOK, "", timeSlot)
 }
 
 // DeleteTimeSlot godoc
@@ -1073,32 +1494,32 @@ func (c *VenueController) UpdateTimeSlot(ctx *gin.Context) {
 // @Produce json
 // @Param venue_id path int true "Venue ID"
 // @Param timeslot_id path int true "Time Slot ID"
-// @Success 200 {object} utils.SuccessResponse "Time slot deleted successfully"
-// @Failure 400 {object} utils.ErrorResponse "Invalid input or time slot doesn't belong to venue"
-// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
-// @Failure 403 {object} utils.ErrorResponse "Forbidden - not the venue manager"
-// @Failure 404 {object} utils.ErrorResponse "Time slot or venue not found"
-// @Failure 409 {object} utils.ErrorResponse "Cannot delete a booked time slot"
-// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Success 200 {object} response.SuccessResponse "Time slot deleted successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid input or time slot doesn't belong to venue"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - not the venue manager"
+// @Failure 404 {object} response.ErrorResponse "Time slot or venue not found"
+// @Failure 409 {object} response.ErrorResponse "Cannot delete a booked time slot"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /manager/venues/{venue_id}/timeslots/{timeslot_id} [delete]
 // @Security Bearer
 func (c *VenueController) DeleteTimeSlot(ctx *gin.Context) {
 	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid venue ID"})
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
 		return
 	}
 
 	timeSlotID, err := strconv.ParseUint(ctx.Param("timeslot_id"), 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "invalid time slot ID"})
+		response.Error(ctx, http.StatusBadRequest, "invalid time slot ID")
 		return
 	}
 
 	// Get user ID from context (set by auth middleware)
-	userID, exists := ctx.Get("userID")
+	userID, exists := middleware.CurrentUserID(ctx)
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, utils.ErrorResponse{Error: "unauthorized"})
+		response.Error(ctx, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
@@ -1106,16 +1527,21 @@ func (c *VenueController) DeleteTimeSlot(ctx *gin.Context) {
 	venue, err := c.repo.GetVenueByID(uint(venueID))
 	if err != nil {
 		if err.Error() == "venue not found" {
-			ctx.JSON(http.StatusNotFound, utils.ErrorResponse{Error: "venue not found"})
+			response.Error(ctx, http.StatusNotFound, "venue not found")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get venue: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
 		}
 		return
 	}
 
-	// Check if the user is the venue manager
-	if venue.ManagerID != userID.(uint) {
-		ctx.JSON(http.StatusForbidden, utils.ErrorResponse{Error: "you are not authorized to delete time slots for this venue"})
+	// Check if the user manages the venue
+	manages, err := c.managesVenue(venue, userID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to check venue access: "+err.Error())
+		return
+	}
+	if !manages {
+		response.Error(ctx, http.StatusForbidden, "you are not authorized to delete time slots for this venue")
 		return
 	}
 
@@ -1123,32 +1549,32 @@ func (c *VenueController) DeleteTimeSlot(ctx *gin.Context) {
 	timeSlot, err := c.repo.GetTimeSlotByID(uint(timeSlotID))
 	if err != nil {
 		if err.Error() == "time slot not found" {
-			ctx.JSON(http.StatusNotFound, utils.ErrorResponse{Error: "time slot not found"})
+			response.Error(ctx, http.StatusNotFound, "time slot not found")
 		} else {
-			ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to get time slot: " + err.Error()})
+			response.Error(ctx, http.StatusInternalServerError, "failed to get time slot: "+err.Error())
 		}
 		return
 	}
 
 	// Verify time slot belongs to the venue
 	if timeSlot.VenueID != uint(venueID) {
-		ctx.JSON(http.StatusBadRequest, utils.ErrorResponse{Error: "time slot does not belong to the specified venue"})
+		response.Error(ctx, http.StatusBadRequest, "time slot does not belong to the specified venue")
 		return
 	}
 
 	// Check if the time slot is already booked
 	if timeSlot.IsBooked {
-		ctx.JSON(http.StatusConflict, utils.ErrorResponse{Error: "cannot delete a time slot that is already booked"})
+		response.Error(ctx, http.StatusConflict, "cannot delete a time slot that is already booked")
 		return
 	}
 
 	// Delete time slot
 	if err := c.repo.DeleteTimeSlot(uint(timeSlotID)); err != nil {
-		ctx.JSON(http.StatusInternalServerError, utils.ErrorResponse{Error: "failed to delete time slot: " + err.Error()})
+		response.Error(ctx, http.StatusInternalServerError, "failed to delete time slot: "+err.Error())
 		return
 	}
 
-	ctx.JSON(http.StatusOK, utils.SuccessResponse{Message: "time slot deleted successfully"})
+	response.Success(ctx, http.StatusOK, "time slot deleted successfully", nil)
 }
 
 // UpdateBookingStatusRequest represents the request body for status updates
@@ -1162,6 +1588,226 @@ type PaginationQuery struct {
 	Limit int `form:"limit,default=10" binding:"min=1,max=100"`
 }
 
+// CreateVenueClosure godoc
+// @Summary Close a venue or court
+// @Description Close the whole venue, or one of its courts, for a period. Bookings that fall inside a closure are rejected and its time slots are hidden. A closure may not overlap another closure for the same court or for the whole venue.
+// @Tags venues
+// @Accept json
+// @Produce json
+// @Param venue_id path int true "Venue ID"
+// @Param closure body ClosureInput true "Closure information"
+// @Success 201 {object} response.SuccessResponse{data=VenueClosure} "Closure created successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid input or court doesn't belong to venue"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - not the venue manager"
+// @Failure 404 {object} response.ErrorResponse "Venue or court not found"
+// @Failure 409 {object} response.ErrorResponse "Closure overlaps an existing closure"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /manager/venues/{venue_id}/closures [post]
+// @Security Bearer
+func (c *VenueController) CreateVenueClosure(ctx *gin.Context) {
+	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
+		return
+	}
+
+	var input ClosureInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		response.ValidationError(ctx, err)
+		return
+	}
+
+	if !input.EndsAt.After(input.StartsAt) {
+		response.Error(ctx, http.StatusBadRequest, "closure end must be after its start")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := middleware.CurrentUserID(ctx)
+	if !exists {
+		response.Error(ctx, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get existing venue
+	venue, err := c.repo.GetVenueByID(uint(venueID))
+	if err != nil {
+		if err.Error() == "venue not found" {
+			response.Error(ctx, http.StatusNotFound, "venue not found")
+		} else {
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
+		}
+		return
+	}
+
+	// Check if the user manages the venue
+	manages, err := c.managesVenue(venue, userID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to check venue access: "+err.Error())
+		return
+	}
+	if !manages {
+		response.Error(ctx, http.StatusForbidden, "you are not authorized to close this venue")
+		return
+	}
+
+	// A court-level closure must target a court of this venue
+	if input.GroundID != nil {
+		court, err := c.repo.GetCourtByID(*input.GroundID)
+		if err != nil {
+			if err.Error() == "court not found" {
+				response.Error(ctx, http.StatusNotFound, "court not found")
+			} else {
+				response.Error(ctx, http.StatusInternalServerError, "failed to get court: "+err.Error())
+			}
+			return
+		}
+		if court.VenueID != uint(venueID) {
+			response.Error(ctx, http.StatusBadRequest, "court does not belong to this venue")
+			return
+		}
+	}
+
+	closure := &VenueClosure{
+		VenueID:     uint(venueID),
+		GroundID:    input.GroundID,
+		StartsAt:    input.StartsAt.UTC(),
+		EndsAt:      input.EndsAt.UTC(),
+		Reason:      strings.TrimSpace(input.Reason),
+		CreatedByID: userID,
+	}
+
+	if err := c.repo.CreateClosure(closure); err != nil {
+		var conflict *ClosureConflictError
+		if errors.As(err, &conflict) {
+			response.ErrorWithDetails(ctx, http.StatusConflict, "closure overlaps an existing closure", gin.H{"conflicts": conflict.Conflicts})
+			return
+		}
+		response.Error(ctx, http.StatusInternalServerError, "failed to create closure: "+err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusCreated, "", closure)
+}
+
+// GetVenueClosures godoc
+// @Summary List venue closures
+// @Description Get the current and upcoming closures of a venue, including court-level closures
+// @Tags venues
+// @Produce json
+// @Param venue_id path int true "Venue ID"
+// @Success 200 {object} response.SuccessResponse{data=[]VenueClosure} "List of closures"
+// @Failure 400 {object} response.ErrorResponse "Invalid venue ID"
+// @Failure 404 {object} response.ErrorResponse "Venue not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /venues/{venue_id}/closures [get]
+func (c *VenueController) GetVenueClosures(ctx *gin.Context) {
+	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
+		return
+	}
+
+	// Verify venue exists
+	if _, err := c.repo.GetVenueByID(uint(venueID)); err != nil {
+		if err.Error() == "venue not found" {
+			response.Error(ctx, http.StatusNotFound, "venue not found")
+		} else {
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
+		}
+		return
+	}
+
+	closures, err := c.repo.GetClosuresByVenueID(uint(venueID), time.Now().UTC())
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to get closures: "+err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "", closures)
+}
+
+// DeleteVenueClosure godoc
+// @Summary Delete venue closure
+// @Description Remove a closure, reopening the venue or court for that period
+// @Tags venues
+// @Produce json
+// @Param venue_id path int true "Venue ID"
+// @Param closure_id path int true "Closure ID"
+// @Success 200 {object} response.SuccessResponse "Closure deleted successfully"
+// @Failure 400 {object} response.ErrorResponse "Invalid input or closure doesn't belong to venue"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - not the venue manager"
+// @Failure 404 {object} response.ErrorResponse "Closure or venue not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /manager/venues/{venue_id}/closures/{closure_id} [delete]
+// @Security Bearer
+func (c *VenueController) DeleteVenueClosure(ctx *gin.Context) {
+	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
+		return
+	}
+
+	closureID, err := strconv.ParseUint(ctx.Param("closure_id"), 10, 32)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, "invalid closure ID")
+		return
+	}
+
+	// Get user ID from context (set by auth middleware)
+	userID, exists := middleware.CurrentUserID(ctx)
+	if !exists {
+		response.Error(ctx, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get existing venue
+	venue, err := c.repo.GetVenueByID(uint(venueID))
+	if err != nil {
+		if err.Error() == "venue not found" {
+			response.Error(ctx, http.StatusNotFound, "venue not found")
+		} else {
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
+		}
+		return
+	}
+
+	// Check if the user manages the venue
+	manages, err := c.managesVenue(venue, userID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to check venue access: "+err.Error())
+		return
+	}
+	if !manages {
+		response.Error(ctx, http.StatusForbidden, "you are not authorized to delete closures of this venue")
+		return
+	}
+
+	closure, err := c.repo.GetClosureByID(uint(closureID))
+	if err != nil {
+		if err.Error() == "closure not found" {
+			response.Error(ctx, http.StatusNotFound, "closure not found")
+		} else {
+			response.Error(ctx, http.StatusInternalServerError, "failed to get closure: "+err.Error())
+		}
+		return
+	}
+
+	if closure.VenueID != uint(venueID) {
+		response.Error(ctx, http.StatusBadRequest, "closure does not belong to this venue")
+		return
+	}
+
+	if err := c.repo.DeleteClosure(uint(closureID)); err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to delete closure: "+err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "closure deleted successfully", nil)
+}
+
 // GetVenueBookings godoc
 // @Summary Get bookings for a specific venue
 // @Description Retrieves all bookings for a venue with pagination and optional filters
@@ -1174,48 +1820,200 @@ type PaginationQuery struct {
 // @Param status query string false "Filter by status (pending, confirmed, cancelled, completed, rejected)"
 // @Param date query string false "Filter by date (YYYY-MM-DD format)"
 // @Param court_id query int false "Filter by court ID"
-// @Success 200 {object} map[string]interface{} "List of bookings and pagination metadata"
-// @Failure 400 {object} map[string]interface{} "Bad request"
-// @Failure 404 {object} map[string]interface{} "Venue not found"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Param q query string false "Search purpose (case-insensitive, partial match) or booking reference"
+// @Param purpose query string false "Alias of q"
+// @Success 200 {object} response.SuccessResponse "List of bookings and pagination metadata"
+// @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 404 {object} response.ErrorResponse "Venue not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /api/venue-manager/{venue_id}/bookings [get]
 func (c *VenueController) GetVenueBookings(ctx *gin.Context) {
 	// Parse venue ID from URL
 	venueIDStr := ctx.Param("venue_id")
 	venueID, err := strconv.ParseUint(venueIDStr, 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid venue ID format"})
+		response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingInvalidVenueID))
 		return
 	}
 
 	// Check if venue exists
 	venue, err := c.repo.GetVenueByID(uint(venueID))
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "Venue not found"})
+		response.Error(ctx, http.StatusNotFound, i18n.T(ctx, i18n.BookingVenueNotFound))
 		return
 	}
 
 	// Get manager ID from context (assuming it was set during authentication)
-	managerID, exists := ctx.Get("userID")
+	managerID, exists := middleware.CurrentUserID(ctx)
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized access"})
+		response.Error(ctx, http.StatusUnauthorized, i18n.T(ctx, i18n.BookingUnauthorized))
 		return
 	}
 
 	// Ensure the requester is the manager of this venue
-	if venue.ManagerID != managerID.(uint) {
-		ctx.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to view bookings for this venue"})
+	manages, err := c.managesVenue(venue, managerID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingAccessCheckFailed))
+		return
+	}
+	if !manages {
+		response.Error(ctx, http.StatusForbidden, i18n.T(ctx, i18n.BookingNoVenueViewPermission))
 		return
 	}
 
 	// Parse pagination parameters
 	var pagination PaginationQuery
 	if err := ctx.ShouldBindQuery(&pagination); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pagination parameters"})
+		response.ValidationError(ctx, err)
+		return
+	}
+
+	filters, ok := bookingFiltersFromQuery(ctx)
+	if !ok {
+		return
+	}
+
+	// Get bookings from repository
+	bookings, totalCount, err := c.repo.GetBookingsByVenueID(uint(venueID), pagination.Page, pagination.Limit, filters)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingFetchFailed, err.Error()))
 		return
 	}
 
-	// Build filters
+	response.Paginated(ctx, http.StatusOK, "", bookings, totalCount, pagination.Page, pagination.Limit)
+}
+
+// bookingExportHeader is the column header of venue booking exports
+var bookingExportHeader = []string{"booking_id", "court_id", "court", "user_id", "user", "start_time", "end_time", "status", "purpose", "price"}
+
+// bookingExportFlushRows is how many rows are buffered before an export is flushed to the client
+const bookingExportFlushRows = 200
+
+// csvCell neutralises user-entered text for CSV exports: a cell starting with =, +, -, @, tab
+// or carriage return is prefixed with ' so spreadsheets do not evaluate it as a formula
+func csvCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// ExportVenueBookings godoc
+// @Summary Export bookings for a venue
+// @Description Streams all bookings of a venue as CSV, newest first, with the same filters as the booking list. Times are in the venue's time zone. The price is the one recorded when the booking was made, or the venue's hourly rate for the booked duration for older bookings.
+// @Tags venues
+// @Produce text/csv
+// @Param venue_id path int true "Venue ID"
+// @Param format query string false "Export format (csv)" default(csv)
+// @Param status query string false "Filter by status (pending, confirmed, cancelled, completed, rejected)"
+// @Param date query string false "Filter by date (YYYY-MM-DD format)"
+// @Param court_id query int false "Filter by court ID"
+// @Param q query string false "Search purpose (case-insensitive, partial match) or booking reference"
+// @Param purpose query string false "Alias of q"
+// @Success 200 {file} file "CSV file"
+// @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Venue not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/manager/venues/{venue_id}/bookings/export [get]
+func (c *VenueController) ExportVenueBookings(ctx *gin.Context) {
+	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingInvalidVenueID))
+		return
+	}
+
+	venue, err := c.repo.GetVenueByID(uint(venueID))
+	if err != nil {
+		response.Error(ctx, http.StatusNotFound, i18n.T(ctx, i18n.BookingVenueNotFound))
+		return
+	}
+
+	managerID, exists := middleware.CurrentUserID(ctx)
+	if !exists {
+		response.Error(ctx, http.StatusUnauthorized, i18n.T(ctx, i18n.BookingUnauthorized))
+		return
+	}
+	manages, err := c.managesVenue(venue, managerID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingAccessCheckFailed))
+		return
+	}
+	if !manages {
+		response.Error(ctx, http.StatusForbidden, i18n.T(ctx, i18n.BookingNoVenueViewPermission))
+		return
+	}
+
+	if format := ctx.DefaultQuery("format", "csv"); format != "csv" {
+		response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingInvalidExportFormat, format))
+		return
+	}
+
+	filters, ok := bookingFiltersFromQuery(ctx)
+	if !ok {
+		return
+	}
+
+	loc := venue.TimeLocation()
+	w := csv.NewWriter(ctx.Writer)
+	started := false
+	rows := 0
+	start := func() error {
+		if started {
+			return nil
+		}
+		started = true
+		ctx.Header("Content-Type", "text/csv; charset=utf-8")
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"venue-%d-bookings.csv\"", venue.ID))
+		ctx.Status(http.StatusOK)
+		return w.Write(bookingExportHeader)
+	}
+
+	err = c.repo.StreamVenueBookings(venue.ID, filters, func(b BookingExportRow) error {
+		if err := start(); err != nil {
+			return err
+		}
+		if err := w.Write([]string{
+			strconv.FormatUint(uint64(b.ID), 10),
+			strconv.FormatUint(uint64(b.CourtID), 10),
+			csvCell(b.CourtName),
+			strconv.FormatUint(uint64(b.UserID), 10),
+			csvCell(b.UserName),
+			b.StartTime.In(loc).Format(time.RFC3339),
+			b.EndTime.In(loc).Format(time.RFC3339),
+			csvCell(b.Status),
+			csvCell(b.Purpose),
+			strconv.FormatFloat(b.Price, 'f', 2, 64),
+		}); err != nil {
+			return err
+		}
+		if rows++; rows%bookingExportFlushRows == 0 {
+			w.Flush()
+			return w.Error()
+		}
+		return nil
+	})
+	if err != nil {
+		if !started {
+			response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingFetchFailed, err.Error()))
+			return
+		}
+		// Rows have already been sent, so the status can no longer change; record the error
+		// for the logger and stop writing
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	if err := start(); err != nil {
+		return
+	}
+	w.Flush()
+}
+
+// bookingFiltersFromQuery reads the status, date and court_id filters shared by the venue
+// booking list and export. It writes a 400 response and returns false for invalid values.
+func bookingFiltersFromQuery(ctx *gin.Context) (map[string]interface{}, bool) {
 	filters := map[string]interface{}{}
 
 	// Status filter
@@ -1230,8 +2028,8 @@ func (c *VenueController) GetVenueBookings(ctx *gin.Context) {
 		if _, valid := validStatuses[status]; valid {
 			filters["status"] = status
 		} else {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status filter"})
-			return
+			response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingInvalidStatus))
+			return nil, false
 		}
 	}
 
@@ -1239,8 +2037,8 @@ func (c *VenueController) GetVenueBookings(ctx *gin.Context) {
 	if dateStr := ctx.Query("date"); dateStr != "" {
 		date, err := time.Parse("2006-01-02", dateStr)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format. Use YYYY-MM-DD"})
-			return
+			response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingInvalidDate))
+			return nil, false
 		}
 		filters["date"] = date
 	}
@@ -1249,35 +2047,22 @@ func (c *VenueController) GetVenueBookings(ctx *gin.Context) {
 	if courtIDStr := ctx.Query("court_id"); courtIDStr != "" {
 		courtID, err := strconv.ParseUint(courtIDStr, 10, 32)
 		if err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid court ID format"})
-			return
+			response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingInvalidCourtID))
+			return nil, false
 		}
 		filters["court_id"] = uint(courtID)
 	}
 
-	// Get bookings from repository
-	bookings, totalCount, err := c.repo.GetBookingsByVenueID(uint(venueID), pagination.Page, pagination.Limit, filters)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch bookings: " + err.Error()})
-		return
+	// Search filter: purpose text, or a booking reference (ID)
+	search := strings.TrimSpace(ctx.Query("q"))
+	if search == "" {
+		search = strings.TrimSpace(ctx.Query("purpose"))
+	}
+	if search != "" {
+		filters["search"] = search
 	}
 
-	// Calculate pagination metadata
-	totalPages := (totalCount + int64(pagination.Limit) - 1) / int64(pagination.Limit)
-	hasNextPage := int64(pagination.Page) < totalPages
-	hasPrevPage := pagination.Page > 1
-
-	ctx.JSON(http.StatusOK, gin.H{
-		"bookings": bookings,
-		"pagination": gin.H{
-			"total":       totalCount,
-			"page":        pagination.Page,
-			"limit":       pagination.Limit,
-			"total_pages": totalPages,
-			"has_next":    hasNextPage,
-			"has_prev":    hasPrevPage,
-		},
-	})
+	return filters, true
 }
 
 // UpdateBookingStatus godoc
@@ -1288,88 +2073,108 @@ func (c *VenueController) GetVenueBookings(ctx *gin.Context) {
 // @Produce json
 // @Param booking_id path int true "Booking ID"
 // @Param status body UpdateBookingStatusRequest true "New status"
-// @Success 200 {object} map[string]interface{} "Status updated successfully"
-// @Failure 400 {object} map[string]interface{} "Bad request"
-// @Failure 403 {object} map[string]interface{} "Forbidden"
-// @Failure 404 {object} map[string]interface{} "Booking not found"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Success 200 {object} response.SuccessResponse "Status updated successfully"
+// @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Booking not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /api/venue-manager/bookings/{booking_id}/status [put]
 func (c *VenueController) UpdateBookingStatus(ctx *gin.Context) {
 	// Parse booking ID from URL
 	bookingIDStr := ctx.Param("booking_id")
 	bookingID, err := strconv.ParseUint(bookingIDStr, 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid booking ID format"})
+		response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingInvalidID))
 		return
 	}
 
 	// Parse request body
 	var req UpdateBookingStatusRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+		response.ValidationError(ctx, err)
 		return
 	}
 
 	// Get the booking to verify ownership
 	booking, err := c.repo.GetBookingByID(uint(bookingID))
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "Booking not found"})
+		response.Error(ctx, http.StatusNotFound, i18n.T(ctx, i18n.BookingNotFound))
 		return
 	}
 
 	// Get the court to get the venue
 	court, err := c.repo.GetCourtByID(booking.GroundID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify venue ownership"})
+		response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingOwnershipCheckFailed))
 		return
 	}
 
 	// Get the venue to check manager ID
 	venue, err := c.repo.GetVenueByID(court.VenueID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify venue ownership"})
+		response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingOwnershipCheckFailed))
 		return
 	}
 
 	// Get manager ID from context (assuming it was set during authentication)
-	managerID, exists := ctx.Get("userID")
+	managerID, exists := middleware.CurrentUserID(ctx)
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized access"})
+		response.Error(ctx, http.StatusUnauthorized, i18n.T(ctx, i18n.BookingUnauthorized))
 		return
 	}
 
 	// Ensure the requester is the manager of this venue
-	if venue.ManagerID != managerID.(uint) {
-		ctx.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to update this booking"})
+	manages, err := c.managesVenue(venue, managerID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingAccessCheckFailed))
+		return
+	}
+	if !manages {
+		response.Error(ctx, http.StatusForbidden, i18n.T(ctx, i18n.BookingNoUpdatePermission))
 		return
 	}
 
 	// Check if current status allows the requested change
 	if booking.Status == "cancelled" && req.Status != "cancelled" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Cannot change status of a cancelled booking"})
+		response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingStatusCancelledLocked))
 		return
 	}
 
 	if booking.Status == "completed" && req.Status != "completed" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Cannot change status of a completed booking"})
+		response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingStatusCompletedLocked))
 		return
 	}
 
 	// Special handling for cancellation - must use the cancel endpoint instead
 	if req.Status == "cancelled" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "To cancel a booking, use the cancel booking endpoint"})
+		response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingUseCancelEndpoint))
 		return
 	}
 
 	// Update the booking status
 	if err := c.repo.UpdateBookingStatus(uint(bookingID), req.Status); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update booking status: " + err.Error()})
+		response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingStatusUpdateFailed, err.Error()))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Booking status updated successfully",
-		"status":  req.Status,
+	if req.Status == "confirmed" && booking.Status != "confirmed" {
+		c.notifyBookingConfirmed(booking, venue.Name)
+	}
+
+	response.Success(ctx, http.StatusOK, i18n.T(ctx, i18n.BookingStatusUpdated), gin.H{
+		"status": req.Status,
+	})
+}
+
+// notifyBookingConfirmed tells the booker that their booking has been confirmed
+func (c *VenueController) notifyBookingConfirmed(booking *Booking, venueName string) {
+	c.notifier.Notify(notification.Message{
+		UserID:       booking.UserID,
+		Type:         notification.TypeBookingConfirmed,
+		Title:        "Booking confirmed",
+		Body:         fmt.Sprintf("Your booking at %s on %s has been confirmed.", venueName, booking.StartTime.UTC().Format(time.RFC3339)),
+		ResourceType: "booking",
+		ResourceID:   booking.ID,
 	})
 }
 
@@ -1382,55 +2187,68 @@ type CreateBookingRequest struct {
 
 // CreateBooking godoc
 // @Summary Create a new booking
-// @Description Creates a new booking for a specific ground/court
+// @Description Creates a new booking for a specific ground/court. Bookings on auto-confirm courts are confirmed immediately; others stay pending until the venue manager confirms them.
 // @Tags bookings
 // @Accept json
 // @Produce json
 // @Param booking body CreateBookingRequest true "Booking details"
-// @Success 201 {object} map[string]interface{} "Booking created successfully"
-// @Failure 400 {object} map[string]interface{} "Bad request"
-// @Failure 404 {object} map[string]interface{} "Ground not found"
-// @Failure 409 {object} map[string]interface{} "Time slot not available"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Param strict query bool false "Treat warnings as errors"
+// @Success 201 {object} response.SuccessResponse "Booking created successfully"
+// @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 404 {object} response.ErrorResponse "Ground not found"
+// @Failure 409 {object} response.ErrorResponse "Time slot not available"
+// @Failure 422 {object} response.ErrorResponse "Warnings present in strict mode"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /api/bookings [post]
 func (c *VenueController) CreateBooking(ctx *gin.Context) {
 	// Parse request body
 	var req CreateBookingRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format: " + err.Error()})
+		response.ValidationError(ctx, err)
 		return
 	}
 
 	// Validate time range
 	if req.EndTime.Before(req.StartTime) || req.EndTime.Equal(req.StartTime) {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "End time must be after start time"})
+		response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingEndBeforeStart))
 		return
 	}
 
 	// Ensure booking is not in the past
 	if req.StartTime.Before(time.Now()) {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Cannot create bookings in the past"})
+		response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingInPast))
 		return
 	}
 
 	// Check if the ground exists
 	ground, err := c.repo.GetCourtByID(req.GroundID)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "Ground not found"})
+		response.Error(ctx, http.StatusNotFound, i18n.T(ctx, i18n.BookingGroundNotFound))
 		return
 	}
 
 	// Get userID from the context (set during authentication)
-	userID, exists := ctx.Get("userID")
+	userID, exists := middleware.CurrentUserID(ctx)
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized access"})
+		response.Error(ctx, http.StatusUnauthorized, i18n.T(ctx, i18n.BookingUnauthorized))
+		return
+	}
+
+	// Reject bookings that fall inside a closure of the venue or the court
+	closures, err := c.repo.GetActiveClosures(ground.VenueID, ground.ID, req.StartTime, req.EndTime)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingAvailabilityFailed, err.Error()))
+		return
+	}
+	if len(closures) > 0 {
+		response.ErrorWithDetails(ctx, http.StatusConflict, i18n.T(ctx, i18n.BookingVenueClosed), gin.H{"closures": closures})
 		return
 	}
 
 	// Check if the time slot is available
-	timeSlots, err := c.repo.GetTimeSlotsByVenueID(ground.VenueID, req.StartTime, int(req.GroundID))
+	timeSlots, err := c.repo.GetTimeSlotsByVenueID(ground.VenueID, req.StartTime, ground.ID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check availability: " + err.Error()})
+		response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingAvailabilityFailed, err.Error()))
 		return
 	}
 
@@ -1444,34 +2262,70 @@ func (c *VenueController) CreateBooking(ctx *gin.Context) {
 	}
 
 	if matchingSlot == nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "No matching time slot found for the requested time range"})
+		response.Error(ctx, http.StatusNotFound, i18n.T(ctx, i18n.BookingNoMatchingSlot))
 		return
 	}
 
 	if matchingSlot.IsBooked {
-		ctx.JSON(http.StatusConflict, gin.H{"error": "Time slot is already booked"})
+		response.Error(ctx, http.StatusConflict, i18n.T(ctx, i18n.BookingSlotTaken))
+		return
+	}
+
+	// Soft checks: warn by default, reject when ?strict=true
+	var warnings utils.Warnings
+	if time.Until(req.StartTime) < bookingShortNotice {
+		warnings = append(warnings, i18n.T(ctx, i18n.BookingWarnShortNotice, int(bookingShortNotice.Minutes())))
+	}
+	overlapping, err := c.repo.CountUserBookingsOverlapping(userID, req.StartTime, req.EndTime)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingExistingCheckFailed, err.Error()))
+		return
+	}
+	if overlapping > 0 {
+		warnings = append(warnings, i18n.T(ctx, i18n.BookingWarnOverlap, overlapping))
+	}
+	if len(warnings) > 0 && utils.StrictMode(ctx) {
+		response.ErrorWithDetails(ctx, http.StatusUnprocessableEntity, i18n.T(ctx, i18n.BookingStrictRejected), gin.H{"warnings": warnings})
 		return
 	}
 
 	// Create the booking
 	booking := &Booking{
 		GroundID:  req.GroundID,
-		UserID:    userID.(uint),
+		UserID:    userID,
 		StartTime: req.StartTime,
 		EndTime:   req.EndTime,
 		Status:    "pending", // Default status
 		Purpose:   req.Purpose,
 	}
+	// Auto-confirm courts skip the manager's approval
+	if ground.AutoConfirm {
+		booking.Status = "confirmed"
+	}
+
+	venue, venueErr := c.repo.GetVenueByID(ground.VenueID)
+	if venueErr == nil {
+		price := bookingPrice(venue.HourlyRate, booking.StartTime, booking.EndTime)
+		booking.Price = &price
+	}
 
 	if err := c.repo.CreateBooking(booking); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create booking: " + err.Error()})
+		response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingCreateFailed, err.Error()))
 		return
 	}
 
-	ctx.JSON(http.StatusCreated, gin.H{
-		"message": "Booking created successfully",
+	if booking.Status == "confirmed" && venueErr == nil {
+		c.notifyBookingConfirmed(booking, venue.Name)
+	}
+
+	resp := gin.H{
+		"message": i18n.T(ctx, i18n.BookingCreated),
 		"booking": booking,
-	})
+	}
+	if len(warnings) > 0 {
+		resp["warnings"] = warnings
+	}
+	response.Success(ctx, http.StatusCreated, "", resp)
 }
 
 // GetUserBookings godoc
@@ -1482,48 +2336,170 @@ func (c *VenueController) CreateBooking(ctx *gin.Context) {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Number of items per page" default(10) maximum(100)
-// @Success 200 {object} map[string]interface{} "List of user's bookings"
-// @Failure 401 {object} map[string]interface{} "Unauthorized"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Success 200 {object} response.SuccessResponse "List of user's bookings"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /api/bookings [get]
 func (c *VenueController) GetUserBookings(ctx *gin.Context) {
 	// Get user ID from context (set during authentication)
-	userID, exists := ctx.Get("userID")
+	userID, exists := middleware.CurrentUserID(ctx)
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized access"})
+		response.Error(ctx, http.StatusUnauthorized, i18n.T(ctx, i18n.BookingUnauthorized))
 		return
 	}
 
 	// Parse pagination parameters
 	var pagination PaginationQuery
 	if err := ctx.ShouldBindQuery(&pagination); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pagination parameters"})
+		response.ValidationError(ctx, err)
 		return
 	}
 
 	// Get bookings from repository
-	bookings, totalCount, err := c.repo.GetBookingsByUserID(userID.(uint), pagination.Page, pagination.Limit)
+	bookings, totalCount, err := c.repo.GetBookingsByUserID(userID, pagination.Page, pagination.Limit)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingFetchFailed, err.Error()))
+		return
+	}
+
+	response.Paginated(ctx, http.StatusOK, "", bookings, totalCount, pagination.Page, pagination.Limit)
+}
+
+// bookingHistoryHeader is the column header of booking history exports
+var bookingHistoryHeader = []string{"booking_id", "venue_id", "venue", "court_id", "court", "start_time", "end_time", "status", "price"}
+
+// GetBookingHistory godoc
+// @Summary Get user's booking history
+// @Description Lists the current user's completed and cancelled bookings with their prices, newest first. With format=csv the whole history in the range is downloaded instead of a page; times are in each venue's time zone.
+// @Tags bookings
+// @Produce json
+// @Produce text/csv
+// @Param from query string false "Only bookings starting on or after this date (YYYY-MM-DD)"
+// @Param to query string false "Only bookings starting on or before this date (YYYY-MM-DD)"
+// @Param format query string false "Set to csv to download the history"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10) maximum(100)
+// @Success 200 {object} response.PaginatedResponse{data=response.Page{items=[]BookingHistoryEntry}} "Booking history"
+// @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/users/me/bookings/history [get]
+func (c *VenueController) GetBookingHistory(ctx *gin.Context) {
+	userID, exists := middleware.CurrentUserID(ctx)
+	if !exists {
+		response.Error(ctx, http.StatusUnauthorized, i18n.T(ctx, i18n.BookingUnauthorized))
+		return
+	}
+
+	// Dates are whole days, so "to" includes the bookings on that day
+	var from, to time.Time
+	if fromStr := ctx.Query("from"); fromStr != "" {
+		date, err := time.Parse("2006-01-02", fromStr)
+		if err != nil {
+			response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingInvalidDate))
+			return
+		}
+		from = date
+	}
+	if toStr := ctx.Query("to"); toStr != "" {
+		date, err := time.Parse("2006-01-02", toStr)
+		if err != nil {
+			response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingInvalidDate))
+			return
+		}
+		to = date.AddDate(0, 0, 1)
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingInvalidDateRange))
+		return
+	}
+
+	switch format := ctx.Query("format"); format {
+	case "":
+	case "csv":
+		c.exportBookingHistory(ctx, userID, from, to)
+		return
+	default:
+		response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingInvalidExportFormat, format))
+		return
+	}
+
+	var pagination PaginationQuery
+	if err := ctx.ShouldBindQuery(&pagination); err != nil {
+		response.ValidationError(ctx, err)
+		return
+	}
+
+	entries, totalCount, err := c.repo.GetBookingHistory(userID, from, to, pagination.Page, pagination.Limit)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch bookings: " + err.Error()})
+		response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingFetchFailed, err.Error()))
 		return
 	}
 
-	// Calculate pagination metadata
-	totalPages := (totalCount + int64(pagination.Limit) - 1) / int64(pagination.Limit)
-	hasNextPage := int64(pagination.Page) < totalPages
-	hasPrevPage := pagination.Page > 1
+	response.Paginated(ctx, http.StatusOK, "", entries, totalCount, pagination.Page, pagination.Limit)
+}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"bookings": bookings,
-		"pagination": gin.H{
-			"total":       totalCount,
-			"page":        pagination.Page,
-			"limit":       pagination.Limit,
-			"total_pages": totalPages,
-			"has_next":    hasNextPage,
-			"has_prev":    hasPrevPage,
-		},
+// exportBookingHistory streams a user's booking history as CSV
+func (c *VenueController) exportBookingHistory(ctx *gin.Context, userID uint, from, to time.Time) {
+	w := csv.NewWriter(ctx.Writer)
+	started := false
+	rows := 0
+	start := func() error {
+		if started {
+			return nil
+		}
+		started = true
+		ctx.Header("Content-Type", "text/csv; charset=utf-8")
+		ctx.Header("Content-Disposition", `attachment; filename="booking-history.csv"`)
+		ctx.Status(http.StatusOK)
+		return w.Write(bookingHistoryHeader)
+	}
+
+	locations := map[string]*time.Location{}
+	err := c.repo.StreamBookingHistory(userID, from, to, func(b BookingHistoryEntry) error {
+		if err := start(); err != nil {
+			return err
+		}
+		loc, ok := locations[b.VenueTimezone]
+		if !ok {
+			loc = (&Venue{Timezone: b.VenueTimezone}).TimeLocation()
+			locations[b.VenueTimezone] = loc
+		}
+		if err := w.Write([]string{
+			strconv.FormatUint(uint64(b.ID), 10),
+			strconv.FormatUint(uint64(b.VenueID), 10),
+			csvCell(b.VenueName),
+			strconv.FormatUint(uint64(b.CourtID), 10),
+			csvCell(b.CourtName),
+			b.StartTime.In(loc).Format(time.RFC3339),
+			b.EndTime.In(loc).Format(time.RFC3339),
+			csvCell(b.Status),
+			strconv.FormatFloat(b.Price, 'f', 2, 64),
+		}); err != nil {
+			return err
+		}
+		if rows++; rows%bookingExportFlushRows == 0 {
+			w.Flush()
+			return w.Error()
+		}
+		return nil
 	})
+	if err != nil {
+		if !started {
+			response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingFetchFailed, err.Error()))
+			return
+		}
+		// Rows have already been sent, so the status can no longer change; record the error
+		// for the logger and stop writing
+		_ = ctx.Error(err)
+		ctx.Abort()
+		return
+	}
+
+	if err := start(); err != nil {
+		return
+	}
+	w.Flush()
 }
 
 // GetBookingByID godoc
@@ -1533,60 +2509,92 @@ func (c *VenueController) GetUserBookings(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param booking_id path int true "Booking ID"
-// @Success 200 {object} Booking "Booking details"
-// @Failure 400 {object} map[string]interface{} "Bad request"
-// @Failure 403 {object} map[string]interface{} "Forbidden"
-// @Failure 404 {object} map[string]interface{} "Booking not found"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Param expand query string false "Comma-separated related resources to include: court, venue"
+// @Success 200 {object} response.SuccessResponse{data=BookingDetails} "Booking details"
+// @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Booking not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /api/bookings/{booking_id} [get]
 func (c *VenueController) GetBookingByID(ctx *gin.Context) {
 	// Parse booking ID from URL
 	bookingIDStr := ctx.Param("booking_id")
 	bookingID, err := strconv.ParseUint(bookingIDStr, 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid booking ID format"})
-		return
+		response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingInvalidID))
+		return
+	}
+
+	// Parse the optional expansions; nothing extra is loaded unless asked for
+	var expandCourt, expandVenue bool
+	if expand := ctx.Query("expand"); expand != "" {
+		for _, field := range strings.Split(expand, ",") {
+			switch strings.TrimSpace(strings.ToLower(field)) {
+			case "court":
+				expandCourt = true
+			case "venue":
+				expandVenue = true
+			case "":
+			default:
+				response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingInvalidExpand, strings.TrimSpace(field)))
+				return
+			}
+		}
 	}
 
 	// Get the booking
 	booking, err := c.repo.GetBookingByID(uint(bookingID))
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "Booking not found"})
+		response.Error(ctx, http.StatusNotFound, i18n.T(ctx, i18n.BookingNotFound))
 		return
 	}
 
 	// Get user ID from context (set during authentication)
-	userID, exists := ctx.Get("userID")
+	userID, exists := middleware.CurrentUserID(ctx)
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized access"})
+		response.Error(ctx, http.StatusUnauthorized, i18n.T(ctx, i18n.BookingUnauthorized))
 		return
 	}
 
-	// Check if the requester is the owner of the booking
-	if booking.UserID != userID.(uint) {
-		// Get the court to get the venue
-		court, err := c.repo.GetCourtByID(booking.GroundID)
+	// The court is preloaded with the booking; the venue is only needed for non-owners and
+	// for the venue expansion
+	var venue *Venue
+	if booking.UserID != userID || expandVenue {
+		venue, err = c.repo.GetVenueByID(booking.Ground.VenueID)
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify access permission"})
+			response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingAccessCheckFailed))
 			return
 		}
+	}
 
-		// Get venue to check if requester is the venue manager
-		venue, err := c.repo.GetVenueByID(court.VenueID)
+	// Check if the requester is the owner of the booking or, failing that, a venue manager
+	if booking.UserID != userID {
+		manages, err := c.managesVenue(venue, userID)
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify access permission"})
+			response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingAccessCheckFailed))
 			return
 		}
-
-		// If not the venue manager either, deny access
-		if venue.ManagerID != userID.(uint) {
-			ctx.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to view this booking"})
+		if !manages {
+			response.Error(ctx, http.StatusForbidden, i18n.T(ctx, i18n.BookingNoViewPermission))
 			return
 		}
 	}
 
-	// Return the booking details
-	ctx.JSON(http.StatusOK, booking)
+	if !expandCourt && !expandVenue {
+		// Return the booking details
+		response.Success(ctx, http.StatusOK, "", booking)
+		return
+	}
+
+	details := BookingDetails{Booking: *booking}
+	if expandCourt {
+		court := booking.Ground
+		details.Court = &court
+	}
+	if expandVenue {
+		details.Venue = venue.Summary()
+	}
+	response.Success(ctx, http.StatusOK, "", details)
 }
 
 // CancelBooking godoc
@@ -1596,54 +2604,59 @@ func (c *VenueController) GetBookingByID(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param booking_id path int true "Booking ID"
-// @Success 200 {object} map[string]interface{} "Booking cancelled successfully"
-// @Failure 400 {object} map[string]interface{} "Bad request"
-// @Failure 403 {object} map[string]interface{} "Forbidden"
-// @Failure 404 {object} map[string]interface{} "Booking not found"
-// @Failure 409 {object} map[string]interface{} "Cannot cancel booking"
-// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Success 200 {object} response.SuccessResponse "Booking cancelled successfully"
+// @Failure 400 {object} response.ErrorResponse "Bad request"
+// @Failure 403 {object} response.ErrorResponse "Forbidden"
+// @Failure 404 {object} response.ErrorResponse "Booking not found"
+// @Failure 409 {object} response.ErrorResponse "Cannot cancel booking"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
 // @Router /api/bookings/{booking_id} [delete]
 func (c *VenueController) CancelBooking(ctx *gin.Context) {
 	// Parse booking ID from URL
 	bookingIDStr := ctx.Param("booking_id")
 	bookingID, err := strconv.ParseUint(bookingIDStr, 10, 32)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid booking ID format"})
+		response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingInvalidID))
 		return
 	}
 
 	// Get the booking
 	booking, err := c.repo.GetBookingByID(uint(bookingID))
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "Booking not found"})
+		response.Error(ctx, http.StatusNotFound, i18n.T(ctx, i18n.BookingNotFound))
 		return
 	}
 
 	// Get user ID from context (set during authentication)
-	userID, exists := ctx.Get("userID")
+	userID, exists := middleware.CurrentUserID(ctx)
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized access"})
+		response.Error(ctx, http.StatusUnauthorized, i18n.T(ctx, i18n.BookingUnauthorized))
 		return
 	}
 
 	// Check cancellation permissions
 	isVenueManager := false
-	if booking.UserID != userID.(uint) {
+	if booking.UserID != userID {
 		// Check if the requester is the venue manager
 		court, err := c.repo.GetCourtByID(booking.GroundID)
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify access permission"})
+			response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingAccessCheckFailed))
 			return
 		}
 
 		venue, err := c.repo.GetVenueByID(court.VenueID)
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify access permission"})
+			response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingAccessCheckFailed))
 			return
 		}
 
-		if venue.ManagerID != userID.(uint) {
-			ctx.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to cancel this booking"})
+		manages, err := c.managesVenue(venue, userID)
+		if err != nil {
+			response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingAccessCheckFailed))
+			return
+		}
+		if !manages {
+			response.Error(ctx, http.StatusForbidden, i18n.T(ctx, i18n.BookingNoCancelPermission))
 			return
 		}
 		isVenueManager = true
@@ -1651,32 +2664,27 @@ func (c *VenueController) CancelBooking(ctx *gin.Context) {
 
 	// Check if booking can be cancelled
 	if booking.Status == "cancelled" {
-		ctx.JSON(http.StatusConflict, gin.H{"error": "Booking is already cancelled"})
+		response.Error(ctx, http.StatusConflict, i18n.T(ctx, i18n.BookingAlreadyCancelled))
 		return
 	}
 
 	if booking.Status == "completed" {
-		ctx.JSON(http.StatusConflict, gin.H{"error": "Cannot cancel a completed booking"})
+		response.Error(ctx, http.StatusConflict, i18n.T(ctx, i18n.BookingCannotCancelCompleted))
 		return
 	}
 
 	// Check cancellation time policy (e.g., must cancel at least 24 hours before)
 	// Only apply to user cancellations, not manager cancellations
 	if !isVenueManager && time.Until(booking.StartTime) < 24*time.Hour {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"error": fmt.Sprintf("Bookings must be cancelled at least 24 hours in advance. Current time until booking: %.1f hours",
-				time.Until(booking.StartTime).Hours()),
-		})
+		response.Error(ctx, http.StatusBadRequest, i18n.T(ctx, i18n.BookingCancelTooLate, time.Until(booking.StartTime).Hours()))
 		return
 	}
 
 	// Cancel the booking
 	if err := c.repo.CancelBooking(uint(bookingID)); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to cancel booking: " + err.Error()})
+		response.Error(ctx, http.StatusInternalServerError, i18n.T(ctx, i18n.BookingCancelFailed, err.Error()))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Booking cancelled successfully",
-	})
+	response.Success(ctx, http.StatusOK, i18n.T(ctx, i18n.BookingCancelled), nil)
 }
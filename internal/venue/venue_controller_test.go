@@ -0,0 +1,80 @@
+package venue
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/DhavalSuthar-24/miow/pkg/validator"
+	"github.com/gin-gonic/gin"
+)
+
+func TestCreateVenueReportsFieldErrors(t *testing.T) {
+	validator.RegisterJSONFieldNames()
+	r := gin.New()
+	r.POST("/venues", asUser(1), newTestController(t, nil).CreateVenue)
+
+	w := testutil.Request(t, r, http.MethodPost, "/venues", map[string]interface{}{
+		"location":    "Test Street",
+		"hourly_rate": -5,
+		"court_count": 0,
+	})
+
+	env := testutil.DecodeEnvelope(t, w)
+	if w.Code != http.StatusBadRequest || env.Success || env.Error.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, body = %s; want a 400 error envelope", w.Code, w.Body.String())
+	}
+	var got []response.FieldError
+	if err := json.Unmarshal(env.Error.Details, &got); err != nil {
+		t.Fatalf("details %s are not field errors: %v", env.Error.Details, err)
+	}
+	want := []response.FieldError{
+		{Field: "name", Tag: "required"},
+		{Field: "hourly_rate", Tag: "min", Param: "0"},
+		{Field: "court_count", Tag: "required"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("details = %+v, want %+v", got, want)
+	}
+	for i, fe := range got {
+		if fe.Field != want[i].Field || fe.Tag != want[i].Tag || fe.Param != want[i].Param || fe.Message == "" {
+			t.Errorf("details[%d] = %+v, want %+v with a message", i, fe, want[i])
+		}
+	}
+}
+
+func TestUpdateVenueKeepsTimezoneWhenOmitted(t *testing.T) {
+	db := newTestDB(t)
+	manager := testutil.CreateUser(t, db, "Manager")
+	venue := createVenue(t, db, manager.ID, "Asia/Kolkata")
+
+	r := gin.New()
+	r.PUT("/venues/:venue_id", asUser(manager.ID), newTestController(t, db).UpdateVenue)
+	update := func(timezone string) *Venue {
+		t.Helper()
+		w := testutil.Request(t, r, http.MethodPut, "/venues/"+itoa(venue.ID), VenueInput{
+			Name:       venue.Name,
+			Location:   "New Street",
+			HourlyRate: 25,
+			CourtCount: 1,
+			Timezone:   timezone,
+		})
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+		}
+		var got Venue
+		if err := db.First(&got, venue.ID).Error; err != nil {
+			t.Fatalf("failed to reload venue: %v", err)
+		}
+		return &got
+	}
+
+	if got := update(""); got.Timezone != "Asia/Kolkata" || got.Location != "New Street" {
+		t.Errorf("after update without timezone: timezone = %q, location = %q; want Asia/Kolkata, New Street", got.Timezone, got.Location)
+	}
+	if got := update("Europe/London"); got.Timezone != "Europe/London" {
+		t.Errorf("after update with timezone: timezone = %q, want Europe/London", got.Timezone)
+	}
+}
@@ -0,0 +1,144 @@
+package venue
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// asAdmin authenticates every request as the user holding the admin role
+func asAdmin(userID uint) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(middleware.AuthUserIDKey, userID)
+		c.Set(middleware.UserRolesKey, []string{"admin"})
+		c.Next()
+	}
+}
+
+// deleteRouter serves the public venue routes, and venue deletion and restore as auth
+func deleteRouter(t *testing.T, db *gorm.DB, auth gin.HandlerFunc) *gin.Engine {
+	t.Helper()
+	vc := newTestController(t, db)
+	r := gin.New()
+	r.GET("/venues", vc.GetAllVenues)
+	r.GET("/venues/:venue_id", vc.GetVenueByID)
+	r.DELETE("/manager/venues/:venue_id", auth, vc.DeleteVenue)
+	r.POST("/admin/venues/:venue_id/restore", auth, vc.RestoreVenue)
+	return r
+}
+
+// listedVenueIDs returns the IDs and total GetAllVenues reports
+func listedVenueIDs(t *testing.T, r *gin.Engine) (map[uint]bool, int64) {
+	t.Helper()
+	var page struct {
+		Items      []Venue `json:"items"`
+		Pagination struct {
+			TotalItems int64 `json:"total_items"`
+		} `json:"pagination"`
+	}
+	testutil.DecodeData(t, testutil.Request(t, r, http.MethodGet, "/venues", nil), &page)
+	ids := make(map[uint]bool, len(page.Items))
+	for _, v := range page.Items {
+		ids[v.ID] = true
+	}
+	return ids, page.Pagination.TotalItems
+}
+
+func TestGetAllVenuesExcludesSoftDeletedVenues(t *testing.T) {
+	db := newTestDB(t)
+	owner := testutil.CreateUser(t, db, "Owner")
+	admin := testutil.CreateUser(t, db, "Admin")
+	kept := createVenue(t, db, owner.ID, "UTC")
+	deleted := createVenue(t, db, owner.ID, "UTC")
+
+	r := deleteRouter(t, db, asUser(owner.ID))
+	if w := testutil.Request(t, r, http.MethodDelete, "/manager/venues/"+itoa(deleted.ID), nil); w.Code != http.StatusOK {
+		t.Fatalf("delete status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	ids, total := listedVenueIDs(t, r)
+	if !ids[kept.ID] || ids[deleted.ID] || total != 1 {
+		t.Errorf("listed venues = %v with total %d, want only venue %d", ids, total, kept.ID)
+	}
+	if w := testutil.Request(t, r, http.MethodGet, "/venues/"+itoa(deleted.ID), nil); w.Code != http.StatusNotFound {
+		t.Errorf("deleted venue status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+
+	// The row is kept, so an admin can restore it
+	var stored Venue
+	if err := db.First(&stored, deleted.ID).Error; err != nil || !stored.IsDeleted {
+		t.Fatalf("soft deleted venue = %+v, %v; want the row marked deleted", stored, err)
+	}
+	admins := deleteRouter(t, db, asAdmin(admin.ID))
+	path := "/admin/venues/" + itoa(deleted.ID) + "/restore"
+	if w := testutil.Request(t, admins, http.MethodPost, path, nil); w.Code != http.StatusOK {
+		t.Fatalf("restore status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	if ids, total := listedVenueIDs(t, r); !ids[deleted.ID] || total != 2 {
+		t.Errorf("listed venues after restore = %v with total %d, want both", ids, total)
+	}
+	if w := testutil.Request(t, admins, http.MethodPost, path, nil); w.Code != http.StatusConflict {
+		t.Errorf("restoring a venue that is not deleted: status = %d, want %d", w.Code, http.StatusConflict)
+	}
+}
+
+func TestDeleteVenueWithUpcomingConfirmedBooking(t *testing.T) {
+	db := newTestDB(t)
+	owner := testutil.CreateUser(t, db, "Owner")
+	player := testutil.CreateUser(t, db, "Player")
+	venue := createVenue(t, db, owner.ID, "UTC")
+	court := createGround(t, db, venue.ID, "Court A")
+	booking := &Booking{GroundID: court.ID, UserID: player.ID, StartTime: time.Now().Add(24 * time.Hour),
+		EndTime: time.Now().Add(25 * time.Hour), Status: "confirmed"}
+	if err := db.Omit("Ground").Create(booking).Error; err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+
+	r := deleteRouter(t, db, asUser(owner.ID))
+	del := func() *httptest.ResponseRecorder {
+		return testutil.Request(t, r, http.MethodDelete, "/manager/venues/"+itoa(venue.ID), nil)
+	}
+	if w := del(); w.Code != http.StatusConflict {
+		t.Errorf("status = %d, want %d: %s", w.Code, http.StatusConflict, w.Body)
+	}
+	if ids, _ := listedVenueIDs(t, r); !ids[venue.ID] {
+		t.Error("refused deletion hid the venue")
+	}
+
+	// Once the booking is cancelled the venue can be deleted
+	if err := db.Model(booking).Update("status", "cancelled").Error; err != nil {
+		t.Fatalf("failed to cancel booking: %v", err)
+	}
+	if w := del(); w.Code != http.StatusOK {
+		t.Errorf("status after cancelling = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+}
+
+func TestHardDeleteVenueIsAdminOnly(t *testing.T) {
+	db := newTestDB(t)
+	owner := testutil.CreateUser(t, db, "Owner")
+	admin := testutil.CreateUser(t, db, "Admin")
+	venue := createVenue(t, db, owner.ID, "UTC")
+	createGround(t, db, venue.ID, "Court A")
+	path := "/manager/venues/" + itoa(venue.ID) + "?hard_delete=true"
+
+	if w := testutil.Request(t, deleteRouter(t, db, asUser(owner.ID)), http.MethodDelete, path, nil); w.Code != http.StatusForbidden {
+		t.Errorf("owner hard delete status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if w := testutil.Request(t, deleteRouter(t, db, asAdmin(admin.ID)), http.MethodDelete, path, nil); w.Code != http.StatusOK {
+		t.Fatalf("admin hard delete status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	var venues, courts int64
+	db.Model(&Venue{}).Where("id = ?", venue.ID).Count(&venues)
+	db.Model(&Ground{}).Where("venue_id = ?", venue.ID).Count(&courts)
+	if venues != 0 || courts != 0 {
+		t.Errorf("after a hard delete %d venues and %d courts remain, want none", venues, courts)
+	}
+}
@@ -0,0 +1,162 @@
+package venue
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// createBooking inserts a booking of the ground by the user
+func createBooking(t *testing.T, db *gorm.DB, groundID, userID uint, start time.Time, status, purpose string, price *float64) *Booking {
+	t.Helper()
+	booking := &Booking{GroundID: groundID, UserID: userID, StartTime: start, EndTime: start.Add(90 * time.Minute),
+		Status: status, Purpose: purpose, Price: price}
+	if err := db.Omit("Ground").Create(booking).Error; err != nil {
+		t.Fatalf("failed to create booking: %v", err)
+	}
+	return booking
+}
+
+// exportBookings requests the venue's booking export as the user and parses the CSV body
+func exportBookings(t *testing.T, db *gorm.DB, userID, venueID uint, query string) (*httptest.ResponseRecorder, [][]string) {
+	t.Helper()
+	r := gin.New()
+	r.GET("/manager/venues/:venue_id/bookings/export", asUser(userID), newTestController(t, db).ExportVenueBookings)
+	w := testutil.Request(t, r, http.MethodGet, "/manager/venues/"+itoa(venueID)+"/bookings/export"+query, nil)
+	if w.Code != http.StatusOK {
+		return w, nil
+	}
+	records, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("export is not valid CSV: %v\n%s", err, w.Body)
+	}
+	return w, records
+}
+
+func TestExportVenueBookings(t *testing.T) {
+	db := newTestDB(t)
+	manager := testutil.CreateUser(t, db, "Manager")
+	player := testutil.CreateUser(t, db, "Player")
+	venue := createVenue(t, db, manager.ID, "Asia/Kolkata")
+	court := createGround(t, db, venue.ID, "Court A")
+
+	day := time.Date(2026, 5, 10, 12, 30, 0, 0, time.UTC)
+	early := 25.0
+	late := 30.5
+	createBooking(t, db, court.ID, player.ID, day, "confirmed", "Practice, then a match", &early)
+	latest := createBooking(t, db, court.ID, player.ID, day.Add(3*time.Hour), "cancelled", "Friendly", &late)
+
+	w, records := exportBookings(t, db, manager.ID, venue.ID, "?format=csv")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/csv") {
+		t.Errorf("Content-Type = %q, want text/csv", ct)
+	}
+	if cd := w.Header().Get("Content-Disposition"); !strings.Contains(cd, "attachment") {
+		t.Errorf("Content-Disposition = %q, want an attachment", cd)
+	}
+	if len(records) != 3 {
+		t.Fatalf("export has %d records, want a header and 2 rows:\n%s", len(records), w.Body)
+	}
+	if got := strings.Join(records[0], ","); got != strings.Join(bookingExportHeader, ",") {
+		t.Errorf("header = %q, want %q", got, strings.Join(bookingExportHeader, ","))
+	}
+
+	// Newest first, with times in the venue's time zone
+	wantLatest := []string{itoa(latest.ID), itoa(court.ID), "Court A", itoa(player.ID), "Player",
+		"2026-05-10T21:00:00+05:30", "2026-05-10T22:30:00+05:30", "cancelled", "Friendly", "30.50"}
+	if got := strings.Join(records[1], "|"); got != strings.Join(wantLatest, "|") {
+		t.Errorf("first row = %q, want %q", got, strings.Join(wantLatest, "|"))
+	}
+	if records[2][8] != "Practice, then a match" || records[2][9] != "25.00" {
+		t.Errorf("second row = %q, want the earlier booking with its quoted purpose", records[2])
+	}
+
+	// The list filters apply to the export
+	_, records = exportBookings(t, db, manager.ID, venue.ID, "?status=confirmed")
+	if len(records) != 2 || records[1][7] != "confirmed" {
+		t.Errorf("export filtered by status = %q, want only the confirmed booking", records)
+	}
+}
+
+func TestExportVenueBookingsWithoutRows(t *testing.T) {
+	db := newTestDB(t)
+	manager := testutil.CreateUser(t, db, "Manager")
+	venue := createVenue(t, db, manager.ID, "UTC")
+
+	w, records := exportBookings(t, db, manager.ID, venue.ID, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	if len(records) != 1 {
+		t.Errorf("export = %q, want only the header", records)
+	}
+}
+
+func TestExportVenueBookingsPriceFallback(t *testing.T) {
+	db := newTestDB(t)
+	manager := testutil.CreateUser(t, db, "Manager")
+	player := testutil.CreateUser(t, db, "Player")
+	venue := createVenue(t, db, manager.ID, "UTC")
+	court := createGround(t, db, venue.ID, "Court A")
+	createBooking(t, db, court.ID, player.ID, time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC), "completed", "", nil)
+
+	_, records := exportBookings(t, db, manager.ID, venue.ID, "")
+	if len(records) != 2 || records[1][9] != "30.00" {
+		t.Errorf("export = %q, want the hourly rate for 90 minutes as the price", records)
+	}
+}
+
+func TestExportVenueBookingsRejected(t *testing.T) {
+	db := newTestDB(t)
+	manager := testutil.CreateUser(t, db, "Manager")
+	stranger := testutil.CreateUser(t, db, "Stranger")
+	venue := createVenue(t, db, manager.ID, "UTC")
+
+	tests := []struct {
+		name    string
+		userID  uint
+		venueID uint
+		query   string
+		status  int
+	}{
+		{"not a manager", stranger.ID, venue.ID, "", http.StatusForbidden},
+		{"unknown venue", manager.ID, venue.ID + 1000, "", http.StatusNotFound},
+		{"unsupported format", manager.ID, venue.ID, "?format=pdf", http.StatusBadRequest},
+		{"invalid filter", manager.ID, venue.ID, "?status=lost", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w, _ := exportBookings(t, db, tt.userID, tt.venueID, tt.query); w.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+		})
+	}
+}
+
+func TestCSVCellNeutralisesFormulas(t *testing.T) {
+	tests := map[string]string{
+		"":                    "",
+		"Court 1":             "Court 1",
+		"=HYPERLINK(\"x\")":   "'=HYPERLINK(\"x\")",
+		"+1+2":                "'+1+2",
+		"-2+3":                "'-2+3",
+		"@SUM(A1)":            "'@SUM(A1)",
+		"\tindented":          "'\tindented",
+		"\rreturn":            "'\rreturn",
+		"Birthday = surprise": "Birthday = surprise",
+	}
+	for in, want := range tests {
+		if got := csvCell(in); got != want {
+			t.Errorf("csvCell(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
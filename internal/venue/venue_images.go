@@ -0,0 +1,115 @@
+package venue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/DhavalSuthar-24/miow/pkg/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// maxVenueImages caps how many images a venue can collect through uploads
+const maxVenueImages = 20
+
+// decodeVenueImages parses a venue's images column; empty and null mean no images
+func decodeVenueImages(raw string) ([]string, error) {
+	var images []string
+	if raw == "" || raw == "null" {
+		return images, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &images); err != nil {
+		return nil, ErrVenueImagesInvalid
+	}
+	return images, nil
+}
+
+// uploadImageError writes the response for a rejected image upload
+func uploadImageError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, storage.ErrFileTooLarge):
+		response.Error(ctx, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, storage.ErrUnsupportedImageType):
+		response.Error(ctx, http.StatusUnsupportedMediaType, err.Error())
+	default:
+		response.Error(ctx, http.StatusBadRequest, "failed to read image: "+err.Error())
+	}
+}
+
+// UploadVenueImage godoc
+// @Summary Upload a venue image
+// @Description Stores a JPEG, PNG, GIF or WebP image and appends its URL to the venue's images. The file's content decides its type, and its size is limited by MAX_IMAGE_SIZE_MB. Available to the venue's owner and staff.
+// @Tags venues
+// @Accept multipart/form-data
+// @Produce json
+// @Param venue_id path int true "Venue ID"
+// @Param image formData file true "Image file"
+// @Success 201 {object} response.SuccessResponse{data=Venue} "Image uploaded"
+// @Failure 400 {object} response.ErrorResponse "Invalid venue ID or missing image"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - not a venue manager"
+// @Failure 404 {object} response.ErrorResponse "Venue not found"
+// @Failure 409 {object} response.ErrorResponse "Venue has too many images, or its images are not a list of URLs"
+// @Failure 413 {object} response.ErrorResponse "Image too large"
+// @Failure 415 {object} response.ErrorResponse "Unsupported image type"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /manager/venues/{venue_id}/images [post]
+// @Security Bearer
+func (c *VenueController) UploadVenueImage(ctx *gin.Context) {
+	venue, ok := c.loadManagedVenue(ctx, false)
+	if !ok {
+		return
+	}
+
+	// Checked again under the row lock when the URL is appended; this avoids storing a file
+	// that could never be added
+	images, err := decodeVenueImages(venue.Images)
+	if err != nil {
+		response.Error(ctx, http.StatusConflict, err.Error()+"; replace them with a venue update first")
+		return
+	}
+	if len(images) >= maxVenueImages {
+		response.Error(ctx, http.StatusConflict, ErrVenueImagesFull.Error())
+		return
+	}
+
+	file, err := ctx.FormFile("image")
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, "image file is required: "+err.Error())
+		return
+	}
+	img, err := storage.ReadImage(file, storage.MaxImageBytes(c.appConfig))
+	if err != nil {
+		uploadImageError(ctx, err)
+		return
+	}
+
+	key := storage.ImageKey(fmt.Sprintf("venues/%d", venue.ID), img)
+	url, err := c.storage.Put(ctx.Request.Context(), key, img.Data, img.ContentType)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to store image: "+err.Error())
+		return
+	}
+
+	updated, err := c.repo.AppendVenueImage(venue.ID, url)
+	if err != nil {
+		if delErr := c.storage.Delete(context.Background(), key); delErr != nil {
+			middleware.Logger(ctx).Error("failed to remove orphaned image", "key", key, "error", delErr)
+		}
+		switch {
+		case errors.Is(err, ErrVenueImagesInvalid):
+			response.Error(ctx, http.StatusConflict, err.Error()+"; replace them with a venue update first")
+		case errors.Is(err, ErrVenueImagesFull):
+			response.Error(ctx, http.StatusConflict, err.Error())
+		default:
+			response.Error(ctx, http.StatusInternalServerError, "failed to update venue: "+err.Error())
+		}
+		return
+	}
+
+	response.Success(ctx, http.StatusCreated, "venue image uploaded successfully", updated)
+}
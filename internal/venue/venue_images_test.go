@@ -0,0 +1,143 @@
+package venue
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/DhavalSuthar-24/miow/config"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/pkg/storage"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// storedImages returns the venue's image URLs as stored
+func storedImages(t *testing.T, db *gorm.DB, venueID uint) []string {
+	t.Helper()
+	var venue Venue
+	if err := db.First(&venue, venueID).Error; err != nil {
+		t.Fatalf("failed to reload venue: %v", err)
+	}
+	images, err := decodeVenueImages(venue.Images)
+	if err != nil {
+		t.Fatalf("venue images %q: %v", venue.Images, err)
+	}
+	return images
+}
+
+func TestUploadVenueImage(t *testing.T) {
+	db := newTestDB(t)
+	owner := testutil.CreateUser(t, db, "Owner")
+	stranger := testutil.CreateUser(t, db, "Stranger")
+	venue := createVenue(t, db, owner.ID, "UTC")
+
+	dir := t.TempDir()
+	cfg := &config.Config{}
+	cfg.Storage.MaxImageSizeMB = 1
+	vc := NewVenueController(NewVenueRepository(db), cfg, nil, storage.NewLocalStorage(dir, "/uploads"))
+	upload := func(userID uint, data []byte) *httptest.ResponseRecorder {
+		t.Helper()
+		r := gin.New()
+		r.POST("/manager/venues/:venue_id/images", asUser(userID), vc.UploadVenueImage)
+		return testutil.Upload(t, r, "/manager/venues/"+itoa(venue.ID)+"/images", "image", "court.png", data)
+	}
+
+	if w := upload(owner.ID, testutil.PNG); w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+	}
+	images := storedImages(t, db, venue.ID)
+	if len(images) != 1 || !strings.HasPrefix(images[0], fmt.Sprintf("/uploads/venues/%d/", venue.ID)) || !strings.HasSuffix(images[0], ".png") {
+		t.Fatalf("venue images = %v, want the uploaded PNG's URL", images)
+	}
+	stored, err := os.ReadFile(filepath.Join(dir, strings.TrimPrefix(images[0], "/uploads/")))
+	if err != nil || !bytes.Equal(stored, testutil.PNG) {
+		t.Errorf("stored file = %q, %v; want the uploaded data", stored, err)
+	}
+
+	tests := []struct {
+		name   string
+		userID uint
+		data   []byte
+		status int
+	}{
+		{"not a manager", stranger.ID, testutil.PNG, http.StatusForbidden},
+		{"text disguised as an image", owner.ID, []byte("just some text"), http.StatusUnsupportedMediaType},
+		{"over the size limit", owner.ID, append(append([]byte{}, testutil.PNG...), make([]byte, 1<<20)...), http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := upload(tt.userID, tt.data); w.Code != tt.status {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+		})
+	}
+	if images := storedImages(t, db, venue.ID); len(images) != 1 {
+		t.Errorf("venue images = %v after rejected uploads, want only the first", images)
+	}
+}
+
+func TestUploadVenueImageWhenFull(t *testing.T) {
+	db := newTestDB(t)
+	owner := testutil.CreateUser(t, db, "Owner")
+	venue := createVenue(t, db, owner.ID, "UTC")
+	full := make([]string, maxVenueImages)
+	for i := range full {
+		full[i] = fmt.Sprintf("/uploads/venues/%d/%d.png", venue.ID, i)
+	}
+	encoded, _ := json.Marshal(full)
+	if err := db.Model(venue).Update("images", string(encoded)).Error; err != nil {
+		t.Fatalf("failed to fill venue images: %v", err)
+	}
+
+	r := gin.New()
+	r.POST("/manager/venues/:venue_id/images", asUser(owner.ID), newTestController(t, db).UploadVenueImage)
+	w := testutil.Upload(t, r, "/manager/venues/"+itoa(venue.ID)+"/images", "image", "court.png", testutil.PNG)
+	if w.Code != http.StatusConflict {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusConflict)
+	}
+}
+
+func TestAppendVenueImageConcurrently(t *testing.T) {
+	db := newTestDB(t)
+	owner := testutil.CreateUser(t, db, "Owner")
+	venue := createVenue(t, db, owner.ID, "UTC")
+	repo := NewVenueRepository(db)
+
+	const uploads = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, uploads)
+	for i := 0; i < uploads; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := repo.AppendVenueImage(venue.ID, fmt.Sprintf("/uploads/%d.png", i)); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("AppendVenueImage() error = %v", err)
+	}
+
+	// Every concurrent upload keeps its URL
+	images := storedImages(t, db, venue.ID)
+	seen := make(map[string]bool, len(images))
+	for _, url := range images {
+		seen[url] = true
+	}
+	for i := 0; i < uploads; i++ {
+		if url := fmt.Sprintf("/uploads/%d.png", i); !seen[url] {
+			t.Errorf("venue images %v lost %s", images, url)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package venue
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// venueRole returns the user's role at the venue, or "" if they do not manage it. The owner is
+// also recognised by Venue.ManagerID, which stays authoritative for ownership.
+func (c *VenueController) venueRole(venue *Venue, userID uint) (string, error) {
+	if venue.ManagerID == userID {
+		return VenueManagerOwner, nil
+	}
+	role, err := c.repo.GetVenueManagerRole(venue.ID, userID)
+	if err != nil {
+		return "", err
+	}
+	// A stale owner row left by an interrupted transfer does not grant ownership
+	if role == VenueManagerOwner {
+		role = VenueManagerStaff
+	}
+	return role, nil
+}
+
+// managesVenue reports whether the user is the venue's owner or one of its staff
+func (c *VenueController) managesVenue(venue *Venue, userID uint) (bool, error) {
+	role, err := c.venueRole(venue, userID)
+	return role != "", err
+}
+
+// loadManagedVenue loads the venue in the URL for the current user and checks their role. With
+// ownerOnly set, staff are refused. It writes the error response and returns false on failure.
+func (c *VenueController) loadManagedVenue(ctx *gin.Context, ownerOnly bool) (*Venue, bool) {
+	venueID, err := strconv.ParseUint(ctx.Param("venue_id"), 10, 32)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, "invalid venue ID")
+		return nil, false
+	}
+
+	userID, exists := middleware.CurrentUserID(ctx)
+	if !exists {
+		response.Error(ctx, http.StatusUnauthorized, "unauthorized")
+		return nil, false
+	}
+
+	venue, err := c.repo.GetVenueByID(uint(venueID))
+	if err != nil {
+		if err.Error() == "venue not found" {
+			response.Error(ctx, http.StatusNotFound, "venue not found")
+		} else {
+			response.Error(ctx, http.StatusInternalServerError, "failed to get venue: "+err.Error())
+		}
+		return nil, false
+	}
+
+	role, err := c.venueRole(venue, userID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to check venue access: "+err.Error())
+		return nil, false
+	}
+	if role == "" || (ownerOnly && role != VenueManagerOwner) {
+		response.Error(ctx, http.StatusForbidden, "you are not authorized to manage this venue")
+		return nil, false
+	}
+	return venue, true
+}
+
+// GetVenueManagers godoc
+// @Summary List venue managers
+// @Description Lists the owner and staff of a venue. Available to any of its managers.
+// @Tags venues
+// @Produce json
+// @Param venue_id path int true "Venue ID"
+// @Success 200 {object} response.SuccessResponse{data=[]VenueManager} "Venue managers"
+// @Failure 400 {object} response.ErrorResponse "Invalid venue ID"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - not a venue manager"
+// @Failure 404 {object} response.ErrorResponse "Venue not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /manager/venues/{venue_id}/managers [get]
+// @Security Bearer
+func (c *VenueController) GetVenueManagers(ctx *gin.Context) {
+	venue, ok := c.loadManagedVenue(ctx, false)
+	if !ok {
+		return
+	}
+
+	managers, err := c.repo.GetVenueManagers(venue.ID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to get venue managers: "+err.Error())
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "", managers)
+}
+
+// AddVenueManager godoc
+// @Summary Add a venue manager
+// @Description Adds a user as staff of the venue. Staff manage courts, time slots, closures and bookings but cannot delete the venue or change its managers. Owner only.
+// @Tags venues
+// @Accept json
+// @Produce json
+// @Param venue_id path int true "Venue ID"
+// @Param manager body VenueManagerInput true "User to add"
+// @Success 201 {object} response.SuccessResponse{data=VenueManager} "Manager added"
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - not the venue owner"
+// @Failure 404 {object} response.ErrorResponse "Venue or user not found"
+// @Failure 409 {object} response.ErrorResponse "User already manages the venue"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /manager/venues/{venue_id}/managers [post]
+// @Security Bearer
+func (c *VenueController) AddVenueManager(ctx *gin.Context) {
+	var input VenueManagerInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		response.ValidationError(ctx, err)
+		return
+	}
+
+	venue, ok := c.loadManagedVenue(ctx, true)
+	if !ok {
+		return
+	}
+	if input.UserID == venue.ManagerID {
+		response.Error(ctx, http.StatusConflict, ErrVenueManagerExists.Error())
+		return
+	}
+
+	manager := &VenueManager{VenueID: venue.ID, UserID: input.UserID, Role: VenueManagerStaff}
+	if err := c.repo.AddVenueManager(manager); err != nil {
+		switch {
+		case errors.Is(err, ErrVenueManagerUserNotFound):
+			response.Error(ctx, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrVenueManagerExists):
+			response.Error(ctx, http.StatusConflict, err.Error())
+		default:
+			response.Error(ctx, http.StatusInternalServerError, "failed to add venue manager: "+err.Error())
+		}
+		return
+	}
+
+	response.Success(ctx, http.StatusCreated, "", manager)
+}
+
+// RemoveVenueManager godoc
+// @Summary Remove a venue manager
+// @Description Removes a staff member from the venue. The owner cannot be removed; transfer ownership instead. Owner only.
+// @Tags venues
+// @Produce json
+// @Param venue_id path int true "Venue ID"
+// @Param user_id path int true "User ID of the staff member"
+// @Success 200 {object} response.SuccessResponse "Manager removed"
+// @Failure 400 {object} response.ErrorResponse "Invalid ID or the user is the owner"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - not the venue owner"
+// @Failure 404 {object} response.ErrorResponse "Venue not found or the user is not staff"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /manager/venues/{venue_id}/managers/{user_id} [delete]
+// @Security Bearer
+func (c *VenueController) RemoveVenueManager(ctx *gin.Context) {
+	userID, err := strconv.ParseUint(ctx.Param("user_id"), 10, 32)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	venue, ok := c.loadManagedVenue(ctx, true)
+	if !ok {
+		return
+	}
+	if uint(userID) == venue.ManagerID {
+		response.Error(ctx, http.StatusBadRequest, "the owner cannot be removed; transfer ownership first")
+		return
+	}
+
+	if err := c.repo.RemoveVenueManager(venue.ID, uint(userID)); err != nil {
+		if errors.Is(err, ErrVenueManagerNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+		} else {
+			response.Error(ctx, http.StatusInternalServerError, "failed to remove venue manager: "+err.Error())
+		}
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "venue manager removed successfully", nil)
+}
+
+// TransferVenueOwnership godoc
+// @Summary Transfer venue ownership
+// @Description Makes another user the owner of the venue. The previous owner stays on as staff. Owner only.
+// @Tags venues
+// @Accept json
+// @Produce json
+// @Param venue_id path int true "Venue ID"
+// @Param owner body VenueManagerInput true "New owner"
+// @Success 200 {object} response.SuccessResponse "Ownership transferred"
+// @Failure 400 {object} response.ErrorResponse "Invalid input"
+// @Failure 401 {object} response.ErrorResponse "Unauthorized"
+// @Failure 403 {object} response.ErrorResponse "Forbidden - not the venue owner"
+// @Failure 404 {object} response.ErrorResponse "Venue or user not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /manager/venues/{venue_id}/transfer [post]
+// @Security Bearer
+func (c *VenueController) TransferVenueOwnership(ctx *gin.Context) {
+	var input VenueManagerInput
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		response.ValidationError(ctx, err)
+		return
+	}
+
+	venue, ok := c.loadManagedVenue(ctx, true)
+	if !ok {
+		return
+	}
+	if input.UserID == venue.ManagerID {
+		response.Error(ctx, http.StatusBadRequest, "user already owns this venue")
+		return
+	}
+
+	if err := c.repo.TransferVenueOwnership(venue.ID, input.UserID); err != nil {
+		if errors.Is(err, ErrVenueManagerUserNotFound) {
+			response.Error(ctx, http.StatusNotFound, err.Error())
+		} else {
+			response.Error(ctx, http.StatusInternalServerError, "failed to transfer venue ownership: "+err.Error())
+		}
+		return
+	}
+
+	response.Success(ctx, http.StatusOK, "venue ownership transferred successfully", nil)
+}
@@ -0,0 +1,135 @@
+package venue
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// managerRouter serves the venue management routes as the user
+func managerRouter(t *testing.T, db *gorm.DB, userID uint) *gin.Engine {
+	t.Helper()
+	vc := newTestController(t, db)
+	r := gin.New()
+	r.Use(asUser(userID))
+	r.DELETE("/manager/venues/:venue_id", vc.DeleteVenue)
+	r.GET("/manager/venues/:venue_id/managers", vc.GetVenueManagers)
+	r.POST("/manager/venues/:venue_id/managers", vc.AddVenueManager)
+	r.DELETE("/manager/venues/:venue_id/managers/:user_id", vc.RemoveVenueManager)
+	r.POST("/manager/venues/:venue_id/transfer", vc.TransferVenueOwnership)
+	r.POST("/manager/venues/:venue_id/courts", vc.AddCourt)
+	return r
+}
+
+// addStaff makes the user staff of the venue through the owner's endpoint
+func addStaff(t *testing.T, db *gorm.DB, ownerID, venueID, userID uint) {
+	t.Helper()
+	w := testutil.Request(t, managerRouter(t, db, ownerID), http.MethodPost, "/manager/venues/"+itoa(venueID)+"/managers",
+		VenueManagerInput{UserID: userID})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("add staff status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+	}
+}
+
+func TestVenueStaffPermissions(t *testing.T) {
+	db := newTestDB(t)
+	owner := testutil.CreateUser(t, db, "Owner")
+	staff := testutil.CreateUser(t, db, "Staff")
+	other := testutil.CreateUser(t, db, "Other")
+	venue := createVenue(t, db, owner.ID, "UTC")
+	addStaff(t, db, owner.ID, venue.ID, staff.ID)
+
+	base := "/manager/venues/" + itoa(venue.ID)
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   interface{}
+		staff  int
+		owner  int
+	}{
+		{"list managers", http.MethodGet, base + "/managers", nil, http.StatusOK, http.StatusOK},
+		{"add a court", http.MethodPost, base + "/courts", CourtInput{Name: "Court", Type: "court"}, http.StatusCreated, http.StatusCreated},
+		{"add a manager", http.MethodPost, base + "/managers", VenueManagerInput{UserID: other.ID}, http.StatusForbidden, http.StatusCreated},
+		{"remove a manager", http.MethodDelete, base + "/managers/" + itoa(other.ID), nil, http.StatusForbidden, http.StatusOK},
+		{"transfer ownership", http.MethodPost, base + "/transfer", VenueManagerInput{UserID: staff.ID}, http.StatusForbidden, http.StatusOK},
+	}
+	// Staff are tried first; the owner's call then shows the action was possible
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := testutil.Request(t, managerRouter(t, db, staff.ID), tt.method, tt.path, tt.body); w.Code != tt.staff {
+				t.Errorf("staff status = %d, want %d: %s", w.Code, tt.staff, w.Body)
+			}
+			if w := testutil.Request(t, managerRouter(t, db, owner.ID), tt.method, tt.path, tt.body); w.Code != tt.owner {
+				t.Errorf("owner status = %d, want %d: %s", w.Code, tt.owner, w.Body)
+			}
+		})
+	}
+
+	// After the transfer the former staff member owns the venue and the old owner is staff
+	var reloaded Venue
+	if err := db.First(&reloaded, venue.ID).Error; err != nil {
+		t.Fatalf("failed to reload venue: %v", err)
+	}
+	if reloaded.ManagerID != staff.ID {
+		t.Errorf("venue owner = %d, want %d", reloaded.ManagerID, staff.ID)
+	}
+	w := testutil.Request(t, managerRouter(t, db, owner.ID), http.MethodPost, base+"/managers", VenueManagerInput{UserID: other.ID})
+	if w.Code != http.StatusForbidden {
+		t.Errorf("former owner adding a manager: status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	w = testutil.Request(t, managerRouter(t, db, owner.ID), http.MethodPost, base+"/courts", CourtInput{Name: "Court 2", Type: "court"})
+	if w.Code != http.StatusCreated {
+		t.Errorf("former owner adding a court: status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+	}
+}
+
+func TestVenueStaffCannotDeleteVenue(t *testing.T) {
+	db := newTestDB(t)
+	owner := testutil.CreateUser(t, db, "Owner")
+	staff := testutil.CreateUser(t, db, "Staff")
+	venue := createVenue(t, db, owner.ID, "UTC")
+	addStaff(t, db, owner.ID, venue.ID, staff.ID)
+
+	path := "/manager/venues/" + itoa(venue.ID)
+	if w := testutil.Request(t, managerRouter(t, db, staff.ID), http.MethodDelete, path, nil); w.Code != http.StatusForbidden {
+		t.Errorf("staff delete status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if w := testutil.Request(t, managerRouter(t, db, owner.ID), http.MethodDelete, path, nil); w.Code != http.StatusOK {
+		t.Errorf("owner delete status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+}
+
+func TestRemovedStaffLosesAccess(t *testing.T) {
+	db := newTestDB(t)
+	owner := testutil.CreateUser(t, db, "Owner")
+	staff := testutil.CreateUser(t, db, "Staff")
+	stranger := testutil.CreateUser(t, db, "Stranger")
+	venue := createVenue(t, db, owner.ID, "UTC")
+	addStaff(t, db, owner.ID, venue.ID, staff.ID)
+
+	managers := "/manager/venues/" + itoa(venue.ID) + "/managers"
+	if w := testutil.Request(t, managerRouter(t, db, stranger.ID), http.MethodGet, managers, nil); w.Code != http.StatusForbidden {
+		t.Errorf("stranger status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+
+	// The owner cannot be removed, and a user can only be added once
+	ownerPath := managers + "/" + itoa(owner.ID)
+	if w := testutil.Request(t, managerRouter(t, db, owner.ID), http.MethodDelete, ownerPath, nil); w.Code != http.StatusBadRequest {
+		t.Errorf("removing the owner: status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	w := testutil.Request(t, managerRouter(t, db, owner.ID), http.MethodPost, managers, VenueManagerInput{UserID: staff.ID})
+	if w.Code != http.StatusConflict {
+		t.Errorf("adding staff twice: status = %d, want %d", w.Code, http.StatusConflict)
+	}
+
+	if w := testutil.Request(t, managerRouter(t, db, owner.ID), http.MethodDelete, managers+"/"+itoa(staff.ID), nil); w.Code != http.StatusOK {
+		t.Fatalf("remove staff status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	if w := testutil.Request(t, managerRouter(t, db, staff.ID), http.MethodGet, managers, nil); w.Code != http.StatusForbidden {
+		t.Errorf("removed staff status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+}
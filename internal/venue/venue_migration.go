@@ -0,0 +1,102 @@
+package venue
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MigrateTimeSlotGrounds moves legacy time slots from the old `court_number`
+// column onto real Ground rows. For every venue, court number N is mapped to the
+// venue's N-th ground (ordered by ID); missing grounds are created as "Court N".
+// Once every slot has a ground, the legacy column is dropped. It is safe to run
+// on every startup: it is a no-op once the column is gone.
+func MigrateTimeSlotGrounds(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&TimeSlot{}) || !migrator.HasColumn(&TimeSlot{}, "court_number") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		type legacyCourt struct {
+			VenueID     uint
+			CourtNumber int
+		}
+		var legacy []legacyCourt
+		if err := tx.Model(&TimeSlot{}).
+			Select("DISTINCT venue_id, court_number").
+			Where("ground_id IS NULL OR ground_id = 0").
+			Scan(&legacy).Error; err != nil {
+			return fmt.Errorf("failed to load legacy court numbers: %w", err)
+		}
+
+		grounds := make(map[uint][]Ground)
+		for _, lc := range legacy {
+			index := lc.CourtNumber
+			if index < 1 {
+				index = 1
+			}
+
+			venueGrounds, ok := grounds[lc.VenueID]
+			if !ok {
+				if err := tx.Where("venue_id = ?", lc.VenueID).Order("id asc").Find(&venueGrounds).Error; err != nil {
+					return fmt.Errorf("failed to load grounds for venue %d: %w", lc.VenueID, err)
+				}
+			}
+
+			// Create placeholder grounds until the court number can be mapped
+			for len(venueGrounds) < index {
+				ground := Ground{
+					VenueID: lc.VenueID,
+					Name:    fmt.Sprintf("Court %d", len(venueGrounds)+1),
+					Type:    "court",
+				}
+				if err := tx.Create(&ground).Error; err != nil {
+					return fmt.Errorf("failed to create ground for venue %d: %w", lc.VenueID, err)
+				}
+				venueGrounds = append(venueGrounds, ground)
+			}
+			grounds[lc.VenueID] = venueGrounds
+
+			if err := tx.Model(&TimeSlot{}).
+				Where("venue_id = ? AND court_number = ? AND (ground_id IS NULL OR ground_id = 0)", lc.VenueID, lc.CourtNumber).
+				Update("ground_id", venueGrounds[index-1].ID).Error; err != nil {
+				return fmt.Errorf("failed to map court %d of venue %d: %w", lc.CourtNumber, lc.VenueID, err)
+			}
+		}
+
+		if err := tx.Migrator().DropColumn(&TimeSlot{}, "court_number"); err != nil {
+			return fmt.Errorf("failed to drop court_number column: %w", err)
+		}
+		return nil
+	})
+}
+
+// EnsureVenueOwners records the manager of every venue created before venue managers were
+// stored as that venue's owner. It is a no-op once every venue has an owner row.
+func EnsureVenueOwners(db *gorm.DB) error {
+	var missing []VenueManager
+	if err := db.Model(&Venue{}).
+		Select("id AS venue_id, manager_id AS user_id").
+		Where("manager_id <> 0").
+		Where("NOT EXISTS (SELECT 1 FROM venue_managers vm WHERE vm.venue_id = venues.id AND vm.role = ?)", VenueManagerOwner).
+		Scan(&missing).Error; err != nil {
+		return fmt.Errorf("failed to find venues without an owner: %w", err)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	for i := range missing {
+		missing[i].Role = VenueManagerOwner
+	}
+	// A legacy owner may already be listed as staff; promote them instead of inserting
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "venue_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role"}),
+	}).CreateInBatches(missing, 500).Error; err != nil {
+		return fmt.Errorf("failed to record venue owners: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package venue
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/gin-gonic/gin"
+)
+
+func TestMigrateTimeSlotGroundsMapsCourtNumbersToGrounds(t *testing.T) {
+	db := newTestDB(t)
+	if err := db.Exec("ALTER TABLE time_slots ADD COLUMN court_number integer").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	manager := testutil.CreateUser(t, db, "Manager")
+	venue := createVenue(t, db, manager.ID, "UTC")
+	court1 := createGround(t, db, venue.ID, "Centre Court")
+
+	start := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	legacy := map[int]*TimeSlot{}
+	for _, courtNumber := range []int{1, 3} {
+		slot := createTimeSlot(t, db, venue.ID, 0, start, start.Add(time.Hour))
+		if err := db.Exec("UPDATE time_slots SET court_number = ? WHERE id = ?", courtNumber, slot.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+		legacy[courtNumber] = slot
+	}
+
+	if err := MigrateTimeSlotGrounds(db); err != nil {
+		t.Fatalf("MigrateTimeSlotGrounds() error = %v", err)
+	}
+
+	var grounds []Ground
+	if err := db.Where("venue_id = ?", venue.ID).Order("id").Find(&grounds).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(grounds) != 3 {
+		t.Fatalf("venue has %d grounds, want 3 (the existing one and placeholders for courts 2 and 3)", len(grounds))
+	}
+	if grounds[0].ID != court1.ID || grounds[1].Name != "Court 2" || grounds[2].Name != "Court 3" {
+		t.Errorf("grounds = %q, %q, %q; want Centre Court, Court 2, Court 3", grounds[0].Name, grounds[1].Name, grounds[2].Name)
+	}
+
+	wantGround := map[int]uint{1: grounds[0].ID, 3: grounds[2].ID}
+	for courtNumber, slot := range legacy {
+		var migrated TimeSlot
+		if err := db.First(&migrated, slot.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+		if migrated.GroundID != wantGround[courtNumber] {
+			t.Errorf("slot on court %d has ground %d, want %d", courtNumber, migrated.GroundID, wantGround[courtNumber])
+		}
+	}
+
+	if db.Migrator().HasColumn(&TimeSlot{}, "court_number") {
+		t.Error("court_number column was not dropped")
+	}
+	// Running again is a no-op
+	if err := MigrateTimeSlotGrounds(db); err != nil {
+		t.Fatalf("second MigrateTimeSlotGrounds() error = %v", err)
+	}
+}
+
+func TestGenerateAutoTimeSlotsUsesVenueGrounds(t *testing.T) {
+	db := newTestDB(t)
+	manager := testutil.CreateUser(t, db, "Manager")
+	venue := createVenue(t, db, manager.ID, "UTC")
+	ground := createGround(t, db, venue.ID, "Court 1")
+	otherVenue := createVenue(t, db, manager.ID, "UTC")
+	foreignGround := createGround(t, db, otherVenue.ID, "Court 1")
+
+	r := gin.New()
+	r.POST("/venues/:venue_id/timeslots/auto", asUser(manager.ID), newTestController(t, db).GenerateAutoTimeSlots)
+	path := "/venues/" + itoa(venue.ID) + "/timeslots/auto"
+	input := func(groundIDs ...uint) AutoTimeSlotInput {
+		return AutoTimeSlotInput{
+			GroundIDs:  groundIDs,
+			StartDate:  "2026-05-04",
+			EndDate:    "2026-05-04",
+			StartTime:  "09:00",
+			EndTime:    "11:00",
+			Duration:   60,
+			Price:      10,
+			DaysOfWeek: []string{"monday"},
+			Equipment:  "[]",
+		}
+	}
+
+	w := testutil.Request(t, r, http.MethodPost, path, input(ground.ID, foreignGround.ID))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("ground of another venue: status %d, want %d: %s", w.Code, http.StatusBadRequest, w.Body)
+	}
+
+	w = testutil.Request(t, r, http.MethodPost, path, input(ground.ID))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+	}
+	var slots []TimeSlot
+	if err := db.Where("venue_id = ?", venue.ID).Find(&slots).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(slots) != 2 {
+		t.Fatalf("created %d slots, want 2", len(slots))
+	}
+	for _, slot := range slots {
+		if slot.GroundID != ground.ID {
+			t.Errorf("slot %d is on ground %d, want %d", slot.ID, slot.GroundID, ground.ID)
+		}
+	}
+}
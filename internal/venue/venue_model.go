@@ -1,8 +1,14 @@
 package venue
 
 import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/DhavalSuthar-24/miow/internal/models"
 	"github.com/DhavalSuthar-24/miow/internal/user"
 )
 
@@ -27,8 +33,10 @@ type Venue struct {
 	HourlyRate  float64   `json:"hourly_rate"`
 	CourtCount  int       `json:"court_count" gorm:"default:1"`
 	SocialHours string    `json:"social_hours" gorm:"type:json"`
+	Timezone    string    `json:"timezone" gorm:"not null;default:'UTC'"` // IANA name, e.g. "Europe/London"
 	ManagerID   uint      `json:"manager_id"`
 	Manager     user.User `json:"-" gorm:"foreignKey:ManagerID"`
+	IsDeleted   bool      `json:"is_deleted" gorm:"default:false;index"` // Soft deleted; hidden from listings until restored
 }
 
 type Ground struct {
@@ -38,6 +46,8 @@ type Ground struct {
 	Name        string `json:"name" gorm:"not null"`
 	Type        string `json:"type" gorm:"not null"`
 	Description string `json:"description"`
+	AutoConfirm bool   `json:"auto_confirm" gorm:"default:false"` // Bookings are confirmed without manager approval
+	SportID     *uint  `json:"sport_id,omitempty" gorm:"index"`   // Sport the court is set up for, if any
 }
 
 type VenueSchedule struct {
@@ -63,13 +73,83 @@ type Booking struct {
 	EndTime   time.Time `json:"end_time"`
 	Status    string    `json:"status" gorm:"type:varchar(20);default:'pending'"`
 	Purpose   string    `json:"purpose"`
+
+	// Price snapshot taken when the booking is made, so later rate changes do not alter it.
+	// Nil for bookings made before prices were recorded.
+	Price *float64 `json:"price,omitempty"`
+}
+
+// bookingPrice is the cost of booking a venue at its hourly rate for the given period
+func bookingPrice(hourlyRate float64, start, end time.Time) float64 {
+	return math.Round(hourlyRate*end.Sub(start).Hours()*100) / 100
+}
+
+// BookingExportRow is one booking in a venue's booking export
+type BookingExportRow struct {
+	ID        uint
+	CourtID   uint
+	CourtName string
+	UserID    uint
+	UserName  string
+	StartTime time.Time
+	EndTime   time.Time
+	Status    string
+	Purpose   string
+	Price     float64
+}
+
+// VenueManagerInput is the request body for adding a venue manager or transferring ownership
+type VenueManagerInput struct {
+	UserID uint `json:"user_id" binding:"required"`
+}
+
+// BookingHistoryEntry is one past booking in a user's booking history
+type BookingHistoryEntry struct {
+	ID            uint      `json:"id"`
+	VenueID       uint      `json:"venue_id"`
+	VenueName     string    `json:"venue_name"`
+	VenueTimezone string    `json:"venue_timezone"`
+	CourtID       uint      `json:"court_id"`
+	CourtName     string    `json:"court_name"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+	Status        string    `json:"status"`
+	Price         float64   `json:"price"`
 }
 
-// TimeSlot represents available booking slots for venues
+// VenueSummary is the subset of venue details embedded in other resources
+type VenueSummary struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Location    string `json:"location"`
+	ContactInfo string `json:"contact_info"`
+	Timezone    string `json:"timezone"`
+}
+
+// Summary returns the venue's summary details
+func (v *Venue) Summary() *VenueSummary {
+	return &VenueSummary{
+		ID:          v.ID,
+		Name:        v.Name,
+		Location:    v.Location,
+		ContactInfo: v.ContactInfo,
+		Timezone:    v.Timezone,
+	}
+}
+
+// BookingDetails is a booking with the related resources requested through ?expand=
+type BookingDetails struct {
+	Booking
+	Court *Ground       `json:"court,omitempty"`
+	Venue *VenueSummary `json:"venue,omitempty"`
+}
+
+// TimeSlot represents available booking slots for a specific ground (court) of a venue
 type TimeSlot struct {
 	BaseModel
 	VenueID     uint      `json:"venue_id" gorm:"index"`
-	CourtNumber int       `json:"court_number" gorm:"default:1"`
+	GroundID    uint      `json:"ground_id" gorm:"index"`
+	Ground      *Ground   `json:"ground,omitempty" gorm:"foreignKey:GroundID"`
 	StartTime   time.Time `json:"start_time"`
 	EndTime     time.Time `json:"end_time"`
 	IsBooked    bool      `json:"is_booked" gorm:"default:false"`
@@ -79,20 +159,151 @@ type TimeSlot struct {
 	Equipment   string    `json:"equipment" gorm:"type:json"`
 }
 
+// Venue manager roles
+const (
+	VenueManagerOwner = "owner" // Manages the venue and its managers; also Venue.ManagerID
+	VenueManagerStaff = "staff" // Manages courts, time slots, closures and bookings
+)
+
+// VenueManager grants a user management of a venue. Each venue has one owner, who is also
+// recorded in Venue.ManagerID, and any number of staff.
+type VenueManager struct {
+	BaseModel
+	VenueID uint   `json:"venue_id" gorm:"not null;uniqueIndex:idx_venue_manager"`
+	UserID  uint   `json:"user_id" gorm:"not null;uniqueIndex:idx_venue_manager;index"`
+	Role    string `json:"role" gorm:"type:varchar(20);not null;default:'staff'"`
+}
+
+// VenueClosure blocks bookings at a venue for a period of time. A closure with no GroundID
+// applies to every court of the venue; otherwise it only applies to that court
+type VenueClosure struct {
+	BaseModel
+	VenueID     uint      `json:"venue_id" gorm:"index;not null"`
+	GroundID    *uint     `json:"ground_id,omitempty" gorm:"index"`
+	StartsAt    time.Time `json:"starts_at" gorm:"not null;index"`
+	EndsAt      time.Time `json:"ends_at" gorm:"not null;index"`
+	Reason      string    `json:"reason"`
+	CreatedByID uint      `json:"created_by_id"`
+}
+
+// Overlaps reports whether the closure covers any part of [start, end) on the given court.
+// A groundID of 0 matches closures on any court
+func (vc *VenueClosure) Overlaps(groundID uint, start, end time.Time) bool {
+	if vc.GroundID != nil && groundID != 0 && *vc.GroundID != groundID {
+		return false
+	}
+	return vc.StartsAt.Before(end) && vc.EndsAt.After(start)
+}
+
+// TimeSlotResponse is a TimeSlot with its times rendered both in UTC and in the venue's local timezone
+type TimeSlotResponse struct {
+	TimeSlot
+	Timezone       string    `json:"timezone"`
+	LocalStartTime time.Time `json:"local_start_time"`
+	LocalEndTime   time.Time `json:"local_end_time"`
+}
+
+// AutoTimeSlotResult reports the outcome of automatic time slot generation
+type AutoTimeSlotResult struct {
+	Created int                `json:"created"`
+	Skipped int                `json:"skipped"` // Generated slots dropped because they overlap existing ones
+	Slots   []TimeSlotResponse `json:"slots"`
+}
+
+// TimeLocation returns the venue's time zone, falling back to UTC if it is unset or invalid
+func (v *Venue) TimeLocation() *time.Location {
+	if v.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(v.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// normalizeTimezone validates an IANA time zone name, defaulting to UTC when empty
+func normalizeTimezone(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "UTC", nil
+	}
+	if strings.EqualFold(name, "local") {
+		return "", fmt.Errorf("invalid timezone %q: use an IANA name such as \"Europe/London\"", name)
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return "", fmt.Errorf("invalid timezone %q: use an IANA name such as \"Europe/London\"", name)
+	}
+	return name, nil
+}
+
+// normalizeCoordinates checks venue coordinates, given as {"latitude":..,"longitude":..} or as
+// "lat,lng", and returns them in the JSON form the column stores. Empty means unknown.
+func normalizeCoordinates(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+
+	var coords models.Coordinates
+	if strings.HasPrefix(raw, "{") {
+		if err := json.Unmarshal([]byte(raw), &coords); err != nil {
+			return "", fmt.Errorf("invalid coordinates: %v", err)
+		}
+	} else {
+		parts := strings.Split(raw, ",")
+		if len(parts) != 2 {
+			return "", fmt.Errorf("invalid coordinates %q: use \"lat,lng\" or {\"latitude\":..,\"longitude\":..}", raw)
+		}
+		var errLat, errLng error
+		coords.Latitude, errLat = strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		coords.Longitude, errLng = strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if errLat != nil || errLng != nil {
+			return "", fmt.Errorf("invalid coordinates %q: use \"lat,lng\" or {\"latitude\":..,\"longitude\":..}", raw)
+		}
+	}
+
+	if math.IsNaN(coords.Latitude) || coords.Latitude < -90 || coords.Latitude > 90 {
+		return "", fmt.Errorf("invalid coordinates: latitude must be between -90 and 90")
+	}
+	if math.IsNaN(coords.Longitude) || coords.Longitude < -180 || coords.Longitude > 180 {
+		return "", fmt.Errorf("invalid coordinates: longitude must be between -180 and 180")
+	}
+	encoded, _ := json.Marshal(coords)
+	return string(encoded), nil
+}
+
+// newTimeSlotResponses wraps time slots with their UTC and venue-local representations
+func newTimeSlotResponses(slots []TimeSlot, loc *time.Location) []TimeSlotResponse {
+	responses := make([]TimeSlotResponse, 0, len(slots))
+	for _, slot := range slots {
+		slot.StartTime = slot.StartTime.UTC()
+		slot.EndTime = slot.EndTime.UTC()
+		responses = append(responses, TimeSlotResponse{
+			TimeSlot:       slot,
+			Timezone:       loc.String(),
+			LocalStartTime: slot.StartTime.In(loc),
+			LocalEndTime:   slot.EndTime.In(loc),
+		})
+	}
+	return responses
+}
+
 // VenueInput represents the input for venue creation and update
 type VenueInput struct {
 	Name        string  `json:"name" binding:"required"`
 	Location    string  `json:"location" binding:"required"`
-	Coordinates string  `json:"coordinates"`
+	Coordinates string  `json:"coordinates" example:"51.5072,-0.1276"` // "lat,lng" or {"latitude":..,"longitude":..}
 	Facilities  string  `json:"facilities"`
 	Available   bool    `json:"available"`
 	ContactInfo string  `json:"contact_info"`
 	Description string  `json:"description"`
 	Images      string  `json:"images"`
-	Capacity    int     `json:"capacity"`
+	Capacity    int     `json:"capacity" binding:"min=0"`
 	HourlyRate  float64 `json:"hourly_rate" binding:"required,min=0"`
 	CourtCount  int     `json:"court_count" binding:"required,min=1"`
 	SocialHours string  `json:"social_hours"`
+	Timezone    string  `json:"timezone" example:"Asia/Kolkata"` // IANA name, defaults to UTC; kept on update when empty
 }
 
 // CourtInput represents the input for court creation and update
@@ -100,11 +311,33 @@ type CourtInput struct {
 	Name        string `json:"name" binding:"required"`
 	Type        string `json:"type" binding:"required"`
 	Description string `json:"description"`
+	AutoConfirm bool   `json:"auto_confirm"` // Confirm bookings immediately instead of waiting for the manager
+	SportID     *uint  `json:"sport_id"`     // Sport the court is set up for; omit for a general purpose court
+}
+
+// BulkCourtInput represents the input for creating several courts in one request
+type BulkCourtInput struct {
+	Courts []CourtInput `json:"courts" binding:"required,min=1,max=50,dive"`
+}
+
+// CourtInputError describes why one entry of a bulk court request was rejected
+type CourtInputError struct {
+	Index   int    `json:"index"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ClosureInput represents the input for closing a venue, or one of its courts, for a period
+type ClosureInput struct {
+	GroundID *uint     `json:"ground_id"` // Omit to close the whole venue
+	StartsAt time.Time `json:"starts_at" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	EndsAt   time.Time `json:"ends_at" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
+	Reason   string    `json:"reason" binding:"max=500"`
 }
 
 // TimeSlotInput represents the input for time slot creation
 type TimeSlotInput struct {
-	CourtNumber int       `json:"court_number" binding:"required,min=1"`
+	GroundID    uint      `json:"ground_id" binding:"required"`
 	StartTime   time.Time `json:"start_time" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
 	EndTime     time.Time `json:"end_time" binding:"required" time_format:"2006-01-02T15:04:05Z07:00"`
 	Price       float64   `json:"price" binding:"required,min=0"`
@@ -114,16 +347,16 @@ type TimeSlotInput struct {
 
 // AutoTimeSlotInput represents the input for generating time slots automatically
 type AutoTimeSlotInput struct {
-	CourtNumbers []int    `json:"court_numbers" binding:"required"`
-	StartDate    string   `json:"start_date" binding:"required"`
-	EndDate      string   `json:"end_date" binding:"required"`
-	StartTime    string   `json:"start_time" binding:"required"`
-	EndTime      string   `json:"end_time" binding:"required"`
-	Duration     int      `json:"duration" binding:"required,min=15"`
-	Price        float64  `json:"price" binding:"required,min=0"`
-	DaysOfWeek   []string `json:"days_of_week" binding:"required"`
-	BookingType  string   `json:"booking_type"`
-	Equipment    string   `json:"equipment"`
+	GroundIDs   []uint   `json:"ground_ids" binding:"required,min=1"`
+	StartDate   string   `json:"start_date" binding:"required"`
+	EndDate     string   `json:"end_date" binding:"required"`
+	StartTime   string   `json:"start_time" binding:"required"`
+	EndTime     string   `json:"end_time" binding:"required"`
+	Duration    int      `json:"duration" binding:"required,min=15"`
+	Price       float64  `json:"price" binding:"required,min=0"`
+	DaysOfWeek  []string `json:"days_of_week" binding:"required"`
+	BookingType string   `json:"booking_type"`
+	Equipment   string   `json:"equipment"`
 }
 
 type BookingInput struct {
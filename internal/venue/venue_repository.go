@@ -2,10 +2,17 @@
 package venue
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/DhavalSuthar-24/miow/internal/user"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
 )
 
 // VenueRepository interface defines all database operations for venue management
@@ -13,22 +20,35 @@ type VenueRepository interface {
 	// Venue operations
 	CreateVenue(venue *Venue) error
 	GetVenueByID(id uint) (*Venue, error)
+	GetVenueByIDIncludingDeleted(id uint) (*Venue, error)
 	GetVenuesByManagerID(managerID uint) ([]Venue, error)
 	GetAllVenues(page, limit int, filters map[string]interface{}) ([]Venue, int64, error)
 	UpdateVenue(venue *Venue) error
-	DeleteVenue(id uint) error
+	AppendVenueImage(venueID uint, url string) (*Venue, error)
+	DeleteVenue(id uint, hardDelete bool) error
+	RestoreVenue(id uint) error
+
+	// Manager operations
+	GetVenueManagerRole(venueID, userID uint) (string, error)
+	GetVenueManagers(venueID uint) ([]VenueManager, error)
+	AddVenueManager(manager *VenueManager) error
+	RemoveVenueManager(venueID, userID uint) error
+	TransferVenueOwnership(venueID, newOwnerID uint) error
 
 	// Court operations
 	AddCourt(court *Ground) error
+	AddCourts(courts []Ground) error
 	GetCourtsByVenueID(venueID uint) ([]Ground, error)
 	GetCourtByID(id uint) (*Ground, error)
 	UpdateCourt(court *Ground) error
 	DeleteCourt(id uint) error
+	SportExists(sportID uint) (bool, error)
 
 	// TimeSlot operations
 	CreateTimeSlot(timeSlot *TimeSlot) error
 	CreateTimeSlots(timeSlots []TimeSlot) error
-	GetTimeSlotsByVenueID(venueID uint, date time.Time, courtNumber int) ([]TimeSlot, error)
+	FilterOverlappingTimeSlots(venueID uint, timeSlots []TimeSlot) ([]TimeSlot, int, error)
+	GetTimeSlotsByVenueID(venueID uint, date time.Time, groundID uint) ([]TimeSlot, error)
 	GetTimeSlotByID(id uint) (*TimeSlot, error)
 	UpdateTimeSlot(timeSlot *TimeSlot) error
 	DeleteTimeSlot(id uint) error
@@ -38,14 +58,59 @@ type VenueRepository interface {
 	GetBookingByID(id uint) (*Booking, error)
 	GetBookingsByUserID(userID uint, page, limit int) ([]Booking, int64, error)
 	GetBookingsByVenueID(venueID uint, page, limit int, filters map[string]interface{}) ([]Booking, int64, error)
+	StreamVenueBookings(venueID uint, filters map[string]interface{}, fn func(BookingExportRow) error) error
+	GetBookingHistory(userID uint, from, to time.Time, page, limit int) ([]BookingHistoryEntry, int64, error)
+	StreamBookingHistory(userID uint, from, to time.Time, fn func(BookingHistoryEntry) error) error
 	UpdateBookingStatus(id uint, status string) error
 	CancelBooking(id uint) error
+	CountUserBookingsOverlapping(userID uint, start, end time.Time) (int64, error)
 
 	// Schedule operations
 	CreateVenueSchedule(schedule *VenueSchedule) error
 	GetVenueSchedules(venueID uint) ([]VenueSchedule, error)
 	UpdateVenueSchedule(schedule *VenueSchedule) error
 	DeleteVenueSchedule(id uint) error
+
+	// Closure operations
+	CreateClosure(closure *VenueClosure) error
+	GetClosuresByVenueID(venueID uint, from time.Time) ([]VenueClosure, error)
+	GetClosureByID(id uint) (*VenueClosure, error)
+	GetActiveClosures(venueID, groundID uint, start, end time.Time) ([]VenueClosure, error)
+	DeleteClosure(id uint) error
+
+	// Health check
+	Ping(ctx context.Context) error
+}
+
+// ErrClosureOverlap is returned when a new closure overlaps an existing one for the same scope
+var ErrClosureOverlap = errors.New("closure overlaps an existing closure")
+
+var (
+	// ErrVenueManagerExists is returned when adding a user who already manages the venue
+	ErrVenueManagerExists = errors.New("user already manages this venue")
+	// ErrVenueManagerNotFound is returned when removing a user who does not manage the venue
+	ErrVenueManagerNotFound = errors.New("user does not manage this venue")
+	// ErrVenueManagerUserNotFound is returned when the user to add does not exist
+	ErrVenueManagerUserNotFound = errors.New("user not found")
+	// ErrVenueHasUpcomingBookings is returned when deleting a venue with future confirmed bookings
+	ErrVenueHasUpcomingBookings = errors.New("venue has upcoming confirmed bookings")
+	// ErrVenueImagesInvalid is returned when a venue's images column is not a JSON list of URLs
+	ErrVenueImagesInvalid = errors.New("venue images are not a list of URLs")
+	// ErrVenueImagesFull is returned when a venue already has maxVenueImages images
+	ErrVenueImagesFull = fmt.Errorf("a venue can have at most %d images", maxVenueImages)
+)
+
+// ClosureConflictError lists the existing closures that a rejected closure overlaps
+type ClosureConflictError struct {
+	Conflicts []VenueClosure
+}
+
+func (e *ClosureConflictError) Error() string {
+	return ErrClosureOverlap.Error()
+}
+
+func (e *ClosureConflictError) Unwrap() error {
+	return ErrClosureOverlap
 }
 
 // venueRepository implements VenueRepository interface
@@ -58,13 +123,30 @@ func NewVenueRepository(db *gorm.DB) VenueRepository {
 	return &venueRepository{db: db}
 }
 
-// CreateVenue adds a new venue to the database
+// CreateVenue adds a new venue to the database, with its manager recorded as owner
 func (r *venueRepository) CreateVenue(venue *Venue) error {
-	return r.db.Create(venue).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(venue).Error; err != nil {
+			return err
+		}
+		return tx.Create(&VenueManager{VenueID: venue.ID, UserID: venue.ManagerID, Role: VenueManagerOwner}).Error
+	})
 }
 
-// GetVenueByID retrieves a venue by its ID
+// GetVenueByID retrieves a venue by its ID, treating soft deleted venues as not found
 func (r *venueRepository) GetVenueByID(id uint) (*Venue, error) {
+	var venue Venue
+	if err := r.db.Where("is_deleted = ?", false).First(&venue, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("venue not found")
+		}
+		return nil, err
+	}
+	return &venue, nil
+}
+
+// GetVenueByIDIncludingDeleted retrieves a venue by its ID even if it has been soft deleted
+func (r *venueRepository) GetVenueByIDIncludingDeleted(id uint) (*Venue, error) {
 	var venue Venue
 	if err := r.db.First(&venue, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -75,16 +157,20 @@ func (r *venueRepository) GetVenueByID(id uint) (*Venue, error) {
 	return &venue, nil
 }
 
-// GetVenuesByManagerID retrieves all venues managed by a specific user
+// GetVenuesByManagerID retrieves all venues a specific user owns or is staff at
 func (r *venueRepository) GetVenuesByManagerID(managerID uint) ([]Venue, error) {
 	var venues []Venue
-	if err := r.db.Where("manager_id = ?", managerID).Find(&venues).Error; err != nil {
+	if err := r.db.Where("is_deleted = ?", false).
+		Where(r.db.Where("manager_id = ?", managerID).
+			Or("id IN (?)", r.db.Model(&VenueManager{}).Select("venue_id").Where("user_id = ?", managerID))).
+		Find(&venues).Error; err != nil {
 		return nil, err
 	}
 	return venues, nil
 }
 
-// GetAllVenues retrieves all venues with pagination and filters
+// GetAllVenues retrieves all venues with pagination and filters, from the read replica if
+// there is one
 func (r *venueRepository) GetAllVenues(page, limit int, filters map[string]interface{}) ([]Venue, int64, error) {
 	var venues []Venue
 	var totalCount int64
@@ -92,8 +178,8 @@ func (r *venueRepository) GetAllVenues(page, limit int, filters map[string]inter
 	// Calculate offset
 	offset := (page - 1) * limit
 
-	// Start with the base query
-	query := r.db.Model(&Venue{})
+	// Start with the base query, leaving out soft deleted venues
+	query := r.db.Clauses(dbresolver.Read).Model(&Venue{}).Where("is_deleted = ?", false)
 
 	// Apply filters if any
 	for key, value := range filters {
@@ -106,6 +192,31 @@ func (r *venueRepository) GetAllVenues(page, limit int, filters map[string]inter
 			query = query.Where("court_count >= ?", value)
 		case "max_price":
 			query = query.Where("hourly_rate <= ?", value)
+		case "free_slot_window":
+			// Bounded to the window so only a few days of slots per venue are scanned
+			window := value.(AvailabilityWindow)
+			query = query.Where(`EXISTS (
+				SELECT 1 FROM time_slots ts
+				WHERE ts.venue_id = venues.id AND ts.is_booked = false
+					AND ts.start_time >= ? AND ts.start_time < ?
+					AND NOT EXISTS (
+						SELECT 1 FROM venue_closures vc
+						WHERE vc.venue_id = ts.venue_id
+							AND (vc.ground_id IS NULL OR vc.ground_id = ts.ground_id)
+							AND vc.starts_at < ts.end_time AND vc.ends_at > ts.start_time
+					)
+			)`, window.From, window.To)
+		case "sport_id":
+			query = query.Where("EXISTS (SELECT 1 FROM grounds g WHERE g.venue_id = venues.id AND g.sport_id = ?)", value)
+		case "near":
+			// Haversine distance over the JSON coordinates, clamped against rounding before ASIN;
+			// venues without coordinates never match
+			near := value.(GeoFilter)
+			query = query.Where(`coordinates IS NOT NULL AND 6371 * 2 * ASIN(LEAST(1, SQRT(
+				POWER(SIN(RADIANS(CAST(coordinates->>'latitude' AS double precision) - ?) / 2), 2) +
+				COS(RADIANS(?)) * COS(RADIANS(CAST(coordinates->>'latitude' AS double precision))) *
+				POWER(SIN(RADIANS(CAST(coordinates->>'longitude' AS double precision) - ?) / 2), 2)
+			))) <= ?`, near.Latitude, near.Latitude, near.Longitude, near.RadiusKm)
 		}
 	}
 
@@ -122,14 +233,169 @@ func (r *venueRepository) GetAllVenues(page, limit int, filters map[string]inter
 	return venues, totalCount, nil
 }
 
+// GetVenueManagerRole returns the user's role at the venue, or "" if they do not manage it
+func (r *venueRepository) GetVenueManagerRole(venueID, userID uint) (string, error) {
+	var manager VenueManager
+	if err := r.db.Where("venue_id = ? AND user_id = ?", venueID, userID).First(&manager).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return manager.Role, nil
+}
+
+// GetVenueManagers lists the managers of a venue, owner first
+func (r *venueRepository) GetVenueManagers(venueID uint) ([]VenueManager, error) {
+	var managers []VenueManager
+	if err := r.db.Where("venue_id = ?", venueID).
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: "role = ? DESC, created_at ASC", Vars: []interface{}{VenueManagerOwner}}}).
+		Find(&managers).Error; err != nil {
+		return nil, err
+	}
+	return managers, nil
+}
+
+// AddVenueManager grants a user management of a venue
+func (r *venueRepository) AddVenueManager(manager *VenueManager) error {
+	var users int64
+	if err := r.db.Model(&user.User{}).Where("id = ?", manager.UserID).Count(&users).Error; err != nil {
+		return err
+	}
+	if users == 0 {
+		return ErrVenueManagerUserNotFound
+	}
+
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(manager)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVenueManagerExists
+	}
+	return nil
+}
+
+// RemoveVenueManager revokes a staff member's management of a venue. The owner cannot be
+// removed; ownership has to be transferred instead.
+func (r *venueRepository) RemoveVenueManager(venueID, userID uint) error {
+	result := r.db.Where("venue_id = ? AND user_id = ? AND role <> ?", venueID, userID, VenueManagerOwner).
+		Delete(&VenueManager{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVenueManagerNotFound
+	}
+	return nil
+}
+
+// TransferVenueOwnership makes another user the venue's owner. The previous owner stays on as
+// staff; the new owner need not manage the venue already.
+func (r *venueRepository) TransferVenueOwnership(venueID, newOwnerID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var venue Venue
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&venue, venueID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("venue not found")
+			}
+			return err
+		}
+
+		var users int64
+		if err := tx.Model(&user.User{}).Where("id = ?", newOwnerID).Count(&users).Error; err != nil {
+			return err
+		}
+		if users == 0 {
+			return ErrVenueManagerUserNotFound
+		}
+
+		setRole := func(userID uint, role string) error {
+			return tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "venue_id"}, {Name: "user_id"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{"role": role, "updated_at": time.Now()}),
+			}).Create(&VenueManager{VenueID: venueID, UserID: userID, Role: role}).Error
+		}
+		if venue.ManagerID != 0 && venue.ManagerID != newOwnerID {
+			if err := setRole(venue.ManagerID, VenueManagerStaff); err != nil {
+				return err
+			}
+		}
+		if err := setRole(newOwnerID, VenueManagerOwner); err != nil {
+			return err
+		}
+		return tx.Model(&Venue{}).Where("id = ?", venueID).Update("manager_id", newOwnerID).Error
+	})
+}
+
+// GeoFilter limits the "near" venue filter to venues within RadiusKm of a point
+type GeoFilter struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
+}
+
+// AvailabilityWindow limits the "free_slot_window" venue filter to slots starting in [From, To)
+type AvailabilityWindow struct {
+	From time.Time
+	To   time.Time
+}
+
 // UpdateVenue updates venue information
 func (r *venueRepository) UpdateVenue(venue *Venue) error {
 	return r.db.Save(venue).Error
 }
 
-// DeleteVenue removes a venue from the database
-func (r *venueRepository) DeleteVenue(id uint) error {
+// AppendVenueImage adds an image URL to a venue's images while holding the venue's row lock, so
+// concurrent uploads cannot overwrite each other's URL. It returns the updated venue.
+func (r *venueRepository) AppendVenueImage(venueID uint, url string) (*Venue, error) {
+	var venue Venue
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("is_deleted = ?", false).First(&venue, venueID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("venue not found")
+			}
+			return err
+		}
+
+		images, err := decodeVenueImages(venue.Images)
+		if err != nil {
+			return err
+		}
+		if len(images) >= maxVenueImages {
+			return ErrVenueImagesFull
+		}
+		encoded, _ := json.Marshal(append(images, url))
+		venue.Images = string(encoded)
+		return tx.Model(&venue).Update("images", venue.Images).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &venue, nil
+}
+
+// DeleteVenue soft deletes a venue, or with hardDelete removes it and its courts, time slots,
+// schedules and managers. Either way it is refused while the venue has future confirmed bookings.
+func (r *venueRepository) DeleteVenue(id uint, hardDelete bool) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
+		var upcoming int64
+		if err := tx.Model(&Booking{}).
+			Joins("JOIN grounds ON grounds.id = bookings.ground_id").
+			Where("grounds.venue_id = ? AND bookings.status = ? AND bookings.start_time > ?", id, "confirmed", time.Now()).
+			Count(&upcoming).Error; err != nil {
+			return err
+		}
+		if upcoming > 0 {
+			return ErrVenueHasUpcomingBookings
+		}
+
+		if !hardDelete {
+			return tx.Model(&Venue{}).Where("id = ?", id).
+				Updates(map[string]interface{}{"is_deleted": true, "deleted_at": time.Now()}).Error
+		}
+
 		// Delete related time slots first
 		if err := tx.Where("venue_id = ?", id).Delete(&TimeSlot{}).Error; err != nil {
 			return err
@@ -145,6 +411,10 @@ func (r *venueRepository) DeleteVenue(id uint) error {
 			return err
 		}
 
+		if err := tx.Where("venue_id = ?", id).Delete(&VenueManager{}).Error; err != nil {
+			return err
+		}
+
 		// Finally delete the venue
 		if err := tx.Delete(&Venue{}, id).Error; err != nil {
 			return err
@@ -154,11 +424,30 @@ func (r *venueRepository) DeleteVenue(id uint) error {
 	})
 }
 
+// RestoreVenue brings back a soft deleted venue. DeletedAt is a plain time, so live venues hold
+// its zero value rather than NULL.
+func (r *venueRepository) RestoreVenue(id uint) error {
+	return r.db.Model(&Venue{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"is_deleted": false, "deleted_at": time.Time{}}).Error
+}
+
 // AddCourt adds a new court to a venue
 func (r *venueRepository) AddCourt(court *Ground) error {
 	return r.db.Create(court).Error
 }
 
+// AddCourts adds several courts in one transaction; either all of them are created or none
+func (r *venueRepository) AddCourts(courts []Ground) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range courts {
+			if err := tx.Create(&courts[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // GetCourtsByVenueID retrieves all courts for a specific venue
 func (r *venueRepository) GetCourtsByVenueID(venueID uint) ([]Ground, error) {
 	var courts []Ground
@@ -190,13 +479,23 @@ func (r *venueRepository) DeleteCourt(id uint) error {
 	return r.db.Delete(&Ground{}, id).Error
 }
 
+// SportExists reports whether the sport exists. The sports table is queried directly so the
+// venue package does not depend on the sport package.
+func (r *venueRepository) SportExists(sportID uint) (bool, error) {
+	var count int64
+	if err := r.db.Table("sports").Where("id = ?", sportID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 // CreateTimeSlot adds a new time slot
 func (r *venueRepository) CreateTimeSlot(timeSlot *TimeSlot) error {
-	// Check if there's an overlapping time slot for the same court
+	// Check if there's an overlapping time slot for the same ground
 	var count int64
 	err := r.db.Model(&TimeSlot{}).
-		Where("venue_id = ? AND court_number = ? AND ((start_time <= ? AND end_time > ?) OR (start_time < ? AND end_time >= ?) OR (start_time >= ? AND end_time <= ?))",
-			timeSlot.VenueID, timeSlot.CourtNumber,
+		Where("venue_id = ? AND ground_id = ? AND ((start_time <= ? AND end_time > ?) OR (start_time < ? AND end_time >= ?) OR (start_time >= ? AND end_time <= ?))",
+			timeSlot.VenueID, timeSlot.GroundID,
 			timeSlot.StartTime, timeSlot.StartTime,
 			timeSlot.EndTime, timeSlot.EndTime,
 			timeSlot.StartTime, timeSlot.EndTime).
@@ -218,25 +517,81 @@ func (r *venueRepository) CreateTimeSlots(timeSlots []TimeSlot) error {
 	return r.db.Create(&timeSlots).Error
 }
 
-// GetTimeSlotsByVenueID retrieves all time slots for a specific venue, optionally filtered by date and court number
-func (r *venueRepository) GetTimeSlotsByVenueID(venueID uint, date time.Time, courtNumber int) ([]TimeSlot, error) {
+// FilterOverlappingTimeSlots drops candidate slots that overlap an existing slot on the same
+// ground (or an earlier candidate in the batch). Existing slots for the whole range are loaded
+// in a single query. It returns the remaining slots and the number skipped.
+func (r *venueRepository) FilterOverlappingTimeSlots(venueID uint, timeSlots []TimeSlot) ([]TimeSlot, int, error) {
+	if len(timeSlots) == 0 {
+		return timeSlots, 0, nil
+	}
+
+	rangeStart, rangeEnd := timeSlots[0].StartTime, timeSlots[0].EndTime
+	groundSet := make(map[uint]bool)
+	for _, slot := range timeSlots {
+		if slot.StartTime.Before(rangeStart) {
+			rangeStart = slot.StartTime
+		}
+		if slot.EndTime.After(rangeEnd) {
+			rangeEnd = slot.EndTime
+		}
+		groundSet[slot.GroundID] = true
+	}
+	groundIDs := make([]uint, 0, len(groundSet))
+	for id := range groundSet {
+		groundIDs = append(groundIDs, id)
+	}
+
+	var existing []TimeSlot
+	if err := r.db.Select("ground_id, start_time, end_time").
+		Where("venue_id = ? AND ground_id IN ? AND start_time < ? AND end_time > ?", venueID, groundIDs, rangeEnd, rangeStart).
+		Find(&existing).Error; err != nil {
+		return nil, 0, err
+	}
+
+	taken := make(map[uint][]TimeSlot)
+	for _, slot := range existing {
+		taken[slot.GroundID] = append(taken[slot.GroundID], slot)
+	}
+
+	filtered := make([]TimeSlot, 0, len(timeSlots))
+	skipped := 0
+	for _, slot := range timeSlots {
+		overlaps := false
+		for _, other := range taken[slot.GroundID] {
+			if slot.StartTime.Before(other.EndTime) && slot.EndTime.After(other.StartTime) {
+				overlaps = true
+				break
+			}
+		}
+		if overlaps {
+			skipped++
+			continue
+		}
+		taken[slot.GroundID] = append(taken[slot.GroundID], slot)
+		filtered = append(filtered, slot)
+	}
+
+	return filtered, skipped, nil
+}
+
+// GetTimeSlotsByVenueID retrieves all time slots for a specific venue, optionally filtered by date and ground
+func (r *venueRepository) GetTimeSlotsByVenueID(venueID uint, date time.Time, groundID uint) ([]TimeSlot, error) {
 	var timeSlots []TimeSlot
 	query := r.db.Where("venue_id = ?", venueID)
 
 	// Add date filter if provided
 	if !date.IsZero() {
-		startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-		endOfDay := startOfDay.Add(24 * time.Hour)
+		startOfDay, endOfDay := dayBounds(date)
 		query = query.Where("start_time >= ? AND start_time < ?", startOfDay, endOfDay)
 	}
 
-	// Add court number filter if provided
-	if courtNumber > 0 {
-		query = query.Where("court_number = ?", courtNumber)
+	// Add ground filter if provided
+	if groundID > 0 {
+		query = query.Where("ground_id = ?", groundID)
 	}
 
-	// Order by court number and start time
-	query = query.Order("court_number asc, start_time asc")
+	// Order by ground and start time
+	query = query.Order("ground_id asc, start_time asc")
 
 	if err := query.Find(&timeSlots).Error; err != nil {
 		return nil, err
@@ -277,8 +632,8 @@ func (r *venueRepository) CreateBooking(booking *Booking) error {
 
 		// Update the time slot to show it's booked
 		if err := tx.Model(&TimeSlot{}).
-			Where("venue_id = ? AND court_number = ? AND start_time = ? AND end_time = ?",
-				booking.Ground.VenueID, booking.Ground.ID, booking.StartTime, booking.EndTime).
+			Where("ground_id = ? AND start_time = ? AND end_time = ?",
+				booking.GroundID, booking.StartTime, booking.EndTime).
 			Updates(map[string]interface{}{
 				"is_booked": true,
 				"booked_by": booking.UserID,
@@ -335,7 +690,34 @@ func (r *venueRepository) GetBookingsByVenueID(venueID uint, page, limit int, fi
 		Joins("JOIN grounds ON bookings.ground_id = grounds.id").
 		Where("grounds.venue_id = ?", venueID)
 
-	// Apply filters
+	query = applyBookingFilters(query, filters)
+
+	// Get total count
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	if err := query.Preload("Ground").
+		Order("bookings.start_time desc").
+		Offset(offset).Limit(limit).
+		Find(&bookings).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return bookings, totalCount, nil
+}
+
+// dayBounds returns the start of date's calendar day in its location and the start of the
+// next day. A day may have 23 or 25 hours when the clocks change.
+func dayBounds(date time.Time) (time.Time, time.Time) {
+	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	return startOfDay, startOfDay.AddDate(0, 0, 1)
+}
+
+// applyBookingFilters narrows a venue bookings query by status, start date, court and a search
+// of the purpose or booking reference
+func applyBookingFilters(query *gorm.DB, filters map[string]interface{}) *gorm.DB {
 	for key, value := range filters {
 		switch key {
 		case "status":
@@ -343,29 +725,116 @@ func (r *venueRepository) GetBookingsByVenueID(venueID uint, page, limit int, fi
 		case "date":
 			date, ok := value.(time.Time)
 			if ok {
-				startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-				endOfDay := startOfDay.Add(24 * time.Hour)
+				startOfDay, endOfDay := dayBounds(date)
 				query = query.Where("bookings.start_time >= ? AND bookings.start_time < ?", startOfDay, endOfDay)
 			}
 		case "court_id":
 			query = query.Where("bookings.ground_id = ?", value)
+		case "search":
+			search := value.(string)
+			if id, err := strconv.ParseUint(search, 10, 32); err == nil {
+				query = query.Where("(bookings.purpose ILIKE ? OR bookings.id = ?)", "%"+search+"%", id)
+			} else {
+				query = query.Where("bookings.purpose ILIKE ?", "%"+search+"%")
+			}
 		}
 	}
+	return query
+}
 
-	// Get total count
-	if err := query.Count(&totalCount).Error; err != nil {
+// bookingPriceSQL selects a booking's price snapshot, falling back to the venue's current hourly
+// rate for the booked duration for bookings made before prices were recorded
+const bookingPriceSQL = "COALESCE(bookings.price, venues.hourly_rate * EXTRACT(EPOCH FROM (bookings.end_time - bookings.start_time)) / 3600)"
+
+// StreamVenueBookings calls fn for each booking of a venue matching the filters, newest first.
+// Rows are read one at a time so large venues can be exported without loading every booking.
+func (r *venueRepository) StreamVenueBookings(venueID uint, filters map[string]interface{}, fn func(BookingExportRow) error) error {
+	query := r.db.Model(&Booking{}).
+		Select("bookings.id, bookings.ground_id AS court_id, grounds.name AS court_name, "+
+			"bookings.user_id, users.name AS user_name, bookings.start_time, bookings.end_time, "+
+			"bookings.status, bookings.purpose, "+
+			bookingPriceSQL+" AS price").
+		Joins("JOIN grounds ON bookings.ground_id = grounds.id").
+		Joins("JOIN venues ON venues.id = grounds.venue_id").
+		Joins("LEFT JOIN users ON users.id = bookings.user_id").
+		Where("grounds.venue_id = ?", venueID)
+
+	rows, err := applyBookingFilters(query, filters).Order("bookings.start_time desc").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row BookingExportRow
+		if err := r.db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// bookingHistoryStatuses are the final booking statuses listed in a user's booking history
+var bookingHistoryStatuses = []string{"completed", "cancelled"}
+
+// bookingHistoryQuery selects a user's completed and cancelled bookings starting in [from, to);
+// a zero bound is left open
+func (r *venueRepository) bookingHistoryQuery(userID uint, from, to time.Time) *gorm.DB {
+	query := r.db.Model(&Booking{}).
+		Select("bookings.id, venues.id AS venue_id, venues.name AS venue_name, venues.timezone AS venue_timezone, "+
+			"bookings.ground_id AS court_id, grounds.name AS court_name, bookings.start_time, bookings.end_time, "+
+			"bookings.status, "+bookingPriceSQL+" AS price").
+		Joins("JOIN grounds ON bookings.ground_id = grounds.id").
+		Joins("JOIN venues ON venues.id = grounds.venue_id").
+		Where("bookings.user_id = ? AND bookings.status IN ?", userID, bookingHistoryStatuses)
+	if !from.IsZero() {
+		query = query.Where("bookings.start_time >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("bookings.start_time < ?", to)
+	}
+	return query
+}
+
+// GetBookingHistory retrieves a page of a user's completed and cancelled bookings, newest first
+func (r *venueRepository) GetBookingHistory(userID uint, from, to time.Time, page, limit int) ([]BookingHistoryEntry, int64, error) {
+	var totalCount int64
+	if err := r.bookingHistoryQuery(userID, from, to).Count(&totalCount).Error; err != nil {
 		return nil, 0, err
 	}
 
-	// Get paginated results
-	if err := query.Preload("Ground").
-		Order("bookings.start_time desc").
-		Offset(offset).Limit(limit).
-		Find(&bookings).Error; err != nil {
+	var entries []BookingHistoryEntry
+	if err := r.bookingHistoryQuery(userID, from, to).
+		Order("bookings.start_time desc, bookings.id desc").
+		Offset((page - 1) * limit).Limit(limit).
+		Scan(&entries).Error; err != nil {
 		return nil, 0, err
 	}
+	return entries, totalCount, nil
+}
 
-	return bookings, totalCount, nil
+// StreamBookingHistory calls fn for each of a user's completed and cancelled bookings, newest first
+func (r *venueRepository) StreamBookingHistory(userID uint, from, to time.Time, fn func(BookingHistoryEntry) error) error {
+	rows, err := r.bookingHistoryQuery(userID, from, to).
+		Order("bookings.start_time desc, bookings.id desc").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry BookingHistoryEntry
+		if err := r.db.ScanRows(rows, &entry); err != nil {
+			return err
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
 }
 
 // UpdateBookingStatus updates the status of a booking
@@ -386,16 +855,10 @@ func (r *venueRepository) CancelBooking(id uint) error {
 			return err
 		}
 
-		// Find the ground to get venue ID
-		var ground Ground
-		if err := tx.First(&ground, booking.GroundID).Error; err != nil {
-			return err
-		}
-
 		// Release the time slot
 		if err := tx.Model(&TimeSlot{}).
-			Where("venue_id = ? AND court_number = ? AND start_time = ? AND end_time = ?",
-				ground.VenueID, ground.ID, booking.StartTime, booking.EndTime).
+			Where("ground_id = ? AND start_time = ? AND end_time = ?",
+				booking.GroundID, booking.StartTime, booking.EndTime).
 			Updates(map[string]interface{}{
 				"is_booked": false,
 				"booked_by": 0,
@@ -407,6 +870,16 @@ func (r *venueRepository) CancelBooking(id uint) error {
 	})
 }
 
+// CountUserBookingsOverlapping counts the user's active bookings that overlap the given time range
+func (r *venueRepository) CountUserBookingsOverlapping(userID uint, start, end time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&Booking{}).
+		Where("user_id = ? AND status NOT IN ? AND start_time < ? AND end_time > ?",
+			userID, []string{"cancelled", "rejected"}, end, start).
+		Count(&count).Error
+	return count, err
+}
+
 // CreateVenueSchedule adds a new venue schedule
 func (r *venueRepository) CreateVenueSchedule(schedule *VenueSchedule) error {
 	return r.db.Create(schedule).Error
@@ -430,3 +903,83 @@ func (r *venueRepository) UpdateVenueSchedule(schedule *VenueSchedule) error {
 func (r *venueRepository) DeleteVenueSchedule(id uint) error {
 	return r.db.Delete(&VenueSchedule{}, id).Error
 }
+
+// CreateClosure stores a closure after checking it does not overlap another closure for the same
+// scope. A venue-wide closure conflicts with every closure at the venue, while a court closure
+// conflicts with venue-wide closures and closures on the same court
+func (r *venueRepository) CreateClosure(closure *VenueClosure) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Serialise closure creation per venue so concurrent requests cannot both pass the check
+		var venue Venue
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&venue, closure.VenueID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("venue not found")
+			}
+			return err
+		}
+
+		var groundID uint
+		if closure.GroundID != nil {
+			groundID = *closure.GroundID
+		}
+		conflicts, err := activeClosures(tx, closure.VenueID, groundID, closure.StartsAt, closure.EndsAt)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return &ClosureConflictError{Conflicts: conflicts}
+		}
+		return tx.Create(closure).Error
+	})
+}
+
+// GetClosuresByVenueID retrieves the closures of a venue that have not ended by the given time
+func (r *venueRepository) GetClosuresByVenueID(venueID uint, from time.Time) ([]VenueClosure, error) {
+	var closures []VenueClosure
+	if err := r.db.Where("venue_id = ? AND ends_at > ?", venueID, from).
+		Order("starts_at ASC").Find(&closures).Error; err != nil {
+		return nil, err
+	}
+	return closures, nil
+}
+
+// GetClosureByID retrieves a closure by its ID
+func (r *venueRepository) GetClosureByID(id uint) (*VenueClosure, error) {
+	var closure VenueClosure
+	if err := r.db.First(&closure, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("closure not found")
+		}
+		return nil, err
+	}
+	return &closure, nil
+}
+
+// GetActiveClosures retrieves the closures that cover any part of [start, end) on a court,
+// including venue-wide closures. A groundID of 0 returns closures on every court of the venue
+func (r *venueRepository) GetActiveClosures(venueID, groundID uint, start, end time.Time) ([]VenueClosure, error) {
+	return activeClosures(r.db, venueID, groundID, start, end)
+}
+
+// DeleteClosure removes a closure from the database
+func (r *venueRepository) DeleteClosure(id uint) error {
+	return r.db.Delete(&VenueClosure{}, id).Error
+}
+
+func activeClosures(db *gorm.DB, venueID, groundID uint, start, end time.Time) ([]VenueClosure, error) {
+	query := db.Where("venue_id = ? AND starts_at < ? AND ends_at > ?", venueID, end, start)
+	if groundID != 0 {
+		query = query.Where("ground_id IS NULL OR ground_id = ?", groundID)
+	}
+
+	var closures []VenueClosure
+	if err := query.Order("starts_at ASC").Find(&closures).Error; err != nil {
+		return nil, err
+	}
+	return closures, nil
+}
+
+// Ping checks that the venues table answers a trivial query
+func (r *venueRepository) Ping(ctx context.Context) error {
+	return r.db.WithContext(ctx).Exec("SELECT 1 FROM venues LIMIT 1").Error
+}
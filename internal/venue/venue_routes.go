@@ -1,62 +1,26 @@
 package venue
 
 import (
-	"net/http"
-	"strconv"
-
+	"github.com/DhavalSuthar-24/miow/pkg/cache"
+	"github.com/DhavalSuthar-24/miow/pkg/storage"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 
 	"github.com/DhavalSuthar-24/miow/config"
 	mw "github.com/DhavalSuthar-24/miow/internal/middleware"
-
-	"github.com/DhavalSuthar-24/miow/pkg/rmiddleware"
+	"github.com/DhavalSuthar-24/miow/internal/notification"
 )
 
-func RequireOwnership[T any](load func(uint) (*T, error), ownerField func(*T) uint, idParam string) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		userIDIfc, exists := c.Get("currentUserID")
-		if !exists {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
-			return
-		}
-		userID := userIDIfc.(uint)
-
-		param := c.Param(idParam)
-		id64, err := strconv.ParseUint(param, 10, 64)
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
-			return
-		}
-		resID := uint(id64)
-
-		model, err := load(resID)
-		if err != nil {
-			if err == gorm.ErrRecordNotFound {
-				c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "resource not found"})
-			} else {
-				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "load error"})
-			}
-			return
-		}
-
-		if ownerField(model) != userID {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden: not owner"})
-			return
-		}
-
-		c.Set("loadedResource", model)
-		c.Next()
-	}
-}
-
-func VenueSetupRoutes(r *gin.Engine, db *gorm.DB, appConfig *config.Config, jwtSecret string) {
+// VenueSetupRoutes sets up the venue, court, time slot and booking routes
+func VenueSetupRoutes(r *gin.RouterGroup, db *gorm.DB, appConfig *config.Config, jwtSecret string) {
 	public := r.Group("/")
-	venueController := NewVenueController(NewVenueRepository(db), appConfig)
+	venueController := NewVenueController(NewCachedVenueRepository(NewVenueRepository(db), cache.Shared(appConfig), cache.TTL(appConfig)), appConfig, notification.NewDefaultDispatcher(db, appConfig), storage.Shared(appConfig))
 	public.GET("/venues", venueController.GetAllVenues)
 	public.GET("/venues/:venue_id", venueController.GetVenueByID)
 	public.GET("/venues/:venue_id/courts", venueController.GetVenueCourts)
 	public.GET("/venues/:venue_id/timeslots", venueController.GetVenueTimeSlots)
+	public.GET("/venues/:venue_id/closures", venueController.GetVenueClosures)
+	public.GET("/sports/:sport_id/venues", venueController.GetSportVenues)
 
 	authenticated := r.Group("/")
 	authenticated.Use(mw.AuthMiddleware(jwtSecret, db))
@@ -65,96 +29,44 @@ func VenueSetupRoutes(r *gin.Engine, db *gorm.DB, appConfig *config.Config, jwtS
 		authenticated.GET("/bookings", venueController.GetUserBookings)
 		authenticated.GET("/bookings/:booking_id", venueController.GetBookingByID)
 		authenticated.DELETE("/bookings/:booking_id", venueController.CancelBooking)
+		authenticated.GET("/users/me/bookings/history", venueController.GetBookingHistory)
 	}
 
+	// Creating a venue needs the venue_manager role. The venue's own routes are open to its
+	// owner and staff, which each handler checks, so staff need no global role.
 	venueManager := authenticated.Group("/manager/venues")
-	venueManager.Use(rmiddleware.VenueManagerhOrAdminMiddleware())
 	{
-		venueManager.POST("", venueController.CreateVenue)
+		venueManager.POST("", mw.RequireAnyRole("venue_manager", "admin"), venueController.CreateVenue)
+		venueManager.PUT("/:venue_id", venueController.UpdateVenue)
+		venueManager.DELETE("/:venue_id", venueController.DeleteVenue)
+		venueManager.POST("/:venue_id/images", venueController.UploadVenueImage)
+
+		venueManager.GET("/:venue_id/managers", venueController.GetVenueManagers)
+		venueManager.POST("/:venue_id/managers", venueController.AddVenueManager)
+		venueManager.DELETE("/:venue_id/managers/:user_id", venueController.RemoveVenueManager)
+		venueManager.POST("/:venue_id/transfer", venueController.TransferVenueOwnership)
 
-		venueManager.PUT("/:venue_id",
-			RequireOwnership(
-				func(id uint) (*Venue, error) { var v Venue; return &v, db.First(&v, id).Error },
-				func(v *Venue) uint { return v.ManagerID },
-				"venue_id",
-			),
-			venueController.UpdateVenue,
-		)
-		venueManager.DELETE("/:venue_id",
-			RequireOwnership(
-				func(id uint) (*Venue, error) { var v Venue; return &v, db.First(&v, id).Error },
-				func(v *Venue) uint { return v.ManagerID },
-				"venue_id",
-			),
-			venueController.DeleteVenue,
-		)
+		venueManager.POST("/:venue_id/courts", venueController.AddCourt)
+		venueManager.POST("/:venue_id/courts/bulk", venueController.BulkAddCourts)
+		venueManager.PUT("/:venue_id/courts/:court_id", venueController.UpdateCourt)
+		venueManager.DELETE("/:venue_id/courts/:court_id", venueController.DeleteCourt)
 
-		venueManager.POST("/:venue_id/courts",
-			RequireOwnership(
-				func(id uint) (*Venue, error) { var v Venue; return &v, db.First(&v, id).Error },
-				func(v *Venue) uint { return v.ManagerID },
-				"venue_id",
-			),
-			venueController.AddCourt,
-		)
-		venueManager.PUT("/:venue_id/courts/:court_id",
-			RequireOwnership(
-				func(cid uint) (*Ground, error) { var g Ground; return &g, db.Preload("Venue").First(&g, cid).Error },
-				func(g *Ground) uint { return g.Venue.ManagerID },
-				"court_id",
-			),
-			venueController.UpdateCourt,
-		)
-		venueManager.DELETE("/:venue_id/courts/:court_id",
-			RequireOwnership(
-				func(cid uint) (*Ground, error) { var g Ground; return &g, db.Preload("Venue").First(&g, cid).Error },
-				func(g *Ground) uint { return g.Venue.ManagerID },
-				"court_id",
-			),
-			venueController.DeleteCourt,
-		)
+		venueManager.POST("/:venue_id/timeslots", venueController.CreateTimeSlots)
+		venueManager.POST("/:venue_id/timeslots/auto", venueController.GenerateAutoTimeSlots)
+		venueManager.PUT("/:venue_id/timeslots/:timeslot_id", venueController.UpdateTimeSlot)
+		venueManager.DELETE("/:venue_id/timeslots/:timeslot_id", venueController.DeleteTimeSlot)
 
-		venueManager.POST("/:venue_id/timeslots",
-			RequireOwnership(
-				func(id uint) (*Venue, error) { var v Venue; return &v, db.First(&v, id).Error },
-				func(v *Venue) uint { return v.ManagerID },
-				"venue_id",
-			),
-			venueController.CreateTimeSlots,
-		)
-		venueManager.POST("/:venue_id/timeslots/auto",
-			RequireOwnership(
-				func(id uint) (*Venue, error) { var v Venue; return &v, db.First(&v, id).Error },
-				func(v *Venue) uint { return v.ManagerID },
-				"venue_id",
-			),
-			venueController.GenerateAutoTimeSlots,
-		)
-		venueManager.PUT("/:venue_id/timeslots/:timeslot_id",
-			RequireOwnership(
-				func(id uint) (*TimeSlot, error) { var ts TimeSlot; return &ts, db.First(&ts, id).Error },
-				func(ts *TimeSlot) uint { return ts.VenueID },
-				"timeslot_id",
-			),
-			venueController.UpdateTimeSlot,
-		)
-		venueManager.DELETE("/:venue_id/timeslots/:timeslot_id",
-			RequireOwnership(
-				func(id uint) (*TimeSlot, error) { var ts TimeSlot; return &ts, db.First(&ts, id).Error },
-				func(ts *TimeSlot) uint { return ts.VenueID },
-				"timeslot_id",
-			),
-			venueController.DeleteTimeSlot,
-		)
+		venueManager.POST("/:venue_id/closures", venueController.CreateVenueClosure)
+		venueManager.DELETE("/:venue_id/closures/:closure_id", venueController.DeleteVenueClosure)
 
 		venueManager.GET("/:venue_id/bookings", venueController.GetVenueBookings)
-		venueManager.PUT("/bookings/:booking_id/status",
-			RequireOwnership(
-				func(id uint) (*Booking, error) { var b Booking; return &b, db.First(&b, id).Error },
-				func(b *Booking) uint { return b.UserID },
-				"booking_id",
-			),
-			venueController.UpdateBookingStatus,
-		)
+		venueManager.GET("/:venue_id/bookings/export", venueController.ExportVenueBookings)
+		venueManager.PUT("/bookings/:booking_id/status", venueController.UpdateBookingStatus)
+	}
+
+	adminVenues := authenticated.Group("/admin/venues")
+	adminVenues.Use(mw.RequireRole("admin"))
+	{
+		adminVenues.POST("/:venue_id/restore", venueController.RestoreVenue)
 	}
 }
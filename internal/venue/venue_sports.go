@@ -0,0 +1,86 @@
+package venue
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/DhavalSuthar-24/miow/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// checkCourtSport verifies that the sport a court is set up for exists. A nil sport is a general
+// purpose court. It writes the error response and returns false on failure.
+func (c *VenueController) checkCourtSport(ctx *gin.Context, sportID *uint) bool {
+	if sportID == nil {
+		return true
+	}
+	exists, err := c.repo.SportExists(*sportID)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to check sport: "+err.Error())
+		return false
+	}
+	if !exists {
+		response.Error(ctx, http.StatusBadRequest, "sport not found")
+		return false
+	}
+	return true
+}
+
+// GetSportVenues godoc
+// @Summary Get venues for a sport
+// @Description Get a paginated list of venues with at least one court set up for the sport, with the same filters as the venue listing
+// @Tags venues
+// @Produce json
+// @Param sport_id path int true "Sport ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Number of items per page (default: 10, max: 100)"
+// @Param available query boolean false "Filter by availability"
+// @Param location query string false "Filter by location (partial match)"
+// @Param min_courts query int false "Filter by minimum number of courts"
+// @Param max_price query number false "Filter by maximum hourly rate"
+// @Param has_availability_this_week query boolean false "Only venues with a free, unclosed time slot starting in the next 7 days"
+// @Param lat query number false "Latitude to search around; requires lng"
+// @Param lng query number false "Longitude to search around; requires lat"
+// @Param radius_km query number false "Search radius around lat and lng in km (default: 10, max: 500)"
+// @Success 200 {object} response.PaginatedResponse{data=response.Page{items=[]Venue}} "List of venues"
+// @Failure 400 {object} response.ErrorResponse "Invalid query parameters"
+// @Failure 404 {object} response.ErrorResponse "Sport not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /sports/{sport_id}/venues [get]
+func (c *VenueController) GetSportVenues(ctx *gin.Context) {
+	sportID, err := strconv.ParseUint(ctx.Param("sport_id"), 10, 32)
+	if err != nil {
+		response.Error(ctx, http.StatusBadRequest, "invalid sport ID")
+		return
+	}
+
+	var pagination PaginationInput
+	if err := ctx.ShouldBindQuery(&pagination); err != nil {
+		response.ValidationError(ctx, err)
+		return
+	}
+
+	filters, ok := venueFilters(ctx)
+	if !ok {
+		return
+	}
+	filters["sport_id"] = uint(sportID)
+
+	exists, err := c.repo.SportExists(uint(sportID))
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to check sport: "+err.Error())
+		return
+	}
+	if !exists {
+		response.Error(ctx, http.StatusNotFound, "sport not found")
+		return
+	}
+
+	venues, totalCount, err := c.repo.GetAllVenues(pagination.Page, pagination.Limit, filters)
+	if err != nil {
+		response.Error(ctx, http.StatusInternalServerError, "failed to get venues: "+err.Error())
+		return
+	}
+
+	response.Paginated(ctx, http.StatusOK, "", venues, totalCount, pagination.Page, pagination.Limit)
+}
@@ -0,0 +1,182 @@
+package venue
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/gin-gonic/gin"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s unavailable: %v", name, err)
+	}
+	return loc
+}
+
+func TestDayBoundsAcrossDST(t *testing.T) {
+	london := mustLoadLocation(t, "Europe/London")
+	tests := []struct {
+		name string
+		date time.Time
+		want time.Duration
+	}{
+		{name: "regular day", date: time.Date(2026, 3, 27, 15, 0, 0, 0, london), want: 24 * time.Hour},
+		{name: "clocks go forward", date: time.Date(2026, 3, 29, 15, 0, 0, 0, london), want: 23 * time.Hour},
+		{name: "clocks go back", date: time.Date(2026, 10, 25, 15, 0, 0, 0, london), want: 25 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := dayBounds(tt.date)
+			if start.Hour() != 0 || start.Day() != tt.date.Day() {
+				t.Errorf("start = %v, want local midnight of %v", start, tt.date)
+			}
+			if end.Hour() != 0 || end.Day() != tt.date.AddDate(0, 0, 1).Day() {
+				t.Errorf("end = %v, want local midnight of the next day", end)
+			}
+			if got := end.Sub(start); got != tt.want {
+				t.Errorf("day length = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateAutoTimeSlotsAcrossDST(t *testing.T) {
+	db := newTestDB(t)
+	manager := testutil.CreateUser(t, db, "Manager")
+	venue := createVenue(t, db, manager.ID, "Europe/London")
+	ground := createGround(t, db, venue.ID, "Court 1")
+
+	r := gin.New()
+	r.POST("/venues/:venue_id/timeslots/auto", asUser(manager.ID), newTestController(t, db).GenerateAutoTimeSlots)
+	w := testutil.Request(t, r, http.MethodPost, "/venues/"+itoa(venue.ID)+"/timeslots/auto", AutoTimeSlotInput{
+		GroundIDs:  []uint{ground.ID},
+		StartDate:  "2026-03-28", // Saturday, GMT
+		EndDate:    "2026-03-30", // Monday, BST; the clocks went forward on Sunday
+		StartTime:  "09:00",
+		EndTime:    "10:00",
+		Duration:   60,
+		Price:      10,
+		DaysOfWeek: []string{"saturday", "sunday", "monday"},
+		Equipment:  "[]",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+	}
+
+	var slots []TimeSlot
+	if err := db.Where("venue_id = ?", venue.ID).Order("start_time").Find(&slots).Error; err != nil {
+		t.Fatal(err)
+	}
+	want := []time.Time{
+		time.Date(2026, 3, 28, 9, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 29, 8, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 30, 8, 0, 0, 0, time.UTC),
+	}
+	if len(slots) != len(want) {
+		t.Fatalf("created %d slots, want %d", len(slots), len(want))
+	}
+	for i, slot := range slots {
+		if !slot.StartTime.Equal(want[i]) {
+			t.Errorf("slot %d starts at %v, want %v (09:00 London)", i, slot.StartTime.UTC(), want[i])
+		}
+		if slot.EndTime.Sub(slot.StartTime) != time.Hour {
+			t.Errorf("slot %d lasts %v, want 1h", i, slot.EndTime.Sub(slot.StartTime))
+		}
+	}
+}
+
+func TestGetTimeSlotsByVenueIDDateFilterOnLongDay(t *testing.T) {
+	db := newTestDB(t)
+	london := mustLoadLocation(t, "Europe/London")
+	manager := testutil.CreateUser(t, db, "Manager")
+	venue := createVenue(t, db, manager.ID, "Europe/London")
+	ground := createGround(t, db, venue.ID, "Court 1")
+
+	// 25 October 2026 has 25 hours in London. The last hour of the day starts at 23:00 UTC,
+	// which a 24-hour window from local midnight would cut off.
+	lateSlot := createTimeSlot(t, db, venue.ID, ground.ID,
+		time.Date(2026, 10, 25, 23, 30, 0, 0, london), time.Date(2026, 10, 25, 23, 59, 0, 0, london))
+	createTimeSlot(t, db, venue.ID, ground.ID,
+		time.Date(2026, 10, 26, 0, 30, 0, 0, london), time.Date(2026, 10, 26, 1, 0, 0, 0, london))
+	earlySlot := createTimeSlot(t, db, venue.ID, ground.ID,
+		time.Date(2026, 10, 25, 0, 30, 0, 0, london), time.Date(2026, 10, 25, 1, 0, 0, 0, london))
+
+	slots, err := NewVenueRepository(db).GetTimeSlotsByVenueID(venue.ID, time.Date(2026, 10, 25, 0, 0, 0, 0, london), 0)
+	if err != nil {
+		t.Fatalf("GetTimeSlotsByVenueID() error = %v", err)
+	}
+	if len(slots) != 2 || slots[0].ID != earlySlot.ID || slots[1].ID != lateSlot.ID {
+		ids := make([]uint, len(slots))
+		for i := range slots {
+			ids[i] = slots[i].ID
+		}
+		t.Fatalf("slots = %v, want [%d %d] (both slots of 25 October, none of the 26th)", ids, earlySlot.ID, lateSlot.ID)
+	}
+}
+
+func TestFilterOverlappingTimeSlots(t *testing.T) {
+	db := newTestDB(t)
+	manager := testutil.CreateUser(t, db, "Manager")
+	venue := createVenue(t, db, manager.ID, "UTC")
+	court1 := createGround(t, db, venue.ID, "Court 1")
+	court2 := createGround(t, db, venue.ID, "Court 2")
+
+	at := func(hour, minute int) time.Time { return time.Date(2026, 6, 1, hour, minute, 0, 0, time.UTC) }
+	createTimeSlot(t, db, venue.ID, court1.ID, at(10, 0), at(11, 0))
+
+	candidate := func(groundID uint, start, end time.Time) TimeSlot {
+		return TimeSlot{VenueID: venue.ID, GroundID: groundID, StartTime: start, EndTime: end}
+	}
+	candidates := []TimeSlot{
+		candidate(court1.ID, at(9, 0), at(10, 0)),    // ends as the existing slot starts: kept
+		candidate(court1.ID, at(10, 30), at(11, 30)), // overlaps the existing slot: skipped
+		candidate(court1.ID, at(11, 0), at(12, 0)),   // starts as the existing slot ends: kept
+		candidate(court1.ID, at(11, 30), at(12, 30)), // overlaps the previous candidate: skipped
+		candidate(court2.ID, at(10, 0), at(11, 0)),   // same time on another court: kept
+	}
+
+	kept, skipped, err := NewVenueRepository(db).FilterOverlappingTimeSlots(venue.ID, candidates)
+	if err != nil {
+		t.Fatalf("FilterOverlappingTimeSlots() error = %v", err)
+	}
+	if skipped != 2 {
+		t.Errorf("skipped = %d, want 2", skipped)
+	}
+	want := []TimeSlot{candidates[0], candidates[2], candidates[4]}
+	if len(kept) != len(want) {
+		t.Fatalf("kept %d slots, want %d", len(kept), len(want))
+	}
+	for i := range want {
+		if kept[i].GroundID != want[i].GroundID || !kept[i].StartTime.Equal(want[i].StartTime) {
+			t.Errorf("kept[%d] = ground %d at %v, want ground %d at %v",
+				i, kept[i].GroundID, kept[i].StartTime, want[i].GroundID, want[i].StartTime)
+		}
+	}
+}
+
+func TestFilterOverlappingTimeSlotsAllConflicting(t *testing.T) {
+	db := newTestDB(t)
+	manager := testutil.CreateUser(t, db, "Manager")
+	venue := createVenue(t, db, manager.ID, "UTC")
+	court := createGround(t, db, venue.ID, "Court 1")
+
+	start := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	createTimeSlot(t, db, venue.ID, court.ID, start, start.Add(3*time.Hour))
+
+	candidates := []TimeSlot{
+		{VenueID: venue.ID, GroundID: court.ID, StartTime: start, EndTime: start.Add(time.Hour)},
+		{VenueID: venue.ID, GroundID: court.ID, StartTime: start.Add(2 * time.Hour), EndTime: start.Add(3 * time.Hour)},
+	}
+	kept, skipped, err := NewVenueRepository(db).FilterOverlappingTimeSlots(venue.ID, candidates)
+	if err != nil {
+		t.Fatalf("FilterOverlappingTimeSlots() error = %v", err)
+	}
+	if len(kept) != 0 || skipped != 2 {
+		t.Errorf("kept %d and skipped %d, want 0 and 2", len(kept), skipped)
+	}
+}
@@ -1,45 +1,169 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/DhavalSuthar-24/miow/config"
 	_ "github.com/DhavalSuthar-24/miow/docs"
 	"github.com/DhavalSuthar-24/miow/internal/auth"
+	"github.com/DhavalSuthar-24/miow/internal/match"
+	"github.com/DhavalSuthar-24/miow/internal/notification"
 	"github.com/DhavalSuthar-24/miow/internal/sport"
+	"github.com/DhavalSuthar-24/miow/internal/team"
 	"github.com/DhavalSuthar-24/miow/internal/user"
 	"github.com/DhavalSuthar-24/miow/internal/venue"
 	"github.com/DhavalSuthar-24/miow/routes"
+	"gorm.io/gorm"
 )
 
 // @title MiowNation REST API(-_-)
 // @version 1.0
 // @description This is a  server for Sport_go🏏.
+// @description All timestamps are RFC3339 in UTC (e.g. "2024-05-01T18:30:00Z"); clients should convert them to the user's local time.
 // @host localhost:8088
 // @BasePath /api
 func main() {
+	// Serialize every timestamp (including time.Now() and values read from the database) in UTC
+	time.Local = time.UTC
+
+	// Structured JSON logs; the standard log package is routed through the same handler
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+
 	if err := config.Initialize(); err != nil {
 		log.Fatalf("Failed to initialize application: %v", err)
 	}
 
 	cfg := config.GetConfig()
 
-	err := config.DB.AutoMigrate(
-		&user.User{}, &user.Role{}, &auth.OTP{}, &user.UserRole{},
-		&sport.Sport{}, &sport.UserSport{}, &sport.Skill{},
-		&venue.Venue{}, &venue.Ground{}, &venue.Booking{},
-		&user.RefreshToken{},
-	)
-	if err != nil {
-		log.Fatalf("AutoMigrate failed: %v", err)
+	if err := migrate(config.DB); err != nil {
+		log.Fatal(err)
 	}
 	log.Println("AutoMigrate successful")
 
+	// Cancelled on SIGINT/SIGTERM to stop background workers and drain the HTTP server
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	workers := []<-chan struct{}{
+		match.StartChallengeExpiryWorker(ctx, match.NewGormMatchRepository(config.DB),
+			time.Duration(cfg.Workers.ChallengeExpiryIntervalMinutes)*time.Minute),
+		match.StartMatchReminderWorker(ctx, match.NewGormMatchRepository(config.DB),
+			notification.NewDefaultDispatcher(config.DB, cfg),
+			time.Duration(cfg.Workers.MatchReminderLeadMinutes)*time.Minute,
+			time.Duration(cfg.Workers.MatchReminderIntervalMinutes)*time.Minute),
+		match.StartMatchAutoStartWorker(ctx, match.NewGormMatchRepository(config.DB),
+			time.Duration(cfg.Workers.MatchAutoStartIntervalMinutes)*time.Minute),
+	}
+
 	r := routes.SetupRoutes()
+	srv := &http.Server{
+		Addr:    ":" + cfg.App.Port,
+		Handler: r,
+	}
 
 	// Use port from loaded configuration
+	ln, err := net.Listen("tcp", srv.Addr)
+	if err != nil {
+		log.Fatalf("Failed to run server: %v", err)
+	}
 	log.Printf("Starting server on port %s in %s mode\n", cfg.App.Port, cfg.App.Env)
-	if err := r.Run(":" + cfg.App.Port); err != nil {
+	context.AfterFunc(ctx, stop) // A second signal kills the process without waiting for the drain
+	if err := serve(ctx, srv, ln, time.Duration(cfg.App.ShutdownTimeoutSeconds)*time.Second, workers); err != nil {
 		log.Fatalf("Failed to run server: %v", err)
 	}
+
+	if sqlDB, err := config.DB.DB(); err == nil {
+		if err := sqlDB.Close(); err != nil {
+			log.Printf("Failed to close database connections: %v", err)
+		}
+	}
+	log.Println("Shutdown complete")
+}
+
+// migrate creates or updates the tables of every module and runs the data migrations that go
+// with them. It is safe to run on every startup.
+func migrate(db *gorm.DB) error {
+	err := db.AutoMigrate(
+		&user.User{}, &user.Role{}, &auth.OTP{}, &auth.InviteCode{}, &auth.ImpersonationLog{}, &user.UserRole{},
+		&sport.Sport{}, &sport.UserSport{}, &sport.Skill{}, &sport.SkillEndorsement{},
+		&venue.Venue{}, &venue.Ground{}, &venue.Booking{}, &venue.TimeSlot{}, &venue.VenueClosure{}, &venue.VenueManager{},
+		&team.Team{}, &team.TeamMember{}, &team.TeamInvitation{}, &team.JoinRequest{}, &team.TeamBlock{},
+		&match.Challenge{}, &match.ChallengeCounterOffer{},
+		&match.Match{}, &match.MatchTeam{}, &match.MatchPeriodScore{}, &match.MatchComment{}, &match.MatchOfficial{},
+		&match.MatchLineup{}, &match.MatchPlayer{},
+		&match.Inning{}, &match.BallDelivery{}, &match.FallOfWicket{}, &match.PlayerMatchStat{}, &match.PlayerOverallCricketStat{},
+		&match.Tournament{}, &match.TournamentTeam{},
+		&match.AdminAuditLog{},
+		&user.RefreshToken{},
+		&notification.Notification{},
+	)
+	if err != nil {
+		return fmt.Errorf("AutoMigrate failed: %w", err)
+	}
+	if err := venue.MigrateTimeSlotGrounds(db); err != nil {
+		return fmt.Errorf("time slot ground migration failed: %w", err)
+	}
+	if err := venue.EnsureVenueOwners(db); err != nil {
+		return fmt.Errorf("venue owner migration failed: %w", err)
+	}
+	if err := team.EnsureTeamNameIndex(db); err != nil {
+		return fmt.Errorf("team name index migration failed: %w", err)
+	}
+	if err := team.NormalizePositions(db); err != nil {
+		return fmt.Errorf("team position migration failed: %w", err)
+	}
+	if err := auth.EnsureAuditLogIndexes(db); err != nil {
+		return fmt.Errorf("audit log index migration failed: %w", err)
+	}
+	return nil
+}
+
+// serve runs srv on ln until ctx is cancelled, then stops accepting connections and gives
+// in-flight requests and the background workers, whose channels close once they have stopped,
+// up to timeout to finish. It returns an error only if the server fails to serve.
+func serve(ctx context.Context, srv *http.Server, ln net.Listener, timeout time.Duration, workers []<-chan struct{}) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+	log.Printf("Shutdown signal received, draining in-flight requests (timeout %s)", timeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// New connections are refused straight away; open ones finish their current request
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Server forced to shut down: %v", err)
+	} else {
+		log.Println("HTTP server stopped")
+	}
+	if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	log.Println("Waiting for background workers to stop")
+	for _, done := range workers {
+		select {
+		case <-done:
+		case <-shutdownCtx.Done():
+			log.Println("Background workers did not stop before the shutdown timeout")
+			return nil
+		}
+	}
+	return nil
 }
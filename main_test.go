@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/internal/match"
+	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/routes"
+)
+
+// shutdownServer serves /ok and a /slow endpoint that holds its request until release is
+// closed, announcing on started when it begins
+func shutdownServer(started chan<- struct{}, release <-chan struct{}) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+	return &http.Server{Handler: mux}
+}
+
+func TestServeDrainsOnShutdownSignal(t *testing.T) {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	base := "http://" + ln.Addr().String()
+	client := &http.Client{Timeout: 5 * time.Second, Transport: &http.Transport{DisableKeepAlives: true}}
+
+	started, release := make(chan struct{}), make(chan struct{})
+	worker := make(chan struct{})
+	go func() {
+		<-ctx.Done()
+		close(worker)
+	}()
+	served := make(chan error, 1)
+	go func() {
+		served <- serve(ctx, shutdownServer(started, release), ln, 5*time.Second, []<-chan struct{}{worker})
+	}()
+
+	resp, err := client.Get(base + "/ok")
+	if err != nil {
+		t.Fatalf("request before shutdown failed: %v", err)
+	}
+	resp.Body.Close()
+
+	slow := make(chan error, 1)
+	go func() {
+		resp, err := client.Get(base + "/slow")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				err = errors.New(resp.Status)
+			}
+		}
+		slow <- err
+	}()
+	<-started
+
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatalf("failed to find own process: %v", err)
+	}
+	if err := p.Signal(syscall.SIGTERM); err != nil {
+		t.Fatalf("failed to send SIGTERM: %v", err)
+	}
+
+	// The listener closes as soon as the drain starts
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.DialTimeout("tcp", ln.Addr().String(), time.Second)
+		if err != nil {
+			break
+		}
+		conn.Close()
+		if time.Now().After(deadline) {
+			t.Fatal("server still accepts connections after the shutdown signal")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case err := <-served:
+		t.Fatalf("serve returned (%v) while a request was in flight", err)
+	default:
+	}
+
+	close(release)
+	if err := <-slow; err != nil {
+		t.Errorf("in-flight request failed: %v", err)
+	}
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("serve() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve did not return after the drain")
+	}
+	select {
+	case <-worker:
+	default:
+		t.Error("serve returned before the worker stopped")
+	}
+}
+
+func TestServeGivesUpAfterTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	served := make(chan error, 1)
+	go func() {
+		// The worker never stops
+		served <- serve(ctx, shutdownServer(started, release), ln, 100*time.Millisecond, []<-chan struct{}{make(chan struct{})})
+	}()
+	go func() {
+		client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+		if resp, err := client.Get("http://" + ln.Addr().String() + "/slow"); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+
+	cancel()
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Errorf("serve() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("serve waited past its timeout")
+	}
+}
+
+func TestMigrateCreatesEveryModuleTable(t *testing.T) {
+	db := testutil.DB(t)
+	// Startup migrates on every run, so a second run over the migrated schema must succeed too
+	for run := 1; run <= 2; run++ {
+		if err := migrate(db); err != nil {
+			t.Fatalf("migrate() run %d error = %v", run, err)
+		}
+	}
+
+	tables := []interface{}{
+		&team.Team{}, &team.TeamMember{}, &team.TeamInvitation{}, &team.JoinRequest{}, &team.TeamBlock{},
+		&match.Challenge{}, &match.ChallengeCounterOffer{}, &match.Match{}, &match.MatchTeam{},
+		&match.MatchPeriodScore{}, &match.MatchComment{}, &match.MatchOfficial{}, &match.MatchLineup{},
+		&match.Tournament{}, &match.TournamentTeam{}, &match.AdminAuditLog{},
+	}
+	for _, table := range tables {
+		if !db.Migrator().HasTable(table) {
+			t.Errorf("table for %T was not created", table)
+		}
+	}
+
+	columns := []struct {
+		model interface{}
+		field string
+	}{
+		{&team.Team{}, "Version"},
+		{&team.Team{}, "HomeVenueID"},
+		{&match.Match{}, "Version"},
+		{&match.Match{}, "ReminderSentAt"},
+		{&match.MatchTeam{}, "Score"},
+		{&match.Challenge{}, "Version"},
+		{&match.Tournament{}, "Version"},
+	}
+	for _, c := range columns {
+		if !db.Migrator().HasColumn(c.model, c.field) {
+			t.Errorf("%T has no column for %s", c.model, c.field)
+		}
+	}
+	if !db.Migrator().HasIndex(&team.TeamMember{}, "idx_team_members_team_user") {
+		t.Error("team members have no unique (team_id, user_id) index for AddTeamMember to upsert on")
+	}
+}
+
+func TestFreshDatabaseIsReadyAfterMigrate(t *testing.T) {
+	db := testutil.DB(t)
+	if err := migrate(db); err != nil {
+		t.Fatalf("migrate() error = %v", err)
+	}
+	for _, check := range routes.ReadinessChecks(db) {
+		if err := check.Pinger.Ping(context.Background()); err != nil {
+			t.Errorf("%s readiness check failed on a freshly migrated database: %v", check.Name, err)
+		}
+	}
+}
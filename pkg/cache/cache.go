@@ -0,0 +1,105 @@
+// Package cache provides a small key/value cache used for cache-aside reads of rarely
+// changing records. Cache failures are never fatal: callers treat any error as a miss
+// and fall back to the database.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/config"
+)
+
+// ErrMiss is returned by Get when the key is not cached
+var ErrMiss = errors.New("cache: miss")
+
+// Cache stores raw values by key
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Key builds the cache key for one record of an entity, e.g. Key("team", 7) is "team:7"
+func Key(entity string, id uint) string {
+	return fmt.Sprintf("%s:%d", entity, id)
+}
+
+// GetJSON loads a cached value into dest. It reports false on a miss or any cache error.
+func GetJSON(ctx context.Context, c Cache, key string, dest interface{}) bool {
+	data, err := c.Get(ctx, key)
+	if err != nil {
+		if !errors.Is(err, ErrMiss) {
+			log.Printf("Cache: failed to read %s: %v", key, err)
+		}
+		return false
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		log.Printf("Cache: failed to decode %s: %v", key, err)
+		return false
+	}
+	return true
+}
+
+// SetJSON caches value under key. Errors are logged and otherwise ignored.
+func SetJSON(ctx context.Context, c Cache, key string, value interface{}, ttl time.Duration) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("Cache: failed to encode %s: %v", key, err)
+		return
+	}
+	if err := c.Set(ctx, key, data, ttl); err != nil {
+		log.Printf("Cache: failed to write %s: %v", key, err)
+	}
+}
+
+// Invalidate drops cached keys. Errors are logged and otherwise ignored; entries that could
+// not be dropped expire with their TTL.
+func Invalidate(ctx context.Context, c Cache, keys ...string) {
+	if err := c.Delete(ctx, keys...); err != nil {
+		log.Printf("Cache: failed to invalidate %v: %v", keys, err)
+	}
+}
+
+// NoopCache caches nothing; every read is a miss. It is used when no cache is configured.
+type NoopCache struct{}
+
+func (NoopCache) Get(ctx context.Context, key string) ([]byte, error) { return nil, ErrMiss }
+
+func (NoopCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return nil
+}
+
+func (NoopCache) Delete(ctx context.Context, keys ...string) error { return nil }
+
+var (
+	sharedOnce  sync.Once
+	sharedCache Cache
+)
+
+// Shared returns the process-wide cache built from the configuration: Redis when REDIS_ADDR is
+// set, otherwise a NoopCache. It is created on first use and reused afterwards.
+func Shared(appConfig *config.Config) Cache {
+	sharedOnce.Do(func() {
+		if appConfig == nil || appConfig.Cache.RedisAddr == "" {
+			log.Println("Cache disabled (REDIS_ADDR not set)")
+			sharedCache = NoopCache{}
+			return
+		}
+		sharedCache = NewRedisCache(appConfig.Cache.RedisAddr, appConfig.Cache.RedisPassword, appConfig.Cache.RedisDB)
+	})
+	return sharedCache
+}
+
+// TTL returns the configured cache lifetime for records
+func TTL(appConfig *config.Config) time.Duration {
+	if appConfig == nil {
+		return 0
+	}
+	return time.Duration(appConfig.Cache.TTLSeconds) * time.Second
+}
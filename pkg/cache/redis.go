@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisTimeout bounds each Redis call so an unreachable server only adds a short delay
+// before requests fall back to the database
+const redisTimeout = 200 * time.Millisecond
+
+// RedisCache stores values in Redis
+type RedisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache connects to Redis. An unreachable server is logged, not fatal: the client keeps
+// reconnecting and reads fall back to the database meanwhile.
+func NewRedisCache(addr, password string, db int) *RedisCache {
+	client := redis.NewClient(&redis.Options{
+		Addr:         addr,
+		Password:     password,
+		DB:           db,
+		DialTimeout:  redisTimeout,
+		ReadTimeout:  redisTimeout,
+		WriteTimeout: redisTimeout,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		log.Printf("WARNING: Redis at %s is unavailable, reads will go to the database: %v", addr, err)
+	} else {
+		log.Printf("Cache connected to Redis at %s", addr)
+	}
+	return &RedisCache{client: client}
+}
+
+func (r *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := r.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrMiss
+	}
+	return data, err
+}
+
+func (r *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return r.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (r *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return r.client.Del(ctx, keys...).Err()
+}
+
+// Client exposes the underlying Redis client for features that need more than key/value
+// access, such as rate limiting
+func (r *RedisCache) Client() *redis.Client {
+	return r.client
+}
@@ -0,0 +1,358 @@
+package i18n
+
+// catalog holds the translated message templates per language. Templates use
+// fmt verbs for dynamic values. English is the reference language; any key
+// missing from another language falls back to it.
+var catalog = map[string]map[string]string{
+	"en": {
+		CommonInvalidInput:           "Invalid input: %s",
+		CommonValidationFailed:       "Validation failed. Please check your input.",
+		CommonDatabaseError:          "Database error: %s",
+		CommonUnauthorized:           "Unauthorized",
+		CommonUnauthorizedDetail:     "Unauthorized: %s",
+		AuthEmailExists:              "User with this email already exists",
+		AuthPhoneExists:              "User with this phone number already exists",
+		AuthUsernameExists:           "User with this username already exists",
+		AuthRegistrationClosed:       "Registration is currently closed",
+		AuthInviteRequired:           "Registration is invite-only. An invite code is required",
+		AuthInviteInvalid:            "Invalid, expired or already used invite code",
+		AuthRoleNotFound:             "role %q does not exist",
+		AuthRoleLookupFailed:         "Role lookup failed",
+		AuthHashFailed:               "Error hashing password",
+		AuthHashNewFailed:            "Failed to hash new password.",
+		AuthUserCreationFailed:       "User creation failed: %s",
+		AuthCreateUserFailed:         "Failed to create user: %s",
+		AuthIdentifierRequired:       "At least one of username, email or phone is required",
+		AuthAvailabilityFailed:       "Failed to check availability",
+		AuthUserNotFound:             "User not found.",
+		AuthRetrieveUserFailed:       "Failed to retrieve user: %s",
+		AuthRetrieveProfileFailed:    "Failed to retrieve profile: %s",
+		AuthInvalidCredentials:       "Invalid credentials",
+		AuthInvalidRefreshToken:      "Invalid or expired refresh token",
+		AuthAccessTokenFailed:        "New access token generation failed",
+		AuthUsernameTaken:            "Username already taken.",
+		AuthUpdateProfileFailed:      "Could not update profile: %s",
+		AuthImageRequired:            "Image file is required: %s",
+		AuthUploadDirFailed:          "Could not create upload directory: %s",
+		AuthSaveImageFailed:          "Failed to save uploaded image: %s",
+		AuthSaveImagePathFailed:      "Failed to save profile image path to database: %s",
+		AuthProfileImageUpdated:      "Profile image updated successfully",
+		AuthIncorrectOldPassword:     "Incorrect old password.",
+		AuthSamePassword:             "New password cannot be the same as the old password.",
+		AuthChangePasswordFailed:     "Failed to change password: %s",
+		AuthPasswordChanged:          "Password changed successfully.",
+		AuthInvalidateTokenFailed:    "Failed to invalidate refresh token: %s",
+		AuthInvalidateSessionsFailed: "Failed to invalidate all sessions: %s",
+		AuthLoggedOut:                "Logged out successfully",
+		AuthOTPCooldown:              "Too many OTP requests. Please try again in %.0f minute(s).",
+		AuthOTPRecentlySent:          "An OTP was recently sent. Please wait a moment before requesting a new one.",
+		AuthSaveOTPFailed:            "Failed to save OTP: %s",
+		AuthOTPSendFailed:            "Failed to send OTP. Please try again.",
+		AuthOTPSent:                  "OTP sent successfully.",
+		AuthInvalidOTP:               "Invalid, expired, or already used OTP.",
+		AuthUpdateOTPFailed:          "Failed to update OTP status: %s",
+		AuthPhoneNotFound:            "User with this phone number not found",
+		AuthUpdateUserFailed:         "Failed to update user: %s",
+		AuthUserNotVerified:          "User account is not verified.",
+		AuthEmailNotFound:            "User with this email not found.",
+		AuthSaveResetTokenFailed:     "Failed to save reset token: %s",
+		AuthResetInstructionsSent:    "If an account with that email exists, password reset instructions have been sent.",
+		AuthInvalidResetToken:        "Invalid or expired password reset token.",
+		AuthUpdatePasswordFailed:     "Failed to update password: %s",
+		AuthPasswordReset:            "Password has been reset successfully.",
+		AuthVerifyTokenRequired:      "Verification token is required.",
+		AuthInvalidVerifyToken:       "Invalid or expired email verification token.",
+		AuthVerifyTokenExpired:       "This verification link has expired. Please request a new one via /auth/resend-verification.",
+		AuthEmailAlreadyVerified:     "Email is already verified.",
+		AuthUpdateEmailStatusFailed:  "Failed to update email verification status: %s",
+		AuthEmailVerified:            "Email verified successfully.",
+		AuthUpdateVerifyTokenFailed:  "Failed to update verification token: %s",
+		AuthVerificationEmailFailed:  "Failed to send verification email. Please try again later.",
+		AuthVerificationResent:       "Verification email has been resent.",
+		AuthInvalidUserID:            "Invalid user ID",
+		AuthImpersonateSelf:          "You cannot impersonate yourself",
+		AuthImpersonateAdmin:         "Administrators cannot be impersonated",
+		AuthImpersonationFailed:      "Failed to issue impersonation token",
+		AuthUserIDsRequired:          "Provide the user IDs as a comma-separated ids parameter",
+		AuthTooManyUserIDs:           "At most %d users can be requested at once",
+		AuthRetrieveUsersFailed:      "Failed to retrieve users: %s",
+		AuthSearchQueryTooShort:      "Search term must be at least %d characters",
+		AuthUnknownSports:            "Unknown preferred sports: %s",
+		AuthSportLookupFailed:        "Failed to validate preferred sports",
+		BookingEndBeforeStart:        "End time must be after start time",
+		BookingInPast:                "Cannot create bookings in the past",
+		BookingGroundNotFound:        "Ground not found",
+		BookingUnauthorized:          "Unauthorized access",
+		BookingAvailabilityFailed:    "Failed to check availability: %s",
+		BookingNoMatchingSlot:        "No matching time slot found for the requested time range",
+		BookingSlotTaken:             "Time slot is already booked",
+		BookingVenueClosed:           "The venue is closed for the requested time",
+		BookingWarnShortNotice:       "booking starts in less than %d minutes and may not be confirmed in time",
+		BookingWarnOverlap:           "you already have %d booking(s) overlapping this time",
+		BookingExistingCheckFailed:   "Failed to check existing bookings: %s",
+		BookingStrictRejected:        "Booking rejected in strict mode",
+		BookingCreateFailed:          "Failed to create booking: %s",
+		BookingCreated:               "Booking created successfully",
+		BookingFetchFailed:           "Failed to fetch bookings: %s",
+		BookingInvalidID:             "Invalid booking ID format",
+		BookingNotFound:              "Booking not found",
+		BookingAccessCheckFailed:     "Failed to verify access permission",
+		BookingNoViewPermission:      "You don't have permission to view this booking",
+		BookingInvalidExpand:         "Unknown expand value %q (use court, venue)",
+		BookingNoCancelPermission:    "You don't have permission to cancel this booking",
+		BookingAlreadyCancelled:      "Booking is already cancelled",
+		BookingCannotCancelCompleted: "Cannot cancel a completed booking",
+		BookingCancelTooLate:         "Bookings must be cancelled at least 24 hours in advance. Current time until booking: %.1f hours",
+		BookingCancelFailed:          "Failed to cancel booking: %s",
+		BookingCancelled:             "Booking cancelled successfully",
+		BookingInvalidVenueID:        "Invalid venue ID format",
+		BookingVenueNotFound:         "Venue not found",
+		BookingNoVenueViewPermission: "You don't have permission to view bookings for this venue",
+		BookingInvalidStatus:         "Invalid status filter",
+		BookingInvalidDate:           "Invalid date format. Use YYYY-MM-DD",
+		BookingInvalidDateRange:      "The from date must not be after the to date",
+		BookingInvalidCourtID:        "Invalid court ID format",
+		BookingInvalidExportFormat:   "Unsupported export format %q (use csv)",
+		BookingOwnershipCheckFailed:  "Failed to verify venue ownership",
+		BookingNoUpdatePermission:    "You don't have permission to update this booking",
+		BookingStatusCancelledLocked: "Cannot change status of a cancelled booking",
+		BookingStatusCompletedLocked: "Cannot change status of a completed booking",
+		BookingUseCancelEndpoint:     "To cancel a booking, use the cancel booking endpoint",
+		BookingStatusUpdateFailed:    "Failed to update booking status: %s",
+		BookingStatusUpdated:         "Booking status updated successfully",
+	},
+	"es": {
+		CommonInvalidInput:           "Entrada no válida: %s",
+		CommonValidationFailed:       "La validación falló. Revisa los datos enviados.",
+		CommonDatabaseError:          "Error de base de datos: %s",
+		CommonUnauthorized:           "No autorizado",
+		CommonUnauthorizedDetail:     "No autorizado: %s",
+		AuthEmailExists:              "Ya existe un usuario con este correo electrónico",
+		AuthPhoneExists:              "Ya existe un usuario con este número de teléfono",
+		AuthUsernameExists:           "Ya existe un usuario con este nombre de usuario",
+		AuthRegistrationClosed:       "El registro está cerrado actualmente",
+		AuthInviteRequired:           "El registro es solo por invitación. Se requiere un código de invitación",
+		AuthInviteInvalid:            "Código de invitación no válido, caducado o ya utilizado",
+		AuthRoleNotFound:             "el rol %q no existe",
+		AuthRoleLookupFailed:         "Error al buscar el rol",
+		AuthHashFailed:               "Error al cifrar la contraseña",
+		AuthHashNewFailed:            "No se pudo cifrar la nueva contraseña.",
+		AuthUserCreationFailed:       "No se pudo crear el usuario: %s",
+		AuthCreateUserFailed:         "No se pudo crear el usuario: %s",
+		AuthIdentifierRequired:       "Se requiere al menos un nombre de usuario, correo electrónico o teléfono",
+		AuthAvailabilityFailed:       "No se pudo comprobar la disponibilidad",
+		AuthUserNotFound:             "Usuario no encontrado.",
+		AuthRetrieveUserFailed:       "No se pudo obtener el usuario: %s",
+		AuthRetrieveProfileFailed:    "No se pudo obtener el perfil: %s",
+		AuthInvalidCredentials:       "Credenciales no válidas",
+		AuthInvalidRefreshToken:      "Token de actualización no válido o caducado",
+		AuthAccessTokenFailed:        "No se pudo generar un nuevo token de acceso",
+		AuthUsernameTaken:            "El nombre de usuario ya está en uso.",
+		AuthUpdateProfileFailed:      "No se pudo actualizar el perfil: %s",
+		AuthImageRequired:            "Se requiere un archivo de imagen: %s",
+		AuthUploadDirFailed:          "No se pudo crear el directorio de subida: %s",
+		AuthSaveImageFailed:          "No se pudo guardar la imagen subida: %s",
+		AuthSaveImagePathFailed:      "No se pudo guardar la ruta de la imagen de perfil: %s",
+		AuthProfileImageUpdated:      "Imagen de perfil actualizada correctamente",
+		AuthIncorrectOldPassword:     "La contraseña anterior es incorrecta.",
+		AuthSamePassword:             "La nueva contraseña no puede ser igual a la anterior.",
+		AuthChangePasswordFailed:     "No se pudo cambiar la contraseña: %s",
+		AuthPasswordChanged:          "Contraseña cambiada correctamente.",
+		AuthInvalidateTokenFailed:    "No se pudo invalidar el token de actualización: %s",
+		AuthInvalidateSessionsFailed: "No se pudieron cerrar todas las sesiones: %s",
+		AuthLoggedOut:                "Sesión cerrada correctamente",
+		AuthOTPCooldown:              "Demasiadas solicitudes de OTP. Inténtelo de nuevo en %.0f minuto(s).",
+		AuthOTPRecentlySent:          "Se envió un OTP recientemente. Espere un momento antes de solicitar uno nuevo.",
+		AuthSaveOTPFailed:            "No se pudo guardar el OTP: %s",
+		AuthOTPSendFailed:            "No se pudo enviar el OTP. Inténtelo de nuevo.",
+		AuthOTPSent:                  "OTP enviado correctamente.",
+		AuthInvalidOTP:               "OTP no válido, caducado o ya utilizado.",
+		AuthUpdateOTPFailed:          "No se pudo actualizar el estado del OTP: %s",
+		AuthPhoneNotFound:            "No se encontró ningún usuario con este número de teléfono",
+		AuthUpdateUserFailed:         "No se pudo actualizar el usuario: %s",
+		AuthUserNotVerified:          "La cuenta de usuario no está verificada.",
+		AuthEmailNotFound:            "No se encontró ningún usuario con este correo electrónico.",
+		AuthSaveResetTokenFailed:     "No se pudo guardar el token de restablecimiento: %s",
+		AuthResetInstructionsSent:    "Si existe una cuenta con ese correo, se han enviado las instrucciones para restablecer la contraseña.",
+		AuthInvalidResetToken:        "Token de restablecimiento no válido o caducado.",
+		AuthUpdatePasswordFailed:     "No se pudo actualizar la contraseña: %s",
+		AuthPasswordReset:            "La contraseña se restableció correctamente.",
+		AuthVerifyTokenRequired:      "Se requiere el token de verificación.",
+		AuthInvalidVerifyToken:       "Token de verificación de correo no válido o caducado.",
+		AuthVerifyTokenExpired:       "Este enlace de verificación ha caducado. Solicite uno nuevo mediante /auth/resend-verification.",
+		AuthEmailAlreadyVerified:     "El correo electrónico ya está verificado.",
+		AuthUpdateEmailStatusFailed:  "No se pudo actualizar el estado de verificación del correo: %s",
+		AuthEmailVerified:            "Correo electrónico verificado correctamente.",
+		AuthUpdateVerifyTokenFailed:  "No se pudo actualizar el token de verificación: %s",
+		AuthVerificationEmailFailed:  "No se pudo enviar el correo de verificación. Inténtelo más tarde.",
+		AuthVerificationResent:       "Se ha reenviado el correo de verificación.",
+		AuthInvalidUserID:            "ID de usuario no válido",
+		AuthImpersonateSelf:          "No puede suplantarse a sí mismo",
+		AuthImpersonateAdmin:         "No se puede suplantar a los administradores",
+		AuthImpersonationFailed:      "No se pudo emitir el token de suplantación",
+		AuthUserIDsRequired:          "Indica los IDs de usuario separados por comas en el parámetro ids",
+		AuthTooManyUserIDs:           "Se pueden solicitar como máximo %d usuarios a la vez",
+		AuthRetrieveUsersFailed:      "No se pudieron obtener los usuarios: %s",
+		AuthSearchQueryTooShort:      "El término de búsqueda debe tener al menos %d caracteres",
+		AuthUnknownSports:            "Deportes preferidos desconocidos: %s",
+		AuthSportLookupFailed:        "No se pudieron validar los deportes preferidos",
+		BookingEndBeforeStart:        "La hora de fin debe ser posterior a la hora de inicio",
+		BookingInPast:                "No se pueden crear reservas en el pasado",
+		BookingGroundNotFound:        "Cancha no encontrada",
+		BookingUnauthorized:          "Acceso no autorizado",
+		BookingAvailabilityFailed:    "No se pudo comprobar la disponibilidad: %s",
+		BookingNoMatchingSlot:        "No se encontró ningún horario para el intervalo solicitado",
+		BookingSlotTaken:             "El horario ya está reservado",
+		BookingVenueClosed:           "El recinto está cerrado en el horario solicitado",
+		BookingWarnShortNotice:       "la reserva empieza en menos de %d minutos y puede que no se confirme a tiempo",
+		BookingWarnOverlap:           "ya tiene %d reserva(s) que coinciden con este horario",
+		BookingExistingCheckFailed:   "No se pudieron comprobar las reservas existentes: %s",
+		BookingStrictRejected:        "Reserva rechazada en modo estricto",
+		BookingCreateFailed:          "No se pudo crear la reserva: %s",
+		BookingCreated:               "Reserva creada correctamente",
+		BookingFetchFailed:           "No se pudieron obtener las reservas: %s",
+		BookingInvalidID:             "Formato de ID de reserva no válido",
+		BookingNotFound:              "Reserva no encontrada",
+		BookingAccessCheckFailed:     "No se pudo verificar el permiso de acceso",
+		BookingNoViewPermission:      "No tiene permiso para ver esta reserva",
+		BookingInvalidExpand:         "Valor de expand desconocido %q (use court, venue)",
+		BookingNoCancelPermission:    "No tiene permiso para cancelar esta reserva",
+		BookingAlreadyCancelled:      "La reserva ya está cancelada",
+		BookingCannotCancelCompleted: "No se puede cancelar una reserva completada",
+		BookingCancelTooLate:         "Las reservas deben cancelarse con al menos 24 horas de antelación. Tiempo restante hasta la reserva: %.1f horas",
+		BookingCancelFailed:          "No se pudo cancelar la reserva: %s",
+		BookingCancelled:             "Reserva cancelada correctamente",
+		BookingInvalidVenueID:        "Formato de ID de sede no válido",
+		BookingVenueNotFound:         "Sede no encontrada",
+		BookingNoVenueViewPermission: "No tiene permiso para ver las reservas de esta sede",
+		BookingInvalidStatus:         "Filtro de estado no válido",
+		BookingInvalidDate:           "Formato de fecha no válido. Use AAAA-MM-DD",
+		BookingInvalidDateRange:      "La fecha inicial no puede ser posterior a la fecha final",
+		BookingInvalidCourtID:        "Formato de ID de cancha no válido",
+		BookingInvalidExportFormat:   "Formato de exportación no admitido %q (use csv)",
+		BookingOwnershipCheckFailed:  "No se pudo verificar la propiedad de la sede",
+		BookingNoUpdatePermission:    "No tiene permiso para actualizar esta reserva",
+		BookingStatusCancelledLocked: "No se puede cambiar el estado de una reserva cancelada",
+		BookingStatusCompletedLocked: "No se puede cambiar el estado de una reserva completada",
+		BookingUseCancelEndpoint:     "Para cancelar una reserva, use el endpoint de cancelación",
+		BookingStatusUpdateFailed:    "No se pudo actualizar el estado de la reserva: %s",
+		BookingStatusUpdated:         "Estado de la reserva actualizado correctamente",
+	},
+	"hi": {
+		CommonInvalidInput:           "अमान्य इनपुट: %s",
+		CommonValidationFailed:       "सत्यापन विफल रहा। कृपया अपना इनपुट जांचें।",
+		CommonDatabaseError:          "डेटाबेस त्रुटि: %s",
+		CommonUnauthorized:           "अनधिकृत",
+		CommonUnauthorizedDetail:     "अनधिकृत: %s",
+		AuthEmailExists:              "इस ईमेल वाला उपयोगकर्ता पहले से मौजूद है",
+		AuthPhoneExists:              "इस फ़ोन नंबर वाला उपयोगकर्ता पहले से मौजूद है",
+		AuthUsernameExists:           "इस उपयोगकर्ता नाम वाला उपयोगकर्ता पहले से मौजूद है",
+		AuthRegistrationClosed:       "पंजीकरण अभी बंद है",
+		AuthInviteRequired:           "पंजीकरण केवल आमंत्रण द्वारा है। आमंत्रण कोड आवश्यक है",
+		AuthInviteInvalid:            "आमंत्रण कोड अमान्य, समाप्त या पहले ही उपयोग किया जा चुका है",
+		AuthRoleNotFound:             "भूमिका %q मौजूद नहीं है",
+		AuthRoleLookupFailed:         "भूमिका खोजने में विफल",
+		AuthHashFailed:               "पासवर्ड हैश करने में त्रुटि",
+		AuthHashNewFailed:            "नया पासवर्ड हैश करने में विफल।",
+		AuthUserCreationFailed:       "उपयोगकर्ता बनाने में विफल: %s",
+		AuthCreateUserFailed:         "उपयोगकर्ता बनाने में विफल: %s",
+		AuthIdentifierRequired:       "उपयोगकर्ता नाम, ईमेल या फ़ोन में से कम से कम एक आवश्यक है",
+		AuthAvailabilityFailed:       "उपलब्धता जाँचने में विफल",
+		AuthUserNotFound:             "उपयोगकर्ता नहीं मिला।",
+		AuthRetrieveUserFailed:       "उपयोगकर्ता प्राप्त करने में विफल: %s",
+		AuthRetrieveProfileFailed:    "प्रोफ़ाइल प्राप्त करने में विफल: %s",
+		AuthInvalidCredentials:       "अमान्य क्रेडेंशियल",
+		AuthInvalidRefreshToken:      "रीफ़्रेश टोकन अमान्य या समाप्त हो चुका है",
+		AuthAccessTokenFailed:        "नया एक्सेस टोकन बनाने में विफल",
+		AuthUsernameTaken:            "यह उपयोगकर्ता नाम पहले से लिया जा चुका है।",
+		AuthUpdateProfileFailed:      "प्रोफ़ाइल अपडेट नहीं हो सकी: %s",
+		AuthImageRequired:            "इमेज फ़ाइल आवश्यक है: %s",
+		AuthUploadDirFailed:          "अपलोड डायरेक्टरी नहीं बन सकी: %s",
+		AuthSaveImageFailed:          "अपलोड की गई इमेज सहेजने में विफल: %s",
+		AuthSaveImagePathFailed:      "प्रोफ़ाइल इमेज का पथ सहेजने में विफल: %s",
+		AuthProfileImageUpdated:      "प्रोफ़ाइल इमेज सफलतापूर्वक अपडेट हो गई",
+		AuthIncorrectOldPassword:     "पुराना पासवर्ड गलत है।",
+		AuthSamePassword:             "नया पासवर्ड पुराने पासवर्ड के समान नहीं हो सकता।",
+		AuthChangePasswordFailed:     "पासवर्ड बदलने में विफल: %s",
+		AuthPasswordChanged:          "पासवर्ड सफलतापूर्वक बदल दिया गया।",
+		AuthInvalidateTokenFailed:    "रीफ़्रेश टोकन अमान्य करने में विफल: %s",
+		AuthInvalidateSessionsFailed: "सभी सत्र समाप्त करने में विफल: %s",
+		AuthLoggedOut:                "सफलतापूर्वक लॉग आउट हो गए",
+		AuthOTPCooldown:              "बहुत अधिक OTP अनुरोध। कृपया %.0f मिनट बाद पुनः प्रयास करें।",
+		AuthOTPRecentlySent:          "हाल ही में एक OTP भेजा गया था। नया अनुरोध करने से पहले कृपया थोड़ा इंतज़ार करें।",
+		AuthSaveOTPFailed:            "OTP सहेजने में विफल: %s",
+		AuthOTPSendFailed:            "OTP भेजने में विफल। कृपया पुनः प्रयास करें।",
+		AuthOTPSent:                  "OTP सफलतापूर्वक भेजा गया।",
+		AuthInvalidOTP:               "OTP अमान्य, समाप्त या पहले ही उपयोग किया जा चुका है।",
+		AuthUpdateOTPFailed:          "OTP स्थिति अपडेट करने में विफल: %s",
+		AuthPhoneNotFound:            "इस फ़ोन नंबर वाला उपयोगकर्ता नहीं मिला",
+		AuthUpdateUserFailed:         "उपयोगकर्ता अपडेट करने में विफल: %s",
+		AuthUserNotVerified:          "उपयोगकर्ता खाता सत्यापित नहीं है।",
+		AuthEmailNotFound:            "इस ईमेल वाला उपयोगकर्ता नहीं मिला।",
+		AuthSaveResetTokenFailed:     "रीसेट टोकन सहेजने में विफल: %s",
+		AuthResetInstructionsSent:    "यदि उस ईमेल से कोई खाता मौजूद है, तो पासवर्ड रीसेट निर्देश भेज दिए गए हैं।",
+		AuthInvalidResetToken:        "पासवर्ड रीसेट टोकन अमान्य या समाप्त हो चुका है।",
+		AuthUpdatePasswordFailed:     "पासवर्ड अपडेट करने में विफल: %s",
+		AuthPasswordReset:            "पासवर्ड सफलतापूर्वक रीसेट हो गया है।",
+		AuthVerifyTokenRequired:      "सत्यापन टोकन आवश्यक है।",
+		AuthInvalidVerifyToken:       "ईमेल सत्यापन टोकन अमान्य या समाप्त हो चुका है।",
+		AuthVerifyTokenExpired:       "यह सत्यापन लिंक समाप्त हो चुका है। कृपया /auth/resend-verification के माध्यम से नया लिंक मंगाएँ।",
+		AuthEmailAlreadyVerified:     "ईमेल पहले से सत्यापित है।",
+		AuthUpdateEmailStatusFailed:  "ईमेल सत्यापन स्थिति अपडेट करने में विफल: %s",
+		AuthEmailVerified:            "ईमेल सफलतापूर्वक सत्यापित हो गया।",
+		AuthUpdateVerifyTokenFailed:  "सत्यापन टोकन अपडेट करने में विफल: %s",
+		AuthVerificationEmailFailed:  "सत्यापन ईमेल भेजने में विफल। कृपया बाद में पुनः प्रयास करें।",
+		AuthVerificationResent:       "सत्यापन ईमेल फिर से भेज दिया गया है।",
+		AuthInvalidUserID:            "अमान्य उपयोगकर्ता ID",
+		AuthImpersonateSelf:          "आप स्वयं का प्रतिरूपण नहीं कर सकते",
+		AuthImpersonateAdmin:         "व्यवस्थापकों का प्रतिरूपण नहीं किया जा सकता",
+		AuthImpersonationFailed:      "प्रतिरूपण टोकन जारी करने में विफल",
+		AuthUserIDsRequired:          "उपयोगकर्ता ID को ids पैरामीटर में अल्पविराम से अलग करके दें",
+		AuthTooManyUserIDs:           "एक बार में अधिकतम %d उपयोगकर्ताओं का अनुरोध किया जा सकता है",
+		AuthRetrieveUsersFailed:      "उपयोगकर्ताओं को प्राप्त करने में विफल: %s",
+		AuthSearchQueryTooShort:      "खोज शब्द कम से कम %d अक्षरों का होना चाहिए",
+		AuthUnknownSports:            "अज्ञात पसंदीदा खेल: %s",
+		AuthSportLookupFailed:        "पसंदीदा खेलों को सत्यापित करने में विफल",
+		BookingEndBeforeStart:        "समाप्ति समय प्रारंभ समय के बाद होना चाहिए",
+		BookingInPast:                "पिछली तारीख़ के लिए बुकिंग नहीं की जा सकती",
+		BookingGroundNotFound:        "ग्राउंड नहीं मिला",
+		BookingUnauthorized:          "अनधिकृत पहुँच",
+		BookingAvailabilityFailed:    "उपलब्धता जाँचने में विफल: %s",
+		BookingNoMatchingSlot:        "अनुरोधित समय के लिए कोई मेल खाता टाइम स्लॉट नहीं मिला",
+		BookingSlotTaken:             "यह टाइम स्लॉट पहले से बुक है",
+		BookingVenueClosed:           "अनुरोधित समय पर वेन्यू बंद है",
+		BookingWarnShortNotice:       "बुकिंग %d मिनट से कम समय में शुरू होगी और शायद समय पर पुष्टि न हो",
+		BookingWarnOverlap:           "इस समय से टकराने वाली आपकी %d बुकिंग पहले से हैं",
+		BookingExistingCheckFailed:   "मौजूदा बुकिंग जाँचने में विफल: %s",
+		BookingStrictRejected:        "स्ट्रिक्ट मोड में बुकिंग अस्वीकार की गई",
+		BookingCreateFailed:          "बुकिंग बनाने में विफल: %s",
+		BookingCreated:               "बुकिंग सफलतापूर्वक बनाई गई",
+		BookingFetchFailed:           "बुकिंग प्राप्त करने में विफल: %s",
+		BookingInvalidID:             "बुकिंग ID का प्रारूप अमान्य है",
+		BookingNotFound:              "बुकिंग नहीं मिली",
+		BookingAccessCheckFailed:     "पहुँच अनुमति सत्यापित करने में विफल",
+		BookingNoViewPermission:      "आपको यह बुकिंग देखने की अनुमति नहीं है",
+		BookingInvalidExpand:         "अज्ञात expand मान %q (court, venue का उपयोग करें)",
+		BookingNoCancelPermission:    "आपको यह बुकिंग रद्द करने की अनुमति नहीं है",
+		BookingAlreadyCancelled:      "बुकिंग पहले ही रद्द की जा चुकी है",
+		BookingCannotCancelCompleted: "पूरी हो चुकी बुकिंग रद्द नहीं की जा सकती",
+		BookingCancelTooLate:         "बुकिंग कम से कम 24 घंटे पहले रद्द की जानी चाहिए। बुकिंग में शेष समय: %.1f घंटे",
+		BookingCancelFailed:          "बुकिंग रद्द करने में विफल: %s",
+		BookingCancelled:             "बुकिंग सफलतापूर्वक रद्द की गई",
+		BookingInvalidVenueID:        "वेन्यू ID का प्रारूप अमान्य है",
+		BookingVenueNotFound:         "वेन्यू नहीं मिला",
+		BookingNoVenueViewPermission: "आपको इस वेन्यू की बुकिंग देखने की अनुमति नहीं है",
+		BookingInvalidStatus:         "स्थिति फ़िल्टर अमान्य है",
+		BookingInvalidDate:           "तारीख़ का प्रारूप अमान्य है। YYYY-MM-DD का उपयोग करें",
+		BookingInvalidDateRange:      "आरंभ तिथि अंतिम तिथि के बाद नहीं हो सकती",
+		BookingInvalidCourtID:        "कोर्ट ID का प्रारूप अमान्य है",
+		BookingInvalidExportFormat:   "असमर्थित निर्यात प्रारूप %q (csv का उपयोग करें)",
+		BookingOwnershipCheckFailed:  "वेन्यू स्वामित्व सत्यापित करने में विफल",
+		BookingNoUpdatePermission:    "आपको यह बुकिंग अपडेट करने की अनुमति नहीं है",
+		BookingStatusCancelledLocked: "रद्द की गई बुकिंग की स्थिति नहीं बदली जा सकती",
+		BookingStatusCompletedLocked: "पूरी हो चुकी बुकिंग की स्थिति नहीं बदली जा सकती",
+		BookingUseCancelEndpoint:     "बुकिंग रद्द करने के लिए, रद्द करने वाले endpoint का उपयोग करें",
+		BookingStatusUpdateFailed:    "बुकिंग स्थिति अपडेट करने में विफल: %s",
+		BookingStatusUpdated:         "बुकिंग स्थिति सफलतापूर्वक अपडेट हो गई",
+	},
+}
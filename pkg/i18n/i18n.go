@@ -0,0 +1,105 @@
+// Package i18n resolves user-facing response messages into the language
+// requested by the client through the Accept-Language header.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultLanguage is used when the client does not ask for a supported language.
+const DefaultLanguage = "en"
+
+// langContextKey caches the negotiated language on the gin context.
+const langContextKey = "i18n_lang"
+
+// Supported reports whether a catalog exists for the given language tag.
+func Supported(lang string) bool {
+	_, ok := catalog[lang]
+	return ok
+}
+
+// Lang returns the best supported language for the request, honouring the
+// quality values of the Accept-Language header. Region subtags are ignored, so
+// "es-MX" resolves to "es".
+func Lang(c *gin.Context) string {
+	if c == nil {
+		return DefaultLanguage
+	}
+	if lang := c.GetString(langContextKey); lang != "" {
+		return lang
+	}
+	lang := negotiate(c.GetHeader("Accept-Language"))
+	c.Set(langContextKey, lang)
+	return lang
+}
+
+// T translates the message key into the request's language, formatting it
+// with args when given. Unknown keys are returned unchanged, which lets
+// callers pass through plain strings that have no catalog entry yet.
+func T(c *gin.Context, key string, args ...interface{}) string {
+	return Translate(Lang(c), key, args...)
+}
+
+// Translate resolves a key for an explicit language, falling back to English.
+func Translate(lang, key string, args ...interface{}) string {
+	msg, ok := catalog[lang][key]
+	if !ok {
+		if msg, ok = catalog[DefaultLanguage][key]; !ok {
+			msg = key
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+type weightedLang struct {
+	tag string
+	q   float64
+}
+
+func negotiate(header string) string {
+	if header == "" {
+		return DefaultLanguage
+	}
+
+	var langs []weightedLang
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		langs = append(langs, weightedLang{tag: tag, q: q})
+	}
+	sort.SliceStable(langs, func(i, j int) bool { return langs[i].q > langs[j].q })
+
+	for _, l := range langs {
+		if l.tag == "*" {
+			return DefaultLanguage
+		}
+		base := strings.SplitN(l.tag, "-", 2)[0]
+		if Supported(base) {
+			return base
+		}
+	}
+	return DefaultLanguage
+}
@@ -0,0 +1,124 @@
+package i18n
+
+// Message keys. Keys are grouped by feature and resolved against the catalog
+// for the language negotiated from the request's Accept-Language header.
+const (
+	// Auth
+	CommonInvalidInput           = "common.invalid_input"
+	CommonValidationFailed       = "common.validation_failed"
+	CommonDatabaseError          = "common.database_error"
+	CommonUnauthorized           = "common.unauthorized"
+	CommonUnauthorizedDetail     = "common.unauthorized_detail"
+	AuthEmailExists              = "auth.email_exists"
+	AuthPhoneExists              = "auth.phone_exists"
+	AuthUsernameExists           = "auth.username_exists"
+	AuthRegistrationClosed       = "auth.registration_closed"
+	AuthInviteRequired           = "auth.invite_required"
+	AuthInviteInvalid            = "auth.invite_invalid"
+	AuthRoleNotFound             = "auth.role_not_found"
+	AuthRoleLookupFailed         = "auth.role_lookup_failed"
+	AuthHashFailed               = "auth.hash_failed"
+	AuthHashNewFailed            = "auth.hash_new_failed"
+	AuthUserCreationFailed       = "auth.user_creation_failed"
+	AuthCreateUserFailed         = "auth.create_user_failed"
+	AuthIdentifierRequired       = "auth.identifier_required"
+	AuthAvailabilityFailed       = "auth.availability_failed"
+	AuthUserNotFound             = "auth.user_not_found"
+	AuthRetrieveUserFailed       = "auth.retrieve_user_failed"
+	AuthRetrieveProfileFailed    = "auth.retrieve_profile_failed"
+	AuthInvalidCredentials       = "auth.invalid_credentials"
+	AuthInvalidRefreshToken      = "auth.invalid_refresh_token"
+	AuthAccessTokenFailed        = "auth.access_token_failed"
+	AuthUsernameTaken            = "auth.username_taken"
+	AuthUpdateProfileFailed      = "auth.update_profile_failed"
+	AuthImageRequired            = "auth.image_required"
+	AuthUploadDirFailed          = "auth.upload_dir_failed"
+	AuthSaveImageFailed          = "auth.save_image_failed"
+	AuthSaveImagePathFailed      = "auth.save_image_path_failed"
+	AuthProfileImageUpdated      = "auth.profile_image_updated"
+	AuthIncorrectOldPassword     = "auth.incorrect_old_password"
+	AuthSamePassword             = "auth.same_password"
+	AuthChangePasswordFailed     = "auth.change_password_failed"
+	AuthPasswordChanged          = "auth.password_changed"
+	AuthInvalidateTokenFailed    = "auth.invalidate_token_failed"
+	AuthInvalidateSessionsFailed = "auth.invalidate_sessions_failed"
+	AuthLoggedOut                = "auth.logged_out"
+	AuthOTPCooldown              = "auth.otp_cooldown"
+	AuthOTPRecentlySent          = "auth.otp_recently_sent"
+	AuthSaveOTPFailed            = "auth.save_otp_failed"
+	AuthOTPSendFailed            = "auth.otp_send_failed"
+	AuthOTPSent                  = "auth.otp_sent"
+	AuthInvalidOTP               = "auth.invalid_otp"
+	AuthUpdateOTPFailed          = "auth.update_otp_failed"
+	AuthPhoneNotFound            = "auth.phone_not_found"
+	AuthUpdateUserFailed         = "auth.update_user_failed"
+	AuthUserNotVerified          = "auth.user_not_verified"
+	AuthEmailNotFound            = "auth.email_not_found"
+	AuthSaveResetTokenFailed     = "auth.save_reset_token_failed"
+	AuthResetInstructionsSent    = "auth.reset_instructions_sent"
+	AuthInvalidResetToken        = "auth.invalid_reset_token"
+	AuthUpdatePasswordFailed     = "auth.update_password_failed"
+	AuthPasswordReset            = "auth.password_reset"
+	AuthVerifyTokenRequired      = "auth.verify_token_required"
+	AuthInvalidVerifyToken       = "auth.invalid_verify_token"
+	AuthVerifyTokenExpired       = "auth.verify_token_expired"
+	AuthEmailAlreadyVerified     = "auth.email_already_verified"
+	AuthUpdateEmailStatusFailed  = "auth.update_email_status_failed"
+	AuthEmailVerified            = "auth.email_verified"
+	AuthUpdateVerifyTokenFailed  = "auth.update_verify_token_failed"
+	AuthVerificationEmailFailed  = "auth.verification_email_failed"
+	AuthVerificationResent       = "auth.verification_resent"
+	AuthInvalidUserID            = "auth.invalid_user_id"
+	AuthImpersonateSelf          = "auth.impersonate_self"
+	AuthImpersonateAdmin         = "auth.impersonate_admin"
+	AuthImpersonationFailed      = "auth.impersonation_failed"
+	AuthUserIDsRequired          = "auth.user_ids_required"
+	AuthTooManyUserIDs           = "auth.too_many_user_ids"
+	AuthRetrieveUsersFailed      = "auth.retrieve_users_failed"
+	AuthSearchQueryTooShort      = "auth.search_query_too_short"
+	AuthUnknownSports            = "auth.unknown_sports"
+	AuthSportLookupFailed        = "auth.sport_lookup_failed"
+
+	// Bookings
+	BookingEndBeforeStart        = "booking.end_before_start"
+	BookingInPast                = "booking.in_past"
+	BookingGroundNotFound        = "booking.ground_not_found"
+	BookingUnauthorized          = "booking.unauthorized"
+	BookingAvailabilityFailed    = "booking.availability_failed"
+	BookingNoMatchingSlot        = "booking.no_matching_slot"
+	BookingSlotTaken             = "booking.slot_taken"
+	BookingVenueClosed           = "booking.venue_closed"
+	BookingWarnShortNotice       = "booking.warn_short_notice"
+	BookingWarnOverlap           = "booking.warn_overlap"
+	BookingExistingCheckFailed   = "booking.existing_check_failed"
+	BookingStrictRejected        = "booking.strict_rejected"
+	BookingCreateFailed          = "booking.create_failed"
+	BookingCreated               = "booking.created"
+	BookingFetchFailed           = "booking.fetch_failed"
+	BookingInvalidID             = "booking.invalid_id"
+	BookingNotFound              = "booking.not_found"
+	BookingAccessCheckFailed     = "booking.access_check_failed"
+	BookingNoViewPermission      = "booking.no_view_permission"
+	BookingInvalidExpand         = "booking.invalid_expand"
+	BookingNoCancelPermission    = "booking.no_cancel_permission"
+	BookingAlreadyCancelled      = "booking.already_cancelled"
+	BookingCannotCancelCompleted = "booking.cannot_cancel_completed"
+	BookingCancelTooLate         = "booking.cancel_too_late"
+	BookingCancelFailed          = "booking.cancel_failed"
+	BookingCancelled             = "booking.cancelled"
+	BookingInvalidVenueID        = "booking.invalid_venue_id"
+	BookingVenueNotFound         = "booking.venue_not_found"
+	BookingNoVenueViewPermission = "booking.no_venue_view_permission"
+	BookingInvalidStatus         = "booking.invalid_status"
+	BookingInvalidDate           = "booking.invalid_date"
+	BookingInvalidDateRange      = "booking.invalid_date_range"
+	BookingInvalidCourtID        = "booking.invalid_court_id"
+	BookingInvalidExportFormat   = "booking.invalid_export_format"
+	BookingOwnershipCheckFailed  = "booking.ownership_check_failed"
+	BookingNoUpdatePermission    = "booking.no_update_permission"
+	BookingStatusCancelledLocked = "booking.status_cancelled_locked"
+	BookingStatusCompletedLocked = "booking.status_completed_locked"
+	BookingUseCancelEndpoint     = "booking.use_cancel_endpoint"
+	BookingStatusUpdateFailed    = "booking.status_update_failed"
+	BookingStatusUpdated         = "booking.status_updated"
+)
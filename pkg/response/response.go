@@ -1,61 +1,222 @@
-package responses
+// Package response writes the JSON envelope shared by every API endpoint:
+//
+//	{"success": bool, "message": string, "data": any, "error": {"code", "message", "details"}}
+//
+// Successful responses carry data; failed ones carry error. Paginated lists put their
+// items and pagination metadata under data.
+package response
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"math"
+	"net/http"
+	"strings"
 
+	"github.com/DhavalSuthar-24/miow/pkg/i18n"
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 )
 
+// SuccessResponse is the envelope for successful requests
 type SuccessResponse struct {
-	Status  string      `json:"status"`
-	Message string      `json:"message"`
+	Success bool        `json:"success" example:"true"`
+	Message string      `json:"message,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
 }
 
+// ErrorResponse is the envelope for failed requests
 type ErrorResponse struct {
-	Status  string      `json:"status"`
+	Success bool        `json:"success" example:"false"`
 	Message string      `json:"message"`
-	Errors  interface{} `json:"errors,omitempty"`
+	Error   ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes why a request failed
+type ErrorDetail struct {
+	Code    int         `json:"code"`              // HTTP status code
+	Message string      `json:"message"`           // Same as the top-level message
+	Details interface{} `json:"details,omitempty"` // e.g. per-field validation errors
 }
 
+// PaginatedResponse is the envelope for paginated lists
 type PaginatedResponse struct {
-	Status     string      `json:"status"`
-	Message    string      `json:"message"`
-	Data       interface{} `json:"data"`
-	Total      int64       `json:"total"`
-	Page       int         `json:"page"`
-	PageSize   int         `json:"page_size"`
-	TotalPages int         `json:"total_pages"`
+	Success bool   `json:"success" example:"true"`
+	Message string `json:"message,omitempty"`
+	Data    Page   `json:"data"`
+}
+
+// Page holds one page of items with its pagination details
+type Page struct {
+	Items      interface{} `json:"items"`
+	Pagination Pagination  `json:"pagination"`
+}
+
+// Pagination holds pagination information.
+type Pagination struct {
+	TotalItems   int64 `json:"total_items"`
+	TotalPages   int   `json:"total_pages"`
+	CurrentPage  int   `json:"current_page"`
+	PageSize     int   `json:"page_size"`
+	HasNextPage  bool  `json:"has_next_page"`
+	HasPrevPage  bool  `json:"has_prev_page"`
+	NextPage     *int  `json:"next_page,omitempty"`
+	PreviousPage *int  `json:"previous_page,omitempty"`
 }
 
-func SendSuccess(c *gin.Context, statusCode int, message string, data interface{}) {
+// Success sends a successful response. The message may be a message catalog key.
+func Success(c *gin.Context, statusCode int, message string, data interface{}) {
+	if message != "" {
+		message = i18n.T(c, message)
+	}
 	c.JSON(statusCode, SuccessResponse{
-		Status:  "success",
+		Success: true,
 		Message: message,
 		Data:    data,
 	})
 }
 
-func SendError(c *gin.Context, statusCode int, message string, errors interface{}) {
+// Error aborts the request with an error response. The message may be a message catalog key.
+func Error(c *gin.Context, statusCode int, message string) {
+	ErrorWithDetails(c, statusCode, message, nil)
+}
+
+// ErrorWithDetails aborts the request with an error response carrying extra detail,
+// such as per-field validation errors or the warnings that blocked a strict request.
+func ErrorWithDetails(c *gin.Context, statusCode int, message string, details interface{}) {
+	message = i18n.T(c, message)
 	c.AbortWithStatusJSON(statusCode, ErrorResponse{
-		Status:  "error",
+		Success: false,
 		Message: message,
-		Errors:  errors,
+		Error: ErrorDetail{
+			Code:    statusCode,
+			Message: message,
+			Details: details,
+		},
 	})
 }
 
-func SendPaginated(c *gin.Context, statusCode int, message string, data interface{}, total int64, page, pageSize int) {
-	totalPages := 0
-	if pageSize > 0 {
-		totalPages = int(math.Ceil(float64(total) / float64(pageSize)))
+// Paginated sends one page of items along with its pagination details.
+func Paginated(c *gin.Context, statusCode int, message string, items interface{}, totalItems int64, currentPage, pageSize int) {
+	if message != "" {
+		message = i18n.T(c, message)
 	}
 	c.JSON(statusCode, PaginatedResponse{
-		Status:     "success",
-		Message:    message,
-		Data:       data,
-		Total:      total,
-		Page:       page,
-		PageSize:   pageSize,
-		TotalPages: totalPages,
+		Success: true,
+		Message: message,
+		Data: Page{
+			Items:      items,
+			Pagination: NewPagination(totalItems, currentPage, pageSize),
+		},
 	})
 }
+
+// NewPagination computes the pagination details for a page of a list. A page past the end
+// links back to the last page, and an empty list has no next or previous page.
+func NewPagination(totalItems int64, currentPage, pageSize int) Pagination {
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	if currentPage < 1 {
+		currentPage = 1
+	}
+	if totalItems < 0 {
+		totalItems = 0
+	}
+	totalPages := int(math.Ceil(float64(totalItems) / float64(pageSize)))
+
+	p := Pagination{
+		TotalItems:  totalItems,
+		TotalPages:  totalPages,
+		CurrentPage: currentPage,
+		PageSize:    pageSize,
+		HasNextPage: currentPage < totalPages,
+		HasPrevPage: currentPage > 1 && totalPages > 0,
+	}
+	if p.HasNextPage {
+		next := currentPage + 1
+		p.NextPage = &next
+	}
+	if p.HasPrevPage {
+		prev := currentPage - 1
+		if prev > totalPages {
+			prev = totalPages
+		}
+		p.PreviousPage = &prev
+	}
+	return p
+}
+
+// FieldError describes one field that failed validation. Tag is the failing binding
+// rule (e.g. "required", "min", "oneof") and Param its argument, so clients can
+// render their own localized message.
+type FieldError struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidationError aborts the request with the per-field errors from a failed
+// ShouldBindJSON/ShouldBindQuery. Malformed payloads are reported the same way when the
+// offending field is known, and as a plain invalid input error otherwise.
+func ValidationError(c *gin.Context, err error) {
+	var ve validator.ValidationErrors
+	if errors.As(err, &ve) {
+		fields := make([]FieldError, 0, len(ve))
+		for _, fe := range ve {
+			fields = append(fields, FieldError{
+				Field:   fe.Field(),
+				Tag:     fe.Tag(),
+				Param:   fe.Param(),
+				Message: validationMessage(fe),
+			})
+		}
+		ErrorWithDetails(c, http.StatusBadRequest, i18n.CommonValidationFailed, fields)
+		return
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		ErrorWithDetails(c, http.StatusBadRequest, i18n.CommonValidationFailed, []FieldError{{
+			Field:   typeErr.Field,
+			Tag:     "type",
+			Param:   typeErr.Type.String(),
+			Message: fmt.Sprintf("The %s field must be of type %s.", typeErr.Field, typeErr.Type.String()),
+		}})
+		return
+	}
+
+	Error(c, http.StatusBadRequest, i18n.T(c, i18n.CommonInvalidInput, err.Error()))
+}
+
+// validationMessage builds an English description of a failed validation rule
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("The %s field is required.", fe.Field())
+	case "min":
+		return fmt.Sprintf("The %s field must be at least %s.", fe.Field(), fe.Param())
+	case "max":
+		return fmt.Sprintf("The %s field must not exceed %s.", fe.Field(), fe.Param())
+	case "len":
+		return fmt.Sprintf("The %s field must have length %s.", fe.Field(), fe.Param())
+	case "oneof":
+		return fmt.Sprintf("The %s field must be one of the following: %s.", fe.Field(), strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "email":
+		return fmt.Sprintf("The %s field must be a valid email address.", fe.Field())
+	case "url":
+		return fmt.Sprintf("The %s field must be a valid URL.", fe.Field())
+	case "json_object":
+		return fmt.Sprintf("The %s field must be a JSON object.", fe.Field())
+	case "json_string_map":
+		return fmt.Sprintf("The %s field must be a JSON object of strings.", fe.Field())
+	case "json_string_array":
+		return fmt.Sprintf("The %s field must be a JSON array of strings.", fe.Field())
+	case "gt", "gte", "lt", "lte":
+		return fmt.Sprintf("The %s field must satisfy %s=%s.", fe.Field(), fe.Tag(), fe.Param())
+	default:
+		return fmt.Sprintf("Field validation for '%s' failed on the '%s' tag.", fe.Field(), fe.Tag())
+	}
+}
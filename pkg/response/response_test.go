@@ -0,0 +1,171 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// record runs write against a fresh context and decodes the JSON it produced
+func record(t *testing.T, write func(c *gin.Context)) (int, map[string]interface{}) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	write(c)
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response is not a JSON object: %v\n%s", err, w.Body.String())
+	}
+	return w.Code, body
+}
+
+func TestEnvelopeShapes(t *testing.T) {
+	tests := []struct {
+		name   string
+		write  func(c *gin.Context)
+		status int
+		want   string
+	}{
+		{
+			name:   "success",
+			write:  func(c *gin.Context) { Success(c, http.StatusCreated, "Created", gin.H{"id": 1}) },
+			status: http.StatusCreated,
+			want:   `{"success":true,"message":"Created","data":{"id":1}}`,
+		},
+		{
+			name:   "success without data",
+			write:  func(c *gin.Context) { Success(c, http.StatusOK, "Done", nil) },
+			status: http.StatusOK,
+			want:   `{"success":true,"message":"Done"}`,
+		},
+		{
+			name:   "error",
+			write:  func(c *gin.Context) { Error(c, http.StatusNotFound, "Team not found") },
+			status: http.StatusNotFound,
+			want:   `{"success":false,"message":"Team not found","error":{"code":404,"message":"Team not found"}}`,
+		},
+		{
+			name: "error with details",
+			write: func(c *gin.Context) {
+				ErrorWithDetails(c, http.StatusConflict, "Schedule conflict", []string{"match 3"})
+			},
+			status: http.StatusConflict,
+			want:   `{"success":false,"message":"Schedule conflict","error":{"code":409,"message":"Schedule conflict","details":["match 3"]}}`,
+		},
+		{
+			name:   "paginated",
+			write:  func(c *gin.Context) { Paginated(c, http.StatusOK, "", []int{4, 5}, 5, 2, 3) },
+			status: http.StatusOK,
+			want: `{"success":true,"data":{"items":[4,5],"pagination":{"total_items":5,"total_pages":2,` +
+				`"current_page":2,"page_size":3,"has_next_page":false,"has_prev_page":true,"previous_page":1}}}`,
+		},
+		{
+			name:   "paginated empty",
+			write:  func(c *gin.Context) { Paginated(c, http.StatusOK, "", []int{}, 0, 1, 0) },
+			status: http.StatusOK,
+			want: `{"success":true,"data":{"items":[],"pagination":{"total_items":0,"total_pages":0,` +
+				`"current_page":1,"page_size":10,"has_next_page":false,"has_prev_page":false}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, got := record(t, tt.write)
+			if status != tt.status {
+				t.Errorf("status = %d, want %d", status, tt.status)
+			}
+			var want map[string]interface{}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("body = %v\nwant   %v", got, want)
+			}
+		})
+	}
+}
+
+func TestValidationErrorListsFields(t *testing.T) {
+	type payload struct {
+		Name  string `json:"name" binding:"required"`
+		Level string `json:"level" binding:"omitempty,oneof=low high"`
+	}
+
+	status, body := record(t, func(c *gin.Context) {
+		c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"level":"medium"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		var p payload
+		ValidationError(c, c.ShouldBindJSON(&p))
+	})
+	if status != http.StatusBadRequest || body["success"] != false {
+		t.Fatalf("status = %d, body = %v; want a 400 error envelope", status, body)
+	}
+	errBody, _ := body["error"].(map[string]interface{})
+	details, _ := errBody["details"].([]interface{})
+	if len(details) != 2 {
+		t.Fatalf("details = %v, want one entry per failing field", errBody["details"])
+	}
+	tags := map[string]bool{}
+	for _, d := range details {
+		field, _ := d.(map[string]interface{})
+		tags[field["tag"].(string)] = true
+		if field["message"] == "" {
+			t.Errorf("field error %v has no message", field)
+		}
+	}
+	if !tags["required"] || !tags["oneof"] {
+		t.Errorf("details = %v, want required and oneof failures", details)
+	}
+}
+
+func TestValidationErrorWithoutFieldIsInvalidInput(t *testing.T) {
+	status, body := record(t, func(c *gin.Context) {
+		ValidationError(c, errors.New("unexpected EOF"))
+	})
+	errBody, _ := body["error"].(map[string]interface{})
+	if status != http.StatusBadRequest || body["success"] != false || errBody["details"] != nil {
+		t.Errorf("status = %d, body = %v; want a 400 error envelope without details", status, body)
+	}
+}
+
+func TestNewPagination(t *testing.T) {
+	one, two, three, four := 1, 2, 3, 4
+	tests := []struct {
+		name       string
+		total      int64
+		page, size int
+		want       Pagination
+	}{
+		{"empty list", 0, 1, 10, Pagination{TotalPages: 0, CurrentPage: 1, PageSize: 10}},
+		{"first page", 25, 1, 10, Pagination{TotalItems: 25, TotalPages: 3, CurrentPage: 1, PageSize: 10, HasNextPage: true, NextPage: &two}},
+		{"defaults", 5, 0, 0, Pagination{TotalItems: 5, TotalPages: 1, CurrentPage: 1, PageSize: 10}},
+		{"middle page", 25, 3, 5, Pagination{TotalItems: 25, TotalPages: 5, CurrentPage: 3, PageSize: 5, HasNextPage: true, HasPrevPage: true, NextPage: &four, PreviousPage: &two}},
+		{"last page", 25, 3, 10, Pagination{TotalItems: 25, TotalPages: 3, CurrentPage: 3, PageSize: 10, HasPrevPage: true, PreviousPage: &two}},
+		{"last page exactly full", 30, 3, 10, Pagination{TotalItems: 30, TotalPages: 3, CurrentPage: 3, PageSize: 10, HasPrevPage: true, PreviousPage: &two}},
+		{"one item per page", 4, 3, 1, Pagination{TotalItems: 4, TotalPages: 4, CurrentPage: 3, PageSize: 1, HasNextPage: true, HasPrevPage: true, NextPage: &four, PreviousPage: &two}},
+		{"past the end", 5, 4, 5, Pagination{TotalItems: 5, TotalPages: 1, CurrentPage: 4, PageSize: 5, HasPrevPage: true, PreviousPage: &one}},
+		{"far past the end", 25, 9, 10, Pagination{TotalItems: 25, TotalPages: 3, CurrentPage: 9, PageSize: 10, HasPrevPage: true, PreviousPage: &three}},
+		{"past the end of an empty list", 0, 3, 10, Pagination{CurrentPage: 3, PageSize: 10}},
+		{"zero limit", 25, 2, 0, Pagination{TotalItems: 25, TotalPages: 3, CurrentPage: 2, PageSize: 10, HasNextPage: true, HasPrevPage: true, NextPage: &three, PreviousPage: &one}},
+		{"negative limit and page", 25, -2, -5, Pagination{TotalItems: 25, TotalPages: 3, CurrentPage: 1, PageSize: 10, HasNextPage: true, NextPage: &two}},
+		{"negative total", -3, 1, 10, Pagination{CurrentPage: 1, PageSize: 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewPagination(tt.total, tt.page, tt.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewPagination(%d, %d, %d) = %+v, want %+v", tt.total, tt.page, tt.size, got, tt.want)
+			}
+		})
+	}
+}
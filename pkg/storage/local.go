@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/DhavalSuthar-24/miow/pkg/utils"
+)
+
+// LocalStorage saves files under a directory that the server serves at baseURL
+type LocalStorage struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalStorage creates a LocalStorage rooted at dir, e.g. "./public/uploads" served at
+// "/public/uploads"
+func NewLocalStorage(dir, baseURL string) *LocalStorage {
+	return &LocalStorage{dir: dir, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// path resolves a key inside the storage directory, refusing keys that would escape it
+func (s *LocalStorage) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if clean == "/" {
+		return "", errors.New("storage: empty key")
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(clean)), nil
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, data []byte, contentType string) (string, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := utils.EnsureDir(filepath.Dir(path)); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", err
+	}
+	return s.baseURL + "/" + strings.TrimLeft(filepath.ToSlash(filepath.Clean("/"+key)), "/"), nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
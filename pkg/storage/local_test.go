@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalStoragePutAndDelete(t *testing.T) {
+	dir := t.TempDir()
+	s := NewLocalStorage(dir, "/public/uploads/")
+	ctx := context.Background()
+
+	url, err := s.Put(ctx, "venues/3/1700000000.png", []byte("image"), "image/png")
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if url != "/public/uploads/venues/3/1700000000.png" {
+		t.Errorf("Put() URL = %q", url)
+	}
+	path := filepath.Join(dir, "venues", "3", "1700000000.png")
+	if data, err := os.ReadFile(path); err != nil || string(data) != "image" {
+		t.Fatalf("stored file = %q, %v; want the uploaded data", data, err)
+	}
+
+	if err := s.Delete(ctx, "venues/3/1700000000.png"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("file still exists after Delete(): %v", err)
+	}
+	// Deleting a missing file is not an error, so cleanup after a failed update can always run
+	if err := s.Delete(ctx, "venues/3/1700000000.png"); err != nil {
+		t.Errorf("second Delete() error = %v", err)
+	}
+}
+
+func TestLocalStorageKeepsKeysInsideDir(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "uploads")
+	s := NewLocalStorage(dir, "/uploads")
+
+	url, err := s.Put(context.Background(), "../../escape.png", []byte("image"), "image/png")
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if url != "/uploads/escape.png" {
+		t.Errorf("Put() URL = %q, want the key cleaned", url)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escape.png")); err != nil {
+		t.Errorf("file not stored inside the directory: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "escape.png")); !os.IsNotExist(err) {
+		t.Errorf("file written outside the directory")
+	}
+
+	if _, err := s.Put(context.Background(), "/", []byte("image"), "image/png"); err == nil {
+		t.Error("Put() with an empty key succeeded")
+	}
+}
@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3Service       = "s3"
+	s3Algorithm     = "AWS4-HMAC-SHA256"
+	s3AmzDateFormat = "20060102T150405Z"
+	s3RequestTTL    = 30 * time.Second
+)
+
+// S3Storage saves files to a bucket of an S3 compatible service (AWS S3, MinIO, R2, ...) using
+// path-style requests signed with Signature Version 4. Objects must be readable at publicURL,
+// e.g. through a bucket policy or a CDN in front of the bucket.
+type S3Storage struct {
+	endpoint  string
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	publicURL string
+	client    *http.Client
+}
+
+// NewS3Storage creates an S3Storage. An empty endpoint means AWS S3 in the region, and an
+// empty publicURL serves objects straight from the endpoint.
+func NewS3Storage(endpoint, region, bucket, accessKey, secretKey, publicURL string) *S3Storage {
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	}
+	endpoint = strings.TrimRight(endpoint, "/")
+	if publicURL == "" {
+		publicURL = endpoint + "/" + bucket
+	}
+	return &S3Storage{
+		endpoint:  endpoint,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		publicURL: strings.TrimRight(publicURL, "/"),
+		client:    &http.Client{Timeout: s3RequestTTL},
+	}
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, data []byte, contentType string) (string, error) {
+	key = strings.TrimLeft(key, "/")
+	headers := map[string]string{"content-type": contentType}
+	if err := s.do(ctx, http.MethodPut, key, data, headers); err != nil {
+		return "", err
+	}
+	return s.publicURL + "/" + escapeS3Path(key), nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	return s.do(ctx, http.MethodDelete, strings.TrimLeft(key, "/"), nil, nil)
+}
+
+// do sends a signed request for an object and treats any non-2xx status as an error
+func (s *S3Storage) do(ctx context.Context, method, key string, body []byte, headers map[string]string) error {
+	path := "/" + escapeS3Path(s.bucket+"/"+key)
+	req, err := http.NewRequestWithContext(ctx, method, s.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = int64(len(body))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	s.sign(req, path, body, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("storage: %s %s failed with status %d: %s", method, key, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// sign adds the Signature Version 4 authorization headers to req. Host and every header set on
+// req are signed.
+func (s *S3Storage) sign(req *http.Request, path string, body []byte, now time.Time) {
+	amzDate := now.Format(s3AmzDateFormat)
+	date := amzDate[:8]
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	values := map[string]string{"host": req.URL.Host}
+	for name, v := range req.Header {
+		values[strings.ToLower(name)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	signed := make([]string, 0, len(values))
+	for name := range values {
+		signed = append(signed, name)
+	}
+	sort.Strings(signed)
+
+	var canonicalHeaders strings.Builder
+	for _, h := range signed {
+		canonicalHeaders.WriteString(h + ":" + values[h] + "\n")
+	}
+	signedHeaders := strings.Join(signed, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		"", // No query string
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/" + s3Service + "/aws4_request"
+	stringToSign := strings.Join([]string{s3Algorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKey, scope, signedHeaders, signature))
+}
+
+// escapeS3Path percent-encodes an object path as Signature Version 4 requires: everything but
+// unreserved characters and the slashes between segments
+func escapeS3Path(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if c == '/' || c == '-' || c == '_' || c == '.' || c == '~' ||
+			('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,118 @@
+// Package storage saves uploaded files, such as venue images and team logos, and returns the
+// URL they are served from. Files go to the local upload directory or to an S3 compatible
+// bucket, depending on STORAGE_BACKEND.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DhavalSuthar-24/miow/config"
+)
+
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+var (
+	// ErrUnsupportedImageType is returned for uploads that are not JPEG, PNG, GIF or WebP images
+	ErrUnsupportedImageType = errors.New("unsupported image type; use JPEG, PNG, GIF or WebP")
+	// ErrFileTooLarge is returned for uploads over the configured size limit
+	ErrFileTooLarge = errors.New("file is too large")
+)
+
+// imageExtensions maps the accepted image content types to the extension stored files get
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// Storage stores files by key, e.g. "venues/3/1700000000.jpg"
+type Storage interface {
+	// Put stores the file and returns the URL it can be fetched from
+	Put(ctx context.Context, key string, data []byte, contentType string) (string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Image is a validated image upload
+type Image struct {
+	Data        []byte
+	ContentType string
+	Extension   string
+}
+
+// ReadImage reads an uploaded file, checking its size and that its content, not just its
+// declared type, is a supported image
+func ReadImage(file *multipart.FileHeader, maxBytes int64) (*Image, error) {
+	if file.Size > maxBytes {
+		return nil, fmt.Errorf("%w: the limit is %d bytes", ErrFileTooLarge, maxBytes)
+	}
+	f, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// Read one byte past the limit so a wrong declared size cannot get a bigger file through
+	data, err := io.ReadAll(io.LimitReader(f, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("%w: the limit is %d bytes", ErrFileTooLarge, maxBytes)
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		return nil, ErrUnsupportedImageType
+	}
+	return &Image{Data: data, ContentType: contentType, Extension: ext}, nil
+}
+
+// ImageKey builds a unique key for an image under a prefix, e.g. ImageKey("teams/7/logo", img)
+func ImageKey(prefix string, img *Image) string {
+	return fmt.Sprintf("%s/%d%s", strings.Trim(prefix, "/"), time.Now().UnixNano(), img.Extension)
+}
+
+// MaxImageBytes returns the configured upload limit for images
+func MaxImageBytes(appConfig *config.Config) int64 {
+	if appConfig == nil || appConfig.Storage.MaxImageSizeMB < 1 {
+		return 5 << 20
+	}
+	return int64(appConfig.Storage.MaxImageSizeMB) << 20
+}
+
+var (
+	sharedStorage Storage
+	sharedOnce    sync.Once
+)
+
+// Shared returns the process-wide storage, built from the configuration on first use
+func Shared(appConfig *config.Config) Storage {
+	sharedOnce.Do(func() {
+		if appConfig != nil && appConfig.Storage.Backend == BackendS3 {
+			s := appConfig.Storage
+			sharedStorage = NewS3Storage(s.S3Endpoint, s.S3Region, s.S3Bucket, s.S3AccessKeyID, s.S3SecretAccessKey, s.S3PublicURL)
+			return
+		}
+		dir, baseURL := "./public/uploads", "/public/uploads"
+		if appConfig != nil {
+			dir, baseURL = appConfig.App.UploadDir, appConfig.Storage.LocalBaseURL
+		}
+		log.Printf("Storage: saving uploads to %s", dir)
+		sharedStorage = NewLocalStorage(dir, baseURL)
+	})
+	return sharedStorage
+}
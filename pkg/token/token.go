@@ -32,6 +32,22 @@ func GenerateRefreshToken(userID uint, secret string, expiryDays int) (string, e
 	return token.SignedString([]byte(secret))
 }
 
+// GenerateImpersonationJWT issues a short-lived access token for userID on behalf of the admin
+// adminID. The token carries an "impersonated_by" claim and is never paired with a refresh token.
+func GenerateImpersonationJWT(userID, adminID uint, secret string, expiryMinutes int) (string, error) {
+
+	claims := jwt.MapClaims{
+		"user_id":         userID,
+		"impersonated_by": adminID,
+		"exp":             time.Now().Add(time.Minute * time.Duration(expiryMinutes)).Unix(),
+		"iat":             time.Now().Unix(),
+		"type":            "impersonation",
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secret))
+}
+
 func ValidateToken(tokenString string, secret string) (*jwt.Token, error) {
 	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -54,3 +70,19 @@ func ExtractUserID(token *jwt.Token) (uint, error) {
 
 	return uint(userID), nil
 }
+
+// ExtractImpersonatorID returns the admin ID from the "impersonated_by" claim of an
+// impersonation token. ok is false for regular tokens.
+func ExtractImpersonatorID(token *jwt.Token) (uint, bool) {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, false
+	}
+
+	adminID, ok := claims["impersonated_by"].(float64)
+	if !ok {
+		return 0, false
+	}
+
+	return uint(adminID), true
+}
@@ -2,8 +2,16 @@ package utils
 
 import "golang.org/x/crypto/bcrypt"
 
+// DefaultPasswordCost is the bcrypt cost used when none is configured
+const DefaultPasswordCost = 14
+
 func HashPassword(p string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(p), 14)
+	return HashPasswordWithCost(p, DefaultPasswordCost)
+}
+
+// HashPasswordWithCost hashes p with the given bcrypt cost
+func HashPasswordWithCost(p string, cost int) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(p), cost)
 	return string(bytes), err
 }
 
@@ -11,3 +19,13 @@ func CheckPassword(hash, pass string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(pass))
 	return err == nil
 }
+
+// PasswordNeedsRehash reports whether hash was generated with a lower cost than cost.
+// Unreadable hashes are left alone; CheckPassword rejects them anyway.
+func PasswordNeedsRehash(hash string, cost int) bool {
+	current, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return false
+	}
+	return current < cost
+}
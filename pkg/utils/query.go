@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ParseOptionalID parses an optional positive ID query value; empty yields 0
+func ParseOptionalID(raw string) (uint, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("invalid id %q", raw)
+	}
+	return uint(id), nil
+}
+
+// ParseOptionalTime parses an optional RFC 3339 timestamp or YYYY-MM-DD date (UTC midnight);
+// empty yields the zero time
+func ParseOptionalTime(raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", raw)
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Warnings collects non-blocking validation messages for create endpoints.
+// They are returned alongside the created resource unless the client asks
+// for strict mode, in which case they are treated as errors.
+type Warnings []string
+
+// Add appends a formatted warning message
+func (w *Warnings) Add(format string, args ...interface{}) {
+	*w = append(*w, fmt.Sprintf(format, args...))
+}
+
+// StrictMode reports whether the request asked for warnings to be treated as errors (?strict=true)
+func StrictMode(ctx *gin.Context) bool {
+	strict, _ := strconv.ParseBool(ctx.Query("strict"))
+	return strict
+}
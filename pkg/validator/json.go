@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"encoding/json"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// RegisterJSONStringRules adds binding tags for string fields that carry JSON:
+//
+//	json_object        a JSON object, e.g. {"min_age": 16}
+//	json_string_map    a JSON object whose values are all strings
+//	json_string_array  a JSON array of strings, e.g. ["passing", "defence"]
+//
+// Empty strings pass; combine with required to forbid them.
+func RegisterJSONStringRules() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	_ = v.RegisterValidation("json_object", func(fl validator.FieldLevel) bool {
+		var obj map[string]interface{}
+		return validJSONField(fl, &obj) && (fl.Field().String() == "" || obj != nil)
+	})
+	_ = v.RegisterValidation("json_string_map", func(fl validator.FieldLevel) bool {
+		var obj map[string]string
+		return validJSONField(fl, &obj) && (fl.Field().String() == "" || obj != nil)
+	})
+	_ = v.RegisterValidation("json_string_array", func(fl validator.FieldLevel) bool {
+		var arr []string
+		return validJSONField(fl, &arr) && (fl.Field().String() == "" || arr != nil)
+	})
+}
+
+// validJSONField reports whether the field is empty or decodes into dest
+func validJSONField(fl validator.FieldLevel, dest interface{}) bool {
+	raw := fl.Field().String()
+	return raw == "" || json.Unmarshal([]byte(raw), dest) == nil
+}
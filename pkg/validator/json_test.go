@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+type jsonFields struct {
+	Object   string  `json:"object" binding:"json_object"`
+	Map      string  `json:"map" binding:"json_string_map"`
+	Array    string  `json:"array" binding:"json_string_array"`
+	Optional *string `json:"optional" binding:"omitempty,json_object"`
+}
+
+// failedTag validates s and returns the tag of its only failure, or "" if it is valid
+func failedTag(t *testing.T, s jsonFields) string {
+	t.Helper()
+	err := binding.Validator.ValidateStruct(s)
+	if err == nil {
+		return ""
+	}
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("ValidateStruct() error = %v, want one field error", err)
+	}
+	return errs[0].Tag()
+}
+
+func TestJSONStringRules(t *testing.T) {
+	RegisterJSONStringRules()
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		name   string
+		fields jsonFields
+		want   string
+	}{
+		{"all empty", jsonFields{}, ""},
+		{"valid values", jsonFields{
+			Object: `{"min_age": 16, "kit": {"colour": "red"}}`,
+			Map:    `{"instagram": "https://instagram.com/warriors"}`,
+			Array:  `["passing", "defence"]`,
+		}, ""},
+		{"empty collections", jsonFields{Object: "{}", Map: " {} ", Array: "[]"}, ""},
+
+		{"malformed object", jsonFields{Object: `{"min_age": 16`}, "json_object"},
+		{"array as object", jsonFields{Object: `["a"]`}, "json_object"},
+		{"null as object", jsonFields{Object: "null"}, "json_object"},
+		{"string as object", jsonFields{Object: `"text"`}, "json_object"},
+
+		{"malformed map", jsonFields{Map: `{instagram: "x"}`}, "json_string_map"},
+		{"map with a number", jsonFields{Map: `{"instagram": 3}`}, "json_string_map"},
+		{"map with an object", jsonFields{Map: `{"instagram": {"url": "x"}}`}, "json_string_map"},
+
+		{"malformed array", jsonFields{Array: `["passing",`}, "json_string_array"},
+		{"array of numbers", jsonFields{Array: `[1, 2]`}, "json_string_array"},
+		{"object as array", jsonFields{Array: `{"skill": "passing"}`}, "json_string_array"},
+		{"null as array", jsonFields{Array: "null"}, "json_string_array"},
+
+		{"optional set", jsonFields{Optional: str(`{"a": 1}`)}, ""},
+		{"optional empty", jsonFields{Optional: str("")}, ""},
+		{"optional malformed", jsonFields{Optional: str(`{"a"`)}, "json_object"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := failedTag(t, tt.fields); got != tt.want {
+				t.Errorf("failed tag = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,19 +1,31 @@
 package validator
 
 import (
-	"fmt"
+	"reflect"
+	"strings"
 
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
 
-func ParseError(err error) map[string]string {
-	errors := make(map[string]string)
-	if ve, ok := err.(validator.ValidationErrors); ok {
-		for _, fe := range ve {
-			errors[fe.Field()] = fmt.Sprintf("Field validation for '%s' failed on the '%s' tag", fe.Field(), fe.Tag())
-		}
-	} else if err != nil { // Non-validator errors
-		errors["error"] = err.Error()
+// RegisterJSONFieldNames makes validation errors from gin's binding report fields by
+// their JSON (or form) name, e.g. "start_time" instead of "StartTime", so clients can
+// match errors to the payload they sent.
+func RegisterJSONFieldNames() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
 	}
-	return errors
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, tag := range []string{"json", "form"} {
+			name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
 }
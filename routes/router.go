@@ -1,6 +1,7 @@
 package routes
 
 import (
+	"log/slog"
 	"net/http"
 	"os"
 	"time"
@@ -9,29 +10,56 @@ import (
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
-	"gorm.io/gorm"
 
 	"github.com/DhavalSuthar-24/miow/config" // Import the config package
 	"github.com/DhavalSuthar-24/miow/internal/auth"
+	"github.com/DhavalSuthar-24/miow/internal/health"
+	"github.com/DhavalSuthar-24/miow/internal/match"
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/internal/notification"
 	"github.com/DhavalSuthar-24/miow/internal/sport"
 	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/venue"
+	"github.com/DhavalSuthar-24/miow/pkg/validator"
+	"gorm.io/gorm"
 )
 
+// ReadinessChecks probes the tables of every module the API serves. Startup migrates each of
+// them, so a fresh database is ready once migrations have run.
+func ReadinessChecks(db *gorm.DB) []health.Check {
+	return []health.Check{
+		{Name: "auth", Pinger: auth.NewAuthRepository(db)},
+		{Name: "sport", Pinger: sport.NewSportRepository(db)},
+		{Name: "team", Pinger: team.NewTeamRepository(db)},
+		{Name: "venue", Pinger: venue.NewVenueRepository(db)},
+		{Name: "match", Pinger: match.NewGormMatchRepository(db)},
+		{Name: "notification", Pinger: notification.NewNotificationRepository(db)},
+	}
+}
+
 func SetupRoutes() *gin.Engine {
-	r := gin.Default()
+	validator.RegisterJSONFieldNames()
+	validator.RegisterJSONStringRules()
+
+	// Get the loaded configuration and database instance
+	cfg := config.GetConfig()
+	dbInstance := config.DB // Access the global DB instance
+
+	r := gin.New()
+	// Client IPs key the rate limits, so forwarding headers are only believed from known proxies
+	if err := r.SetTrustedProxies(cfg.App.TrustedProxies); err != nil {
+		slog.Error("Invalid trusted proxies", "error", err)
+		os.Exit(1)
+	}
+	r.Use(gin.Recovery(), middleware.RequestLogger(slog.Default()))
 	r.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:8080"}, // Where Swagger UI is hosted
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", middleware.RequestIDHeader},
+		ExposeHeaders:    []string{middleware.RequestIDHeader},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
-	var db *gorm.DB
-	r.Use(func(c *gin.Context) {
-		c.Set("auth_repo", auth.NewAuthRepository(db)) // Assuming NewAuthRepository() initializes an instance
-		c.Next()
-	})
-
 	// Access config for static file serving if needed (e.g., dynamic public path)
 	// cfg := config.GetConfig()
 	// r.Static("/public", cfg.App.PublicDir) // Example if public dir was configurable
@@ -59,17 +87,25 @@ func SetupRoutes() *gin.Engine {
 	// Swagger route
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Liveness and readiness probes, outside /api so they are not rate limited
+	probes := health.NewHandler(dbInstance, ReadinessChecks(dbInstance)...)
+	r.GET("/healthz", probes.Live)
+	r.GET("/readyz", probes.Ready)
+
 	// API routes
 	api := r.Group("/api")
-
-	// Get the loaded configuration and database instance
-	cfg := config.GetConfig()
-	dbInstance := config.DB // Access the global DB instance
+	api.Use(middleware.NewTokenBucketLimiter(cfg).Limit("api", middleware.RateLimit{
+		RequestsPerMinute: cfg.RateLimit.RequestsPerMinute,
+		Burst:             cfg.RateLimit.Burst,
+	}))
 
 	// Pass dbInstance and cfg to RegisterAuthRoutes
 	auth.RegisterAuthRoutes(api, dbInstance, cfg)
 	sport.RegisterSportRoutes(api, dbInstance, cfg, os.Getenv("JWT_ACCESS_TOKEN_SECRET"))
 	team.TeamRoutes(api, dbInstance, cfg, os.Getenv("JWT_ACCESS_TOKEN_SECRET"))
+	notification.NotificationRoutes(api, dbInstance, os.Getenv("JWT_ACCESS_TOKEN_SECRET"))
+	venue.VenueSetupRoutes(api, dbInstance, cfg, os.Getenv("JWT_ACCESS_TOKEN_SECRET"))
+	match.MatchRoutes(api, dbInstance, cfg, team.NewTeamRepository(dbInstance), os.Getenv("JWT_ACCESS_TOKEN_SECRET"))
 
 	return r
 }
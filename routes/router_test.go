@@ -0,0 +1,144 @@
+package routes
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DhavalSuthar-24/miow/config"
+	"github.com/DhavalSuthar-24/miow/internal/auth"
+	"github.com/DhavalSuthar-24/miow/internal/match"
+	"github.com/DhavalSuthar-24/miow/internal/middleware"
+	"github.com/DhavalSuthar-24/miow/internal/notification"
+	"github.com/DhavalSuthar-24/miow/internal/sport"
+	"github.com/DhavalSuthar-24/miow/internal/team"
+	"github.com/DhavalSuthar-24/miow/internal/testutil"
+	"github.com/DhavalSuthar-24/miow/internal/user"
+	"github.com/DhavalSuthar-24/miow/internal/venue"
+	"github.com/DhavalSuthar-24/miow/pkg/token"
+	"github.com/DhavalSuthar-24/miow/pkg/validator"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newAPIRouter registers the API route groups the way SetupRoutes does, against a test
+// database. It returns the router and an access token for a regular user.
+func newAPIRouter(t *testing.T) (*gin.Engine, string) {
+	t.Helper()
+	validator.RegisterJSONFieldNames()
+	validator.RegisterJSONStringRules()
+
+	db := testutil.DB(t,
+		&user.User{}, &user.Role{}, &user.UserRole{}, &user.RefreshToken{},
+		&auth.OTP{}, &auth.InviteCode{}, &auth.ImpersonationLog{},
+		&sport.Sport{}, &sport.UserSport{}, &sport.Skill{}, &sport.SkillEndorsement{},
+		&team.Team{}, &team.TeamMember{}, &team.TeamBlock{}, &team.TeamInvitation{}, &team.JoinRequest{},
+		&venue.Venue{}, &venue.Ground{}, &venue.Booking{}, &venue.TimeSlot{}, &venue.VenueClosure{}, &venue.VenueManager{},
+		&match.Challenge{}, &match.ChallengeCounterOffer{}, &match.Match{}, &match.MatchTeam{}, &match.MatchPeriodScore{},
+		&match.MatchComment{}, &match.MatchOfficial{}, &match.MatchLineup{}, &match.MatchPlayer{},
+		&match.Tournament{}, &match.TournamentTeam{}, &match.AdminAuditLog{},
+		&notification.Notification{},
+	)
+	// The auth routes authenticate against the global connection
+	previousDB := config.DB
+	config.DB = db
+	t.Cleanup(func() { config.DB = previousDB })
+
+	cfg := &config.Config{}
+	cfg.JWT.AccessTokenSecret = "test-access-secret"
+	cfg.JWT.RefreshTokenSecret = "test-refresh-secret"
+
+	r := gin.New()
+	api := r.Group("/api")
+	auth.RegisterAuthRoutes(api, db, cfg)
+	sport.RegisterSportRoutes(api, db, cfg, cfg.JWT.AccessTokenSecret)
+	team.TeamRoutes(api, db, cfg, cfg.JWT.AccessTokenSecret)
+	notification.NotificationRoutes(api, db, cfg.JWT.AccessTokenSecret)
+	venue.VenueSetupRoutes(api, db, cfg, cfg.JWT.AccessTokenSecret)
+	match.MatchRoutes(api, db, cfg, team.NewTeamRepository(db), cfg.JWT.AccessTokenSecret)
+
+	player := testutil.CreateUser(t, db, "Player")
+	middleware.InvalidateUserRoles(player.ID)
+	accessToken, err := token.GenerateJWT(player.ID, cfg.JWT.AccessTokenSecret, 5)
+	if err != nil {
+		t.Fatalf("GenerateJWT() error = %v", err)
+	}
+	return r, accessToken
+}
+
+// envelopeKeys are the only top-level fields an API response may carry
+var envelopeKeys = map[string]bool{"success": true, "message": true, "data": true, "error": true}
+
+func TestEveryEndpointUsesTheResponseEnvelope(t *testing.T) {
+	r, accessToken := newAPIRouter(t)
+
+	for _, route := range r.Routes() {
+		path := route.Path
+		for _, segment := range strings.Split(route.Path, "/") {
+			if strings.HasPrefix(segment, ":") {
+				path = strings.Replace(path, segment, "1", 1)
+			}
+		}
+		for _, bearer := range []string{"", accessToken} {
+			name := route.Method + " " + route.Path
+			if bearer != "" {
+				name += " as user"
+			}
+			t.Run(name, func(t *testing.T) {
+				assertEnvelope(t, r, route.Method, path, bearer)
+			})
+		}
+	}
+}
+
+// assertEnvelope sends an empty request and checks the response is a well-formed envelope
+func assertEnvelope(t *testing.T, r *gin.Engine, method, path, bearer string) {
+	t.Helper()
+	var body io.Reader
+	if method == http.MethodPost || method == http.MethodPut {
+		body = strings.NewReader("{}")
+	}
+	req := httptest.NewRequest(method, path, body)
+	req.Header.Set("Content-Type", "application/json")
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		// Redirects such as the email verification link carry no envelope
+		if w.Code >= 300 && w.Code < 400 {
+			return
+		}
+		t.Fatalf("status %d with content type %q, want a JSON envelope", w.Code, w.Header().Get("Content-Type"))
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(w.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("response is not a JSON object: %v\n%s", err, w.Body.String())
+	}
+	for key := range raw {
+		if !envelopeKeys[key] {
+			t.Errorf("unexpected top-level field %q in %s", key, w.Body.String())
+		}
+	}
+
+	env := testutil.DecodeEnvelope(t, w)
+	if _, ok := raw["success"]; !ok {
+		t.Fatalf("response has no success field: %s", w.Body.String())
+	}
+	if w.Code >= http.StatusBadRequest {
+		if env.Success || env.Message == "" || env.Error.Code != w.Code || env.Error.Message != env.Message {
+			t.Errorf("status %d with error envelope %s", w.Code, w.Body.String())
+		}
+	} else if !env.Success {
+		t.Errorf("status %d reported success=false: %s", w.Code, w.Body.String())
+	}
+}